@@ -6,11 +6,41 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
 	// ErrAlertWithInvalidDescription is the error with which Gatus will panic if an alert has an invalid character
 	ErrAlertWithInvalidDescription = errors.New("alert description must not have \" or \\")
+
+	// ErrAlertWithInvalidTriggerIf is the error with which Gatus will panic if an alert has an invalid trigger-if expression
+	ErrAlertWithInvalidTriggerIf = errors.New("alert trigger-if must be in the format 'last-success-older-than <duration>' or 'slo-burn-rate-above <burn-rate> [over <duration>]', e.g. 'last-success-older-than 15m' or 'slo-burn-rate-above 14.4 over 1h'")
+
+	// ErrAlertWithInvalidSeverity is the error with which Gatus will panic if an alert has an unknown severity
+	ErrAlertWithInvalidSeverity = errors.New("alert severity must be one of: critical, warning, info")
+)
+
+const (
+	// TriggerIfLastSuccessOlderThan is the prefix of the trigger-if expression used to trigger an alert once the
+	// last successful evaluation of the endpoint is older than a given duration.
+	TriggerIfLastSuccessOlderThan = "last-success-older-than"
+
+	// TriggerIfSLOBurnRateAbove is the prefix of the trigger-if expression used to trigger an alert once the error
+	// budget of the service level objective of the endpoint is consumed faster than a given burn rate.
+	TriggerIfSLOBurnRateAbove = "slo-burn-rate-above"
+
+	// DefaultSLOBurnRateLookback is the duration over which the burn rate is measured if the trigger-if expression
+	// using TriggerIfSLOBurnRateAbove doesn't specify one
+	DefaultSLOBurnRateLookback = time.Hour
+)
+
+// Severity of an alert
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
 )
 
 // Alert is a endpoint.Endpoint's alert configuration
@@ -32,12 +62,29 @@ type Alert struct {
 	// SuccessThreshold defines how many successful executions must happen in a row before an ongoing incident is marked as resolved
 	SuccessThreshold int `yaml:"success-threshold"`
 
+	// TriggerIf is an optional expression that, if set, is used instead of FailureThreshold to determine whether the
+	// alert should be triggered.
+	//
+	// The expressions supported are:
+	//   - "last-success-older-than <duration>", which triggers the alert once the endpoint has been failing for longer
+	//     than the specified duration. This is better suited than FailureThreshold for endpoints checked at irregular
+	//     intervals, or on a cron schedule.
+	//   - "slo-burn-rate-above <burn-rate> [over <duration>]", which triggers the alert once the error budget of the
+	//     service level objective of the endpoint has been consumed faster than the specified burn rate over the
+	//     specified duration (1h by default), and resolves it once it no longer is, regardless of SuccessThreshold.
+	TriggerIf string `yaml:"trigger-if,omitempty"`
+
 	// Description of the alert. Will be included in the alert sent.
 	//
 	// This is a pointer, because it is populated by YAML and we need to know whether it was explicitly set to a value
 	// or not for provider.ParseWithDefaultAlert to work.
 	Description *string `yaml:"description"`
 
+	// Severity of the alert. Defaults to SeverityCritical.
+	//
+	// Providers may use it to decide how to deliver the alert, e.g. Teams can batch non-critical alerts into a digest.
+	Severity Severity `yaml:"severity,omitempty"`
+
 	// SendOnResolved defines whether to send a second notification when the issue has been resolved
 	//
 	// This is a pointer, because it is populated by YAML and we need to know whether it was explicitly set to a value
@@ -57,6 +104,15 @@ type Alert struct {
 	// some reason, the alert provider always returns errors when trying to send the resolved notification
 	// (SendOnResolved).
 	Triggered bool `yaml:"-"`
+
+	// lastSuccessOlderThan is the duration parsed from TriggerIf. If 0, FailureThreshold is used instead.
+	lastSuccessOlderThan time.Duration
+
+	// sloBurnRateAbove is the burn rate parsed from TriggerIf. If 0, the alert isn't triggered by the burn rate.
+	sloBurnRateAbove float64
+
+	// sloBurnRateLookback is the duration over which the burn rate is measured, parsed from TriggerIf
+	sloBurnRateLookback time.Duration
 }
 
 // ValidateAndSetDefaults validates the alert's configuration and sets the default value of fields that have one
@@ -70,9 +126,86 @@ func (alert *Alert) ValidateAndSetDefaults() error {
 	if strings.ContainsAny(alert.GetDescription(), "\"\\") {
 		return ErrAlertWithInvalidDescription
 	}
+	switch alert.Severity {
+	case "":
+		alert.Severity = SeverityCritical
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	default:
+		return ErrAlertWithInvalidSeverity
+	}
+	if len(alert.TriggerIf) > 0 {
+		expression := strings.Fields(alert.TriggerIf)
+		if len(expression) > 0 && expression[0] == TriggerIfSLOBurnRateAbove {
+			return alert.parseSLOBurnRateTriggerIf(expression)
+		}
+		if len(expression) != 2 || expression[0] != TriggerIfLastSuccessOlderThan {
+			return ErrAlertWithInvalidTriggerIf
+		}
+		duration, err := time.ParseDuration(expression[1])
+		if err != nil || duration <= 0 {
+			return ErrAlertWithInvalidTriggerIf
+		}
+		alert.lastSuccessOlderThan = duration
+	}
+	return nil
+}
+
+// parseSLOBurnRateTriggerIf parses the fields of a trigger-if expression using TriggerIfSLOBurnRateAbove.
+// Because uptime data is aggregated by hour, the duration over which the burn rate is measured must be at least 1h.
+func (alert *Alert) parseSLOBurnRateTriggerIf(expression []string) error {
+	if len(expression) != 2 && (len(expression) != 4 || expression[2] != "over") {
+		return ErrAlertWithInvalidTriggerIf
+	}
+	burnRate, err := strconv.ParseFloat(expression[1], 64)
+	if err != nil || burnRate <= 0 {
+		return ErrAlertWithInvalidTriggerIf
+	}
+	lookback := DefaultSLOBurnRateLookback
+	if len(expression) == 4 {
+		if lookback, err = time.ParseDuration(expression[3]); err != nil || lookback < time.Hour {
+			return ErrAlertWithInvalidTriggerIf
+		}
+	}
+	alert.sloBurnRateAbove, alert.sloBurnRateLookback = burnRate, lookback
 	return nil
 }
 
+// ShouldBeTriggered returns whether the alert should be triggered based on the number of failures in a row
+// or, if TriggerIf is set, on the time elapsed since the last successful evaluation.
+func (alert *Alert) ShouldBeTriggered(numberOfFailuresInARow int, timeSinceLastSuccess time.Duration) bool {
+	return alert.ShouldBeTriggeredWithFailureThreshold(numberOfFailuresInARow, timeSinceLastSuccess, alert.FailureThreshold)
+}
+
+// ShouldBeTriggeredWithFailureThreshold is the same as ShouldBeTriggered, except that the failure threshold passed
+// is used instead of the alert's FailureThreshold, e.g. when it is temporarily overridden.
+func (alert *Alert) ShouldBeTriggeredWithFailureThreshold(numberOfFailuresInARow int, timeSinceLastSuccess time.Duration, failureThreshold int) bool {
+	if alert.IsTriggeredBySLOBurnRate() {
+		// Alerts triggered by the burn rate are handled regardless of the failures of the endpoint
+		return false
+	}
+	if alert.lastSuccessOlderThan > 0 {
+		return timeSinceLastSuccess >= alert.lastSuccessOlderThan
+	}
+	return numberOfFailuresInARow >= failureThreshold
+}
+
+// IsTriggeredBySLOBurnRate returns whether the alert is triggered by the burn rate of the error budget of the service
+// level objective of the endpoint rather than by its failures
+func (alert *Alert) IsTriggeredBySLOBurnRate() bool {
+	return alert.sloBurnRateAbove > 0
+}
+
+// SLOBurnRateLookback returns the duration over which the burn rate that triggers the alert is measured
+func (alert *Alert) SLOBurnRateLookback() time.Duration {
+	return alert.sloBurnRateLookback
+}
+
+// ShouldBeTriggeredBySLOBurnRate returns whether the alert should be triggered, or remain triggered, based on the burn
+// rate passed, which must have been measured over SLOBurnRateLookback
+func (alert *Alert) ShouldBeTriggeredBySLOBurnRate(burnRate float64) bool {
+	return alert.IsTriggeredBySLOBurnRate() && burnRate > alert.sloBurnRateAbove
+}
+
 // GetDescription retrieves the description of the alert
 func (alert *Alert) GetDescription() string {
 	if alert.Description == nil {
@@ -81,6 +214,11 @@ func (alert *Alert) GetDescription() string {
 	return *alert.Description
 }
 
+// IsCritical returns whether the alert is critical, which is the case if its severity is SeverityCritical or unset
+func (alert *Alert) IsCritical() bool {
+	return alert.Severity == SeverityCritical || len(alert.Severity) == 0
+}
+
 // IsEnabled returns whether an alert is enabled or not
 // Returns true if not set
 func (alert *Alert) IsEnabled() bool {
@@ -109,5 +247,9 @@ func (alert *Alert) Checksum() string {
 		strconv.Itoa(alert.FailureThreshold) + "_" +
 		alert.GetDescription()),
 	)
+	if len(alert.TriggerIf) > 0 {
+		// Only included when set so that the checksum of existing alerts remains unchanged
+		hash.Write([]byte("_" + alert.TriggerIf))
+	}
 	return hex.EncodeToString(hash.Sum(nil))
 }
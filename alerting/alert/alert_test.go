@@ -3,6 +3,7 @@ package alert
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestAlert_ValidateAndSetDefaults(t *testing.T) {
@@ -36,6 +37,83 @@ func TestAlert_ValidateAndSetDefaults(t *testing.T) {
 			expectedFailureThreshold: 10,
 			expectedSuccessThreshold: 5,
 		},
+		{
+			name:                     "valid-trigger-if",
+			alert:                    Alert{TriggerIf: "last-success-older-than 15m"},
+			expectedError:            nil,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-trigger-if-expression",
+			alert:                    Alert{TriggerIf: "failures-greater-than 5"},
+			expectedError:            ErrAlertWithInvalidTriggerIf,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-trigger-if-duration",
+			alert:                    Alert{TriggerIf: "last-success-older-than forever"},
+			expectedError:            ErrAlertWithInvalidTriggerIf,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-trigger-if-missing-duration",
+			alert:                    Alert{TriggerIf: "last-success-older-than"},
+			expectedError:            ErrAlertWithInvalidTriggerIf,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "valid-trigger-if-slo-burn-rate",
+			alert:                    Alert{TriggerIf: "slo-burn-rate-above 14.4"},
+			expectedError:            nil,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "valid-trigger-if-slo-burn-rate-with-lookback",
+			alert:                    Alert{TriggerIf: "slo-burn-rate-above 6 over 6h"},
+			expectedError:            nil,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-trigger-if-slo-burn-rate",
+			alert:                    Alert{TriggerIf: "slo-burn-rate-above fast"},
+			expectedError:            ErrAlertWithInvalidTriggerIf,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-trigger-if-slo-burn-rate-lookback-shorter-than-1h",
+			alert:                    Alert{TriggerIf: "slo-burn-rate-above 14.4 over 5m"},
+			expectedError:            ErrAlertWithInvalidTriggerIf,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-trigger-if-slo-burn-rate-missing-lookback",
+			alert:                    Alert{TriggerIf: "slo-burn-rate-above 14.4 over"},
+			expectedError:            ErrAlertWithInvalidTriggerIf,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "valid-severity",
+			alert:                    Alert{Severity: SeverityWarning},
+			expectedError:            nil,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
+		{
+			name:                     "invalid-severity",
+			alert:                    Alert{Severity: "urgent"},
+			expectedError:            ErrAlertWithInvalidSeverity,
+			expectedFailureThreshold: 3,
+			expectedSuccessThreshold: 2,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -52,6 +130,106 @@ func TestAlert_ValidateAndSetDefaults(t *testing.T) {
 	}
 }
 
+func TestAlert_IsCritical(t *testing.T) {
+	if !(&Alert{}).IsCritical() {
+		t.Error("alert without severity should've been critical")
+	}
+	if !(&Alert{Severity: SeverityCritical}).IsCritical() {
+		t.Error("alert with critical severity should've been critical")
+	}
+	if (&Alert{Severity: SeverityInfo}).IsCritical() {
+		t.Error("alert with info severity shouldn't have been critical")
+	}
+	alert := Alert{}
+	_ = alert.ValidateAndSetDefaults()
+	if alert.Severity != SeverityCritical {
+		t.Errorf("expected default severity to be %s, got %s", SeverityCritical, alert.Severity)
+	}
+}
+
+func TestAlert_ShouldBeTriggered(t *testing.T) {
+	scenarios := []struct {
+		name                   string
+		alert                  Alert
+		numberOfFailuresInARow int
+		timeSinceLastSuccess   time.Duration
+		expected               bool
+	}{
+		{
+			name:                   "failure-threshold-not-reached",
+			alert:                  Alert{FailureThreshold: 3},
+			numberOfFailuresInARow: 2,
+			timeSinceLastSuccess:   time.Hour,
+			expected:               false,
+		},
+		{
+			name:                   "failure-threshold-reached",
+			alert:                  Alert{FailureThreshold: 3},
+			numberOfFailuresInARow: 3,
+			timeSinceLastSuccess:   0,
+			expected:               true,
+		},
+		{
+			name:                   "trigger-if-not-reached",
+			alert:                  Alert{FailureThreshold: 3, TriggerIf: "last-success-older-than 15m"},
+			numberOfFailuresInARow: 10,
+			timeSinceLastSuccess:   14 * time.Minute,
+			expected:               false,
+		},
+		{
+			name:                   "trigger-if-reached",
+			alert:                  Alert{FailureThreshold: 3, TriggerIf: "last-success-older-than 15m"},
+			numberOfFailuresInARow: 1,
+			timeSinceLastSuccess:   15 * time.Minute,
+			expected:               true,
+		},
+		{
+			name:                   "trigger-if-slo-burn-rate-ignores-failures",
+			alert:                  Alert{FailureThreshold: 3, TriggerIf: "slo-burn-rate-above 14.4"},
+			numberOfFailuresInARow: 10,
+			timeSinceLastSuccess:   time.Hour,
+			expected:               false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.alert.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if actual := scenario.alert.ShouldBeTriggered(scenario.numberOfFailuresInARow, scenario.timeSinceLastSuccess); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAlert_ShouldBeTriggeredBySLOBurnRate(t *testing.T) {
+	alert := Alert{TriggerIf: "slo-burn-rate-above 6 over 6h"}
+	if err := alert.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !alert.IsTriggeredBySLOBurnRate() {
+		t.Error("expected alert to be triggered by the burn rate")
+	}
+	if alert.SLOBurnRateLookback() != 6*time.Hour {
+		t.Errorf("expected lookback to be 6h, got %s", alert.SLOBurnRateLookback())
+	}
+	if alert.ShouldBeTriggeredBySLOBurnRate(6) {
+		t.Error("expected alert not to be triggered by a burn rate equal to the threshold")
+	}
+	if !alert.ShouldBeTriggeredBySLOBurnRate(6.5) {
+		t.Error("expected alert to be triggered by a burn rate above the threshold")
+	}
+	defaultLookbackAlert := Alert{TriggerIf: "slo-burn-rate-above 14.4"}
+	_ = defaultLookbackAlert.ValidateAndSetDefaults()
+	if defaultLookbackAlert.SLOBurnRateLookback() != DefaultSLOBurnRateLookback {
+		t.Errorf("expected lookback to default to %s, got %s", DefaultSLOBurnRateLookback, defaultLookbackAlert.SLOBurnRateLookback())
+	}
+	if (&Alert{FailureThreshold: 3}).ShouldBeTriggeredBySLOBurnRate(100) {
+		t.Error("expected alert without slo-burn-rate-above trigger-if never to be triggered by the burn rate")
+	}
+}
+
 func TestAlert_IsEnabled(t *testing.T) {
 	if !(&Alert{Enabled: nil}).IsEnabled() {
 		t.Error("alert.IsEnabled() should've returned true, because Enabled was set to nil")
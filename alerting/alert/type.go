@@ -17,6 +17,9 @@ const (
 	// TypeEmail is the Type for the email alerting provider
 	TypeEmail Type = "email"
 
+	// TypeFCM is the Type for the fcm alerting provider
+	TypeFCM Type = "fcm"
+
 	// TypeGitHub is the Type for the github alerting provider
 	TypeGitHub Type = "github"
 
@@ -32,6 +35,9 @@ const (
 	// TypeJetBrainsSpace is the Type for the jetbrains alerting provider
 	TypeJetBrainsSpace Type = "jetbrainsspace"
 
+	// TypeKeybase is the Type for the keybase alerting provider
+	TypeKeybase Type = "keybase"
+
 	// TypeMatrix is the Type for the matrix alerting provider
 	TypeMatrix Type = "matrix"
 
@@ -41,6 +47,9 @@ const (
 	// TypeMessagebird is the Type for the messagebird alerting provider
 	TypeMessagebird Type = "messagebird"
 
+	// TypeMQTT is the Type for the mqtt alerting provider
+	TypeMQTT Type = "mqtt"
+
 	// TypeNtfy is the Type for the ntfy alerting provider
 	TypeNtfy Type = "ntfy"
 
@@ -53,6 +62,9 @@ const (
 	// TypePushover is the Type for the pushover alerting provider
 	TypePushover Type = "pushover"
 
+	// TypeRocketChat is the Type for the rocketchat alerting provider
+	TypeRocketChat Type = "rocketchat"
+
 	// TypeSlack is the Type for the slack alerting provider
 	TypeSlack Type = "slack"
 
@@ -0,0 +1,191 @@
+package delivery
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	// DefaultMaximumAttempts is the default number of attempts made to deliver an alert before it is dead-lettered
+	DefaultMaximumAttempts = 10
+
+	// DefaultInitialBackoff is the default duration to wait before retrying to deliver an alert for the first time
+	DefaultInitialBackoff = 30 * time.Second
+
+	// DefaultMaximumBackoff is the default maximum duration to wait between two attempts
+	DefaultMaximumBackoff = time.Hour
+)
+
+var (
+	// ErrInvalidMaximumAttempts is the error with which Gatus will panic if the maximum number of attempts is negative
+	ErrInvalidMaximumAttempts = errors.New("alerting delivery maximum-attempts must not be negative")
+
+	// ErrInvalidBackoff is the error with which Gatus will panic if a backoff is negative, or if the initial backoff is
+	// greater than the maximum backoff
+	ErrInvalidBackoff = errors.New("alerting delivery initial-backoff must be positive and lower than or equal to maximum-backoff")
+)
+
+// Config is the configuration of the queue through which alerts are delivered when it is enabled.
+//
+// Rather than being sent by the goroutine monitoring the endpoint, alerts are persisted in the storage and delivered
+// in the background, so a slow alerting provider doesn't delay the evaluation of endpoints, and a transient outage of
+// an alerting provider doesn't cause alerts to be lost.
+type Config struct {
+	// MaximumAttempts is the number of attempts made to deliver an alert before it is dead-lettered
+	MaximumAttempts int `yaml:"maximum-attempts,omitempty"`
+
+	// InitialBackoff is the duration to wait before retrying to deliver an alert for the first time.
+	// The duration doubles after every failed attempt, up to MaximumBackoff.
+	InitialBackoff time.Duration `yaml:"initial-backoff,omitempty"`
+
+	// MaximumBackoff is the maximum duration to wait between two attempts
+	MaximumBackoff time.Duration `yaml:"maximum-backoff,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the delivery configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.MaximumAttempts < 0 {
+		return ErrInvalidMaximumAttempts
+	}
+	if c.MaximumAttempts == 0 {
+		c.MaximumAttempts = DefaultMaximumAttempts
+	}
+	if c.InitialBackoff == 0 {
+		c.InitialBackoff = DefaultInitialBackoff
+	}
+	if c.MaximumBackoff == 0 {
+		c.MaximumBackoff = max(DefaultMaximumBackoff, c.InitialBackoff)
+	}
+	if c.InitialBackoff < 0 || c.InitialBackoff > c.MaximumBackoff {
+		return ErrInvalidBackoff
+	}
+	return nil
+}
+
+// BackoffAfter returns the duration to wait after the number of failed attempts passed
+func (c *Config) BackoffAfter(attempts int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 1; i < attempts && backoff < c.MaximumBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.MaximumBackoff)
+}
+
+// Delivery is an alert waiting to be delivered through its alerting provider
+type Delivery struct {
+	// ID is the unique identifier of the delivery, which is set by the store
+	ID int64 `json:"id"`
+
+	// EndpointKey is the key of the endpoint for which the alert was triggered or resolved
+	EndpointKey string `json:"endpointKey"`
+
+	// AlertType is the type of the alert, and therefore of the alerting provider used to deliver it
+	AlertType alert.Type `json:"alertType"`
+
+	// AlertChecksum is the checksum of the configuration of the alert, which is used to retrieve the alert
+	AlertChecksum string `json:"alertChecksum"`
+
+	// HistoryEntryID is the ID of the entry of the alert history the delivery is for, if any
+	HistoryEntryID int64 `json:"historyEntryId,omitempty"`
+
+	// Resolved is whether the alert was resolved, as opposed to triggered
+	Resolved bool `json:"resolved"`
+
+	// Result is the result that caused the alert to be triggered or resolved
+	Result *endpoint.Result `json:"result"`
+
+	// State is the alerting state of the endpoint and of the alert when the delivery was queued
+	State State `json:"state"`
+
+	// Attempts is the number of failed attempts made to deliver the alert
+	Attempts int `json:"attempts"`
+
+	// LastError is the error returned by the last failed attempt
+	LastError string `json:"lastError,omitempty"`
+
+	// DeadLettered is whether the delivery was abandoned after too many failed attempts
+	DeadLettered bool `json:"deadLettered"`
+
+	// CreatedAt is the time at which the delivery was queued
+	CreatedAt time.Time `json:"createdAt"`
+
+	// NextAttemptAt is the time from which the next attempt to deliver the alert may be made
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+}
+
+// State is the alerting state of an endpoint and of its alert when a delivery was queued.
+//
+// Alerts are delivered with this state rather than with the current state of the endpoint and of the alert, which
+// keeps changing while the endpoint is monitored, so that an alert describes the situation that caused it to be sent.
+type State struct {
+	// NumberOfFailuresInARow is the number of consecutive failures of the endpoint
+	NumberOfFailuresInARow int `json:"numberOfFailuresInARow"`
+
+	// NumberOfSuccessesInARow is the number of consecutive successes of the endpoint
+	NumberOfSuccessesInARow int `json:"numberOfSuccessesInARow"`
+
+	// DownSince is the timestamp of the first failure of the outage of the endpoint, if any
+	DownSince *time.Time `json:"downSince,omitempty"`
+
+	// ResolveKey is the key some alerting providers require to resolve the alert
+	ResolveKey string `json:"resolveKey,omitempty"`
+}
+
+// NewDelivery creates a delivery for an alert of the endpoint passed that is ready to be attempted
+func NewDelivery(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, resolved bool) *Delivery {
+	now := time.Now()
+	d := &Delivery{
+		EndpointKey:   ep.Key(),
+		AlertType:     endpointAlert.Type,
+		AlertChecksum: endpointAlert.Checksum(),
+		Resolved:      resolved,
+		Result:        result,
+		State: State{
+			NumberOfFailuresInARow:  ep.NumberOfFailuresInARow,
+			NumberOfSuccessesInARow: ep.NumberOfSuccessesInARow,
+			ResolveKey:              endpointAlert.ResolveKey,
+		},
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if !ep.DownSince.IsZero() {
+		downSince := ep.DownSince
+		d.State.DownSince = &downSince
+	}
+	return d
+}
+
+// Apply sets the state of the delivery on the endpoint and the alert passed, which must be copies of the endpoint
+// and of the alert the delivery is for
+func (d *Delivery) Apply(ep *endpoint.Endpoint, endpointAlert *alert.Alert) {
+	ep.NumberOfFailuresInARow = d.State.NumberOfFailuresInARow
+	ep.NumberOfSuccessesInARow = d.State.NumberOfSuccessesInARow
+	ep.DownSince = time.Time{}
+	if d.State.DownSince != nil {
+		ep.DownSince = *d.State.DownSince
+	}
+	endpointAlert.Triggered = !d.Resolved
+	endpointAlert.ResolveKey = d.State.ResolveKey
+}
+
+// RecordFailure records a failed attempt to deliver the alert, and dead-letters the delivery if there have been too
+// many failed attempts
+func (d *Delivery) RecordFailure(err error, cfg *Config) {
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= cfg.MaximumAttempts {
+		d.DeadLettered = true
+	} else {
+		d.NextAttemptAt = time.Now().Add(cfg.BackoffAfter(d.Attempts))
+	}
+}
+
+// Requeue makes a dead-lettered delivery ready to be attempted again
+func (d *Delivery) Requeue() {
+	d.Attempts = 0
+	d.DeadLettered = false
+	d.NextAttemptAt = time.Now()
+}
@@ -0,0 +1,108 @@
+package delivery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		cfg            *Config
+		expectedConfig *Config
+		expectedError  error
+	}{
+		{
+			name:           "defaults",
+			cfg:            &Config{},
+			expectedConfig: &Config{MaximumAttempts: DefaultMaximumAttempts, InitialBackoff: DefaultInitialBackoff, MaximumBackoff: DefaultMaximumBackoff},
+		},
+		{
+			name:           "initial-backoff-greater-than-default-maximum-backoff",
+			cfg:            &Config{InitialBackoff: 2 * time.Hour},
+			expectedConfig: &Config{MaximumAttempts: DefaultMaximumAttempts, InitialBackoff: 2 * time.Hour, MaximumBackoff: 2 * time.Hour},
+		},
+		{
+			name:          "negative-maximum-attempts",
+			cfg:           &Config{MaximumAttempts: -1},
+			expectedError: ErrInvalidMaximumAttempts,
+		},
+		{
+			name:          "negative-initial-backoff",
+			cfg:           &Config{InitialBackoff: -time.Second},
+			expectedError: ErrInvalidBackoff,
+		},
+		{
+			name:          "initial-backoff-greater-than-maximum-backoff",
+			cfg:           &Config{InitialBackoff: time.Minute, MaximumBackoff: time.Second},
+			expectedError: ErrInvalidBackoff,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if scenario.expectedConfig != nil && *scenario.cfg != *scenario.expectedConfig {
+				t.Errorf("expected config %+v, got %+v", scenario.expectedConfig, scenario.cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_BackoffAfter(t *testing.T) {
+	cfg := &Config{InitialBackoff: 30 * time.Second, MaximumBackoff: 5 * time.Minute}
+	expectedBackoffs := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, expectedBackoff := range expectedBackoffs {
+		if backoff := cfg.BackoffAfter(i + 1); backoff != expectedBackoff {
+			t.Errorf("expected backoff after %d attempts to be %s, got %s", i+1, expectedBackoff, backoff)
+		}
+	}
+}
+
+func TestDelivery_RecordFailure(t *testing.T) {
+	cfg := &Config{MaximumAttempts: 2, InitialBackoff: time.Minute, MaximumBackoff: time.Hour}
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	d := NewDelivery(ep, &alert.Alert{Type: alert.TypeSlack}, &endpoint.Result{}, false)
+	if d.EndpointKey != "core_frontend" || d.AlertType != alert.TypeSlack || d.Resolved {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+	d.RecordFailure(errors.New("provider unavailable"), cfg)
+	if d.DeadLettered || d.Attempts != 1 || d.LastError != "provider unavailable" {
+		t.Errorf("expected delivery to be retried, got %+v", d)
+	}
+	if time.Until(d.NextAttemptAt) < 59*time.Second {
+		t.Errorf("expected next attempt to be in a minute, got %s", time.Until(d.NextAttemptAt))
+	}
+	d.RecordFailure(errors.New("provider still unavailable"), cfg)
+	if !d.DeadLettered || d.Attempts != 2 {
+		t.Errorf("expected delivery to be dead-lettered, got %+v", d)
+	}
+	d.Requeue()
+	if d.DeadLettered || d.Attempts != 0 || time.Until(d.NextAttemptAt) > 0 {
+		t.Errorf("expected delivery to be requeued, got %+v", d)
+	}
+}
+
+func TestDelivery_Apply(t *testing.T) {
+	downSince := time.Now().Add(-time.Hour)
+	ep := &endpoint.Endpoint{Name: "frontend", NumberOfFailuresInARow: 3, DownSince: downSince}
+	endpointAlert := &alert.Alert{Type: alert.TypePagerDuty, Triggered: true, ResolveKey: "dedup-key"}
+	d := NewDelivery(ep, endpointAlert, &endpoint.Result{}, true)
+	// The endpoint and the alert keep changing after the delivery was queued
+	ep.NumberOfFailuresInARow, ep.NumberOfSuccessesInARow, ep.DownSince = 0, 1, time.Time{}
+	endpointAlert.Triggered, endpointAlert.ResolveKey = false, ""
+	endpointCopy, alertCopy := *ep, *endpointAlert
+	d.Apply(&endpointCopy, &alertCopy)
+	if endpointCopy.NumberOfFailuresInARow != 3 || endpointCopy.NumberOfSuccessesInARow != 0 || !endpointCopy.DownSince.Equal(downSince) {
+		t.Errorf("expected the state of the endpoint when the delivery was queued, got %+v", endpointCopy)
+	}
+	if alertCopy.Triggered || alertCopy.ResolveKey != "dedup-key" {
+		t.Errorf("expected the state of the alert when the delivery was queued, got %+v", alertCopy)
+	}
+}
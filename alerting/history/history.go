@@ -0,0 +1,118 @@
+package history
+
+import (
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// DeliveryStatus is the status of the delivery of a triggered or resolved alert
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusSent means that the alert was sent through its alerting provider
+	DeliveryStatusSent DeliveryStatus = "SENT"
+
+	// DeliveryStatusFailed means that the alert could not be sent through its alerting provider
+	DeliveryStatusFailed DeliveryStatus = "FAILED"
+
+	// DeliveryStatusQueued means that the alert is waiting to be delivered, possibly after failed attempts
+	DeliveryStatusQueued DeliveryStatus = "QUEUED"
+
+	// DeliveryStatusDeadLettered means that the delivery of the alert was abandoned after too many failed attempts
+	DeliveryStatusDeadLettered DeliveryStatus = "DEAD_LETTERED"
+
+	// DeliveryStatusSilenced means that the alert wasn't sent because the alerts of its endpoint were silenced
+	DeliveryStatusSilenced DeliveryStatus = "SILENCED"
+)
+
+// Entry is an alert that was triggered, along with its resolution if it has been resolved
+type Entry struct {
+	// ID is the unique identifier of the entry, which is set by the store
+	ID int64 `json:"id"`
+
+	// EndpointKey is the key of the endpoint for which the alert was triggered
+	EndpointKey string `json:"endpointKey"`
+
+	// EndpointGroup is the group of the endpoint for which the alert was triggered
+	EndpointGroup string `json:"endpointGroup,omitempty"`
+
+	// EndpointName is the name of the endpoint for which the alert was triggered
+	EndpointName string `json:"endpointName"`
+
+	// AlertType is the type of the alert, and therefore of the alerting provider used to send it
+	AlertType alert.Type `json:"alertType"`
+
+	// AlertChecksum is the checksum of the configuration of the alert
+	AlertChecksum string `json:"alertChecksum"`
+
+	// Description is the description of the alert
+	Description string `json:"description,omitempty"`
+
+	// TriggeredAt is the time at which the alert was triggered
+	TriggeredAt time.Time `json:"triggeredAt"`
+
+	// ResolvedAt is the time at which the alert was resolved, or nil if it is still triggered
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+
+	// TriggeredDelivery is the summary of the delivery of the triggered alert
+	TriggeredDelivery DeliverySummary `json:"triggeredDelivery"`
+
+	// ResolvedDelivery is the summary of the delivery of the resolved alert, or nil if the alert hasn't been resolved
+	// or if its resolution isn't sent
+	ResolvedDelivery *DeliverySummary `json:"resolvedDelivery,omitempty"`
+}
+
+// DeliverySummary is a summary of the delivery of a triggered or resolved alert
+type DeliverySummary struct {
+	// Status is the status of the delivery
+	Status DeliveryStatus `json:"status"`
+
+	// Attempts is the number of attempts made to deliver the alert
+	Attempts int `json:"attempts"`
+
+	// LastError is the error returned by the last failed attempt, if any
+	LastError string `json:"lastError,omitempty"`
+}
+
+// NewEntry creates an entry for an alert of the endpoint passed that has just been triggered
+func NewEntry(ep *endpoint.Endpoint, endpointAlert *alert.Alert, triggeredAt time.Time, triggeredDelivery DeliverySummary) *Entry {
+	return &Entry{
+		EndpointKey:       ep.Key(),
+		EndpointGroup:     ep.Group,
+		EndpointName:      ep.Name,
+		AlertType:         endpointAlert.Type,
+		AlertChecksum:     endpointAlert.Checksum(),
+		Description:       endpointAlert.GetDescription(),
+		TriggeredAt:       triggeredAt,
+		TriggeredDelivery: triggeredDelivery,
+	}
+}
+
+// Resolve marks the entry as resolved at the time passed, along with the summary of the delivery of the resolution,
+// if it is sent
+func (e *Entry) Resolve(resolvedAt time.Time, resolvedDelivery *DeliverySummary) {
+	e.ResolvedAt = &resolvedAt
+	e.ResolvedDelivery = resolvedDelivery
+}
+
+// Duration returns for how long the alert was triggered, or has been triggered so far if it hasn't been resolved
+func (e *Entry) Duration() time.Duration {
+	if e.ResolvedAt == nil {
+		return time.Since(e.TriggeredAt)
+	}
+	return e.ResolvedAt.Sub(e.TriggeredAt)
+}
+
+// IsInTimeRange returns whether the alert was triggered at some point between the times passed.
+// A zero time means that the range is unbounded on that side.
+func (e *Entry) IsInTimeRange(from, to time.Time) bool {
+	if !to.IsZero() && e.TriggeredAt.After(to) {
+		return false
+	}
+	if !from.IsZero() && e.ResolvedAt != nil && e.ResolvedAt.Before(from) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntry_IsInTimeRange(t *testing.T) {
+	now := time.Now()
+	resolvedAt := now.Add(-time.Hour)
+	resolved := &Entry{TriggeredAt: now.Add(-2 * time.Hour), ResolvedAt: &resolvedAt}
+	unresolved := &Entry{TriggeredAt: now.Add(-30 * time.Minute)}
+	scenarios := []struct {
+		name     string
+		entry    *Entry
+		from, to time.Time
+		expected bool
+	}{
+		{name: "unbounded", entry: resolved, expected: true},
+		{name: "resolved-within-range", entry: resolved, from: now.Add(-90 * time.Minute), to: now, expected: true},
+		{name: "resolved-before-range", entry: resolved, from: now.Add(-30 * time.Minute), expected: false},
+		{name: "triggered-after-range", entry: resolved, to: now.Add(-3 * time.Hour), expected: false},
+		{name: "unresolved-triggered-before-range", entry: unresolved, from: now.Add(-10 * time.Minute), expected: true},
+		{name: "unresolved-triggered-after-range", entry: unresolved, to: now.Add(-time.Hour), expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.entry.IsInTimeRange(scenario.from, scenario.to); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEntry_Duration(t *testing.T) {
+	entry := &Entry{TriggeredAt: time.Now().Add(-time.Hour)}
+	if duration := entry.Duration(); duration < time.Hour {
+		t.Errorf("expected the duration of an unresolved alert to be at least an hour, got %s", duration)
+	}
+	entry.Resolve(entry.TriggeredAt.Add(10*time.Minute), nil)
+	if duration := entry.Duration(); duration != 10*time.Minute {
+		t.Errorf("expected a duration of 10m, got %s", duration)
+	}
+}
@@ -114,7 +114,7 @@ func (provider *AlertProvider) buildMessageSubjectAndBody(ep *endpoint.Endpoint,
 	var subject, message string
 	if resolved {
 		subject = fmt.Sprintf("[%s] Alert resolved", ep.DisplayName())
-		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row%s", ep.DisplayName(), alert.SuccessThreshold, ep.ResolvedDowntimeSuffix(result))
 	} else {
 		subject = fmt.Sprintf("[%s] Alert triggered", ep.DisplayName())
 		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -50,7 +51,7 @@ func (provider *AlertProvider) GetAlertStatePlaceholderValue(resolved bool) stri
 	return status
 }
 
-func (provider *AlertProvider) buildHTTPRequest(ep *endpoint.Endpoint, alert *alert.Alert, resolved bool) *http.Request {
+func (provider *AlertProvider) buildHTTPRequest(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) *http.Request {
 	body, url, method := provider.Body, provider.URL, provider.Method
 	body = strings.ReplaceAll(body, "[ALERT_DESCRIPTION]", alert.GetDescription())
 	url = strings.ReplaceAll(url, "[ALERT_DESCRIPTION]", alert.GetDescription())
@@ -60,6 +61,21 @@ func (provider *AlertProvider) buildHTTPRequest(ep *endpoint.Endpoint, alert *al
 	url = strings.ReplaceAll(url, "[ENDPOINT_GROUP]", ep.Group)
 	body = strings.ReplaceAll(body, "[ENDPOINT_URL]", ep.URL)
 	url = strings.ReplaceAll(url, "[ENDPOINT_URL]", ep.URL)
+	body = strings.ReplaceAll(body, "[ENDPOINT_PAGE_URL]", ep.PageURL)
+	url = strings.ReplaceAll(url, "[ENDPOINT_PAGE_URL]", ep.PageURL)
+	var downSince, downFor string
+	if !ep.DownSince.IsZero() {
+		downSince = ep.DownSince.UTC().Format(time.RFC3339)
+		if resolved {
+			downFor = endpoint.HumanizeDuration(ep.DowntimeBeforeResolution(result))
+		} else {
+			downFor = endpoint.HumanizeDuration(ep.DownFor(time.Now()))
+		}
+	}
+	body = strings.ReplaceAll(body, "[ENDPOINT_DOWN_SINCE]", downSince)
+	url = strings.ReplaceAll(url, "[ENDPOINT_DOWN_SINCE]", downSince)
+	body = strings.ReplaceAll(body, "[ENDPOINT_DOWN_FOR]", downFor)
+	url = strings.ReplaceAll(url, "[ENDPOINT_DOWN_FOR]", downFor)
 	if resolved {
 		body = strings.ReplaceAll(body, "[ALERT_TRIGGERED_OR_RESOLVED]", provider.GetAlertStatePlaceholderValue(true))
 		url = strings.ReplaceAll(url, "[ALERT_TRIGGERED_OR_RESOLVED]", provider.GetAlertStatePlaceholderValue(true))
@@ -79,7 +95,7 @@ func (provider *AlertProvider) buildHTTPRequest(ep *endpoint.Endpoint, alert *al
 }
 
 func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
-	request := provider.buildHTTPRequest(ep, alert, resolved)
+	request := provider.buildHTTPRequest(ep, alert, result, resolved)
 	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
 	if err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -140,6 +141,7 @@ func TestAlertProvider_buildHTTPRequest(t *testing.T) {
 			request := customAlertProvider.buildHTTPRequest(
 				&endpoint.Endpoint{Name: "endpoint-name", Group: "endpoint-group", URL: "https://example.com"},
 				&alert.Alert{Description: &alertDescription},
+				&endpoint.Result{},
 				scenario.Resolved,
 			)
 			if request.URL.String() != scenario.ExpectedURL {
@@ -153,6 +155,41 @@ func TestAlertProvider_buildHTTPRequest(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildHTTPRequestWithDowntimePlaceholders(t *testing.T) {
+	customAlertProvider := &AlertProvider{
+		URL:  "https://example.com/[ENDPOINT_NAME]?since=[ENDPOINT_DOWN_SINCE]",
+		Body: "[ENDPOINT_NAME] has been down for [ENDPOINT_DOWN_FOR]",
+	}
+	downSince := time.Now().Add(-43*time.Minute - 10*time.Second)
+	request := customAlertProvider.buildHTTPRequest(
+		&endpoint.Endpoint{Name: "endpoint-name", DownSince: downSince},
+		&alert.Alert{},
+		&endpoint.Result{},
+		false,
+	)
+	if expectedURL := "https://example.com/endpoint-name?since=" + downSince.UTC().Format(time.RFC3339); request.URL.String() != expectedURL {
+		t.Error("expected URL to be", expectedURL, "got", request.URL.String())
+	}
+	if body, _ := io.ReadAll(request.Body); string(body) != "endpoint-name has been down for 43 minutes" {
+		t.Error("expected body to be", "endpoint-name has been down for 43 minutes", "got", string(body))
+	}
+	// When resolved, the downtime is until the result that resolved the outage
+	request = customAlertProvider.buildHTTPRequest(
+		&endpoint.Endpoint{Name: "endpoint-name", DownSince: downSince},
+		&alert.Alert{},
+		&endpoint.Result{Timestamp: downSince.Add(2 * time.Hour)},
+		true,
+	)
+	if body, _ := io.ReadAll(request.Body); string(body) != "endpoint-name has been down for 2 hours" {
+		t.Error("expected body to be", "endpoint-name has been down for 2 hours", "got", string(body))
+	}
+	// If the endpoint isn't down, the placeholders are replaced by empty strings
+	request = customAlertProvider.buildHTTPRequest(&endpoint.Endpoint{Name: "endpoint-name"}, &alert.Alert{}, &endpoint.Result{}, true)
+	if body, _ := io.ReadAll(request.Body); string(body) != "endpoint-name has been down for " {
+		t.Error("expected body to be", "endpoint-name has been down for ", "got", string(body))
+	}
+}
+
 func TestAlertProvider_buildHTTPRequestWithCustomPlaceholder(t *testing.T) {
 	customAlertProvider := &AlertProvider{
 		URL:     "https://example.com/[ENDPOINT_GROUP]/[ENDPOINT_NAME]?event=[ALERT_TRIGGERED_OR_RESOLVED]&description=[ALERT_DESCRIPTION]",
@@ -190,6 +227,7 @@ func TestAlertProvider_buildHTTPRequestWithCustomPlaceholder(t *testing.T) {
 			request := customAlertProvider.buildHTTPRequest(
 				&endpoint.Endpoint{Name: "endpoint-name", Group: "endpoint-group"},
 				&alert.Alert{Description: &alertDescription},
+				&endpoint.Result{},
 				scenario.Resolved,
 			)
 			if request.URL.String() != scenario.ExpectedURL {
@@ -73,6 +73,7 @@ type Body struct {
 
 type Embed struct {
 	Title       string  `json:"title"`
+	URL         string  `json:"url,omitempty"`
 	Description string  `json:"description"`
 	Color       int     `json:"color"`
 	Fields      []Field `json:"fields,omitempty"`
@@ -89,7 +90,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	var message string
 	var colorCode int
 	if resolved {
-		message = fmt.Sprintf("An alert for **%s** has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		message = fmt.Sprintf("An alert for **%s** has been resolved after passing successfully %d time(s) in a row%s", ep.DisplayName(), alert.SuccessThreshold, ep.ResolvedDowntimeSuffix(result))
 		colorCode = 3066993
 	} else {
 		message = fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
@@ -118,6 +119,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		Embeds: []Embed{
 			{
 				Title:       title,
+				URL:         ep.PageURL,
 				Description: message + description,
 				Color:       colorCode,
 			},
@@ -0,0 +1,273 @@
+package fcm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// v1APIURLFormat is the format of the URL of the HTTP v1 API, which takes the ID of the project as parameter
+	v1APIURLFormat = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+
+	// legacyAPIURL is the URL of the legacy HTTP API, which is used when a server key is configured
+	legacyAPIURL = "https://fcm.googleapis.com/fcm/send"
+
+	// messagingScope is the OAuth2 scope required to send messages through the HTTP v1 API
+	messagingScope = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+var (
+	// tokenSources caches the token source of each service account, so that access tokens are reused until they expire
+	tokenSources      = make(map[string]oauth2.TokenSource)
+	tokenSourcesMutex sync.Mutex
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Firebase Cloud Messaging
+type AlertProvider struct {
+	// ServiceAccount is the content of the JSON key of a service account allowed to send messages, which is used to
+	// send messages through the HTTP v1 API
+	ServiceAccount string `yaml:"service-account,omitempty"`
+
+	// ProjectID is the ID of the Firebase project messages are sent through.
+	// Defaults to the project of the service account.
+	ProjectID string `yaml:"project-id,omitempty"`
+
+	// ServerKey is the key used to send messages through the legacy HTTP API, if no service account is configured
+	ServerKey string `yaml:"server-key,omitempty"`
+
+	// Topic is the topic the messages are sent to
+	Topic string `yaml:"topic,omitempty"`
+
+	// Tokens is the list of registration tokens of the devices the messages are sent to
+	Tokens []string `yaml:"tokens,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group  string   `yaml:"group"`
+	Topic  string   `yaml:"topic,omitempty"`
+	Tokens []string `yaml:"tokens,omitempty"`
+}
+
+// serviceAccount is the subset of the JSON key of a service account required to send messages
+type serviceAccount struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || (len(override.Topic) == 0 && len(override.Tokens) == 0) {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	if len(provider.Topic) == 0 && len(provider.Tokens) == 0 {
+		return false
+	}
+	if len(provider.ServiceAccount) > 0 {
+		if len(provider.ServerKey) > 0 {
+			return false
+		}
+		var account serviceAccount
+		if err := json.Unmarshal([]byte(provider.ServiceAccount), &account); err != nil {
+			return false
+		}
+		if len(provider.ProjectID) == 0 {
+			provider.ProjectID = account.ProjectID
+		}
+		return account.Type == "service_account" && len(account.ClientEmail) > 0 && len(account.PrivateKey) > 0 && len(provider.ProjectID) > 0
+	}
+	return len(provider.ServerKey) > 0
+}
+
+// Send an alert using the provider
+// Reference doc for FCM: https://firebase.google.com/docs/cloud-messaging/send-message
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	topic, tokens := provider.getTargetsForGroup(ep.Group)
+	var targets []target
+	if len(topic) > 0 {
+		targets = append(targets, target{topic: topic})
+	}
+	for _, token := range tokens {
+		targets = append(targets, target{token: token})
+	}
+	for _, t := range targets {
+		if err := provider.send(provider.buildRequestBody(ep, alert, result, resolved, t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (provider *AlertProvider) send(body []byte) error {
+	url := legacyAPIURL
+	if len(provider.ServiceAccount) > 0 {
+		url = fmt.Sprintf(v1APIURLFormat, provider.ProjectID)
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if len(provider.ServiceAccount) > 0 {
+		token, err := provider.getAccessToken()
+		if err != nil {
+			return fmt.Errorf("failed to retrieve access token: %w", err)
+		}
+		request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	} else {
+		request.Header.Set("Authorization", "key="+provider.ServerKey)
+	}
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+// getAccessToken returns an access token for the service account, which is only exchanged again once it has expired
+func (provider *AlertProvider) getAccessToken() (*oauth2.Token, error) {
+	tokenSourcesMutex.Lock()
+	tokenSource, exists := tokenSources[provider.ServiceAccount]
+	if !exists {
+		jwtConfig, err := google.JWTConfigFromJSON([]byte(provider.ServiceAccount), messagingScope)
+		if err != nil {
+			tokenSourcesMutex.Unlock()
+			return nil, err
+		}
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client.GetHTTPClient(nil))
+		tokenSource = jwtConfig.TokenSource(ctx)
+		tokenSources[provider.ServiceAccount] = tokenSource
+	}
+	tokenSourcesMutex.Unlock()
+	return tokenSource.Token()
+}
+
+// target is either a topic or the registration token of a device a message is sent to
+type target struct {
+	topic string
+	token string
+}
+
+type V1Body struct {
+	Message V1Message `json:"message"`
+}
+
+type V1Message struct {
+	Topic        string            `json:"topic,omitempty"`
+	Token        string            `json:"token,omitempty"`
+	Notification Notification      `json:"notification"`
+	Data         map[string]string `json:"data"`
+	Android      V1Android         `json:"android"`
+}
+
+type V1Android struct {
+	Priority string `json:"priority"`
+}
+
+type LegacyBody struct {
+	To           string            `json:"to"`
+	Priority     string            `json:"priority"`
+	Notification Notification      `json:"notification"`
+	Data         map[string]string `json:"data"`
+}
+
+type Notification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool, t target) []byte {
+	var message, state string
+	if resolved {
+		state = "resolved"
+		message = "An alert has been resolved after passing successfully " + strconv.Itoa(alert.SuccessThreshold) + " time(s) in a row" + ep.ResolvedDowntimeSuffix(result)
+	} else {
+		state = "triggered"
+		message = "An alert has been triggered due to having failed " + strconv.Itoa(alert.FailureThreshold) + " time(s) in a row"
+	}
+	if len(alert.GetDescription()) > 0 {
+		message += " with the following description: " + alert.GetDescription()
+	}
+	notification := Notification{Title: "Gatus: " + ep.DisplayName(), Body: message}
+	// The data lets companion apps handle the notification, for instance by opening the page of the endpoint
+	data := map[string]string{
+		"key":         ep.Key(),
+		"group":       ep.Group,
+		"name":        ep.Name,
+		"state":       state,
+		"description": alert.GetDescription(),
+	}
+	var body []byte
+	if len(provider.ServiceAccount) > 0 {
+		body, _ = json.Marshal(V1Body{
+			Message: V1Message{
+				Topic:        t.topic,
+				Token:        t.token,
+				Notification: notification,
+				Data:         data,
+				Android:      V1Android{Priority: "HIGH"},
+			},
+		})
+	} else {
+		to := t.token
+		if len(t.topic) > 0 {
+			to = "/topics/" + t.topic
+		}
+		body, _ = json.Marshal(LegacyBody{
+			To:           to,
+			Priority:     "high",
+			Notification: notification,
+			Data:         data,
+		})
+	}
+	return body
+}
+
+// getTargetsForGroup returns the topic and the tokens messages should be sent to for a given group
+func (provider *AlertProvider) getTargetsForGroup(group string) (string, []string) {
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if group == override.Group {
+				return override.Topic, override.Tokens
+			}
+		}
+	}
+	return provider.Topic, provider.Tokens
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,284 @@
+package fcm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+// newServiceAccount creates the JSON key of a service account with a freshly generated private key
+func newServiceAccount(t *testing.T, clientEmail string) string {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyBytes, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes})
+	serviceAccountJSON, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "gatus-project",
+		"client_email": clientEmail,
+		"private_key":  string(privateKeyPEM),
+		"token_uri":    "https://oauth2.example.org/token",
+	})
+	return string(serviceAccountJSON)
+}
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	serviceAccount := newServiceAccount(t, "gatus@gatus-project.iam.gserviceaccount.com")
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "server-key-with-topic",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus"},
+			Expected: true,
+		},
+		{
+			Name:     "server-key-with-tokens",
+			Provider: AlertProvider{ServerKey: "server-key", Tokens: []string{"token-1"}},
+			Expected: true,
+		},
+		{
+			Name:     "service-account",
+			Provider: AlertProvider{ServiceAccount: serviceAccount, Topic: "gatus"},
+			Expected: true,
+		},
+		{
+			Name:     "no-target",
+			Provider: AlertProvider{ServerKey: "server-key"},
+			Expected: false,
+		},
+		{
+			Name:     "no-credentials",
+			Provider: AlertProvider{Topic: "gatus"},
+			Expected: false,
+		},
+		{
+			Name:     "both-server-key-and-service-account",
+			Provider: AlertProvider{ServiceAccount: serviceAccount, ServerKey: "server-key", Topic: "gatus"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-service-account",
+			Provider: AlertProvider{ServiceAccount: "{", Topic: "gatus"},
+			Expected: false,
+		},
+		{
+			Name:     "service-account-without-private-key",
+			Provider: AlertProvider{ServiceAccount: `{"type":"service_account","project_id":"gatus-project","client_email":"gatus@gatus-project.iam.gserviceaccount.com"}`, Topic: "gatus"},
+			Expected: false,
+		},
+		{
+			Name:     "override-without-target",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus", Overrides: []Override{{Group: "core"}}},
+			Expected: false,
+		},
+		{
+			Name:     "override-without-group",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus", Overrides: []Override{{Topic: "core"}}},
+			Expected: false,
+		},
+		{
+			Name:     "duplicate-override",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus", Overrides: []Override{{Group: "core", Topic: "core"}, {Group: "core", Topic: "other"}}},
+			Expected: false,
+		},
+		{
+			Name:     "override",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus", Overrides: []Override{{Group: "core", Tokens: []string{"token-1"}}}},
+			Expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_IsValidDefaultsProjectIDToProjectOfServiceAccount(t *testing.T) {
+	provider := AlertProvider{ServiceAccount: newServiceAccount(t, "gatus@gatus-project.iam.gserviceaccount.com"), Topic: "gatus"}
+	if !provider.IsValid() {
+		t.Fatal("expected provider to be valid")
+	}
+	if provider.ProjectID != "gatus-project" {
+		t.Errorf("expected project-id to default to gatus-project, got %s", provider.ProjectID)
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	description := "description-1"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedURLs     []string
+		ExpectedError    bool
+	}{
+		{
+			Name:     "legacy-triggered",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus", Tokens: []string{"token-1"}},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.Header.Get("Authorization") != "key=server-key" {
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedURLs: []string{legacyAPIURL, legacyAPIURL},
+		},
+		{
+			Name:     "legacy-error",
+			Provider: AlertProvider{ServerKey: "server-key", Topic: "gatus"},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedURLs:  []string{legacyAPIURL},
+			ExpectedError: true,
+		},
+		{
+			Name:     "v1-triggered",
+			Provider: AlertProvider{ServiceAccount: newServiceAccount(t, "v1-triggered@gatus-project.iam.gserviceaccount.com"), ProjectID: "gatus-project", Topic: "gatus"},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.Host == "oauth2.example.org" {
+					return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(strings.NewReader(`{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`))}
+				}
+				if r.Header.Get("Authorization") != "Bearer access-token" {
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedURLs: []string{"https://oauth2.example.org/token", "https://fcm.googleapis.com/v1/projects/gatus-project/messages:send"},
+		},
+		{
+			Name:     "v1-token-exchange-error",
+			Provider: AlertProvider{ServiceAccount: newServiceAccount(t, "v1-token-exchange-error@gatus-project.iam.gserviceaccount.com"), ProjectID: "gatus-project", Topic: "gatus"},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(`{"error":"invalid_grant"}`))}
+			}),
+			ExpectedURLs:  []string{"https://oauth2.example.org/token"},
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var urls []string
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				urls = append(urls, r.URL.String())
+				return scenario.MockRoundTripper(r)
+			})})
+			err := scenario.Provider.Send(
+				&endpoint.Endpoint{Name: "endpoint-name"},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			if strings.Join(urls, " ") != strings.Join(scenario.ExpectedURLs, " ") {
+				t.Errorf("expected requests to %v, got %v", scenario.ExpectedURLs, urls)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	description := "description-1"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Target       target
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "legacy-topic-triggered",
+			Provider:     AlertProvider{ServerKey: "server-key"},
+			Target:       target{topic: "gatus"},
+			ExpectedBody: `{"to":"/topics/gatus","priority":"high","notification":{"title":"Gatus: core/frontend","body":"An alert has been triggered due to having failed 3 time(s) in a row with the following description: description-1"},"data":{"description":"description-1","group":"core","key":"core_frontend","name":"frontend","state":"triggered"}}`,
+		},
+		{
+			Name:         "legacy-token-resolved",
+			Provider:     AlertProvider{ServerKey: "server-key"},
+			Target:       target{token: "token-1"},
+			Resolved:     true,
+			ExpectedBody: `{"to":"token-1","priority":"high","notification":{"title":"Gatus: core/frontend","body":"An alert has been resolved after passing successfully 5 time(s) in a row with the following description: description-1"},"data":{"description":"description-1","group":"core","key":"core_frontend","name":"frontend","state":"resolved"}}`,
+		},
+		{
+			Name:         "v1-topic-triggered",
+			Provider:     AlertProvider{ServiceAccount: "{}"},
+			Target:       target{topic: "gatus"},
+			ExpectedBody: `{"message":{"topic":"gatus","notification":{"title":"Gatus: core/frontend","body":"An alert has been triggered due to having failed 3 time(s) in a row with the following description: description-1"},"data":{"description":"description-1","group":"core","key":"core_frontend","name":"frontend","state":"triggered"},"android":{"priority":"HIGH"}}}`,
+		},
+		{
+			Name:         "v1-token-resolved",
+			Provider:     AlertProvider{ServiceAccount: "{}"},
+			Target:       target{token: "token-1"},
+			Resolved:     true,
+			ExpectedBody: `{"message":{"token":"token-1","notification":{"title":"Gatus: core/frontend","body":"An alert has been resolved after passing successfully 5 time(s) in a row with the following description: description-1"},"data":{"description":"description-1","group":"core","key":"core_frontend","name":"frontend","state":"resolved"},"android":{"priority":"HIGH"}}}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&endpoint.Endpoint{Name: "frontend", Group: "core"},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&endpoint.Result{},
+				scenario.Resolved,
+				scenario.Target,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getTargetsForGroup(t *testing.T) {
+	provider := AlertProvider{
+		Topic:     "gatus",
+		Tokens:    []string{"token-1"},
+		Overrides: []Override{{Group: "core", Tokens: []string{"token-2", "token-3"}}},
+	}
+	if topic, tokens := provider.getTargetsForGroup("other"); topic != "gatus" || len(tokens) != 1 || tokens[0] != "token-1" {
+		t.Errorf("expected the default targets, got topic=%s and tokens=%v", topic, tokens)
+	}
+	if topic, tokens := provider.getTargetsForGroup("core"); topic != "" || len(tokens) != 2 {
+		t.Errorf("expected the targets of the override, got topic=%s and tokens=%v", topic, tokens)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
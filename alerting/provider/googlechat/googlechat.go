@@ -116,7 +116,7 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	var message, color string
 	if resolved {
 		color = "#36A64F"
-		message = fmt.Sprintf("<font color='%s'>An alert has been resolved after passing successfully %d time(s) in a row</font>", color, alert.SuccessThreshold)
+		message = fmt.Sprintf("<font color='%s'>An alert has been resolved after passing successfully %d time(s) in a row%s</font>", color, alert.SuccessThreshold, ep.ResolvedDowntimeSuffix(result))
 	} else {
 		color = "#DD0000"
 		message = fmt.Sprintf("<font color='%s'>An alert has been triggered due to having failed %d time(s) in a row</font>", color, alert.FailureThreshold)
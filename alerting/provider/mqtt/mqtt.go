@@ -0,0 +1,227 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	DefaultClientID                     = "gatus"
+	DefaultTopicPrefix                  = "gatus"
+	DefaultHomeAssistantDiscoveryPrefix = "homeassistant"
+
+	StateTriggered = "triggered"
+	StateResolved  = "resolved"
+
+	// disconnectQuiesce is the number of milliseconds to wait for pending work to complete when disconnecting
+	disconnectQuiesce = 250
+)
+
+var ErrTimeout = errors.New("timed out waiting for the MQTT broker")
+
+// AlertProvider is the configuration necessary for publishing the state of endpoints to an MQTT broker
+type AlertProvider struct {
+	// BrokerURL is the URL of the MQTT broker (e.g. tcp://mqtt.example.org:1883, ssl://mqtt.example.org:8883)
+	BrokerURL string `yaml:"broker-url"`
+
+	// Username used to authenticate with the broker (optional)
+	Username string `yaml:"username,omitempty"`
+
+	// Password used to authenticate with the broker (optional)
+	Password string `yaml:"password,omitempty"`
+
+	// ClientID is the identifier of the client when connecting to the broker. Defaults to DefaultClientID
+	ClientID string `yaml:"client-id,omitempty"`
+
+	// TopicPrefix is the prefix of the topics the state of endpoints is published to. Defaults to DefaultTopicPrefix
+	//
+	// The state of an endpoint is published to {topic-prefix}/{endpoint key}/state
+	TopicPrefix string `yaml:"topic-prefix,omitempty"`
+
+	// QoS is the quality of service level used to publish messages (0, 1 or 2)
+	QoS byte `yaml:"qos,omitempty"`
+
+	// HomeAssistant is the configuration for Home Assistant MQTT discovery
+	HomeAssistant *HomeAssistantConfig `yaml:"home-assistant,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the provider's target
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+}
+
+// HomeAssistantConfig is the configuration for Home Assistant MQTT discovery
+type HomeAssistantConfig struct {
+	// Enabled is whether to publish discovery payloads so that each endpoint shows up as a binary sensor
+	Enabled bool `yaml:"enabled"`
+
+	// DiscoveryPrefix is the discovery prefix configured in Home Assistant. Defaults to DefaultHomeAssistantDiscoveryPrefix
+	DiscoveryPrefix string `yaml:"discovery-prefix,omitempty"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.ClientConfig == nil {
+		provider.ClientConfig = client.GetDefaultConfig()
+	}
+	if len(provider.ClientID) == 0 {
+		provider.ClientID = DefaultClientID
+	}
+	if len(provider.TopicPrefix) == 0 {
+		provider.TopicPrefix = DefaultTopicPrefix
+	}
+	if provider.HomeAssistant != nil && len(provider.HomeAssistant.DiscoveryPrefix) == 0 {
+		provider.HomeAssistant.DiscoveryPrefix = DefaultHomeAssistantDiscoveryPrefix
+	}
+	if len(provider.BrokerURL) == 0 || provider.QoS > 2 {
+		return false
+	}
+	brokerURL, err := url.Parse(provider.BrokerURL)
+	return err == nil && len(brokerURL.Scheme) > 0 && len(brokerURL.Host) > 0
+}
+
+// Send publishes the state of the endpoint to the broker
+func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
+	clientConfig := provider.ClientConfig
+	if clientConfig == nil {
+		clientConfig = client.GetDefaultConfig()
+	}
+	timeout := clientConfig.Timeout
+	options := paho.NewClientOptions().
+		AddBroker(provider.BrokerURL).
+		SetClientID(provider.ClientID).
+		SetUsername(provider.Username).
+		SetPassword(provider.Password).
+		SetConnectTimeout(timeout).
+		SetAutoReconnect(false).
+		SetTLSConfig(&tls.Config{InsecureSkipVerify: clientConfig.Insecure})
+	mqttClient := paho.NewClient(options)
+	if err := wait(mqttClient.Connect(), timeout); err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	defer mqttClient.Disconnect(disconnectQuiesce)
+	if provider.HomeAssistant != nil && provider.HomeAssistant.Enabled {
+		// Discovery payloads are retained, so publishing them again is harmless and ensures that endpoints added since
+		// the last alert show up in Home Assistant
+		if err := wait(mqttClient.Publish(provider.discoveryTopic(ep), provider.QoS, true, provider.buildDiscoveryPayload(ep)), timeout); err != nil {
+			return fmt.Errorf("failed to publish Home Assistant discovery payload: %w", err)
+		}
+	}
+	if err := wait(mqttClient.Publish(provider.stateTopic(ep), provider.QoS, true, provider.buildStatePayload(ep, alert, result, resolved)), timeout); err != nil {
+		return fmt.Errorf("failed to publish state: %w", err)
+	}
+	return nil
+}
+
+func wait(token paho.Token, timeout time.Duration) error {
+	if !token.WaitTimeout(timeout) {
+		return ErrTimeout
+	}
+	return token.Error()
+}
+
+type StatePayload struct {
+	State       string             `json:"state"`
+	Endpoint    string             `json:"endpoint"`
+	Group       string             `json:"group,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Conditions  []ConditionPayload `json:"conditions,omitempty"`
+	Errors      []string           `json:"errors,omitempty"`
+	Timestamp   time.Time          `json:"timestamp"`
+
+	// DownFor is for how long the endpoint was down, e.g. "43 minutes". Only set when the alert is resolved.
+	DownFor string `json:"downFor,omitempty"`
+}
+
+type ConditionPayload struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+}
+
+// buildStatePayload builds the payload published to the state topic of the endpoint
+func (provider *AlertProvider) buildStatePayload(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
+	payload := StatePayload{
+		State:       StateTriggered,
+		Endpoint:    ep.Name,
+		Group:       ep.Group,
+		Description: alert.GetDescription(),
+		Errors:      result.Errors,
+		Timestamp:   result.Timestamp.UTC(),
+	}
+	if resolved {
+		payload.State = StateResolved
+		if downFor := ep.DowntimeBeforeResolution(result); downFor > 0 {
+			payload.DownFor = endpoint.HumanizeDuration(downFor)
+		}
+	}
+	for _, conditionResult := range result.ConditionResults {
+		payload.Conditions = append(payload.Conditions, ConditionPayload{Condition: conditionResult.Condition, Success: conditionResult.Success})
+	}
+	payloadAsJSON, _ := json.Marshal(payload)
+	return payloadAsJSON
+}
+
+type DiscoveryPayload struct {
+	Name                string          `json:"name"`
+	UniqueID            string          `json:"unique_id"`
+	ObjectID            string          `json:"object_id"`
+	DeviceClass         string          `json:"device_class"`
+	StateTopic          string          `json:"state_topic"`
+	ValueTemplate       string          `json:"value_template"`
+	PayloadOn           string          `json:"payload_on"`
+	PayloadOff          string          `json:"payload_off"`
+	JSONAttributesTopic string          `json:"json_attributes_topic"`
+	Device              DiscoveryDevice `json:"device"`
+}
+
+type DiscoveryDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+}
+
+// buildDiscoveryPayload builds the payload that makes Home Assistant create a binary sensor for the endpoint, which
+// is on when an alert is triggered and off once it is resolved
+func (provider *AlertProvider) buildDiscoveryPayload(ep *endpoint.Endpoint) []byte {
+	payload := DiscoveryPayload{
+		Name:                ep.DisplayName(),
+		UniqueID:            provider.ClientID + "_" + ep.Key(),
+		ObjectID:            provider.ClientID + "_" + ep.Key(),
+		DeviceClass:         "problem",
+		StateTopic:          provider.stateTopic(ep),
+		ValueTemplate:       "{{ value_json.state }}",
+		PayloadOn:           StateTriggered,
+		PayloadOff:          StateResolved,
+		JSONAttributesTopic: provider.stateTopic(ep),
+		Device: DiscoveryDevice{
+			Identifiers:  []string{provider.ClientID},
+			Name:         "Gatus",
+			Manufacturer: "Gatus",
+		},
+	}
+	payloadAsJSON, _ := json.Marshal(payload)
+	return payloadAsJSON
+}
+
+func (provider *AlertProvider) stateTopic(ep *endpoint.Endpoint) string {
+	return provider.TopicPrefix + "/" + ep.Key() + "/state"
+}
+
+func (provider *AlertProvider) discoveryTopic(ep *endpoint.Endpoint) string {
+	return provider.HomeAssistant.DiscoveryPrefix + "/binary_sensor/" + provider.ClientID + "/" + ep.Key() + "/config"
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider *AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
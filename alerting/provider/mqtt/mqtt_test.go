@@ -0,0 +1,273 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "no-broker-url",
+			Provider: AlertProvider{},
+			Expected: false,
+		},
+		{
+			Name:     "broker-url-without-scheme",
+			Provider: AlertProvider{BrokerURL: "mqtt.example.org:1883"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-qos",
+			Provider: AlertProvider{BrokerURL: "tcp://mqtt.example.org:1883", QoS: 3},
+			Expected: false,
+		},
+		{
+			Name:     "valid",
+			Provider: AlertProvider{BrokerURL: "tcp://mqtt.example.org:1883"},
+			Expected: true,
+		},
+		{
+			Name:     "valid-with-home-assistant",
+			Provider: AlertProvider{BrokerURL: "ssl://mqtt.example.org:8883", QoS: 1, HomeAssistant: &HomeAssistantConfig{Enabled: true}},
+			Expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %v, got %v", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_IsValidSetsDefaults(t *testing.T) {
+	provider := AlertProvider{BrokerURL: "tcp://mqtt.example.org:1883", HomeAssistant: &HomeAssistantConfig{Enabled: true}}
+	if !provider.IsValid() {
+		t.Fatal("provider should've been valid")
+	}
+	if provider.ClientID != DefaultClientID {
+		t.Errorf("expected client id %s, got %s", DefaultClientID, provider.ClientID)
+	}
+	if provider.TopicPrefix != DefaultTopicPrefix {
+		t.Errorf("expected topic prefix %s, got %s", DefaultTopicPrefix, provider.TopicPrefix)
+	}
+	if provider.HomeAssistant.DiscoveryPrefix != DefaultHomeAssistantDiscoveryPrefix {
+		t.Errorf("expected discovery prefix %s, got %s", DefaultHomeAssistantDiscoveryPrefix, provider.HomeAssistant.DiscoveryPrefix)
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	broker := newTestBroker(t)
+	defer broker.Close()
+	description := "description-1"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Resolved         bool
+		ExpectedMessages []string
+		ExpectedState    string
+	}{
+		{
+			Name:             "triggered",
+			Provider:         AlertProvider{BrokerURL: "tcp://" + broker.Addr(), QoS: 1},
+			Resolved:         false,
+			ExpectedMessages: []string{"gatus/core_api/state"},
+			ExpectedState:    StateTriggered,
+		},
+		{
+			Name:             "resolved-with-home-assistant",
+			Provider:         AlertProvider{BrokerURL: "tcp://" + broker.Addr(), TopicPrefix: "monitoring", HomeAssistant: &HomeAssistantConfig{Enabled: true}},
+			Resolved:         true,
+			ExpectedMessages: []string{"homeassistant/binary_sensor/gatus/core_api/config", "monitoring/core_api/state"},
+			ExpectedState:    StateResolved,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			broker.Reset()
+			if !scenario.Provider.IsValid() {
+				t.Fatal("provider should've been valid")
+			}
+			err := scenario.Provider.Send(
+				&endpoint.Endpoint{Name: "api", Group: "core"},
+				&alert.Alert{Description: &description},
+				&endpoint.Result{ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: scenario.Resolved}}},
+				scenario.Resolved,
+			)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			messages := broker.WaitForMessages(len(scenario.ExpectedMessages))
+			if len(messages) != len(scenario.ExpectedMessages) {
+				t.Fatalf("expected %d messages, got %d", len(scenario.ExpectedMessages), len(messages))
+			}
+			for i, message := range messages {
+				if message.topic != scenario.ExpectedMessages[i] {
+					t.Errorf("expected message %d to be published to %s, got %s", i, scenario.ExpectedMessages[i], message.topic)
+				}
+				if !message.retained {
+					t.Errorf("expected message %d to be retained", i)
+				}
+			}
+			var state StatePayload
+			if err = json.Unmarshal(messages[len(messages)-1].payload, &state); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if state.State != scenario.ExpectedState {
+				t.Errorf("expected state %s, got %s", scenario.ExpectedState, state.State)
+			}
+			if state.Endpoint != "api" || state.Group != "core" || state.Description != description || len(state.Conditions) != 1 {
+				t.Errorf("unexpected state payload %+v", state)
+			}
+			if scenario.Provider.HomeAssistant != nil {
+				var discovery DiscoveryPayload
+				if err = json.Unmarshal(messages[0].payload, &discovery); err != nil {
+					t.Fatal("expected no error, got", err.Error())
+				}
+				if discovery.StateTopic != scenario.ExpectedMessages[1] || discovery.PayloadOn != StateTriggered || discovery.PayloadOff != StateResolved {
+					t.Errorf("unexpected discovery payload %+v", discovery)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertProvider_SendWithUnreachableBroker(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close()
+	provider := AlertProvider{BrokerURL: "tcp://" + address, ClientConfig: &client.Config{Timeout: time.Second}}
+	if !provider.IsValid() {
+		t.Fatal("provider should've been valid")
+	}
+	if err := provider.Send(&endpoint.Endpoint{Name: "api"}, &alert.Alert{}, &endpoint.Result{}, false); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (&AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (&AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
+
+type testMessage struct {
+	topic    string
+	payload  []byte
+	retained bool
+}
+
+// testBroker is a minimal MQTT 3.1.1 broker which accepts every connection and records the messages published
+type testBroker struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	messages []testMessage
+}
+
+func newTestBroker(t *testing.T) *testBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	broker := &testBroker{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go broker.handle(conn)
+		}
+	}()
+	return broker
+}
+
+func (b *testBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		header, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		length, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return
+		}
+		packet := make([]byte, length)
+		if _, err = io.ReadFull(reader, packet); err != nil {
+			return
+		}
+		switch header >> 4 {
+		case 1: // CONNECT
+			_, _ = conn.Write([]byte{0x20, 0x02, 0x00, 0x00})
+		case 3: // PUBLISH
+			qos := (header >> 1) & 0x03
+			topicLength := int(binary.BigEndian.Uint16(packet))
+			message := testMessage{topic: string(packet[2 : 2+topicLength]), retained: header&0x01 == 1}
+			payloadStart := 2 + topicLength
+			if qos > 0 {
+				_, _ = conn.Write([]byte{0x40, 0x02, packet[payloadStart], packet[payloadStart+1]})
+				payloadStart += 2
+			}
+			message.payload = packet[payloadStart:]
+			b.mutex.Lock()
+			b.messages = append(b.messages, message)
+			b.mutex.Unlock()
+		case 12: // PINGREQ
+			_, _ = conn.Write([]byte{0xD0, 0x00})
+		case 14: // DISCONNECT
+			return
+		}
+	}
+}
+
+func (b *testBroker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *testBroker) Messages() []testMessage {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]testMessage(nil), b.messages...)
+}
+
+// WaitForMessages waits until the number of messages passed has been received, which is necessary because QoS 0
+// messages are not acknowledged
+func (b *testBroker) WaitForMessages(n int) []testMessage {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if messages := b.Messages(); len(messages) >= n {
+			return messages
+		}
+	}
+	return b.Messages()
+}
+
+func (b *testBroker) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.messages = nil
+}
+
+func (b *testBroker) Close() {
+	_ = b.listener.Close()
+}
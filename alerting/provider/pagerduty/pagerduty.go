@@ -104,7 +104,7 @@ type Payload struct {
 func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
 	var message, eventAction, resolveKey string
 	if resolved {
-		message = fmt.Sprintf("RESOLVED: %s - %s", ep.DisplayName(), alert.GetDescription())
+		message = fmt.Sprintf("RESOLVED: %s - %s%s", ep.DisplayName(), alert.GetDescription(), ep.ResolvedDowntimeSuffix(result))
 		eventAction = "resolve"
 		resolveKey = alert.ResolveKey
 	} else {
@@ -110,6 +110,23 @@ func TestParseWithDefaultAlert(t *testing.T) {
 				SuccessThreshold: 10,
 			},
 		},
+		{
+			Name: "endpoint-alert-inherits-default-alert-trigger-if",
+			DefaultAlert: &alert.Alert{
+				FailureThreshold: 5,
+				SuccessThreshold: 10,
+				TriggerIf:        "last-success-older-than 15m",
+			},
+			EndpointAlert: &alert.Alert{
+				Type: alert.TypeDiscord,
+			},
+			ExpectedOutputAlert: &alert.Alert{
+				Type:             alert.TypeDiscord,
+				FailureThreshold: 5,
+				SuccessThreshold: 10,
+				TriggerIf:        "last-success-older-than 15m",
+			},
+		},
 		{
 			Name: "no-default-alert",
 			DefaultAlert: &alert.Alert{
@@ -148,6 +165,9 @@ func TestParseWithDefaultAlert(t *testing.T) {
 			if scenario.EndpointAlert.SuccessThreshold != scenario.ExpectedOutputAlert.SuccessThreshold {
 				t.Errorf("expected EndpointAlert.SuccessThreshold to be %v, got %v", scenario.ExpectedOutputAlert.SuccessThreshold, scenario.EndpointAlert.SuccessThreshold)
 			}
+			if scenario.EndpointAlert.TriggerIf != scenario.ExpectedOutputAlert.TriggerIf {
+				t.Errorf("expected EndpointAlert.TriggerIf to be %v, got %v", scenario.ExpectedOutputAlert.TriggerIf, scenario.EndpointAlert.TriggerIf)
+			}
 		})
 	}
 }
@@ -84,7 +84,7 @@ type Body struct {
 func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
 	var message string
 	if resolved {
-		message = fmt.Sprintf("RESOLVED: %s - %s", ep.DisplayName(), alert.GetDescription())
+		message = fmt.Sprintf("RESOLVED: %s - %s%s", ep.DisplayName(), alert.GetDescription(), ep.ResolvedDowntimeSuffix(result))
 	} else {
 		message = fmt.Sprintf("TRIGGERED: %s - %s", ep.DisplayName(), alert.GetDescription())
 	}
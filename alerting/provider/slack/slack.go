@@ -67,11 +67,12 @@ type Body struct {
 }
 
 type Attachment struct {
-	Title  string  `json:"title"`
-	Text   string  `json:"text"`
-	Short  bool    `json:"short"`
-	Color  string  `json:"color"`
-	Fields []Field `json:"fields,omitempty"`
+	Title     string  `json:"title"`
+	TitleLink string  `json:"title_link,omitempty"`
+	Text      string  `json:"text"`
+	Short     bool    `json:"short"`
+	Color     string  `json:"color"`
+	Fields    []Field `json:"fields,omitempty"`
 }
 
 type Field struct {
@@ -84,7 +85,7 @@ type Field struct {
 func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
 	var message, color string
 	if resolved {
-		message = fmt.Sprintf("An alert for *%s* has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		message = fmt.Sprintf("An alert for *%s* has been resolved after passing successfully %d time(s) in a row%s", ep.DisplayName(), alert.SuccessThreshold, ep.ResolvedDowntimeSuffix(result))
 		color = "#36A64F"
 	} else {
 		message = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
@@ -108,10 +109,11 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 		Text: "",
 		Attachments: []Attachment{
 			{
-				Title: ":helmet_with_white_cross: Gatus",
-				Text:  message + description,
-				Short: false,
-				Color: color,
+				Title:     ":helmet_with_white_cross: Gatus",
+				TitleLink: ep.PageURL,
+				Text:      message + description,
+				Short:     false,
+				Color:     color,
 			},
 		},
 	}
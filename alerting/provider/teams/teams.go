@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -24,6 +27,39 @@ type AlertProvider struct {
 
 	// Title is the title of the message that will be sent
 	Title string `yaml:"title,omitempty"`
+
+	// Digest is the configuration for batching non-critical alerts into a periodic summary message.
+	// If nil, every alert is sent immediately.
+	Digest *DigestConfig `yaml:"digest,omitempty"`
+}
+
+// DefaultDigestInterval is the default interval at which a digest is sent
+const DefaultDigestInterval = 30 * time.Minute
+
+// DigestConfig is the configuration for batching alerts that aren't critical into a summary message sent
+// periodically. Critical alerts are still sent immediately.
+type DigestConfig struct {
+	// Interval is the duration during which non-critical alerts are accumulated before being sent as a single message
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	mutex   sync.Mutex
+	pending map[string][]digestEntry // entries waiting to be sent, by webhook URL
+}
+
+// digestEntry is an alert waiting to be sent as part of a digest
+type digestEntry struct {
+	endpointName string
+	description  string
+	severity     alert.Severity
+	resolved     bool
+	timestamp    time.Time
+}
+
+func (digest *DigestConfig) getInterval() time.Duration {
+	if digest.Interval <= 0 {
+		return DefaultDigestInterval
+	}
+	return digest.Interval
 }
 
 // Override is a case under which the default integration is overridden
@@ -43,13 +79,73 @@ func (provider *AlertProvider) IsValid() bool {
 			registeredGroups[override.Group] = true
 		}
 	}
+	if provider.Digest != nil && provider.Digest.Interval < 0 {
+		return false
+	}
 	return len(provider.WebhookURL) > 0
 }
 
 // Send an alert using the provider
+//
+// If a digest is configured and the alert isn't critical, the alert is added to the next digest instead
 func (provider *AlertProvider) Send(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) error {
-	buffer := bytes.NewBuffer(provider.buildRequestBody(ep, alert, result, resolved))
-	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(ep.Group), buffer)
+	webhookURL := provider.getWebhookURLForGroup(ep.Group)
+	if provider.Digest != nil && !alert.IsCritical() {
+		provider.addToDigest(webhookURL, digestEntry{
+			endpointName: ep.DisplayName(),
+			description:  alert.GetDescription(),
+			severity:     alert.Severity,
+			resolved:     resolved,
+			timestamp:    result.Timestamp,
+		})
+		return nil
+	}
+	return provider.post(webhookURL, provider.buildRequestBody(ep, alert, result, resolved))
+}
+
+// addToDigest adds an entry to the digest of the given webhook URL, and schedules the digest to be sent if it's the
+// first entry since the last digest was sent
+func (provider *AlertProvider) addToDigest(webhookURL string, entry digestEntry) {
+	provider.Digest.mutex.Lock()
+	defer provider.Digest.mutex.Unlock()
+	if provider.Digest.pending == nil {
+		provider.Digest.pending = make(map[string][]digestEntry)
+	}
+	if len(provider.Digest.pending[webhookURL]) == 0 {
+		time.AfterFunc(provider.Digest.getInterval(), func() {
+			provider.sendDigest(webhookURL)
+		})
+	}
+	provider.Digest.pending[webhookURL] = append(provider.Digest.pending[webhookURL], entry)
+}
+
+// sendDigest sends every pending entry for the given webhook URL as a single message
+//
+// If the message cannot be sent, the entries are kept for the next digest
+func (provider *AlertProvider) sendDigest(webhookURL string) {
+	provider.Digest.mutex.Lock()
+	entries := provider.Digest.pending[webhookURL]
+	delete(provider.Digest.pending, webhookURL)
+	provider.Digest.mutex.Unlock()
+	if len(entries) == 0 {
+		return
+	}
+	if err := provider.post(webhookURL, provider.buildDigestRequestBody(entries)); err != nil {
+		log.Printf("[teams.sendDigest] Failed to send digest of %d alert(s), retrying in %s: %s", len(entries), provider.Digest.getInterval(), err.Error())
+		provider.Digest.mutex.Lock()
+		if len(provider.Digest.pending[webhookURL]) == 0 {
+			time.AfterFunc(provider.Digest.getInterval(), func() {
+				provider.sendDigest(webhookURL)
+			})
+		}
+		provider.Digest.pending[webhookURL] = append(entries, provider.Digest.pending[webhookURL]...)
+		provider.Digest.mutex.Unlock()
+	}
+}
+
+// post sends a request body to the given webhook URL
+func (provider *AlertProvider) post(webhookURL string, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -84,7 +180,7 @@ type Section struct {
 func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *alert.Alert, result *endpoint.Result, resolved bool) []byte {
 	var message, color string
 	if resolved {
-		message = fmt.Sprintf("An alert for *%s* has been resolved after passing successfully %d time(s) in a row", ep.DisplayName(), alert.SuccessThreshold)
+		message = fmt.Sprintf("An alert for *%s* has been resolved after passing successfully %d time(s) in a row%s", ep.DisplayName(), alert.SuccessThreshold, ep.ResolvedDowntimeSuffix(result))
 		color = "#36A64F"
 	} else {
 		message = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", ep.DisplayName(), alert.FailureThreshold)
@@ -124,6 +220,38 @@ func (provider *AlertProvider) buildRequestBody(ep *endpoint.Endpoint, alert *al
 	return bodyAsJSON
 }
 
+// buildDigestRequestBody builds the request body of a digest message summarizing the entries passed
+func (provider *AlertProvider) buildDigestRequestBody(entries []digestEntry) []byte {
+	body := Body{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		ThemeColor: "#FFA500",
+		Title:      provider.Title,
+		Text:       fmt.Sprintf("%d non-critical alert(s) over the last %s", len(entries), provider.Digest.getInterval()),
+	}
+	if len(body.Title) == 0 {
+		body.Title = "&#x1F4CB; Gatus digest"
+	}
+	for _, entry := range entries {
+		var state string
+		if entry.resolved {
+			state = "&#x2705; Resolved"
+		} else {
+			state = "&#x26A0;&#xFE0F; Triggered"
+		}
+		text := fmt.Sprintf("%s at %s (severity: %s)", state, entry.timestamp.UTC().Format(time.RFC3339), entry.severity)
+		if len(entry.description) > 0 {
+			text += "<br/>" + entry.description
+		}
+		body.Sections = append(body.Sections, Section{
+			ActivityTitle: entry.endpointName,
+			Text:          text,
+		})
+	}
+	bodyAsJSON, _ := json.Marshal(body)
+	return bodyAsJSON
+}
+
 // getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
 func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
 	if provider.Overrides != nil {
@@ -2,8 +2,11 @@ package teams
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -136,6 +139,64 @@ func TestAlertProvider_Send(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_SendWithDigest(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	requestBodies := make(chan string, 10)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		body, _ := io.ReadAll(r.Body)
+		requestBodies <- string(body)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})})
+	provider := AlertProvider{WebhookURL: "http://example.com", Digest: &DigestConfig{Interval: 50 * time.Millisecond}}
+	if !provider.IsValid() {
+		t.Fatal("provider should've been valid")
+	}
+	description := "disk usage above 80%"
+	warningAlert := &alert.Alert{Description: &description, Severity: alert.SeverityWarning, FailureThreshold: 3, SuccessThreshold: 2}
+	criticalAlert := &alert.Alert{Severity: alert.SeverityCritical, FailureThreshold: 3, SuccessThreshold: 2}
+	timestamp := time.Date(2024, 3, 12, 2, 30, 0, 0, time.UTC)
+	if err := provider.Send(&endpoint.Endpoint{Name: "disk", Group: "infra"}, warningAlert, &endpoint.Result{Timestamp: timestamp}, false); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := provider.Send(&endpoint.Endpoint{Name: "memory", Group: "infra"}, warningAlert, &endpoint.Result{Timestamp: timestamp}, true); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err := provider.Send(&endpoint.Endpoint{Name: "api"}, criticalAlert, &endpoint.Result{Timestamp: timestamp}, false); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	select {
+	case body := <-requestBodies:
+		if !strings.Contains(body, "An alert for *api* has been triggered") {
+			t.Errorf("expected the critical alert to be sent immediately, got %s", body)
+		}
+	default:
+		t.Fatal("expected the critical alert to be sent immediately")
+	}
+	select {
+	case body := <-requestBodies:
+		expectedBody := `{"@type":"MessageCard","@context":"http://schema.org/extensions","themeColor":"#FFA500","title":"\u0026#x1F4CB; Gatus digest","text":"2 non-critical alert(s) over the last 50ms","sections":[{"activityTitle":"infra/disk","text":"\u0026#x26A0;\u0026#xFE0F; Triggered at 2024-03-12T02:30:00Z (severity: warning)\u003cbr/\u003edisk usage above 80%"},{"activityTitle":"infra/memory","text":"\u0026#x2705; Resolved at 2024-03-12T02:30:00Z (severity: warning)\u003cbr/\u003edisk usage above 80%"}]}`
+		if body != expectedBody {
+			t.Errorf("expected:\n%s\ngot:\n%s", expectedBody, body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the digest to be sent")
+	}
+	select {
+	case body := <-requestBodies:
+		t.Errorf("expected no other request, got %s", body)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAlertProvider_IsValidWithDigest(t *testing.T) {
+	if !(&AlertProvider{WebhookURL: "http://example.com", Digest: &DigestConfig{}}).IsValid() {
+		t.Error("provider with digest using the default interval should've been valid")
+	}
+	if (&AlertProvider{WebhookURL: "http://example.com", Digest: &DigestConfig{Interval: -time.Minute}}).IsValid() {
+		t.Error("provider with digest with a negative interval shouldn't have been valid")
+	}
+}
+
 func TestAlertProvider_buildRequestBody(t *testing.T) {
 	firstDescription := "description-1"
 	secondDescription := "description-2"
@@ -0,0 +1,107 @@
+package silence
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidDuration is the error returned when the duration of a silence isn't positive
+	ErrInvalidDuration = errors.New("duration of silence must be positive")
+
+	silences      = make(map[string]*Silence)
+	silencesMutex sync.RWMutex
+)
+
+// Silence prevents the alerts of an endpoint from being sent until it expires, which allows acknowledging an ongoing
+// incident without being notified about it again and again.
+//
+// While an endpoint is silenced, it keeps being monitored and its results keep being stored, but its alerts are
+// neither triggered nor sent. Alerts that were triggered before the silence are still resolved, but the resolution
+// isn't sent. If the endpoint is still unhealthy once the silence expires, its alerts are triggered as usual.
+type Silence struct {
+	// EndpointKey is the key of the silenced endpoint
+	EndpointKey string
+
+	// Reason for silencing the endpoint (optional)
+	Reason string
+
+	// CreatedAt is the time at which the silence was created
+	CreatedAt time.Time
+
+	// ExpiresAt is the time at which the silence expires
+	ExpiresAt time.Time
+}
+
+// NewSilence creates a silence for the endpoint with the key passed, which expires after the duration passed
+func NewSilence(endpointKey string, duration time.Duration, reason string) (*Silence, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	now := time.Now()
+	return &Silence{EndpointKey: endpointKey, Reason: reason, CreatedAt: now, ExpiresAt: now.Add(duration)}, nil
+}
+
+// IsActive returns whether the silence hasn't expired yet
+func (s *Silence) IsActive() bool {
+	return time.Now().Before(s.ExpiresAt)
+}
+
+// Set silences the alerts of an endpoint, replacing the silence of that endpoint if there's already one
+func Set(s *Silence) {
+	silencesMutex.Lock()
+	defer silencesMutex.Unlock()
+	silences[s.EndpointKey] = s
+}
+
+// Delete removes the silence of the endpoint with the key passed, and returns whether the endpoint was silenced
+func Delete(endpointKey string) bool {
+	silencesMutex.Lock()
+	defer silencesMutex.Unlock()
+	s, exists := silences[endpointKey]
+	delete(silences, endpointKey)
+	return exists && s.IsActive()
+}
+
+// Get returns the silence of the endpoint with the key passed, or nil if the endpoint isn't silenced
+func Get(endpointKey string) *Silence {
+	silencesMutex.RLock()
+	defer silencesMutex.RUnlock()
+	if s, exists := silences[endpointKey]; exists && s.IsActive() {
+		return s
+	}
+	return nil
+}
+
+// GetAll returns every silence that hasn't expired yet, ordered by endpoint key.
+// Expired silences are removed in the process.
+func GetAll() []*Silence {
+	silencesMutex.Lock()
+	defer silencesMutex.Unlock()
+	activeSilences := make([]*Silence, 0, len(silences))
+	for endpointKey, s := range silences {
+		if !s.IsActive() {
+			delete(silences, endpointKey)
+			continue
+		}
+		activeSilences = append(activeSilences, s)
+	}
+	sort.Slice(activeSilences, func(i, j int) bool {
+		return activeSilences[i].EndpointKey < activeSilences[j].EndpointKey
+	})
+	return activeSilences
+}
+
+// IsSilenced returns whether the alerts of the endpoint with the key passed are silenced
+func IsSilenced(endpointKey string) bool {
+	return Get(endpointKey) != nil
+}
+
+// Clear removes every silence
+func Clear() {
+	silencesMutex.Lock()
+	defer silencesMutex.Unlock()
+	silences = make(map[string]*Silence)
+}
@@ -0,0 +1,42 @@
+package silence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSilence(t *testing.T) {
+	if _, err := NewSilence("core_frontend", 0, ""); !errors.Is(err, ErrInvalidDuration) {
+		t.Errorf("expected error %v, got %v", ErrInvalidDuration, err)
+	}
+	s, err := NewSilence("core_frontend", time.Hour, "investigating")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !s.IsActive() || s.ExpiresAt.Sub(s.CreatedAt) != time.Hour || s.Reason != "investigating" {
+		t.Errorf("unexpected silence %+v", s)
+	}
+}
+
+func TestSetGetAndDelete(t *testing.T) {
+	defer Clear()
+	active, _ := NewSilence("core_frontend", time.Hour, "")
+	Set(active)
+	Set(&Silence{EndpointKey: "core_backend", ExpiresAt: time.Now().Add(-time.Minute)})
+	if !IsSilenced("core_frontend") {
+		t.Error("expected core_frontend to be silenced")
+	}
+	if IsSilenced("core_backend") {
+		t.Error("expected the expired silence of core_backend to be ignored")
+	}
+	if all := GetAll(); len(all) != 1 || all[0] != active {
+		t.Errorf("expected only the active silence to be returned, got %v", all)
+	}
+	if !Delete("core_frontend") {
+		t.Error("expected core_frontend to have been silenced")
+	}
+	if Delete("core_frontend") || IsSilenced("core_frontend") {
+		t.Error("expected core_frontend to no longer be silenced")
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+var alertDeliveryIDPathParameter = &openAPIParameter{Name: "id", In: "path", Required: true, Description: "ID of the alert delivery", Schema: &openAPISchema{Type: "integer", Format: "int64"}}
+
+// getAlertDeliveriesOperation documents AlertDeliveries
+var getAlertDeliveriesOperation = &openAPIOperation{
+	OperationID: "getAlertDeliveries",
+	Summary:     "Retrieve the alerts waiting to be delivered, including the ones that have been dead-lettered",
+	Tags:        []string{"alerting"},
+	Parameters: []*openAPIParameter{
+		{Name: "dead-lettered", In: "query", Description: "If true, only the dead-lettered alerts are returned. If false, only the alerts still being retried are returned", Schema: &openAPISchema{Type: "boolean"}},
+	},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Alert deliveries"}, "400": badRequestResponse, "401": unauthorizedResponse, "500": internalErrorResponse},
+	responseType: []*delivery.Delivery{},
+}
+
+// AlertDeliveries handles requests to retrieve the alerts queued for delivery
+func AlertDeliveries(c *fiber.Ctx) error {
+	deliveries, err := store.Get().GetAlertDeliveries()
+	if err != nil {
+		log.Printf("[api.AlertDeliveries] Failed to retrieve alert deliveries: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	if deadLetteredParameter := c.Query("dead-lettered"); len(deadLetteredParameter) > 0 {
+		deadLettered, err := strconv.ParseBool(deadLetteredParameter)
+		if err != nil {
+			return c.Status(400).SendString("invalid dead-lettered parameter")
+		}
+		filteredDeliveries := make([]*delivery.Delivery, 0, len(deliveries))
+		for _, d := range deliveries {
+			if d.DeadLettered == deadLettered {
+				filteredDeliveries = append(filteredDeliveries, d)
+			}
+		}
+		deliveries = filteredDeliveries
+	}
+	return c.Status(200).JSON(deliveries)
+}
+
+// retryAlertDeliveryOperation documents RetryAlertDelivery
+var retryAlertDeliveryOperation = &openAPIOperation{
+	OperationID: "retryAlertDelivery",
+	Summary:     "Attempt to deliver an alert again, such as one that has been dead-lettered",
+	Tags:        []string{"alerting"},
+	Parameters:  []*openAPIParameter{alertDeliveryIDPathParameter},
+	Responses: map[string]*openAPIResponse{
+		"200": {Description: "Alert delivery queued"},
+		"400": badRequestResponse,
+		"401": unauthorizedResponse,
+		"404": {Description: "Alert delivery not found"},
+		"500": internalErrorResponse,
+	},
+	responseType: delivery.Delivery{},
+}
+
+// RetryAlertDelivery handles requests to attempt to deliver an alert again, resetting its number of attempts
+func RetryAlertDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).SendString("invalid id")
+	}
+	d, err := watchdog.RequeueAlertDelivery(id)
+	if err != nil {
+		if errors.Is(err, common.ErrAlertDeliveryNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.RetryAlertDelivery] Failed to requeue alert delivery with id=%d: %s", id, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	log.Printf("[api.RetryAlertDelivery] Requeued %s alert delivery with id=%d for endpoint with key=%s", d.AlertType, d.ID, d.EndpointKey)
+	return c.Status(200).JSON(d)
+}
+
+// deleteAlertDeliveryOperation documents DeleteAlertDelivery
+var deleteAlertDeliveryOperation = &openAPIOperation{
+	OperationID: "deleteAlertDelivery",
+	Summary:     "Discard an alert waiting to be delivered, such as one that has been dead-lettered",
+	Tags:        []string{"alerting"},
+	Parameters:  []*openAPIParameter{alertDeliveryIDPathParameter},
+	Responses: map[string]*openAPIResponse{
+		"204": {Description: "Alert delivery discarded"},
+		"400": badRequestResponse,
+		"401": unauthorizedResponse,
+		"404": {Description: "Alert delivery not found"},
+		"500": internalErrorResponse,
+	},
+}
+
+// DeleteAlertDelivery handles requests to discard an alert waiting to be delivered
+func DeleteAlertDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).SendString("invalid id")
+	}
+	if err = store.Get().DeleteAlertDelivery(id); err != nil {
+		if errors.Is(err, common.ErrAlertDeliveryNotFound) {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api.DeleteAlertDelivery] Failed to delete alert delivery with id=%d: %s", id, err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	log.Printf("[api.DeleteAlertDelivery] Deleted alert delivery with id=%d", id)
+	return c.SendStatus(204)
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestAlertDeliveries(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	store.Get().Clear()
+	deliveryConfig := &delivery.Config{MaximumAttempts: 1}
+	_ = deliveryConfig.ValidateAndSetDefaults()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{Delivery: deliveryConfig},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	pending := delivery.NewDelivery(ep, &alert.Alert{Type: alert.TypeSlack}, &endpoint.Result{}, false)
+	deadLettered := delivery.NewDelivery(ep, &alert.Alert{Type: alert.TypePagerDuty}, &endpoint.Result{}, false)
+	deadLettered.RecordFailure(errors.New("provider unavailable"), deliveryConfig)
+	_ = store.Get().InsertAlertDelivery(pending)
+	_ = store.Get().InsertAlertDelivery(deadLettered)
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                string
+		Method              string
+		Path                string
+		Authenticated       bool
+		ExpectedCode        int
+		ExpectedDeliveryIDs []int64
+	}{
+		{
+			Name:         "unauthenticated",
+			Method:       "GET",
+			Path:         "/api/v1/alerting/deliveries",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:                "all",
+			Method:              "GET",
+			Path:                "/api/v1/alerting/deliveries",
+			Authenticated:       true,
+			ExpectedCode:        http.StatusOK,
+			ExpectedDeliveryIDs: []int64{pending.ID, deadLettered.ID},
+		},
+		{
+			Name:                "dead-lettered",
+			Method:              "GET",
+			Path:                "/api/v1/alerting/deliveries?dead-lettered=true",
+			Authenticated:       true,
+			ExpectedCode:        http.StatusOK,
+			ExpectedDeliveryIDs: []int64{deadLettered.ID},
+		},
+		{
+			Name:          "invalid-dead-lettered-parameter",
+			Method:        "GET",
+			Path:          "/api/v1/alerting/deliveries?dead-lettered=maybe",
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "retry-not-found",
+			Method:        "POST",
+			Path:          "/api/v1/alerting/deliveries/999/retry",
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+		{
+			Name:          "retry-invalid-id",
+			Method:        "POST",
+			Path:          "/api/v1/alerting/deliveries/abc/retry",
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "retry",
+			Method:        "POST",
+			Path:          "/api/v1/alerting/deliveries/" + strconv.FormatInt(deadLettered.ID, 10) + "/retry",
+			Authenticated: true,
+			ExpectedCode:  http.StatusOK,
+		},
+		{
+			Name:                "dead-lettered-after-retry",
+			Method:              "GET",
+			Path:                "/api/v1/alerting/deliveries?dead-lettered=true",
+			Authenticated:       true,
+			ExpectedCode:        http.StatusOK,
+			ExpectedDeliveryIDs: []int64{},
+		},
+		{
+			Name:          "delete",
+			Method:        "DELETE",
+			Path:          "/api/v1/alerting/deliveries/" + strconv.FormatInt(pending.ID, 10),
+			Authenticated: true,
+			ExpectedCode:  http.StatusNoContent,
+		},
+		{
+			Name:          "delete-not-found",
+			Method:        "DELETE",
+			Path:          "/api/v1/alerting/deliveries/" + strconv.FormatInt(pending.ID, 10),
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+		{
+			Name:                "all-after-delete",
+			Method:              "GET",
+			Path:                "/api/v1/alerting/deliveries",
+			Authenticated:       true,
+			ExpectedCode:        http.StatusOK,
+			ExpectedDeliveryIDs: []int64{deadLettered.ID},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedDeliveryIDs == nil {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var deliveries []*delivery.Delivery
+			if err := json.Unmarshal(body, &deliveries); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if len(deliveries) != len(scenario.ExpectedDeliveryIDs) {
+				t.Fatalf("expected %d deliveries, got %d", len(scenario.ExpectedDeliveryIDs), len(deliveries))
+			}
+			for i, d := range deliveries {
+				if d.ID != scenario.ExpectedDeliveryIDs[i] {
+					t.Errorf("expected delivery %d to have id=%d, got %d", i, scenario.ExpectedDeliveryIDs[i], d.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertDeliveries_WithoutDeliveryConfig(t *testing.T) {
+	router := New(&config.Config{Alerting: &alerting.Config{}}).Router()
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/alerting/deliveries", http.NoBody))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		t.Error("expected alert deliveries to be unavailable when the delivery queue isn't configured")
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// alertHistoryEntry is an entry of the alert history along with the duration of the alert
+type alertHistoryEntry struct {
+	*history.Entry
+
+	// Duration is for how long the alert was triggered, or has been triggered so far if it hasn't been resolved
+	Duration time.Duration `json:"duration"`
+}
+
+// getAlertHistoryOperation documents AlertHistory
+var getAlertHistoryOperation = &openAPIOperation{
+	OperationID: "getAlertHistory",
+	Summary:     "Retrieve the alerts that were triggered, along with their resolution and a summary of their delivery",
+	Tags:        []string{"alerting"},
+	Parameters: []*openAPIParameter{
+		{Name: "from", In: "query", Description: "Only retrieve alerts that were still triggered at or after this timestamp", Schema: &openAPISchema{Type: "string", Format: "date-time"}},
+		{Name: "to", In: "query", Description: "Only retrieve alerts that were triggered at or before this timestamp", Schema: &openAPISchema{Type: "string", Format: "date-time"}},
+		{Name: "group", In: "query", Description: "Only retrieve the alerts of the endpoints in this group", Schema: &openAPISchema{Type: "string"}},
+	},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Alerts, from the most recently triggered to the least recently triggered"}, "400": badRequestResponse, "401": unauthorizedResponse, "500": internalErrorResponse},
+	responseType: []*alertHistoryEntry{},
+}
+
+// AlertHistory handles requests to retrieve the alerts that were triggered across all endpoints, which is useful for
+// post-incident reviews
+func AlertHistory(c *fiber.Ctx) error {
+	from, to, _, err := extractResultsTimeRangeAndOrderFromRequest(c)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	entries, err := store.Get().GetAlertHistory(from, to)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.AlertHistory] Failed to retrieve alert history: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	group, filterByGroup := c.Queries()["group"]
+	alertHistory := make([]*alertHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(entry.EndpointKey)) > 0 || (filterByGroup && entry.EndpointGroup != group) {
+			continue
+		}
+		alertHistory = append(alertHistory, &alertHistoryEntry{Entry: entry, Duration: entry.Duration()})
+	}
+	return c.Status(200).JSON(alertHistory)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestAlertHistory(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	store.Get().Clear()
+	now := time.Now().Truncate(time.Second)
+	sent := history.DeliverySummary{Status: history.DeliveryStatusSent, Attempts: 1}
+	resolved := history.NewEntry(&endpoint.Endpoint{Name: "frontend", Group: "core"}, &alert.Alert{Type: alert.TypeSlack}, now.Add(-3*time.Hour), sent)
+	resolved.Resolve(now.Add(-2*time.Hour), &sent)
+	unresolved := history.NewEntry(&endpoint.Endpoint{Name: "backend", Group: "data"}, &alert.Alert{Type: alert.TypeSlack}, now.Add(-time.Hour), sent)
+	tenantEntry := history.NewEntry(&endpoint.Endpoint{Name: "frontend", Group: "core", Tenant: "acme"}, &alert.Alert{Type: alert.TypeSlack}, now, sent)
+	for _, entry := range []*history.Entry{resolved, unresolved, tenantEntry} {
+		_ = store.Get().InsertAlertHistoryEntry(entry)
+	}
+	router := New(&config.Config{Alerting: &alerting.Config{}}).Router()
+	scenarios := []struct {
+		Name              string
+		Path              string
+		ExpectedCode      int
+		ExpectedEntryIDs  []int64
+		ExpectedDurations []time.Duration
+	}{
+		{
+			Name:              "all",
+			Path:              "/api/v1/alerts/history",
+			ExpectedCode:      http.StatusOK,
+			ExpectedEntryIDs:  []int64{unresolved.ID, resolved.ID},
+			ExpectedDurations: []time.Duration{0, time.Hour},
+		},
+		{
+			Name:             "group",
+			Path:             "/api/v1/alerts/history?group=core",
+			ExpectedCode:     http.StatusOK,
+			ExpectedEntryIDs: []int64{resolved.ID},
+		},
+		{
+			Name:             "from",
+			Path:             "/api/v1/alerts/history?from=" + now.Add(-90*time.Minute).UTC().Format(time.RFC3339),
+			ExpectedCode:     http.StatusOK,
+			ExpectedEntryIDs: []int64{unresolved.ID},
+		},
+		{
+			Name:             "to",
+			Path:             "/api/v1/alerts/history?to=" + now.Add(-4*time.Hour).UTC().Format(time.RFC3339),
+			ExpectedCode:     http.StatusOK,
+			ExpectedEntryIDs: []int64{},
+		},
+		{
+			Name:         "invalid-from",
+			Path:         "/api/v1/alerts/history?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-time-range",
+			Path:         "/api/v1/alerts/history?from=" + now.UTC().Format(time.RFC3339) + "&to=" + now.Add(-time.Hour).UTC().Format(time.RFC3339),
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedEntryIDs == nil {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var entries []*alertHistoryEntry
+			if err := json.Unmarshal(body, &entries); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if len(entries) != len(scenario.ExpectedEntryIDs) {
+				t.Fatalf("expected %d entries, got %d", len(scenario.ExpectedEntryIDs), len(entries))
+			}
+			for i, entry := range entries {
+				if entry.ID != scenario.ExpectedEntryIDs[i] {
+					t.Errorf("expected entry %d to have id=%d, got %d", i, scenario.ExpectedEntryIDs[i], entry.ID)
+				}
+				// The duration of an alert that is still triggered keeps increasing, so it is only checked if resolved
+				if scenario.ExpectedDurations != nil && entry.ResolvedAt != nil && entry.Duration != scenario.ExpectedDurations[i] {
+					t.Errorf("expected entry %d to have a duration of %s, got %s", i, scenario.ExpectedDurations[i], entry.Duration)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertHistory_WithoutAlertingConfig(t *testing.T) {
+	router := New(&config.Config{}).Router()
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/alerts/history", http.NoBody))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		t.Error("expected the alert history to be unavailable when alerting isn't configured")
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	testAlertEndpointName = "gatus-test-alert"
+	testAlertDescription  = "This is a test alert sent by Gatus to validate the configuration of the alerting provider"
+)
+
+type testAlertingProviderRequest struct {
+	// Group is the group of the synthetic endpoint, which is useful to test group-specific provider overrides
+	Group string `json:"group,omitempty"`
+}
+
+type testAlertingProviderResponse struct {
+	Provider  string                   `json:"provider"`
+	Triggered *alertDeliveryDiagnostic `json:"triggered"`
+	Resolved  *alertDeliveryDiagnostic `json:"resolved"`
+}
+
+type alertDeliveryDiagnostic struct {
+	Sent     bool          `json:"sent"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// testAlertingProviderOperation documents TestAlertingProvider
+var testAlertingProviderOperation = &openAPIOperation{
+	OperationID: "testAlertingProvider",
+	Summary:     "Send a synthetic triggered and resolved alert using the configuration of an alerting provider",
+	Tags:        []string{"alerting"},
+	Parameters: []*openAPIParameter{
+		{Name: "provider", In: "path", Required: true, Description: "Type of the alerting provider (e.g. slack)", Schema: &openAPISchema{Type: "string"}},
+	},
+	Responses: map[string]*openAPIResponse{
+		"200": {Description: "Both alerts were sent"},
+		"401": unauthorizedResponse,
+		"404": {Description: "Alerting provider not configured"},
+		"502": {Description: "At least one of the alerts could not be sent"},
+	},
+	requestBodyType: testAlertingProviderRequest{},
+	responseType:    testAlertingProviderResponse{},
+}
+
+// TestAlertingProvider handles requests to send a synthetic triggered alert followed by its resolution using the
+// configuration of an alerting provider, so that misconfigurations can be caught before an actual outage.
+func TestAlertingProvider(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		alertType := alert.Type(c.Params("provider"))
+		alertProvider := cfg.Alerting.GetAlertingProviderByAlertType(alertType)
+		if alertProvider == nil {
+			return c.Status(404).SendString("alerting provider " + string(alertType) + " is not configured")
+		}
+		var request testAlertingProviderRequest
+		if len(c.Body()) > 0 {
+			if err := json.Unmarshal(c.Body(), &request); err != nil {
+				return c.Status(400).SendString("invalid request body")
+			}
+		}
+		ep := &endpoint.Endpoint{
+			Name:       testAlertEndpointName,
+			Group:      request.Group,
+			URL:        "https://example.org",
+			Conditions: []endpoint.Condition{"[STATUS] == 200"},
+		}
+		description := testAlertDescription
+		sendOnResolved := true
+		testAlert := &alert.Alert{Type: alertType, Description: &description, SendOnResolved: &sendOnResolved, FailureThreshold: 1, SuccessThreshold: 1}
+		ep.Alerts = []*alert.Alert{testAlert}
+		failedResult := &endpoint.Result{
+			HTTPStatus:       500,
+			Success:          false,
+			Timestamp:        time.Now(),
+			Errors:           []string{testAlertDescription},
+			ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] (500) == 200", Success: false}},
+		}
+		response := testAlertingProviderResponse{Provider: string(alertType)}
+		response.Triggered = sendTestAlert(alertProvider.Send, ep, testAlert, failedResult, false)
+		testAlert.Triggered = response.Triggered.Sent
+		successfulResult := &endpoint.Result{
+			HTTPStatus:       200,
+			Success:          true,
+			Timestamp:        time.Now(),
+			ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] (200) == 200", Success: true}},
+		}
+		response.Resolved = sendTestAlert(alertProvider.Send, ep, testAlert, successfulResult, true)
+		testAlert.Triggered = false
+		if !response.Triggered.Sent || !response.Resolved.Sent {
+			log.Printf("[api.TestAlertingProvider] Failed to send test alert using provider=%s: triggered=%+v; resolved=%+v", alertType, response.Triggered, response.Resolved)
+			return c.Status(502).JSON(response)
+		}
+		log.Printf("[api.TestAlertingProvider] Sent test alert using provider=%s", alertType)
+		return c.Status(200).JSON(response)
+	}
+}
+
+func sendTestAlert(send func(*endpoint.Endpoint, *alert.Alert, *endpoint.Result, bool) error, ep *endpoint.Endpoint, testAlert *alert.Alert, result *endpoint.Result, resolved bool) *alertDeliveryDiagnostic {
+	start := time.Now()
+	err := send(ep, testAlert, result, resolved)
+	diagnostic := &alertDeliveryDiagnostic{Sent: err == nil, Duration: time.Since(start)}
+	if err != nil {
+		diagnostic.Error = err.Error()
+	}
+	return diagnostic
+}
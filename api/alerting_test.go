@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+func TestTestAlertingProvider(t *testing.T) {
+	var mutex sync.Mutex
+	var receivedBodies []string
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		receivedBodies = append(receivedBodies, string(body))
+		mutex.Unlock()
+		if strings.Contains(r.URL.Path, "broken") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhookServer.Close()
+	newConfig := func(webhookURL string) *config.Config {
+		return &config.Config{
+			Alerting: &alerting.Config{
+				Custom: &custom.AlertProvider{
+					URL:          webhookURL,
+					Body:         "[ENDPOINT_GROUP]/[ENDPOINT_NAME] [ALERT_TRIGGERED_OR_RESOLVED]",
+					ClientConfig: client.GetDefaultConfig(),
+				},
+			},
+			Security: &security.Config{
+				Basic: &security.BasicConfig{
+					Username:                        "john.doe",
+					PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+				},
+			},
+		}
+	}
+	scenarios := []struct {
+		Name           string
+		Config         *config.Config
+		Path           string
+		Body           string
+		Authenticated  bool
+		ExpectedCode   int
+		ExpectedBodies []string
+	}{
+		{
+			Name:          "unauthenticated",
+			Config:        newConfig(webhookServer.URL),
+			Path:          "/api/v1/alerting/custom/test",
+			Authenticated: false,
+			ExpectedCode:  http.StatusUnauthorized,
+		},
+		{
+			Name:          "provider-not-configured",
+			Config:        newConfig(webhookServer.URL),
+			Path:          "/api/v1/alerting/slack/test",
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+		{
+			Name:          "invalid-body",
+			Config:        newConfig(webhookServer.URL),
+			Path:          "/api/v1/alerting/custom/test",
+			Body:          "invalid",
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:           "sent",
+			Config:         newConfig(webhookServer.URL),
+			Path:           "/api/v1/alerting/custom/test",
+			Body:           `{"group":"core"}`,
+			Authenticated:  true,
+			ExpectedCode:   http.StatusOK,
+			ExpectedBodies: []string{"core/gatus-test-alert TRIGGERED", "core/gatus-test-alert RESOLVED"},
+		},
+		{
+			Name:           "delivery-failure",
+			Config:         newConfig(webhookServer.URL + "/broken"),
+			Path:           "/api/v1/alerting/custom/test",
+			Authenticated:  true,
+			ExpectedCode:   http.StatusBadGateway,
+			ExpectedBodies: []string{"/gatus-test-alert TRIGGERED", "/gatus-test-alert RESOLVED"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			receivedBodies = nil
+			router := New(scenario.Config).Router()
+			request := httptest.NewRequest("POST", scenario.Path, bytes.NewBufferString(scenario.Body))
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			if strings.Join(receivedBodies, "\n") != strings.Join(scenario.ExpectedBodies, "\n") {
+				t.Errorf("expected webhook to receive %v, got %v", scenario.ExpectedBodies, receivedBodies)
+			}
+			if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusBadGateway {
+				return
+			}
+			var diagnostics testAlertingProviderResponse
+			if err = json.NewDecoder(response.Body).Decode(&diagnostics); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if diagnostics.Provider != "custom" {
+				t.Errorf("expected provider custom, got %s", diagnostics.Provider)
+			}
+			expectedSent := response.StatusCode == http.StatusOK
+			if diagnostics.Triggered.Sent != expectedSent || diagnostics.Resolved.Sent != expectedSent {
+				t.Errorf("expected sent to be %v, got triggered=%v and resolved=%v", expectedSent, diagnostics.Triggered.Sent, diagnostics.Resolved.Sent)
+			}
+			if !expectedSent && len(diagnostics.Triggered.Error) == 0 {
+				t.Error("expected triggered error to be set")
+			}
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package api
 
 import (
+	"context"
 	"io/fs"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/web"
+	gatusv1 "github.com/TwiN/gatus/v5/proto/gatus/v1"
 	static "github.com/TwiN/gatus/v5/web"
 	"github.com/TwiN/health"
 	fiber "github.com/gofiber/fiber/v2"
@@ -17,6 +20,7 @@ import (
 	fiberfs "github.com/gofiber/fiber/v2/middleware/filesystem"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/redirect"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -48,7 +52,15 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 		ReadBufferSize: cfg.Web.ReadBufferSize,
 		Network:        fiber.NetworkTCP,
 	})
-	if os.Getenv("ENVIRONMENT") == "dev" {
+	if cfg.Web.CORS != nil {
+		app.Use(cors.New(cors.Config{
+			AllowOrigins:     strings.Join(cfg.Web.CORS.AllowedOrigins, ","),
+			AllowMethods:     strings.Join(cfg.Web.CORS.AllowedMethods, ","),
+			AllowHeaders:     strings.Join(cfg.Web.CORS.AllowedHeaders, ","),
+			AllowCredentials: cfg.Web.CORS.AllowCredentials,
+			MaxAge:           int(cfg.Web.CORS.MaxAge.Seconds()),
+		}))
+	} else if os.Getenv("ENVIRONMENT") == "dev" {
 		app.Use(cors.New(cors.Config{
 			AllowOrigins:     "http://localhost:8081",
 			AllowCredentials: true,
@@ -57,40 +69,88 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	// Middlewares
 	app.Use(recover.New())
 	app.Use(compress.New())
+	if cfg.Web.SecurityHeaders != nil {
+		app.Use(securityHeaders(cfg.Web.SecurityHeaders))
+	}
+	// All routes are defined relative to the base path, if one is configured
+	router := app.Group(cfg.Web.BasePath)
 	// Define metrics handler, if necessary
 	if cfg.Metrics {
 		metricsHandler := promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
 			DisableCompression: true,
 		}))
-		app.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
+		router.Get("/metrics", adaptor.HTTPHandler(metricsHandler))
 	}
 	// Define main router
-	apiRouter := app.Group("/api")
+	apiRouter := router.Group("/api")
+	// API keys are enforced on every API route, since their scopes and rate limits apply regardless of whether the
+	// route requires authentication
+	if cfg.Security != nil {
+		cfg.Security.ApplyAPIKeyMiddleware(apiRouter)
+	}
 	////////////////////////
 	// UNPROTECTED ROUTES //
 	////////////////////////
+	// All API routes are documented in an OpenAPI specification as they are registered
+	spec := newOpenAPISpecification(cfg)
 	unprotectedAPIRouter := apiRouter.Group("/")
-	unprotectedAPIRouter.Get("/v1/config", ConfigHandler{securityConfig: cfg.Security}.GetConfig)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.svg", HealthBadge)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/health/badge.shields", HealthBadgeShields)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/uptimes/:duration/badge.svg", UptimeBadge)
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/badge.svg", ResponseTimeBadge(cfg))
-	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/chart.svg", ResponseTimeChart)
+	documentedUnprotectedAPIRouter := &documentedRouter{router: unprotectedAPIRouter, prefix: "/api", spec: spec}
+	documentedUnprotectedAPIRouter.get("/v1/openapi.json", openAPISpecificationOperation, OpenAPISpecification(spec))
+	documentedUnprotectedAPIRouter.get("/v1/config", getConfigOperation, ConfigHandler{securityConfig: cfg.Security}.GetConfig)
+	documentedUnprotectedAPIRouter.get("/v1/endpoints/:key/health/badge.svg", healthBadgeOperation, HealthBadge)
+	documentedUnprotectedAPIRouter.get("/v1/endpoints/:key/health/badge.shields", healthBadgeShieldsOperation, HealthBadgeShields)
+	documentedUnprotectedAPIRouter.get("/v1/endpoints/:key/uptimes/:duration/badge.svg", uptimeBadgeOperation, UptimeBadge)
+	documentedUnprotectedAPIRouter.get("/v1/endpoints/:key/response-times/:duration/badge.svg", responseTimeBadgeOperation, ResponseTimeBadge(cfg))
+	documentedUnprotectedAPIRouter.get("/v1/endpoints/:key/response-times/:duration/chart.svg", responseTimeChartOperation, ResponseTimeChart)
+	documentedUnprotectedAPIRouter.get("/v1/endpoints/:key/uptime/daily", getDailyUptimeOperation, DailyUptime)
+	documentedUnprotectedAPIRouter.get("/v1/groups/:group/health", groupHealthOperation, GroupHealth(cfg))
 	// This endpoint requires authz with bearer token, so technically it is protected
-	unprotectedAPIRouter.Post("/v1/endpoints/:key/external", CreateExternalEndpointResult(cfg))
+	documentedUnprotectedAPIRouter.post("/v1/endpoints/:key/external", createExternalEndpointResultOperation, CreateExternalEndpointResult(cfg))
+	// The gRPC gateway handles authentication the same way the gRPC server does
+	if cfg.Web.GRPC != nil && cfg.Web.GRPC.Gateway {
+		gatewayMux := runtime.NewServeMux()
+		if err := gatusv1.RegisterGatusServiceHandlerServer(context.Background(), gatewayMux, NewGRPCServer(cfg)); err != nil {
+			panic(err)
+		}
+		unprotectedAPIRouter.All("/gateway/*", adaptor.HTTPHandler(http.StripPrefix(cfg.Web.BasePath+"/api/gateway", gatewayMux)))
+	}
+	// Share links grant read-only access to a subset of groups without authentication
+	hasShareLinks := cfg.Security != nil && cfg.Security.ShareLinks != nil
+	if hasShareLinks {
+		documentedUnprotectedAPIRouter.get("/v1/share/:token/endpoints/statuses", getSharedEndpointStatusesOperation, SharedEndpointStatuses(cfg))
+		documentedUnprotectedAPIRouter.get("/v1/share/:token/endpoints/:key/statuses", getSharedEndpointStatusOperation, SharedEndpointStatus(cfg))
+	}
+	// Tenants authenticate with their own bearer token, so these endpoints don't require the security configuration
+	hasTenants := len(cfg.Tenants) > 0
+	if hasTenants {
+		documentedUnprotectedAPIRouter.get("/v1/tenants/:tenant/endpoints/statuses", getTenantEndpointStatusesOperation, TenantEndpointStatuses(cfg))
+		documentedUnprotectedAPIRouter.get("/v1/tenants/:tenant/endpoints/:key/statuses", getTenantEndpointStatusOperation, TenantEndpointStatus(cfg))
+	}
+	// Routes compatible with healthchecks.io clients, which authenticate using the secret ping UUID of external endpoints
+	documentedPingRouter := &documentedRouter{router: router, prefix: "", spec: spec}
+	documentedPingRouter.get("/ping/:uuid", pingOperation, Ping(cfg))
+	documentedPingRouter.post("/ping/:uuid", pingOperation, Ping(cfg))
+	documentedPingRouter.get("/ping/:uuid/:signal", pingWithSignalOperation, Ping(cfg))
+	documentedPingRouter.post("/ping/:uuid/:signal", pingWithSignalOperation, Ping(cfg))
 	// SPA
-	app.Get("/", SinglePageApplication(cfg.UI))
-	app.Get("/endpoints/:name", SinglePageApplication(cfg.UI))
+	router.Get("/", SinglePageApplication(cfg.UI, cfg.Web.BasePath))
+	if hasShareLinks {
+		router.Get("/share/:token", SinglePageApplication(cfg.UI, cfg.Web.BasePath))
+	}
+	if hasTenants {
+		router.Get("/tenants/:tenant", SinglePageApplication(cfg.UI, cfg.Web.BasePath))
+	}
+	router.Get("/endpoints/:name", SinglePageApplication(cfg.UI, cfg.Web.BasePath))
 	// Health endpoint
 	healthHandler := health.Handler().WithJSON(true)
-	app.Get("/health", func(c *fiber.Ctx) error {
+	router.Get("/health", func(c *fiber.Ctx) error {
 		statusCode, body := healthHandler.GetResponseStatusCodeAndBody()
 		return c.Status(statusCode).Send(body)
 	})
 	// Everything else falls back on static content
-	app.Use(redirect.New(redirect.Config{
+	router.Use(redirect.New(redirect.Config{
 		Rules: map[string]string{
-			"/index.html": "/",
+			cfg.Web.BasePath + "/index.html": cfg.Web.BasePath + "/",
 		},
 		StatusCode: 301,
 	}))
@@ -98,7 +158,7 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	if err != nil {
 		panic(err)
 	}
-	app.Use("/", fiberfs.New(fiberfs.Config{
+	router.Use("/", fiberfs.New(fiberfs.Config{
 		Root:   http.FS(staticFileSystem),
 		Index:  "index.html",
 		Browse: true,
@@ -109,14 +169,75 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	// ORDER IS IMPORTANT: all routes applied AFTER the security middleware will require authn
 	protectedAPIRouter := apiRouter.Group("/")
 	if cfg.Security != nil {
-		if err := cfg.Security.RegisterHandlers(app); err != nil {
+		if err := cfg.Security.RegisterHandlers(router); err != nil {
 			panic(err)
 		}
 		if err := cfg.Security.ApplySecurityMiddleware(protectedAPIRouter); err != nil {
 			panic(err)
 		}
 	}
-	protectedAPIRouter.Get("/v1/endpoints/statuses", EndpointStatuses(cfg))
-	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", EndpointStatus)
+	documentedProtectedAPIRouter := &documentedRouter{router: protectedAPIRouter, prefix: "/api", protected: true, spec: spec}
+	documentedProtectedAPIRouter.get("/v1/endpoints/statuses", getEndpointStatusesOperation, EndpointStatuses(cfg))
+	documentedProtectedAPIRouter.get("/v1/endpoints/statuses/compact", getCompactEndpointStatusesOperation, CompactEndpointStatuses(cfg))
+	documentedProtectedAPIRouter.get("/v1/endpoints/:key/statuses", getEndpointStatusOperation, EndpointStatus)
+	documentedProtectedAPIRouter.get("/v1/endpoints/:key/failures/breakdown", getFailureBreakdownOperation, FailureBreakdown)
+	documentedProtectedAPIRouter.get("/v1/endpoints/:key/slo", getServiceLevelObjectiveOperation, ServiceLevelObjective(cfg))
+	documentedProtectedAPIRouter.get("/v1/system/scheduler", getSchedulerOperation, Scheduler)
+	documentedProtectedAPIRouter.get("/v1/config/versions", getConfigurationVersionsOperation, ConfigurationVersions)
+	documentedProtectedAPIRouter.get("/v1/grafana", grafanaTestConnectionOperation, GrafanaTestConnection)
+	documentedProtectedAPIRouter.post("/v1/grafana/search", grafanaSearchOperation, GrafanaSearch)
+	documentedProtectedAPIRouter.post("/v1/grafana/query", grafanaQueryOperation, GrafanaQuery)
+	documentedProtectedAPIRouter.post("/v1/grafana/annotations", grafanaAnnotationsOperation, GrafanaAnnotations)
+	if cfg.Alerting != nil {
+		documentedProtectedAPIRouter.get("/v1/alerts/history", getAlertHistoryOperation, AlertHistory)
+	}
+	hasAlertDelivery := cfg.Alerting != nil && cfg.Alerting.Delivery != nil
+	if hasAlertDelivery {
+		documentedProtectedAPIRouter.get("/v1/alerting/deliveries", getAlertDeliveriesOperation, AlertDeliveries)
+	}
+	// Routes that modify data are only available if security is configured, since they'd otherwise be open to anyone
+	if cfg.Security != nil {
+		documentedProtectedAPIRouter.post("/v1/import", importDataOperation, ImportData(cfg))
+		documentedProtectedAPIRouter.post("/v1/config/rollback/:version", rollBackConfigurationOperation, RollBackConfiguration(cfg))
+		documentedProtectedAPIRouter.patch("/v1/endpoints/:key", overrideEndpointOperation, OverrideEndpoint(cfg))
+		documentedProtectedAPIRouter.delete("/v1/endpoints/:key/override", clearEndpointOverrideOperation, ClearEndpointOverride(cfg))
+		documentedProtectedAPIRouter.get("/v1/endpoints/:key/external/tokens", getExternalEndpointTokensOperation, ExternalEndpointTokens(cfg))
+		documentedProtectedAPIRouter.post("/v1/endpoints/:key/external/tokens", createExternalEndpointTokenOperation, CreateExternalEndpointToken(cfg))
+		documentedProtectedAPIRouter.delete("/v1/endpoints/:key/external/tokens/:id", revokeExternalEndpointTokenOperation, RevokeExternalEndpointToken(cfg))
+		if cfg.Alerting != nil {
+			documentedProtectedAPIRouter.post("/v1/alerting/:provider/test", testAlertingProviderOperation, TestAlertingProvider(cfg))
+		}
+		if hasAlertDelivery {
+			documentedProtectedAPIRouter.post("/v1/alerting/deliveries/:id/retry", retryAlertDeliveryOperation, RetryAlertDelivery)
+			documentedProtectedAPIRouter.delete("/v1/alerting/deliveries/:id", deleteAlertDeliveryOperation, DeleteAlertDelivery)
+		}
+	}
+	if hasShareLinks {
+		documentedProtectedAPIRouter.post("/v1/share-links", createShareLinkOperation, CreateShareLink(cfg))
+	}
 	return app
 }
+
+// securityHeaders returns a middleware adding the configured security headers to every response
+func securityHeaders(cfg *web.SecurityHeadersConfig) fiber.Handler {
+	var strictTransportSecurity string
+	if cfg.HSTS != nil {
+		strictTransportSecurity = cfg.HSTS.Value()
+	}
+	return func(c *fiber.Ctx) error {
+		if len(cfg.ContentSecurityPolicy) > 0 {
+			c.Set(fiber.HeaderContentSecurityPolicy, cfg.ContentSecurityPolicy)
+		}
+		if len(cfg.FrameOptions) > 0 {
+			c.Set(fiber.HeaderXFrameOptions, cfg.FrameOptions)
+		}
+		if len(cfg.ReferrerPolicy) > 0 {
+			c.Set(fiber.HeaderReferrerPolicy, cfg.ReferrerPolicy)
+		}
+		if len(strictTransportSecurity) > 0 {
+			c.Set(fiber.HeaderStrictTransportSecurity, strictTransportSecurity)
+		}
+		c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		return c.Next()
+	}
+}
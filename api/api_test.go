@@ -4,9 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,6 +20,7 @@ func TestNew(t *testing.T) {
 		ExpectedCode int
 		Gzip         bool
 		WithSecurity bool
+		BasePath     string
 	}
 	scenarios := []Scenario{
 		{
@@ -91,10 +94,62 @@ func TestNew(t *testing.T) {
 			ExpectedCode: fiber.StatusOK,
 			WithSecurity: false,
 		},
+		{
+			Name:         "base-path-health",
+			Path:         "/status/health",
+			ExpectedCode: fiber.StatusOK,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-index",
+			Path:         "/status/",
+			ExpectedCode: fiber.StatusOK,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-index-without-trailing-slash",
+			Path:         "/status",
+			ExpectedCode: fiber.StatusOK,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-index-html-redirect",
+			Path:         "/status/index.html",
+			ExpectedCode: fiber.StatusMovedPermanently,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-app.js",
+			Path:         "/status/js/app.js",
+			ExpectedCode: fiber.StatusOK,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-config",
+			Path:         "/status/api/v1/config",
+			ExpectedCode: fiber.StatusOK,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-endpoints-should-return-401-if-not-authenticated",
+			Path:         "/status/api/v1/endpoints/statuses",
+			ExpectedCode: fiber.StatusUnauthorized,
+			WithSecurity: true,
+			BasePath:     "/status",
+		},
+		{
+			Name:         "base-path-route-outside-of-base-path",
+			Path:         "/api/v1/config",
+			ExpectedCode: fiber.StatusNotFound,
+			BasePath:     "/status",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
 			cfg := &config.Config{Metrics: true, UI: &ui.Config{}}
+			if len(scenario.BasePath) > 0 {
+				cfg.Web = &web.Config{BasePath: scenario.BasePath}
+			}
 			if scenario.WithSecurity {
 				cfg.Security = &security.Config{
 					Basic: &security.BasicConfig{
@@ -119,3 +174,85 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWithCORSAndSecurityHeaders(t *testing.T) {
+	cfg := &config.Config{
+		UI: &ui.Config{},
+		Web: &web.Config{
+			CORS: &web.CORSConfig{
+				AllowedOrigins:   []string{"https://dashboard.example.com"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+			SecurityHeaders: &web.SecurityHeadersConfig{
+				ContentSecurityPolicy: "default-src 'self'",
+				FrameOptions:          "DENY",
+				HSTS:                  &web.HSTSConfig{MaxAge: 24 * time.Hour, IncludeSubdomains: true},
+			},
+		},
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name                string
+		Method              string
+		Origin              string
+		ExpectedCode        int
+		ExpectedAllowOrigin string
+	}{
+		{
+			Name:                "preflight-from-allowed-origin",
+			Method:              "OPTIONS",
+			Origin:              "https://dashboard.example.com",
+			ExpectedCode:        fiber.StatusNoContent,
+			ExpectedAllowOrigin: "https://dashboard.example.com",
+		},
+		{
+			Name:                "request-from-allowed-origin",
+			Method:              "GET",
+			Origin:              "https://dashboard.example.com",
+			ExpectedCode:        fiber.StatusOK,
+			ExpectedAllowOrigin: "https://dashboard.example.com",
+		},
+		{
+			Name:                "request-from-other-origin",
+			Method:              "GET",
+			Origin:              "https://evil.example.com",
+			ExpectedCode:        fiber.StatusOK,
+			ExpectedAllowOrigin: "",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, "/api/v1/config", http.NoBody)
+			request.Header.Set("Origin", scenario.Origin)
+			if scenario.Method == "OPTIONS" {
+				request.Header.Set("Access-Control-Request-Method", "GET")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			if allowOrigin := response.Header.Get("Access-Control-Allow-Origin"); allowOrigin != scenario.ExpectedAllowOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin to be %q, got %q", scenario.ExpectedAllowOrigin, allowOrigin)
+			}
+			if scenario.Method == "OPTIONS" {
+				return
+			}
+			if csp := response.Header.Get("Content-Security-Policy"); csp != "default-src 'self'" {
+				t.Errorf("expected Content-Security-Policy to be set, got %q", csp)
+			}
+			if frameOptions := response.Header.Get("X-Frame-Options"); frameOptions != "DENY" {
+				t.Errorf("expected X-Frame-Options to be DENY, got %q", frameOptions)
+			}
+			if hsts := response.Header.Get("Strict-Transport-Security"); hsts != "max-age=86400; includeSubDomains" {
+				t.Errorf("expected Strict-Transport-Security to be set, got %q", hsts)
+			}
+			if nosniff := response.Header.Get("X-Content-Type-Options"); nosniff != "nosniff" {
+				t.Errorf("expected X-Content-Type-Options to be nosniff, got %q", nosniff)
+			}
+		})
+	}
+}
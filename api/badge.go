@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/endpoint/ui"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
@@ -35,10 +36,25 @@ var (
 	badgeColors = []string{badgeColorHexAwesome, badgeColorHexGreat, badgeColorHexGood, badgeColorHexPassable, badgeColorHexBad}
 )
 
+// uptimeBadgeOperation documents UptimeBadge
+var uptimeBadgeOperation = &openAPIOperation{
+	OperationID:  "getUptimeBadge",
+	Summary:      "Generate a badge showing the uptime of an endpoint",
+	Tags:         []string{"badges"},
+	Parameters:   []*openAPIParameter{keyPathParameter, durationPathParameter("30d", "7d", "24h", "1h")},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "SVG badge"}, "400": badRequestResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: "",
+	contentType:  "image/svg+xml",
+}
+
 // UptimeBadge handles the automatic generation of badge based on the group name and endpoint name passed.
 //
 // Valid values for :duration -> 30d, 7d, 24h, 1h
 func UptimeBadge(c *fiber.Ctx) error {
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(c.Params("key"))) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
 	duration := c.Params("duration")
 	var from time.Time
 	switch duration {
@@ -72,8 +88,23 @@ func UptimeBadge(c *fiber.Ctx) error {
 // ResponseTimeBadge handles the automatic generation of badge based on the group name and endpoint name passed.
 //
 // Valid values for :duration -> 30d, 7d, 24h, 1h
+// responseTimeBadgeOperation documents ResponseTimeBadge
+var responseTimeBadgeOperation = &openAPIOperation{
+	OperationID:  "getResponseTimeBadge",
+	Summary:      "Generate a badge showing the average response time of an endpoint",
+	Tags:         []string{"badges"},
+	Parameters:   []*openAPIParameter{keyPathParameter, durationPathParameter("30d", "7d", "24h", "1h")},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "SVG badge"}, "400": badRequestResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: "",
+	contentType:  "image/svg+xml",
+}
+
 func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(c.Params("key"))) > 0 {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
 		duration := c.Params("duration")
 		var from time.Time
 		switch duration {
@@ -106,7 +137,22 @@ func ResponseTimeBadge(cfg *config.Config) fiber.Handler {
 }
 
 // HealthBadge handles the automatic generation of badge based on the group name and endpoint name passed.
+// healthBadgeOperation documents HealthBadge
+var healthBadgeOperation = &openAPIOperation{
+	OperationID:  "getHealthBadge",
+	Summary:      "Generate a badge showing the current health of an endpoint",
+	Tags:         []string{"badges"},
+	Parameters:   []*openAPIParameter{keyPathParameter},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "SVG badge"}, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: "",
+	contentType:  "image/svg+xml",
+}
+
 func HealthBadge(c *fiber.Ctx) error {
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(c.Params("key"))) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
 	key := c.Params("key")
 	pagingConfig := paging.NewEndpointStatusParams()
 	status, err := store.Get().GetEndpointStatusByKey(key, pagingConfig.WithResults(1, 1))
@@ -132,7 +178,26 @@ func HealthBadge(c *fiber.Ctx) error {
 	return c.Status(200).Send(generateHealthBadgeSVG(healthStatus))
 }
 
+// healthBadgeShieldsOperation documents HealthBadgeShields
+var healthBadgeShieldsOperation = &openAPIOperation{
+	OperationID: "getHealthBadgeShields",
+	Summary:     "Generate a Shields.io endpoint badge showing the current health of an endpoint",
+	Tags:        []string{"badges"},
+	Parameters:  []*openAPIParameter{keyPathParameter},
+	Responses:   map[string]*openAPIResponse{"200": {Description: "Shields.io endpoint badge"}, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		Label         string `json:"label"`
+		Message       string `json:"message"`
+		Color         string `json:"color"`
+	}{},
+}
+
 func HealthBadgeShields(c *fiber.Ctx) error {
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(c.Params("key"))) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
 	key := c.Params("key")
 	pagingConfig := paging.NewEndpointStatusParams()
 	status, err := store.Get().GetEndpointStatusByKey(key, pagingConfig.WithResults(1, 1))
@@ -282,8 +347,8 @@ func generateResponseTimeBadgeSVG(duration string, averageResponseTime int, key
 
 func getBadgeColorFromResponseTime(responseTime int, key string, cfg *config.Config) string {
 	thresholds := ui.GetDefaultConfig().Badge.ResponseTime.Thresholds
-	if endpoint := cfg.GetEndpointByKey(key); endpoint != nil {
-		thresholds = endpoint.UIConfig.Badge.ResponseTime.Thresholds
+	if ep := cfg.GetEndpointByKey(key); ep != nil {
+		thresholds = ep.UIConfig.Badge.ResponseTime.Thresholds
 	}
 	// the threshold config requires 5 values, so we can be sure it's set here
 	for i := 0; i < 5; i++ {
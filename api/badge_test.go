@@ -28,14 +28,21 @@ func TestBadge(t *testing.T) {
 				Name:  "backend",
 				Group: "core",
 			},
+			{
+				Name:   "billing",
+				Group:  "core",
+				Tenant: "acme",
+			},
 		},
 	}
 
 	cfg.Endpoints[0].UIConfig = ui.GetDefaultConfig()
 	cfg.Endpoints[1].UIConfig = ui.GetDefaultConfig()
+	cfg.Endpoints[2].UIConfig = ui.GetDefaultConfig()
 
 	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Connected: true, Duration: time.Millisecond, Timestamp: time.Now()})
 	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: false, Connected: false, Duration: time.Second, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[2], &endpoint.Result{Success: true, Connected: true, Duration: time.Millisecond, Timestamp: time.Now()})
 	api := New(cfg)
 	router := api.Router()
 	type Scenario struct {
@@ -140,6 +147,31 @@ func TestBadge(t *testing.T) {
 			Path:         "/api/v1/endpoints/core_backend/response-times/3d/chart.svg",
 			ExpectedCode: http.StatusBadRequest,
 		},
+		{
+			Name:         "badge-uptime-for-tenant-endpoint",
+			Path:         "/api/v1/endpoints/acme_core_billing/uptimes/7d/badge.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "badge-response-time-for-tenant-endpoint",
+			Path:         "/api/v1/endpoints/acme_core_billing/response-times/7d/badge.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "badge-health-for-tenant-endpoint",
+			Path:         "/api/v1/endpoints/acme_core_billing/health/badge.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "badge-shields-health-for-tenant-endpoint",
+			Path:         "/api/v1/endpoints/acme_core_billing/health/badge.shields",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "chart-response-time-for-tenant-endpoint",
+			Path:         "/api/v1/endpoints/acme_core_billing/response-times/7d/chart.svg",
+			ExpectedCode: http.StatusNotFound,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/gofiber/fiber/v2"
@@ -30,11 +31,35 @@ var (
 	}
 )
 
+// responseTimeChartOperation documents ResponseTimeChart
+var responseTimeChartOperation = &openAPIOperation{
+	OperationID:  "getResponseTimeChart",
+	Summary:      "Generate a chart showing the hourly, or daily for durations longer than 30d, average response time of an endpoint",
+	Tags:         []string{"badges"},
+	Parameters:   []*openAPIParameter{keyPathParameter, durationPathParameter("1y", "90d", "30d", "7d", "24h")},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "SVG chart"}, "400": badRequestResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: "",
+	contentType:  "image/svg+xml",
+}
+
 func ResponseTimeChart(c *fiber.Ctx) error {
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(c.Params("key"))) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
 	duration := c.Params("duration")
 	chartTimestampFormatter := chart.TimeValueFormatterWithFormat(timeFormat)
 	var from time.Time
+	// Durations longer than 30d are charted by day rather than by hour, which also lets them be served from the daily
+	// uptime entries into which hourly uptime entries are merged once they exceed the retention of the storage
+	daily := false
 	switch duration {
+	case "1y":
+		from, daily = endpoint.TruncateToDay(time.Now()).AddDate(-1, 0, 0), true
+		chartTimestampFormatter = chart.TimeDateValueFormatter
+	case "90d":
+		from, daily = endpoint.TruncateToDay(time.Now()).AddDate(0, 0, -90), true
+		chartTimestampFormatter = chart.TimeDateValueFormatter
 	case "30d":
 		from = time.Now().Truncate(time.Hour).Add(-30 * 24 * time.Hour)
 		chartTimestampFormatter = chart.TimeDateValueFormatter
@@ -43,9 +68,15 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 	case "24h":
 		from = time.Now().Truncate(time.Hour).Add(-24 * time.Hour)
 	default:
-		return c.Status(400).SendString("Durations supported: 30d, 7d, 24h")
+		return c.Status(400).SendString("Durations supported: 1y, 90d, 30d, 7d, 24h")
+	}
+	var averageResponseTimes map[int64]int
+	var err error
+	if daily {
+		averageResponseTimes, err = getDailyAverageResponseTimes(c.Params("key"), from, time.Now())
+	} else {
+		averageResponseTimes, err = store.Get().GetHourlyAverageResponseTimeByKey(c.Params("key"), from, time.Now())
 	}
-	hourlyAverageResponseTime, err := store.Get().GetHourlyAverageResponseTimeByKey(c.Params("key"), from, time.Now())
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			return c.Status(404).SendString(err.Error())
@@ -54,7 +85,7 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 		}
 		return c.Status(500).SendString(err.Error())
 	}
-	if len(hourlyAverageResponseTime) == 0 {
+	if len(averageResponseTimes) == 0 {
 		return c.Status(204).SendString("")
 	}
 	series := chart.TimeSeries{
@@ -64,22 +95,29 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 			DotWidth:    2.0,
 		},
 	}
-	keys := make([]int, 0, len(hourlyAverageResponseTime))
+	if daily {
+		series.Name = "Average response time per day"
+	}
+	keys := make([]int, 0, len(averageResponseTimes))
 	earliestTimestamp := int64(0)
-	for hourlyTimestamp := range hourlyAverageResponseTime {
-		keys = append(keys, int(hourlyTimestamp))
-		if earliestTimestamp == 0 || hourlyTimestamp < earliestTimestamp {
-			earliestTimestamp = hourlyTimestamp
+	for timestamp := range averageResponseTimes {
+		keys = append(keys, int(timestamp))
+		if earliestTimestamp == 0 || timestamp < earliestTimestamp {
+			earliestTimestamp = timestamp
 		}
 	}
 	for earliestTimestamp > from.Unix() {
-		earliestTimestamp -= int64(time.Hour.Seconds())
+		if daily {
+			earliestTimestamp = time.Unix(earliestTimestamp, 0).AddDate(0, 0, -1).Unix()
+		} else {
+			earliestTimestamp -= int64(time.Hour.Seconds())
+		}
 		keys = append(keys, int(earliestTimestamp))
 	}
 	sort.Ints(keys)
 	var maxAverageResponseTime float64
 	for _, key := range keys {
-		averageResponseTime := float64(hourlyAverageResponseTime[int64(key)])
+		averageResponseTime := float64(averageResponseTimes[int64(key)])
 		if maxAverageResponseTime < averageResponseTime {
 			maxAverageResponseTime = averageResponseTime
 		}
@@ -121,3 +159,19 @@ func ResponseTimeChart(c *fiber.Ctx) error {
 	}
 	return nil
 }
+
+// getDailyAverageResponseTimes returns a map of daily (key) average response time in milliseconds (value) during a time
+// range, which is computed from the daily uptime statistics of the endpoint
+func getDailyAverageResponseTimes(key string, from, to time.Time) (map[int64]int, error) {
+	dailyUptimeStatistics, err := store.Get().GetDailyUptimeStatisticsByKey(key, from, to)
+	if err != nil {
+		return nil, err
+	}
+	dailyAverageResponseTimes := make(map[int64]int, len(dailyUptimeStatistics))
+	for dailyTimestamp, dailyStats := range dailyUptimeStatistics {
+		if dailyStats.TotalExecutions > 0 {
+			dailyAverageResponseTimes[dailyTimestamp] = dailyStats.AverageResponseTime()
+		}
+	}
+	return dailyAverageResponseTimes, nil
+}
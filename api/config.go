@@ -11,6 +11,18 @@ type ConfigHandler struct {
 	securityConfig *security.Config
 }
 
+// getConfigOperation documents ConfigHandler.GetConfig
+var getConfigOperation = &openAPIOperation{
+	OperationID: "getConfig",
+	Summary:     "Retrieve the configuration relevant to the user interface",
+	Tags:        []string{"meta"},
+	Responses:   map[string]*openAPIResponse{"200": {Description: "Configuration"}},
+	responseType: struct {
+		OIDC          bool `json:"oidc"`
+		Authenticated bool `json:"authenticated"`
+	}{},
+}
+
 func (handler ConfigHandler) GetConfig(c *fiber.Ctx) error {
 	hasOIDC := false
 	isAuthenticated := true // Default to true if no security config is set
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/snapshot"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// getConfigurationVersionsOperation documents ConfigurationVersions
+var getConfigurationVersionsOperation = &openAPIOperation{
+	OperationID:  "getConfigurationVersions",
+	Summary:      "Retrieve the versions of the configuration that have been applied, which can be rolled back to",
+	Tags:         []string{"system"},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Versions of the configuration, from the most recent to the least recent"}, "401": unauthorizedResponse, "500": internalErrorResponse},
+	responseType: []*snapshot.Snapshot{},
+}
+
+// ConfigurationVersions handles requests to retrieve the versions of the configuration that have been applied.
+// The configurations themselves aren't returned, since they may contain secrets.
+func ConfigurationVersions(c *fiber.Ctx) error {
+	snapshots, err := store.Get().GetConfigurationSnapshots()
+	if err != nil {
+		log.Printf("[api.ConfigurationVersions] Failed to retrieve configuration snapshots: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return c.Status(200).JSON(snapshots)
+}
+
+// rollBackConfigurationOperation documents RollBackConfiguration
+var rollBackConfigurationOperation = &openAPIOperation{
+	OperationID: "rollBackConfiguration",
+	Summary:     "Replace the configuration by a version that was previously applied",
+	Tags:        []string{"system"},
+	Parameters:  []*openAPIParameter{{Name: "version", In: "path", Required: true, Description: "Version of the configuration to roll back to", Schema: &openAPISchema{Type: "integer", Format: "int64"}}},
+	Responses: map[string]*openAPIResponse{
+		"202": {Description: "Rollback scheduled"},
+		"400": badRequestResponse,
+		"401": unauthorizedResponse,
+		"404": {Description: "Version of the configuration not found"},
+		"500": internalErrorResponse,
+	},
+	responseType: snapshot.Snapshot{},
+}
+
+// RollBackConfiguration handles requests to roll back to a version of the configuration that was previously applied.
+//
+// The configuration is validated before the rollback is scheduled, and is applied shortly after like any other
+// reload, at which point a new version is recorded.
+func RollBackConfiguration(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		version, err := strconv.ParseInt(c.Params("version"), 10, 64)
+		if err != nil {
+			return c.Status(400).SendString("invalid version")
+		}
+		configurationSnapshot, err := store.Get().GetConfigurationSnapshot(version)
+		if err != nil {
+			if errors.Is(err, common.ErrConfigurationSnapshotNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			log.Printf("[api.RollBackConfiguration] Failed to retrieve configuration snapshot with version=%d: %s", version, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		if err = cfg.RollBackTo(configurationSnapshot); err != nil {
+			return c.Status(400).SendString(fmt.Sprintf("configuration with version=%d is not valid: %s", version, err.Error()))
+		}
+		log.Printf("[api.RollBackConfiguration] Scheduled rollback to configuration with version=%d", version)
+		return c.Status(202).JSON(configurationSnapshot)
+	}
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/snapshot"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestConfigurationVersionsAndRollBackConfiguration(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	validSnapshot := snapshot.New([]byte("endpoints:\n  - name: website\n    url: https://example.org\n    conditions:\n      - \"[STATUS] == 200\""), snapshot.SourceFile, 0)
+	invalidSnapshot := snapshot.New([]byte("endpoints: []"), snapshot.SourceFile, 0)
+	for _, configurationSnapshot := range []*snapshot.Snapshot{validSnapshot, invalidSnapshot} {
+		if err := store.Get().InsertConfigurationSnapshot(configurationSnapshot); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name          string
+		Method        string
+		Path          string
+		Authenticated bool
+		ExpectedCode  int
+	}{
+		{
+			Name:         "versions-unauthenticated",
+			Method:       "GET",
+			Path:         "/api/v1/config/versions",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:          "versions",
+			Method:        "GET",
+			Path:          "/api/v1/config/versions",
+			Authenticated: true,
+			ExpectedCode:  http.StatusOK,
+		},
+		{
+			Name:         "rollback-unauthenticated",
+			Method:       "POST",
+			Path:         "/api/v1/config/rollback/" + strconv.FormatInt(validSnapshot.Version, 10),
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:          "rollback-with-invalid-version",
+			Method:        "POST",
+			Path:          "/api/v1/config/rollback/latest",
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "rollback-to-unknown-version",
+			Method:        "POST",
+			Path:          "/api/v1/config/rollback/1000",
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+		{
+			Name:          "rollback-to-invalid-configuration",
+			Method:        "POST",
+			Path:          "/api/v1/config/rollback/" + strconv.FormatInt(invalidSnapshot.Version, 10),
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "rollback",
+			Method:        "POST",
+			Path:          "/api/v1/config/rollback/" + strconv.FormatInt(validSnapshot.Version, 10),
+			Authenticated: true,
+			ExpectedCode:  http.StatusAccepted,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.Name == "versions" {
+				body, _ := io.ReadAll(response.Body)
+				var snapshots []map[string]any
+				if err := json.Unmarshal(body, &snapshots); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				if len(snapshots) != 2 || int64(snapshots[0]["version"].(float64)) != invalidSnapshot.Version || snapshots[1]["checksum"] != validSnapshot.Checksum {
+					t.Errorf("expected both versions from the most recent to the least recent, got %s", body)
+				}
+				if _, exists := snapshots[0]["data"]; exists {
+					t.Error("expected the configuration not to be returned")
+				}
+			}
+		})
+	}
+	select {
+	case <-cfg.ReloadRequested():
+	default:
+		t.Fatal("expected a reload to have been requested")
+	}
+	rolledBackConfig := cfg.PendingRollback()
+	if rolledBackConfig == nil || len(rolledBackConfig.Endpoints) != 1 || rolledBackConfig.Endpoints[0].Name != "website" {
+		t.Fatalf("expected the configuration to be rolled back to version %d, got %v", validSnapshot.Version, rolledBackConfig)
+	}
+	if rolledBackConfigurationSnapshot := rolledBackConfig.Snapshot(); rolledBackConfigurationSnapshot.Source != snapshot.SourceRollback || rolledBackConfigurationSnapshot.RollbackOf != validSnapshot.Version || rolledBackConfigurationSnapshot.Checksum != validSnapshot.Checksum {
+		t.Errorf("expected the snapshot of the configuration rolled back to reference version %d, got %+v", validSnapshot.Version, rolledBackConfigurationSnapshot)
+	}
+	if cfg.PendingRollback() != nil {
+		t.Error("expected the pending rollback to only be returned once")
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/gofiber/fiber/v2"
+)
+
+type overrideEndpointRequest struct {
+	// Interval overrides the interval of the endpoint (e.g. 10s)
+	Interval string `json:"interval,omitempty"`
+
+	// Conditions overrides the conditions of the endpoint
+	Conditions []string `json:"conditions,omitempty"`
+
+	// FailureThreshold overrides the failure threshold of every alert of the endpoint
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold overrides the success threshold of every alert of the endpoint
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+
+	// Duration is how long the override applies for (e.g. 30m). Defaults to 1h.
+	Duration string `json:"duration,omitempty"`
+}
+
+// overrideEndpointOperation documents OverrideEndpoint
+var overrideEndpointOperation = &openAPIOperation{
+	OperationID: "overrideEndpoint",
+	Summary:     "Temporarily override the interval, conditions or alerting thresholds of an endpoint",
+	Tags:        []string{"endpoints"},
+	Parameters:  []*openAPIParameter{keyPathParameter},
+	Responses: map[string]*openAPIResponse{
+		"200": {Description: "Override applied"},
+		"400": badRequestResponse,
+		"401": unauthorizedResponse,
+		"404": notFoundResponse,
+	},
+	requestBodyType: overrideEndpointRequest{},
+	responseType:    endpoint.Override{},
+}
+
+// OverrideEndpoint handles requests to temporarily override the configuration of an endpoint.
+//
+// The override replaces the existing one, if any, and is discarded once it expires or when the configuration is
+// reloaded.
+func OverrideEndpoint(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ep := cfg.GetEndpointByKey(c.Params("key"))
+		if ep == nil {
+			return c.Status(404).SendString("endpoint not found")
+		}
+		var request overrideEndpointRequest
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body")
+		}
+		override := &endpoint.Override{
+			FailureThreshold: request.FailureThreshold,
+			SuccessThreshold: request.SuccessThreshold,
+		}
+		if len(request.Interval) > 0 {
+			interval, err := time.ParseDuration(request.Interval)
+			if err != nil {
+				return c.Status(400).SendString("invalid interval: " + err.Error())
+			}
+			override.Interval = interval
+		}
+		for _, condition := range request.Conditions {
+			override.Conditions = append(override.Conditions, endpoint.Condition(condition))
+		}
+		duration := endpoint.DefaultOverrideDuration
+		if len(request.Duration) > 0 {
+			var err error
+			if duration, err = time.ParseDuration(request.Duration); err != nil {
+				return c.Status(400).SendString("invalid duration: " + err.Error())
+			}
+		}
+		override.ExpiresAt = time.Now().Add(duration)
+		if err := ep.ValidateOverride(override); err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		ep.SetOverride(override)
+		log.Printf("[api.OverrideEndpoint] Overrode configuration of endpoint with key=%s until %s", ep.Key(), override.ExpiresAt.Format(time.RFC3339))
+		return c.Status(200).JSON(override)
+	}
+}
+
+// clearEndpointOverrideOperation documents ClearEndpointOverride
+var clearEndpointOverrideOperation = &openAPIOperation{
+	OperationID: "clearEndpointOverride",
+	Summary:     "Discard the override of an endpoint before it expires",
+	Tags:        []string{"endpoints"},
+	Parameters:  []*openAPIParameter{keyPathParameter},
+	Responses: map[string]*openAPIResponse{
+		"204": {Description: "Override discarded"},
+		"401": unauthorizedResponse,
+		"404": {Description: "Endpoint not found or endpoint has no override"},
+	},
+}
+
+// ClearEndpointOverride handles requests to discard the override of an endpoint
+func ClearEndpointOverride(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ep := cfg.GetEndpointByKey(c.Params("key"))
+		if ep == nil {
+			return c.Status(404).SendString("endpoint not found")
+		}
+		if !ep.ClearOverride() {
+			return c.Status(404).SendString("endpoint has no override")
+		}
+		log.Printf("[api.ClearEndpointOverride] Cleared override of endpoint with key=%s", ep.Key())
+		return c.SendStatus(204)
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+func TestOverrideEndpoint(t *testing.T) {
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name:       "frontend",
+				Group:      "core",
+				Interval:   time.Minute,
+				Conditions: []endpoint.Condition{"[STATUS] == 200"},
+				Alerts:     []*alert.Alert{{Type: alert.TypeSlack, FailureThreshold: 3, SuccessThreshold: 2}},
+			},
+		},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	ep := cfg.Endpoints[0]
+	router := New(cfg).Router()
+	scenarios := []struct {
+		Name             string
+		Method           string
+		Path             string
+		Body             string
+		Authenticated    bool
+		ExpectedCode     int
+		ExpectedInterval time.Duration
+	}{
+		{
+			Name:             "unauthenticated",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_frontend",
+			Body:             `{"interval":"10s"}`,
+			ExpectedCode:     http.StatusUnauthorized,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "endpoint-not-found",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_backend",
+			Body:             `{"interval":"10s"}`,
+			Authenticated:    true,
+			ExpectedCode:     http.StatusNotFound,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "invalid-body",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_frontend",
+			Body:             `invalid`,
+			Authenticated:    true,
+			ExpectedCode:     http.StatusBadRequest,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "invalid-interval",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_frontend",
+			Body:             `{"interval":"soon"}`,
+			Authenticated:    true,
+			ExpectedCode:     http.StatusBadRequest,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "invalid-condition",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_frontend",
+			Body:             `{"conditions":["[STATUS] invalid"]}`,
+			Authenticated:    true,
+			ExpectedCode:     http.StatusBadRequest,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "no-change",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_frontend",
+			Body:             `{"duration":"10m"}`,
+			Authenticated:    true,
+			ExpectedCode:     http.StatusBadRequest,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "clear-without-override",
+			Method:           "DELETE",
+			Path:             "/api/v1/endpoints/core_frontend/override",
+			Authenticated:    true,
+			ExpectedCode:     http.StatusNotFound,
+			ExpectedInterval: time.Minute,
+		},
+		{
+			Name:             "override",
+			Method:           "PATCH",
+			Path:             "/api/v1/endpoints/core_frontend",
+			Body:             `{"interval":"10s","failureThreshold":1,"duration":"30m"}`,
+			Authenticated:    true,
+			ExpectedCode:     http.StatusOK,
+			ExpectedInterval: 10 * time.Second,
+		},
+		{
+			Name:             "clear",
+			Method:           "DELETE",
+			Path:             "/api/v1/endpoints/core_frontend/override",
+			Authenticated:    true,
+			ExpectedCode:     http.StatusNoContent,
+			ExpectedInterval: time.Minute,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, bytes.NewBufferString(scenario.Body))
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			if ep.EffectiveInterval() != scenario.ExpectedInterval {
+				t.Errorf("expected effective interval %s, got %s", scenario.ExpectedInterval, ep.EffectiveInterval())
+			}
+		})
+	}
+}
+
+func TestOverrideEndpoint_WithoutSecurity(t *testing.T) {
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core", Interval: time.Minute, Conditions: []endpoint.Condition{"[STATUS] == 200"}}},
+	}
+	router := New(cfg).Router()
+	request := httptest.NewRequest("PATCH", "/api/v1/endpoints/core_frontend", bytes.NewBufferString(`{"interval":"10s"}`))
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		t.Error("expected override to be unavailable when security isn't configured")
+	}
+	if cfg.Endpoints[0].GetOverride() != nil {
+		t.Error("expected endpoint to have no override")
+	}
+}
@@ -17,19 +17,38 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// getEndpointStatusesOperation documents EndpointStatuses
+var getEndpointStatusesOperation = &openAPIOperation{
+	OperationID:  "getEndpointStatuses",
+	Summary:      "Retrieve the status of all endpoints",
+	Tags:         []string{"endpoints"},
+	Parameters:   append(pageQueryParameters, resultsTimeRangeQueryParameters...),
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Statuses of all endpoints"}, "400": badRequestResponse, "401": unauthorizedResponse, "500": internalErrorResponse},
+	responseType: []*endpoint.Status{},
+}
+
 // EndpointStatuses handles requests to retrieve all EndpointStatus
 // Due to how intensive this operation can be on the storage, this function leverages a cache.
 func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		page, pageSize := extractPageAndPageSizeFromRequest(c)
-		value, exists := cache.Get(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize))
+		from, to, order, err := extractResultsTimeRangeAndOrderFromRequest(c)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		cacheKey := fmt.Sprintf("endpoint-status-%d-%d-%d-%d-%s", page, pageSize, from.Unix(), to.Unix(), order)
+		value, exists := cache.Get(cacheKey)
 		var data []byte
 		if !exists {
-			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
+			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize).WithResultsTimeRange(from, to).WithResultsOrder(order))
 			if err != nil {
+				if errors.Is(err, common.ErrInvalidTimeRange) {
+					return c.Status(400).SendString(err.Error())
+				}
 				log.Printf("[api.EndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
 				return c.Status(500).SendString(err.Error())
 			}
+			endpointStatuses = excludeTenantEndpointStatuses(endpointStatuses)
 			// ALPHA: Retrieve endpoint statuses from remote instances
 			if endpointStatusesFromRemote, err := getEndpointStatusesFromRemoteInstances(cfg.Remote); err != nil {
 				log.Printf("[handler.EndpointStatuses] Silently failed to retrieve endpoint statuses from remote: %s", err.Error())
@@ -42,7 +61,7 @@ func EndpointStatuses(cfg *config.Config) fiber.Handler {
 				log.Printf("[api.EndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
 				return c.Status(500).SendString("unable to marshal object to JSON")
 			}
-			cache.SetWithTTL(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize), data, cacheTTL)
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
 		} else {
 			data = value.([]byte)
 		}
@@ -84,13 +103,34 @@ func getEndpointStatusesFromRemoteInstances(remoteConfig *remote.Config) ([]*end
 }
 
 // EndpointStatus retrieves a single endpoint.Status by group and endpoint name
+// getEndpointStatusOperation documents EndpointStatus
+var getEndpointStatusOperation = &openAPIOperation{
+	OperationID:  "getEndpointStatus",
+	Summary:      "Retrieve the status of an endpoint",
+	Tags:         []string{"endpoints"},
+	Parameters:   append(append([]*openAPIParameter{keyPathParameter}, pageQueryParameters...), resultsTimeRangeQueryParameters...),
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Status of the endpoint"}, "400": badRequestResponse, "401": unauthorizedResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: &endpoint.Status{},
+}
+
 func EndpointStatus(c *fiber.Ctx) error {
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(c.Params("key"))) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
 	page, pageSize := extractPageAndPageSizeFromRequest(c)
-	endpointStatus, err := store.Get().GetEndpointStatusByKey(c.Params("key"), paging.NewEndpointStatusParams().WithResults(page, pageSize).WithEvents(1, common.MaximumNumberOfEvents))
+	from, to, order, err := extractResultsTimeRangeAndOrderFromRequest(c)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(c.Params("key"), paging.NewEndpointStatusParams().WithResults(page, pageSize).WithResultsTimeRange(from, to).WithResultsOrder(order).WithEvents(1, common.MaximumNumberOfEvents))
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			return c.Status(404).SendString(err.Error())
 		}
+		if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
 		log.Printf("[api.EndpointStatus] Failed to retrieve endpoint status: %s", err.Error())
 		return c.Status(500).SendString(err.Error())
 	}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	compactFieldKey          = "key"
+	compactFieldName         = "name"
+	compactFieldGroup        = "group"
+	compactFieldSuccess      = "success"
+	compactFieldStatus       = "status"
+	compactFieldResponseTime = "responseTime"
+	compactFieldTimestamp    = "timestamp"
+
+	// DefaultCompactFields are the fields returned by CompactEndpointStatuses if none are specified
+	DefaultCompactFields = compactFieldKey + "," + compactFieldSuccess + "," + compactFieldResponseTime
+
+	// DefaultCompactResults is the number of results per endpoint returned by CompactEndpointStatuses if none is specified
+	DefaultCompactResults = 1
+)
+
+var compactFields = []string{compactFieldKey, compactFieldName, compactFieldGroup, compactFieldSuccess, compactFieldStatus, compactFieldResponseTime, compactFieldTimestamp}
+
+// compactEndpointStatus is a minimal representation of endpoint.Status, which only contains the fields requested
+type compactEndpointStatus struct {
+	Key     string           `json:"key,omitempty"`
+	Name    string           `json:"name,omitempty"`
+	Group   string           `json:"group,omitempty"`
+	Results []*compactResult `json:"results,omitempty"`
+}
+
+// compactResult is a minimal representation of endpoint.Result, which only contains the fields requested
+type compactResult struct {
+	Success *bool `json:"success,omitempty"`
+	Status  *int  `json:"status,omitempty"`
+
+	// ResponseTime is the duration of the evaluation in milliseconds
+	ResponseTime *int64 `json:"responseTime,omitempty"`
+
+	// Timestamp is the time at which the evaluation took place, in seconds since the Unix epoch
+	Timestamp *int64 `json:"timestamp,omitempty"`
+}
+
+// getCompactEndpointStatusesOperation documents CompactEndpointStatuses
+var getCompactEndpointStatusesOperation = &openAPIOperation{
+	OperationID: "getCompactEndpointStatuses",
+	Summary:     "Retrieve the latest results of all endpoints with only the fields requested",
+	Tags:        []string{"endpoints"},
+	Parameters: []*openAPIParameter{
+		{Name: "fields", In: "query", Description: "Comma-separated list of fields to return (" + strings.Join(compactFields, ", ") + "). Defaults to " + DefaultCompactFields, Schema: &openAPISchema{Type: "string"}},
+		{Name: "results", In: "query", Description: "Number of latest results to return per endpoint. Defaults to 1", Schema: &openAPISchema{Type: "integer", Format: "int32"}},
+	},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Compact statuses of all endpoints"}, "400": badRequestResponse, "401": unauthorizedResponse, "500": internalErrorResponse},
+	responseType: []*compactEndpointStatus{},
+}
+
+// CompactEndpointStatuses handles requests to retrieve the latest results of all endpoints with only the fields
+// requested, which is meant for clients polling the statuses of many endpoints frequently, such as wallboards.
+// Like EndpointStatuses, this function leverages a cache.
+func CompactEndpointStatuses(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		fields, err := extractCompactFieldsFromRequest(c)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		numberOfResults := extractCompactResultsFromRequest(c)
+		cacheKey := fmt.Sprintf("endpoint-status-compact-%s-%d", strings.Join(fields, ","), numberOfResults)
+		value, exists := cache.Get(cacheKey)
+		var data []byte
+		if !exists {
+			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, numberOfResults))
+			if err != nil {
+				log.Printf("[api.CompactEndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			endpointStatuses = excludeTenantEndpointStatuses(endpointStatuses)
+			if endpointStatusesFromRemote, err := getEndpointStatusesFromRemoteInstances(cfg.Remote); err != nil {
+				log.Printf("[api.CompactEndpointStatuses] Silently failed to retrieve endpoint statuses from remote: %s", err.Error())
+			} else if endpointStatusesFromRemote != nil {
+				endpointStatuses = append(endpointStatuses, endpointStatusesFromRemote...)
+			}
+			compactEndpointStatuses := make([]*compactEndpointStatus, 0, len(endpointStatuses))
+			for _, endpointStatus := range endpointStatuses {
+				compactEndpointStatuses = append(compactEndpointStatuses, newCompactEndpointStatus(endpointStatus, fields, numberOfResults))
+			}
+			data, err = json.Marshal(compactEndpointStatuses)
+			if err != nil {
+				log.Printf("[api.CompactEndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
+				return c.Status(500).SendString("unable to marshal object to JSON")
+			}
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
+		} else {
+			data = value.([]byte)
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(data)
+	}
+}
+
+// extractCompactFieldsFromRequest returns the fields requested, deduplicated and sorted so that equivalent requests
+// share the same cache entry
+func extractCompactFieldsFromRequest(c *fiber.Ctx) ([]string, error) {
+	fieldsParameter := c.Query("fields")
+	if len(fieldsParameter) == 0 {
+		fieldsParameter = DefaultCompactFields
+	}
+	var fields []string
+	for _, field := range strings.Split(fieldsParameter, ",") {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(compactFields, field) {
+			return nil, fmt.Errorf("unknown field %q, supported fields are: %s", field, strings.Join(compactFields, ", "))
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+	return fields, nil
+}
+
+// extractCompactResultsFromRequest returns the number of results requested per endpoint, falling back to the default
+// or maximum value if the value is invalid
+func extractCompactResultsFromRequest(c *fiber.Ctx) int {
+	numberOfResults, err := strconv.Atoi(c.Query("results"))
+	if err != nil || numberOfResults < 1 {
+		return DefaultCompactResults
+	}
+	if numberOfResults > MaximumPageSize {
+		return MaximumPageSize
+	}
+	return numberOfResults
+}
+
+func newCompactEndpointStatus(endpointStatus *endpoint.Status, fields []string, numberOfResults int) *compactEndpointStatus {
+	compactStatus := &compactEndpointStatus{}
+	if slices.Contains(fields, compactFieldKey) {
+		compactStatus.Key = endpointStatus.Key
+	}
+	if slices.Contains(fields, compactFieldName) {
+		compactStatus.Name = endpointStatus.Name
+	}
+	if slices.Contains(fields, compactFieldGroup) {
+		compactStatus.Group = endpointStatus.Group
+	}
+	includeSuccess := slices.Contains(fields, compactFieldSuccess)
+	includeStatus := slices.Contains(fields, compactFieldStatus)
+	includeResponseTime := slices.Contains(fields, compactFieldResponseTime)
+	includeTimestamp := slices.Contains(fields, compactFieldTimestamp)
+	if !includeSuccess && !includeStatus && !includeResponseTime && !includeTimestamp {
+		return compactStatus
+	}
+	results := endpointStatus.Results
+	// Results from remote instances aren't paginated, so only the latest ones are kept
+	if len(results) > numberOfResults {
+		results = results[len(results)-numberOfResults:]
+	}
+	compactStatus.Results = make([]*compactResult, 0, len(results))
+	for _, result := range results {
+		compactResult := &compactResult{}
+		if includeSuccess {
+			compactResult.Success = &result.Success
+		}
+		if includeStatus {
+			compactResult.Status = &result.HTTPStatus
+		}
+		if includeResponseTime {
+			responseTime := result.Duration.Milliseconds()
+			compactResult.ResponseTime = &responseTime
+		}
+		if includeTimestamp {
+			timestamp := result.Timestamp.Unix()
+			compactResult.Timestamp = &timestamp
+		}
+		compactStatus.Results = append(compactStatus.Results, compactResult)
+	}
+	return compactStatus
+}
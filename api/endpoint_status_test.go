@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 	"time"
 
@@ -128,6 +129,26 @@ func TestEndpointStatus(t *testing.T) {
 			Path:         "/api/v1/endpoints/invalid_key/statuses",
 			ExpectedCode: http.StatusNotFound,
 		},
+		{
+			Name:         "endpoint-status-with-time-range",
+			Path:         "/api/v1/endpoints/core_frontend/statuses?from=2024-01-02T02:00:00Z&to=2024-01-02T03:00:00Z&order=asc",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "endpoint-status-with-invalid-from",
+			Path:         "/api/v1/endpoints/core_frontend/statuses?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "endpoint-status-with-from-after-to",
+			Path:         "/api/v1/endpoints/core_frontend/statuses?from=2024-01-02T03:00:00Z&to=2024-01-02T02:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "endpoint-status-with-invalid-order",
+			Path:         "/api/v1/endpoints/core_frontend/statuses?order=random",
+			ExpectedCode: http.StatusBadRequest,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -146,6 +167,8 @@ func TestEndpointStatus(t *testing.T) {
 	}
 }
 
+var downtimePattern = regexp.MustCompile(`"downSince":"[^"]+","downFor":"[^"]+"`)
+
 func TestEndpointStatuses(t *testing.T) {
 	defer store.Get().Clear()
 	defer cache.Clear()
@@ -169,31 +192,43 @@ func TestEndpointStatuses(t *testing.T) {
 			Name:         "no-pagination",
 			Path:         "/api/v1/endpoints/statuses",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"},{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"},{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
 		},
 		{
 			Name:         "pagination-first-result",
 			Path:         "/api/v1/endpoints/statuses?page=1&pageSize=1",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
 		},
 		{
 			Name:         "pagination-second-result",
 			Path:         "/api/v1/endpoints/statuses?page=2&pageSize=1",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"}],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
 		},
 		{
 			Name:         "pagination-no-results",
 			Path:         "/api/v1/endpoints/statuses?page=5&pageSize=20",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[]}]`,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
+		},
+		{
+			Name:         "time-range-excluding-all-results",
+			Path:         "/api/v1/endpoints/statuses?from=2100-01-01T00:00:00Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
+		},
+		{
+			Name:         "pagination-first-result-in-ascending-order",
+			Path:         "/api/v1/endpoints/statuses?page=1&pageSize=1&order=asc",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"}],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
 		},
 		{
 			Name:         "invalid-pagination-should-fall-back-to-default",
 			Path:         "/api/v1/endpoints/statuses?page=INVALID&pageSize=INVALID",
 			ExpectedCode: http.StatusOK,
-			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"},{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}]}]`,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","results":[{"status":200,"hostname":"example.org","duration":150000000,"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":true},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":true}],"success":true,"timestamp":"0001-01-01T00:00:00Z"},{"status":200,"hostname":"example.org","duration":750000000,"errors":["error-1","error-2"],"conditionResults":[{"condition":"[STATUS] == 200","success":true},{"condition":"[RESPONSE_TIME] \u003c 500","success":false},{"condition":"[CERTIFICATE_EXPIRATION] \u003c 72h","success":false}],"success":false,"timestamp":"0001-01-01T00:00:00Z"}],"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"}]`,
 		},
 	}
 
@@ -212,6 +247,84 @@ func TestEndpointStatuses(t *testing.T) {
 			if err != nil {
 				t.Error("expected err to be nil, but was", err)
 			}
+			// The endpoint is down since its last result was unsuccessful, but the moment it became unhealthy isn't fixed
+			body = downtimePattern.ReplaceAll(body, []byte(`"downSince":"<DOWN_SINCE>","downFor":"<DOWN_FOR>"`))
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n %s\n\ngot:\n %s", scenario.ExpectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestCompactEndpointStatuses(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	firstResult := &testSuccessfulResult
+	secondResult := &testUnsuccessfulResult
+	store.Get().Insert(&testEndpoint, firstResult)
+	store.Get().Insert(&testEndpoint, secondResult)
+	// Can't be bothered dealing with timezone issues on the worker that runs the automated tests
+	firstResult.Timestamp = time.Time{}
+	secondResult.Timestamp = time.Time{}
+	router := New(&config.Config{}).Router()
+	scenarios := []struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+		ExpectedBody string
+	}{
+		{
+			Name:         "default",
+			Path:         "/api/v1/endpoints/statuses/compact",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"key":"group_name","results":[{"success":false,"responseTime":750}]}]`,
+		},
+		{
+			Name:         "fields",
+			Path:         "/api/v1/endpoints/statuses/compact?fields=name,group,status,timestamp",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"name","group":"group","results":[{"status":200,"timestamp":-62135596800}]}]`,
+		},
+		{
+			Name:         "fields-without-result-fields",
+			Path:         "/api/v1/endpoints/statuses/compact?fields=key",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"key":"group_name"}]`,
+		},
+		{
+			Name:         "multiple-results",
+			Path:         "/api/v1/endpoints/statuses/compact?fields=success,key&results=2",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"key":"group_name","results":[{"success":true},{"success":false}]}]`,
+		},
+		{
+			Name:         "invalid-results-should-fall-back-to-default",
+			Path:         "/api/v1/endpoints/statuses/compact?fields=success&results=INVALID",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"results":[{"success":false}]}]`,
+		},
+		{
+			Name:         "unknown-field",
+			Path:         "/api/v1/endpoints/statuses/compact?fields=key,body",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `unknown field "body", supported fields are: key, name, group, success, status, responseTime, timestamp`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Error("expected err to be nil, but was", err)
+			}
 			if string(body) != scenario.ExpectedBody {
 				t.Errorf("expected:\n %s\n\ngot:\n %s", scenario.ExpectedBody, string(body))
 			}
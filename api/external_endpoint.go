@@ -1,32 +1,87 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
-	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/watchdog"
 	"github.com/gofiber/fiber/v2"
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// maximumExternalEndpointResultsPerRequest is the maximum number of results that can be pushed in a single request
+const maximumExternalEndpointResultsPerRequest = 1000
+
+// externalEndpointResultRequest is a result of an external endpoint pushed through the body of the request
+type externalEndpointResultRequest struct {
+	// Success is whether the execution was successful
+	Success *bool `json:"success"`
+
+	// Timestamp is when the execution happened. Defaults to the time at which the result is received.
+	Timestamp time.Time `json:"timestamp,omitempty"`
+
+	// Duration is how long the execution took (e.g. 1.5s)
+	Duration string `json:"duration,omitempty"`
+
+	// Errors are the errors to attach to the result
+	Errors []string `json:"errors,omitempty"`
+}
+
+// createExternalEndpointResultOperation documents CreateExternalEndpointResult
+var createExternalEndpointResultOperation = &openAPIOperation{
+	OperationID: "createExternalEndpointResult",
+	Summary:     "Push the result of an external endpoint, or a batch of results through the body of the request",
+	Tags:        []string{"endpoints"},
+	Parameters: []*openAPIParameter{
+		keyPathParameter,
+		{Name: "success", In: "query", Description: "Whether the execution was successful. Required unless results are pushed through the body of the request.", Schema: &openAPISchema{Type: "boolean"}},
+		{Name: "error", In: "query", Description: "Error to attach to the result", Schema: &openAPISchema{Type: "string"}},
+	},
+	Responses:           map[string]*openAPIResponse{"200": {Description: "Result(s) persisted"}, "400": badRequestResponse, "401": {Description: "Missing or invalid bearer token"}, "404": notFoundResponse, "500": internalErrorResponse},
+	Security:            []map[string][]string{{securitySchemeBearer: {}}},
+	requestBodyType:     []*externalEndpointResultRequest{},
+	requestBodyOptional: true,
+}
+
+// CreateExternalEndpointResult handles requests to push results of an external endpoint.
+//
+// A single result can be pushed through the success and error query parameters, or a batch of results can be pushed
+// through a JSON array in the body of the request (e.g. by an agent that buffered its results while offline), in which
+// case the results are processed in chronological order.
 func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Check if the success query parameter is present
-		success, exists := c.Queries()["success"]
-		if !exists || (success != "true" && success != "false") {
-			return c.Status(400).SendString("missing or invalid success query parameter")
-		}
-
-		// Get the error if present
-		resultError := c.Queries()["error"]
-		if resultError != "" {
-			resultError = sanitizeInput(resultError)
+		var results []*endpoint.Result
+		if c.Is("json") && len(c.Body()) > 0 {
+			var err error
+			if results, err = parseExternalEndpointResultsFromBody(c.Body()); err != nil {
+				return c.Status(400).SendString(err.Error())
+			}
+		} else {
+			// Check if the success query parameter is present
+			success, exists := c.Queries()["success"]
+			if !exists || (success != "true" && success != "false") {
+				return c.Status(400).SendString("missing or invalid success query parameter")
+			}
+			result := &endpoint.Result{
+				Timestamp: time.Now(),
+				Success:   c.QueryBool("success"),
+				Errors:    []string{},
+			}
+			// Get the error if present
+			if resultError := c.Queries()["error"]; resultError != "" {
+				result.Errors = append(result.Errors, sanitizeInput(resultError))
+			}
+			results = append(results, result)
 		}
 
 		// Check if the authorization bearer token header is correct
@@ -39,44 +94,108 @@ func CreateExternalEndpointResult(cfg *config.Config) fiber.Handler {
 			return c.Status(401).SendString("bearer token must not be empty")
 		}
 		key := c.Params("key")
-		externalEndpoint := cfg.GetExternalEndpointByKey(key)
+		// API keys reaching this handler grant the push-external scope, which has the same effect as the access token of
+		// a service account
+		isServiceAccount := security.GetAPIKeyFromContext(c) != nil || isServiceAccountToken(c.UserContext(), cfg, token)
+		var externalEndpoint *endpoint.ExternalEndpoint
+		if isServiceAccount {
+			externalEndpoint = cfg.GetExternalEndpointByKey(key)
+		} else {
+			externalEndpoint = cfg.GetOrCreateExternalEndpointByKey(key, token)
+		}
 		if externalEndpoint == nil {
 			log.Printf("[api.CreateExternalEndpointResult] External endpoint with key=%s not found", key)
 			return c.Status(404).SendString("not found")
 		}
-		if externalEndpoint.Token != token {
-			log.Printf("[api.CreateExternalEndpointResult] Invalid token for external endpoint with key=%s", key)
-			return c.Status(401).SendString("invalid token")
+		if !isServiceAccount {
+			if isValid, err := isValidExternalEndpointToken(externalEndpoint, token); err != nil {
+				log.Printf("[api.CreateExternalEndpointResult] Failed to retrieve tokens of external endpoint with key=%s: %s", key, err.Error())
+				return c.Status(500).SendString(err.Error())
+			} else if !isValid {
+				log.Printf("[api.CreateExternalEndpointResult] Invalid token for external endpoint with key=%s", key)
+				return c.Status(401).SendString("invalid token")
+			}
+		}
+		// Persist the results in the storage
+		for _, result := range results {
+			if err := insertExternalEndpointResult(cfg, externalEndpoint, result); err != nil {
+				if errors.Is(err, common.ErrEndpointNotFound) {
+					return c.Status(404).SendString(err.Error())
+				}
+				log.Printf("[api.CreateExternalEndpointResult] Failed to insert result in storage: %s", err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+		}
+		if len(results) == 1 {
+			log.Printf("[api.CreateExternalEndpointResult] Successfully inserted result for external endpoint with key=%s and success=%t", key, results[0].Success)
+		} else {
+			log.Printf("[api.CreateExternalEndpointResult] Successfully inserted %d results for external endpoint with key=%s", len(results), key)
+		}
+		// Return the result
+		return c.Status(200).SendString("")
+	}
+}
+
+// parseExternalEndpointResultsFromBody parses the results pushed through the body of a request and sorts them from
+// the oldest to the most recent, so that the alerts are handled in the order in which the executions happened
+func parseExternalEndpointResultsFromBody(body []byte) ([]*endpoint.Result, error) {
+	var requests []*externalEndpointResultRequest
+	if err := json.Unmarshal(body, &requests); err != nil {
+		return nil, errors.New("body must be a JSON array of results")
+	}
+	if len(requests) == 0 {
+		return nil, errors.New("body must contain at least one result")
+	}
+	if len(requests) > maximumExternalEndpointResultsPerRequest {
+		return nil, fmt.Errorf("body must not contain more than %d results", maximumExternalEndpointResultsPerRequest)
+	}
+	now := time.Now()
+	results := make([]*endpoint.Result, 0, len(requests))
+	for i, request := range requests {
+		if request == nil || request.Success == nil {
+			return nil, fmt.Errorf("missing success field in result at index %d", i)
 		}
-		// Persist the result in the storage
 		result := &endpoint.Result{
-			Timestamp: time.Now(),
-			Success:   c.QueryBool("success"),
+			Timestamp: request.Timestamp,
+			Success:   *request.Success,
 			Errors:    []string{},
 		}
-
-		if resultError != "" {
-			result.Errors = append(result.Errors, resultError)
+		if result.Timestamp.IsZero() {
+			result.Timestamp = now
+		} else if result.Timestamp.After(now) {
+			return nil, fmt.Errorf("timestamp of result at index %d must not be in the future", i)
 		}
-
-		convertedEndpoint := externalEndpoint.ToEndpoint()
-		if err := store.Get().Insert(convertedEndpoint, result); err != nil {
-			if errors.Is(err, common.ErrEndpointNotFound) {
-				return c.Status(404).SendString(err.Error())
+		if len(request.Duration) > 0 {
+			duration, err := time.ParseDuration(request.Duration)
+			if err != nil || duration < 0 {
+				return nil, fmt.Errorf("invalid duration of result at index %d", i)
 			}
-			log.Printf("[api.CreateExternalEndpointResult] Failed to insert result in storage: %s", err.Error())
-			return c.Status(500).SendString(err.Error())
+			result.Duration = duration
 		}
-		log.Printf("[api.CreateExternalEndpointResult] Successfully inserted result for external endpoint with key=%s and success=%s", c.Params("key"), success)
-		// Check if an alert should be triggered or resolved
-		if !cfg.Maintenance.IsUnderMaintenance() {
-			watchdog.HandleAlerting(convertedEndpoint, result, cfg.Alerting, cfg.Debug)
-			externalEndpoint.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
-			externalEndpoint.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+		for _, resultError := range request.Errors {
+			if resultError = sanitizeInput(resultError); resultError != "" {
+				result.Errors = append(result.Errors, resultError)
+			}
 		}
-		// Return the result
-		return c.Status(200).SendString("")
+		results = append(results, result)
 	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Timestamp.Before(results[j].Timestamp)
+	})
+	return results, nil
+}
+
+// isServiceAccountToken returns whether the token passed is the access token of a service account, which can push the
+// results of every configured external endpoint, but cannot create external endpoints through auto-create rules
+func isServiceAccountToken(ctx context.Context, cfg *config.Config, token string) bool {
+	return cfg.Security != nil && cfg.Security.IsAuthorizedServiceAccountToken(ctx, token)
+}
+
+// insertExternalEndpointResult persists a result pushed to an external endpoint, which also resets the heartbeat of
+// the external endpoint, if any. See watchdog.InsertExternalEndpointResult.
+func insertExternalEndpointResult(cfg *config.Config, externalEndpoint *endpoint.ExternalEndpoint, result *endpoint.Result) error {
+	externalEndpoint.MarkResultReceived(time.Now())
+	return watchdog.InsertExternalEndpointResult(cfg, externalEndpoint, result)
 }
 
 func sanitizeInput(s string) string {
@@ -3,7 +3,9 @@ package api
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
@@ -11,8 +13,10 @@ import (
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/test"
 )
 
 func TestCreateExternalEndpointResult(t *testing.T) {
@@ -152,6 +156,382 @@ func TestCreateExternalEndpointResult(t *testing.T) {
 	})
 }
 
+func TestCreateExternalEndpointResultWithAutoCreate(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "configured", Group: "jobs"}},
+		ExternalEndpointsAutoCreate: []*endpoint.ExternalEndpointAutoCreateRule{
+			{Group: "jobs", Name: "backup-*", Token: "backup-token"},
+			{Group: "jobs", Token: "token"},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	for _, rule := range cfg.ExternalEndpointsAutoCreate {
+		if err := rule.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name                           string
+		Path                           string
+		AuthorizationHeaderBearerToken string
+		ExpectedCode                   int
+	}{
+		{
+			Name:                           "bad-token",
+			Path:                           "/api/v1/endpoints/jobs_cleanup/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer bad-token",
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "group-without-rule",
+			Path:                           "/api/v1/endpoints/other_cleanup/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "key-of-endpoint",
+			Path:                           "/api/v1/endpoints/jobs_configured/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "token-of-rule-with-other-name",
+			Path:                           "/api/v1/endpoints/jobs_cleanup/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer backup-token",
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "created",
+			Path:                           "/api/v1/endpoints/jobs_cleanup/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   200,
+		},
+		{
+			Name:                           "created-with-token-of-matching-rule",
+			Path:                           "/api/v1/endpoints/jobs_backup-db/external?success=false",
+			AuthorizationHeaderBearerToken: "Bearer backup-token",
+			ExpectedCode:                   200,
+		},
+		{
+			Name:                           "existing-with-token-of-other-rule",
+			Path:                           "/api/v1/endpoints/jobs_backup-db/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   401,
+		},
+		{
+			Name:                           "existing",
+			Path:                           "/api/v1/endpoints/jobs_cleanup/external?success=false",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, http.NoBody)
+			request.Header.Set("Authorization", scenario.AuthorizationHeaderBearerToken)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-end-results", func(t *testing.T) {
+		if len(cfg.ExternalEndpoints) != 2 {
+			t.Fatalf("expected 2 external endpoints to have been created, got %d", len(cfg.ExternalEndpoints))
+		}
+		endpointStatus, err := store.Get().GetEndpointStatusByKey("jobs_cleanup", paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			t.Fatal("failed to get endpoint status:", err)
+		}
+		if len(endpointStatus.Results) != 2 || !endpointStatus.Results[0].Success || endpointStatus.Results[1].Success {
+			t.Errorf("expected a successful result followed by an unsuccessful one, got %+v", endpointStatus.Results)
+		}
+		if externalEndpoint := cfg.GetExternalEndpointByKey("jobs_cleanup"); externalEndpoint.Name != "cleanup" || externalEndpoint.Group != "jobs" {
+			t.Errorf("expected external endpoint jobs/cleanup, got %s", externalEndpoint.DisplayName())
+		}
+	})
+}
+
+func TestCreateExternalEndpointResultWithBatch(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Discord: &discord.AlertProvider{},
+		},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{
+				Name:   "n",
+				Group:  "g",
+				Token:  "token",
+				Alerts: []*alert.Alert{{Type: alert.TypeDiscord, FailureThreshold: 2, SuccessThreshold: 2}},
+			},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	api := New(cfg)
+	router := api.Router()
+	now := time.Now()
+	scenarios := []struct {
+		Name                           string
+		Body                           string
+		AuthorizationHeaderBearerToken string
+		ExpectedCode                   int
+	}{
+		{
+			Name:                           "not-an-array",
+			Body:                           `{"success":true}`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "empty-array",
+			Body:                           `[]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "missing-success",
+			Body:                           `[{"errors":["error"]}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "invalid-duration",
+			Body:                           `[{"success":true,"duration":"potato"}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "timestamp-in-the-future",
+			Body:                           `[{"success":true,"timestamp":"` + now.Add(time.Hour).Format(time.RFC3339) + `"}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "too-many-results",
+			Body:                           "[" + strings.Repeat(`{"success":true},`, maximumExternalEndpointResultsPerRequest) + `{"success":true}]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   400,
+		},
+		{
+			Name:                           "bad-token",
+			Body:                           `[{"success":true}]`,
+			AuthorizationHeaderBearerToken: "Bearer bad-token",
+			ExpectedCode:                   401,
+		},
+		{
+			Name: "batch",
+			// Out of order on purpose, as the results must be processed in chronological order
+			Body: `[
+				{"success":false,"timestamp":"` + now.Add(-time.Minute).Format(time.RFC3339) + `","errors":["second"]},
+				{"success":true,"timestamp":"` + now.Add(-2*time.Minute).Format(time.RFC3339) + `","duration":"1.5s"},
+				{"success":false,"timestamp":"` + now.Add(-30*time.Second).Format(time.RFC3339) + `","errors":["third","<script>alert(1)</script>"]}
+			]`,
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/endpoints/g_n/external", strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", scenario.AuthorizationHeaderBearerToken)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-end-results", func(t *testing.T) {
+		endpointStatus, err := store.Get().GetEndpointStatusByKey("g_n", paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			t.Fatal("failed to get endpoint status:", err)
+		}
+		if len(endpointStatus.Results) != 3 {
+			t.Fatalf("expected 3 results but got %d", len(endpointStatus.Results))
+		}
+		if !endpointStatus.Results[0].Success || endpointStatus.Results[0].Duration != 1500*time.Millisecond {
+			t.Errorf("expected first result to be successful with a duration of 1.5s, got %+v", endpointStatus.Results[0])
+		}
+		if endpointStatus.Results[1].Success || len(endpointStatus.Results[1].Errors) != 1 || endpointStatus.Results[1].Errors[0] != "second" {
+			t.Errorf("expected second result to be unsuccessful with the error 'second', got %+v", endpointStatus.Results[1])
+		}
+		if endpointStatus.Results[2].Success || len(endpointStatus.Results[2].Errors) != 1 || endpointStatus.Results[2].Errors[0] != "third" {
+			t.Errorf("expected third result to be unsuccessful with the sanitized errors, got %+v", endpointStatus.Results[2])
+		}
+		externalEndpointFromConfig := cfg.GetExternalEndpointByKey("g_n")
+		if externalEndpointFromConfig.NumberOfFailuresInARow != 2 {
+			t.Errorf("expected 2 failures in a row but got %d", externalEndpointFromConfig.NumberOfFailuresInARow)
+		}
+	})
+}
+
+func TestCreateExternalEndpointResultWithServiceAccount(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	issuer := test.NewOIDCIssuer(t)
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+		ExternalEndpointsAutoCreate: []*endpoint.ExternalEndpointAutoCreateRule{
+			{Group: "jobs", Token: "token"},
+		},
+		Security: &security.Config{
+			ServiceAccounts: &security.ServiceAccountsConfig{IssuerURL: issuer.URL, Audience: "api://gatus", RequiredScopes: []string{"gatus.write"}},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	for _, rule := range cfg.ExternalEndpointsAutoCreate {
+		if err := rule.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	api := New(cfg)
+	router := api.Router()
+	issueToken := func(scope string) string {
+		return issuer.IssueToken(t, map[string]any{
+			"iss":   issuer.URL,
+			"sub":   "ci-pipeline",
+			"aud":   "api://gatus",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": scope,
+		})
+	}
+	scenarios := []struct {
+		Name                           string
+		Path                           string
+		AuthorizationHeaderBearerToken string
+		ExpectedCode                   int
+	}{
+		{
+			Name:                           "service-account",
+			Path:                           "/api/v1/endpoints/g_n/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer " + issueToken("gatus.write"),
+			ExpectedCode:                   200,
+		},
+		{
+			Name:                           "service-account-without-required-scope",
+			Path:                           "/api/v1/endpoints/g_n/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer " + issueToken("gatus.read"),
+			ExpectedCode:                   401,
+		},
+		{
+			Name:                           "service-account-cannot-auto-create",
+			Path:                           "/api/v1/endpoints/jobs_cleanup/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer " + issueToken("gatus.write"),
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "static-token-still-accepted",
+			Path:                           "/api/v1/endpoints/g_n/external?success=false",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, http.NoBody)
+			request.Header.Set("Authorization", scenario.AuthorizationHeaderBearerToken)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	if len(cfg.ExternalEndpoints) != 1 {
+		t.Errorf("expected no external endpoint to have been created, got %d external endpoints", len(cfg.ExternalEndpoints))
+	}
+}
+
+func TestCreateExternalEndpointResultWithAPIKey(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+		ExternalEndpointsAutoCreate: []*endpoint.ExternalEndpointAutoCreateRule{
+			{Group: "jobs", Token: "token"},
+		},
+		Security: &security.Config{
+			APIKeys: []*security.APIKeyConfig{
+				{Name: "agent", Key: "push-0123456789abcdefghijklmnopqrstuvwxyz", Scopes: []security.APIKeyScope{security.APIKeyScopePushExternal}},
+				{Name: "dashboard", Key: "read-only-0123456789abcdefghijklmnopqrstuvwxyz", Scopes: []security.APIKeyScope{security.APIKeyScopeReadOnly}},
+			},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	for _, rule := range cfg.ExternalEndpointsAutoCreate {
+		if err := rule.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name                           string
+		Path                           string
+		AuthorizationHeaderBearerToken string
+		ExpectedCode                   int
+	}{
+		{
+			Name:                           "api-key-with-push-external-scope",
+			Path:                           "/api/v1/endpoints/g_n/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer push-0123456789abcdefghijklmnopqrstuvwxyz",
+			ExpectedCode:                   200,
+		},
+		{
+			Name:                           "api-key-without-push-external-scope",
+			Path:                           "/api/v1/endpoints/g_n/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer read-only-0123456789abcdefghijklmnopqrstuvwxyz",
+			ExpectedCode:                   403,
+		},
+		{
+			Name:                           "api-key-cannot-auto-create",
+			Path:                           "/api/v1/endpoints/jobs_cleanup/external?success=true",
+			AuthorizationHeaderBearerToken: "Bearer push-0123456789abcdefghijklmnopqrstuvwxyz",
+			ExpectedCode:                   404,
+		},
+		{
+			Name:                           "static-token-still-accepted",
+			Path:                           "/api/v1/endpoints/g_n/external?success=false",
+			AuthorizationHeaderBearerToken: "Bearer token",
+			ExpectedCode:                   200,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, http.NoBody)
+			request.Header.Set("Authorization", scenario.AuthorizationHeaderBearerToken)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	if len(cfg.ExternalEndpoints) != 1 {
+		t.Errorf("expected no external endpoint to have been created, got %d external endpoints", len(cfg.ExternalEndpoints))
+	}
+}
+
 func TestSanitize(t *testing.T) {
 	scenarios := []struct {
 		input  string
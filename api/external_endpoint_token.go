@@ -0,0 +1,165 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+var externalEndpointTokenIDPathParameter = &openAPIParameter{Name: "id", In: "path", Required: true, Description: "ID of the token", Schema: &openAPISchema{Type: "integer", Format: "int64"}}
+
+type createExternalEndpointTokenRequest struct {
+	// Name describes what the token is used by (e.g. the name of the agent)
+	Name string `json:"name"`
+
+	// Duration is how long the token is valid for (e.g. 720h). If empty, the token never expires.
+	Duration string `json:"duration,omitempty"`
+}
+
+type createExternalEndpointTokenResponse struct {
+	*endpoint.ExternalEndpointToken
+
+	// Token is the token itself, which cannot be retrieved afterward
+	Token string `json:"token"`
+}
+
+// getExternalEndpointTokensOperation documents ExternalEndpointTokens
+var getExternalEndpointTokensOperation = &openAPIOperation{
+	OperationID:  "getExternalEndpointTokens",
+	Summary:      "Retrieve the tokens created for an external endpoint, including the ones that expired or were revoked",
+	Tags:         []string{"endpoints"},
+	Parameters:   []*openAPIParameter{keyPathParameter},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Tokens of the external endpoint"}, "401": unauthorizedResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: []*endpoint.ExternalEndpointToken{},
+}
+
+// ExternalEndpointTokens handles requests to retrieve the tokens created for an external endpoint
+func ExternalEndpointTokens(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		externalEndpoint := cfg.GetExternalEndpointByKey(c.Params("key"))
+		if externalEndpoint == nil {
+			return c.Status(404).SendString("external endpoint not found")
+		}
+		tokens, err := store.Get().GetExternalEndpointTokens(externalEndpoint.Key())
+		if err != nil {
+			log.Printf("[api.ExternalEndpointTokens] Failed to retrieve tokens of external endpoint with key=%s: %s", externalEndpoint.Key(), err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		return c.Status(200).JSON(tokens)
+	}
+}
+
+// createExternalEndpointTokenOperation documents CreateExternalEndpointToken
+var createExternalEndpointTokenOperation = &openAPIOperation{
+	OperationID:     "createExternalEndpointToken",
+	Summary:         "Create a token with which results can be pushed to an external endpoint",
+	Tags:            []string{"endpoints"},
+	Parameters:      []*openAPIParameter{keyPathParameter},
+	Responses:       map[string]*openAPIResponse{"201": {Description: "Token created"}, "400": badRequestResponse, "401": unauthorizedResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	requestBodyType: createExternalEndpointTokenRequest{},
+	responseType:    createExternalEndpointTokenResponse{},
+}
+
+// CreateExternalEndpointToken handles requests to create a token for an external endpoint, which is usable right away
+// alongside the token configured for the external endpoint and the other tokens created
+func CreateExternalEndpointToken(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		externalEndpoint := cfg.GetExternalEndpointByKey(c.Params("key"))
+		if externalEndpoint == nil {
+			return c.Status(404).SendString("external endpoint not found")
+		}
+		var request createExternalEndpointTokenRequest
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body")
+		}
+		if len(request.Name) == 0 {
+			return c.Status(400).SendString("name must not be empty")
+		}
+		var duration time.Duration
+		if len(request.Duration) > 0 {
+			var err error
+			if duration, err = time.ParseDuration(request.Duration); err != nil || duration <= 0 {
+				return c.Status(400).SendString("invalid duration")
+			}
+		}
+		token, externalEndpointToken, err := endpoint.NewExternalEndpointToken(externalEndpoint, request.Name, duration)
+		if err != nil {
+			return c.Status(500).SendString(err.Error())
+		}
+		if err = store.Get().InsertExternalEndpointToken(externalEndpointToken); err != nil {
+			log.Printf("[api.CreateExternalEndpointToken] Failed to persist token of external endpoint with key=%s: %s", externalEndpoint.Key(), err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		log.Printf("[api.CreateExternalEndpointToken] Created token with id=%d and name=%s for external endpoint with key=%s", externalEndpointToken.ID, externalEndpointToken.Name, externalEndpoint.Key())
+		return c.Status(201).JSON(createExternalEndpointTokenResponse{ExternalEndpointToken: externalEndpointToken, Token: token})
+	}
+}
+
+// revokeExternalEndpointTokenOperation documents RevokeExternalEndpointToken
+var revokeExternalEndpointTokenOperation = &openAPIOperation{
+	OperationID: "revokeExternalEndpointToken",
+	Summary:     "Revoke a token of an external endpoint, which can no longer be used to push results",
+	Tags:        []string{"endpoints"},
+	Parameters:  []*openAPIParameter{keyPathParameter, externalEndpointTokenIDPathParameter},
+	Responses: map[string]*openAPIResponse{
+		"204": {Description: "Token revoked"},
+		"400": badRequestResponse,
+		"401": unauthorizedResponse,
+		"404": {Description: "External endpoint or token not found"},
+		"500": internalErrorResponse,
+	},
+}
+
+// RevokeExternalEndpointToken handles requests to revoke a token of an external endpoint
+func RevokeExternalEndpointToken(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		externalEndpoint := cfg.GetExternalEndpointByKey(c.Params("key"))
+		if externalEndpoint == nil {
+			return c.Status(404).SendString("external endpoint not found")
+		}
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			return c.Status(400).SendString("invalid id")
+		}
+		if err = store.Get().RevokeExternalEndpointToken(externalEndpoint.Key(), id); err != nil {
+			if errors.Is(err, common.ErrExternalEndpointTokenNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			log.Printf("[api.RevokeExternalEndpointToken] Failed to revoke token with id=%d of external endpoint with key=%s: %s", id, externalEndpoint.Key(), err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		log.Printf("[api.RevokeExternalEndpointToken] Revoked token with id=%d of external endpoint with key=%s", id, externalEndpoint.Key())
+		return c.SendStatus(204)
+	}
+}
+
+// isValidExternalEndpointToken returns whether a token grants access to push results to an external endpoint, which
+// is the case for the token configured for the external endpoint as well as for the active tokens created through the
+// API
+//
+// Tokens are compared in constant time to avoid leaking how much of a token is correct through the response time
+func isValidExternalEndpointToken(externalEndpoint *endpoint.ExternalEndpoint, token string) (bool, error) {
+	if subtle.ConstantTimeCompare([]byte(externalEndpoint.Token), []byte(token)) == 1 {
+		return true, nil
+	}
+	tokens, err := store.Get().GetExternalEndpointTokens(externalEndpoint.Key())
+	if err != nil {
+		return false, err
+	}
+	hash := endpoint.HashExternalEndpointToken(token)
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Hash), []byte(hash)) == 1 && t.IsActive() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestExternalEndpointTokens(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "configured-token"}},
+		Maintenance:       &maintenance.Config{},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+	router := New(cfg).Router()
+	send := func(method, path, body string, authenticated bool) *http.Response {
+		request := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		if authenticated {
+			request.SetBasicAuth("john.doe", "hunter2")
+		}
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+	push := func(token string) int {
+		request := httptest.NewRequest("POST", "/api/v1/endpoints/g_n/external?success=true", http.NoBody)
+		request.Header.Set("Authorization", "Bearer "+token)
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response.StatusCode
+	}
+	scenarios := []struct {
+		Name          string
+		Method        string
+		Path          string
+		Body          string
+		Authenticated bool
+		ExpectedCode  int
+	}{
+		{
+			Name:         "unauthenticated",
+			Method:       "POST",
+			Path:         "/api/v1/endpoints/g_n/external/tokens",
+			Body:         `{"name":"agent"}`,
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:          "external-endpoint-not-found",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/g_unknown/external/tokens",
+			Body:          `{"name":"agent"}`,
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+		{
+			Name:          "no-name",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/g_n/external/tokens",
+			Body:          `{}`,
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "invalid-duration",
+			Method:        "POST",
+			Path:          "/api/v1/endpoints/g_n/external/tokens",
+			Body:          `{"name":"agent","duration":"forever"}`,
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "revoke-invalid-id",
+			Method:        "DELETE",
+			Path:          "/api/v1/endpoints/g_n/external/tokens/abc",
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "revoke-unknown-token",
+			Method:        "DELETE",
+			Path:          "/api/v1/endpoints/g_n/external/tokens/999",
+			Authenticated: true,
+			ExpectedCode:  http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if response := send(scenario.Method, scenario.Path, scenario.Body, scenario.Authenticated); response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", scenario.Method, scenario.Path, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("rotation", func(t *testing.T) {
+		response := send("POST", "/api/v1/endpoints/g_n/external/tokens", `{"name":"agent-1","duration":"720h"}`, true)
+		if response.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status code %d, got %d", http.StatusCreated, response.StatusCode)
+		}
+		body, _ := io.ReadAll(response.Body)
+		var created createExternalEndpointTokenResponse
+		if err := json.Unmarshal(body, &created); err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if len(created.Token) == 0 || created.ID == 0 || created.ExpiresAt == nil || created.EndpointKey != "g_n" {
+			t.Fatalf("unexpected response: %s", string(body))
+		}
+		// Both the configured token and the token created can be used
+		if code := push("configured-token"); code != http.StatusOK {
+			t.Errorf("expected the configured token to be accepted, got %d", code)
+		}
+		if code := push(created.Token); code != http.StatusOK {
+			t.Errorf("expected the created token to be accepted, got %d", code)
+		}
+		// The token itself must never be returned again
+		response = send("GET", "/api/v1/endpoints/g_n/external/tokens", "", true)
+		body, _ = io.ReadAll(response.Body)
+		if bytes.Contains(body, []byte(created.Token)) {
+			t.Error("expected the token not to be returned when listing tokens")
+		}
+		var tokens []*endpoint.ExternalEndpointToken
+		if err := json.Unmarshal(body, &tokens); err != nil || len(tokens) != 1 || tokens[0].Name != "agent-1" {
+			t.Fatalf("unexpected tokens: %s", string(body))
+		}
+		if response = send("DELETE", "/api/v1/endpoints/g_n/external/tokens/"+strconv.FormatInt(created.ID, 10), "", true); response.StatusCode != http.StatusNoContent {
+			t.Fatalf("expected status code %d, got %d", http.StatusNoContent, response.StatusCode)
+		}
+		if code := push(created.Token); code != http.StatusUnauthorized {
+			t.Errorf("expected the revoked token to be rejected, got %d", code)
+		}
+		if code := push("configured-token"); code != http.StatusOK {
+			t.Errorf("expected the configured token to still be accepted, got %d", code)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// failureBreakdown is the number of failures of an endpoint by reason
+type failureBreakdown struct {
+	// Total is the number of failures across all reasons
+	Total int `json:"total"`
+
+	// Reasons are the failure reasons that were observed, from the most to the least frequent
+	Reasons []*failureReasonCount `json:"reasons"`
+}
+
+// failureReasonCount is the number of failures of an endpoint for a single reason
+type failureReasonCount struct {
+	Reason endpoint.FailureReason `json:"reason"`
+	Count  int                    `json:"count"`
+
+	// Ratio is the share of the failures that had this reason, as a value between 0 and 1
+	Ratio float64 `json:"ratio"`
+}
+
+// getFailureBreakdownOperation documents FailureBreakdown
+var getFailureBreakdownOperation = &openAPIOperation{
+	OperationID: "getFailureBreakdown",
+	Summary:     "Retrieve the number of failures of an endpoint by reason",
+	Tags:        []string{"endpoints"},
+	Parameters: []*openAPIParameter{
+		keyPathParameter,
+		{Name: "from", In: "query", Description: "Only count the failures that happened at or after this timestamp", Schema: &openAPISchema{Type: "string", Format: "date-time"}},
+		{Name: "to", In: "query", Description: "Only count the failures that happened at or before this timestamp (defaults to now)", Schema: &openAPISchema{Type: "string", Format: "date-time"}},
+	},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Failure breakdown of the endpoint"}, "400": badRequestResponse, "401": unauthorizedResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: &failureBreakdown{},
+}
+
+// FailureBreakdown handles requests to retrieve how many times an endpoint failed for each failure reason, so that
+// recurring root causes can be identified.
+//
+// Because the breakdown is computed from the results, only the results that are still stored are taken into account.
+func FailureBreakdown(c *fiber.Ctx) error {
+	key := c.Params("key")
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(key)) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
+	from, to, _, err := extractResultsTimeRangeAndOrderFromRequest(c)
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	failureCountByReason, err := store.Get().GetFailureBreakdownByKey(key, from, to)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.FailureBreakdown] Failed to retrieve failure breakdown: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	breakdown := &failureBreakdown{Reasons: make([]*failureReasonCount, 0, len(failureCountByReason))}
+	for reason, count := range failureCountByReason {
+		breakdown.Total += count
+		breakdown.Reasons = append(breakdown.Reasons, &failureReasonCount{Reason: reason, Count: count})
+	}
+	for _, reasonCount := range breakdown.Reasons {
+		reasonCount.Ratio = float64(reasonCount.Count) / float64(breakdown.Total)
+	}
+	sort.Slice(breakdown.Reasons, func(i, j int) bool {
+		if breakdown.Reasons[i].Count != breakdown.Reasons[j].Count {
+			return breakdown.Reasons[i].Count > breakdown.Reasons[j].Count
+		}
+		return breakdown.Reasons[i].Reason < breakdown.Reasons[j].Reason
+	})
+	return c.Status(200).JSON(breakdown)
+}
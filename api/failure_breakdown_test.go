@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestFailureBreakdown(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name:  "frontend",
+				Group: "core",
+			},
+		},
+	}
+	now := time.Now()
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, FailureReason: endpoint.FailureReasonTLS, Timestamp: now.Add(-2 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, FailureReason: endpoint.FailureReasonTimeout, Timestamp: now.Add(-time.Minute)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now.Add(-time.Minute)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, FailureReason: endpoint.FailureReasonTimeout, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, FailureReason: endpoint.FailureReasonCondition, Timestamp: now})
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name              string
+		Path              string
+		ExpectedCode      int
+		ExpectedBreakdown *failureBreakdown
+	}{
+		{
+			Name:         "all",
+			Path:         "/api/v1/endpoints/core_frontend/failures/breakdown",
+			ExpectedCode: http.StatusOK,
+			ExpectedBreakdown: &failureBreakdown{Total: 4, Reasons: []*failureReasonCount{
+				{Reason: endpoint.FailureReasonTimeout, Count: 2, Ratio: 0.5},
+				{Reason: endpoint.FailureReasonCondition, Count: 1, Ratio: 0.25},
+				{Reason: endpoint.FailureReasonTLS, Count: 1, Ratio: 0.25},
+			}},
+		},
+		{
+			Name:         "from",
+			Path:         "/api/v1/endpoints/core_frontend/failures/breakdown?from=" + now.Add(-time.Hour).Format(time.RFC3339),
+			ExpectedCode: http.StatusOK,
+			ExpectedBreakdown: &failureBreakdown{Total: 3, Reasons: []*failureReasonCount{
+				{Reason: endpoint.FailureReasonTimeout, Count: 2, Ratio: 2.0 / 3},
+				{Reason: endpoint.FailureReasonCondition, Count: 1, Ratio: 1.0 / 3},
+			}},
+		},
+		{
+			Name:              "no-failures",
+			Path:              "/api/v1/endpoints/core_frontend/failures/breakdown?to=" + now.Add(-3*time.Hour).Format(time.RFC3339),
+			ExpectedCode:      http.StatusOK,
+			ExpectedBreakdown: &failureBreakdown{Total: 0, Reasons: []*failureReasonCount{}},
+		},
+		{
+			Name:         "invalid-from",
+			Path:         "/api/v1/endpoints/core_frontend/failures/breakdown?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "from-after-to",
+			Path:         "/api/v1/endpoints/core_frontend/failures/breakdown?from=" + now.Add(time.Hour).Format(time.RFC3339) + "&to=" + now.Format(time.RFC3339),
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/failures/breakdown",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedBreakdown == nil {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var breakdown failureBreakdown
+			if err := json.Unmarshal(body, &breakdown); err != nil {
+				t.Fatal("failed to decode body:", err)
+			}
+			if breakdown.Total != scenario.ExpectedBreakdown.Total || len(breakdown.Reasons) != len(scenario.ExpectedBreakdown.Reasons) {
+				t.Fatalf("expected %d failures across %d reasons, got %s", scenario.ExpectedBreakdown.Total, len(scenario.ExpectedBreakdown.Reasons), body)
+			}
+			for i, expectedReason := range scenario.ExpectedBreakdown.Reasons {
+				if *breakdown.Reasons[i] != *expectedReason {
+					t.Errorf("expected reason %+v at index %d, got %+v", expectedReason, i, breakdown.Reasons[i])
+				}
+			}
+		})
+	}
+}
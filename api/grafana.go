@@ -0,0 +1,279 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// grafanaMetricUptime is the metric of the uptime of an endpoint, as a percentage, averaged per hour
+	grafanaMetricUptime = "uptime"
+
+	// grafanaMetricResponseTime is the metric of the response time of an endpoint in milliseconds, averaged per hour
+	grafanaMetricResponseTime = "response-time"
+
+	// grafanaMaximumTimeRange is the largest time range that can be queried, since every hour is a data point
+	grafanaMaximumTimeRange = 30 * 24 * time.Hour
+)
+
+// grafanaMetrics are the metrics available for each endpoint, in the order in which they're listed
+var grafanaMetrics = []string{grafanaMetricUptime, grafanaMetricResponseTime}
+
+// grafanaTimeRange is the time range of a request made by the Grafana JSON datasource
+type grafanaTimeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// grafanaSearchRequest is the body of a request made by the Grafana JSON datasource to list the metrics available
+type grafanaSearchRequest struct {
+	// Target is the text typed by the user, which the metrics returned must contain
+	Target string `json:"target,omitempty"`
+}
+
+// grafanaQueryRequest is the body of a request made by the Grafana JSON datasource to retrieve time series
+type grafanaQueryRequest struct {
+	Range   grafanaTimeRange      `json:"range"`
+	Targets []*grafanaQueryTarget `json:"targets"`
+}
+
+// grafanaQueryTarget is a time series requested by the Grafana JSON datasource
+type grafanaQueryTarget struct {
+	// Target is the metric, in the format <ENDPOINT_KEY>:<METRIC> (e.g. core_frontend:uptime)
+	Target string `json:"target"`
+}
+
+// grafanaTimeSeries is a time series returned to the Grafana JSON datasource
+type grafanaTimeSeries struct {
+	Target string `json:"target"`
+
+	// Datapoints are pairs of values and unix timestamps in milliseconds, from the oldest to the most recent
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// grafanaAnnotationsRequest is the body of a request made by the Grafana JSON datasource to retrieve annotations
+type grafanaAnnotationsRequest struct {
+	Range      grafanaTimeRange `json:"range"`
+	Annotation struct {
+		// Query is the key of the endpoint whose annotations should be returned. If empty, the annotations of all
+		// endpoints are returned.
+		Query string `json:"query,omitempty"`
+	} `json:"annotation"`
+}
+
+// grafanaAnnotation is an annotation returned to the Grafana JSON datasource
+type grafanaAnnotation struct {
+	// Time is the unix timestamp in milliseconds at which the alert was triggered
+	Time int64 `json:"time"`
+
+	// TimeEnd is the unix timestamp in milliseconds at which the alert was resolved, if it has been resolved
+	TimeEnd int64 `json:"timeEnd,omitempty"`
+
+	Title string   `json:"title"`
+	Text  string   `json:"text,omitempty"`
+	Tags  []string `json:"tags"`
+}
+
+// grafanaTestConnectionOperation documents GrafanaTestConnection
+var grafanaTestConnectionOperation = &openAPIOperation{
+	OperationID: "grafanaTestConnection",
+	Summary:     "Test the connection of the Grafana JSON datasource",
+	Tags:        []string{"grafana"},
+	Responses:   map[string]*openAPIResponse{"200": {Description: "Datasource reachable"}, "401": unauthorizedResponse},
+}
+
+// GrafanaTestConnection handles the requests made by the Grafana JSON datasource to test its connection
+func GrafanaTestConnection(c *fiber.Ctx) error {
+	return c.Status(200).SendString("OK")
+}
+
+// grafanaSearchOperation documents GrafanaSearch
+var grafanaSearchOperation = &openAPIOperation{
+	OperationID:         "grafanaSearch",
+	Summary:             "List the metrics that can be queried through the Grafana JSON datasource",
+	Tags:                []string{"grafana"},
+	Responses:           map[string]*openAPIResponse{"200": {Description: "Metrics, in the format <ENDPOINT_KEY>:<METRIC>"}, "400": badRequestResponse, "401": unauthorizedResponse, "500": internalErrorResponse},
+	requestBodyType:     grafanaSearchRequest{},
+	requestBodyOptional: true,
+	responseType:        []string{},
+}
+
+// GrafanaSearch handles the requests made by the Grafana JSON datasource to list the metrics available
+func GrafanaSearch(c *fiber.Ctx) error {
+	var request grafanaSearchRequest
+	if len(c.Body()) > 0 {
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body")
+		}
+	}
+	endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams())
+	if err != nil {
+		log.Printf("[api.GrafanaSearch] Failed to retrieve endpoint statuses: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	endpointStatuses = excludeTenantEndpointStatuses(endpointStatuses)
+	targets := make([]string, 0, len(endpointStatuses)*len(grafanaMetrics))
+	for _, endpointStatus := range endpointStatuses {
+		for _, metric := range grafanaMetrics {
+			if target := endpointStatus.Key + ":" + metric; strings.Contains(target, request.Target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return c.Status(200).JSON(targets)
+}
+
+// grafanaQueryOperation documents GrafanaQuery
+var grafanaQueryOperation = &openAPIOperation{
+	OperationID:     "grafanaQuery",
+	Summary:         "Retrieve the time series of metrics through the Grafana JSON datasource",
+	Tags:            []string{"grafana"},
+	Responses:       map[string]*openAPIResponse{"200": {Description: "Time series of the metrics requested"}, "400": badRequestResponse, "401": unauthorizedResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	requestBodyType: grafanaQueryRequest{},
+	responseType:    []*grafanaTimeSeries{},
+}
+
+// GrafanaQuery handles the requests made by the Grafana JSON datasource to retrieve the time series of metrics.
+//
+// Since the data points are computed from the hourly uptime statistics, each data point covers an hour.
+func GrafanaQuery(c *fiber.Ctx) error {
+	var request grafanaQueryRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(400).SendString("invalid request body")
+	}
+	if err := request.Range.validate(); err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	// Include the hour in which the range starts, since its data point covers the start of the range
+	from := request.Range.From.Truncate(time.Hour)
+	timeSeries := make([]*grafanaTimeSeries, 0, len(request.Targets))
+	for _, target := range request.Targets {
+		if target == nil || len(target.Target) == 0 {
+			continue
+		}
+		separatorIndex := strings.LastIndex(target.Target, ":")
+		if separatorIndex == -1 {
+			return c.Status(400).SendString(fmt.Sprintf("target '%s' must be in the format <ENDPOINT_KEY>:<METRIC>", target.Target))
+		}
+		key, metric := target.Target[:separatorIndex], target.Target[separatorIndex+1:]
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(key)) > 0 {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		var valueByHourlyUnixTimestamp map[int64]float64
+		var err error
+		switch metric {
+		case grafanaMetricUptime:
+			valueByHourlyUnixTimestamp, err = store.Get().GetHourlyUptimeByKey(key, from, request.Range.To)
+			for hourlyUnixTimestamp, uptime := range valueByHourlyUnixTimestamp {
+				valueByHourlyUnixTimestamp[hourlyUnixTimestamp] = uptime * 100
+			}
+		case grafanaMetricResponseTime:
+			var hourlyAverageResponseTimes map[int64]int
+			hourlyAverageResponseTimes, err = store.Get().GetHourlyAverageResponseTimeByKey(key, from, request.Range.To)
+			valueByHourlyUnixTimestamp = make(map[int64]float64, len(hourlyAverageResponseTimes))
+			for hourlyUnixTimestamp, averageResponseTime := range hourlyAverageResponseTimes {
+				valueByHourlyUnixTimestamp[hourlyUnixTimestamp] = float64(averageResponseTime)
+			}
+		default:
+			return c.Status(400).SendString(fmt.Sprintf("metric of target '%s' must be one of: %s", target.Target, strings.Join(grafanaMetrics, ", ")))
+		}
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			} else if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			log.Printf("[api.GrafanaQuery] Failed to retrieve %s of endpoint with key=%s: %s", metric, key, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		series := &grafanaTimeSeries{Target: target.Target, Datapoints: make([][2]float64, 0, len(valueByHourlyUnixTimestamp))}
+		for hourlyUnixTimestamp, value := range valueByHourlyUnixTimestamp {
+			series.Datapoints = append(series.Datapoints, [2]float64{value, float64(hourlyUnixTimestamp * 1000)})
+		}
+		sort.Slice(series.Datapoints, func(i, j int) bool {
+			return series.Datapoints[i][1] < series.Datapoints[j][1]
+		})
+		timeSeries = append(timeSeries, series)
+	}
+	return c.Status(200).JSON(timeSeries)
+}
+
+// grafanaAnnotationsOperation documents GrafanaAnnotations
+var grafanaAnnotationsOperation = &openAPIOperation{
+	OperationID:     "grafanaAnnotations",
+	Summary:         "Retrieve the alerts triggered during a time range as annotations of the Grafana JSON datasource",
+	Tags:            []string{"grafana"},
+	Responses:       map[string]*openAPIResponse{"200": {Description: "Annotations, from the most recently triggered alert to the least recently triggered"}, "400": badRequestResponse, "401": unauthorizedResponse, "500": internalErrorResponse},
+	requestBodyType: grafanaAnnotationsRequest{},
+	responseType:    []*grafanaAnnotation{},
+}
+
+// GrafanaAnnotations handles the requests made by the Grafana JSON datasource to retrieve annotations, which are the
+// alerts that were triggered during the time range requested
+func GrafanaAnnotations(c *fiber.Ctx) error {
+	var request grafanaAnnotationsRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(400).SendString("invalid request body")
+	}
+	if err := request.Range.validate(); err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	entries, err := store.Get().GetAlertHistory(request.Range.From, request.Range.To)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.GrafanaAnnotations] Failed to retrieve alert history: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	key := strings.TrimSpace(request.Annotation.Query)
+	annotations := make([]*grafanaAnnotation, 0, len(entries))
+	for _, entry := range entries {
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(entry.EndpointKey)) > 0 || (len(key) > 0 && entry.EndpointKey != key) {
+			continue
+		}
+		displayName := entry.EndpointName
+		if len(entry.EndpointGroup) > 0 {
+			displayName = entry.EndpointGroup + "/" + entry.EndpointName
+		}
+		annotation := &grafanaAnnotation{
+			Time:  entry.TriggeredAt.UnixMilli(),
+			Title: fmt.Sprintf("Alert triggered for %s", displayName),
+			Text:  entry.Description,
+			Tags:  []string{entry.EndpointKey, string(entry.AlertType)},
+		}
+		if entry.ResolvedAt != nil {
+			annotation.TimeEnd = entry.ResolvedAt.UnixMilli()
+		}
+		annotations = append(annotations, annotation)
+	}
+	return c.Status(200).JSON(annotations)
+}
+
+// validate validates the time range of a request made by the Grafana JSON datasource
+func (r *grafanaTimeRange) validate() error {
+	if r.From.IsZero() || r.To.IsZero() {
+		return errors.New("range must have both a 'from' and a 'to' timestamp")
+	}
+	if r.From.After(r.To) {
+		return common.ErrInvalidTimeRange
+	}
+	if r.To.Sub(r.From) > grafanaMaximumTimeRange {
+		return fmt.Errorf("range must not be longer than %s", grafanaMaximumTimeRange)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestGrafana(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+		},
+	}
+	now := time.Now().Truncate(time.Hour)
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: 100 * time.Millisecond, Timestamp: now.Add(-2 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Duration: 300 * time.Millisecond, Timestamp: now.Add(-2*time.Hour + time.Minute)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Duration: 50 * time.Millisecond, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(&endpoint.Endpoint{Name: "frontend", Group: "core", Tenant: "acme"}, &endpoint.Result{Success: true, Timestamp: now})
+	sent := history.DeliverySummary{Status: history.DeliveryStatusSent, Attempts: 1}
+	description := "frontend is down"
+	resolved := history.NewEntry(cfg.Endpoints[0], &alert.Alert{Type: alert.TypeSlack, Description: &description}, now.Add(-2*time.Hour), sent)
+	resolved.Resolve(now.Add(-time.Hour), &sent)
+	unresolved := history.NewEntry(cfg.Endpoints[1], &alert.Alert{Type: alert.TypeSlack}, now, sent)
+	for _, entry := range []*history.Entry{resolved, unresolved} {
+		_ = store.Get().InsertAlertHistoryEntry(entry)
+	}
+	api := New(cfg)
+	router := api.Router()
+	timeRange := `"range":{"from":"` + now.Add(-3*time.Hour+30*time.Minute).Format(time.RFC3339) + `","to":"` + now.Add(time.Minute).Format(time.RFC3339) + `"}`
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		Body         string
+		ExpectedCode int
+		ExpectedBody string
+	}{
+		{
+			Name:         "test-connection",
+			Method:       "GET",
+			Path:         "/api/v1/grafana",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: "OK",
+		},
+		{
+			Name:         "search",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/search",
+			Body:         `{"target":""}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `["core_backend:response-time","core_backend:uptime","core_frontend:response-time","core_frontend:uptime"]`,
+		},
+		{
+			Name:         "search-with-target",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/search",
+			Body:         `{"target":"frontend:up"}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `["core_frontend:uptime"]`,
+		},
+		{
+			Name:         "search-without-body",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/search",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `["core_backend:response-time","core_backend:uptime","core_frontend:response-time","core_frontend:uptime"]`,
+		},
+		{
+			Name:         "query",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{` + timeRange + `,"targets":[{"target":"core_frontend:uptime"},{"target":"core_frontend:response-time"}]}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"target":"core_frontend:uptime","datapoints":[[50,` + unixMilli(now.Add(-2*time.Hour)) + `],[100,` + unixMilli(now) + `]]},{"target":"core_frontend:response-time","datapoints":[[200,` + unixMilli(now.Add(-2*time.Hour)) + `],[50,` + unixMilli(now) + `]]}]`,
+		},
+		{
+			Name:         "query-with-invalid-target",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{` + timeRange + `,"targets":[{"target":"core_frontend"}]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "query-with-invalid-metric",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{` + timeRange + `,"targets":[{"target":"core_frontend:potato"}]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "query-with-unknown-endpoint",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{` + timeRange + `,"targets":[{"target":"core_potato:uptime"}]}`,
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "query-with-tenant-endpoint",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{` + timeRange + `,"targets":[{"target":"acme_core_frontend:uptime"}]}`,
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "query-without-range",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{"targets":[{"target":"core_frontend:uptime"}]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "query-with-range-too-long",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/query",
+			Body:         `{"range":{"from":"` + now.Add(-grafanaMaximumTimeRange-time.Hour).Format(time.RFC3339) + `","to":"` + now.Format(time.RFC3339) + `"},"targets":[{"target":"core_frontend:uptime"}]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "annotations",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/annotations",
+			Body:         `{` + timeRange + `,"annotation":{"query":""}}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"time":` + unixMilli(now) + `,"title":"Alert triggered for core/backend","tags":["core_backend","slack"]},{"time":` + unixMilli(now.Add(-2*time.Hour)) + `,"timeEnd":` + unixMilli(now.Add(-time.Hour)) + `,"title":"Alert triggered for core/frontend","text":"frontend is down","tags":["core_frontend","slack"]}]`,
+		},
+		{
+			Name:         "annotations-of-endpoint",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/annotations",
+			Body:         `{` + timeRange + `,"annotation":{"query":"core_backend"}}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"time":` + unixMilli(now) + `,"title":"Alert triggered for core/backend","tags":["core_backend","slack"]}]`,
+		},
+		{
+			Name:         "annotations-with-invalid-body",
+			Method:       "POST",
+			Path:         "/api/v1/grafana/annotations",
+			Body:         `potato`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if len(scenario.ExpectedBody) == 0 {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			if scenario.ExpectedBody == "OK" {
+				if string(body) != scenario.ExpectedBody {
+					t.Errorf("expected body %s, got %s", scenario.ExpectedBody, body)
+				}
+				return
+			}
+			var actual, expected any
+			if err := json.Unmarshal(body, &actual); err != nil {
+				t.Fatalf("failed to unmarshal body %s: %s", body, err)
+			}
+			if err := json.Unmarshal([]byte(scenario.ExpectedBody), &expected); err != nil {
+				t.Fatalf("failed to unmarshal expected body: %s", err)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected body %s, got %s", scenario.ExpectedBody, body)
+			}
+		})
+	}
+}
+
+func unixMilli(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
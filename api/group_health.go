@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+var errInvalidQuorum = errors.New("quorum must be a positive number of endpoints (e.g. 2) or a percentage (e.g. 50%)")
+
+// groupHealthOperation documents GroupHealth
+var groupHealthOperation = &openAPIOperation{
+	OperationID: "getGroupHealth",
+	Summary:     "Get the health of a group, which is healthy if all or a quorum of its endpoints are healthy",
+	Tags:        []string{"groups"},
+	Parameters: []*openAPIParameter{
+		{Name: "group", In: "path", Required: true, Description: "Name of the group", Schema: &openAPISchema{Type: "string"}},
+		{Name: "quorum", In: "query", Description: "Number (e.g. 2) or percentage (e.g. 50%) of endpoints that must be healthy. Defaults to all of them.", Schema: &openAPISchema{Type: "string"}},
+	},
+	Responses: map[string]*openAPIResponse{
+		"200": {Description: "The group is healthy"},
+		"400": badRequestResponse,
+		"404": {Description: "Group not found"},
+		"500": internalErrorResponse,
+		"503": {Description: "The group is unhealthy"},
+	},
+	responseType: GroupHealthResponse{},
+}
+
+// GroupHealthResponse is the response of GroupHealth
+type GroupHealthResponse struct {
+	Group string `json:"group"`
+
+	// Healthy is whether at least Quorum endpoints of the group were healthy
+	Healthy bool `json:"healthy"`
+
+	// Quorum is the number of endpoints that must be healthy for the group to be healthy
+	Quorum int `json:"quorum"`
+
+	// Total is the number of enabled endpoints in the group
+	Total int `json:"total"`
+
+	// Up is the number of endpoints whose last result was successful
+	Up int `json:"up"`
+
+	// Down is the number of endpoints whose last result was unsuccessful
+	Down int `json:"down"`
+
+	// Unknown is the number of endpoints that have no result yet, which are not considered as healthy
+	Unknown int `json:"unknown"`
+}
+
+// GroupHealth returns the health of a group based on the last result of each of its enabled endpoints, responding with
+// a 200 if the group is healthy and a 503 otherwise, which allows load balancers to make decisions based on it
+func GroupHealth(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		group, err := url.PathUnescape(c.Params("group"))
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		// Endpoints of a tenant are excluded, since this route is unprotected and must not reveal anything about them
+		var keys []string
+		for _, ep := range cfg.Endpoints {
+			if ep.Group == group && ep.IsEnabled() && len(ep.Tenant) == 0 {
+				keys = append(keys, ep.Key())
+			}
+		}
+		for _, ee := range cfg.GetExternalEndpoints() {
+			if ee.Group == group && ee.IsEnabled() && len(ee.Tenant) == 0 {
+				keys = append(keys, ee.Key())
+			}
+		}
+		if len(keys) == 0 {
+			return c.Status(404).SendString("group not found")
+		}
+		quorum, err := parseQuorum(c.Query("quorum"), len(keys))
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		response := GroupHealthResponse{Group: group, Quorum: quorum, Total: len(keys)}
+		for _, key := range keys {
+			status, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams().WithResults(1, 1))
+			if err != nil && !errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(500).SendString(err.Error())
+			}
+			if status == nil || len(status.Results) == 0 {
+				response.Unknown++
+			} else if status.Results[0].Success {
+				response.Up++
+			} else {
+				response.Down++
+			}
+		}
+		response.Healthy = response.Up >= response.Quorum
+		c.Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		if !response.Healthy {
+			return c.Status(503).JSON(response)
+		}
+		return c.Status(200).JSON(response)
+	}
+}
+
+// parseQuorum returns the number of endpoints that must be healthy out of the total number of endpoints passed based
+// on a quorum that's either a number of endpoints or a percentage of them.
+//
+// If no quorum is specified, every endpoint must be healthy. A quorum greater than the total number of endpoints is
+// capped to the total number of endpoints.
+func parseQuorum(quorum string, total int) (int, error) {
+	if len(quorum) == 0 {
+		return total, nil
+	}
+	if percentage, isPercentage := strings.CutSuffix(quorum, "%"); isPercentage {
+		value, err := strconv.ParseFloat(percentage, 64)
+		if err != nil || value <= 0 || value > 100 {
+			return 0, errInvalidQuorum
+		}
+		return int(math.Ceil(value / 100 * float64(total))), nil
+	}
+	value, err := strconv.Atoi(quorum)
+	if err != nil || value <= 0 {
+		return 0, errInvalidQuorum
+	}
+	return min(value, total), nil
+}
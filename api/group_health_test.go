@@ -0,0 +1,147 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestGroupHealth(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	disabled := false
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core"},
+			{Name: "database", Group: "core"},
+			{Name: "queue", Group: "core", Enabled: &disabled},
+			{Name: "website", Group: "public"},
+			{Name: "docs", Group: "with spaces"},
+			{Name: "billing", Group: "core", Tenant: "acme"},
+			{Name: "invoices", Group: "acme", Tenant: "acme"},
+		},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[2], &endpoint.Result{Success: false, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[3], &endpoint.Result{Success: false, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[5], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	api := New(cfg)
+	router := api.Router()
+	type Scenario struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "all-endpoints-by-default",
+			Path:         "/api/v1/groups/core/health",
+			ExpectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			Name:         "quorum-number-reached",
+			Path:         "/api/v1/groups/core/health?quorum=2",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "quorum-number-not-reached",
+			Path:         "/api/v1/groups/core/health?quorum=3",
+			ExpectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			Name:         "quorum-number-greater-than-total",
+			Path:         "/api/v1/groups/core/health?quorum=10",
+			ExpectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			Name:         "quorum-percentage-reached",
+			Path:         "/api/v1/groups/core/health?quorum=66%25",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "quorum-percentage-not-reached",
+			Path:         "/api/v1/groups/core/health?quorum=67%25",
+			ExpectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			Name:         "invalid-quorum",
+			Path:         "/api/v1/groups/core/health?quorum=-1",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-quorum-percentage",
+			Path:         "/api/v1/groups/core/health?quorum=150%25",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "endpoint-without-results",
+			Path:         "/api/v1/groups/public/health",
+			ExpectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			Name:         "escaped-group",
+			Path:         "/api/v1/groups/with%20spaces/health",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "group-with-only-tenant-endpoints",
+			Path:         "/api/v1/groups/acme/health",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "unknown-group",
+			Path:         "/api/v1/groups/unknown/health",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestParseQuorum(t *testing.T) {
+	scenarios := []struct {
+		quorum         string
+		total          int
+		expectedQuorum int
+		expectedErr    error
+	}{
+		{quorum: "", total: 4, expectedQuorum: 4},
+		{quorum: "2", total: 4, expectedQuorum: 2},
+		{quorum: "5", total: 4, expectedQuorum: 4},
+		{quorum: "50%", total: 4, expectedQuorum: 2},
+		{quorum: "51%", total: 4, expectedQuorum: 3},
+		{quorum: "100%", total: 4, expectedQuorum: 4},
+		{quorum: "0", total: 4, expectedErr: errInvalidQuorum},
+		{quorum: "0%", total: 4, expectedErr: errInvalidQuorum},
+		{quorum: "abc", total: 4, expectedErr: errInvalidQuorum},
+	}
+	for _, scenario := range scenarios {
+		t.Run("quorum-"+scenario.quorum, func(t *testing.T) {
+			quorum, err := parseQuorum(scenario.quorum, scenario.total)
+			if err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if quorum != scenario.expectedQuorum {
+				t.Errorf("expected quorum %d, got %d", scenario.expectedQuorum, quorum)
+			}
+		})
+	}
+}
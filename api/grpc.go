@@ -0,0 +1,277 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	gatusv1 "github.com/TwiN/gatus/v5/proto/gatus/v1"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCServer is the implementation of gatusv1.GatusServiceServer
+type GRPCServer struct {
+	gatusv1.UnimplementedGatusServiceServer
+
+	cfg *config.Config
+}
+
+// NewGRPCServer creates a new GRPCServer
+func NewGRPCServer(cfg *config.Config) *GRPCServer {
+	return &GRPCServer{cfg: cfg}
+}
+
+// Register creates a grpc.Server with the GatusService registered
+func (s *GRPCServer) Register(options ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(options...)
+	gatusv1.RegisterGatusServiceServer(server, s)
+	return server
+}
+
+// GetEndpointStatuses returns the status of all endpoints
+func (s *GRPCServer) GetEndpointStatuses(ctx context.Context, request *gatusv1.GetEndpointStatusesRequest) (*gatusv1.GetEndpointStatusesResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	page, pageSize := validatePageAndPageSize(int(request.GetPage()), int(request.GetPageSize()))
+	endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
+	if err != nil {
+		log.Printf("[api.GetEndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	endpointStatuses = excludeTenantEndpointStatuses(endpointStatuses)
+	response := &gatusv1.GetEndpointStatusesResponse{EndpointStatuses: make([]*gatusv1.EndpointStatus, 0, len(endpointStatuses))}
+	for _, endpointStatus := range endpointStatuses {
+		response.EndpointStatuses = append(response.EndpointStatuses, toProtoEndpointStatus(endpointStatus))
+	}
+	return response, nil
+}
+
+// GetEndpointStatus returns the status of a single endpoint by its key
+func (s *GRPCServer) GetEndpointStatus(ctx context.Context, request *gatusv1.GetEndpointStatusRequest) (*gatusv1.GetEndpointStatusResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(request.GetKey())) > 0 {
+		return nil, status.Error(codes.NotFound, common.ErrEndpointNotFound.Error())
+	}
+	page, pageSize := validatePageAndPageSize(int(request.GetPage()), int(request.GetPageSize()))
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(request.GetKey(), paging.NewEndpointStatusParams().WithResults(page, pageSize).WithEvents(1, common.MaximumNumberOfEvents))
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		log.Printf("[api.GetEndpointStatus] Failed to retrieve endpoint status: %s", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if endpointStatus == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &gatusv1.GetEndpointStatusResponse{EndpointStatus: toProtoEndpointStatus(endpointStatus)}, nil
+}
+
+// PushExternalEndpointResult pushes the result of an external endpoint
+func (s *GRPCServer) PushExternalEndpointResult(ctx context.Context, request *gatusv1.PushExternalEndpointResultRequest) (*gatusv1.PushExternalEndpointResultResponse, error) {
+	token, _ := strings.CutPrefix(getAuthorizationFromMetadata(ctx), "Bearer ")
+	token = strings.TrimSpace(token)
+	if len(token) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "bearer token must not be empty")
+	}
+	isServiceAccount := isServiceAccountToken(ctx, s.cfg, token)
+	var externalEndpoint *endpoint.ExternalEndpoint
+	if isServiceAccount {
+		externalEndpoint = s.cfg.GetExternalEndpointByKey(request.GetKey())
+	} else {
+		externalEndpoint = s.cfg.GetOrCreateExternalEndpointByKey(request.GetKey(), token)
+	}
+	if externalEndpoint == nil {
+		log.Printf("[api.PushExternalEndpointResult] External endpoint with key=%s not found", request.GetKey())
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if !isServiceAccount {
+		if isValid, err := isValidExternalEndpointToken(externalEndpoint, token); err != nil {
+			log.Printf("[api.PushExternalEndpointResult] Failed to retrieve tokens of external endpoint with key=%s: %s", request.GetKey(), err.Error())
+			return nil, status.Error(codes.Internal, err.Error())
+		} else if !isValid {
+			log.Printf("[api.PushExternalEndpointResult] Invalid token for external endpoint with key=%s", request.GetKey())
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+	}
+	result := &endpoint.Result{
+		Timestamp: time.Now(),
+		Success:   request.GetSuccess(),
+		Errors:    []string{},
+	}
+	if len(request.GetError()) > 0 {
+		result.Errors = append(result.Errors, sanitizeInput(request.GetError()))
+	}
+	if err := insertExternalEndpointResult(s.cfg, externalEndpoint, result); err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		log.Printf("[api.PushExternalEndpointResult] Failed to insert result in storage: %s", err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("[api.PushExternalEndpointResult] Successfully inserted result for external endpoint with key=%s and success=%v", request.GetKey(), request.GetSuccess())
+	return &gatusv1.PushExternalEndpointResultResponse{}, nil
+}
+
+// GetSilences returns the silences that haven't expired yet
+func (s *GRPCServer) GetSilences(ctx context.Context, _ *gatusv1.GetSilencesRequest) (*gatusv1.GetSilencesResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	response := &gatusv1.GetSilencesResponse{Silences: []*gatusv1.Silence{}}
+	for _, endpointSilence := range silence.GetAll() {
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(endpointSilence.EndpointKey)) > 0 {
+			continue
+		}
+		response.Silences = append(response.Silences, toProtoSilence(endpointSilence))
+	}
+	return response, nil
+}
+
+// SilenceEndpointAlerts prevents the alerts of an endpoint from being sent for a duration
+func (s *GRPCServer) SilenceEndpointAlerts(ctx context.Context, request *gatusv1.SilenceEndpointAlertsRequest) (*gatusv1.SilenceEndpointAlertsResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if !s.isSilenceableEndpoint(request.GetKey()) {
+		return nil, status.Error(codes.NotFound, common.ErrEndpointNotFound.Error())
+	}
+	endpointSilence, err := silence.NewSilence(request.GetKey(), request.GetDuration().AsDuration(), sanitizeInput(request.GetReason()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	silence.Set(endpointSilence)
+	log.Printf("[api.SilenceEndpointAlerts] Silenced alerts of endpoint with key=%s until %s", request.GetKey(), endpointSilence.ExpiresAt.Format(time.RFC3339))
+	return &gatusv1.SilenceEndpointAlertsResponse{Silence: toProtoSilence(endpointSilence)}, nil
+}
+
+// UnsilenceEndpointAlerts removes the silence of an endpoint
+func (s *GRPCServer) UnsilenceEndpointAlerts(ctx context.Context, request *gatusv1.UnsilenceEndpointAlertsRequest) (*gatusv1.UnsilenceEndpointAlertsResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if !s.isSilenceableEndpoint(request.GetKey()) || !silence.Delete(request.GetKey()) {
+		return nil, status.Error(codes.NotFound, "endpoint is not silenced")
+	}
+	log.Printf("[api.UnsilenceEndpointAlerts] Removed silence of endpoint with key=%s", request.GetKey())
+	return &gatusv1.UnsilenceEndpointAlertsResponse{}, nil
+}
+
+// isSilenceableEndpoint returns whether the key passed is the key of a configured endpoint or external endpoint that
+// doesn't belong to a tenant
+func (s *GRPCServer) isSilenceableEndpoint(key string) bool {
+	// The endpoints of tenants can only be managed through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(key)) > 0 {
+		return false
+	}
+	return s.cfg.GetEndpointByKey(key) != nil || s.cfg.GetExternalEndpointByKey(key) != nil
+}
+
+// authenticate validates the credentials passed through the authorization metadata against the security configuration.
+//
+// Because the gRPC API has no concept of sessions, only basic authentication and the access tokens of service accounts
+// are supported. If the security configuration only has OIDC configured, the status queries cannot be used.
+func (s *GRPCServer) authenticate(ctx context.Context) error {
+	if s.cfg.Security == nil || !s.cfg.Security.IsValid() {
+		return nil
+	}
+	authorization := getAuthorizationFromMetadata(ctx)
+	if token, found := strings.CutPrefix(authorization, "Bearer "); found && isServiceAccountToken(ctx, s.cfg, strings.TrimSpace(token)) {
+		return nil
+	}
+	if s.cfg.Security.Basic == nil {
+		return status.Error(codes.Unauthenticated, "only basic authentication and service accounts are supported by the gRPC API")
+	}
+	encodedCredentials, found := strings.CutPrefix(authorization, "Basic ")
+	if !found {
+		return status.Error(codes.Unauthenticated, "missing basic authentication credentials")
+	}
+	decodedCredentials, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedCredentials))
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "invalid basic authentication credentials")
+	}
+	username, password, _ := strings.Cut(string(decodedCredentials), ":")
+	if !s.cfg.Security.Basic.IsAuthorized(username, password) {
+		return status.Error(codes.Unauthenticated, "invalid basic authentication credentials")
+	}
+	return nil
+}
+
+func getAuthorizationFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func toProtoEndpointStatus(endpointStatus *endpoint.Status) *gatusv1.EndpointStatus {
+	protoEndpointStatus := &gatusv1.EndpointStatus{
+		Name:    endpointStatus.Name,
+		Group:   endpointStatus.Group,
+		Key:     endpointStatus.Key,
+		Results: make([]*gatusv1.Result, 0, len(endpointStatus.Results)),
+		Events:  make([]*gatusv1.Event, 0, len(endpointStatus.Events)),
+	}
+	for _, result := range endpointStatus.Results {
+		protoResult := &gatusv1.Result{
+			HttpStatus:            int32(result.HTTPStatus),
+			DnsRcode:              result.DNSRCode,
+			Hostname:              result.Hostname,
+			Ip:                    result.IP,
+			Connected:             result.Connected,
+			Duration:              durationpb.New(result.Duration),
+			Errors:                result.Errors,
+			Success:               result.Success,
+			Timestamp:             timestamppb.New(result.Timestamp),
+			CertificateExpiration: durationpb.New(result.CertificateExpiration),
+			DomainExpiration:      durationpb.New(result.DomainExpiration),
+		}
+		for _, conditionResult := range result.ConditionResults {
+			protoResult.ConditionResults = append(protoResult.ConditionResults, &gatusv1.ConditionResult{
+				Condition: conditionResult.Condition,
+				Success:   conditionResult.Success,
+			})
+		}
+		protoEndpointStatus.Results = append(protoEndpointStatus.Results, protoResult)
+	}
+	for _, event := range endpointStatus.Events {
+		protoEndpointStatus.Events = append(protoEndpointStatus.Events, &gatusv1.Event{
+			Type:      string(event.Type),
+			Timestamp: timestamppb.New(event.Timestamp),
+		})
+	}
+	return protoEndpointStatus
+}
+
+func toProtoSilence(endpointSilence *silence.Silence) *gatusv1.Silence {
+	return &gatusv1.Silence{
+		Key:       endpointSilence.EndpointKey,
+		Reason:    endpointSilence.Reason,
+		CreatedAt: timestamppb.New(endpointSilence.CreatedAt),
+		ExpiresAt: timestamppb.New(endpointSilence.ExpiresAt),
+	}
+}
@@ -0,0 +1,465 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/silence"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/web"
+	gatusv1 "github.com/TwiN/gatus/v5/proto/gatus/v1"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newGRPCTestClient(t *testing.T, cfg *config.Config) gatusv1.GatusServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewGRPCServer(cfg).Register()
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	connection, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = connection.Close()
+	})
+	return gatusv1.NewGatusServiceClient(connection)
+}
+
+func TestGRPCServer_GetEndpointStatuses(t *testing.T) {
+	defer store.Get().Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{
+		HTTPStatus:       200,
+		Hostname:         "example.org",
+		Duration:         150 * time.Millisecond,
+		ConditionResults: []*endpoint.ConditionResult{{Condition: "[STATUS] == 200", Success: true}},
+		Success:          true,
+		Timestamp:        time.Now(),
+	})
+	client := newGRPCTestClient(t, cfg)
+	response, err := client.GetEndpointStatuses(context.Background(), &gatusv1.GetEndpointStatusesRequest{})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(response.GetEndpointStatuses()) != 1 {
+		t.Fatalf("expected 1 endpoint status, got %d", len(response.GetEndpointStatuses()))
+	}
+	endpointStatus := response.GetEndpointStatuses()[0]
+	if endpointStatus.GetKey() != "core_frontend" {
+		t.Errorf("expected key to be core_frontend, got %s", endpointStatus.GetKey())
+	}
+	if len(endpointStatus.GetResults()) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(endpointStatus.GetResults()))
+	}
+	result := endpointStatus.GetResults()[0]
+	if result.GetHttpStatus() != 200 || !result.GetSuccess() || result.GetDuration().AsDuration() != 150*time.Millisecond {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if len(result.GetConditionResults()) != 1 || result.GetConditionResults()[0].GetCondition() != "[STATUS] == 200" {
+		t.Errorf("unexpected condition results: %v", result.GetConditionResults())
+	}
+}
+
+func TestGRPCServer_GetEndpointStatus(t *testing.T) {
+	defer store.Get().Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: time.Now()})
+	client := newGRPCTestClient(t, cfg)
+	scenarios := []struct {
+		name         string
+		key          string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "existing-endpoint",
+			key:          "core_frontend",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "non-existing-endpoint",
+			key:          "core_backend",
+			expectedCode: codes.NotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			response, err := client.GetEndpointStatus(context.Background(), &gatusv1.GetEndpointStatusRequest{Key: scenario.key})
+			if status.Code(err) != scenario.expectedCode {
+				t.Fatalf("expected code %s, got %s", scenario.expectedCode, status.Code(err))
+			}
+			if scenario.expectedCode == codes.OK {
+				if response.GetEndpointStatus().GetKey() != scenario.key {
+					t.Errorf("expected key to be %s, got %s", scenario.key, response.GetEndpointStatus().GetKey())
+				}
+				if len(response.GetEndpointStatus().GetEvents()) == 0 {
+					t.Error("expected events to be returned")
+				}
+			}
+		})
+	}
+}
+
+func TestGRPCServer_Authentication(t *testing.T) {
+	defer store.Get().Clear()
+	scenarios := []struct {
+		name          string
+		security      *security.Config
+		authorization string
+		expectedCode  codes.Code
+	}{
+		{
+			name:         "no-security",
+			expectedCode: codes.OK,
+		},
+		{
+			name: "basic-without-credentials",
+			security: &security.Config{Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			}},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name: "basic-with-bad-credentials",
+			security: &security.Config{Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			}},
+			authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("john.doe:hunter3")),
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name: "basic-with-good-credentials",
+			security: &security.Config{Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			}},
+			authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("john.doe:hunter2")),
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "oidc",
+			security: &security.Config{OIDC: &security.OIDCConfig{
+				IssuerURL:       "https://sso.gatus.io/",
+				RedirectURL:     "http://localhost:80/authorization-code/callback",
+				ClientID:        "client-id",
+				ClientSecret:    "client-secret",
+				Scopes:          []string{"openid"},
+				AllowedSubjects: []string{"user1@example.com"},
+			}},
+			authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("john.doe:hunter2")),
+			expectedCode:  codes.Unauthenticated,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			client := newGRPCTestClient(t, &config.Config{Security: scenario.security})
+			ctx := context.Background()
+			if len(scenario.authorization) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", scenario.authorization)
+			}
+			_, err := client.GetEndpointStatuses(ctx, &gatusv1.GetEndpointStatusesRequest{})
+			if status.Code(err) != scenario.expectedCode {
+				t.Errorf("expected code %s, got %s", scenario.expectedCode, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestGRPCServer_PushExternalEndpointResult(t *testing.T) {
+	defer store.Get().Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+		Maintenance:       &maintenance.Config{},
+	}
+	client := newGRPCTestClient(t, cfg)
+	scenarios := []struct {
+		name          string
+		key           string
+		authorization string
+		expectedCode  codes.Code
+	}{
+		{
+			name:         "no-token",
+			key:          "g_n",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:          "bad-token",
+			key:           "g_n",
+			authorization: "Bearer bad-token",
+			expectedCode:  codes.Unauthenticated,
+		},
+		{
+			name:          "bad-key",
+			key:           "bad_key",
+			authorization: "Bearer token",
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:          "good-token",
+			key:           "g_n",
+			authorization: "Bearer token",
+			expectedCode:  codes.OK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ctx := context.Background()
+			if len(scenario.authorization) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", scenario.authorization)
+			}
+			_, err := client.PushExternalEndpointResult(ctx, &gatusv1.PushExternalEndpointResultRequest{Key: scenario.key, Success: false, Error: "failed"})
+			if status.Code(err) != scenario.expectedCode {
+				t.Errorf("expected code %s, got %s", scenario.expectedCode, status.Code(err))
+			}
+		})
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey("g_n", paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(endpointStatus.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(endpointStatus.Results))
+	}
+	if endpointStatus.Results[0].Success || len(endpointStatus.Results[0].Errors) != 1 || endpointStatus.Results[0].Errors[0] != "failed" {
+		t.Errorf("unexpected result: %v", endpointStatus.Results[0])
+	}
+}
+
+func TestGRPCServer_PushExternalEndpointResultWithTokenCreatedThroughAPI(t *testing.T) {
+	defer store.Get().Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+		Maintenance:       &maintenance.Config{},
+	}
+	token, externalEndpointToken, err := endpoint.NewExternalEndpointToken(cfg.ExternalEndpoints[0], "agent", 0)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if err = store.Get().InsertExternalEndpointToken(externalEndpointToken); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	client := newGRPCTestClient(t, cfg)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	if _, err = client.PushExternalEndpointResult(ctx, &gatusv1.PushExternalEndpointResultRequest{Key: "g_n", Success: true}); err != nil {
+		t.Error("expected no error, got", err)
+	}
+}
+
+func TestGRPCServer_WithServiceAccount(t *testing.T) {
+	defer store.Get().Clear()
+	issuer := test.NewOIDCIssuer(t)
+	cfg := &config.Config{
+		Endpoints:         []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+		Security: &security.Config{
+			ServiceAccounts: &security.ServiceAccountsConfig{IssuerURL: issuer.URL, Audience: "api://gatus"},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	if err := cfg.Security.RegisterHandlers(fiber.New()); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	client := newGRPCTestClient(t, cfg)
+	token := issuer.IssueToken(t, map[string]any{"iss": issuer.URL, "sub": "ci-pipeline", "aud": "api://gatus", "exp": time.Now().Add(time.Hour).Unix()})
+	if _, err := client.GetEndpointStatuses(context.Background(), &gatusv1.GetEndpointStatusesRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected code %s without token, got %s", codes.Unauthenticated, status.Code(err))
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	if _, err := client.GetEndpointStatuses(ctx, &gatusv1.GetEndpointStatusesRequest{}); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if _, err := client.PushExternalEndpointResult(ctx, &gatusv1.PushExternalEndpointResultRequest{Key: "g_n", Success: true}); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if _, err := client.PushExternalEndpointResult(ctx, &gatusv1.PushExternalEndpointResultRequest{Key: "g_unknown", Success: true}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected code %s, got %s", codes.NotFound, status.Code(err))
+	}
+}
+
+func TestGRPCServer_Silences(t *testing.T) {
+	defer silence.Clear()
+	cfg := &config.Config{
+		Endpoints:         []*endpoint.Endpoint{{Name: "frontend", Group: "core"}, {Name: "billing", Group: "core", Tenant: "acme"}},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+	}
+	client := newGRPCTestClient(t, cfg)
+	ctx := context.Background()
+	response, err := client.SilenceEndpointAlerts(ctx, &gatusv1.SilenceEndpointAlertsRequest{Key: "core_frontend", Duration: durationpb.New(time.Hour), Reason: "investigating"})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if response.GetSilence().GetKey() != "core_frontend" || response.GetSilence().GetReason() != "investigating" {
+		t.Errorf("unexpected silence %v", response.GetSilence())
+	}
+	if !silence.IsSilenced("core_frontend") {
+		t.Error("expected core_frontend to be silenced")
+	}
+	if _, err = client.SilenceEndpointAlerts(ctx, &gatusv1.SilenceEndpointAlertsRequest{Key: "g_n", Duration: durationpb.New(time.Minute)}); err != nil {
+		t.Error("expected external endpoints to be silenceable, got", err)
+	}
+	if _, err = client.SilenceEndpointAlerts(ctx, &gatusv1.SilenceEndpointAlertsRequest{Key: "core_frontend"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected code %s for a silence without duration, got %s", codes.InvalidArgument, status.Code(err))
+	}
+	if _, err = client.SilenceEndpointAlerts(ctx, &gatusv1.SilenceEndpointAlertsRequest{Key: "core_backend", Duration: durationpb.New(time.Hour)}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected code %s for an endpoint that doesn't exist, got %s", codes.NotFound, status.Code(err))
+	}
+	tenantKey := cfg.Endpoints[1].Key()
+	if _, err = client.SilenceEndpointAlerts(ctx, &gatusv1.SilenceEndpointAlertsRequest{Key: tenantKey, Duration: durationpb.New(time.Hour)}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected code %s for the endpoint of a tenant, got %s", codes.NotFound, status.Code(err))
+	}
+	silences, err := client.GetSilences(ctx, &gatusv1.GetSilencesRequest{})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(silences.GetSilences()) != 2 || silences.GetSilences()[0].GetKey() != "core_frontend" || silences.GetSilences()[1].GetKey() != "g_n" {
+		t.Errorf("expected the silences of core_frontend and g_n, got %v", silences.GetSilences())
+	}
+	if _, err = client.UnsilenceEndpointAlerts(ctx, &gatusv1.UnsilenceEndpointAlertsRequest{Key: "core_frontend"}); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if silence.IsSilenced("core_frontend") {
+		t.Error("expected core_frontend to no longer be silenced")
+	}
+	if _, err = client.UnsilenceEndpointAlerts(ctx, &gatusv1.UnsilenceEndpointAlertsRequest{Key: "core_frontend"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected code %s for an endpoint that isn't silenced, got %s", codes.NotFound, status.Code(err))
+	}
+}
+
+func TestGRPCGateway(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Web:               &web.Config{BasePath: "/status", GRPC: &web.GRPCConfig{Port: 8082, Gateway: true}},
+		Endpoints:         []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "n", Group: "g", Token: "token"}},
+		Maintenance:       &maintenance.Config{},
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: time.Now()})
+	router := New(cfg).Router()
+	scenarios := []struct {
+		name                 string
+		method               string
+		path                 string
+		body                 string
+		authorization        string
+		expectedCode         int
+		expectedBodyContains string
+	}{
+		{
+			name:                 "get-endpoint-statuses",
+			method:               "GET",
+			path:                 "/status/api/gateway/v1/endpoints/statuses",
+			expectedCode:         200,
+			expectedBodyContains: `"key":"core_frontend"`,
+		},
+		{
+			name:                 "get-endpoint-status",
+			method:               "GET",
+			path:                 "/status/api/gateway/v1/endpoints/core_frontend/statuses?pageSize=1",
+			expectedCode:         200,
+			expectedBodyContains: `"key":"core_frontend"`,
+		},
+		{
+			name:         "get-endpoint-status-not-found",
+			method:       "GET",
+			path:         "/status/api/gateway/v1/endpoints/core_backend/statuses",
+			expectedCode: 404,
+		},
+		{
+			name:         "push-external-endpoint-result-without-token",
+			method:       "POST",
+			path:         "/status/api/gateway/v1/endpoints/g_n/external",
+			body:         `{"success":true}`,
+			expectedCode: 401,
+		},
+		{
+			name:          "push-external-endpoint-result",
+			method:        "POST",
+			path:          "/status/api/gateway/v1/endpoints/g_n/external",
+			body:          `{"success":true}`,
+			authorization: "Bearer token",
+			expectedCode:  200,
+		},
+		{
+			name:                 "silence-endpoint-alerts",
+			method:               "POST",
+			path:                 "/status/api/gateway/v1/endpoints/core_frontend/alerts/silence",
+			body:                 `{"duration":"3600s","reason":"investigating"}`,
+			expectedCode:         200,
+			expectedBodyContains: `"reason":"investigating"`,
+		},
+		{
+			name:                 "get-silences",
+			method:               "GET",
+			path:                 "/status/api/gateway/v1/silences",
+			expectedCode:         200,
+			expectedBodyContains: `"key":"core_frontend"`,
+		},
+		{
+			name:         "unsilence-endpoint-alerts",
+			method:       "DELETE",
+			path:         "/status/api/gateway/v1/endpoints/core_frontend/alerts/silence",
+			expectedCode: 200,
+		},
+	}
+	defer silence.Clear()
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.method, scenario.path, strings.NewReader(scenario.body))
+			if len(scenario.authorization) > 0 {
+				request.Header.Set("Authorization", scenario.authorization)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.expectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if !strings.Contains(string(body), scenario.expectedBodyContains) {
+				t.Errorf("%s %s should have returned a body containing %s, got %s", request.Method, request.URL, scenario.expectedBodyContains, string(body))
+			}
+		})
+	}
+	request := httptest.NewRequest("GET", "/status/api/gateway/v1/endpoints/statuses", http.NoBody)
+	if response, err := New(&config.Config{Web: &web.Config{BasePath: "/status"}}).Router().Test(request); err != nil || response.StatusCode == 200 {
+		t.Error("the gateway should not be exposed unless enabled")
+	}
+}
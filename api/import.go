@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"log"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/importer"
+	"github.com/gofiber/fiber/v2"
+)
+
+type importResponse struct {
+	Endpoints     int      `json:"endpoints"`
+	Results       int      `json:"results"`
+	Skipped       []string `json:"skipped"`
+	Configuration string   `json:"configuration"`
+}
+
+// importDataOperation documents ImportData
+var importDataOperation = &openAPIOperation{
+	OperationID: "importData",
+	Summary:     "Import the monitors and history exported from another monitoring tool",
+	Tags:        []string{"import"},
+	Parameters: []*openAPIParameter{
+		{Name: "from", In: "query", Required: true, Description: "Monitoring tool the data was exported from", Schema: &openAPISchema{Type: "string", Enum: []string{importer.SourceUptimeKuma}}},
+	},
+	RequestBody: &openAPIRequestBody{
+		Required: true,
+		Content:  map[string]*openAPIMediaType{fiber.MIMEApplicationJSON: {Schema: &openAPISchema{Type: "object"}}},
+	},
+	Responses: map[string]*openAPIResponse{
+		"200": {Description: "Data imported"},
+		"400": badRequestResponse,
+		"401": unauthorizedResponse,
+		"409": {Description: "An imported endpoint has the same key as a configured endpoint"},
+		"500": internalErrorResponse,
+	},
+	responseType: importResponse{},
+}
+
+// ImportData handles requests to import the monitors and history exported from another monitoring tool.
+//
+// The results are inserted in the storage, and the configuration of the imported endpoints is returned so that it
+// can be added to the configuration file. Until then, the imported endpoints are not monitored, and their results are
+// deleted the next time Gatus starts.
+func ImportData(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		importedData, err := importer.Import(c.Query("from"), c.Body())
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		numberOfResultsImported, err := importedData.Persist(cfg.GetEndpointKeys())
+		if err != nil {
+			if errors.Is(err, importer.ErrEndpointAlreadyConfigured) {
+				return c.Status(409).SendString(err.Error())
+			}
+			log.Printf("[api.ImportData] Failed to persist imported data: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		configuration, err := importedData.EndpointsConfiguration()
+		if err != nil {
+			return c.Status(500).SendString(err.Error())
+		}
+		log.Printf("[api.ImportData] Imported %d endpoints and %d results from %s", len(importedData.Endpoints), numberOfResultsImported, c.Query("from"))
+		skipped := importedData.Skipped
+		if skipped == nil {
+			skipped = []string{}
+		}
+		return c.Status(200).JSON(importResponse{
+			Endpoints:     len(importedData.Endpoints),
+			Results:       numberOfResultsImported,
+			Skipped:       skipped,
+			Configuration: string(configuration),
+		})
+	}
+}
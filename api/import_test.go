@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestImportData(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	backup := `{"version":"1.23.0","monitorList":[{"id":1,"name":"website","type":"http","active":true,"url":"https://example.org","method":"GET","accepted_statuscodes":["200-299"]},{"id":2,"name":"broker","type":"mqtt","active":true}],"heartbeatList":{"1":[{"status":1,"msg":"OK","time":"2024-01-01 00:00:00.000","ping":100}]}}`
+	router := New(&config.Config{
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}).Router()
+	scenarios := []struct {
+		Name          string
+		Path          string
+		Body          string
+		Authenticated bool
+		ExpectedCode  int
+	}{
+		{
+			Name:          "unauthenticated",
+			Path:          "/api/v1/import?from=uptime-kuma",
+			Body:          backup,
+			Authenticated: false,
+			ExpectedCode:  http.StatusUnauthorized,
+		},
+		{
+			Name:          "unsupported-source",
+			Path:          "/api/v1/import?from=nagios",
+			Body:          backup,
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "invalid-body",
+			Path:          "/api/v1/import?from=uptime-kuma",
+			Body:          "invalid",
+			Authenticated: true,
+			ExpectedCode:  http.StatusBadRequest,
+		},
+		{
+			Name:          "valid",
+			Path:          "/api/v1/import?from=uptime-kuma",
+			Body:          backup,
+			Authenticated: true,
+			ExpectedCode:  http.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, bytes.NewBufferString(scenario.Body))
+			if scenario.Authenticated {
+				request.SetBasicAuth("john.doe", "hunter2")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			var body importResponse
+			if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if body.Endpoints != 1 || body.Results != 1 || len(body.Skipped) != 1 || !strings.Contains(body.Configuration, "name: website") {
+				t.Errorf("unexpected response: %+v", body)
+			}
+		})
+	}
+	if _, err := store.Get().GetEndpointStatusByKey("_website", paging.NewEndpointStatusParams()); err != nil {
+		t.Error("expected imported results to be persisted, got", err)
+	}
+}
+
+func TestImportData_WithConfiguredEndpoint(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	backup := `{"version":"1.23.0","monitorList":[{"id":1,"name":"website","type":"http","active":true,"url":"https://example.org","method":"GET","accepted_statuscodes":["200-299"]}],"heartbeatList":{"1":[{"status":1,"msg":"OK","time":"2024-01-01 00:00:00.000","ping":100}]}}`
+	router := New(&config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "website", URL: "https://example.org"}},
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}).Router()
+	request := httptest.NewRequest("POST", "/api/v1/import?from=uptime-kuma", bytes.NewBufferString(backup))
+	request.SetBasicAuth("john.doe", "hunter2")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusConflict {
+		t.Errorf("expected status code %d, got %d", http.StatusConflict, response.StatusCode)
+	}
+}
+
+func TestImportData_WithoutSecurity(t *testing.T) {
+	router := New(&config.Config{}).Router()
+	response, err := router.Test(httptest.NewRequest("POST", "/api/v1/import?from=uptime-kuma", bytes.NewBufferString("{}")))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusOK {
+		t.Error("expected import to be unavailable when security is not configured")
+	}
+}
@@ -0,0 +1,335 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+
+	securitySchemeBasic       = "basic"
+	securitySchemeOIDCSession = "oidcSession"
+	securitySchemeBearer      = "bearer"
+)
+
+var fiberPathParameterRegex = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Servers    []openAPIServer                         `json:"servers"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// openAPIOperation is the annotation of a handler, which documents the operation it performs
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+
+	// requestBodyType and responseType are set by annotations and converted to schemas when the handler is documented
+	requestBodyType any
+	responseType    any
+	contentType     string
+
+	// requestBodyOptional documents the request body as optional, for handlers that can do without it
+	requestBodyOptional bool
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `json:"$ref,omitempty"`
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Enum       []string                  `json:"enum,omitempty"`
+	Items      *openAPISchema            `json:"items,omitempty"`
+	Properties map[string]*openAPISchema `json:"properties,omitempty"`
+	Required   []string                  `json:"required,omitempty"`
+}
+
+// openAPISpecification keeps track of the routes registered on the router and generates the OpenAPI
+// specification describing them
+type openAPISpecification struct {
+	document *openAPIDocument
+	security []map[string][]string
+
+	once sync.Once
+	data []byte
+}
+
+func newOpenAPISpecification(cfg *config.Config) *openAPISpecification {
+	spec := &openAPISpecification{
+		document: &openAPIDocument{
+			OpenAPI: openAPIVersion,
+			Info:    openAPIInfo{Title: "Gatus", Version: "v1"},
+			Servers: []openAPIServer{{URL: cfg.Web.BasePath + "/"}},
+			Paths:   make(map[string]map[string]*openAPIOperation),
+			Components: openAPIComponents{
+				Schemas: make(map[string]*openAPISchema),
+				SecuritySchemes: map[string]*openAPISecurityScheme{
+					securitySchemeBearer: {Type: "http", Scheme: "bearer"},
+				},
+			},
+		},
+	}
+	if cfg.Security != nil {
+		if cfg.Security.Basic != nil {
+			spec.document.Components.SecuritySchemes[securitySchemeBasic] = &openAPISecurityScheme{Type: "http", Scheme: "basic"}
+			spec.security = append(spec.security, map[string][]string{securitySchemeBasic: {}})
+		}
+		if cfg.Security.OIDC != nil {
+			spec.document.Components.SecuritySchemes[securitySchemeOIDCSession] = &openAPISecurityScheme{Type: "apiKey", In: "cookie", Name: "gatus_session"}
+			spec.security = append(spec.security, map[string][]string{securitySchemeOIDCSession: {}})
+		}
+		if len(cfg.Security.APIKeys) > 0 {
+			spec.security = append(spec.security, map[string][]string{securitySchemeBearer: {}})
+		}
+	}
+	return spec
+}
+
+// add adds the operation to the specification
+//
+// If protected is true and security is configured, the operation is documented as requiring authentication.
+func (spec *openAPISpecification) add(method, path string, operation *openAPIOperation, protected bool) {
+	// Copy the operation, because annotations are shared between all routers created
+	documentedOperation := *operation
+	if protected {
+		documentedOperation.Security = spec.security
+	}
+	contentType := operation.contentType
+	if len(contentType) == 0 {
+		contentType = fiber.MIMEApplicationJSON
+	}
+	if operation.requestBodyType != nil {
+		documentedOperation.RequestBody = &openAPIRequestBody{
+			Required: !operation.requestBodyOptional,
+			Content:  map[string]*openAPIMediaType{fiber.MIMEApplicationJSON: {Schema: spec.schemaOf(reflect.TypeOf(operation.requestBodyType))}},
+		}
+	}
+	documentedOperation.Responses = make(map[string]*openAPIResponse, len(operation.Responses))
+	for code, response := range operation.Responses {
+		documentedResponse := *response
+		if strings.HasPrefix(code, "2") && operation.responseType != nil {
+			documentedResponse.Content = map[string]*openAPIMediaType{contentType: {Schema: spec.schemaOf(reflect.TypeOf(operation.responseType))}}
+		}
+		documentedOperation.Responses[code] = &documentedResponse
+	}
+	// Convert fiber path parameters (e.g. :key) to OpenAPI path parameters (e.g. {key})
+	path = fiberPathParameterRegex.ReplaceAllString(path, "{$1}")
+	if spec.document.Paths[path] == nil {
+		spec.document.Paths[path] = make(map[string]*openAPIOperation)
+	}
+	spec.document.Paths[path][strings.ToLower(method)] = &documentedOperation
+}
+
+// schemaOf returns the schema of the type passed. Structs are added to the components of the specification.
+func (spec *openAPISpecification) schemaOf(t reflect.Type) *openAPISchema {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case reflect.TypeOf(time.Duration(0)):
+		// Durations are serialized in nanoseconds
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.TypeOf([]byte{}):
+		return &openAPISchema{Type: "string", Format: "byte"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return spec.schemaOf(t.Elem())
+	case reflect.Slice, reflect.Array:
+		return &openAPISchema{Type: "array", Items: spec.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.Struct:
+		name := t.Name()
+		// Anonymous structs are inlined, whereas named structs are referenced from the components
+		if len(name) == 0 {
+			return spec.structSchemaOf(t)
+		}
+		if _, exists := spec.document.Components.Schemas[name]; !exists {
+			// Register a placeholder before going through the fields to support recursive types
+			spec.document.Components.Schemas[name] = &openAPISchema{}
+			*spec.document.Components.Schemas[name] = *spec.structSchemaOf(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + name}
+	default:
+		return &openAPISchema{}
+	}
+}
+
+func (spec *openAPISpecification) structSchemaOf(t reflect.Type) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		fieldName, options, _ := strings.Cut(tag, ",")
+		if len(fieldName) == 0 {
+			fieldName = field.Name
+		}
+		schema.Properties[fieldName] = spec.schemaOf(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, fieldName)
+		}
+	}
+	return schema
+}
+
+func (spec *openAPISpecification) marshal() []byte {
+	spec.once.Do(func() {
+		var err error
+		if spec.data, err = json.Marshal(spec.document); err != nil {
+			log.Printf("[api.openAPISpecification] Unable to marshal OpenAPI specification to JSON: %s", err.Error())
+		}
+	})
+	return spec.data
+}
+
+// OpenAPISpecification handles requests to retrieve the OpenAPI specification describing the API
+//
+// The specification is generated the first time it is requested, at which point all routes have been registered.
+func OpenAPISpecification(spec *openAPISpecification) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		data := spec.marshal()
+		if data == nil {
+			return c.Status(500).SendString("unable to marshal OpenAPI specification to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(data)
+	}
+}
+
+// documentedRouter registers routes on a router and documents them in an OpenAPI specification
+type documentedRouter struct {
+	router    fiber.Router
+	prefix    string
+	protected bool
+	spec      *openAPISpecification
+}
+
+func (r *documentedRouter) get(path string, operation *openAPIOperation, handler fiber.Handler) {
+	r.router.Get(path, handler)
+	r.spec.add(fiber.MethodGet, r.prefix+path, operation, r.protected)
+}
+
+func (r *documentedRouter) post(path string, operation *openAPIOperation, handler fiber.Handler) {
+	r.router.Post(path, handler)
+	r.spec.add(fiber.MethodPost, r.prefix+path, operation, r.protected)
+}
+
+func (r *documentedRouter) patch(path string, operation *openAPIOperation, handler fiber.Handler) {
+	r.router.Patch(path, handler)
+	r.spec.add(fiber.MethodPatch, r.prefix+path, operation, r.protected)
+}
+
+func (r *documentedRouter) delete(path string, operation *openAPIOperation, handler fiber.Handler) {
+	r.router.Delete(path, handler)
+	r.spec.add(fiber.MethodDelete, r.prefix+path, operation, r.protected)
+}
+
+///////////////////////////////////////////////
+// Annotations shared by multiple operations //
+///////////////////////////////////////////////
+
+var (
+	keyPathParameter = &openAPIParameter{Name: "key", In: "path", Required: true, Description: "Key of the endpoint, which is composed of its group and name (e.g. core_frontend)", Schema: &openAPISchema{Type: "string"}}
+
+	pageQueryParameters = []*openAPIParameter{
+		{Name: "page", In: "query", Description: "Page of results to retrieve", Schema: &openAPISchema{Type: "integer", Format: "int32"}},
+		{Name: "pageSize", In: "query", Description: "Number of results per page", Schema: &openAPISchema{Type: "integer", Format: "int32"}},
+	}
+	resultsTimeRangeQueryParameters = []*openAPIParameter{
+		{Name: "from", In: "query", Description: "Only retrieve results at or after this timestamp", Schema: &openAPISchema{Type: "string", Format: "date-time"}},
+		{Name: "to", In: "query", Description: "Only retrieve results at or before this timestamp", Schema: &openAPISchema{Type: "string", Format: "date-time"}},
+		{Name: "order", In: "query", Description: "Whether the first page contains the most recent (desc) or the oldest (asc) results", Schema: &openAPISchema{Type: "string", Enum: []string{"desc", "asc"}}},
+	}
+
+	badRequestResponse    = &openAPIResponse{Description: "Invalid request"}
+	unauthorizedResponse  = &openAPIResponse{Description: "Missing or invalid credentials"}
+	notFoundResponse      = &openAPIResponse{Description: "Endpoint not found"}
+	internalErrorResponse = &openAPIResponse{Description: "Internal error"}
+)
+
+func durationPathParameter(durations ...string) *openAPIParameter {
+	return &openAPIParameter{Name: "duration", In: "path", Required: true, Description: "Duration over which the data is aggregated", Schema: &openAPISchema{Type: "string", Enum: durations}}
+}
+
+// openAPISpecificationOperation documents OpenAPISpecification
+var openAPISpecificationOperation = &openAPIOperation{
+	OperationID: "getOpenAPISpecification",
+	Summary:     "Retrieve the OpenAPI specification of the API",
+	Tags:        []string{"meta"},
+	Responses:   map[string]*openAPIResponse{"200": {Description: "OpenAPI specification", Content: map[string]*openAPIMediaType{fiber.MIMEApplicationJSON: {Schema: &openAPISchema{Type: "object"}}}}},
+}
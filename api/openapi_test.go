@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/security"
+)
+
+func TestOpenAPISpecification(t *testing.T) {
+	scenarios := []struct {
+		name                    string
+		cfg                     *config.Config
+		expectedSecuritySchemes []string
+		expectedPaths           []string
+		unexpectedPaths         []string
+	}{
+		{
+			name:                    "default",
+			cfg:                     &config.Config{},
+			expectedSecuritySchemes: []string{securitySchemeBearer},
+			expectedPaths:           []string{"/api/v1/openapi.json", "/api/v1/endpoints/statuses", "/api/v1/endpoints/{key}/statuses", "/api/v1/endpoints/{key}/uptimes/{duration}/badge.svg", "/api/v1/endpoints/{key}/external"},
+			unexpectedPaths:         []string{"/api/v1/share-links"},
+		},
+		{
+			name: "with-basic-auth-share-links-and-base-path",
+			cfg: &config.Config{
+				Web: &web.Config{Address: web.DefaultAddress, Port: web.DefaultPort, BasePath: "/status"},
+				Security: &security.Config{
+					Basic:      &security.BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT"},
+					ShareLinks: &security.ShareLinksConfig{Secret: strings.Repeat("s", 32)},
+				},
+			},
+			expectedSecuritySchemes: []string{securitySchemeBearer, securitySchemeBasic},
+			expectedPaths:           []string{"/api/v1/endpoints/statuses", "/api/v1/share-links", "/api/v1/share/{token}/endpoints/statuses", "/api/v1/share/{token}/endpoints/{key}/statuses"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			router := New(scenario.cfg).Router()
+			basePath := scenario.cfg.Web.BasePath
+			response, err := router.Test(httptest.NewRequest("GET", basePath+"/api/v1/openapi.json", http.NoBody))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+			}
+			var document openAPIDocument
+			if err = json.NewDecoder(response.Body).Decode(&document); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if document.OpenAPI != openAPIVersion || document.Servers[0].URL != basePath+"/" {
+				t.Errorf("unexpected document header: openapi=%s, servers=%v", document.OpenAPI, document.Servers)
+			}
+			if len(document.Components.SecuritySchemes) != len(scenario.expectedSecuritySchemes) {
+				t.Errorf("expected security schemes %v, got %v", scenario.expectedSecuritySchemes, document.Components.SecuritySchemes)
+			}
+			for _, securityScheme := range scenario.expectedSecuritySchemes {
+				if _, exists := document.Components.SecuritySchemes[securityScheme]; !exists {
+					t.Errorf("expected security scheme %s to be documented", securityScheme)
+				}
+			}
+			for _, path := range scenario.expectedPaths {
+				if _, exists := document.Paths[path]; !exists {
+					t.Errorf("expected path %s to be documented", path)
+				}
+			}
+			for _, path := range scenario.unexpectedPaths {
+				if _, exists := document.Paths[path]; exists {
+					t.Errorf("expected path %s not to be documented", path)
+				}
+			}
+			if statuses := document.Paths["/api/v1/endpoints/statuses"]["get"]; (scenario.cfg.Security == nil) != (len(statuses.Security) == 0) {
+				t.Errorf("expected protected operation to require authentication only if security is configured, got %v", statuses.Security)
+			}
+			if resultSchema := document.Components.Schemas["Result"]; resultSchema == nil || resultSchema.Properties["timestamp"].Format != "date-time" || resultSchema.Properties["IP"] != nil {
+				t.Errorf("expected Result schema to be generated from endpoint.Result, got %+v", resultSchema)
+			}
+			// Every route registered under /api must be documented to keep the specification in sync with the router
+			for _, route := range router.GetRoutes(true) {
+				if (route.Method != http.MethodGet && route.Method != http.MethodPost) || !strings.HasPrefix(route.Path, basePath+"/api/") {
+					continue
+				}
+				path := fiberPathParameterRegex.ReplaceAllString(strings.TrimPrefix(route.Path, basePath), "{$1}")
+				if _, exists := document.Paths[path][strings.ToLower(route.Method)]; !exists {
+					t.Errorf("expected route %s %s to be documented", route.Method, route.Path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// maximumPingBodyLength is the maximum number of characters of the body of a ping attached to the result as an error
+	maximumPingBodyLength = 1000
+
+	pingSignalFail  = "fail"
+	pingSignalStart = "start"
+	pingSignalLog   = "log"
+)
+
+var pingUUIDPathParameter = &openAPIParameter{Name: "uuid", In: "path", Required: true, Description: "Ping UUID of the external endpoint", Schema: &openAPISchema{Type: "string"}}
+
+// pingOperation documents Ping
+var pingOperation = &openAPIOperation{
+	OperationID: "ping",
+	Summary:     "Report the success of an external endpoint, compatible with healthchecks.io clients",
+	Tags:        []string{"ping"},
+	Parameters:  []*openAPIParameter{pingUUIDPathParameter},
+	Responses:   map[string]*openAPIResponse{"200": {Description: "Result persisted"}, "404": notFoundResponse, "500": internalErrorResponse},
+	contentType: fiber.MIMETextPlain,
+}
+
+// pingWithSignalOperation documents Ping when a signal is provided
+var pingWithSignalOperation = &openAPIOperation{
+	OperationID: "pingWithSignal",
+	Summary:     "Report the failure, the start or the exit status of an external endpoint, compatible with healthchecks.io clients",
+	Tags:        []string{"ping"},
+	Parameters: []*openAPIParameter{
+		pingUUIDPathParameter,
+		{Name: "signal", In: "path", Required: true, Description: "Either fail, start, log or the exit status of the job (0 meaning success)", Schema: &openAPISchema{Type: "string"}},
+	},
+	Responses:   map[string]*openAPIResponse{"200": {Description: "Result persisted"}, "400": badRequestResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	contentType: fiber.MIMETextPlain,
+}
+
+// Ping handles requests from healthchecks.io clients, which report the outcome of a job by sending a request to
+// /ping/{uuid} on success, /ping/{uuid}/fail on failure, or /ping/{uuid}/{exit-status}.
+//
+// Because healthchecks.io clients don't support any other authentication, the ping UUID must be kept secret.
+// Signals that don't represent an outcome, such as start and log, are acknowledged without persisting any result.
+func Ping(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		externalEndpoint := cfg.GetExternalEndpointByPingUUID(c.Params("uuid"))
+		if externalEndpoint == nil {
+			return c.Status(404).SendString("not found")
+		}
+		result := &endpoint.Result{
+			Timestamp: time.Now(),
+			Success:   true,
+			Errors:    []string{},
+		}
+		var errorMessage string
+		switch signal := c.Params("signal"); signal {
+		case "":
+		case pingSignalStart, pingSignalLog:
+			return c.Status(200).SendString("OK")
+		case pingSignalFail:
+			result.Success = false
+			errorMessage = "job reported a failure"
+		default:
+			exitStatus, err := strconv.Atoi(signal)
+			if err != nil || exitStatus < 0 || exitStatus > 255 {
+				return c.Status(400).SendString("invalid signal")
+			}
+			if exitStatus != 0 {
+				result.Success = false
+				errorMessage = "job exited with status " + signal
+			}
+		}
+		if !result.Success {
+			// healthchecks.io clients usually send the output of the job as the body of the request
+			if body := string(c.Body()); len(body) > 0 {
+				if len(body) > maximumPingBodyLength {
+					body = body[:maximumPingBodyLength]
+				}
+				errorMessage += ": " + sanitizeInput(body)
+			}
+			result.Errors = append(result.Errors, errorMessage)
+		}
+		if err := insertExternalEndpointResult(cfg, externalEndpoint, result); err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			log.Printf("[api.Ping] Failed to insert result in storage: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		log.Printf("[api.Ping] Successfully inserted result for external endpoint with key=%s and success=%v", externalEndpoint.Key(), result.Success)
+		return c.Status(200).SendString("OK")
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestPing(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{
+			{
+				Name:     "backup",
+				Group:    "cron",
+				Token:    "token",
+				PingUUID: "5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278",
+			},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Method       string
+		Path         string
+		Body         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "unknown-uuid",
+			Method:       "GET",
+			Path:         "/ping/unknown",
+			ExpectedCode: 404,
+		},
+		{
+			Name:         "success",
+			Method:       "GET",
+			Path:         "/ping/5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "start",
+			Method:       "GET",
+			Path:         "/ping/5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278/start",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "fail-with-body",
+			Method:       "POST",
+			Path:         "/ping/5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278/fail",
+			Body:         "disk full",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "exit-status-zero",
+			Method:       "POST",
+			Path:         "/ping/5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278/0",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "exit-status-non-zero",
+			Method:       "GET",
+			Path:         "/ping/5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278/2",
+			ExpectedCode: 200,
+		},
+		{
+			Name:         "invalid-signal",
+			Method:       "GET",
+			Path:         "/ping/5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278/invalid",
+			ExpectedCode: 400,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, strings.NewReader(scenario.Body))
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("verify-end-results", func(t *testing.T) {
+		endpointStatus, err := store.Get().GetEndpointStatus("cron", "backup", paging.NewEndpointStatusParams().WithResults(1, 10))
+		if err != nil {
+			t.Fatalf("failed to get endpoint status: %s", err.Error())
+		}
+		if len(endpointStatus.Results) != 4 {
+			t.Fatalf("expected 4 results but got %d", len(endpointStatus.Results))
+		}
+		expectedSuccesses := []bool{true, false, true, false}
+		for i, result := range endpointStatus.Results {
+			if result.Success != expectedSuccesses[i] {
+				t.Errorf("expected result #%d to have success=%v", i, expectedSuccesses[i])
+			}
+		}
+		if errors := endpointStatus.Results[1].Errors; len(errors) != 1 || errors[0] != "job reported a failure: disk full" {
+			t.Errorf("expected the body of the ping to be attached to the error, got %v", errors)
+		}
+		if errors := endpointStatus.Results[3].Errors; len(errors) != 1 || errors[0] != "job exited with status 2" {
+			t.Errorf("expected the exit status to be attached to the error, got %v", errors)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// getSchedulerOperation documents Scheduler
+var getSchedulerOperation = &openAPIOperation{
+	OperationID:  "getScheduler",
+	Summary:      "Retrieve the next scheduled run, the duration of the last run and the current status of each endpoint",
+	Tags:         []string{"system"},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Schedule of each endpoint"}, "401": unauthorizedResponse},
+	responseType: []*watchdog.EndpointSchedule{},
+}
+
+// Scheduler handles requests to retrieve the schedule of every endpoint being monitored, which is useful to diagnose
+// why a check seems stuck or delayed
+func Scheduler(c *fiber.Ctx) error {
+	schedules := watchdog.GetEndpointSchedules()
+	filteredSchedules := make([]*watchdog.EndpointSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(schedule.Key)) == 0 {
+			filteredSchedules = append(filteredSchedules, schedule)
+		}
+	}
+	return c.Status(200).JSON(filteredSchedules)
+}
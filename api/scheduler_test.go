@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestScheduler(t *testing.T) {
+	defer cache.Clear()
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{{Name: "frontend", Group: "core"}},
+	}
+	router := New(cfg).Router()
+	request := httptest.NewRequest("GET", "/api/v1/system/scheduler", http.NoBody)
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	body, _ := io.ReadAll(response.Body)
+	var schedules []*watchdog.EndpointSchedule
+	if err := json.Unmarshal(body, &schedules); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if schedules == nil {
+		t.Errorf("expected an empty list rather than null when no endpoint is being monitored, got %s", string(body))
+	}
+}
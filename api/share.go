@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+var shareLinkTokenPathParameter = &openAPIParameter{Name: "token", In: "path", Required: true, Description: "Token of the share link", Schema: &openAPISchema{Type: "string"}}
+
+type createShareLinkRequest struct {
+	Groups   []string `json:"groups"`
+	Duration string   `json:"duration"`
+}
+
+type createShareLinkResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires-at"`
+}
+
+// createShareLinkOperation documents CreateShareLink
+var createShareLinkOperation = &openAPIOperation{
+	OperationID:     "createShareLink",
+	Summary:         "Generate a share link granting read-only access to a subset of groups",
+	Tags:            []string{"share-links"},
+	Responses:       map[string]*openAPIResponse{"201": {Description: "Share link generated"}, "400": badRequestResponse, "401": unauthorizedResponse},
+	requestBodyType: createShareLinkRequest{},
+	responseType:    createShareLinkResponse{},
+}
+
+// CreateShareLink handles requests to generate a share link granting read-only access to a subset of groups
+func CreateShareLink(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request createShareLinkRequest
+		if err := json.Unmarshal(c.Body(), &request); err != nil {
+			return c.Status(400).SendString("invalid request body")
+		}
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil {
+			return c.Status(400).SendString("invalid duration")
+		}
+		for _, group := range request.Groups {
+			if !hasGroup(cfg, group) {
+				return c.Status(400).SendString("group " + group + " does not exist")
+			}
+		}
+		token, shareLink, err := cfg.Security.ShareLinks.GenerateToken(request.Groups, duration)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.CreateShareLink] Generated share link for groups=%v expiring at %s", shareLink.Groups, time.Unix(shareLink.ExpiresAt, 0).Format(time.RFC3339))
+		return c.Status(201).JSON(createShareLinkResponse{
+			Token:     token,
+			URL:       c.BaseURL() + cfg.Web.BasePath + "/share/" + token,
+			ExpiresAt: time.Unix(shareLink.ExpiresAt, 0).UTC(),
+		})
+	}
+}
+
+// getSharedEndpointStatusesOperation documents SharedEndpointStatuses
+var getSharedEndpointStatusesOperation = &openAPIOperation{
+	OperationID:  "getSharedEndpointStatuses",
+	Summary:      "Retrieve the status of the endpoints a share link grants access to",
+	Tags:         []string{"share-links"},
+	Parameters:   append([]*openAPIParameter{shareLinkTokenPathParameter}, pageQueryParameters...),
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Statuses of the shared endpoints"}, "401": {Description: "Invalid or expired share link"}, "500": internalErrorResponse},
+	responseType: []*endpoint.Status{},
+}
+
+// SharedEndpointStatuses handles requests to retrieve the statuses of the endpoints a share link grants access to
+func SharedEndpointStatuses(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		shareLink, err := cfg.Security.ShareLinks.ParseToken(c.Params("token"))
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
+		}
+		page, pageSize := extractPageAndPageSizeFromRequest(c)
+		endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
+		if err != nil {
+			log.Printf("[api.SharedEndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		sharedEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+		for _, endpointStatus := range excludeTenantEndpointStatuses(endpointStatuses) {
+			if shareLink.HasAccessToGroup(endpointStatus.Group) {
+				sharedEndpointStatuses = append(sharedEndpointStatuses, endpointStatus)
+			}
+		}
+		return c.Status(200).JSON(sharedEndpointStatuses)
+	}
+}
+
+// getSharedEndpointStatusOperation documents SharedEndpointStatus
+var getSharedEndpointStatusOperation = &openAPIOperation{
+	OperationID:  "getSharedEndpointStatus",
+	Summary:      "Retrieve the status of an endpoint a share link grants access to",
+	Tags:         []string{"share-links"},
+	Parameters:   append([]*openAPIParameter{shareLinkTokenPathParameter, keyPathParameter}, pageQueryParameters...),
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Status of the shared endpoint"}, "401": {Description: "Invalid or expired share link"}, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: &endpoint.Status{},
+}
+
+// SharedEndpointStatus handles requests to retrieve the status of an endpoint a share link grants access to
+func SharedEndpointStatus(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		shareLink, err := cfg.Security.ShareLinks.ParseToken(c.Params("token"))
+		if err != nil {
+			return c.Status(401).SendString(err.Error())
+		}
+		page, pageSize := extractPageAndPageSizeFromRequest(c)
+		endpointStatus, err := store.Get().GetEndpointStatusByKey(c.Params("key"), paging.NewEndpointStatusParams().WithResults(page, pageSize).WithEvents(1, common.MaximumNumberOfEvents))
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			log.Printf("[api.SharedEndpointStatus] Failed to retrieve endpoint status: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		// Endpoints outside the groups of the share link are reported as not found to avoid leaking their existence
+		if endpointStatus == nil || !shareLink.HasAccessToGroup(endpointStatus.Group) || len(endpoint.ExtractTenantFromKey(endpointStatus.Key)) > 0 {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		return c.Status(200).JSON(endpointStatus)
+	}
+}
+
+func hasGroup(cfg *config.Config, group string) bool {
+	for _, ep := range cfg.Endpoints {
+		if ep.Group == group && len(ep.Tenant) == 0 {
+			return true
+		}
+	}
+	for _, ee := range cfg.GetExternalEndpoints() {
+		if ee.Group == group && len(ee.Tenant) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestShareLinks(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	sharedEndpoint := &endpoint.Endpoint{Name: "website", Group: "core", URL: "https://example.org"}
+	privateEndpoint := &endpoint.Endpoint{Name: "database", Group: "internal", URL: "https://example.com"}
+	store.Get().Insert(sharedEndpoint, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	store.Get().Insert(privateEndpoint, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{sharedEndpoint, privateEndpoint},
+		UI:        ui.GetDefaultConfig(),
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+			ShareLinks: &security.ShareLinksConfig{Secret: strings.Repeat("s", 32), MaxDuration: 24 * time.Hour},
+		},
+	}
+	router := New(cfg).Router()
+	createShareLink := func(body string, authenticated bool) *http.Response {
+		request := httptest.NewRequest("POST", "/api/v1/share-links", bytes.NewBufferString(body))
+		if authenticated {
+			request.SetBasicAuth("john.doe", "hunter2")
+		}
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		return response
+	}
+	t.Run("create-without-authentication", func(t *testing.T) {
+		if response := createShareLink(`{"groups":["core"],"duration":"1h"}`, false); response.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected status code %d, got %d", http.StatusUnauthorized, response.StatusCode)
+		}
+	})
+	t.Run("create-with-invalid-requests", func(t *testing.T) {
+		for _, body := range []string{`invalid`, `{"groups":["core"],"duration":"invalid"}`, `{"groups":["core"],"duration":"48h"}`, `{"groups":["unknown"],"duration":"1h"}`, `{"groups":[],"duration":"1h"}`} {
+			if response := createShareLink(body, true); response.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected status code %d for body %s, got %d", http.StatusBadRequest, body, response.StatusCode)
+			}
+		}
+	})
+	response := createShareLink(`{"groups":["core"],"duration":"1h"}`, true)
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status code %d, got %d", http.StatusCreated, response.StatusCode)
+	}
+	var shareLink createShareLinkResponse
+	if err := json.NewDecoder(response.Body).Decode(&shareLink); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !strings.HasSuffix(shareLink.URL, "/share/"+shareLink.Token) || shareLink.ExpiresAt.Before(time.Now()) {
+		t.Errorf("unexpected share link: %+v", shareLink)
+	}
+	scenarios := []struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+		ExpectedBody string
+	}{
+		{
+			Name:         "statuses",
+			Path:         "/api/v1/share/" + shareLink.Token + "/endpoints/statuses",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `"key":"core_website"`,
+		},
+		{
+			Name:         "statuses-with-invalid-token",
+			Path:         "/api/v1/share/invalid/endpoints/statuses",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "status-of-shared-endpoint",
+			Path:         "/api/v1/share/" + shareLink.Token + "/endpoints/core_website/statuses",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `"key":"core_website"`,
+		},
+		{
+			Name:         "status-of-endpoint-outside-shared-groups",
+			Path:         "/api/v1/share/" + shareLink.Token + "/endpoints/internal_database/statuses",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "status-with-invalid-token",
+			Path:         "/api/v1/share/invalid/endpoints/core_website/statuses",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "spa",
+			Path:         "/share/" + shareLink.Token,
+			ExpectedCode: http.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			response, err := router.Test(httptest.NewRequest("GET", scenario.Path, http.NoBody))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("expected status code %d, got %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if !strings.Contains(string(body), scenario.ExpectedBody) {
+				t.Errorf("expected body to contain %s, got %s", scenario.ExpectedBody, body)
+			}
+			if strings.Contains(string(body), "internal_database") {
+				t.Errorf("expected body not to contain endpoints outside the shared groups, got %s", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// sloBurnRateLookbacks are the durations over which the burn rates returned by ServiceLevelObjective are measured,
+// as long as they don't exceed the window of the service level objective
+var sloBurnRateLookbacks = []string{"1h", "6h", "24h", "72h"}
+
+// serviceLevelObjectiveStatus is the status of the service level objective of an endpoint over its window
+type serviceLevelObjectiveStatus struct {
+	// Objective is the percentage of events that must be good over Window
+	Objective float64 `json:"objective"`
+
+	// Window is the rolling window over which Objective is evaluated
+	Window time.Duration `json:"window"`
+
+	// ResponseTime is the maximum response time of a successful result for it to be a good event, if any
+	ResponseTime time.Duration `json:"responseTime,omitempty"`
+
+	// TotalEvents is the number of events over Window
+	TotalEvents uint64 `json:"totalEvents"`
+
+	// GoodEvents is the number of good events over Window
+	GoodEvents uint64 `json:"goodEvents"`
+
+	// Indicator is the percentage of good events over Window. Omitted if there were no events.
+	Indicator *float64 `json:"indicator,omitempty"`
+
+	// ErrorBudgetRemaining is the ratio of the error budget that hasn't been consumed over Window, which is negative
+	// once the error budget is exhausted
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+
+	// BurnRates are the rates at which the error budget is consumed (value) over each lookback (key)
+	BurnRates map[string]float64 `json:"burnRates"`
+}
+
+// getServiceLevelObjectiveOperation documents ServiceLevelObjective
+var getServiceLevelObjectiveOperation = &openAPIOperation{
+	OperationID:  "getServiceLevelObjective",
+	Summary:      "Retrieve the error budget and burn rates of the service level objective of an endpoint",
+	Tags:         []string{"endpoints"},
+	Parameters:   []*openAPIParameter{keyPathParameter},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Status of the service level objective of the endpoint"}, "401": unauthorizedResponse, "404": {Description: "Endpoint not found or without service level objective"}, "500": internalErrorResponse},
+	responseType: &serviceLevelObjectiveStatus{},
+}
+
+// ServiceLevelObjective handles requests to retrieve the status of the service level objective of an endpoint, which
+// is computed from the uptime data of the endpoint
+func ServiceLevelObjective(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Params("key")
+		// The endpoints of tenants can only be retrieved through the API of their tenant
+		if len(endpoint.ExtractTenantFromKey(key)) > 0 {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		ep := cfg.GetEndpointByKey(key)
+		if ep == nil {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		if ep.SLOConfig == nil {
+			return c.Status(404).SendString("endpoint has no service level objective")
+		}
+		now := time.Now()
+		statistics, err := store.Get().GetServiceLevelStatisticsByKey(key, now.Add(-ep.SLOConfig.Window), now)
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			log.Printf("[api.ServiceLevelObjective] Failed to retrieve service level statistics of endpoint with key=%s: %s", key, err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		status := &serviceLevelObjectiveStatus{
+			Objective:            ep.SLOConfig.Objective,
+			Window:               ep.SLOConfig.Window,
+			ResponseTime:         ep.SLOConfig.ResponseTime,
+			TotalEvents:          statistics.TotalEvents,
+			GoodEvents:           statistics.GoodEvents,
+			ErrorBudgetRemaining: ep.SLOConfig.ErrorBudgetRemaining(statistics.TotalEvents, statistics.GoodEvents),
+			BurnRates:            make(map[string]float64),
+		}
+		if statistics.TotalEvents > 0 {
+			indicator := float64(statistics.GoodEvents) / float64(statistics.TotalEvents) * 100
+			status.Indicator = &indicator
+		}
+		for _, lookback := range sloBurnRateLookbacks {
+			duration, _ := time.ParseDuration(lookback)
+			if duration > ep.SLOConfig.Window {
+				break
+			}
+			lookbackStatistics, err := store.Get().GetServiceLevelStatisticsByKey(key, now.Add(-duration), now)
+			if err != nil {
+				log.Printf("[api.ServiceLevelObjective] Failed to retrieve service level statistics of endpoint with key=%s: %s", key, err.Error())
+				return c.Status(500).SendString(err.Error())
+			}
+			status.BurnRates[lookback] = ep.SLOConfig.BurnRate(lookbackStatistics.TotalEvents, lookbackStatistics.GoodEvents)
+		}
+		return c.Status(200).JSON(status)
+	}
+}
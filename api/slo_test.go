@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestServiceLevelObjective(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name:      "frontend",
+				Group:     "core",
+				SLOConfig: &slo.Config{Objective: 90, Window: 24 * time.Hour, ResponseTime: time.Second},
+			},
+			{
+				Name:  "backend",
+				Group: "core",
+			},
+		},
+	}
+	if err := cfg.Endpoints[0].SLOConfig.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	now := time.Now()
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: now.Add(-12 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now.Add(-12 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now.Add(-12 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now.Add(-12 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, ExceededResponseTimeObjective: true, Timestamp: now})
+	for i := 0; i < 15; i++ {
+		watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now})
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &endpoint.Result{Success: true, Timestamp: now})
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+	}{
+		{
+			Name:         "slo",
+			Path:         "/api/v1/endpoints/core_frontend/slo",
+			ExpectedCode: http.StatusOK,
+		},
+		{
+			Name:         "endpoint-without-slo",
+			Path:         "/api/v1/endpoints/core_backend/slo",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "unknown-endpoint",
+			Path:         "/api/v1/endpoints/core_unknown/slo",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "endpoint-of-tenant",
+			Path:         "/api/v1/endpoints/acme_core_frontend/slo",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var status serviceLevelObjectiveStatus
+			if err := json.Unmarshal(body, &status); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if status.Objective != 90 || status.Window != 24*time.Hour || status.ResponseTime != time.Second {
+				t.Errorf("expected the configuration of the service level objective to be returned, got %s", body)
+			}
+			if status.TotalEvents != 20 || status.GoodEvents != 18 {
+				t.Errorf("expected 20 events of which 18 are good, got %d and %d", status.TotalEvents, status.GoodEvents)
+			}
+			if status.Indicator == nil || math.Abs(*status.Indicator-90) > 1e-9 {
+				t.Errorf("expected indicator to be 90, got %s", body)
+			}
+			if math.Abs(status.ErrorBudgetRemaining) > 1e-9 {
+				t.Errorf("expected error budget to be exhausted, got %f remaining", status.ErrorBudgetRemaining)
+			}
+			if len(status.BurnRates) != 3 {
+				t.Errorf("expected burn rates over 1h, 6h and 24h, got %v", status.BurnRates)
+			}
+			if burnRate := status.BurnRates["1h"]; math.Abs(burnRate-0.625) > 1e-9 {
+				t.Errorf("expected burn rate over the last hour to be 0.625, got %f", burnRate)
+			}
+			if burnRate := status.BurnRates["24h"]; math.Abs(burnRate-1) > 1e-9 {
+				t.Errorf("expected burn rate over the last 24 hours to be 1, got %f", burnRate)
+			}
+		})
+	}
+}
@@ -4,13 +4,32 @@ import (
 	_ "embed"
 	"html/template"
 	"log"
+	"regexp"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/config/ui"
 	static "github.com/TwiN/gatus/v5/web"
 	"github.com/gofiber/fiber/v2"
 )
 
-func SinglePageApplication(ui *ui.Config) fiber.Handler {
+// forwardedPrefixPattern is the pattern a X-Forwarded-Prefix header must match to be honored, which is a path made of
+// one or more segments of unreserved characters, e.g. /status
+var forwardedPrefixPattern = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+/?$`)
+
+// getForwardedPrefix returns the prefix passed through the X-Forwarded-Prefix header without its trailing slash, or an
+// empty string if the header is missing or invalid.
+//
+// Because the prefix is used as-is to reference the assets of the page, anything but a single leading slash followed by
+// a safe path is ignored, so that the header cannot be used to load assets from another origin (e.g. //example.com).
+func getForwardedPrefix(c *fiber.Ctx) string {
+	forwardedPrefix := c.Get("X-Forwarded-Prefix")
+	if !forwardedPrefixPattern.MatchString(forwardedPrefix) {
+		return ""
+	}
+	return strings.TrimRight(forwardedPrefix, "/")
+}
+
+func SinglePageApplication(ui *ui.Config, basePath string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		t, err := template.ParseFS(static.FileSystem, static.IndexPath)
 		if err != nil {
@@ -18,8 +37,11 @@ func SinglePageApplication(ui *ui.Config) fiber.Handler {
 			log.Println("[api.SinglePageApplication] Failed to parse template. This should never happen, because the template is validated on start. Error:", err.Error())
 			return c.Status(500).SendString("Failed to parse template. This should never happen, because the template is validated on start.")
 		}
+		// Copy the UI configuration so that the base path of the request doesn't leak into other requests
+		uiConfig := *ui
+		uiConfig.BasePath = getForwardedPrefix(c) + basePath
 		c.Set("Content-Type", "text/html")
-		err = t.Execute(c, ui)
+		err = t.Execute(c, uiConfig)
 		if err != nil {
 			// This should never happen, because ui.ValidateAndSetDefaults validates that the template works.
 			log.Println("[api.SinglePageApplication] Failed to execute template. This should never happen, because the template is validated on start. Error:", err.Error())
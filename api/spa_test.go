@@ -11,6 +11,7 @@ import (
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/watchdog"
 )
@@ -77,3 +78,72 @@ func TestSinglePageApplication(t *testing.T) {
 		})
 	}
 }
+
+func TestSinglePageApplicationWithBasePath(t *testing.T) {
+	cfg := &config.Config{
+		UI:  &ui.Config{Title: "example-title"},
+		Web: &web.Config{BasePath: "/status"},
+	}
+	api := New(cfg)
+	router := api.Router()
+	type Scenario struct {
+		Name                    string
+		Path                    string
+		ForwardedPrefix         string
+		ExpectedAssetPathPrefix string
+	}
+	scenarios := []Scenario{
+		{
+			Name:                    "base-path",
+			Path:                    "/status/",
+			ExpectedAssetPathPrefix: "/status/js/app.js",
+		},
+		{
+			Name:                    "base-path-with-forwarded-prefix",
+			Path:                    "/status/endpoints/core_frontend",
+			ForwardedPrefix:         "/monitoring/",
+			ExpectedAssetPathPrefix: "/monitoring/status/js/app.js",
+		},
+		{
+			Name:                    "base-path-with-forwarded-prefix-pointing-to-another-origin",
+			Path:                    "/status/",
+			ForwardedPrefix:         "//example.com",
+			ExpectedAssetPathPrefix: "/status/js/app.js",
+		},
+		{
+			Name:                    "base-path-with-forwarded-prefix-containing-unsafe-characters",
+			Path:                    "/status/",
+			ForwardedPrefix:         `/monitoring"><script>`,
+			ExpectedAssetPathPrefix: "/status/js/app.js",
+		},
+		{
+			Name:                    "base-path-with-relative-forwarded-prefix",
+			Path:                    "/status/",
+			ForwardedPrefix:         "monitoring",
+			ExpectedAssetPathPrefix: "/status/js/app.js",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			if len(scenario.ForwardedPrefix) > 0 {
+				request.Header.Set("X-Forwarded-Prefix", scenario.ForwardedPrefix)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != 200 {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, 200, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if !strings.Contains(string(body), `src="`+scenario.ExpectedAssetPathPrefix+`"`) {
+				t.Errorf("%s %s should have referenced assets using %s, got %s", request.Method, request.URL, scenario.ExpectedAssetPathPrefix, string(body))
+			}
+		})
+	}
+	if len(cfg.UI.BasePath) != 0 {
+		t.Error("the base path of a request should not have been persisted in the UI configuration")
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/tenant"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/gofiber/fiber/v2"
+)
+
+var tenantPathParameter = &openAPIParameter{Name: "tenant", In: "path", Required: true, Description: "Name of the tenant", Schema: &openAPISchema{Type: "string"}}
+
+var invalidTenantTokenResponse = &openAPIResponse{Description: "Unknown tenant, or missing or invalid bearer token"}
+
+// getTenantEndpointStatusesOperation documents TenantEndpointStatuses
+var getTenantEndpointStatusesOperation = &openAPIOperation{
+	OperationID:  "getTenantEndpointStatuses",
+	Summary:      "Retrieve the status of all endpoints of a tenant",
+	Tags:         []string{"tenants"},
+	Parameters:   append(append([]*openAPIParameter{tenantPathParameter}, pageQueryParameters...), resultsTimeRangeQueryParameters...),
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Statuses of the endpoints of the tenant"}, "400": badRequestResponse, "401": invalidTenantTokenResponse, "500": internalErrorResponse},
+	Security:     []map[string][]string{{securitySchemeBearer: {}}},
+	responseType: []*endpoint.Status{},
+}
+
+// TenantEndpointStatuses handles requests to retrieve the statuses of the endpoints of a tenant
+func TenantEndpointStatuses(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		t := authorizeTenant(cfg, c)
+		if t == nil {
+			return c.Status(401).SendString("unknown tenant or invalid token")
+		}
+		page, pageSize := extractPageAndPageSizeFromRequest(c)
+		from, to, order, err := extractResultsTimeRangeAndOrderFromRequest(c)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize).WithResultsTimeRange(from, to).WithResultsOrder(order))
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			log.Printf("[api.TenantEndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		tenantEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+		for _, endpointStatus := range endpointStatuses {
+			if endpoint.ExtractTenantFromKey(endpointStatus.Key) == t.Name {
+				tenantEndpointStatuses = append(tenantEndpointStatuses, endpointStatus)
+			}
+		}
+		return c.Status(200).JSON(tenantEndpointStatuses)
+	}
+}
+
+// getTenantEndpointStatusOperation documents TenantEndpointStatus
+var getTenantEndpointStatusOperation = &openAPIOperation{
+	OperationID:  "getTenantEndpointStatus",
+	Summary:      "Retrieve the status of an endpoint of a tenant",
+	Tags:         []string{"tenants"},
+	Parameters:   append(append([]*openAPIParameter{tenantPathParameter, keyPathParameter}, pageQueryParameters...), resultsTimeRangeQueryParameters...),
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Status of the endpoint"}, "400": badRequestResponse, "401": invalidTenantTokenResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	Security:     []map[string][]string{{securitySchemeBearer: {}}},
+	responseType: &endpoint.Status{},
+}
+
+// TenantEndpointStatus handles requests to retrieve the status of an endpoint of a tenant
+func TenantEndpointStatus(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		t := authorizeTenant(cfg, c)
+		if t == nil {
+			return c.Status(401).SendString("unknown tenant or invalid token")
+		}
+		// Endpoints of other tenants are reported as not found to avoid leaking their existence
+		if endpoint.ExtractTenantFromKey(c.Params("key")) != t.Name {
+			return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+		}
+		page, pageSize := extractPageAndPageSizeFromRequest(c)
+		from, to, order, err := extractResultsTimeRangeAndOrderFromRequest(c)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		endpointStatus, err := store.Get().GetEndpointStatusByKey(c.Params("key"), paging.NewEndpointStatusParams().WithResults(page, pageSize).WithResultsTimeRange(from, to).WithResultsOrder(order).WithEvents(1, common.MaximumNumberOfEvents))
+		if err != nil {
+			if errors.Is(err, common.ErrEndpointNotFound) {
+				return c.Status(404).SendString(err.Error())
+			}
+			if errors.Is(err, common.ErrInvalidTimeRange) {
+				return c.Status(400).SendString(err.Error())
+			}
+			log.Printf("[api.TenantEndpointStatus] Failed to retrieve endpoint status: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		return c.Status(200).JSON(endpointStatus)
+	}
+}
+
+// authorizeTenant returns the tenant referenced by the request if the bearer token of the request grants access to it,
+// or nil otherwise. Unknown tenants and invalid tokens are not distinguished to avoid leaking the name of tenants.
+func authorizeTenant(cfg *config.Config, c *fiber.Ctx) *tenant.Config {
+	t := cfg.GetTenantByName(c.Params("tenant"))
+	if t == nil {
+		return nil
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(string(c.Request().Header.Peek("Authorization")), "Bearer "))
+	if !t.HasAccess(token) {
+		return nil
+	}
+	return t
+}
+
+// excludeTenantEndpointStatuses returns the statuses of the endpoints that don't belong to any tenant, which are the
+// only ones that may be exposed outside the API of tenants
+func excludeTenantEndpointStatuses(endpointStatuses []*endpoint.Status) []*endpoint.Status {
+	filteredEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		if len(endpoint.ExtractTenantFromKey(endpointStatus.Key)) == 0 {
+			filteredEndpointStatuses = append(filteredEndpointStatuses, endpointStatus)
+		}
+	}
+	return filteredEndpointStatuses
+}
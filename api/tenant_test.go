@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/tenant"
+	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestTenants(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	globalEndpoint := &endpoint.Endpoint{Name: "website", Group: "core", URL: "https://example.org"}
+	acmeEndpoint := &endpoint.Endpoint{Name: "website", Group: "core", URL: "https://acme.example.org", Tenant: "acme"}
+	globexEndpoint := &endpoint.Endpoint{Name: "website", Group: "core", URL: "https://globex.example.org", Tenant: "globex"}
+	for _, ep := range []*endpoint.Endpoint{globalEndpoint, acmeEndpoint, globexEndpoint} {
+		store.Get().Insert(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	}
+	cfg := &config.Config{
+		Endpoints: []*endpoint.Endpoint{globalEndpoint, acmeEndpoint, globexEndpoint},
+		Tenants: []*tenant.Config{
+			{Name: "acme", Token: "acme-token", Endpoints: []*endpoint.Endpoint{acmeEndpoint}},
+			{Name: "globex", Public: true, Endpoints: []*endpoint.Endpoint{globexEndpoint}},
+		},
+		UI: ui.GetDefaultConfig(),
+	}
+	router := New(cfg).Router()
+	scenarios := []struct {
+		name         string
+		path         string
+		token        string
+		expectedCode int
+		expectedKeys []string
+		single       bool // whether the response is a single endpoint status rather than a list
+	}{
+		{
+			name:         "global-statuses-exclude-tenants",
+			path:         "/api/v1/endpoints/statuses",
+			expectedCode: http.StatusOK,
+			expectedKeys: []string{"core_website"},
+		},
+		{
+			name:         "global-status-of-tenant-endpoint",
+			path:         "/api/v1/endpoints/acme_core_website/statuses",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "tenant-statuses",
+			path:         "/api/v1/tenants/acme/endpoints/statuses",
+			token:        "acme-token",
+			expectedCode: http.StatusOK,
+			expectedKeys: []string{"acme_core_website"},
+		},
+		{
+			name:         "tenant-statuses-without-token",
+			path:         "/api/v1/tenants/acme/endpoints/statuses",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "tenant-statuses-with-token-of-other-tenant",
+			path:         "/api/v1/tenants/acme/endpoints/statuses",
+			token:        "globex-token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "unknown-tenant-statuses",
+			path:         "/api/v1/tenants/initech/endpoints/statuses",
+			token:        "acme-token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "public-tenant-statuses",
+			path:         "/api/v1/tenants/globex/endpoints/statuses",
+			expectedCode: http.StatusOK,
+			expectedKeys: []string{"globex_core_website"},
+		},
+		{
+			name:         "tenant-status",
+			path:         "/api/v1/tenants/acme/endpoints/acme_core_website/statuses",
+			token:        "acme-token",
+			expectedCode: http.StatusOK,
+			expectedKeys: []string{"acme_core_website"},
+			single:       true,
+		},
+		{
+			name:         "tenant-status-of-endpoint-of-other-tenant",
+			path:         "/api/v1/tenants/globex/endpoints/acme_core_website/statuses",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "tenant-status-of-global-endpoint",
+			path:         "/api/v1/tenants/acme/endpoints/core_website/statuses",
+			token:        "acme-token",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "tenant-status-page",
+			path:         "/tenants/acme",
+			expectedCode: http.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.path, http.NoBody)
+			if len(scenario.token) > 0 {
+				request.Header.Set("Authorization", "Bearer "+scenario.token)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.expectedCode {
+				t.Fatalf("expected status code %d, got %d", scenario.expectedCode, response.StatusCode)
+			}
+			if scenario.expectedKeys == nil {
+				return
+			}
+			var keys []string
+			var endpointStatuses []*endpoint.Status
+			var endpointStatus endpoint.Status
+			decoder := json.NewDecoder(response.Body)
+			if scenario.single {
+				if err := decoder.Decode(&endpointStatus); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				keys = append(keys, endpointStatus.Key)
+			} else {
+				if err := decoder.Decode(&endpointStatuses); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				for _, status := range endpointStatuses {
+					keys = append(keys, status.Key)
+				}
+			}
+			if len(keys) != len(scenario.expectedKeys) {
+				t.Fatalf("expected keys %v, got %v", scenario.expectedKeys, keys)
+			}
+			for i := range keys {
+				if keys[i] != scenario.expectedKeys[i] {
+					t.Errorf("expected keys %v, got %v", scenario.expectedKeys, keys)
+				}
+			}
+		})
+	}
+}
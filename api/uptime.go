@@ -0,0 +1,92 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultDailyUptimeDays is the number of days returned by DailyUptime when the days query parameter is omitted
+	defaultDailyUptimeDays = 90
+
+	// maximumDailyUptimeDays is the maximum number of days that can be retrieved through DailyUptime, which matches
+	// the longest retention of the uptime data worth charting (2y). Days older than the retention configured for the
+	// storage are simply returned without uptime.
+	maximumDailyUptimeDays = 2 * 365
+)
+
+// dailyUptime is the uptime of an endpoint over the course of a single day
+type dailyUptime struct {
+	// Date is the day, formatted as YYYY-MM-DD, in the time zone of the server
+	Date string `json:"date"`
+
+	// Uptime is the percentage of successful executions during the day, as a value between 0 and 1, or nil if there
+	// were no executions during the day
+	Uptime *float64 `json:"uptime"`
+
+	// Incidents is the number of times the endpoint went from healthy to unhealthy during the day
+	Incidents uint64 `json:"incidents"`
+}
+
+// getDailyUptimeOperation documents DailyUptime
+var getDailyUptimeOperation = &openAPIOperation{
+	OperationID: "getDailyUptime",
+	Summary:     "Retrieve the daily uptime and number of incidents of an endpoint",
+	Tags:        []string{"endpoints"},
+	Parameters: []*openAPIParameter{
+		keyPathParameter,
+		{Name: "days", In: "query", Description: "Number of days to retrieve, including the current day (1-730, defaults to 90)", Schema: &openAPISchema{Type: "integer"}},
+	},
+	Responses:    map[string]*openAPIResponse{"200": {Description: "Uptime of each day, from the oldest to the current day"}, "400": badRequestResponse, "404": notFoundResponse, "500": internalErrorResponse},
+	responseType: []*dailyUptime{},
+}
+
+// DailyUptime handles requests to retrieve the uptime and the number of incidents of an endpoint for each of
+// the last days, as required to render availability bars on status pages.
+//
+// Because the data is computed from the aggregated uptime data rather than from the results, this is cheap regardless
+// of how many results have been stored.
+func DailyUptime(c *fiber.Ctx) error {
+	key := c.Params("key")
+	// The endpoints of tenants can only be retrieved through the API of their tenant
+	if len(endpoint.ExtractTenantFromKey(key)) > 0 {
+		return c.Status(404).SendString(common.ErrEndpointNotFound.Error())
+	}
+	days := defaultDailyUptimeDays
+	if daysParameter := c.Query("days"); len(daysParameter) > 0 {
+		var err error
+		if days, err = strconv.Atoi(daysParameter); err != nil || days < 1 || days > maximumDailyUptimeDays {
+			return c.Status(400).SendString("days must be an integer between 1 and " + strconv.Itoa(maximumDailyUptimeDays))
+		}
+	}
+	now := time.Now()
+	from := endpoint.TruncateToDay(now).AddDate(0, 0, -(days - 1))
+	dailyUptimeStatistics, err := store.Get().GetDailyUptimeStatisticsByKey(key, from, now)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return c.Status(404).SendString(err.Error())
+		} else if errors.Is(err, common.ErrInvalidTimeRange) {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api.DailyUptime] Failed to retrieve daily uptime: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	dailyUptimes := make([]*dailyUptime, 0, days)
+	for day := from; !day.After(now); day = day.AddDate(0, 0, 1) {
+		entry := &dailyUptime{Date: day.Format(time.DateOnly)}
+		if dailyStats := dailyUptimeStatistics[day.Unix()]; dailyStats != nil && dailyStats.TotalExecutions > 0 {
+			uptime := dailyStats.Uptime()
+			entry.Uptime = &uptime
+			entry.Incidents = dailyStats.Incidents
+		}
+		dailyUptimes = append(dailyUptimes, entry)
+	}
+	return c.Status(200).JSON(dailyUptimes)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestDailyUptime(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name:  "frontend",
+				Group: "core",
+			},
+		},
+	}
+	now := time.Now()
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: false, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &endpoint.Result{Success: true, Timestamp: now})
+	api := New(cfg)
+	router := api.Router()
+	scenarios := []struct {
+		Name                 string
+		Path                 string
+		ExpectedCode         int
+		ExpectedNumberOfDays int
+	}{
+		{
+			Name:                 "default-days",
+			Path:                 "/api/v1/endpoints/core_frontend/uptime/daily",
+			ExpectedCode:         http.StatusOK,
+			ExpectedNumberOfDays: 90,
+		},
+		{
+			Name:                 "7-days",
+			Path:                 "/api/v1/endpoints/core_frontend/uptime/daily?days=7",
+			ExpectedCode:         http.StatusOK,
+			ExpectedNumberOfDays: 7,
+		},
+		{
+			Name:         "too-many-days",
+			Path:         "/api/v1/endpoints/core_frontend/uptime/daily?days=731",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-days",
+			Path:         "/api/v1/endpoints/core_frontend/uptime/daily?days=invalid",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/uptime/daily",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var dailyUptimes []*dailyUptime
+			if err := json.Unmarshal(body, &dailyUptimes); err != nil {
+				t.Fatal("failed to decode body:", err)
+			}
+			if len(dailyUptimes) != scenario.ExpectedNumberOfDays {
+				t.Fatalf("expected %d days, got %d", scenario.ExpectedNumberOfDays, len(dailyUptimes))
+			}
+			if dailyUptimes[0].Uptime != nil {
+				t.Errorf("expected the oldest day to have no uptime, got %v", *dailyUptimes[0].Uptime)
+			}
+			today := dailyUptimes[len(dailyUptimes)-1]
+			if today.Date != now.Format(time.DateOnly) {
+				t.Errorf("expected the last day to be %s, got %s", now.Format(time.DateOnly), today.Date)
+			}
+			if today.Uptime == nil || *today.Uptime != 0.75 {
+				t.Errorf("expected the uptime of the current day to be 0.75, got %v", today.Uptime)
+			}
+			if today.Incidents != 1 {
+				t.Errorf("expected 1 incident during the current day, got %d", today.Incidents)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -46,3 +49,37 @@ func extractPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int) {
 	}
 	return
 }
+
+// validatePageAndPageSize replaces invalid page and page size values by their default or maximum value
+func validatePageAndPageSize(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = DefaultPage
+	}
+	if pageSize > MaximumPageSize {
+		pageSize = MaximumPageSize
+	} else if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	return page, pageSize
+}
+
+// extractResultsTimeRangeAndOrderFromRequest extracts the optional from, to and order query parameters, which are used
+// to filter the results of an endpoint by time range and to define from which end they're paged
+func extractResultsTimeRangeAndOrderFromRequest(c *fiber.Ctx) (from, to time.Time, order paging.Order, err error) {
+	if fromParameter := c.Query("from"); len(fromParameter) > 0 {
+		if from, err = time.Parse(time.RFC3339, fromParameter); err != nil {
+			return time.Time{}, time.Time{}, "", errors.New("'from' must be a timestamp in RFC3339 format")
+		}
+	}
+	if toParameter := c.Query("to"); len(toParameter) > 0 {
+		if to, err = time.Parse(time.RFC3339, toParameter); err != nil {
+			return time.Time{}, time.Time{}, "", errors.New("'to' must be a timestamp in RFC3339 format")
+		}
+	}
+	switch order = paging.Order(c.Query("order", string(paging.OrderDescending))); order {
+	case paging.OrderAscending, paging.OrderDescending:
+	default:
+		return time.Time{}, time.Time{}, "", errors.New("'order' must be either 'asc' or 'desc'")
+	}
+	return from, to, order, nil
+}
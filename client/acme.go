@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	acmeContentType      = "application/jose+json"
+	acmeReplayNonce      = "Replay-Nonce"
+	acmeIdentifierDNS    = "dns"
+	acmeSignatureES256   = "ES256"
+	acmeES256ScalarBytes = 32
+)
+
+var (
+	// ErrACMEDirectoryIncomplete is the error returned when the directory of an ACME server lacks a required resource
+	ErrACMEDirectoryIncomplete = errors.New("acme directory is missing newNonce, newAccount or newOrder")
+
+	// ErrACMENoNonce is the error returned when an ACME server doesn't return a nonce
+	ErrACMENoNonce = errors.New("acme server did not return a " + acmeReplayNonce + " header")
+
+	// ErrACMENoAccountURL is the error returned when an ACME server doesn't return the URL of the account registered
+	ErrACMENoAccountURL = errors.New("acme server did not return the URL of the account")
+)
+
+type acmeDirectory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type acmeIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// acmeCheckResult is the body of the result of an ACME check, which can be used in conditions
+// (e.g. [BODY].order.status == pending)
+type acmeCheckResult struct {
+	Directory json.RawMessage `json:"directory"`
+	Order     json.RawMessage `json:"order,omitempty"`
+}
+
+// CheckACMEDirectory checks whether an ACME server is able to issue certificates by retrieving its directory and a
+// fresh nonce and, if orderIdentifiers is not empty, by registering an account with the key passed and creating an
+// order for the identifiers as a dry run. The order is never finalized, so no certificate is issued.
+//
+// The body returned contains the directory and, if one was created, the order.
+func CheckACMEDirectory(directoryURL string, orderIdentifiers []string, accountKey *ecdsa.PrivateKey, config *Config) (connected bool, status int, body []byte, certificate *x509.Certificate, err error) {
+	httpClient := GetHTTPClient(config)
+	response, err := httpClient.Get(directoryURL)
+	if err != nil {
+		return false, 0, nil, nil, err
+	}
+	defer response.Body.Close()
+	if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
+		certificate = response.TLS.PeerCertificates[0]
+	}
+	checkResult := acmeCheckResult{}
+	if checkResult.Directory, err = io.ReadAll(response.Body); err != nil {
+		return true, response.StatusCode, nil, certificate, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return true, response.StatusCode, checkResult.Directory, certificate, fmt.Errorf("acme directory returned status code %d", response.StatusCode)
+	}
+	var directory acmeDirectory
+	if err = json.Unmarshal(checkResult.Directory, &directory); err != nil {
+		return true, response.StatusCode, checkResult.Directory, certificate, fmt.Errorf("invalid acme directory: %w", err)
+	}
+	if len(directory.NewNonce) == 0 || len(directory.NewAccount) == 0 || len(directory.NewOrder) == 0 {
+		return true, response.StatusCode, checkResult.Directory, certificate, ErrACMEDirectoryIncomplete
+	}
+	// Retrieve a fresh nonce, which every request that follows requires
+	nonceResponse, err := httpClient.Head(directory.NewNonce)
+	if err != nil {
+		return true, 0, nil, certificate, err
+	}
+	_ = nonceResponse.Body.Close()
+	status = nonceResponse.StatusCode
+	nonce := nonceResponse.Header.Get(acmeReplayNonce)
+	if len(nonce) == 0 {
+		return true, status, nil, certificate, ErrACMENoNonce
+	}
+	if len(orderIdentifiers) > 0 {
+		if accountKey == nil {
+			return true, status, nil, certificate, errors.New("an account key is required to create an acme order")
+		}
+		// Register the account, or retrieve it if it has already been registered with the same key
+		accountResponse, accountBody, err := postACME(httpClient, directory.NewAccount, map[string]any{"termsOfServiceAgreed": true}, accountKey, "", nonce)
+		if err != nil {
+			return true, 0, nil, certificate, err
+		}
+		status = accountResponse.StatusCode
+		if status != http.StatusOK && status != http.StatusCreated {
+			return true, status, accountBody, certificate, fmt.Errorf("acme account registration returned status code %d: %s", status, string(accountBody))
+		}
+		accountURL := accountResponse.Header.Get("Location")
+		if len(accountURL) == 0 {
+			return true, status, accountBody, certificate, ErrACMENoAccountURL
+		}
+		if nonce = accountResponse.Header.Get(acmeReplayNonce); len(nonce) == 0 {
+			return true, status, accountBody, certificate, ErrACMENoNonce
+		}
+		identifiers := make([]acmeIdentifier, 0, len(orderIdentifiers))
+		for _, identifier := range orderIdentifiers {
+			identifiers = append(identifiers, acmeIdentifier{Type: acmeIdentifierDNS, Value: identifier})
+		}
+		orderResponse, orderBody, err := postACME(httpClient, directory.NewOrder, map[string]any{"identifiers": identifiers}, accountKey, accountURL, nonce)
+		if err != nil {
+			return true, 0, nil, certificate, err
+		}
+		status = orderResponse.StatusCode
+		if status != http.StatusCreated {
+			return true, status, orderBody, certificate, fmt.Errorf("acme order creation returned status code %d: %s", status, string(orderBody))
+		}
+		checkResult.Order = orderBody
+	}
+	body, err = json.Marshal(checkResult)
+	return true, status, body, certificate, err
+}
+
+// postACME sends a request signed with the account key passed to an ACME server.
+//
+// If accountURL is empty, the public key is embedded in the request, which is required to register an account.
+func postACME(httpClient *http.Client, url string, payload any, accountKey *ecdsa.PrivateKey, accountURL, nonce string) (*http.Response, []byte, error) {
+	signedPayload, err := signACMEPayload(url, payload, accountKey, accountURL, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	response, err := httpClient.Post(url, acmeContentType, bytes.NewReader(signedPayload))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, body, nil
+}
+
+// signACMEPayload returns the payload passed as a JSON Web Signature using the flattened JSON serialization, as
+// required by RFC 8555
+func signACMEPayload(url string, payload any, accountKey *ecdsa.PrivateKey, accountURL, nonce string) ([]byte, error) {
+	protectedHeader := map[string]any{"alg": acmeSignatureES256, "nonce": nonce, "url": url}
+	if len(accountURL) > 0 {
+		protectedHeader["kid"] = accountURL
+	} else {
+		protectedHeader["jwk"] = acmeJWK(&accountKey.PublicKey)
+	}
+	protectedHeaderJSON, err := json.Marshal(protectedHeader)
+	if err != nil {
+		return nil, err
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	encodedProtectedHeader := base64.RawURLEncoding.EncodeToString(protectedHeaderJSON)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
+	digest := sha256.Sum256([]byte(encodedProtectedHeader + "." + encodedPayload))
+	r, s, err := ecdsa.Sign(rand.Reader, accountKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	// ES256 signatures are the concatenation of r and s, each padded to 32 bytes
+	signature := make([]byte, 2*acmeES256ScalarBytes)
+	r.FillBytes(signature[:acmeES256ScalarBytes])
+	s.FillBytes(signature[acmeES256ScalarBytes:])
+	return json.Marshal(map[string]string{
+		"protected": encodedProtectedHeader,
+		"payload":   encodedPayload,
+		"signature": base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
+
+func acmeJWK(publicKey *ecdsa.PublicKey) map[string]string {
+	x := make([]byte, acmeES256ScalarBytes)
+	y := make([]byte, acmeES256ScalarBytes)
+	publicKey.X.FillBytes(x)
+	publicKey.Y.FillBytes(y)
+	return map[string]string{
+		"crv": "P-256",
+		"kty": "EC",
+		"x":   base64.RawURLEncoding.EncodeToString(x),
+		"y":   base64.RawURLEncoding.EncodeToString(y),
+	}
+}
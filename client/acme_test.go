@@ -0,0 +1,158 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newFakeACMEServer creates a server implementing the subset of RFC 8555 used by CheckACMEDirectory, which verifies
+// the signature of every request it receives
+func newFakeACMEServer(t *testing.T, accountKey *ecdsa.PrivateKey, omitNonce bool) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"newNonce":"` + server.URL + `/new-nonce","newAccount":"` + server.URL + `/new-account","newOrder":"` + server.URL + `/new-order"}`))
+	})
+	mux.HandleFunc("/new-nonce", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !omitNonce {
+			w.Header().Set(acmeReplayNonce, "nonce-1")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	verify := func(r *http.Request, expectedNonce string, expectKID bool) (map[string]any, bool) {
+		body, _ := io.ReadAll(r.Body)
+		var jws map[string]string
+		if err := json.Unmarshal(body, &jws); err != nil {
+			return nil, false
+		}
+		protectedHeaderJSON, _ := base64.RawURLEncoding.DecodeString(jws["protected"])
+		var protectedHeader map[string]any
+		if err := json.Unmarshal(protectedHeaderJSON, &protectedHeader); err != nil {
+			return nil, false
+		}
+		if protectedHeader["nonce"] != expectedNonce || protectedHeader["url"] != server.URL+r.URL.Path || protectedHeader["alg"] != acmeSignatureES256 {
+			return nil, false
+		}
+		if _, hasKID := protectedHeader["kid"]; hasKID != expectKID {
+			return nil, false
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(jws["signature"])
+		if len(signature) != 2*acmeES256ScalarBytes {
+			return nil, false
+		}
+		digest := sha256.Sum256([]byte(jws["protected"] + "." + jws["payload"]))
+		rValue, sValue := new(big.Int).SetBytes(signature[:acmeES256ScalarBytes]), new(big.Int).SetBytes(signature[acmeES256ScalarBytes:])
+		if !ecdsa.Verify(&accountKey.PublicKey, digest[:], rValue, sValue) {
+			return nil, false
+		}
+		payloadJSON, _ := base64.RawURLEncoding.DecodeString(jws["payload"])
+		var payload map[string]any
+		_ = json.Unmarshal(payloadJSON, &payload)
+		return payload, true
+	}
+	mux.HandleFunc("/new-account", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := verify(r, "nonce-1", false); !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Location", server.URL+"/account/1")
+		w.Header().Set(acmeReplayNonce, "nonce-2")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status":"valid"}`))
+	})
+	mux.HandleFunc("/new-order", func(w http.ResponseWriter, r *http.Request) {
+		payload, ok := verify(r, "nonce-2", true)
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		identifiers, _ := json.Marshal(payload["identifiers"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status":"pending","identifiers":` + string(identifiers) + `}`))
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckACMEDirectory(t *testing.T) {
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newFakeACMEServer(t, accountKey, false)
+	connected, status, body, _, err := CheckACMEDirectory(server.URL+"/directory", nil, nil, nil)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !connected || status != http.StatusOK {
+		t.Errorf("expected to be connected with status 200, got connected=%v and status=%d", connected, status)
+	}
+	if !strings.Contains(string(body), `"directory":{"newNonce"`) || strings.Contains(string(body), `"order"`) {
+		t.Errorf("expected body to contain the directory and no order, got %s", string(body))
+	}
+}
+
+func TestCheckACMEDirectoryWithOrder(t *testing.T) {
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newFakeACMEServer(t, accountKey, false)
+	connected, status, body, _, err := CheckACMEDirectory(server.URL+"/directory", []string{"example.internal"}, accountKey, nil)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !connected || status != http.StatusCreated {
+		t.Errorf("expected to be connected with status 201, got connected=%v and status=%d", connected, status)
+	}
+	if !strings.Contains(string(body), `"order":{"status":"pending","identifiers":[{"type":"dns","value":"example.internal"}]}`) {
+		t.Errorf("expected body to contain the pending order, got %s", string(body))
+	}
+}
+
+func TestCheckACMEDirectoryWithOrderSignedByWrongKey(t *testing.T) {
+	accountKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	server := newFakeACMEServer(t, accountKey, false)
+	connected, status, _, _, err := CheckACMEDirectory(server.URL+"/directory", []string{"example.internal"}, otherKey, nil)
+	if err == nil {
+		t.Fatal("expected an error, because the server couldn't verify the signature")
+	}
+	if !connected || status != http.StatusBadRequest {
+		t.Errorf("expected to be connected with status 400, got connected=%v and status=%d", connected, status)
+	}
+}
+
+func TestCheckACMEDirectoryWithoutNonce(t *testing.T) {
+	accountKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	server := newFakeACMEServer(t, accountKey, true)
+	if _, _, _, _, err := CheckACMEDirectory(server.URL+"/directory", nil, nil, nil); !errors.Is(err, ErrACMENoNonce) {
+		t.Errorf("expected error %v, got %v", ErrACMENoNonce, err)
+	}
+}
+
+func TestCheckACMEDirectoryWithIncompleteDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"newNonce":"https://example.org/new-nonce"}`))
+	}))
+	defer server.Close()
+	if _, _, _, _, err := CheckACMEDirectory(server.URL, nil, nil, nil); !errors.Is(err, ErrACMEDirectoryIncomplete) {
+		t.Errorf("expected error %v, got %v", ErrACMEDirectoryIncomplete, err)
+	}
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAWSService is the default AWS service used to sign requests
+	DefaultAWSService = "lambda"
+
+	// AWSServiceS3 is the name of the S3 service, whose requests are signed slightly differently than other services
+	AWSServiceS3 = "s3"
+
+	awsSigningAlgorithm = "AWS4-HMAC-SHA256"
+	awsTimeFormat       = "20060102T150405Z"
+	awsDateFormat       = "20060102"
+)
+
+// AWSConfig is the configuration used to sign requests using AWS Signature Version 4 (SigV4)
+//
+// If any of the fields are empty, they are retrieved from the standard AWS environment variables.
+type AWSConfig struct {
+	// Region of the AWS service (e.g. us-east-1). Falls back to AWS_REGION, then AWS_DEFAULT_REGION.
+	Region string `yaml:"region,omitempty"`
+
+	// AccessKeyID used to sign requests. Falls back to AWS_ACCESS_KEY_ID.
+	AccessKeyID string `yaml:"access-key-id,omitempty"`
+
+	// SecretAccessKey used to sign requests. Falls back to AWS_SECRET_ACCESS_KEY.
+	SecretAccessKey string `yaml:"secret-access-key,omitempty"`
+
+	// SessionToken is the optional token of temporary credentials. Falls back to AWS_SESSION_TOKEN.
+	SessionToken string `yaml:"session-token,omitempty"`
+
+	// Service is the name of the AWS service the requests are signed for (defaults to DefaultAWSService)
+	Service string `yaml:"service,omitempty"`
+}
+
+// setDefaults fills the empty fields of the AWS configuration using the standard AWS environment variables
+func (c *AWSConfig) setDefaults() {
+	if len(c.Region) == 0 {
+		if c.Region = os.Getenv("AWS_REGION"); len(c.Region) == 0 {
+			c.Region = os.Getenv("AWS_DEFAULT_REGION")
+		}
+	}
+	if len(c.AccessKeyID) == 0 && len(c.SecretAccessKey) == 0 {
+		c.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		c.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if len(c.SessionToken) == 0 {
+			c.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+		}
+	}
+	if len(c.Service) == 0 {
+		c.Service = DefaultAWSService
+	}
+}
+
+// isValid returns true if the AWS configuration is valid
+func (c *AWSConfig) isValid() bool {
+	return len(c.Region) > 0 && len(c.AccessKeyID) > 0 && len(c.SecretAccessKey) > 0 && len(c.Service) > 0
+}
+
+// awsSigV4RoundTripper is an http.RoundTripper that signs every request using AWS Signature Version 4
+type awsSigV4RoundTripper struct {
+	next   http.RoundTripper
+	config AWSConfig
+}
+
+func (rt *awsSigV4RoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		_ = request.Body.Close()
+	}
+	// RoundTrippers should not modify the original request
+	signedRequest := request.Clone(request.Context())
+	signedRequest.Body = io.NopCloser(bytes.NewReader(body))
+	signAWSRequest(signedRequest, body, rt.config, time.Now())
+	return rt.next.RoundTrip(signedRequest)
+}
+
+// configureAWS returns an HTTP client that signs every request using AWS Signature Version 4
+func configureAWS(httpClient *http.Client, c AWSConfig) *http.Client {
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &awsSigV4RoundTripper{next: next, config: c}
+	return httpClient
+}
+
+// signAWSRequest adds the headers required by AWS Signature Version 4 to the request
+func signAWSRequest(request *http.Request, body []byte, c AWSConfig, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(awsTimeFormat)
+	scope := strings.Join([]string{now.Format(awsDateFormat), c.Region, c.Service, "aws4_request"}, "/")
+	if len(request.Host) == 0 {
+		request.Host = request.URL.Host
+	}
+	request.Header.Set("X-Amz-Date", amzDate)
+	if len(c.SessionToken) > 0 {
+		request.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	}
+	canonicalPath := request.URL.EscapedPath()
+	if c.Service == AWSServiceS3 {
+		// S3 requires the hash of the payload to be sent as a header, and its paths must not be escaped twice
+		request.Header.Set("X-Amz-Content-Sha256", hexSHA256(body))
+	} else {
+		canonicalPath = awsEscapePath(canonicalPath)
+	}
+	// Build the canonical headers
+	headers := map[string]string{"host": request.Host}
+	for name, values := range request.Header {
+		lowerCaseName := strings.ToLower(name)
+		if strings.HasPrefix(lowerCaseName, "x-amz-") || lowerCaseName == "content-type" {
+			headers[lowerCaseName] = strings.Join(values, ",")
+		}
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalPath,
+		awsCanonicalQueryString(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+	stringToSign := strings.Join([]string{awsSigningAlgorithm, amzDate, scope, hexSHA256([]byte(canonicalRequest))}, "\n")
+	signingKey := hmacSHA256([]byte("AWS4"+c.SecretAccessKey), now.Format(awsDateFormat))
+	signingKey = hmacSHA256(signingKey, c.Region)
+	signingKey = hmacSHA256(signingKey, c.Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", awsSigningAlgorithm, c.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// awsEscapePath escapes each segment of an already escaped path, as required by every AWS service but S3
+func awsEscapePath(path string) string {
+	if len(path) == 0 {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = awsEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func awsCanonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parameters []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parameters = append(parameters, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+	return strings.Join(parameters, "&")
+}
+
+// awsEscape percent-encodes every character except the unreserved characters defined by RFC 3986
+func awsEscape(s string) string {
+	var builder strings.Builder
+	for _, b := range []byte(s) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' {
+			builder.WriteByte(b)
+		} else {
+			builder.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+	return builder.String()
+}
+
+func hexSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrInvalidCABundle is the error returned when a CA bundle file doesn't contain any PEM-encoded certificate
+	ErrInvalidCABundle = errors.New("invalid CA bundle: file must contain at least one PEM-encoded certificate")
+
+	// globalCABundle is the CA bundle trusted by every client, in addition to the one of its own configuration
+	globalCABundle *caBundle
+
+	// lastCABundleGeneration is the generation assigned to the last CA bundle (re)loaded
+	lastCABundleGeneration atomic.Int64
+)
+
+// caBundle is a set of files containing PEM-encoded CA certificates that are reloaded whenever they are modified,
+// which allows trusting short-lived internal CAs without restarting Gatus every time they are rotated
+type caBundle struct {
+	files []string
+
+	mutex        sync.Mutex
+	certificates []*x509.Certificate
+	lastModTimes map[string]time.Time
+
+	// generation is a number that is unique to each (re)load of any CA bundle and increases with every one of them,
+	// which allows determining whether clients created with a set of bundles are outdated
+	generation int64
+}
+
+// newCABundle loads the CA certificates from the files passed
+func newCABundle(files []string) (*caBundle, error) {
+	bundle := &caBundle{files: files}
+	if err := bundle.load(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// load reads the certificates of every file of the bundle
+func (b *caBundle) load() error {
+	var certificates []*x509.Certificate
+	lastModTimes := make(map[string]time.Time, len(b.files))
+	for _, file := range b.files {
+		fileInfo, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		certificatesInFile, err := parseCertificates(data)
+		if err != nil {
+			return err
+		}
+		if len(certificatesInFile) == 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidCABundle, file)
+		}
+		certificates = append(certificates, certificatesInFile...)
+		lastModTimes[file] = fileInfo.ModTime()
+	}
+	b.certificates = certificates
+	b.lastModTimes = lastModTimes
+	b.generation = lastCABundleGeneration.Add(1)
+	return nil
+}
+
+// refresh reloads the bundle if one of its files has been modified since it was last loaded and returns the
+// current generation of the bundle.
+//
+// If the bundle cannot be reloaded, the certificates previously loaded are kept.
+func (b *caBundle) refresh() int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, file := range b.files {
+		fileInfo, err := os.Stat(file)
+		if err != nil || fileInfo.ModTime().Equal(b.lastModTimes[file]) {
+			continue
+		}
+		if err = b.load(); err != nil {
+			log.Printf("[client.refresh] Failed to reload CA bundle, keeping the certificates previously loaded: %s", err.Error())
+			// Avoid attempting to reload the bundle again until one of its files is modified again
+			b.lastModTimes[file] = fileInfo.ModTime()
+		} else {
+			log.Printf("[client.refresh] Reloaded CA bundle with %d certificates from %d files", len(b.certificates), len(b.files))
+		}
+		break
+	}
+	return b.generation
+}
+
+// addTo adds the certificates of the bundle to the pool passed
+func (b *caBundle) addTo(pool *x509.CertPool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, certificate := range b.certificates {
+		pool.AddCert(certificate)
+	}
+}
+
+// parseCertificates parses every PEM-encoded certificate in the data passed
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certificates, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+}
+
+// ValidateCABundleFiles returns an error if any of the files passed cannot be loaded as a CA bundle
+func ValidateCABundleFiles(files []string) error {
+	_, err := newCABundle(files)
+	return err
+}
+
+// SetGlobalCABundleFiles sets the files containing the PEM-encoded CA certificates trusted by every client, in
+// addition to the system's certificate pool and to the CA bundle files of their own configuration.
+//
+// Passing no files removes the global CA bundle.
+func SetGlobalCABundleFiles(files []string) error {
+	if len(files) == 0 {
+		globalCABundle = nil
+		return nil
+	}
+	bundle, err := newCABundle(files)
+	if err != nil {
+		return err
+	}
+	globalCABundle = bundle
+	return nil
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCertificateToFile(t *testing.T, path string, certificate *x509.Certificate, modTime time.Time) {
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal("failed to write certificate:", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal("failed to change the modification time of the certificate:", err)
+	}
+}
+
+func generateCACertificate(t *testing.T) *x509.Certificate {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("failed to generate private key:", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Internal CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	data, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal("failed to create certificate:", err)
+	}
+	certificate, _ := x509.ParseCertificate(data)
+	return certificate
+}
+
+func TestValidateCABundleFiles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "ca.pem")
+	writeCertificateToFile(t, validFile, server.Certificate(), time.Now())
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateCABundleFiles([]string{validFile}); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if err := ValidateCABundleFiles([]string{validFile, invalidFile}); !errors.Is(err, ErrInvalidCABundle) {
+		t.Errorf("expected error %v, got %v", ErrInvalidCABundle, err)
+	}
+	if err := ValidateCABundleFiles([]string{filepath.Join(dir, "missing.pem")}); err == nil {
+		t.Error("expected an error for a file that doesn't exist, got none")
+	}
+}
+
+func TestConfig_getHTTPClient_withCABundleFiles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	bundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	// Start by trusting another CA, as would be the case before a CA rotation
+	writeCertificateToFile(t, bundleFile, generateCACertificate(t), time.Now().Add(-time.Hour))
+	cfg := &Config{TLS: &TLSConfig{CABundleFiles: []string{bundleFile}}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if response, err := cfg.getHTTPClient().Get(server.URL); err == nil {
+		response.Body.Close()
+		t.Fatal("expected the certificate of the server to not be trusted")
+	}
+	writeCertificateToFile(t, bundleFile, server.Certificate(), time.Now())
+	response, err := cfg.getHTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatal("expected the certificate of the server to be trusted after the CA bundle was reloaded, got", err)
+	}
+	response.Body.Close()
+	// A CA bundle that becomes invalid must not cause the certificates previously loaded to be forgotten
+	if err := os.WriteFile(bundleFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(bundleFile, time.Now().Add(time.Hour), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	response, err = cfg.getHTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatal("expected the certificate of the server to still be trusted, got", err)
+	}
+	response.Body.Close()
+}
+
+func TestSetGlobalCABundleFiles(t *testing.T) {
+	defer SetGlobalCABundleFiles(nil)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	bundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	writeCertificateToFile(t, bundleFile, server.Certificate(), time.Now())
+	if err := SetGlobalCABundleFiles([]string{bundleFile}); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cfg := &Config{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	response, err := cfg.getHTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatal("expected the certificate of the server to be trusted through the global CA bundle, got", err)
+	}
+	response.Body.Close()
+	if err := SetGlobalCABundleFiles(nil); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if globalCABundle != nil {
+		t.Error("expected the global CA bundle to have been removed")
+	}
+}
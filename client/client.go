@@ -1,14 +1,16 @@
 package client
 
 import (
+	"bytes"
 	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/smtp"
+	"net/url"
 	"runtime"
 	"strings"
 	"time"
@@ -76,7 +78,11 @@ func GetDomainExpiration(hostname string) (domainExpiration time.Duration, err e
 
 // CanCreateTCPConnection checks whether a connection can be established with a TCP endpoint
 func CanCreateTCPConnection(address string, config *Config) bool {
-	conn, err := net.DialTimeout("tcp", address, config.Timeout)
+	dialer, err := config.getDialer("tcp")
+	if err != nil {
+		return false
+	}
+	conn, err := dialer.Dial("tcp", address)
 	if err != nil {
 		return false
 	}
@@ -86,7 +92,11 @@ func CanCreateTCPConnection(address string, config *Config) bool {
 
 // CanCreateUDPConnection checks whether a connection can be established with a UDP endpoint
 func CanCreateUDPConnection(address string, config *Config) bool {
-	conn, err := net.DialTimeout("udp", address, config.Timeout)
+	dialer, err := config.getDialer("udp")
+	if err != nil {
+		return false
+	}
+	conn, err := dialer.Dial("udp", address)
 	if err != nil {
 		return false
 	}
@@ -103,7 +113,14 @@ func CanCreateSCTPConnection(address string, config *Config) bool {
 			res <- false
 		}
 
-		conn, err := sctp.DialSCTP("sctp", nil, addr)
+		var localAddr *sctp.SCTPAddr
+		if localIP, err := config.getLocalIP(); err != nil {
+			res <- false
+			return
+		} else if localIP != nil {
+			localAddr = &sctp.SCTPAddr{IPAddrs: []net.IPAddr{{IP: localIP}}}
+		}
+		conn, err := sctp.DialSCTP("sctp", localAddr, addr)
 		if err != nil {
 			res <- false
 		}
@@ -120,52 +137,54 @@ func CanCreateSCTPConnection(address string, config *Config) bool {
 }
 
 // CanPerformStartTLS checks whether a connection can be established to an address using the STARTTLS protocol
-func CanPerformStartTLS(address string, config *Config) (connected bool, certificate *x509.Certificate, err error) {
+//
+// The state of the TLS connection is returned so that the certificate and the TLS version negotiated can be inspected.
+func CanPerformStartTLS(address string, config *Config) (connected bool, state *tls.ConnectionState, err error) {
 	hostAndPort := strings.Split(address, ":")
 	if len(hostAndPort) != 2 {
 		return false, nil, errors.New("invalid address for starttls, format must be host:port")
 	}
-	connection, err := net.DialTimeout("tcp", address, config.Timeout)
+	dialer, err := config.getDialer("tcp")
 	if err != nil {
 		return
 	}
-	smtpClient, err := smtp.NewClient(connection, hostAndPort[0])
+	connection, err := dialer.Dial("tcp", address)
 	if err != nil {
 		return
 	}
-	err = smtpClient.StartTLS(&tls.Config{
-		InsecureSkipVerify: config.Insecure,
-		ServerName:         hostAndPort[0],
-	})
+	smtpClient, err := smtp.NewClient(connection, hostAndPort[0])
 	if err != nil {
 		return
 	}
-	if state, ok := smtpClient.TLSConnectionState(); ok {
-		certificate = state.PeerCertificates[0]
-	} else {
+	tlsConfig := config.getTLSConfig()
+	tlsConfig.ServerName = hostAndPort[0]
+	if err = smtpClient.StartTLS(tlsConfig); err != nil {
+		return
+	}
+	connectionState, ok := smtpClient.TLSConnectionState()
+	if !ok {
 		return false, nil, errors.New("could not get TLS connection state")
 	}
-	return true, certificate, nil
+	return true, &connectionState, nil
 }
 
 // CanPerformTLS checks whether a connection can be established to an address using the TLS protocol
-func CanPerformTLS(address string, config *Config) (connected bool, certificate *x509.Certificate, err error) {
-	connection, err := tls.DialWithDialer(&net.Dialer{Timeout: config.Timeout}, "tcp", address, &tls.Config{
-		InsecureSkipVerify: config.Insecure,
-	})
+//
+// The state of the TLS connection is returned so that the certificate and the TLS version negotiated can be inspected.
+// Note that the first of the PeerCertificates, which is the certificate of the server, is populated even if
+// config.Insecure is set to true, unlike VerifiedChains.
+func CanPerformTLS(address string, config *Config) (connected bool, state *tls.ConnectionState, err error) {
+	dialer, err := config.getDialer("tcp")
+	if err != nil {
+		return
+	}
+	connection, err := tls.DialWithDialer(dialer, "tcp", address, config.getTLSConfig())
 	if err != nil {
 		return
 	}
 	defer connection.Close()
-	verifiedChains := connection.ConnectionState().VerifiedChains
-	// If config.Insecure is set to true, verifiedChains will be an empty list []
-	// We should get the parsed certificates from PeerCertificates, it can't be empty on the client side
-	// Reference: https://pkg.go.dev/crypto/tls#PeerCertificates
-	if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
-		peerCertificates := connection.ConnectionState().PeerCertificates
-		return true, peerCertificates[0], nil
-	}
-	return true, verifiedChains[0][0], nil
+	connectionState := connection.ConnectionState()
+	return true, &connectionState, nil
 }
 
 // CanCreateSSHConnection checks whether a connection can be established and a command can be executed to an address
@@ -183,7 +202,16 @@ func CanCreateSSHConnection(address, username, password string, config *Config)
 		port = "22"
 	}
 
-	cli, err := ssh.Dial("tcp", strings.Join([]string{address, port}, ":"), &ssh.ClientConfig{
+	dialer, err := config.getDialer("tcp")
+	if err != nil {
+		return false, nil, err
+	}
+	address = strings.Join([]string{address, port}, ":")
+	conn, err := dialer.Dial("tcp", address)
+	if err != nil {
+		return false, nil, err
+	}
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, address, &ssh.ClientConfig{
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 		User:            username,
 		Auth: []ssh.AuthMethod{
@@ -192,10 +220,11 @@ func CanCreateSSHConnection(address, username, password string, config *Config)
 		Timeout: config.Timeout,
 	})
 	if err != nil {
+		_ = conn.Close()
 		return false, nil, err
 	}
 
-	return true, cli, nil
+	return true, ssh.NewClient(sshConn, channels, requests), nil
 }
 
 // ExecuteSSHCommand executes a command to an address using the SSH protocol.
@@ -250,6 +279,11 @@ func Ping(address string, config *Config) (bool, time.Duration) {
 	// See https://github.com/prometheus-community/pro-bing#linux
 	pinger.SetPrivileged(runtime.GOOS != "darwin")
 	pinger.SetNetwork(config.Network)
+	if localIP, err := config.getLocalIP(); err != nil {
+		return false, 0
+	} else if localIP != nil {
+		pinger.Source = localIP.String()
+	}
 	err := pinger.Run()
 	if err != nil {
 		return false, 0
@@ -275,7 +309,9 @@ func QueryWebSocket(address, body string, config *Config) (bool, []byte, error)
 		return false, nil, fmt.Errorf("error configuring websocket connection: %w", err)
 	}
 	if config != nil {
-		wsConfig.Dialer = &net.Dialer{Timeout: config.Timeout}
+		if wsConfig.Dialer, err = config.getDialer("tcp"); err != nil {
+			return false, nil, err
+		}
 	}
 	// Dial URL
 	ws, err := websocket.DialConfig(wsConfig)
@@ -296,47 +332,96 @@ func QueryWebSocket(address, body string, config *Config) (bool, []byte, error)
 	return true, msg[:n], nil
 }
 
-func QueryDNS(queryType, queryName, url string) (connected bool, dnsRcode string, body []byte, err error) {
+// DNSRecord is a record of the answer to a DNS query
+type DNSRecord struct {
+	// Value is the value of the record, such as the IP of an A record or the target of a CNAME record
+	Value string
+
+	// TTL is the duration for which the record may be cached
+	TTL time.Duration
+}
+
+// QueryDNS sends a DNS query and returns the value of the last record of the answer as body, as well as every record
+// of the answer of a supported query type
+//
+// The configuration passed may be nil, in which case the query is sent from any local address.
+func QueryDNS(queryType, queryName, url string, config *Config) (connected bool, dnsRcode string, body []byte, records []DNSRecord, err error) {
 	if !strings.Contains(url, ":") {
 		url = fmt.Sprintf("%s:%d", url, dnsPort)
 	}
 	queryTypeAsUint16 := dns.StringToType[queryType]
 	c := new(dns.Client)
+	if config != nil && config.HasLocalAddress() {
+		if c.Dialer, err = config.getDialer("udp"); err != nil {
+			return false, "", nil, nil, err
+		}
+	}
 	m := new(dns.Msg)
 	m.SetQuestion(queryName, queryTypeAsUint16)
 	r, _, err := c.Exchange(m, url)
 	if err != nil {
-		return false, "", nil, err
+		return false, "", nil, nil, err
 	}
 	connected = true
 	dnsRcode = dns.RcodeToString[r.Rcode]
 	for _, rr := range r.Answer {
+		var value string
 		switch rr.Header().Rrtype {
 		case dns.TypeA:
 			if a, ok := rr.(*dns.A); ok {
-				body = []byte(a.A.String())
+				value = a.A.String()
 			}
 		case dns.TypeAAAA:
 			if aaaa, ok := rr.(*dns.AAAA); ok {
-				body = []byte(aaaa.AAAA.String())
+				value = aaaa.AAAA.String()
 			}
 		case dns.TypeCNAME:
 			if cname, ok := rr.(*dns.CNAME); ok {
-				body = []byte(cname.Target)
+				value = cname.Target
 			}
 		case dns.TypeMX:
 			if mx, ok := rr.(*dns.MX); ok {
-				body = []byte(mx.Mx)
+				value = mx.Mx
 			}
 		case dns.TypeNS:
 			if ns, ok := rr.(*dns.NS); ok {
-				body = []byte(ns.Ns)
+				value = ns.Ns
 			}
 		default:
 			body = []byte("query type is not supported yet")
+			continue
 		}
+		body = []byte(value)
+		records = append(records, DNSRecord{Value: value, TTL: time.Duration(rr.Header().Ttl) * time.Second})
+	}
+	return connected, dnsRcode, body, records, nil
+}
+
+// InvokeLambdaFunction invokes an AWS Lambda function synchronously using the Invoke API and returns the payload of
+// the response, as well as the type of error returned by the function, if any.
+//
+// The request is signed using the AWS configuration of the client configuration passed.
+func InvokeLambdaFunction(functionName string, payload []byte, config *Config) (connected bool, status int, body []byte, functionError string, err error) {
+	if config == nil || config.AWSConfig == nil {
+		return false, 0, nil, "", errors.New("AWS configuration is required to invoke a lambda function")
+	}
+	invocationURL := fmt.Sprintf("https://lambda.%s.amazonaws.com/2015-03-31/functions/%s/invocations", config.AWSConfig.Region, url.PathEscape(functionName))
+	request, err := http.NewRequest(http.MethodPost, invocationURL, bytes.NewReader(payload))
+	if err != nil {
+		return false, 0, nil, "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Amz-Invocation-Type", "RequestResponse")
+	response, err := GetHTTPClient(config).Do(request)
+	if err != nil {
+		return false, 0, nil, "", err
+	}
+	defer response.Body.Close()
+	body, err = io.ReadAll(response.Body)
+	if err != nil {
+		return true, response.StatusCode, nil, "", err
 	}
-	return connected, dnsRcode, body, nil
+	return true, response.StatusCode, body, response.Header.Get("X-Amz-Function-Error"), nil
 }
 
 // InjectHTTPClient is used to inject a custom HTTP client for testing purposes
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/tls"
 	"io"
+	"net"
 	"net/http"
 	"testing"
 	"time"
@@ -225,6 +226,29 @@ func TestCanCreateTCPConnection(t *testing.T) {
 	}
 }
 
+func TestCanCreateTCPConnectionWithSourceAddress(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	if !CanCreateTCPConnection(listener.Addr().String(), &Config{Timeout: 5 * time.Second, SourceAddress: "127.0.0.1"}) {
+		t.Error("should've succeeded, because the source address is assigned to the loopback interface")
+	}
+	if CanCreateTCPConnection(listener.Addr().String(), &Config{Timeout: 5 * time.Second, SourceAddress: "192.0.2.1"}) {
+		t.Error("should've failed, because the source address isn't assigned to the host")
+	}
+}
+
 // This test checks if a HTTP client configured with `configureOAuth2()` automatically
 // performs a Client Credentials OAuth2 flow and adds the obtained token as a `Authorization`
 // header to all outgoing HTTP calls.
@@ -409,7 +433,7 @@ func TestQueryDNS(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, dnsRCode, body, err := QueryDNS(test.inputDNS.QueryType, test.inputDNS.QueryName, test.inputURL)
+			_, dnsRCode, body, _, err := QueryDNS(test.inputDNS.QueryType, test.inputDNS.QueryName, test.inputURL, nil)
 			if test.isErrExpected && err == nil {
 				t.Errorf("there should be an error")
 			}
@@ -1,15 +1,21 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -19,6 +25,9 @@ import (
 
 const (
 	defaultTimeout = 10 * time.Second
+
+	// defaultMaxRedirects is the number of redirects followed if Config.MaxRedirects is not set
+	defaultMaxRedirects = 10
 )
 
 var (
@@ -27,6 +36,28 @@ var (
 	ErrInvalidClientOAuth2Config = errors.New("invalid oauth2 configuration: must define all fields for client credentials flow (token-url, client-id, client-secret, scopes)")
 	ErrInvalidClientIAPConfig    = errors.New("invalid Identity-Aware-Proxy configuration: must define all fields for Google Identity-Aware-Proxy programmatic authentication (audience)")
 	ErrInvalidClientTLSConfig    = errors.New("invalid TLS configuration: certificate-file and private-key-file must be specified")
+	ErrInvalidClientAWSConfig    = errors.New("invalid AWS configuration: region, access-key-id and secret-access-key must be specified or set through their respective environment variables")
+
+	// ErrInvalidClientTLSMinVersion is the error returned when the minimum TLS version isn't supported
+	ErrInvalidClientTLSMinVersion = errors.New("invalid TLS configuration: min-version must be one of 1.0, 1.1, 1.2 or 1.3")
+
+	// ErrInvalidClientTLSCipherSuite is the error returned when one of the allowed cipher suites isn't supported
+	ErrInvalidClientTLSCipherSuite = errors.New("invalid TLS configuration: unsupported cipher suite")
+
+	// ErrInvalidClientTLSPinnedPublicKey is the error returned when one of the pinned public keys isn't a
+	// base64-encoded SHA-256 hash prefixed by sha256/
+	ErrInvalidClientTLSPinnedPublicKey = errors.New("invalid TLS configuration: pinned public keys must have the format sha256/<base64-encoded SHA-256 hash of the certificate's public key>")
+
+	// ErrInvalidClientSourceAddress is the error returned when the source address isn't an IP address
+	ErrInvalidClientSourceAddress = errors.New("invalid source-address: must be an IP address")
+
+	// ErrClientSourceAddressWithInterface is the error returned when both a source address and an interface are
+	// specified, as only one of them can determine the address outgoing connections are bound to
+	ErrClientSourceAddressWithInterface = errors.New("source-address and interface are mutually exclusive")
+
+	// ErrCertificatePinningFailed is the error returned when none of the certificates presented by the server have
+	// one of the pinned public keys
+	ErrCertificatePinningFailed = errors.New("none of the certificates presented by the server match the pinned public keys")
 
 	defaultConfig = Config{
 		Insecure:       false,
@@ -53,6 +84,11 @@ type Config struct {
 	// IgnoreRedirect determines whether to ignore redirects (true) or follow them (false, default)
 	IgnoreRedirect bool `yaml:"ignore-redirect,omitempty"`
 
+	// MaxRedirects is the maximum number of redirects to follow, or 0 to use the default of 10.
+	//
+	// This is set through the max-redirects parameter of endpoints.
+	MaxRedirects int `yaml:"-"`
+
 	// Timeout for the client
 	Timeout time.Duration `yaml:"timeout"`
 
@@ -60,6 +96,16 @@ type Config struct {
 	// Expected format is {protocol}://{host}:{port}, e.g. tcp://8.8.8.8:53
 	DNSResolver string `yaml:"dns-resolver,omitempty"`
 
+	// SourceAddress is the IP address outgoing connections are bound to, which allows multi-homed hosts to verify
+	// the reachability of endpoints through a specific uplink.
+	SourceAddress string `yaml:"source-address,omitempty"`
+
+	// Interface is the name of the network interface whose address outgoing connections are bound to (e.g. wg0).
+	//
+	// The address is looked up for every connection, so interfaces whose address may change, such as VPN tunnels,
+	// are supported. The first IPv4 address of the interface is used, unless Network is ip6.
+	Interface string `yaml:"interface,omitempty"`
+
 	// OAuth2Config is the OAuth2 configuration used for the client.
 	//
 	// If non-nil, the http.Client returned by getHTTPClient will automatically retrieve a token if necessary.
@@ -69,8 +115,16 @@ type Config struct {
 	// IAPConfig is the Google Cloud Identity-Aware-Proxy configuration used for the client. (e.g. audience)
 	IAPConfig *IAPConfig `yaml:"identity-aware-proxy,omitempty"`
 
+	// AWSConfig is the AWS configuration used to sign every request sent by the client using AWS Signature Version 4.
+	//
+	// This is required by endpoints of type LAMBDA, as well as by AWS Lambda function URLs using AWS_IAM auth.
+	AWSConfig *AWSConfig `yaml:"aws,omitempty"`
+
 	httpClient *http.Client
 
+	// httpClientCABundleGeneration is the generation of the newest CA bundle trusted by httpClient when it was created
+	httpClientCABundleGeneration int64
+
 	// Network (ip, ip4 or ip6) for the ICMP client
 	Network string `yaml:"network"`
 
@@ -107,6 +161,42 @@ type TLSConfig struct {
 	PrivateKeyFile string `yaml:"private-key-file,omitempty"`
 
 	RenegotiationSupport string `yaml:"renegotiation,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted (1.0, 1.1, 1.2 or 1.3). Defaults to the minimum of crypto/tls.
+	MinVersion string `yaml:"min-version,omitempty"`
+
+	// CipherSuites is the list of cipher suites allowed for TLS 1.0 to 1.2, which uses the names defined by IANA
+	// (e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256). Defaults to the cipher suites of crypto/tls.
+	//
+	// Note that the cipher suites of TLS 1.3 are not configurable.
+	CipherSuites []string `yaml:"cipher-suites,omitempty"`
+
+	// PinnedPublicKeys is the list of public keys, one of which must be used by one of the certificates presented by
+	// the server. Each key has the format sha256/<base64-encoded SHA-256 hash of the SubjectPublicKeyInfo>.
+	//
+	// Pinning is enforced even if Config.Insecure is true, which allows trusting self-signed certificates by pinning
+	// their public key rather than not verifying them at all.
+	PinnedPublicKeys []string `yaml:"pinned-public-keys,omitempty"`
+
+	// CABundleFiles is the list of files containing PEM-encoded CA certificates to trust in addition to the system's
+	// certificate pool. The files are reloaded automatically whenever they are modified.
+	CABundleFiles []string `yaml:"ca-bundle-files,omitempty"`
+
+	caBundle *caBundle
+}
+
+// Copy returns a shallow copy of the configuration, which creates its own HTTP client rather than sharing the one of
+// the configuration copied. The TLS configuration is copied as well, so that validating the copy leaves the CA bundles
+// of the configuration copied untouched.
+func (c *Config) Copy() *Config {
+	configCopy := *c
+	configCopy.httpClient = nil
+	configCopy.httpClientCABundleGeneration = 0
+	if c.TLS != nil {
+		tlsConfigCopy := *c.TLS
+		configCopy.TLS = &tlsConfigCopy
+	}
+	return &configCopy
 }
 
 // ValidateAndSetDefaults validates the client configuration and sets the default values if necessary
@@ -120,17 +210,49 @@ func (c *Config) ValidateAndSetDefaults() error {
 			return err
 		}
 	}
+	if len(c.SourceAddress) > 0 {
+		if len(c.Interface) > 0 {
+			return ErrClientSourceAddressWithInterface
+		}
+		if net.ParseIP(c.SourceAddress) == nil {
+			return ErrInvalidClientSourceAddress
+		}
+	}
 	if c.HasOAuth2Config() && !c.OAuth2Config.isValid() {
 		return ErrInvalidClientOAuth2Config
 	}
 	if c.HasIAPConfig() && !c.IAPConfig.isValid() {
 		return ErrInvalidClientIAPConfig
 	}
+	if c.HasAWSConfig() {
+		c.AWSConfig.setDefaults()
+		if !c.AWSConfig.isValid() {
+			return ErrInvalidClientAWSConfig
+		}
+	}
 	if c.HasTlsConfig() {
 		if err := c.TLS.isValid(); err != nil {
 			return err
 		}
 	}
+	if c.TLS != nil {
+		if _, err := c.TLS.parseMinVersion(); err != nil {
+			return err
+		}
+		if _, err := c.TLS.parseCipherSuites(); err != nil {
+			return err
+		}
+		if _, err := c.TLS.parsePinnedPublicKeys(); err != nil {
+			return err
+		}
+		if len(c.TLS.CABundleFiles) > 0 {
+			bundle, err := newCABundle(c.TLS.CABundleFiles)
+			if err != nil {
+				return err
+			}
+			c.TLS.caBundle = bundle
+		}
+	}
 	return nil
 }
 
@@ -166,6 +288,69 @@ func (c *Config) parseDNSResolver() (*DNSResolverConfig, error) {
 	}, nil
 }
 
+// HasLocalAddress returns whether outgoing connections must be bound to a specific address
+func (c *Config) HasLocalAddress() bool {
+	return len(c.SourceAddress) > 0 || len(c.Interface) > 0
+}
+
+// getLocalIP returns the IP address outgoing connections must be bound to, or nil if they don't have to be bound
+func (c *Config) getLocalIP() (net.IP, error) {
+	if len(c.SourceAddress) > 0 {
+		return net.ParseIP(c.SourceAddress), nil
+	}
+	if len(c.Interface) == 0 {
+		return nil, nil
+	}
+	networkInterface, err := net.InterfaceByName(c.Interface)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up interface %s: %w", c.Interface, err)
+	}
+	addresses, err := networkInterface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving addresses of interface %s: %w", c.Interface, err)
+	}
+	var fallback net.IP
+	for _, address := range addresses {
+		ipNet, ok := address.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			// Link-local addresses can't be used without specifying a zone
+			continue
+		}
+		if (ipNet.IP.To4() == nil) == (c.Network == "ip6") {
+			return ipNet.IP, nil
+		}
+		if fallback == nil {
+			fallback = ipNet.IP
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("no address found for interface %s", c.Interface)
+	}
+	return fallback, nil
+}
+
+// getDialer returns a dialer for the network passed (e.g. tcp or udp) that binds connections to the local address
+// configured, if any
+func (c *Config) getDialer(network string) (*net.Dialer, error) {
+	return c.bindDialer(&net.Dialer{Timeout: c.Timeout}, network)
+}
+
+// bindDialer returns a copy of the dialer passed that binds connections for the network passed to the local address
+// configured, or the dialer itself if connections don't have to be bound
+func (c *Config) bindDialer(dialer *net.Dialer, network string) (*net.Dialer, error) {
+	localIP, err := c.getLocalIP()
+	if err != nil || localIP == nil {
+		return dialer, err
+	}
+	boundDialer := *dialer
+	if strings.HasPrefix(network, "udp") {
+		boundDialer.LocalAddr = &net.UDPAddr{IP: localIP}
+	} else {
+		boundDialer.LocalAddr = &net.TCPAddr{IP: localIP}
+	}
+	return &boundDialer, nil
+}
+
 // HasOAuth2Config returns true if the client has OAuth2 configuration parameters
 func (c *Config) HasOAuth2Config() bool {
 	return c.OAuth2Config != nil
@@ -176,6 +361,11 @@ func (c *Config) HasIAPConfig() bool {
 	return c.IAPConfig != nil
 }
 
+// HasAWSConfig returns true if the client has AWS configuration parameters
+func (c *Config) HasAWSConfig() bool {
+	return c.AWSConfig != nil
+}
+
 // HasTlsConfig returns true if the client has client certificate parameters
 func (c *Config) HasTlsConfig() bool {
 	return c.TLS != nil && len(c.TLS.CertificateFile) > 0 && len(c.TLS.PrivateKeyFile) > 0
@@ -203,15 +393,145 @@ func (t *TLSConfig) isValid() error {
 	return ErrInvalidClientTLSConfig
 }
 
-// GetHTTPClient return an HTTP client matching the Config's parameters.
-func (c *Config) getHTTPClient() *http.Client {
+// parseMinVersion returns the minimum TLS version, or 0 if none was specified
+func (t *TLSConfig) parseMinVersion() (uint16, error) {
+	switch t.MinVersion {
+	case "":
+		return 0, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, ErrInvalidClientTLSMinVersion
+}
+
+// parseCipherSuites returns the IDs of the allowed cipher suites, or nil if none were specified
+func (t *TLSConfig) parseCipherSuites() ([]uint16, error) {
+	if len(t.CipherSuites) == 0 {
+		return nil, nil
+	}
+	supportedCipherSuites := make(map[string]uint16)
+	for _, cipherSuite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		supportedCipherSuites[cipherSuite.Name] = cipherSuite.ID
+	}
+	cipherSuites := make([]uint16, 0, len(t.CipherSuites))
+	for _, name := range t.CipherSuites {
+		id, exists := supportedCipherSuites[name]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidClientTLSCipherSuite, name)
+		}
+		cipherSuites = append(cipherSuites, id)
+	}
+	return cipherSuites, nil
+}
+
+// parsePinnedPublicKeys returns the decoded SHA-256 hashes of the pinned public keys
+func (t *TLSConfig) parsePinnedPublicKeys() ([][]byte, error) {
+	hashes := make([][]byte, 0, len(t.PinnedPublicKeys))
+	for _, pinnedPublicKey := range t.PinnedPublicKeys {
+		encodedHash, hasPrefix := strings.CutPrefix(pinnedPublicKey, "sha256/")
+		if !hasPrefix {
+			return nil, ErrInvalidClientTLSPinnedPublicKey
+		}
+		hash, err := base64.StdEncoding.DecodeString(encodedHash)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, ErrInvalidClientTLSPinnedPublicKey
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// getTLSConfig returns the TLS configuration used to connect to servers, which takes into account whether the
+// certificate must be verified, the client certificate, as well as the TLS versions, cipher suites and public keys
+// allowed
+func (c *Config) getTLSConfig() *tls.Config {
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: c.Insecure,
+		RootCAs:            c.getRootCAs(),
+	}
+	if c.TLS == nil {
+		return tlsConfig
 	}
 	if c.HasTlsConfig() && c.TLS.isValid() == nil {
 		tlsConfig = configureTLS(tlsConfig, *c.TLS)
 	}
+	// The errors are ignored, because the configuration has been validated on startup by ValidateAndSetDefaults
+	tlsConfig.MinVersion, _ = c.TLS.parseMinVersion()
+	tlsConfig.CipherSuites, _ = c.TLS.parseCipherSuites()
+	if pinnedPublicKeyHashes, _ := c.TLS.parsePinnedPublicKeys(); len(pinnedPublicKeyHashes) > 0 {
+		// VerifyConnection is called even if InsecureSkipVerify is true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, certificate := range state.PeerCertificates {
+				publicKeyHash := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+				for _, pinnedPublicKeyHash := range pinnedPublicKeyHashes {
+					if bytes.Equal(publicKeyHash[:], pinnedPublicKeyHash) {
+						return nil
+					}
+				}
+			}
+			return ErrCertificatePinningFailed
+		}
+	}
+	return tlsConfig
+}
+
+// getCABundles returns the CA bundles trusted by the client, which includes the global CA bundle, if any
+func (c *Config) getCABundles() []*caBundle {
+	var bundles []*caBundle
+	if globalCABundle != nil {
+		bundles = append(bundles, globalCABundle)
+	}
+	if c.TLS != nil && c.TLS.caBundle != nil {
+		bundles = append(bundles, c.TLS.caBundle)
+	}
+	return bundles
+}
+
+// refreshCABundles reloads the CA bundles trusted by the client that have been modified and returns the generation
+// of the newest one, or 0 if the client doesn't trust any CA bundle
+func (c *Config) refreshCABundles() int64 {
+	var generation int64
+	for _, bundle := range c.getCABundles() {
+		generation = max(generation, bundle.refresh())
+	}
+	return generation
+}
+
+// getRootCAs returns the pool of CA certificates trusted by the client, or nil if the system's certificate pool
+// should be used
+func (c *Config) getRootCAs() *x509.CertPool {
+	bundles := c.getCABundles()
+	if len(bundles) == 0 {
+		return nil
+	}
+	c.refreshCABundles()
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, bundle := range bundles {
+		bundle.addTo(pool)
+	}
+	return pool
+}
+
+// GetHTTPClient return an HTTP client matching the Config's parameters.
+func (c *Config) getHTTPClient() *http.Client {
+	if c.httpClient != nil && c.refreshCABundles() != c.httpClientCABundleGeneration {
+		// One of the CA bundles has been reloaded, so the client must be recreated in order to trust the new
+		// certificates. Connections established with the previous client are closed once idle.
+		c.httpClient.CloseIdleConnections()
+		c.httpClient = nil
+	}
 	if c.httpClient == nil {
+		c.httpClientCABundleGeneration = c.refreshCABundles()
+		tlsConfig := c.getTLSConfig()
 		c.httpClient = &http.Client{
 			Timeout: c.Timeout,
 			Transport: &http.Transport{
@@ -225,6 +545,13 @@ func (c *Config) getHTTPClient() *http.Client {
 					// Don't follow redirects
 					return http.ErrUseLastResponse
 				}
+				maxRedirects := c.MaxRedirects
+				if maxRedirects == 0 {
+					maxRedirects = defaultMaxRedirects
+				}
+				if len(via) > maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
 				// Follow redirects
 				return nil
 			},
@@ -237,6 +564,7 @@ func (c *Config) getHTTPClient() *http.Client {
 				c.httpClient.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
 			}
 		}
+		dialer := &net.Dialer{}
 		if c.HasCustomDNSResolver() {
 			dnsResolver, err := c.parseDNSResolver()
 			if err != nil {
@@ -244,18 +572,22 @@ func (c *Config) getHTTPClient() *http.Client {
 				// It shouldn't happen, but if it does, we'll log it... Better safe than sorry ;)
 				log.Println("[client.getHTTPClient] THIS SHOULD NOT HAPPEN. Silently ignoring invalid DNS resolver due to error:", err.Error())
 			} else {
-				dialer := &net.Dialer{
-					Resolver: &net.Resolver{
-						PreferGo: true,
-						Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-							d := net.Dialer{}
-							return d.DialContext(ctx, dnsResolver.Protocol, dnsResolver.Host+":"+dnsResolver.Port)
-						},
+				dialer.Resolver = &net.Resolver{
+					PreferGo: true,
+					Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+						d := net.Dialer{}
+						return d.DialContext(ctx, dnsResolver.Protocol, dnsResolver.Host+":"+dnsResolver.Port)
 					},
 				}
-				c.httpClient.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-					return dialer.DialContext(ctx, network, addr)
+			}
+		}
+		if dialer.Resolver != nil || c.HasLocalAddress() {
+			c.httpClient.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				boundDialer, err := c.bindDialer(dialer, network)
+				if err != nil {
+					return nil, err
 				}
+				return boundDialer.DialContext(ctx, network, addr)
 			}
 		}
 		if c.HasOAuth2Config() && c.HasIAPConfig() {
@@ -264,6 +596,8 @@ func (c *Config) getHTTPClient() *http.Client {
 			c.httpClient = configureOAuth2(c.httpClient, *c.OAuth2Config)
 		} else if c.HasIAPConfig() {
 			c.httpClient = configureIAP(c.httpClient, *c.IAPConfig)
+		} else if c.HasAWSConfig() {
+			c.httpClient = configureAWS(c.httpClient, *c.AWSConfig)
 		}
 	}
 	return c.httpClient
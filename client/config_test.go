@@ -1,7 +1,13 @@
 package client
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -169,3 +175,205 @@ func TestConfig_TlsIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ValidateAndSetDefaults_withTLSVerificationOptions(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		tls         *TLSConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			tls: &TLSConfig{
+				MinVersion:       "1.2",
+				CipherSuites:     []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				PinnedPublicKeys: []string{"sha256/" + base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))},
+			},
+		},
+		{
+			name:        "invalid-min-version",
+			tls:         &TLSConfig{MinVersion: "1.4"},
+			expectedErr: ErrInvalidClientTLSMinVersion,
+		},
+		{
+			name:        "unsupported-cipher-suite",
+			tls:         &TLSConfig{CipherSuites: []string{"TLS_MADE_UP"}},
+			expectedErr: ErrInvalidClientTLSCipherSuite,
+		},
+		{
+			name:        "pinned-public-key-without-prefix",
+			tls:         &TLSConfig{PinnedPublicKeys: []string{base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))}},
+			expectedErr: ErrInvalidClientTLSPinnedPublicKey,
+		},
+		{
+			name:        "pinned-public-key-with-invalid-hash",
+			tls:         &TLSConfig{PinnedPublicKeys: []string{"sha256/not-a-hash"}},
+			expectedErr: ErrInvalidClientTLSPinnedPublicKey,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{TLS: scenario.tls}
+			if err := cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_getHTTPClient_withTLSVerificationOptions(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	publicKeyHash := sha256.Sum256(server.Certificate().RawSubjectPublicKeyInfo)
+	pinnedPublicKey := "sha256/" + base64.StdEncoding.EncodeToString(publicKeyHash[:])
+	otherPinnedPublicKey := "sha256/" + base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	scenarios := []struct {
+		name                           string
+		tls                            *TLSConfig
+		expectedError                  bool
+		expectedCertificatePinningFail bool
+	}{
+		{
+			name: "pinned-public-key",
+			tls:  &TLSConfig{PinnedPublicKeys: []string{otherPinnedPublicKey, pinnedPublicKey}},
+		},
+		{
+			name:                           "other-pinned-public-key",
+			tls:                            &TLSConfig{PinnedPublicKeys: []string{otherPinnedPublicKey}},
+			expectedError:                  true,
+			expectedCertificatePinningFail: true,
+		},
+		{
+			name:          "min-version-higher-than-server-max-version",
+			tls:           &TLSConfig{MinVersion: "1.3", PinnedPublicKeys: []string{pinnedPublicKey}},
+			expectedError: true,
+		},
+		{
+			name: "allowed-cipher-suite",
+			tls:  &TLSConfig{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			// The certificate of the test server is self-signed, so it can only be trusted through pinning
+			cfg := &Config{Insecure: true, TLS: scenario.tls}
+			if err := cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			response, err := cfg.getHTTPClient().Get(server.URL)
+			if err == nil {
+				_ = response.Body.Close()
+			}
+			if (err != nil) != scenario.expectedError {
+				t.Errorf("expected error=%v, got %v", scenario.expectedError, err)
+			}
+			if errors.Is(err, ErrCertificatePinningFailed) != scenario.expectedCertificatePinningFail {
+				t.Errorf("expected certificate pinning to fail=%v, got %v", scenario.expectedCertificatePinningFail, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaults_withLocalAddress(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name: "source-address",
+			cfg:  &Config{SourceAddress: "192.168.1.10"},
+		},
+		{
+			name: "source-address-ipv6",
+			cfg:  &Config{SourceAddress: "2001:db8::10"},
+		},
+		{
+			name: "interface",
+			cfg:  &Config{Interface: "wg0"},
+		},
+		{
+			name:        "invalid-source-address",
+			cfg:         &Config{SourceAddress: "uplink"},
+			expectedErr: ErrInvalidClientSourceAddress,
+		},
+		{
+			name:        "source-address-and-interface",
+			cfg:         &Config{SourceAddress: "192.168.1.10", Interface: "wg0"},
+			expectedErr: ErrClientSourceAddressWithInterface,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_getHTTPClient_withLocalAddress(t *testing.T) {
+	var remoteAddr string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+	}))
+	defer server.Close()
+	var loopbackInterface string
+	if interfaces, err := net.Interfaces(); err == nil {
+		for _, networkInterface := range interfaces {
+			if networkInterface.Flags&net.FlagLoopback != 0 {
+				loopbackInterface = networkInterface.Name
+				break
+			}
+		}
+	}
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedError bool
+	}{
+		{
+			name: "source-address",
+			cfg:  &Config{SourceAddress: "127.0.0.1"},
+		},
+		{
+			name: "interface",
+			cfg:  &Config{Interface: loopbackInterface, Network: "ip4"},
+		},
+		{
+			name:          "source-address-not-assigned-to-host",
+			cfg:           &Config{SourceAddress: "192.0.2.1"},
+			expectedError: true,
+		},
+		{
+			name:          "unknown-interface",
+			cfg:           &Config{Interface: "does-not-exist"},
+			expectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if len(scenario.cfg.Interface) == 0 && scenario.name == "interface" {
+				t.Skip("no loopback interface found")
+			}
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			remoteAddr = ""
+			response, err := scenario.cfg.getHTTPClient().Get(server.URL)
+			if err == nil {
+				_ = response.Body.Close()
+			}
+			if (err != nil) != scenario.expectedError {
+				t.Fatalf("expected error=%v, got %v", scenario.expectedError, err)
+			}
+			if !scenario.expectedError {
+				if host, _, _ := net.SplitHostPort(remoteAddr); host != "127.0.0.1" {
+					t.Errorf("expected request to be sent from 127.0.0.1, got %s", remoteAddr)
+				}
+			}
+		})
+	}
+}
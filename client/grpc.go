@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// CheckGRPCHealth checks the health of a gRPC server through the standard gRPC Health Checking Protocol
+// (grpc.health.v1.Health/Check) for the service passed. If the service is empty, the overall health of the server is
+// checked.
+//
+// The status returned is the serving status (e.g. SERVING, NOT_SERVING or SERVICE_UNKNOWN) reported by the server.
+// If useTLS is true, the state of the TLS connection is returned so that the certificate can be inspected.
+func CheckGRPCHealth(address, service string, useTLS bool, config *Config) (connected bool, servingStatus string, state *tls.ConnectionState, err error) {
+	dialer, err := config.getDialer("tcp")
+	if err != nil {
+		return
+	}
+	transportCredentials := insecure.NewCredentials()
+	if useTLS {
+		transportCredentials = credentials.NewTLS(config.getTLSConfig())
+	}
+	connection, err := grpc.NewClient(
+		"passthrough:///"+address,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", address)
+		}),
+	)
+	if err != nil {
+		return
+	}
+	defer connection.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	var serverPeer peer.Peer
+	response, err := healthpb.NewHealthClient(connection).Check(ctx, &healthpb.HealthCheckRequest{Service: service}, grpc.Peer(&serverPeer))
+	if tlsInfo, ok := serverPeer.AuthInfo.(credentials.TLSInfo); ok {
+		state = &tlsInfo.State
+	}
+	if err != nil {
+		// Any response from the server, even an error (e.g. NotFound for an unknown service), means that it's reachable
+		code := status.Code(err)
+		connected = code != codes.Unavailable && code != codes.DeadlineExceeded
+		if code == codes.NotFound {
+			// Servers implementing the protocol respond with NotFound to services they don't know about
+			return connected, healthpb.HealthCheckResponse_SERVICE_UNKNOWN.String(), state, nil
+		}
+		return connected, "", state, err
+	}
+	return true, response.GetStatus().String(), state, nil
+}
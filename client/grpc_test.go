@@ -0,0 +1,132 @@
+package client
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func startGRPCHealthServer(t *testing.T, useTLS bool) string {
+	var options []grpc.ServerOption
+	if useTLS {
+		certificate, err := tls.LoadX509KeyPair("../testdata/cert.pem", "../testdata/cert.key")
+		if err != nil {
+			t.Fatal("failed to load certificate:", err)
+		}
+		options = append(options, grpc.Creds(credentials.NewServerTLSFromCert(&certificate)))
+	}
+	server := grpc.NewServer(options...)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("serving.Service", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("not-serving.Service", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to listen:", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestCheckGRPCHealth(t *testing.T) {
+	address := startGRPCHealthServer(t, false)
+	tlsAddress := startGRPCHealthServer(t, true)
+	closedListener, _ := net.Listen("tcp", "127.0.0.1:0")
+	closedAddress := closedListener.Addr().String()
+	closedListener.Close()
+	scenarios := []struct {
+		name                  string
+		address               string
+		service               string
+		useTLS                bool
+		config                *Config
+		expectedConnected     bool
+		expectedServingStatus string
+		expectedErr           bool
+	}{
+		{
+			name:                  "server",
+			address:               address,
+			expectedConnected:     true,
+			expectedServingStatus: "SERVING",
+		},
+		{
+			name:                  "serving-service",
+			address:               address,
+			service:               "serving.Service",
+			expectedConnected:     true,
+			expectedServingStatus: "SERVING",
+		},
+		{
+			name:                  "not-serving-service",
+			address:               address,
+			service:               "not-serving.Service",
+			expectedConnected:     true,
+			expectedServingStatus: "NOT_SERVING",
+		},
+		{
+			name:                  "unknown-service",
+			address:               address,
+			service:               "unknown.Service",
+			expectedConnected:     true,
+			expectedServingStatus: "SERVICE_UNKNOWN",
+		},
+		{
+			name:                  "tls",
+			address:               tlsAddress,
+			service:               "serving.Service",
+			useTLS:                true,
+			config:                &Config{Timeout: 5 * time.Second, Insecure: true},
+			expectedConnected:     true,
+			expectedServingStatus: "SERVING",
+		},
+		{
+			name:              "tls-with-untrusted-certificate",
+			address:           tlsAddress,
+			useTLS:            true,
+			expectedConnected: false,
+			expectedErr:       true,
+		},
+		{
+			name:              "plaintext-against-tls",
+			address:           tlsAddress,
+			expectedConnected: false,
+			expectedErr:       true,
+		},
+		{
+			name:              "unreachable",
+			address:           closedAddress,
+			expectedConnected: false,
+			expectedErr:       true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := scenario.config
+			if config == nil {
+				config = &Config{Timeout: 5 * time.Second}
+			}
+			connected, servingStatus, state, err := CheckGRPCHealth(scenario.address, scenario.service, scenario.useTLS, config)
+			if connected != scenario.expectedConnected {
+				t.Errorf("expected connected to be %v, got %v", scenario.expectedConnected, connected)
+			}
+			if servingStatus != scenario.expectedServingStatus {
+				t.Errorf("expected serving status to be %q, got %q", scenario.expectedServingStatus, servingStatus)
+			}
+			if (err != nil) != scenario.expectedErr {
+				t.Errorf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.useTLS && err == nil && (state == nil || len(state.PeerCertificates) == 0) {
+				t.Error("expected the state of the TLS connection to be returned")
+			}
+		})
+	}
+}
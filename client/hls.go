@@ -0,0 +1,188 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	hlsTagHeader          = "#EXTM3U"
+	hlsTagStreamInf       = "#EXT-X-STREAM-INF"
+	hlsTagSegmentDuration = "#EXTINF:"
+	hlsTagProgramDateTime = "#EXT-X-PROGRAM-DATE-TIME:"
+)
+
+var (
+	// ErrHLSInvalidPlaylist is the error returned when the body of a playlist doesn't start with #EXTM3U
+	ErrHLSInvalidPlaylist = errors.New("invalid hls playlist: missing " + hlsTagHeader + " header")
+
+	// ErrHLSNoVariant is the error returned when a master playlist doesn't reference any variant stream
+	ErrHLSNoVariant = errors.New("hls master playlist does not reference any variant stream")
+
+	// ErrHLSSegmentUnavailable is the error returned when the most recent media segment of a playlist can't be retrieved
+	ErrHLSSegmentUnavailable = errors.New("hls segment unavailable")
+)
+
+// HLSStream is the state of an HLS stream as observed by CheckHLSStream
+type HLSStream struct {
+	// Status is the status code of the response to the request for the playlist
+	Status int
+
+	// Playlist is the body of the media playlist
+	Playlist []byte
+
+	// SegmentCount is the number of media segments listed in the media playlist
+	SegmentCount int
+
+	// LatestSegmentAt is when the most recent media segment ends, which is zero if it couldn't be determined
+	LatestSegmentAt time.Time
+
+	// TLS is the state of the TLS connection over which the playlist was retrieved, if any
+	TLS *tls.ConnectionState
+}
+
+// CheckHLSStream retrieves the playlist of an HLS stream with the request passed and looks for its most recent media
+// segment. If the playlist is a master playlist, the media playlist of its first variant stream is retrieved instead,
+// with the same headers.
+//
+// The time at which the most recent segment ends is computed from the #EXT-X-PROGRAM-DATE-TIME tags of the playlist
+// or, if it has none, from the Last-Modified header of its response.
+// If fetchSegment is true, the most recent segment is downloaded as well, and an error is returned if it can't be.
+func CheckHLSStream(request *http.Request, fetchSegment bool, config *Config) (*HLSStream, error) {
+	httpClient := GetHTTPClient(config)
+	response, playlist, err := fetchHLSResource(httpClient, request)
+	if err != nil {
+		return nil, err
+	}
+	stream := &HLSStream{Status: response.StatusCode, Playlist: playlist, TLS: response.TLS}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return stream, nil
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(playlist), []byte(hlsTagHeader)) {
+		return stream, ErrHLSInvalidPlaylist
+	}
+	playlistURL := request.URL
+	if variant, isMasterPlaylist := firstHLSVariant(playlist); isMasterPlaylist {
+		if len(variant) == 0 {
+			return stream, ErrHLSNoVariant
+		}
+		if playlistURL, err = playlistURL.Parse(variant); err != nil {
+			return stream, err
+		}
+		if response, playlist, err = fetchHLSResource(httpClient, newHLSRequest(request, playlistURL)); err != nil {
+			return stream, err
+		}
+		stream.Status, stream.Playlist = response.StatusCode, playlist
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return stream, nil
+		}
+		if !bytes.HasPrefix(bytes.TrimSpace(playlist), []byte(hlsTagHeader)) {
+			return stream, ErrHLSInvalidPlaylist
+		}
+	}
+	var latestSegment string
+	stream.SegmentCount, latestSegment, stream.LatestSegmentAt = parseHLSMediaPlaylist(playlist)
+	if stream.LatestSegmentAt.IsZero() && len(latestSegment) > 0 {
+		if lastModified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil {
+			stream.LatestSegmentAt = lastModified
+		}
+	}
+	if fetchSegment && len(latestSegment) > 0 {
+		segmentURL, err := playlistURL.Parse(latestSegment)
+		if err != nil {
+			return stream, err
+		}
+		segmentResponse, err := httpClient.Do(newHLSRequest(request, segmentURL))
+		if err != nil {
+			return stream, err
+		}
+		defer segmentResponse.Body.Close()
+		if segmentResponse.StatusCode < 200 || segmentResponse.StatusCode > 299 {
+			return stream, fmt.Errorf("%w: %s returned status code %d", ErrHLSSegmentUnavailable, segmentURL, segmentResponse.StatusCode)
+		}
+		if _, err = io.Copy(io.Discard, segmentResponse.Body); err != nil {
+			return stream, fmt.Errorf("error reading hls segment %s: %w", segmentURL, err)
+		}
+	}
+	return stream, nil
+}
+
+func fetchHLSResource(httpClient *http.Client, request *http.Request) (*http.Response, []byte, error) {
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return response, nil, fmt.Errorf("error reading hls playlist: %w", err)
+	}
+	return response, body, nil
+}
+
+// newHLSRequest creates a GET request for a resource referenced by the playlist retrieved with the request passed,
+// keeping its headers so that streams requiring authentication can be monitored
+func newHLSRequest(request *http.Request, resourceURL *url.URL) *http.Request {
+	resourceRequest, _ := http.NewRequestWithContext(request.Context(), http.MethodGet, resourceURL.String(), nil)
+	resourceRequest.Header = request.Header.Clone()
+	return resourceRequest
+}
+
+// firstHLSVariant returns the URI of the first variant stream of the playlist passed, as well as whether the playlist
+// is a master playlist
+func firstHLSVariant(playlist []byte) (string, bool) {
+	isMasterPlaylist := false
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, hlsTagStreamInf) {
+			isMasterPlaylist = true
+		} else if isMasterPlaylist && len(line) > 0 && !strings.HasPrefix(line, "#") {
+			return line, true
+		}
+	}
+	return "", isMasterPlaylist
+}
+
+// parseHLSMediaPlaylist returns the number of segments of a media playlist, the URI of the most recent one and, if the
+// playlist has #EXT-X-PROGRAM-DATE-TIME tags, when that segment ends
+func parseHLSMediaPlaylist(playlist []byte) (segmentCount int, latestSegment string, latestSegmentAt time.Time) {
+	var programDateTime time.Time
+	var segmentDuration, elapsedSinceProgramDateTime time.Duration
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case len(line) == 0:
+		case strings.HasPrefix(line, hlsTagSegmentDuration):
+			// e.g. #EXTINF:6.006,title
+			duration, _, _ := strings.Cut(strings.TrimPrefix(line, hlsTagSegmentDuration), ",")
+			seconds, _ := strconv.ParseFloat(duration, 64)
+			segmentDuration = time.Duration(seconds * float64(time.Second))
+		case strings.HasPrefix(line, hlsTagProgramDateTime):
+			// The tag applies to the segment that follows it
+			if parsed, err := time.Parse(time.RFC3339Nano, strings.TrimPrefix(line, hlsTagProgramDateTime)); err == nil {
+				programDateTime, elapsedSinceProgramDateTime = parsed, 0
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			segmentCount++
+			latestSegment = line
+			elapsedSinceProgramDateTime += segmentDuration
+			segmentDuration = 0
+		}
+	}
+	if !programDateTime.IsZero() && segmentCount > 0 {
+		latestSegmentAt = programDateTime.Add(elapsedSinceProgramDateTime)
+	}
+	return segmentCount, latestSegment, latestSegmentAt
+}
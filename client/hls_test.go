@@ -0,0 +1,107 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckHLSStream(t *testing.T) {
+	lastModified := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/master.m3u8":
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-STREAM-INF:BANDWIDTH=1280000\nlow/media.m3u8\n#EXT-X-STREAM-INF:BANDWIDTH=2560000\nhigh/media.m3u8\n"))
+		case "/low/media.m3u8":
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXT-X-PROGRAM-DATE-TIME:2024-06-01T12:00:00.000Z\n#EXTINF:6.0,\n10.ts\n#EXTINF:6.0,\n11.ts\n#EXTINF:4.5,\n12.ts\n"))
+		case "/last-modified.m3u8":
+			w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-TARGETDURATION:6\n#EXTINF:6.0,\nmissing.ts\n"))
+		case "/empty-master.m3u8":
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-STREAM-INF:BANDWIDTH=1280000\n"))
+		case "/index.html":
+			_, _ = w.Write([]byte("<html></html>"))
+		case "/low/12.ts":
+			_, _ = w.Write([]byte("segment"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                    string
+		path                    string
+		fetchSegment            bool
+		expectedStatus          int
+		expectedSegmentCount    int
+		expectedLatestSegmentAt time.Time
+		expectedErr             error
+	}{
+		{
+			name:                    "master-playlist",
+			path:                    "/master.m3u8",
+			fetchSegment:            true,
+			expectedStatus:          200,
+			expectedSegmentCount:    3,
+			expectedLatestSegmentAt: time.Date(2024, 6, 1, 12, 0, 16, 500000000, time.UTC),
+		},
+		{
+			name:                    "media-playlist-without-program-date-time",
+			path:                    "/last-modified.m3u8",
+			expectedStatus:          200,
+			expectedSegmentCount:    1,
+			expectedLatestSegmentAt: lastModified,
+		},
+		{
+			name:                    "missing-segment",
+			path:                    "/last-modified.m3u8",
+			fetchSegment:            true,
+			expectedStatus:          200,
+			expectedSegmentCount:    1,
+			expectedLatestSegmentAt: lastModified,
+			expectedErr:             ErrHLSSegmentUnavailable,
+		},
+		{
+			name:           "master-playlist-without-variant",
+			path:           "/empty-master.m3u8",
+			expectedStatus: 200,
+			expectedErr:    ErrHLSNoVariant,
+		},
+		{
+			name:           "not-a-playlist",
+			path:           "/index.html",
+			expectedStatus: 200,
+			expectedErr:    ErrHLSInvalidPlaylist,
+		},
+		{
+			name:           "not-found",
+			path:           "/unknown.m3u8",
+			expectedStatus: 404,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodGet, server.URL+scenario.path, nil)
+			request.Header.Set("Authorization", "Bearer token")
+			stream, err := CheckHLSStream(request, scenario.fetchSegment, &Config{Timeout: 5 * time.Second})
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error to be %v, got %v", scenario.expectedErr, err)
+			}
+			if stream.Status != scenario.expectedStatus {
+				t.Errorf("expected status to be %d, got %d", scenario.expectedStatus, stream.Status)
+			}
+			if stream.SegmentCount != scenario.expectedSegmentCount {
+				t.Errorf("expected segment count to be %d, got %d", scenario.expectedSegmentCount, stream.SegmentCount)
+			}
+			if !stream.LatestSegmentAt.Equal(scenario.expectedLatestSegmentAt) {
+				t.Errorf("expected latest segment to end at %s, got %s", scenario.expectedLatestSegmentAt, stream.LatestSegmentAt)
+			}
+		})
+	}
+}
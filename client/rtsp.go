@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	rtspDefaultPort  = "554"
+	rtspsDefaultPort = "322"
+
+	// rtspMaximumBodySize is the maximum size of the session description read from the response to DESCRIBE
+	rtspMaximumBodySize = 1 << 20
+)
+
+var (
+	// ErrRTSPInvalidResponse is the error returned when the response of an RTSP server cannot be parsed
+	ErrRTSPInvalidResponse = errors.New("invalid rtsp response")
+)
+
+// DescribeRTSPStream sends an RTSP DESCRIBE request for the stream passed (e.g. rtsp://camera:554/live) and returns the
+// status code of the response as well as its body, which is the session description (SDP) of the stream.
+// If the URL has credentials, they're used to authenticate with either the Basic or the Digest scheme, depending on
+// what the server requires. The scheme rtsps requires TLS.
+func DescribeRTSPStream(streamURL string, config *Config) (connected bool, status int, body []byte, state *tls.ConnectionState, err error) {
+	u, err := url.Parse(streamURL)
+	if err != nil {
+		return false, 0, nil, nil, err
+	}
+	useTLS := u.Scheme == "rtsps"
+	port := u.Port()
+	if len(port) == 0 {
+		port = rtspDefaultPort
+		if useTLS {
+			port = rtspsDefaultPort
+		}
+	}
+	dialer, err := config.getDialer("tcp")
+	if err != nil {
+		return false, 0, nil, nil, err
+	}
+	connection, err := dialer.Dial("tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return false, 0, nil, nil, err
+	}
+	defer connection.Close()
+	_ = connection.SetDeadline(time.Now().Add(config.Timeout))
+	if useTLS {
+		tlsConfig := config.getTLSConfig()
+		tlsConfig.ServerName = u.Hostname()
+		tlsConnection := tls.Client(connection, tlsConfig)
+		if err = tlsConnection.Handshake(); err != nil {
+			return false, 0, nil, nil, err
+		}
+		connectionState := tlsConnection.ConnectionState()
+		state, connection = &connectionState, tlsConnection
+	}
+	var username, password string
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	// The credentials must not be part of the URL sent to the server
+	u.User = nil
+	requestURL := u.String()
+	reader := textproto.NewReader(bufio.NewReader(connection))
+	status, header, body, err := sendRTSPRequest(connection, reader, "DESCRIBE", requestURL, 1, "")
+	if err != nil {
+		return false, 0, nil, state, err
+	}
+	if status == 401 && len(username) > 0 {
+		authorization, err := rtspAuthorization(header.Values("WWW-Authenticate"), "DESCRIBE", requestURL, username, password)
+		if err != nil {
+			return true, status, body, state, err
+		}
+		if status, _, body, err = sendRTSPRequest(connection, reader, "DESCRIBE", requestURL, 2, authorization); err != nil {
+			return true, 0, nil, state, err
+		}
+	}
+	return true, status, body, state, nil
+}
+
+// sendRTSPRequest sends an RTSP request and reads its response
+func sendRTSPRequest(connection net.Conn, reader *textproto.Reader, method, requestURL string, sequence int, authorization string) (int, textproto.MIMEHeader, []byte, error) {
+	request := fmt.Sprintf("%s %s RTSP/1.0\r\nCSeq: %d\r\nAccept: application/sdp\r\nUser-Agent: Gatus/1.0\r\n", method, requestURL, sequence)
+	if len(authorization) > 0 {
+		request += "Authorization: " + authorization + "\r\n"
+	}
+	if _, err := connection.Write([]byte(request + "\r\n")); err != nil {
+		return 0, nil, nil, err
+	}
+	statusLine, err := reader.ReadLine()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	// e.g. RTSP/1.0 200 OK
+	protocol, rest, _ := strings.Cut(statusLine, " ")
+	code, _, _ := strings.Cut(rest, " ")
+	status, err := strconv.Atoi(code)
+	if !strings.HasPrefix(protocol, "RTSP/") || err != nil {
+		return 0, nil, nil, fmt.Errorf("%w: unexpected status line %q", ErrRTSPInvalidResponse, statusLine)
+	}
+	header, err := reader.ReadMIMEHeader()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	var body []byte
+	if contentLength := header.Get("Content-Length"); len(contentLength) > 0 {
+		length, err := strconv.Atoi(contentLength)
+		if err != nil || length < 0 || length > rtspMaximumBodySize {
+			return status, header, nil, fmt.Errorf("%w: invalid Content-Length %q", ErrRTSPInvalidResponse, contentLength)
+		}
+		body = make([]byte, length)
+		if _, err = io.ReadFull(reader.R, body); err != nil {
+			return status, header, nil, err
+		}
+	}
+	return status, header, body, nil
+}
+
+// rtspAuthorization returns the value of the Authorization header answering one of the challenges passed, preferring
+// the Digest scheme over the Basic one
+func rtspAuthorization(challenges []string, method, requestURL, username, password string) (string, error) {
+	for _, challenge := range challenges {
+		scheme, parameters, _ := strings.Cut(challenge, " ")
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+		values := parseAuthenticationParameters(parameters)
+		if algorithm := values["algorithm"]; len(algorithm) > 0 && !strings.EqualFold(algorithm, "MD5") {
+			continue
+		}
+		ha1 := md5Hex(username + ":" + values["realm"] + ":" + password)
+		ha2 := md5Hex(method + ":" + requestURL)
+		authorization := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s"`, username, values["realm"], values["nonce"], requestURL)
+		if qop := values["qop"]; len(qop) > 0 {
+			if !strings.Contains(qop, "auth") {
+				continue
+			}
+			nonce := make([]byte, 8)
+			_, _ = rand.Read(nonce)
+			clientNonce := hex.EncodeToString(nonce)
+			response := md5Hex(ha1 + ":" + values["nonce"] + ":00000001:" + clientNonce + ":auth:" + ha2)
+			authorization += fmt.Sprintf(`, qop=auth, nc=00000001, cnonce="%s", response="%s"`, clientNonce, response)
+		} else {
+			authorization += fmt.Sprintf(`, response="%s"`, md5Hex(ha1+":"+values["nonce"]+":"+ha2))
+		}
+		if opaque, ok := values["opaque"]; ok {
+			authorization += fmt.Sprintf(`, opaque="%s"`, opaque)
+		}
+		return authorization, nil
+	}
+	for _, challenge := range challenges {
+		if scheme, _, _ := strings.Cut(challenge, " "); strings.EqualFold(scheme, "Basic") {
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+		}
+	}
+	return "", fmt.Errorf("%w: unsupported authentication challenge %q", ErrRTSPInvalidResponse, strings.Join(challenges, ", "))
+}
+
+// parseAuthenticationParameters parses the parameters of an authentication challenge (e.g. realm="camera", nonce="abc")
+func parseAuthenticationParameters(parameters string) map[string]string {
+	values := make(map[string]string)
+	for len(parameters) > 0 {
+		name, rest, found := strings.Cut(strings.TrimLeft(parameters, " ,"), "=")
+		if !found {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		parameters = rest
+	}
+	return values
+}
+
+func md5Hex(value string) string {
+	hash := md5.Sum([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
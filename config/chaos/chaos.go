@@ -0,0 +1,111 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+)
+
+var (
+	// ErrExperimentWithNoName is the error with which Gatus will panic if a chaos experiment has no name
+	ErrExperimentWithNoName = errors.New("chaos experiment must have a name")
+
+	// ErrExperimentWithNoEndpoint is the error with which Gatus will panic if a chaos experiment targets no endpoint
+	ErrExperimentWithNoEndpoint = errors.New("chaos experiment must target at least one endpoint")
+
+	// ErrExperimentWithNoSchedule is the error with which Gatus will panic if a chaos experiment has no schedule
+	ErrExperimentWithNoSchedule = errors.New("chaos experiment must have a schedule")
+
+	// ErrDuplicateExperimentName is the error with which Gatus will panic if two chaos experiments have the same name
+	ErrDuplicateExperimentName = errors.New("chaos experiment names must be unique")
+)
+
+// Config is the configuration of chaos experiments, which inject synthetic failures in the results of endpoints
+// on a schedule in order to rehearse alerting escalation and on-call runbooks.
+type Config struct {
+	// Enabled is whether chaos experiments are running
+	Enabled bool `yaml:"enabled"`
+
+	// Experiments is the list of chaos experiments
+	Experiments []*Experiment `yaml:"experiments"`
+}
+
+// Experiment is a chaos experiment, during which the results of the endpoints it targets are failures
+type Experiment struct {
+	// Name of the experiment
+	Name string `yaml:"name"`
+
+	// Endpoints is the list of keys of the endpoints targeted by the experiment (e.g. core_frontend)
+	Endpoints []string `yaml:"endpoints"`
+
+	// Error is the error added to the results of the endpoints targeted while the experiment is running
+	Error string `yaml:"error,omitempty"`
+
+	// Schedule is the window during which the experiment is running.
+	// Once the window ends, the results of the endpoints targeted are no longer altered, so they recover.
+	Schedule *maintenance.Config `yaml:"schedule"`
+}
+
+// ValidateAndSetDefaults validates the chaos configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	names := make(map[string]bool, len(c.Experiments))
+	for _, experiment := range c.Experiments {
+		if err := experiment.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if names[experiment.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateExperimentName, experiment.Name)
+		}
+		names[experiment.Name] = true
+	}
+	return nil
+}
+
+// ValidateAndSetDefaults validates the chaos experiment and sets the default values if necessary
+func (e *Experiment) ValidateAndSetDefaults() error {
+	if len(e.Name) == 0 {
+		return ErrExperimentWithNoName
+	}
+	if len(e.Endpoints) == 0 {
+		return ErrExperimentWithNoEndpoint
+	}
+	if e.Schedule == nil {
+		return ErrExperimentWithNoSchedule
+	}
+	if err := e.Schedule.ValidateAndSetDefaults(); err != nil {
+		return fmt.Errorf("invalid schedule for chaos experiment %s: %w", e.Name, err)
+	}
+	if len(e.Error) == 0 {
+		e.Error = "synthetic failure injected by chaos experiment " + e.Name
+	}
+	return nil
+}
+
+// IsRunning returns whether the experiment is within its scheduled window
+func (e *Experiment) IsRunning() bool {
+	return e.Schedule.IsUnderMaintenance()
+}
+
+// Apply turns the result passed into a failure if the endpoint is targeted by a running experiment, and returns
+// whether it did
+func (c *Config) Apply(ep *endpoint.Endpoint, result *endpoint.Result) bool {
+	if c == nil || !c.Enabled {
+		return false
+	}
+	key := ep.Key()
+	for _, experiment := range c.Experiments {
+		if !slices.Contains(experiment.Endpoints, key) || !experiment.IsRunning() {
+			continue
+		}
+		log.Printf("[chaos.Apply] Injecting synthetic failure in result of endpoint with key=%s due to experiment=%s", key, experiment.Name)
+		result.Success = false
+		result.AddError(experiment.Error)
+		result.ClassifyFailure()
+		return true
+	}
+	return false
+}
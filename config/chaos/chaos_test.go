@@ -0,0 +1,132 @@
+package chaos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedError error
+	}{
+		{
+			name: "valid",
+			cfg: &Config{Enabled: true, Experiments: []*Experiment{
+				{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Schedule: &maintenance.Config{Start: "14:00", Duration: time.Hour}},
+			}},
+		},
+		{
+			name: "no-name",
+			cfg: &Config{Enabled: true, Experiments: []*Experiment{
+				{Endpoints: []string{"core_frontend"}, Schedule: &maintenance.Config{Start: "14:00", Duration: time.Hour}},
+			}},
+			expectedError: ErrExperimentWithNoName,
+		},
+		{
+			name: "no-endpoint",
+			cfg: &Config{Enabled: true, Experiments: []*Experiment{
+				{Name: "rehearsal", Schedule: &maintenance.Config{Start: "14:00", Duration: time.Hour}},
+			}},
+			expectedError: ErrExperimentWithNoEndpoint,
+		},
+		{
+			name: "no-schedule",
+			cfg: &Config{Enabled: true, Experiments: []*Experiment{
+				{Name: "rehearsal", Endpoints: []string{"core_frontend"}},
+			}},
+			expectedError: ErrExperimentWithNoSchedule,
+		},
+		{
+			name: "duplicate-name",
+			cfg: &Config{Enabled: true, Experiments: []*Experiment{
+				{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Schedule: &maintenance.Config{Start: "14:00", Duration: time.Hour}},
+				{Name: "rehearsal", Endpoints: []string{"core_backend"}, Schedule: &maintenance.Config{Start: "16:00", Duration: time.Hour}},
+			}},
+			expectedError: ErrDuplicateExperimentName,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestExperiment_ValidateAndSetDefaultsWithInvalidSchedule(t *testing.T) {
+	experiment := &Experiment{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Schedule: &maintenance.Config{Start: "25:00", Duration: time.Hour}}
+	if err := experiment.ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error, because the start of the schedule is invalid")
+	}
+}
+
+func TestConfig_Apply(t *testing.T) {
+	running := &maintenance.Config{Start: time.Now().UTC().Add(-time.Minute).Format("15:04"), Duration: time.Hour}
+	notRunning := &maintenance.Config{Start: time.Now().UTC().Add(2 * time.Hour).Format("15:04"), Duration: time.Hour}
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	scenarios := []struct {
+		name            string
+		cfg             *Config
+		expectedApplied bool
+		expectedError   string
+	}{
+		{
+			name:            "nil",
+			cfg:             nil,
+			expectedApplied: false,
+		},
+		{
+			name:            "disabled",
+			cfg:             &Config{Enabled: false, Experiments: []*Experiment{{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Schedule: running}}},
+			expectedApplied: false,
+		},
+		{
+			name:            "running",
+			cfg:             &Config{Enabled: true, Experiments: []*Experiment{{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Schedule: running}}},
+			expectedApplied: true,
+			expectedError:   "synthetic failure injected by chaos experiment rehearsal",
+		},
+		{
+			name:            "running-with-custom-error",
+			cfg:             &Config{Enabled: true, Experiments: []*Experiment{{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Error: "database unreachable", Schedule: running}}},
+			expectedApplied: true,
+			expectedError:   "database unreachable",
+		},
+		{
+			name:            "not-running",
+			cfg:             &Config{Enabled: true, Experiments: []*Experiment{{Name: "rehearsal", Endpoints: []string{"core_frontend"}, Schedule: notRunning}}},
+			expectedApplied: false,
+		},
+		{
+			name:            "endpoint-not-targeted",
+			cfg:             &Config{Enabled: true, Experiments: []*Experiment{{Name: "rehearsal", Endpoints: []string{"core_backend"}, Schedule: running}}},
+			expectedApplied: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.cfg != nil {
+				if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+			}
+			result := &endpoint.Result{Success: true}
+			if applied := scenario.cfg.Apply(ep, result); applied != scenario.expectedApplied {
+				t.Errorf("expected applied to be %v, got %v", scenario.expectedApplied, applied)
+			}
+			if result.Success == scenario.expectedApplied {
+				t.Errorf("expected success to be %v, got %v", !scenario.expectedApplied, result.Success)
+			}
+			if scenario.expectedApplied && (len(result.Errors) != 1 || result.Errors[0] != scenario.expectedError) {
+				t.Errorf("expected errors to be [%s], got %v", scenario.expectedError, result.Errors)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -8,18 +10,30 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/deepmerge"
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/chaos"
 	"github.com/TwiN/gatus/v5/config/connectivity"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/kv"
 	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/config/remote"
+	"github.com/TwiN/gatus/v5/config/runner"
+	"github.com/TwiN/gatus/v5/config/snapshot"
+	"github.com/TwiN/gatus/v5/config/tenant"
 	"github.com/TwiN/gatus/v5/config/ui"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/events"
+	"github.com/TwiN/gatus/v5/loganalytics"
+	"github.com/TwiN/gatus/v5/metricspush"
+	"github.com/TwiN/gatus/v5/report"
+	"github.com/TwiN/gatus/v5/resultlog"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage"
 	"gopkg.in/yaml.v3"
@@ -45,6 +59,30 @@ var (
 	// ErrInvalidSecurityConfig is an error returned when the security configuration is invalid
 	ErrInvalidSecurityConfig = errors.New("invalid security configuration")
 
+	// ErrChaosWithoutDebug is an error returned when chaos experiments are enabled, but debug isn't
+	ErrChaosWithoutDebug = errors.New("chaos experiments can only be enabled if debug is set to true")
+
+	// ErrUnknownEndpointInChaosExperiment is an error returned when a chaos experiment targets an endpoint that doesn't exist
+	ErrUnknownEndpointInChaosExperiment = errors.New("chaos experiment targets an unknown endpoint")
+
+	// ErrUnknownEndpointInCondition is an error returned when a condition references the state of an endpoint that doesn't exist
+	ErrUnknownEndpointInCondition = errors.New("condition references an unknown endpoint")
+
+	// ErrDuplicateTenant is an error returned when more than one tenant has the same name
+	ErrDuplicateTenant = errors.New("tenant names must be unique")
+
+	// ErrDuplicateRunner is an error returned when more than one runner has the same name
+	ErrDuplicateRunner = errors.New("runner names must be unique")
+
+	// ErrUnknownRunner is an error returned when an endpoint references a runner that doesn't exist
+	ErrUnknownRunner = errors.New("endpoint references an unknown runner")
+
+	// ErrInvalidGracePeriod is an error returned when the grace period is negative
+	ErrInvalidGracePeriod = errors.New("grace-period must not be negative")
+
+	// ErrDuplicateReport is an error returned when more than one report has the same name
+	ErrDuplicateReport = errors.New("report names must be unique")
+
 	// errEarlyReturn is returned to break out of a loop from a callback early
 	errEarlyReturn = errors.New("early escape")
 )
@@ -66,6 +104,16 @@ type Config struct {
 	// Disabling this may lead to inaccurate response times
 	DisableMonitoringLock bool `yaml:"disable-monitoring-lock,omitempty"`
 
+	// GracePeriod is the duration after Gatus starts or its configuration is reloaded during which results are still
+	// recorded, but no alerts are triggered. This prevents false alerts when the monitored services and Gatus restart
+	// together, e.g. during a deployment.
+	GracePeriod time.Duration `yaml:"grace-period,omitempty"`
+
+	// CABundleFiles is the list of files containing PEM-encoded CA certificates trusted by the client of every
+	// endpoint, in addition to the system's certificate pool. The files are reloaded automatically whenever they are
+	// modified.
+	CABundleFiles []string `yaml:"ca-bundle-files,omitempty"`
+
 	// Security is the configuration for securing access to Gatus
 	Security *security.Config `yaml:"security,omitempty"`
 
@@ -78,6 +126,13 @@ type Config struct {
 	// ExternalEndpoints is the list of all external endpoints
 	ExternalEndpoints []*endpoint.ExternalEndpoint `yaml:"external-endpoints,omitempty"`
 
+	// ExternalEndpointsAutoCreate is the list of rules under which external endpoints that aren't configured are
+	// created the first time a result is pushed to them
+	ExternalEndpointsAutoCreate []*endpoint.ExternalEndpointAutoCreateRule `yaml:"external-endpoints-auto-create,omitempty"`
+
+	// Tenants is the list of tenants, each of which has its own endpoints, API token and status page
+	Tenants []*tenant.Config `yaml:"tenants,omitempty"`
+
 	// Storage is the configuration for how the data is stored
 	Storage *storage.Config `yaml:"storage,omitempty"`
 
@@ -97,8 +152,46 @@ type Config struct {
 	// Connectivity is the configuration for connectivity
 	Connectivity *connectivity.Config `yaml:"connectivity,omitempty"`
 
+	// Chaos is the configuration for chaos experiments, which inject synthetic failures in the results of endpoints.
+	// Only allowed if Debug is true.
+	Chaos *chaos.Config `yaml:"chaos,omitempty"`
+
+	// LogAnalytics is the configuration for sending results and alert events to Azure Log Analytics
+	LogAnalytics *loganalytics.Config `yaml:"log-analytics,omitempty"`
+
+	// MetricsPush is the configuration for pushing the metrics of results to a Prometheus remote write endpoint or to
+	// an OpenTelemetry collector
+	MetricsPush *metricspush.Config `yaml:"metrics-push,omitempty"`
+
+	// ResultLog is the configuration for writing every result as a single JSON line to stdout
+	ResultLog *resultlog.Config `yaml:"result-log,omitempty"`
+
+	// Events is the configuration for emitting structured events on status transitions to webhooks, NATS and/or Kafka
+	Events *events.Config `yaml:"events,omitempty"`
+
+	// Reports is the list of availability reports published to Confluence and/or Notion
+	Reports []*report.Config `yaml:"reports,omitempty"`
+
+	// Runners is the list of remote hosts on which the checks of endpoints can be executed through SSH
+	Runners []*runner.Config `yaml:"runners,omitempty"`
+
 	configPath      string    // path to the file or directory from which config was loaded
 	lastFileModTime time.Time // last modification time
+
+	kvWatcher *kv.Watcher // watcher of the KV backend from which config was loaded, if any
+	kvVersion string      // version of the configuration loaded from the KV backend
+
+	rawBytes   []byte // raw configuration from which config was parsed, before environment variables are expanded
+	rollbackOf int64  // version of the snapshot that config was rolled back to, if any
+
+	// rollbackMutex guards pendingRollback and reloadRequested, which are set by RollBackTo
+	rollbackMutex   sync.Mutex
+	pendingRollback *Config
+	reloadRequested chan struct{}
+
+	// externalEndpointsMutex guards ExternalEndpoints, to which external endpoints may be added at runtime by
+	// GetOrCreateExternalEndpointByKey
+	externalEndpointsMutex sync.RWMutex
 }
 
 func (config *Config) GetEndpointByKey(key string) *endpoint.Endpoint {
@@ -111,7 +204,56 @@ func (config *Config) GetEndpointByKey(key string) *endpoint.Endpoint {
 	return nil
 }
 
+// GetEndpointKeys returns the keys of every endpoint and external endpoint
+func (config *Config) GetEndpointKeys() []string {
+	keys := make([]string, 0, len(config.Endpoints)+len(config.ExternalEndpoints))
+	for _, ep := range config.Endpoints {
+		keys = append(keys, ep.Key())
+	}
+	for _, ee := range config.GetExternalEndpoints() {
+		keys = append(keys, ee.Key())
+	}
+	return keys
+}
+
+// GetExternalEndpoints returns every external endpoint, including the ones created by an auto-create rule
+func (config *Config) GetExternalEndpoints() []*endpoint.ExternalEndpoint {
+	config.externalEndpointsMutex.RLock()
+	defer config.externalEndpointsMutex.RUnlock()
+	return config.ExternalEndpoints
+}
+
+// GetTenantByName returns the tenant with the given name, or nil if there's no such tenant
+func (config *Config) GetTenantByName(name string) *tenant.Config {
+	for _, t := range config.Tenants {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// GetExternalEndpointByPingUUID returns the external endpoint with the given ping UUID, or nil if there's no such
+// external endpoint
+func (config *Config) GetExternalEndpointByPingUUID(uuid string) *endpoint.ExternalEndpoint {
+	if len(uuid) == 0 {
+		return nil
+	}
+	for _, ee := range config.GetExternalEndpoints() {
+		if ee.PingUUID == uuid {
+			return ee
+		}
+	}
+	return nil
+}
+
 func (config *Config) GetExternalEndpointByKey(key string) *endpoint.ExternalEndpoint {
+	config.externalEndpointsMutex.RLock()
+	defer config.externalEndpointsMutex.RUnlock()
+	return config.getExternalEndpointByKey(key)
+}
+
+func (config *Config) getExternalEndpointByKey(key string) *endpoint.ExternalEndpoint {
 	for i := 0; i < len(config.ExternalEndpoints); i++ {
 		ee := config.ExternalEndpoints[i]
 		if ee.Key() == key {
@@ -121,9 +263,78 @@ func (config *Config) GetExternalEndpointByKey(key string) *endpoint.ExternalEnd
 	return nil
 }
 
+// GetOrCreateExternalEndpointByKey returns the external endpoint with the given key. If there's no such external
+// endpoint, it is created from the first auto-create rule that it matches and whose token is the token passed, or nil
+// is returned if there's no such rule.
+//
+// Note that the token passed is not validated against the external endpoint if it already exists.
+func (config *Config) GetOrCreateExternalEndpointByKey(key, token string) *endpoint.ExternalEndpoint {
+	if ee := config.GetExternalEndpointByKey(key); ee != nil || len(config.ExternalEndpointsAutoCreate) == 0 {
+		return ee
+	}
+	return config.createExternalEndpointFromAutoCreateRules(key, func(rule *endpoint.ExternalEndpointAutoCreateRule) bool {
+		return subtle.ConstantTimeCompare([]byte(rule.Token), []byte(token)) == 1
+	})
+}
+
+// RestoreAutoCreatedExternalEndpoints creates the external endpoints with the given keys that aren't configured but
+// match an auto-create rule, so that the external endpoints created before a restart or a reload of the configuration
+// aren't lost, and returns the number of external endpoints created
+func (config *Config) RestoreAutoCreatedExternalEndpoints(keys []string) int {
+	if len(config.ExternalEndpointsAutoCreate) == 0 {
+		return 0
+	}
+	numberOfExternalEndpointsCreated := 0
+	for _, key := range keys {
+		if config.GetEndpointByKey(key) != nil || config.GetExternalEndpointByKey(key) != nil {
+			continue
+		}
+		if config.createExternalEndpointFromAutoCreateRules(key, func(*endpoint.ExternalEndpointAutoCreateRule) bool { return true }) != nil {
+			numberOfExternalEndpointsCreated++
+		}
+	}
+	return numberOfExternalEndpointsCreated
+}
+
+// createExternalEndpointFromAutoCreateRules creates the external endpoint with the given key from the first
+// auto-create rule it matches among the rules for which isEligible returns true
+func (config *Config) createExternalEndpointFromAutoCreateRules(key string, isEligible func(*endpoint.ExternalEndpointAutoCreateRule) bool) *endpoint.ExternalEndpoint {
+	config.externalEndpointsMutex.Lock()
+	defer config.externalEndpointsMutex.Unlock()
+	// The external endpoint may have been created while the lock was being acquired
+	if ee := config.getExternalEndpointByKey(key); ee != nil {
+		return ee
+	}
+	// The key must not be taken by an endpoint either, since the results of both would be mixed up in the storage
+	if config.GetEndpointByKey(key) != nil {
+		return nil
+	}
+	for _, rule := range config.ExternalEndpointsAutoCreate {
+		if !isEligible(rule) {
+			continue
+		}
+		ee := rule.NewExternalEndpoint(key)
+		if ee == nil {
+			continue
+		}
+		if config.Web != nil {
+			ee.PageURL = config.Web.EndpointPageURL(ee.Key())
+		}
+		// The existing slice is left untouched, since it may be in use by callers of GetExternalEndpoints
+		externalEndpoints := make([]*endpoint.ExternalEndpoint, 0, len(config.ExternalEndpoints)+1)
+		config.ExternalEndpoints = append(append(externalEndpoints, config.ExternalEndpoints...), ee)
+		log.Printf("[config.createExternalEndpointFromAutoCreateRules] Created external endpoint with key=%s from the auto-create rule of group=%s", key, rule.Group)
+		return ee
+	}
+	return nil
+}
+
 // HasLoadedConfigurationBeenModified returns whether one of the file that the
 // configuration has been loaded from has been modified since it was last read
 func (config *Config) HasLoadedConfigurationBeenModified() bool {
+	if config.kvWatcher != nil {
+		return config.kvWatcher.LatestVersion() != config.kvVersion
+	}
 	lastMod := config.lastFileModTime.Unix()
 	fileInfo, err := os.Stat(config.configPath)
 	if err != nil {
@@ -142,8 +353,89 @@ func (config *Config) HasLoadedConfigurationBeenModified() bool {
 }
 
 // UpdateLastFileModTime refreshes Config.lastFileModTime
+//
+// If the configuration was loaded from a KV backend, the latest version observed in the backend is considered
+// as loaded instead.
 func (config *Config) UpdateLastFileModTime() {
 	config.lastFileModTime = time.Now()
+	if config.kvWatcher != nil {
+		config.kvVersion = config.kvWatcher.LatestVersion()
+	}
+}
+
+// Close releases the resources held by the configuration, such as the watcher of the KV backend the
+// configuration was loaded from
+func (config *Config) Close() {
+	if config.kvWatcher != nil {
+		config.kvWatcher.Stop()
+	}
+}
+
+// Snapshot returns a snapshot of the raw configuration from which config was parsed, which can be persisted so that
+// the configuration can later be rolled back to with RollBackTo
+func (config *Config) Snapshot() *snapshot.Snapshot {
+	source := snapshot.SourceFile
+	if config.rollbackOf > 0 {
+		source = snapshot.SourceRollback
+	} else if config.kvWatcher != nil {
+		source = snapshot.SourceKV
+	}
+	return snapshot.New(config.rawBytes, source, config.rollbackOf)
+}
+
+// RollBackTo parses and validates the configuration of a snapshot, and schedules it to replace config, which is
+// retrieved through PendingRollback once the reload requested through ReloadRequested is handled.
+//
+// The sources from which config was loaded are still watched afterward, so the next change made to them is applied
+// as usual.
+func (config *Config) RollBackTo(s *snapshot.Snapshot) error {
+	rolledBackConfig, err := parseAndValidateConfigBytes(s.Data)
+	if err != nil {
+		return err
+	}
+	rolledBackConfig.rawBytes = s.Data
+	rolledBackConfig.rollbackOf = s.Version
+	rolledBackConfig.configPath = config.configPath
+	config.rollbackMutex.Lock()
+	defer config.rollbackMutex.Unlock()
+	config.pendingRollback = rolledBackConfig
+	if config.reloadRequested == nil {
+		config.reloadRequested = make(chan struct{}, 1)
+	}
+	select {
+	case config.reloadRequested <- struct{}{}:
+	default:
+		// A reload has already been requested
+	}
+	return nil
+}
+
+// ReloadRequested returns a channel that receives a value whenever the configuration must be reloaded without
+// waiting for its sources to be modified, such as when RollBackTo is called
+func (config *Config) ReloadRequested() <-chan struct{} {
+	config.rollbackMutex.Lock()
+	defer config.rollbackMutex.Unlock()
+	if config.reloadRequested == nil {
+		config.reloadRequested = make(chan struct{}, 1)
+	}
+	return config.reloadRequested
+}
+
+// PendingRollback returns the configuration scheduled to replace config by RollBackTo, or nil if there is none.
+//
+// The configuration returned takes over the watcher of the KV backend from which config was loaded, if any, so it
+// must be applied.
+func (config *Config) PendingRollback() *Config {
+	config.rollbackMutex.Lock()
+	defer config.rollbackMutex.Unlock()
+	rolledBackConfig := config.pendingRollback
+	if rolledBackConfig == nil {
+		return nil
+	}
+	config.pendingRollback = nil
+	rolledBackConfig.kvWatcher, config.kvWatcher = config.kvWatcher, nil
+	rolledBackConfig.UpdateLastFileModTime()
+	return rolledBackConfig
 }
 
 // LoadConfiguration loads the full configuration composed of the main configuration file
@@ -203,10 +495,31 @@ func LoadConfiguration(configPath string) (*Config, error) {
 		return nil, err
 	}
 	config.configPath = usedConfigPath
+	config.rawBytes = configBytes
 	config.UpdateLastFileModTime()
 	return config, err
 }
 
+// LoadConfigurationFromBackend loads the configuration from a KV backend and watches the backend for changes
+func LoadConfigurationFromBackend(backend kv.Backend) (*Config, error) {
+	log.Printf("[config.LoadConfigurationFromBackend] Reading configuration from %s", backend.String())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	configBytes, version, err := backend.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration from %s: %w", backend.String(), err)
+	}
+	config, err := parseAndValidateConfigBytes(configBytes)
+	if err != nil {
+		return nil, err
+	}
+	config.rawBytes = configBytes
+	config.kvVersion = version
+	config.kvWatcher = kv.Watch(backend, version)
+	config.UpdateLastFileModTime()
+	return config, nil
+}
+
 // walkConfigDir is a wrapper for filepath.WalkDir that strips directories and non-config files
 func walkConfigDir(path string, fn fs.WalkDirFunc) error {
 	if len(path) == 0 {
@@ -241,17 +554,32 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 	if err = yaml.Unmarshal(yamlBytes, &config); err != nil {
 		return
 	}
+	// The endpoints of tenants must be added to the other endpoints before checking whether there's any endpoint
+	if config != nil {
+		if err = validateTenantsConfig(config); err != nil {
+			return nil, err
+		}
+	}
 	// Check if the configuration file at least has endpoints configured
 	if config == nil || config.Endpoints == nil || len(config.Endpoints) == 0 {
 		err = ErrNoEndpointInConfig
 	} else {
-		validateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints, config.Debug)
+		validateAlertingConfig(config.Alerting, config.Endpoints, config.ExternalEndpoints, config.ExternalEndpointsAutoCreate, config.Debug)
+		if err := validateAlertingDeliveryConfig(config); err != nil {
+			return nil, err
+		}
 		if err := validateSecurityConfig(config); err != nil {
 			return nil, err
 		}
+		if err := client.ValidateCABundleFiles(config.CABundleFiles); err != nil {
+			return nil, err
+		}
 		if err := validateEndpointsConfig(config); err != nil {
 			return nil, err
 		}
+		if err := validateRunnersConfig(config); err != nil {
+			return nil, err
+		}
 		if err := validateWebConfig(config); err != nil {
 			return nil, err
 		}
@@ -261,6 +589,9 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		if err := validateMaintenanceConfig(config); err != nil {
 			return nil, err
 		}
+		if config.GracePeriod < 0 {
+			return nil, ErrInvalidGracePeriod
+		}
 		if err := validateStorageConfig(config); err != nil {
 			return nil, err
 		}
@@ -270,10 +601,108 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		if err := validateConnectivityConfig(config); err != nil {
 			return nil, err
 		}
+		if err := validateChaosConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateLogAnalyticsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateMetricsPushConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateResultLogConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateEventsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateReportsConfig(config); err != nil {
+			return nil, err
+		}
 	}
 	return
 }
 
+// validateTenantsConfig validates the configuration of each tenant and adds the endpoints of every tenant to the
+// endpoints of the configuration, so that they're monitored, stored and alerted on like any other endpoint
+func validateTenantsConfig(config *Config) error {
+	if len(config.Tenants) == 0 {
+		return nil
+	}
+	tenantNames := make(map[string]bool)
+	for _, t := range config.Tenants {
+		if err := t.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid tenant %s: %w", t.Name, err)
+		}
+		if tenantNames[t.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateTenant, t.Name)
+		}
+		tenantNames[t.Name] = true
+		config.Endpoints = append(config.Endpoints, t.Endpoints...)
+		config.ExternalEndpoints = append(config.ExternalEndpoints, t.ExternalEndpoints...)
+	}
+	log.Printf("[config.validateTenantsConfig] Validated %d tenants", len(config.Tenants))
+	return nil
+}
+
+func validateAlertingDeliveryConfig(config *Config) error {
+	if config.Alerting == nil || config.Alerting.Delivery == nil {
+		return nil
+	}
+	if err := config.Alerting.Delivery.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	log.Printf("[config.validateAlertingDeliveryConfig] Alerts will be delivered through a queue with up to %d attempt(s) per alert", config.Alerting.Delivery.MaximumAttempts)
+	return nil
+}
+
+func validateChaosConfig(config *Config) error {
+	if config.Chaos == nil || !config.Chaos.Enabled {
+		return nil
+	}
+	if !config.Debug {
+		return ErrChaosWithoutDebug
+	}
+	if err := config.Chaos.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	for _, experiment := range config.Chaos.Experiments {
+		for _, key := range experiment.Endpoints {
+			if config.GetEndpointByKey(key) == nil {
+				return fmt.Errorf("%w: chaos experiment %s targets endpoint with key=%s", ErrUnknownEndpointInChaosExperiment, experiment.Name, key)
+			}
+		}
+	}
+	log.Printf("[config.validateChaosConfig] Chaos experiments are enabled; synthetic failures will be injected in the results of %d experiment(s)", len(config.Chaos.Experiments))
+	return nil
+}
+
+// validateRunnersConfig validates the configuration of each runner and sets the runner configuration of every
+// endpoint that references one
+func validateRunnersConfig(config *Config) error {
+	runnersByName := make(map[string]*runner.Config, len(config.Runners))
+	for _, r := range config.Runners {
+		if err := r.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if _, exists := runnersByName[r.Name]; exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateRunner, r.Name)
+		}
+		runnersByName[r.Name] = r
+	}
+	for _, ep := range config.Endpoints {
+		if len(ep.Runner) == 0 {
+			continue
+		}
+		r, exists := runnersByName[ep.Runner]
+		if !exists {
+			return fmt.Errorf("%w: endpoint with key=%s references runner %s", ErrUnknownRunner, ep.Key(), ep.Runner)
+		}
+		ep.RunnerConfig = r
+	}
+	return nil
+}
+
 func validateConnectivityConfig(config *Config) error {
 	if config.Connectivity != nil {
 		return config.Connectivity.ValidateAndSetDefaults()
@@ -281,6 +710,48 @@ func validateConnectivityConfig(config *Config) error {
 	return nil
 }
 
+func validateLogAnalyticsConfig(config *Config) error {
+	if config.LogAnalytics != nil {
+		return config.LogAnalytics.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateMetricsPushConfig(config *Config) error {
+	if config.MetricsPush != nil {
+		return config.MetricsPush.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateResultLogConfig(config *Config) error {
+	if config.ResultLog != nil {
+		return config.ResultLog.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateEventsConfig(config *Config) error {
+	if config.Events != nil {
+		return config.Events.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateReportsConfig(config *Config) error {
+	reportNames := make(map[string]bool, len(config.Reports))
+	for _, r := range config.Reports {
+		if err := r.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if reportNames[r.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateReport, r.Name)
+		}
+		reportNames[r.Name] = true
+	}
+	return nil
+}
+
 func validateRemoteConfig(config *Config) error {
 	if config.Remote != nil {
 		if err := config.Remote.ValidateAndSetDefaults(); err != nil {
@@ -328,8 +799,19 @@ func validateUIConfig(config *Config) error {
 func validateWebConfig(config *Config) error {
 	if config.Web == nil {
 		config.Web = web.GetDefaultConfig()
-	} else {
-		return config.Web.ValidateAndSetDefaults()
+	} else if err := config.Web.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	// The endpoints of tenants are not linked to, because they are not on the dashboard
+	for _, ep := range config.Endpoints {
+		if len(ep.Tenant) == 0 {
+			ep.PageURL = config.Web.EndpointPageURL(ep.Key())
+		}
+	}
+	for _, ee := range config.ExternalEndpoints {
+		if len(ee.Tenant) == 0 {
+			ee.PageURL = config.Web.EndpointPageURL(ee.Key())
+		}
 	}
 	return nil
 }
@@ -352,6 +834,7 @@ func validateEndpointsConfig(config *Config) error {
 	}
 	log.Printf("[config.validateEndpointsConfig] Validated %d endpoints", len(config.Endpoints))
 	// Validate external endpoints
+	pingUUIDs := make(map[string]bool)
 	for _, ee := range config.ExternalEndpoints {
 		if config.Debug {
 			log.Printf("[config.validateEndpointsConfig] Validating external endpoint '%s'", ee.Name)
@@ -361,11 +844,32 @@ func validateEndpointsConfig(config *Config) error {
 		} else {
 			duplicateValidationMap[endpointKey] = true
 		}
+		if len(ee.PingUUID) > 0 {
+			if pingUUIDs[ee.PingUUID] {
+				return fmt.Errorf("invalid external endpoint %s: ping-uuid must be unique", ee.Key())
+			}
+			pingUUIDs[ee.PingUUID] = true
+		}
 		if err := ee.ValidateAndSetDefaults(); err != nil {
 			return fmt.Errorf("invalid external endpoint %s: %w", ee.Key(), err)
 		}
 	}
 	log.Printf("[config.validateEndpointsConfig] Validated %d external endpoints", len(config.ExternalEndpoints))
+	for _, rule := range config.ExternalEndpointsAutoCreate {
+		if err := rule.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid external endpoint auto-create rule for group %s: %w", rule.Group, err)
+		}
+	}
+	// Validate the endpoints referenced by conditions, now that the key of every endpoint is known
+	for _, ep := range config.Endpoints {
+		for _, condition := range ep.Conditions {
+			for _, key := range condition.ReferencedEndpointKeys() {
+				if !duplicateValidationMap[key] {
+					return fmt.Errorf("invalid endpoint %s: %w: %s", ep.Key(), ErrUnknownEndpointInCondition, key)
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -388,7 +892,7 @@ func validateSecurityConfig(config *Config) error {
 // Note that the alerting configuration has to be validated before the endpoint configuration, because the default alert
 // returned by provider.AlertProvider.GetDefaultAlert() must be parsed before endpoint.Endpoint.ValidateAndSetDefaults()
 // sets the default alert values when none are set.
-func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoint.Endpoint, externalEndpoints []*endpoint.ExternalEndpoint, debug bool) {
+func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoint.Endpoint, externalEndpoints []*endpoint.ExternalEndpoint, externalEndpointAutoCreateRules []*endpoint.ExternalEndpointAutoCreateRule, debug bool) {
 	if alertingConfig == nil {
 		log.Printf("[config.validateAlertingConfig] Alerting is not configured")
 		return
@@ -398,18 +902,22 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 		alert.TypeCustom,
 		alert.TypeDiscord,
 		alert.TypeEmail,
+		alert.TypeFCM,
 		alert.TypeGitHub,
 		alert.TypeGitLab,
 		alert.TypeGoogleChat,
 		alert.TypeGotify,
 		alert.TypeJetBrainsSpace,
+		alert.TypeKeybase,
 		alert.TypeMatrix,
 		alert.TypeMattermost,
 		alert.TypeMessagebird,
+		alert.TypeMQTT,
 		alert.TypeNtfy,
 		alert.TypeOpsgenie,
 		alert.TypePagerDuty,
 		alert.TypePushover,
+		alert.TypeRocketChat,
 		alert.TypeSlack,
 		alert.TypeTeams,
 		alert.TypeTelegram,
@@ -442,6 +950,14 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*endpoi
 							}
 						}
 					}
+					// The alerts of the external endpoints created by a rule are copied from the alerts of the rule
+					for _, rule := range externalEndpointAutoCreateRules {
+						for _, ruleAlert := range rule.Alerts {
+							if alertType == ruleAlert.Type {
+								provider.ParseWithDefaultAlert(alertProvider.GetDefaultAlert(), ruleAlert)
+							}
+						}
+					}
 				}
 				validProviders = append(validProviders, alertType)
 			} else {
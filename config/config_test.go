@@ -3,35 +3,50 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
 	"github.com/TwiN/gatus/v5/alerting/provider"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
+	"github.com/TwiN/gatus/v5/alerting/provider/fcm"
 	"github.com/TwiN/gatus/v5/alerting/provider/github"
 	"github.com/TwiN/gatus/v5/alerting/provider/googlechat"
 	"github.com/TwiN/gatus/v5/alerting/provider/gotify"
 	"github.com/TwiN/gatus/v5/alerting/provider/jetbrainsspace"
+	"github.com/TwiN/gatus/v5/alerting/provider/keybase"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
+	"github.com/TwiN/gatus/v5/alerting/provider/mqtt"
 	"github.com/TwiN/gatus/v5/alerting/provider/ntfy"
 	"github.com/TwiN/gatus/v5/alerting/provider/opsgenie"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
+	"github.com/TwiN/gatus/v5/alerting/provider/rocketchat"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/kv"
+	"github.com/TwiN/gatus/v5/config/snapshot"
+	"github.com/TwiN/gatus/v5/config/tenant"
 	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/events"
+	"github.com/TwiN/gatus/v5/loganalytics"
+	"github.com/TwiN/gatus/v5/report"
+	"github.com/TwiN/gatus/v5/resultlog"
 	"github.com/TwiN/gatus/v5/storage"
 	"gopkg.in/yaml.v3"
 )
@@ -321,6 +336,89 @@ func TestConfig_HasLoadedConfigurationBeenModified(t *testing.T) {
 	})
 }
 
+func TestConfig_RollBackTo(t *testing.T) {
+	dir := t.TempDir()
+	configFilePath := filepath.Join(dir, "config.yaml")
+	content := []byte(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`)
+	_ = os.WriteFile(configFilePath, content, 0644)
+	config, err := LoadConfiguration(configFilePath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	configurationSnapshot := config.Snapshot()
+	if string(configurationSnapshot.Data) != string(content) || configurationSnapshot.Checksum != snapshot.Checksum(content) || configurationSnapshot.Source != snapshot.SourceFile {
+		t.Fatalf("expected snapshot of the configuration file, got %+v", configurationSnapshot)
+	}
+	configurationSnapshot.Version = 3
+	if err = config.RollBackTo(&snapshot.Snapshot{Version: 4, Data: []byte("metrics: true")}); !errors.Is(err, ErrNoEndpointInConfig) {
+		t.Errorf("expected error %v, got %v", ErrNoEndpointInConfig, err)
+	}
+	if config.PendingRollback() != nil {
+		t.Error("expected no rollback to be pending after an invalid configuration was rejected")
+	}
+	if err = config.RollBackTo(configurationSnapshot); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	rolledBackConfig := config.PendingRollback()
+	if rolledBackConfig == nil || rolledBackConfig.Snapshot().RollbackOf != 3 || rolledBackConfig.Snapshot().Source != snapshot.SourceRollback {
+		t.Fatalf("expected configuration of version 3 to be pending, got %v", rolledBackConfig)
+	}
+	if rolledBackConfig.HasLoadedConfigurationBeenModified() {
+		t.Error("expected the configuration rolled back to not be considered modified")
+	}
+	time.Sleep(time.Second) // Because the file mod time only has second precision, we have to wait for a second
+	if err = os.WriteFile(configFilePath, append(content, []byte("metrics: true\n")...), 0644); err != nil {
+		t.Fatalf("failed to overwrite config file: %v", err)
+	}
+	if !rolledBackConfig.HasLoadedConfigurationBeenModified() {
+		t.Error("expected the configuration file to still be watched after the rollback")
+	}
+}
+
+func TestLoadConfigurationFromBackend(t *testing.T) {
+	var value atomic.Value
+	value.Store(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"data":{"config":%q},"metadata":{"version":1}}}`, value.Load().(string))
+	}))
+	defer server.Close()
+	config, err := LoadConfigurationFromBackend(kv.NewVaultBackend(server.URL, "secret/data/gatus", "config", "", 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	defer config.Close()
+	if len(config.Endpoints) != 1 || config.Endpoints[0].Name != "website" {
+		t.Fatalf("expected configuration to have been loaded from the backend, got %d endpoints", len(config.Endpoints))
+	}
+	if config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return false because nothing has happened since it was created")
+	}
+	value.Store(`endpoints: []`)
+	for i := 0; i < 100 && !config.HasLoadedConfigurationBeenModified(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return true because the configuration in the backend has changed")
+	}
+	config.UpdateLastFileModTime()
+	if config.HasLoadedConfigurationBeenModified() {
+		t.Error("expected config.HasLoadedConfigurationBeenModified() to return false because the latest version has been marked as loaded")
+	}
+	if _, err = LoadConfigurationFromBackend(kv.NewVaultBackend(server.URL, "secret/data/gatus", "missing", "", time.Second)); !errors.Is(err, kv.ErrKeyNotFound) {
+		t.Errorf("expected error %v, got %v", kv.ErrKeyNotFound, err)
+	}
+}
+
 func TestParseAndValidateConfigBytes(t *testing.T) {
 	file := t.TempDir() + "/test.db"
 	config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
@@ -543,6 +641,32 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithExternalURL(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+web:
+  base-path: /status
+  external-url: https://example.org
+external-endpoints:
+  - name: ext-ep-test
+    group: core
+    token: "potato"
+endpoints:
+  - name: website
+    url: https://twin.sh/actuator/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].PageURL != "https://example.org/status/endpoints/_website" {
+		t.Errorf("expected PageURL to be %s, got %s", "https://example.org/status/endpoints/_website", config.Endpoints[0].PageURL)
+	}
+	if config.ExternalEndpoints[0].PageURL != "https://example.org/status/endpoints/core_ext-ep-test" {
+		t.Errorf("expected PageURL to be %s, got %s", "https://example.org/status/endpoints/core_ext-ep-test", config.ExternalEndpoints[0].PageURL)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithPort(t *testing.T) {
 	config, err := parseAndValidateConfigBytes([]byte(`
 web:
@@ -626,6 +750,212 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithConditionReferencingEndpoint(t *testing.T) {
+	_, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: api
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+      - "[ENDPOINT(core_database).SUCCESS] == true"
+  - name: database
+    group: core
+    url: tcp://127.0.0.1:5432
+    conditions:
+      - "[CONNECTED] == true"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: api
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[ENDPOINT(core_cache).SUCCESS] == true"
+`))
+	if !errors.Is(err, ErrUnknownEndpointInCondition) {
+		t.Errorf("expected error %v, got %v", ErrUnknownEndpointInCondition, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithLogAnalytics(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+log-analytics:
+  workspace-id: "workspace-id"
+  shared-key: "c2hhcmVkLWtleQ=="
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.LogAnalytics == nil || config.LogAnalytics.ResultLogType != loganalytics.DefaultResultLogType {
+		t.Error("expected log-analytics to be configured with the default result log type")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+log-analytics:
+  workspace-id: "workspace-id"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, loganalytics.ErrNoAPIConfigured) {
+		t.Errorf("expected error %v, got %v", loganalytics.ErrNoAPIConfigured, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithResultLog(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+result-log: {}
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.ResultLog == nil || len(config.ResultLog.Fields) != len(resultlog.DefaultFields) {
+		t.Error("expected result-log to be configured with the default fields")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+result-log:
+  fields: [key, body]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, resultlog.ErrUnknownField) {
+		t.Errorf("expected error %v, got %v", resultlog.ErrUnknownField, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithGracePeriod(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+grace-period: 5m
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    initial-delay: 30s
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.GracePeriod != 5*time.Minute {
+		t.Errorf("expected grace period to be 5m, got %s", config.GracePeriod)
+	}
+	if config.Endpoints[0].InitialDelay != 30*time.Second {
+		t.Errorf("expected initial delay to be 30s, got %s", config.Endpoints[0].InitialDelay)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+grace-period: -5m
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrInvalidGracePeriod) {
+		t.Errorf("expected error %v, got %v", ErrInvalidGracePeriod, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithEvents(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+events:
+  types: [endpoint.down, endpoint.up]
+  webhooks:
+    - url: https://example.org/hooks/gatus
+      headers:
+        Authorization: "Bearer token"
+  nats:
+    - url: nats://nats:4222
+      subject: gatus.events
+  kafka:
+    - rest-proxy-url: http://kafka-rest:8082
+      topic: gatus-events
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Events == nil || len(config.Events.Types) != 2 || len(config.Events.Webhooks) != 1 || len(config.Events.NATS) != 1 || len(config.Events.Kafka) != 1 {
+		t.Fatalf("expected events to be configured with a webhook, a nats subject and a kafka topic, got %#v", config.Events)
+	}
+	if config.Events.Webhooks[0].Headers["Authorization"] != "Bearer token" {
+		t.Errorf("expected headers of the webhook to be parsed, got %v", config.Events.Webhooks[0].Headers)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+events:
+  types: [endpoint.down]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, events.ErrNoSink) {
+		t.Errorf("expected error %v, got %v", events.ErrNoSink, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithReports(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+reports:
+  - name: availability
+    notion:
+      token: "secret"
+      parent-page-id: "11111111222233334444555555555555"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Reports) != 1 || config.Reports[0].Period != report.DefaultPeriod {
+		t.Error("expected the report to be configured with the default period")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+reports:
+  - name: availability
+    notion:
+      token: "secret"
+      parent-page-id: "11111111222233334444555555555555"
+  - name: availability
+    notion:
+      token: "secret"
+      parent-page-id: "11111111222233334444555555555555"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrDuplicateReport) {
+		t.Errorf("expected error %v, got %v", ErrDuplicateReport, err)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithMetricsAndCustomUserAgentHeader(t *testing.T) {
 	config, err := parseAndValidateConfigBytes([]byte(`
 metrics: true
@@ -1738,6 +2068,18 @@ endpoints:
     conditions:
       - "[STATUS] == 200"`,
 		},
+		{
+			name:        "same-ping-uuid-different-external-endpoints",
+			shouldError: true,
+			config: `
+external-endpoints:
+  - name: ep1
+    token: "12345678"
+    ping-uuid: "5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278"
+  - name: ep2
+    token: "12345678"
+    ping-uuid: "5bf66975-d4c7-4bf5-bcc8-b8d8a82ea278"`,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -1766,6 +2108,241 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithAlertingDeliveryConfig(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "https://example.com"
+  delivery:
+    maximum-attempts: 5
+    initial-backoff: 10s
+endpoints:
+  - name: example
+    url: https://example.org
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if config.Alerting.Delivery == nil {
+		t.Fatal("expected alerting delivery to be configured")
+	}
+	if config.Alerting.Delivery.MaximumAttempts != 5 || config.Alerting.Delivery.InitialBackoff != 10*time.Second || config.Alerting.Delivery.MaximumBackoff != delivery.DefaultMaximumBackoff {
+		t.Errorf("unexpected alerting delivery config %+v", config.Alerting.Delivery)
+	}
+	if config.Alerting.Slack == nil || !config.Alerting.Slack.IsValid() {
+		t.Error("expected the slack provider to be unaffected by the delivery configuration")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  delivery:
+    maximum-attempts: -1
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, delivery.ErrInvalidMaximumAttempts) {
+		t.Errorf("expected error %v, got %v", delivery.ErrInvalidMaximumAttempts, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithExternalEndpointsAutoCreate(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "https://example.com"
+    default-alert:
+      failure-threshold: 5
+external-endpoints-auto-create:
+  - group: jobs
+    token: "potato"
+    alerts:
+      - type: slack
+endpoints:
+  - name: backup
+    group: jobs
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(config.ExternalEndpointsAutoCreate) != 1 || config.ExternalEndpointsAutoCreate[0].Name != "*" {
+		t.Fatalf("expected 1 auto-create rule matching every name, got %+v", config.ExternalEndpointsAutoCreate)
+	}
+	if ee := config.GetOrCreateExternalEndpointByKey("jobs_cleanup", "tomato"); ee != nil {
+		t.Error("expected no external endpoint to be created with an invalid token")
+	}
+	if ee := config.GetOrCreateExternalEndpointByKey("jobs_backup", "potato"); ee != nil {
+		t.Error("expected no external endpoint to be created with the key of an endpoint")
+	}
+	ee := config.GetOrCreateExternalEndpointByKey("jobs_cleanup", "potato")
+	if ee == nil {
+		t.Fatal("expected the external endpoint to be created")
+	}
+	if len(ee.Alerts) != 1 || ee.Alerts[0].FailureThreshold != 5 || ee.Alerts[0] == config.ExternalEndpointsAutoCreate[0].Alerts[0] {
+		t.Errorf("expected the external endpoint to have a copy of the alerts of the rule parsed with the default alert, got %+v", ee.Alerts)
+	}
+	if config.GetOrCreateExternalEndpointByKey("jobs_cleanup", "potato") != ee || len(config.ExternalEndpoints) != 1 {
+		t.Error("expected the external endpoint to be created only once")
+	}
+	if numberOfExternalEndpointsRestored := config.RestoreAutoCreatedExternalEndpoints([]string{"jobs_backup", "jobs_cleanup", "jobs_report", "other_report"}); numberOfExternalEndpointsRestored != 1 {
+		t.Errorf("expected only jobs_report to be restored, got %d external endpoints restored", numberOfExternalEndpointsRestored)
+	}
+	if config.GetExternalEndpointByKey("jobs_report") == nil {
+		t.Error("expected jobs_report to have been restored")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+external-endpoints-auto-create:
+  - group: jobs
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, endpoint.ErrExternalEndpointAutoCreateRuleWithNoToken) {
+		t.Errorf("expected error %v, got %v", endpoint.ErrExternalEndpointAutoCreateRuleWithNoToken, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithTenants(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    group: core
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+tenants:
+  - name: acme
+    token: "acme-token"
+    endpoints:
+      - name: website
+        group: core
+        url: https://acme.example.org
+        conditions:
+          - "[STATUS] == 200"
+    external-endpoints:
+      - name: backup
+        token: "backup-token"
+  - name: globex
+    public: true
+    endpoints:
+      - name: website
+        group: core
+        url: https://globex.example.org
+        conditions:
+          - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(config.Endpoints) != 3 {
+		t.Fatalf("expected the endpoints of the tenants to be added to the endpoints, got %d endpoints", len(config.Endpoints))
+	}
+	if config.GetEndpointByKey("core_website") == nil || config.GetEndpointByKey("acme_core_website") == nil || config.GetEndpointByKey("globex_core_website") == nil {
+		t.Error("expected endpoints with the same group and name to be isolated by their tenant")
+	}
+	if config.GetExternalEndpointByKey("acme__backup") == nil {
+		t.Error("expected external endpoint of tenant acme to have key acme__backup")
+	}
+	if acme := config.GetTenantByName("acme"); acme == nil || acme.Token != "acme-token" {
+		t.Error("expected tenant acme to exist")
+	}
+	if config.GetTenantByName("initech") != nil {
+		t.Error("expected tenant initech not to exist")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+tenants:
+  - name: acme
+    token: "acme-token"
+    endpoints:
+      - name: website
+        url: https://acme.example.org
+        conditions:
+          - "[STATUS] == 200"
+  - name: acme
+    token: "other-token"
+`))
+	if !errors.Is(err, ErrDuplicateTenant) {
+		t.Errorf("expected error %v, got %v", ErrDuplicateTenant, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+tenants:
+  - name: acme
+    endpoints:
+      - name: website
+        url: https://acme.example.org
+        conditions:
+          - "[STATUS] == 200"
+`))
+	if !errors.Is(err, tenant.ErrTenantWithNoToken) {
+		t.Errorf("expected error %v, got %v", tenant.ErrTenantWithNoToken, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithChaosConfig(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		debug         bool
+		endpointKey   string
+		expectedError error
+	}{
+		{
+			name:        "valid",
+			debug:       true,
+			endpointKey: "core_example",
+		},
+		{
+			name:          "without-debug",
+			debug:         false,
+			endpointKey:   "core_example",
+			expectedError: ErrChaosWithoutDebug,
+		},
+		{
+			name:          "unknown-endpoint",
+			debug:         true,
+			endpointKey:   "core_unknown",
+			expectedError: ErrUnknownEndpointInChaosExperiment,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+debug: %v
+chaos:
+  enabled: true
+  experiments:
+    - name: rehearsal
+      endpoints: ["%s"]
+      schedule:
+        start: "14:00"
+        duration: 30m
+        every: [Tuesday]
+endpoints:
+  - name: example
+    group: core
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`, scenario.debug, scenario.endpointKey)))
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if err == nil && config.Chaos.Experiments[0].Error != "synthetic failure injected by chaos experiment rehearsal" {
+				t.Errorf("expected default error to be set, got %s", config.Chaos.Experiments[0].Error)
+			}
+		})
+	}
+}
+
 func TestParseAndValidateConfigBytesWithInvalidYAML(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 storage:
@@ -1798,6 +2375,78 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithInvalidCABundleFiles(t *testing.T) {
+	_, err := parseAndValidateConfigBytes([]byte(`
+ca-bundle-files:
+  - /path/that/does/not/exist/ca.pem
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err == nil {
+		t.Error("should've returned an error")
+	}
+}
+
+func TestParseAndValidateConfigBytesWithRunners(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		runnerNames    []string
+		endpointRunner string
+		expectedError  error
+	}{
+		{
+			name:           "valid",
+			runnerNames:    []string{"eu-west", "us-east"},
+			endpointRunner: "us-east",
+		},
+		{
+			name:           "unknown-runner",
+			runnerNames:    []string{"eu-west"},
+			endpointRunner: "us-east",
+			expectedError:  ErrUnknownRunner,
+		},
+		{
+			name:           "duplicate-runner",
+			runnerNames:    []string{"eu-west", "eu-west"},
+			endpointRunner: "eu-west",
+			expectedError:  ErrDuplicateRunner,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var runners string
+			for _, name := range scenario.runnerNames {
+				runners += fmt.Sprintf("  - name: %s\n    address: 10.0.0.1\n    username: gatus\n    password: password\n    host-key: ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILZ0jgNtIe6Kg6PVlUh6U6zdtwNvElA14g4A3AxK0gOK\n", name)
+			}
+			config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+runners:
+%s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    runner: %s
+    conditions:
+      - "[STATUS] == 200"
+`, runners, scenario.endpointRunner)))
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if config.Endpoints[0].RunnerConfig == nil || config.Endpoints[0].RunnerConfig.Name != scenario.endpointRunner {
+				t.Errorf("expected endpoint to use runner %s, got %+v", scenario.endpointRunner, config.Endpoints[0].RunnerConfig)
+			}
+			if config.Endpoints[0].RunnerConfig.Address != "10.0.0.1:22" {
+				t.Errorf("expected the address of the runner to default to port 22, got %s", config.Endpoints[0].RunnerConfig.Address)
+			}
+		})
+	}
+}
+
 func TestParseAndValidateConfigBytesWithValidSecurityConfig(t *testing.T) {
 	const expectedUsername = "admin"
 	const expectedPasswordHash = "JDJhJDEwJHRiMnRFakxWazZLdXBzRERQazB1TE8vckRLY05Yb1hSdnoxWU0yQ1FaYXZRSW1McmladDYu"
@@ -1874,17 +2523,21 @@ func TestGetAlertingProviderByAlertType(t *testing.T) {
 		Custom:         &custom.AlertProvider{},
 		Discord:        &discord.AlertProvider{},
 		Email:          &email.AlertProvider{},
+		FCM:            &fcm.AlertProvider{},
 		GitHub:         &github.AlertProvider{},
 		GoogleChat:     &googlechat.AlertProvider{},
 		Gotify:         &gotify.AlertProvider{},
 		JetBrainsSpace: &jetbrainsspace.AlertProvider{},
+		Keybase:        &keybase.AlertProvider{},
 		Matrix:         &matrix.AlertProvider{},
 		Mattermost:     &mattermost.AlertProvider{},
 		Messagebird:    &messagebird.AlertProvider{},
+		MQTT:           &mqtt.AlertProvider{},
 		Ntfy:           &ntfy.AlertProvider{},
 		Opsgenie:       &opsgenie.AlertProvider{},
 		PagerDuty:      &pagerduty.AlertProvider{},
 		Pushover:       &pushover.AlertProvider{},
+		RocketChat:     &rocketchat.AlertProvider{},
 		Slack:          &slack.AlertProvider{},
 		Telegram:       &telegram.AlertProvider{},
 		Twilio:         &twilio.AlertProvider{},
@@ -1897,17 +2550,21 @@ func TestGetAlertingProviderByAlertType(t *testing.T) {
 		{alertType: alert.TypeCustom, expected: alertingConfig.Custom},
 		{alertType: alert.TypeDiscord, expected: alertingConfig.Discord},
 		{alertType: alert.TypeEmail, expected: alertingConfig.Email},
+		{alertType: alert.TypeFCM, expected: alertingConfig.FCM},
 		{alertType: alert.TypeGitHub, expected: alertingConfig.GitHub},
 		{alertType: alert.TypeGoogleChat, expected: alertingConfig.GoogleChat},
 		{alertType: alert.TypeGotify, expected: alertingConfig.Gotify},
 		{alertType: alert.TypeJetBrainsSpace, expected: alertingConfig.JetBrainsSpace},
+		{alertType: alert.TypeKeybase, expected: alertingConfig.Keybase},
 		{alertType: alert.TypeMatrix, expected: alertingConfig.Matrix},
 		{alertType: alert.TypeMattermost, expected: alertingConfig.Mattermost},
 		{alertType: alert.TypeMessagebird, expected: alertingConfig.Messagebird},
+		{alertType: alert.TypeMQTT, expected: alertingConfig.MQTT},
 		{alertType: alert.TypeNtfy, expected: alertingConfig.Ntfy},
 		{alertType: alert.TypeOpsgenie, expected: alertingConfig.Opsgenie},
 		{alertType: alert.TypePagerDuty, expected: alertingConfig.PagerDuty},
 		{alertType: alert.TypePushover, expected: alertingConfig.Pushover},
+		{alertType: alert.TypeRocketChat, expected: alertingConfig.RocketChat},
 		{alertType: alert.TypeSlack, expected: alertingConfig.Slack},
 		{alertType: alert.TypeTelegram, expected: alertingConfig.Telegram},
 		{alertType: alert.TypeTwilio, expected: alertingConfig.Twilio},
@@ -0,0 +1,49 @@
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidOrderIdentifier is the error with which Gatus will panic if an ACME endpoint has an empty order identifier
+	ErrInvalidOrderIdentifier = errors.New("acme order identifiers must be non-empty domain names")
+)
+
+// Config is the configuration for monitoring an ACME directory
+type Config struct {
+	// OrderIdentifiers is the list of DNS identifiers for which to create an order as a dry run.
+	// If empty, only the directory and the nonce endpoint are checked.
+	//
+	// The order is never finalized, so no certificate is issued, and the pending order eventually expires.
+	OrderIdentifiers []string `yaml:"order-identifiers,omitempty"`
+
+	accountKey     *ecdsa.PrivateKey
+	accountKeyErr  error
+	accountKeyOnce sync.Once
+}
+
+// Validate the ACME configuration
+func (cfg *Config) Validate() error {
+	for _, identifier := range cfg.OrderIdentifiers {
+		if len(strings.TrimSpace(identifier)) == 0 {
+			return ErrInvalidOrderIdentifier
+		}
+	}
+	return nil
+}
+
+// AccountKey returns the key of the account used to create orders.
+//
+// The key is generated the first time it is requested and kept in memory, so that the same account is reused for
+// every evaluation instead of registering a new account every time.
+func (cfg *Config) AccountKey() (*ecdsa.PrivateKey, error) {
+	cfg.accountKeyOnce.Do(func() {
+		cfg.accountKey, cfg.accountKeyErr = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	})
+	return cfg.accountKey, cfg.accountKeyErr
+}
@@ -0,0 +1,33 @@
+package acme
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	if err := (&Config{}).Validate(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if err := (&Config{OrderIdentifiers: []string{"example.internal"}}).Validate(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+	if err := (&Config{OrderIdentifiers: []string{"example.internal", " "}}).Validate(); !errors.Is(err, ErrInvalidOrderIdentifier) {
+		t.Errorf("expected error to be '%v', got '%v'", ErrInvalidOrderIdentifier, err)
+	}
+}
+
+func TestConfig_AccountKey(t *testing.T) {
+	cfg := &Config{}
+	firstKey, err := cfg.AccountKey()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	secondKey, err := cfg.AccountKey()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !firstKey.Equal(secondKey) {
+		t.Error("expected the same account key to be returned every time")
+	}
+}
@@ -0,0 +1,56 @@
+package cachebusting
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoCacheBustingOption is the error with which Gatus will panic if cache-busting is set without any option
+	ErrNoCacheBustingOption = errors.New("cache-busting must have query-parameter and/or no-cache set")
+
+	// ErrInvalidQueryParameter is the error with which Gatus will panic if query-parameter can't be used as the name of
+	// a query parameter
+	ErrInvalidQueryParameter = errors.New("cache-busting query-parameter must not contain any of the following characters: &=?# ")
+)
+
+// Config is the cache-busting configuration for endpoint.Endpoint
+//
+// When set, requests are altered so that caches between Gatus and the target (e.g. a CDN) don't serve a cached copy of
+// the response, which makes it possible to verify the health of the origin rather than that of the cache.
+type Config struct {
+	// QueryParameter is the name of a query parameter to set to a value unique to each request (e.g. "_").
+	// No query parameter is added if empty.
+	QueryParameter string `yaml:"query-parameter,omitempty"`
+
+	// NoCache is whether to send the Cache-Control and Pragma headers with the value no-cache, which requires caches to
+	// validate the response with the origin before serving it
+	NoCache bool `yaml:"no-cache,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the cache-busting configuration
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.QueryParameter) == 0 && !c.NoCache {
+		return ErrNoCacheBustingOption
+	}
+	if strings.ContainsAny(c.QueryParameter, "&=?# ") {
+		return ErrInvalidQueryParameter
+	}
+	return nil
+}
+
+// Apply alters the request passed so that it bypasses caches
+func (c *Config) Apply(request *http.Request) {
+	if len(c.QueryParameter) > 0 {
+		query := request.URL.Query()
+		query.Set(c.QueryParameter, strconv.FormatInt(time.Now().UnixNano(), 10))
+		request.URL.RawQuery = query.Encode()
+	}
+	if c.NoCache {
+		request.Header.Set("Cache-Control", "no-cache")
+		request.Header.Set("Pragma", "no-cache")
+	}
+}
@@ -0,0 +1,63 @@
+package cachebusting
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedError error
+	}{
+		{
+			name: "query-parameter",
+			cfg:  &Config{QueryParameter: "_"},
+		},
+		{
+			name: "no-cache",
+			cfg:  &Config{NoCache: true},
+		},
+		{
+			name:          "no-option",
+			cfg:           &Config{},
+			expectedError: ErrNoCacheBustingOption,
+		},
+		{
+			name:          "invalid-query-parameter",
+			cfg:           &Config{QueryParameter: "a=b"},
+			expectedError: ErrInvalidQueryParameter,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Apply(t *testing.T) {
+	cfg := &Config{QueryParameter: "_", NoCache: true}
+	request, _ := http.NewRequest(http.MethodGet, "https://example.org/health?verbose=true", http.NoBody)
+	cfg.Apply(request)
+	firstValue := request.URL.Query().Get("_")
+	if len(firstValue) == 0 || request.URL.Query().Get("verbose") != "true" {
+		t.Errorf("expected the cache-busting query parameter to be added to the existing ones, got %s", request.URL.String())
+	}
+	if request.Header.Get("Cache-Control") != "no-cache" || request.Header.Get("Pragma") != "no-cache" {
+		t.Errorf("expected the no-cache headers to be set, got %v", request.Header)
+	}
+	cfg.Apply(request)
+	if request.URL.Query().Get("_") == firstValue || len(request.URL.Query()["_"]) != 1 {
+		t.Errorf("expected the cache-busting query parameter to be replaced by a new value, got %s", request.URL.String())
+	}
+	request, _ = http.NewRequest(http.MethodGet, "https://example.org/health", http.NoBody)
+	(&Config{NoCache: true}).Apply(request)
+	if len(request.URL.RawQuery) != 0 {
+		t.Errorf("expected no query parameter to be added, got %s", request.URL.String())
+	}
+}
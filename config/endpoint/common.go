@@ -13,6 +13,11 @@ var (
 
 	// ErrEndpointWithInvalidNameOrGroup is the error with which Gatus will panic if an endpoint has an invalid character where it shouldn't
 	ErrEndpointWithInvalidNameOrGroup = errors.New("endpoint name and group must not have \" or \\")
+
+	// ErrEndpointWithSLOBurnRateAlertButNoSLO is the error with which Gatus will panic if an endpoint has an alert
+	// triggered by the burn rate of its service level objective, but no service level objective. Because external
+	// endpoints cannot have a service level objective, this is also the case of every such alert of external endpoints.
+	ErrEndpointWithSLOBurnRateAlertButNoSLO = errors.New("alerts with a trigger-if based on the slo burn rate require the endpoint to have an slo")
 )
 
 // validateEndpointNameGroupAndAlerts validates the name, group and alerts of an endpoint
@@ -30,3 +35,14 @@ func validateEndpointNameGroupAndAlerts(name, group string, alerts []*alert.Aler
 	}
 	return nil
 }
+
+// hasSLOBurnRateAlert returns whether one of the alerts passed is triggered by the burn rate of the error budget of the
+// service level objective of the endpoint
+func hasSLOBurnRateAlert(alerts []*alert.Alert) bool {
+	for _, endpointAlert := range alerts {
+		if endpointAlert.IsTriggeredBySLOBurnRate() {
+			return true
+		}
+	}
+	return false
+}
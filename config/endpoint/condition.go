@@ -3,19 +3,22 @@ package endpoint
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/jsonpath"
 	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/TwiN/gatus/v5/xpath"
 )
 
 // Placeholders
 const (
-	// StatusPlaceholder is a placeholder for a HTTP status.
+	// StatusPlaceholder is a placeholder for a HTTP status, or for the serving status of a gRPC endpoint.
 	//
-	// Values that could replace the placeholder: 200, 404, 500, ...
+	// Values that could replace the placeholder: 200, 404, 500, ..., or SERVING, NOT_SERVING, SERVICE_UNKNOWN, UNKNOWN
 	StatusPlaceholder = "[STATUS]"
 
 	// IPPlaceholder is a placeholder for an IP.
@@ -28,6 +31,23 @@ const (
 	// Values that could replace the placeholder: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
 	DNSRCodePlaceholder = "[DNS_RCODE]"
 
+	// DNSRecordCountPlaceholder is a placeholder for the number of records in the answer to a DNS query
+	//
+	// Values that could replace the placeholder: 0, 1, 4, ...
+	DNSRecordCountPlaceholder = "[DNS_RECORD_COUNT]"
+
+	// DNSMinimumTTLPlaceholder is a placeholder for the lowest TTL of the records in the answer to a DNS query, in
+	// milliseconds.
+	//
+	// Values that could replace the placeholder: 60000 (1 minute), 3600000 (1 hour), ...
+	DNSMinimumTTLPlaceholder = "[DNS_MIN_TTL]"
+
+	// DNSMaximumTTLPlaceholder is a placeholder for the highest TTL of the records in the answer to a DNS query, in
+	// milliseconds.
+	//
+	// Values that could replace the placeholder: 60000 (1 minute), 3600000 (1 hour), ...
+	DNSMaximumTTLPlaceholder = "[DNS_MAX_TTL]"
+
 	// ResponseTimePlaceholder is a placeholder for the request response time, in milliseconds.
 	//
 	// Values that could replace the placeholder: 1, 500, 1000, ...
@@ -48,10 +68,123 @@ const (
 	// Values that could replace the placeholder: 4461677039 (~52 days)
 	CertificateExpirationPlaceholder = "[CERTIFICATE_EXPIRATION]"
 
+	// TLSVersionPlaceholder is a placeholder for the TLS version negotiated with the server.
+	//
+	// Values that could replace the placeholder: 1.2, 1.3
+	TLSVersionPlaceholder = "[TLS_VERSION]"
+
+	// CertificateFingerprintPlaceholder is a placeholder for the SHA-256 fingerprint of the certificate of the server.
+	//
+	// Values that could replace the placeholder: 5E:FF:56:A2:AF:15:88:...
+	CertificateFingerprintPlaceholder = "[CERTIFICATE_FINGERPRINT]"
+
 	// DomainExpirationPlaceholder is a placeholder for the duration before the domain expires, in milliseconds.
 	DomainExpirationPlaceholder = "[DOMAIN_EXPIRATION]"
+
+	// RedirectLocationPlaceholder is a placeholder for the Location header of the last redirect response received.
+	//
+	// Values that could replace the placeholder: /login, https://example.org/login?next=%2F, ...
+	RedirectLocationPlaceholder = "[REDIRECT_LOCATION]"
+
+	// RedirectCountPlaceholder is a placeholder for the number of redirects followed.
+	//
+	// Values that could replace the placeholder: 0, 1, 2, ...
+	RedirectCountPlaceholder = "[REDIRECT_COUNT]"
+
+	// BodySizePlaceholder is a placeholder for the size of the Body of the response, in bytes.
+	//
+	// Unlike BodyPlaceholder, this doesn't require the body to be read if the response has a Content-Length header.
+	//
+	// Values that could replace the placeholder: 0, 512, 1048576, ...
+	BodySizePlaceholder = "[BODY_SIZE]"
+
+	// LastModifiedAgePlaceholder is a placeholder for the duration since the resource was last modified according to
+	// the Last-Modified header of the response, in milliseconds.
+	//
+	// If the response has no valid Last-Modified header, the placeholder is resolved to the largest possible value.
+	//
+	// Values that could replace the placeholder: 60000 (1 minute), 86400000 (1 day), ...
+	LastModifiedAgePlaceholder = "[LAST_MODIFIED_AGE]"
+
+	// TTFBPlaceholder is a placeholder for the time to first byte, which is the duration between the moment the request
+	// was sent and the moment the first byte of the response was received, in milliseconds.
+	//
+	// Requires the endpoint to have download set.
+	//
+	// Values that could replace the placeholder: 15, 120, 850, ...
+	TTFBPlaceholder = "[TTFB]"
+
+	// DownloadSpeedPlaceholder is a placeholder for the speed at which the response body was downloaded, in bytes per
+	// second.
+	//
+	// Requires the endpoint to have download set.
+	//
+	// Values that could replace the placeholder: 524288, 10485760, ...
+	DownloadSpeedPlaceholder = "[DOWNLOAD_SPEED]"
+
+	// BytesReadPlaceholder is a placeholder for the number of bytes of the response body that were downloaded, which
+	// is at most the max-bytes of the download configuration of the endpoint.
+	//
+	// Requires the endpoint to have download set.
+	//
+	// Values that could replace the placeholder: 0, 512, 1048576, ...
+	BytesReadPlaceholder = "[BYTES_READ]"
+
+	// SegmentAgePlaceholder is a placeholder for the duration since the end of the most recent media segment of an HLS
+	// stream, in milliseconds.
+	//
+	// If the time at which the segment ends can't be determined from the #EXT-X-PROGRAM-DATE-TIME tags of the playlist
+	// nor from its Last-Modified header, the placeholder is resolved to the largest possible value.
+	// Requires the endpoint to have hls set.
+	//
+	// Values that could replace the placeholder: 4000, 12000, ...
+	SegmentAgePlaceholder = "[SEGMENT_AGE]"
+
+	// SegmentCountPlaceholder is a placeholder for the number of media segments listed in the playlist of an HLS stream.
+	//
+	// Requires the endpoint to have hls set.
+	//
+	// Values that could replace the placeholder: 0, 3, 6, ...
+	SegmentCountPlaceholder = "[SEGMENT_COUNT]"
+
+	// HeaderPlaceholderPrefix is the prefix of the placeholders for the headers of the response, which are resolved
+	// into the value of the header whose name follows the prefix, or into an empty string if there's no such header.
+	// The name of the header is case-insensitive.
+	//
+	// Usage: [HEADER.X-Cache] == MISS, [HEADER.Age] < 60
+	HeaderPlaceholderPrefix = "[HEADER."
+
+	// EndpointPlaceholderPrefix is the prefix of the placeholders for the state of another endpoint, which are resolved
+	// using the latest result of the endpoint whose key is between the parentheses.
+	//
+	// The supported fields are SUCCESS, STATUS, RESPONSE_TIME (in milliseconds) and AGE (the number of milliseconds
+	// since the latest result). If the endpoint has no result yet, SUCCESS is resolved to false, STATUS to 0, and
+	// RESPONSE_TIME as well as AGE to the largest possible value.
+	//
+	// Usage: [ENDPOINT(core_database).SUCCESS] == true, [ENDPOINT(core_database).AGE] < 120000
+	EndpointPlaceholderPrefix = "[ENDPOINT("
+)
+
+// Fields of the placeholders for the state of another endpoint
+const (
+	endpointPlaceholderSuccessField      = "SUCCESS"
+	endpointPlaceholderStatusField       = "STATUS"
+	endpointPlaceholderResponseTimeField = "RESPONSE_TIME"
+	endpointPlaceholderAgeField          = "AGE"
 )
 
+// LatestResultProvider returns the latest result of the endpoint with the key passed, or nil if it has none
+type LatestResultProvider func(key string) *Result
+
+// latestResultProvider is used to resolve the placeholders for the state of other endpoints
+var latestResultProvider LatestResultProvider
+
+// SetLatestResultProvider sets the function used to retrieve the latest result of the endpoints referenced by
+// conditions through EndpointPlaceholderPrefix
+func SetLatestResultProvider(provider LatestResultProvider) {
+	latestResultProvider = provider
+}
+
 // Functions
 const (
 	// LengthFunctionPrefix is the prefix for the length function
@@ -89,6 +222,9 @@ const (
 	// This is only used for aesthetic purposes; it does not influence whether the condition evaluation results in a
 	// success or a failure
 	maximumLengthBeforeTruncatingWhenComparedWithPattern = 25
+
+	// maximumResolvedValueLength is the maximum length of a resolved value stored in a ConditionResult
+	maximumResolvedValueLength = 256
 )
 
 // Condition is a condition that needs to be met in order for an Endpoint to be considered healthy.
@@ -109,50 +245,68 @@ func (c Condition) evaluate(result *Result, dontResolveFailedConditions bool) bo
 	condition := string(c)
 	success := false
 	conditionToDisplay := condition
+	var operator string
+	var parameters, resolvedParameters []string
 	if strings.Contains(condition, " == ") {
-		parameters, resolvedParameters := sanitizeAndResolve(strings.Split(condition, " == "), result)
-		success = isEqual(resolvedParameters[0], resolvedParameters[1])
-		if !success && !dontResolveFailedConditions {
-			conditionToDisplay = prettify(parameters, resolvedParameters, "==")
+		operator = "=="
+		parameters, resolvedParameters = sanitizeAndResolve(strings.Split(condition, " == "), result)
+		if len(result.DNSRecords) > 1 && strings.ToUpper(parameters[0]) == BodyPlaceholder {
+			// Every record must be equal, otherwise a partial misconfiguration would go unnoticed
+			resolvedParameters[0] = joinDNSRecordValues(result.DNSRecords)
+			success = countDNSRecordsEqualTo(result.DNSRecords, resolvedParameters[1]) == len(result.DNSRecords)
+		} else {
+			success = isEqual(resolvedParameters[0], resolvedParameters[1])
 		}
 	} else if strings.Contains(condition, " != ") {
-		parameters, resolvedParameters := sanitizeAndResolve(strings.Split(condition, " != "), result)
-		success = !isEqual(resolvedParameters[0], resolvedParameters[1])
-		if !success && !dontResolveFailedConditions {
-			conditionToDisplay = prettify(parameters, resolvedParameters, "!=")
+		operator = "!="
+		parameters, resolvedParameters = sanitizeAndResolve(strings.Split(condition, " != "), result)
+		if len(result.DNSRecords) > 1 && strings.ToUpper(parameters[0]) == BodyPlaceholder {
+			// None of the records must be equal
+			resolvedParameters[0] = joinDNSRecordValues(result.DNSRecords)
+			success = countDNSRecordsEqualTo(result.DNSRecords, resolvedParameters[1]) == 0
+		} else {
+			success = !isEqual(resolvedParameters[0], resolvedParameters[1])
 		}
 	} else if strings.Contains(condition, " <= ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " <= "), result)
-		success = resolvedParameters[0] <= resolvedParameters[1]
-		if !success && !dontResolveFailedConditions {
-			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, "<=")
-		}
+		operator = "<="
+		var resolvedNumericalParameters []int64
+		parameters, resolvedNumericalParameters = sanitizeAndResolveNumerical(strings.Split(condition, " <= "), result)
+		success = resolvedNumericalParameters[0] <= resolvedNumericalParameters[1]
+		resolvedParameters = formatNumericalParameters(resolvedNumericalParameters)
 	} else if strings.Contains(condition, " >= ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " >= "), result)
-		success = resolvedParameters[0] >= resolvedParameters[1]
-		if !success && !dontResolveFailedConditions {
-			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, ">=")
-		}
+		operator = ">="
+		var resolvedNumericalParameters []int64
+		parameters, resolvedNumericalParameters = sanitizeAndResolveNumerical(strings.Split(condition, " >= "), result)
+		success = resolvedNumericalParameters[0] >= resolvedNumericalParameters[1]
+		resolvedParameters = formatNumericalParameters(resolvedNumericalParameters)
 	} else if strings.Contains(condition, " > ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " > "), result)
-		success = resolvedParameters[0] > resolvedParameters[1]
-		if !success && !dontResolveFailedConditions {
-			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, ">")
-		}
+		operator = ">"
+		var resolvedNumericalParameters []int64
+		parameters, resolvedNumericalParameters = sanitizeAndResolveNumerical(strings.Split(condition, " > "), result)
+		success = resolvedNumericalParameters[0] > resolvedNumericalParameters[1]
+		resolvedParameters = formatNumericalParameters(resolvedNumericalParameters)
 	} else if strings.Contains(condition, " < ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " < "), result)
-		success = resolvedParameters[0] < resolvedParameters[1]
-		if !success && !dontResolveFailedConditions {
-			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, "<")
-		}
+		operator = "<"
+		var resolvedNumericalParameters []int64
+		parameters, resolvedNumericalParameters = sanitizeAndResolveNumerical(strings.Split(condition, " < "), result)
+		success = resolvedNumericalParameters[0] < resolvedNumericalParameters[1]
+		resolvedParameters = formatNumericalParameters(resolvedNumericalParameters)
 	} else {
 		result.AddError(fmt.Sprintf("invalid condition: %s", condition))
 		return false
 	}
-	if !success {
-		//log.Printf("[Condition.evaluate] Condition '%s' did not succeed because '%s' is false", condition, condition)
+	conditionResult := &ConditionResult{Success: success}
+	if !dontResolveFailedConditions {
+		conditionResult.ResolvedValues = &ResolvedConditionValues{
+			Left:  truncateResolvedValue(resolvedParameters[0]),
+			Right: truncateResolvedValue(resolvedParameters[1]),
+		}
+		if !success {
+			conditionToDisplay = prettify(parameters, resolvedParameters, operator)
+		}
 	}
-	result.ConditionResults = append(result.ConditionResults, &ConditionResult{Condition: conditionToDisplay, Success: success})
+	conditionResult.Condition = conditionToDisplay
+	result.ConditionResults = append(result.ConditionResults, conditionResult)
 	return success
 }
 
@@ -162,12 +316,50 @@ func (c Condition) hasBodyPlaceholder() bool {
 	return strings.Contains(string(c), BodyPlaceholder)
 }
 
+// hasBodySizePlaceholder checks whether the condition has a BodySizePlaceholder
+// Used for determining whether the size of the response body must be computed when it's not known in advance
+func (c Condition) hasBodySizePlaceholder() bool {
+	return strings.Contains(string(c), BodySizePlaceholder)
+}
+
+// hasDownloadPlaceholder checks whether the condition has a TTFBPlaceholder, a DownloadSpeedPlaceholder or a
+// BytesReadPlaceholder
+// Used for determining whether the condition can only be evaluated if the endpoint has download set
+func (c Condition) hasDownloadPlaceholder() bool {
+	condition := strings.ToUpper(string(c))
+	return strings.Contains(condition, TTFBPlaceholder) || strings.Contains(condition, DownloadSpeedPlaceholder) || strings.Contains(condition, BytesReadPlaceholder)
+}
+
+// hasHLSPlaceholder checks whether the condition has a SegmentAgePlaceholder or a SegmentCountPlaceholder
+// Used for determining whether the condition can only be evaluated if the endpoint has hls set
+func (c Condition) hasHLSPlaceholder() bool {
+	condition := strings.ToUpper(string(c))
+	return strings.Contains(condition, SegmentAgePlaceholder) || strings.Contains(condition, SegmentCountPlaceholder)
+}
+
 // hasDomainExpirationPlaceholder checks whether the condition has a DomainExpirationPlaceholder
 // Used for determining whether a whois operation is necessary
 func (c Condition) hasDomainExpirationPlaceholder() bool {
 	return strings.Contains(string(c), DomainExpirationPlaceholder)
 }
 
+// ReferencedEndpointKeys returns the keys of the endpoints whose state is referenced by the condition
+func (c Condition) ReferencedEndpointKeys() []string {
+	var keys []string
+	condition := string(c)
+	for {
+		start := strings.Index(strings.ToUpper(condition), EndpointPlaceholderPrefix)
+		if start == -1 {
+			return keys
+		}
+		condition = condition[start+len(EndpointPlaceholderPrefix):]
+		if end := strings.Index(condition, FunctionSuffix); end != -1 {
+			keys = append(keys, condition[:end])
+			condition = condition[end:]
+		}
+	}
+}
+
 // hasIPPlaceholder checks whether the condition has an IPPlaceholder
 // Used for determining whether an IP lookup is necessary
 func (c Condition) hasIPPlaceholder() bool {
@@ -235,6 +427,38 @@ func isEqual(first, second string) bool {
 	return first == second
 }
 
+// countDNSRecordsEqualTo returns the number of DNS records whose value is equal to the value passed, which supports
+// the same functions as isEqual
+func countDNSRecordsEqualTo(records []client.DNSRecord, value string) int {
+	count := 0
+	for _, record := range records {
+		if isEqual(record.Value, value) {
+			count++
+		}
+	}
+	return count
+}
+
+// joinDNSRecordValues returns the values of the DNS records passed separated by commas
+func joinDNSRecordValues(records []client.DNSRecord) string {
+	values := make([]string, len(records))
+	for i, record := range records {
+		values[i] = record.Value
+	}
+	return strings.Join(values, ", ")
+}
+
+// getDNSRecordTTLBound returns the lowest TTL of the DNS records passed if lowest is true, or the highest TTL otherwise
+func getDNSRecordTTLBound(records []client.DNSRecord, lowest bool) time.Duration {
+	var ttl time.Duration
+	for i, record := range records {
+		if i == 0 || (lowest && record.TTL < ttl) || (!lowest && record.TTL > ttl) {
+			ttl = record.TTL
+		}
+	}
+	return ttl
+}
+
 // sanitizeAndResolve sanitizes and resolves a list of elements and returns the list of parameters as well as a list
 // of resolved parameters
 func sanitizeAndResolve(elements []string, result *Result) ([]string, []string) {
@@ -246,7 +470,12 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 		parameters[i] = element
 		switch strings.ToUpper(element) {
 		case StatusPlaceholder:
-			element = strconv.Itoa(result.HTTPStatus)
+			if len(result.GRPCHealthStatus) > 0 {
+				// The status of gRPC endpoints is the serving status reported by the server
+				element = result.GRPCHealthStatus
+			} else {
+				element = strconv.Itoa(result.HTTPStatus)
+			}
 		case IPPlaceholder:
 			element = result.IP
 		case ResponseTimePlaceholder:
@@ -255,15 +484,55 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 			element = body
 		case DNSRCodePlaceholder:
 			element = result.DNSRCode
+		case DNSRecordCountPlaceholder:
+			element = strconv.Itoa(len(result.DNSRecords))
+		case DNSMinimumTTLPlaceholder:
+			element = strconv.FormatInt(getDNSRecordTTLBound(result.DNSRecords, true).Milliseconds(), 10)
+		case DNSMaximumTTLPlaceholder:
+			element = strconv.FormatInt(getDNSRecordTTLBound(result.DNSRecords, false).Milliseconds(), 10)
 		case ConnectedPlaceholder:
 			element = strconv.FormatBool(result.Connected)
 		case CertificateExpirationPlaceholder:
 			element = strconv.FormatInt(result.CertificateExpiration.Milliseconds(), 10)
+		case TLSVersionPlaceholder:
+			element = result.TLSVersion
+		case CertificateFingerprintPlaceholder:
+			element = result.CertificateFingerprint
 		case DomainExpirationPlaceholder:
 			element = strconv.FormatInt(result.DomainExpiration.Milliseconds(), 10)
+		case RedirectLocationPlaceholder:
+			element = result.RedirectLocation
+		case RedirectCountPlaceholder:
+			element = strconv.Itoa(result.RedirectCount)
+		case BodySizePlaceholder:
+			element = strconv.FormatInt(result.BodySize, 10)
+		case TTFBPlaceholder:
+			element = strconv.FormatInt(result.TTFB.Milliseconds(), 10)
+		case DownloadSpeedPlaceholder:
+			element = strconv.FormatInt(result.DownloadSpeed, 10)
+		case BytesReadPlaceholder:
+			element = strconv.FormatInt(result.BytesRead, 10)
+		case LastModifiedAgePlaceholder:
+			if result.LastModified.IsZero() {
+				element = strconv.FormatInt(math.MaxInt64, 10)
+			} else {
+				element = strconv.FormatInt(time.Since(result.LastModified).Milliseconds(), 10)
+			}
+		case SegmentAgePlaceholder:
+			if result.LatestSegmentAt.IsZero() {
+				element = strconv.FormatInt(math.MaxInt64, 10)
+			} else {
+				element = strconv.FormatInt(time.Since(result.LatestSegmentAt).Milliseconds(), 10)
+			}
+		case SegmentCountPlaceholder:
+			element = strconv.Itoa(result.SegmentCount)
 		default:
-			// if contains the BodyPlaceholder, then evaluate json path
-			if strings.Contains(element, BodyPlaceholder) {
+			if strings.HasPrefix(strings.ToUpper(element), EndpointPlaceholderPrefix) {
+				element = resolveEndpointPlaceholder(element, result)
+			} else if strings.HasPrefix(strings.ToUpper(element), HeaderPlaceholderPrefix) && strings.HasSuffix(element, "]") {
+				element = result.Headers.Get(element[len(HeaderPlaceholderPrefix) : len(element)-1])
+			} else if strings.Contains(element, BodyPlaceholder) {
+				// if contains the BodyPlaceholder, then evaluate json path, or xpath if the path starts with a slash
 				checkingForLength := false
 				checkingForExistence := false
 				if strings.HasPrefix(element, LengthFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
@@ -274,7 +543,14 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 					checkingForExistence = true
 					element = strings.TrimSuffix(strings.TrimPrefix(element, HasFunctionPrefix), FunctionSuffix)
 				}
-				resolvedElement, resolvedElementLength, err := jsonpath.Eval(strings.TrimPrefix(strings.TrimPrefix(element, BodyPlaceholder), "."), result.Body)
+				var resolvedElement string
+				var resolvedElementLength int
+				var err error
+				if path := strings.TrimPrefix(element, BodyPlaceholder); strings.HasPrefix(path, "/") {
+					resolvedElement, resolvedElementLength, err = xpath.Eval(path, result.Body)
+				} else {
+					resolvedElement, resolvedElementLength, err = jsonpath.Eval(strings.TrimPrefix(path, "."), result.Body)
+				}
 				if checkingForExistence {
 					if err != nil {
 						element = "false"
@@ -283,7 +559,7 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 					}
 				} else {
 					if err != nil {
-						if err.Error() != "unexpected end of JSON input" {
+						if err.Error() != "unexpected end of JSON input" && !errors.Is(err, xpath.ErrEmptyDocument) {
 							result.AddError(err.Error())
 						}
 						if checkingForLength {
@@ -306,6 +582,44 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 	return parameters, resolvedParameters
 }
 
+// resolveEndpointPlaceholder resolves a placeholder for the state of another endpoint, such as
+// [ENDPOINT(core_database).SUCCESS], using the latest result of that endpoint
+func resolveEndpointPlaceholder(element string, result *Result) string {
+	keyAndField := strings.TrimSuffix(element[len(EndpointPlaceholderPrefix):], "]")
+	separatorIndex := strings.LastIndex(keyAndField, FunctionSuffix+".")
+	if !strings.HasSuffix(element, "]") || separatorIndex <= 0 {
+		result.AddError(fmt.Sprintf("invalid endpoint placeholder: %s", element))
+		return element + " " + InvalidConditionElementSuffix
+	}
+	key, field := keyAndField[:separatorIndex], strings.ToUpper(keyAndField[separatorIndex+len(FunctionSuffix+"."):])
+	var latestResult *Result
+	if latestResultProvider != nil {
+		latestResult = latestResultProvider(key)
+	}
+	switch field {
+	case endpointPlaceholderSuccessField:
+		return strconv.FormatBool(latestResult != nil && latestResult.Success)
+	case endpointPlaceholderStatusField:
+		if latestResult == nil {
+			return "0"
+		}
+		return strconv.Itoa(latestResult.HTTPStatus)
+	case endpointPlaceholderResponseTimeField:
+		if latestResult == nil {
+			return strconv.FormatInt(math.MaxInt64, 10)
+		}
+		return strconv.FormatInt(latestResult.Duration.Milliseconds(), 10)
+	case endpointPlaceholderAgeField:
+		if latestResult == nil {
+			return strconv.FormatInt(math.MaxInt64, 10)
+		}
+		return strconv.FormatInt(time.Since(latestResult.Timestamp).Milliseconds(), 10)
+	default:
+		result.AddError(fmt.Sprintf("invalid endpoint placeholder field: %s", field))
+		return element + " " + InvalidConditionElementSuffix
+	}
+}
+
 func sanitizeAndResolveNumerical(list []string, result *Result) (parameters []string, resolvedNumericalParameters []int64) {
 	parameters, resolvedParameters := sanitizeAndResolve(list, result)
 	for _, element := range resolvedParameters {
@@ -329,8 +643,17 @@ func sanitizeAndResolveNumerical(list []string, result *Result) (parameters []st
 	return parameters, resolvedNumericalParameters
 }
 
-func prettifyNumericalParameters(parameters []string, resolvedParameters []int64, operator string) string {
-	return prettify(parameters, []string{strconv.Itoa(int(resolvedParameters[0])), strconv.Itoa(int(resolvedParameters[1]))}, operator)
+func formatNumericalParameters(resolvedParameters []int64) []string {
+	return []string{strconv.Itoa(int(resolvedParameters[0])), strconv.Itoa(int(resolvedParameters[1]))}
+}
+
+// truncateResolvedValue truncates a resolved value if it is too long to be stored, which can happen when the value
+// comes from the body of the response
+func truncateResolvedValue(value string) string {
+	if len(value) > maximumResolvedValueLength {
+		return value[:maximumResolvedValueLength] + "...(truncated)"
+	}
+	return value
 }
 
 // prettify returns a string representation of a condition with its parameters resolved between parentheses
@@ -7,4 +7,21 @@ type ConditionResult struct {
 
 	// Success whether the condition was met (successful) or not (failed)
 	Success bool `json:"success"`
+
+	// ResolvedValues are the values that each side of the condition resolved to.
+	//
+	// Nil if the endpoint's ui.dont-resolve-failed-conditions is true, or if the result was stored before resolved
+	// values were persisted.
+	ResolvedValues *ResolvedConditionValues `json:"resolvedValues,omitempty"`
+}
+
+// ResolvedConditionValues are the values that each side of a Condition resolved to
+//
+// For instance, the values of the condition [RESPONSE_TIME] < 1000 could be 1532 and 1000.
+type ResolvedConditionValues struct {
+	// Left is the value that the left side of the condition resolved to
+	Left string `json:"left"`
+
+	// Right is the value that the right side of the condition resolved to
+	Right string `json:"right"`
 }
@@ -3,9 +3,13 @@ package endpoint
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/TwiN/gatus/v5/client"
 )
 
 func TestCondition_Validate(t *testing.T) {
@@ -36,6 +40,10 @@ func TestCondition_Validate(t *testing.T) {
 		{condition: "[BODY].name == pat(john*)", expectedErr: nil},
 		{condition: "[CERTIFICATE_EXPIRATION] > 48h", expectedErr: nil},
 		{condition: "[DOMAIN_EXPIRATION] > 720h", expectedErr: nil},
+		{condition: "[ENDPOINT(core_database).SUCCESS] == true", expectedErr: nil},
+		{condition: "[ENDPOINT(core_database).AGE] < 120000", expectedErr: nil},
+		{condition: "[ENDPOINT(core_database).UPTIME] == 1", expectedErr: errors.New("invalid endpoint placeholder field: UPTIME")},
+		{condition: "[ENDPOINT(core_database)] == true", expectedErr: errors.New("invalid endpoint placeholder: [ENDPOINT(core_database)]")},
 		{condition: "raw == raw", expectedErr: nil},
 		{condition: "[STATUS] ? 201", expectedErr: errors.New("invalid condition: [STATUS] ? 201")},
 		{condition: "[STATUS]==201", expectedErr: errors.New("invalid condition: [STATUS]==201")},
@@ -238,6 +246,41 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "len([BODY].data.name) (INVALID) == john",
 		},
+		{
+			Name:            "body-xpath",
+			Condition:       Condition("[BODY]//GetStatusResult/Status == UP"),
+			Result:          &Result{Body: []byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetStatusResult><Status>UP</Status></GetStatusResult></soap:Body></soap:Envelope>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY]//GetStatusResult/Status == UP",
+		},
+		{
+			Name:            "body-xpath-absolute-with-attribute",
+			Condition:       Condition("[BODY]/soap:Envelope/soap:Body/Service/@state == running"),
+			Result:          &Result{Body: []byte(`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><Service state="running"/></soap:Body></soap:Envelope>`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY]/soap:Envelope/soap:Body/Service/@state == running",
+		},
+		{
+			Name:            "body-xpath-len",
+			Condition:       Condition("len([BODY]//Item) == 3"),
+			Result:          &Result{Body: []byte("<Items><Item>a</Item><Item>b</Item><Item>c</Item></Items>")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "len([BODY]//Item) == 3",
+		},
+		{
+			Name:            "body-xpath-has",
+			Condition:       Condition("has([BODY]//Fault) == false"),
+			Result:          &Result{Body: []byte("<Envelope><Body><Status>UP</Status></Body></Envelope>")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "has([BODY]//Fault) == false",
+		},
+		{
+			Name:            "body-xpath-invalid",
+			Condition:       Condition("[BODY]//Status == UP"),
+			Result:          &Result{Body: []byte("<Envelope><Body><Fault>Server</Fault></Body></Envelope>")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY]//Status (INVALID) == UP",
+		},
 		{
 			Name:            "body-jsonpath-double-placeholder",
 			Condition:       Condition("[BODY].user.firstName != [BODY].user.lastName"),
@@ -490,6 +533,216 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[CERTIFICATE_EXPIRATION] (86400000) > 48h (172800000)",
 		},
+		{
+			Name:            "redirect-location",
+			Condition:       Condition("[REDIRECT_LOCATION] == pat(*/login*)"),
+			Result:          &Result{RedirectLocation: "https://example.org/login?next=%2F"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[REDIRECT_LOCATION] == pat(*/login*)",
+		},
+		{
+			Name:            "redirect-location-failure",
+			Condition:       Condition("[REDIRECT_LOCATION] == /login"),
+			Result:          &Result{},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[REDIRECT_LOCATION] () == /login",
+		},
+		{
+			Name:            "redirect-count",
+			Condition:       Condition("[REDIRECT_COUNT] <= 2"),
+			Result:          &Result{RedirectCount: 2},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[REDIRECT_COUNT] <= 2",
+		},
+		{
+			Name:            "redirect-count-failure",
+			Condition:       Condition("[REDIRECT_COUNT] == 0"),
+			Result:          &Result{RedirectCount: 1},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[REDIRECT_COUNT] (1) == 0",
+		},
+		{
+			Name:            "body-size",
+			Condition:       Condition("[BODY_SIZE] > 1024"),
+			Result:          &Result{BodySize: 4096},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_SIZE] > 1024",
+		},
+		{
+			Name:            "body-size-failure",
+			Condition:       Condition("[BODY_SIZE] > 1024"),
+			Result:          &Result{BodySize: 0},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_SIZE] (0) > 1024",
+		},
+		{
+			Name:            "grpc-status",
+			Condition:       Condition("[STATUS] == SERVING"),
+			Result:          &Result{GRPCHealthStatus: "SERVING"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[STATUS] == SERVING",
+		},
+		{
+			Name:            "grpc-status-failure",
+			Condition:       Condition("[STATUS] == SERVING"),
+			Result:          &Result{GRPCHealthStatus: "NOT_SERVING"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[STATUS] (NOT_SERVING) == SERVING",
+		},
+		{
+			Name:            "header",
+			Condition:       Condition("[HEADER.x-cache] == MISS"),
+			Result:          &Result{Headers: http.Header{"X-Cache": []string{"MISS"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[HEADER.x-cache] == MISS",
+		},
+		{
+			Name:            "header-failure",
+			Condition:       Condition("[HEADER.X-Cache] == MISS"),
+			Result:          &Result{Headers: http.Header{"X-Cache": []string{"HIT"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[HEADER.X-Cache] (HIT) == MISS",
+		},
+		{
+			Name:            "header-numerical",
+			Condition:       Condition("[HEADER.Age] < 60"),
+			Result:          &Result{Headers: http.Header{"Age": []string{"120"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[HEADER.Age] (120) < 60",
+		},
+		{
+			Name:            "header-missing",
+			Condition:       Condition("[HEADER.Age] < 60"),
+			Result:          &Result{},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[HEADER.Age] < 60",
+		},
+		{
+			Name:            "ttfb",
+			Condition:       Condition("[TTFB] < 200"),
+			Result:          &Result{TTFB: 50 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[TTFB] < 200",
+		},
+		{
+			Name:            "download-speed-failure",
+			Condition:       Condition("[DOWNLOAD_SPEED] > 1048576"),
+			Result:          &Result{DownloadSpeed: 524288},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DOWNLOAD_SPEED] (524288) > 1048576",
+		},
+		{
+			Name:            "bytes-read",
+			Condition:       Condition("[BYTES_READ] == 1024"),
+			Result:          &Result{BytesRead: 1024},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BYTES_READ] == 1024",
+		},
+		{
+			Name:            "last-modified-age",
+			Condition:       Condition("[LAST_MODIFIED_AGE] < 24h"),
+			Result:          &Result{LastModified: time.Now().Add(-time.Hour)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[LAST_MODIFIED_AGE] < 24h",
+		},
+		{
+			Name:            "last-modified-age-without-last-modified",
+			Condition:       Condition("[LAST_MODIFIED_AGE] < 24h"),
+			Result:          &Result{},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[LAST_MODIFIED_AGE] (9223372036854775807) < 24h (86400000)",
+		},
+		{
+			Name:            "tls-version",
+			Condition:       Condition("[TLS_VERSION] == any(1.2, 1.3)"),
+			Result:          &Result{TLSVersion: "1.3"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[TLS_VERSION] == any(1.2, 1.3)",
+		},
+		{
+			Name:            "tls-version-failure",
+			Condition:       Condition("[TLS_VERSION] == 1.3"),
+			Result:          &Result{TLSVersion: "1.2"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[TLS_VERSION] (1.2) == 1.3",
+		},
+		{
+			Name:            "certificate-fingerprint",
+			Condition:       Condition("[CERTIFICATE_FINGERPRINT] == 5E:FF:56:A2"),
+			Result:          &Result{CertificateFingerprint: "5E:FF:56:A2"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_FINGERPRINT] == 5E:FF:56:A2",
+		},
+		{
+			Name:            "certificate-fingerprint-failure",
+			Condition:       Condition("[CERTIFICATE_FINGERPRINT] == 5E:FF:56:A2"),
+			Result:          &Result{CertificateFingerprint: "AB:CD:EF:01"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CERTIFICATE_FINGERPRINT] (AB:CD:EF:01) == 5E:FF:56:A2",
+		},
+		{
+			Name:            "dns-record-set",
+			Condition:       Condition("[BODY] == any(1.2.3.4, 1.2.3.5)"),
+			Result:          &Result{Body: []byte("1.2.3.5"), DNSRecords: []client.DNSRecord{{Value: "1.2.3.4"}, {Value: "1.2.3.5"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == any(1.2.3.4, 1.2.3.5)",
+		},
+		{
+			Name:            "dns-record-set-failure",
+			Condition:       Condition("[BODY] == any(1.2.3.4, 1.2.3.5)"),
+			Result:          &Result{Body: []byte("1.2.3.5"), DNSRecords: []client.DNSRecord{{Value: "1.2.3.4"}, {Value: "6.6.6.6"}, {Value: "1.2.3.5"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (1.2.3.4, 6.6.6.6, 1.2.3.5) == any(1.2.3.4, 1.2.3.5)",
+		},
+		{
+			Name:            "dns-record-set-not-equal",
+			Condition:       Condition("[BODY] != 0.0.0.0"),
+			Result:          &Result{Body: []byte("1.2.3.5"), DNSRecords: []client.DNSRecord{{Value: "1.2.3.4"}, {Value: "1.2.3.5"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] != 0.0.0.0",
+		},
+		{
+			Name:            "dns-record-set-not-equal-failure",
+			Condition:       Condition("[BODY] != 0.0.0.0"),
+			Result:          &Result{Body: []byte("1.2.3.5"), DNSRecords: []client.DNSRecord{{Value: "0.0.0.0"}, {Value: "1.2.3.5"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY] (0.0.0.0, 1.2.3.5) != 0.0.0.0",
+		},
+		{
+			Name:            "dns-record-count",
+			Condition:       Condition("[DNS_RECORD_COUNT] == 2"),
+			Result:          &Result{DNSRecords: []client.DNSRecord{{Value: "1.2.3.4"}, {Value: "1.2.3.5"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[DNS_RECORD_COUNT] == 2",
+		},
+		{
+			Name:            "dns-record-count-failure",
+			Condition:       Condition("[DNS_RECORD_COUNT] >= 2"),
+			Result:          &Result{DNSRecords: []client.DNSRecord{{Value: "1.2.3.4"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DNS_RECORD_COUNT] (1) >= 2",
+		},
+		{
+			Name:            "dns-min-ttl",
+			Condition:       Condition("[DNS_MIN_TTL] >= 5m"),
+			Result:          &Result{DNSRecords: []client.DNSRecord{{Value: "1.2.3.4", TTL: time.Hour}, {Value: "1.2.3.5", TTL: 10 * time.Minute}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[DNS_MIN_TTL] >= 5m",
+		},
+		{
+			Name:            "dns-min-ttl-failure",
+			Condition:       Condition("[DNS_MIN_TTL] >= 5m"),
+			Result:          &Result{DNSRecords: []client.DNSRecord{{Value: "1.2.3.4", TTL: time.Hour}, {Value: "1.2.3.5", TTL: time.Minute}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DNS_MIN_TTL] (60000) >= 5m (300000)",
+		},
+		{
+			Name:            "dns-max-ttl-failure",
+			Condition:       Condition("[DNS_MAX_TTL] <= 1h"),
+			Result:          &Result{DNSRecords: []client.DNSRecord{{Value: "1.2.3.4", TTL: 2 * time.Hour}, {Value: "1.2.3.5", TTL: time.Minute}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DNS_MAX_TTL] (7200000) <= 1h (3600000)",
+		},
 		{
 			Name:            "no-placeholders",
 			Condition:       Condition("1 == 2"),
@@ -766,6 +1019,105 @@ func TestCondition_evaluate(t *testing.T) {
 	}
 }
 
+func TestCondition_evaluateWithEndpointPlaceholders(t *testing.T) {
+	defer SetLatestResultProvider(nil)
+	SetLatestResultProvider(func(key string) *Result {
+		switch key {
+		case "core_database":
+			return &Result{Success: true, HTTPStatus: 200, Duration: 50 * time.Millisecond, Timestamp: time.Now().Add(-time.Minute)}
+		case "core_cache":
+			return &Result{Success: false, HTTPStatus: 503, Duration: 5 * time.Second, Timestamp: time.Now()}
+		}
+		return nil
+	})
+	scenarios := []struct {
+		Name            string
+		Condition       Condition
+		ExpectedSuccess bool
+		ExpectedOutput  string
+	}{
+		{
+			Name:            "success",
+			Condition:       Condition("[ENDPOINT(core_database).SUCCESS] == true"),
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[ENDPOINT(core_database).SUCCESS] == true",
+		},
+		{
+			Name:            "success-failure",
+			Condition:       Condition("[ENDPOINT(core_cache).SUCCESS] == true"),
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[ENDPOINT(core_cache).SUCCESS] (false) == true",
+		},
+		{
+			Name:            "status",
+			Condition:       Condition("[ENDPOINT(core_cache).STATUS] == 503"),
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[ENDPOINT(core_cache).STATUS] == 503",
+		},
+		{
+			Name:            "response-time",
+			Condition:       Condition("[ENDPOINT(core_database).RESPONSE_TIME] < 100"),
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[ENDPOINT(core_database).RESPONSE_TIME] < 100",
+		},
+		{
+			Name:            "age",
+			Condition:       Condition("[ENDPOINT(core_database).AGE] < 30000"),
+			ExpectedSuccess: false,
+		},
+		{
+			Name:            "lowercase-field",
+			Condition:       Condition("[endpoint(core_database).success] == true"),
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[endpoint(core_database).success] == true",
+		},
+		{
+			Name:            "endpoint-without-result",
+			Condition:       Condition("[ENDPOINT(core_unknown).SUCCESS] == true"),
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[ENDPOINT(core_unknown).SUCCESS] (false) == true",
+		},
+		{
+			Name:            "endpoint-without-result-response-time",
+			Condition:       Condition("[ENDPOINT(core_unknown).RESPONSE_TIME] < 100"),
+			ExpectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			result := &Result{}
+			scenario.Condition.evaluate(result, false)
+			if result.ConditionResults[0].Success != scenario.ExpectedSuccess {
+				t.Errorf("Condition '%s' should have been success=%v", scenario.Condition, scenario.ExpectedSuccess)
+			}
+			if len(scenario.ExpectedOutput) > 0 && result.ConditionResults[0].Condition != scenario.ExpectedOutput {
+				t.Errorf("Condition '%s' should have resolved to '%s', got '%s'", scenario.Condition, scenario.ExpectedOutput, result.ConditionResults[0].Condition)
+			}
+			if len(result.Errors) != 0 {
+				t.Errorf("expected no errors, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestCondition_ReferencedEndpointKeys(t *testing.T) {
+	scenarios := []struct {
+		Condition    Condition
+		ExpectedKeys []string
+	}{
+		{Condition: "[STATUS] == 200", ExpectedKeys: nil},
+		{Condition: "[ENDPOINT(core_database).SUCCESS] == true", ExpectedKeys: []string{"core_database"}},
+		{Condition: "[ENDPOINT(core_database).STATUS] == [ENDPOINT(core_cache).STATUS]", ExpectedKeys: []string{"core_database", "core_cache"}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(string(scenario.Condition), func(t *testing.T) {
+			if keys := scenario.Condition.ReferencedEndpointKeys(); fmt.Sprint(keys) != fmt.Sprint(scenario.ExpectedKeys) {
+				t.Errorf("expected keys %v, got %v", scenario.ExpectedKeys, keys)
+			}
+		})
+	}
+}
+
 func TestCondition_evaluateWithInvalidOperator(t *testing.T) {
 	condition := Condition("[STATUS] ? 201")
 	result := &Result{HTTPStatus: 201}
@@ -777,3 +1129,63 @@ func TestCondition_evaluateWithInvalidOperator(t *testing.T) {
 		t.Error("condition was invalid, result should've had an error")
 	}
 }
+
+func TestCondition_evaluateResolvedValues(t *testing.T) {
+	scenarios := []struct {
+		Name                        string
+		Condition                   Condition
+		Result                      *Result
+		DontResolveFailedConditions bool
+		ExpectedResolvedValues      *ResolvedConditionValues
+	}{
+		{
+			Name:                   "numerical-failure",
+			Condition:              Condition("[RESPONSE_TIME] < 1000"),
+			Result:                 &Result{Duration: 1532 * time.Millisecond},
+			ExpectedResolvedValues: &ResolvedConditionValues{Left: "1532", Right: "1000"},
+		},
+		{
+			Name:                   "duration-success",
+			Condition:              Condition("[CERTIFICATE_EXPIRATION] > 48h"),
+			Result:                 &Result{CertificateExpiration: 72 * time.Hour},
+			ExpectedResolvedValues: &ResolvedConditionValues{Left: "259200000", Right: "172800000"},
+		},
+		{
+			Name:                   "equality-success",
+			Condition:              Condition("[STATUS] == 200"),
+			Result:                 &Result{HTTPStatus: 200},
+			ExpectedResolvedValues: &ResolvedConditionValues{Left: "200", Right: "200"},
+		},
+		{
+			Name:                   "body-truncated",
+			Condition:              Condition("[BODY] == pat(*john*)"),
+			Result:                 &Result{Body: []byte(strings.Repeat("a", 300))},
+			ExpectedResolvedValues: &ResolvedConditionValues{Left: strings.Repeat("a", 256) + "...(truncated)", Right: "pat(*john*)"},
+		},
+		{
+			Name:                        "dont-resolve-failed-conditions",
+			Condition:                   Condition("[STATUS] == 200"),
+			Result:                      &Result{HTTPStatus: 500},
+			DontResolveFailedConditions: true,
+			ExpectedResolvedValues:      nil,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			scenario.Condition.evaluate(scenario.Result, scenario.DontResolveFailedConditions)
+			resolvedValues := scenario.Result.ConditionResults[0].ResolvedValues
+			if scenario.ExpectedResolvedValues == nil {
+				if resolvedValues != nil {
+					t.Errorf("expected no resolved values, got %+v", resolvedValues)
+				}
+				return
+			}
+			if resolvedValues == nil {
+				t.Fatalf("expected resolved values %+v, got nil", scenario.ExpectedResolvedValues)
+			}
+			if *resolvedValues != *scenario.ExpectedResolvedValues {
+				t.Errorf("expected resolved values %+v, got %+v", scenario.ExpectedResolvedValues, resolvedValues)
+			}
+		})
+	}
+}
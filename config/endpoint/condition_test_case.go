@@ -0,0 +1,102 @@
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ExpectedOutcomePass means that the conditions of the endpoint are expected to pass with the sample result
+	ExpectedOutcomePass = "pass"
+
+	// ExpectedOutcomeFail means that at least one of the conditions of the endpoint is expected to fail with the
+	// sample result
+	ExpectedOutcomeFail = "fail"
+)
+
+var (
+	// ErrConditionTestCaseWithNoName is the error with which Gatus will panic if a test of an endpoint has no name
+	ErrConditionTestCaseWithNoName = errors.New("endpoint test must have a name")
+
+	// ErrConditionTestCaseWithInvalidExpectedOutcome is the error with which Gatus will panic if the expected outcome
+	// of a test of an endpoint is neither pass nor fail
+	ErrConditionTestCaseWithInvalidExpectedOutcome = errors.New("endpoint test expected-outcome must be either " + ExpectedOutcomePass + " or " + ExpectedOutcomeFail)
+)
+
+// ConditionTestCase is a sample result of an endpoint along with whether the conditions of the endpoint are expected
+// to pass with it, which allows testing conditions without sending a single request
+type ConditionTestCase struct {
+	// Name of the test
+	Name string `yaml:"name"`
+
+	// Status is the HTTP status of the sample result
+	Status int `yaml:"status,omitempty"`
+
+	// Body is the body of the sample result
+	Body string `yaml:"body,omitempty"`
+
+	// ResponseTime is the response time of the sample result
+	ResponseTime time.Duration `yaml:"response-time,omitempty"`
+
+	// Connected is whether a connection was established for the sample result. Defaults to true.
+	Connected *bool `yaml:"connected,omitempty"`
+
+	// ExpectedOutcome is whether the conditions are expected to pass or fail with the sample result
+	ExpectedOutcome string `yaml:"expected-outcome"`
+}
+
+// ValidateAndSetDefaults validates the test and sets the default values if necessary
+func (t *ConditionTestCase) ValidateAndSetDefaults() error {
+	if len(t.Name) == 0 {
+		return ErrConditionTestCaseWithNoName
+	}
+	if t.ExpectedOutcome != ExpectedOutcomePass && t.ExpectedOutcome != ExpectedOutcomeFail {
+		return fmt.Errorf("%w: test %s has expected-outcome %q", ErrConditionTestCaseWithInvalidExpectedOutcome, t.Name, t.ExpectedOutcome)
+	}
+	if t.Connected == nil {
+		connected := true
+		t.Connected = &connected
+	}
+	return nil
+}
+
+// result returns the sample result of the test
+func (t *ConditionTestCase) result() *Result {
+	return &Result{
+		HTTPStatus: t.Status,
+		Body:       []byte(t.Body),
+		BodySize:   int64(len(t.Body)),
+		Duration:   t.ResponseTime,
+		Connected:  t.Connected == nil || *t.Connected,
+		Success:    true,
+	}
+}
+
+// RunTests evaluates the conditions of the endpoint against the sample result of each of its tests and returns an
+// error describing every test whose outcome was not the expected one, or nil if every test had the expected outcome
+func (e *Endpoint) RunTests() error {
+	var errs []error
+	for _, t := range e.Tests {
+		result := t.result()
+		for _, condition := range e.Conditions {
+			if !condition.evaluate(result, false) {
+				result.Success = false
+			}
+		}
+		if result.Success == (t.ExpectedOutcome == ExpectedOutcomePass) {
+			continue
+		}
+		var conditionResults []string
+		for _, conditionResult := range result.ConditionResults {
+			if conditionResult.Success {
+				conditionResults = append(conditionResults, conditionResult.Condition+" (passed)")
+			} else {
+				conditionResults = append(conditionResults, conditionResult.Condition+" (failed)")
+			}
+		}
+		errs = append(errs, fmt.Errorf("test %s of endpoint %s was expected to %s: %s", t.Name, e.Key(), t.ExpectedOutcome, strings.Join(conditionResults, ", ")))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,80 @@
+package endpoint
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConditionTestCase_ValidateAndSetDefaults(t *testing.T) {
+	if err := (&ConditionTestCase{ExpectedOutcome: ExpectedOutcomePass}).ValidateAndSetDefaults(); err != ErrConditionTestCaseWithNoName {
+		t.Errorf("expected error %v, got %v", ErrConditionTestCaseWithNoName, err)
+	}
+	if err := (&ConditionTestCase{Name: "healthy", ExpectedOutcome: "success"}).ValidateAndSetDefaults(); !errors.Is(err, ErrConditionTestCaseWithInvalidExpectedOutcome) {
+		t.Errorf("expected error %v, got %v", ErrConditionTestCaseWithInvalidExpectedOutcome, err)
+	}
+	testCase := &ConditionTestCase{Name: "healthy", ExpectedOutcome: ExpectedOutcomeFail}
+	if err := testCase.ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if testCase.Connected == nil || !*testCase.Connected {
+		t.Error("expected connected to default to true")
+	}
+}
+
+func TestEndpoint_RunTests(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		tests         []*ConditionTestCase
+		expectedError string
+	}{
+		{
+			name: "expected-outcomes",
+			tests: []*ConditionTestCase{
+				{Name: "healthy", Status: 200, Body: `{"status":"UP"}`, ExpectedOutcome: ExpectedOutcomePass},
+				{Name: "degraded", Status: 200, Body: `{"status":"DEGRADED"}`, ExpectedOutcome: ExpectedOutcomeFail},
+				{Name: "server-error", Status: 500, ExpectedOutcome: ExpectedOutcomeFail},
+				{Name: "slow", Status: 200, Body: `{"status":"UP"}`, ResponseTime: 2 * time.Second, ExpectedOutcome: ExpectedOutcomeFail},
+			},
+		},
+		{
+			name: "unexpected-failure",
+			tests: []*ConditionTestCase{
+				{Name: "degraded", Status: 200, Body: `{"status":"DEGRADED"}`, ExpectedOutcome: ExpectedOutcomePass},
+			},
+			expectedError: "test degraded of endpoint group_api was expected to pass: [STATUS] == 200 (passed), [BODY].status (DEGRADED) == UP (failed), [RESPONSE_TIME] < 1000 (passed)",
+		},
+		{
+			name: "unexpected-success",
+			tests: []*ConditionTestCase{
+				{Name: "healthy", Status: 200, Body: `{"status":"UP"}`, ExpectedOutcome: ExpectedOutcomeFail},
+			},
+			expectedError: "test healthy of endpoint group_api was expected to fail",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ep := &Endpoint{
+				Name:       "api",
+				Group:      "group",
+				URL:        "https://example.org/health",
+				Conditions: []Condition{"[STATUS] == 200", "[BODY].status == UP", "[RESPONSE_TIME] < 1000"},
+				Tests:      scenario.tests,
+			}
+			if err := ep.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			err := ep.RunTests()
+			if len(scenario.expectedError) == 0 {
+				if err != nil {
+					t.Error("expected no error, got", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), scenario.expectedError) {
+				t.Errorf("expected error starting with %q, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
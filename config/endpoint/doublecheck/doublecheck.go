@@ -0,0 +1,71 @@
+package doublecheck
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+var (
+	// ErrNoAlternateRoute is the error with which Gatus will panic if none of the parameters describing the alternate
+	// route is set
+	ErrNoAlternateRoute = errors.New("double-check must define at least one of dns-resolver, source-address or proxy-url")
+
+	// ErrInvalidProxyURL is the error with which Gatus will panic if proxy-url isn't an absolute URL
+	ErrInvalidProxyURL = errors.New("double-check proxy-url must be an absolute url (e.g. http://proxy.example.com:3128)")
+)
+
+// Config is the configuration for double-checking the failures of an endpoint.Endpoint through an alternate route
+// before they're persisted, which prevents the problems of the network of the host on which Gatus runs from being
+// reported as failures of the endpoint.
+//
+// Each parameter that is set overrides the corresponding parameter of the client of the endpoint for the double check.
+type Config struct {
+	// DNSResolver is the DNS resolver to use for the double check (e.g. tcp://1.1.1.1:53)
+	DNSResolver string `yaml:"dns-resolver,omitempty"`
+
+	// SourceAddress is the IP address the connections of the double check are bound to, which allows double-checking
+	// through another uplink on multi-homed hosts
+	SourceAddress string `yaml:"source-address,omitempty"`
+
+	// ProxyURL is the URL of the proxy to use for the double check. Only applies to endpoints of type HTTP.
+	ProxyURL string `yaml:"proxy-url,omitempty"`
+
+	clientConfig *client.Config
+}
+
+// ValidateAndSetDefaults validates the double check configuration and creates the configuration of the client used
+// for the double check from the configuration of the client of the endpoint passed
+func (c *Config) ValidateAndSetDefaults(endpointClientConfig *client.Config) error {
+	if len(c.DNSResolver) == 0 && len(c.SourceAddress) == 0 && len(c.ProxyURL) == 0 {
+		return ErrNoAlternateRoute
+	}
+	if len(c.ProxyURL) > 0 {
+		if proxyURL, err := url.Parse(c.ProxyURL); err != nil || len(proxyURL.Scheme) == 0 || len(proxyURL.Host) == 0 {
+			return ErrInvalidProxyURL
+		}
+	}
+	clientConfig := endpointClientConfig.Copy()
+	if len(c.DNSResolver) > 0 {
+		clientConfig.DNSResolver = c.DNSResolver
+	}
+	if len(c.SourceAddress) > 0 {
+		// The source address replaces the interface of the endpoint, since both can't be set at the same time
+		clientConfig.SourceAddress, clientConfig.Interface = c.SourceAddress, ""
+	}
+	if len(c.ProxyURL) > 0 {
+		clientConfig.ProxyURL = c.ProxyURL
+	}
+	if err := clientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	c.clientConfig = clientConfig
+	return nil
+}
+
+// ClientConfig returns the configuration of the client to use for the double check.
+// ValidateAndSetDefaults must have been called beforehand.
+func (c *Config) ClientConfig() *client.Config {
+	return c.clientConfig
+}
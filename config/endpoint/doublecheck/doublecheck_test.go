@@ -0,0 +1,81 @@
+package doublecheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}{
+		{
+			name:        "no-alternate-route",
+			config:      &Config{},
+			expectedErr: ErrNoAlternateRoute,
+		},
+		{
+			name:   "dns-resolver",
+			config: &Config{DNSResolver: "tcp://1.1.1.1:53"},
+		},
+		{
+			name:        "invalid-dns-resolver",
+			config:      &Config{DNSResolver: "1.1.1.1"},
+			expectedErr: client.ErrInvalidDNSResolver,
+		},
+		{
+			name:   "source-address",
+			config: &Config{SourceAddress: "192.0.2.10"},
+		},
+		{
+			name:        "invalid-source-address",
+			config:      &Config{SourceAddress: "eth1"},
+			expectedErr: client.ErrInvalidClientSourceAddress,
+		},
+		{
+			name:   "proxy-url",
+			config: &Config{ProxyURL: "http://proxy.example.com:3128"},
+		},
+		{
+			name:        "relative-proxy-url",
+			config:      &Config{ProxyURL: "proxy.example.com:3128"},
+			expectedErr: ErrInvalidProxyURL,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(client.GetDefaultConfig()); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ClientConfig(t *testing.T) {
+	endpointClientConfig := &client.Config{Interface: "eth0", DNSResolver: "udp://192.0.2.53:53", Timeout: 5 * time.Second}
+	config := &Config{SourceAddress: "192.0.2.10", ProxyURL: "http://proxy.example.com:3128"}
+	if err := config.ValidateAndSetDefaults(endpointClientConfig); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	clientConfig := config.ClientConfig()
+	if clientConfig == endpointClientConfig {
+		t.Fatal("expected the client configuration of the double check to be a copy")
+	}
+	if clientConfig.SourceAddress != "192.0.2.10" || len(clientConfig.Interface) > 0 {
+		t.Errorf("expected the source address to replace the interface, got source-address=%q and interface=%q", clientConfig.SourceAddress, clientConfig.Interface)
+	}
+	if clientConfig.ProxyURL != "http://proxy.example.com:3128" {
+		t.Errorf("expected the proxy to be overridden, got %q", clientConfig.ProxyURL)
+	}
+	if clientConfig.DNSResolver != endpointClientConfig.DNSResolver || clientConfig.Timeout != endpointClientConfig.Timeout {
+		t.Error("expected the parameters that aren't overridden to be kept")
+	}
+	if endpointClientConfig.Interface != "eth0" || len(endpointClientConfig.SourceAddress) > 0 || len(endpointClientConfig.ProxyURL) > 0 {
+		t.Error("expected the client configuration of the endpoint to be left untouched")
+	}
+}
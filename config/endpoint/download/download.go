@@ -0,0 +1,35 @@
+package download
+
+import (
+	"errors"
+)
+
+const (
+	// DefaultMaximumBytes is the default maximum number of bytes of the response body that are downloaded
+	DefaultMaximumBytes int64 = 100 << 20
+)
+
+var (
+	// ErrInvalidMaximumBytes is the error with which Gatus will panic if max-bytes is negative
+	ErrInvalidMaximumBytes = errors.New("download max-bytes must not be negative")
+)
+
+// Config is the download configuration for endpoint.Endpoint
+//
+// When set, the response body is streamed until either the end of the body or MaximumBytes is reached, which makes it
+// possible to measure the time to first byte as well as the download speed of the target.
+type Config struct {
+	// MaximumBytes is the maximum number of bytes of the response body to download. Defaults to 100MiB.
+	MaximumBytes int64 `yaml:"max-bytes,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the download configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.MaximumBytes < 0 {
+		return ErrInvalidMaximumBytes
+	}
+	if c.MaximumBytes == 0 {
+		c.MaximumBytes = DefaultMaximumBytes
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package download
+
+import (
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if cfg.MaximumBytes != DefaultMaximumBytes {
+		t.Errorf("expected max-bytes to default to %d, got %d", DefaultMaximumBytes, cfg.MaximumBytes)
+	}
+	if err := (&Config{MaximumBytes: 1024}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if err := (&Config{MaximumBytes: -1}).ValidateAndSetDefaults(); err != ErrInvalidMaximumBytes {
+		t.Errorf("expected error %v, got %v", ErrInvalidMaximumBytes, err)
+	}
+}
@@ -2,6 +2,8 @@ package endpoint
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
@@ -9,15 +11,27 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint/acme"
+	"github.com/TwiN/gatus/v5/config/endpoint/cachebusting"
 	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/config/endpoint/doublecheck"
+	"github.com/TwiN/gatus/v5/config/endpoint/download"
+	grpcconfig "github.com/TwiN/gatus/v5/config/endpoint/grpc"
+	"github.com/TwiN/gatus/v5/config/endpoint/hls"
+	"github.com/TwiN/gatus/v5/config/endpoint/objectstorage"
+	"github.com/TwiN/gatus/v5/config/endpoint/sampling"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/config/endpoint/soap"
 	sshconfig "github.com/TwiN/gatus/v5/config/endpoint/ssh"
 	"github.com/TwiN/gatus/v5/config/endpoint/ui"
+	"github.com/TwiN/gatus/v5/config/runner"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -36,17 +50,23 @@ const (
 	// GatusUserAgent is the default user agent that Gatus uses to send requests.
 	GatusUserAgent = "Gatus/1.0"
 
-	TypeDNS      Type = "DNS"
-	TypeTCP      Type = "TCP"
-	TypeSCTP     Type = "SCTP"
-	TypeUDP      Type = "UDP"
-	TypeICMP     Type = "ICMP"
-	TypeSTARTTLS Type = "STARTTLS"
-	TypeTLS      Type = "TLS"
-	TypeHTTP     Type = "HTTP"
-	TypeWS       Type = "WEBSOCKET"
-	TypeSSH      Type = "SSH"
-	TypeUNKNOWN  Type = "UNKNOWN"
+	TypeDNS           Type = "DNS"
+	TypeTCP           Type = "TCP"
+	TypeSCTP          Type = "SCTP"
+	TypeUDP           Type = "UDP"
+	TypeICMP          Type = "ICMP"
+	TypeSTARTTLS      Type = "STARTTLS"
+	TypeTLS           Type = "TLS"
+	TypeHTTP          Type = "HTTP"
+	TypeWS            Type = "WEBSOCKET"
+	TypeSSH           Type = "SSH"
+	TypeLambda        Type = "LAMBDA"
+	TypeACME          Type = "ACME"
+	TypeObjectStorage Type = "OBJECT_STORAGE"
+	TypeGRPC          Type = "GRPC"
+	TypeRTSP          Type = "RTSP"
+	TypeHLS           Type = "HLS"
+	TypeUNKNOWN       Type = "UNKNOWN"
 )
 
 var (
@@ -67,6 +87,48 @@ var (
 	// This is because the free whois service we are using should not be abused, especially considering the fact that
 	// the data takes a while to be updated.
 	ErrInvalidEndpointIntervalForDomainExpirationPlaceholder = errors.New("the minimum interval for an endpoint with a condition using the " + DomainExpirationPlaceholder + " placeholder is 300s (5m)")
+
+	// ErrEndpointWithInvalidMaxRedirects is the error with which Gatus will panic if an endpoint has a negative max-redirects
+	ErrEndpointWithInvalidMaxRedirects = errors.New("max-redirects must not be negative")
+
+	// ErrEndpointWithMaxRedirectsButRedirectsNotFollowed is the error with which Gatus will panic if an endpoint has
+	// max-redirects set despite follow-redirects being disabled
+	ErrEndpointWithMaxRedirectsButRedirectsNotFollowed = errors.New("max-redirects cannot be set if follow-redirects is false")
+
+	// ErrEndpointWithGraphQLAndSOAP is the error with which Gatus will panic if an endpoint has both graphql and soap set
+	ErrEndpointWithGraphQLAndSOAP = errors.New("an endpoint cannot use both graphql and soap")
+
+	// ErrEndpointWithUnsupportedDownloadType is the error with which Gatus will panic if an endpoint that isn't of type
+	// HTTP, or that is executed on a runner, has download set
+	ErrEndpointWithUnsupportedDownloadType = errors.New("download can only be used by endpoints of type HTTP that aren't executed on a runner")
+
+	// ErrEndpointWithDownloadPlaceholderButNoDownload is the error with which Gatus will panic if an endpoint has a
+	// condition using TTFBPlaceholder, DownloadSpeedPlaceholder or BytesReadPlaceholder without having download set
+	ErrEndpointWithDownloadPlaceholderButNoDownload = errors.New("the " + TTFBPlaceholder + ", " + DownloadSpeedPlaceholder + " and " + BytesReadPlaceholder + " placeholders require download to be set")
+
+	// ErrEndpointWithUnsupportedCacheBustingType is the error with which Gatus will panic if an endpoint that isn't of
+	// type HTTP has cache-busting set
+	ErrEndpointWithUnsupportedCacheBustingType = errors.New("cache-busting can only be used by endpoints of type HTTP")
+
+	// ErrEndpointWithUnsupportedGRPCType is the error with which Gatus will panic if an endpoint that isn't of type
+	// GRPC has grpc set
+	ErrEndpointWithUnsupportedGRPCType = errors.New("grpc can only be used by endpoints of type GRPC")
+
+	// ErrEndpointWithUnsupportedHLSType is the error with which Gatus will panic if an endpoint with hls set has a URL
+	// that isn't an HTTP or HTTPS URL, or is executed on a runner
+	ErrEndpointWithUnsupportedHLSType = errors.New("hls can only be used with an http:// or https:// url by endpoints that aren't executed on a runner")
+
+	// ErrEndpointWithHLSPlaceholderButNoHLS is the error with which Gatus will panic if an endpoint has a condition
+	// using SegmentAgePlaceholder or SegmentCountPlaceholder without having hls set
+	ErrEndpointWithHLSPlaceholderButNoHLS = errors.New("the " + SegmentAgePlaceholder + " and " + SegmentCountPlaceholder + " placeholders require hls to be set")
+
+	// ErrEndpointWithInvalidInitialDelay is the error with which Gatus will panic if an endpoint has a negative
+	// initial-delay
+	ErrEndpointWithInvalidInitialDelay = errors.New("initial-delay must not be negative")
+
+	// ErrEndpointWithDoubleCheckOnRunner is the error with which Gatus will panic if an endpoint executed on a runner
+	// has double-check set
+	ErrEndpointWithDoubleCheckOnRunner = errors.New("double-check can only be used by endpoints that aren't executed on a runner")
 )
 
 // Endpoint is the configuration of a service to be monitored
@@ -92,35 +154,125 @@ type Endpoint struct {
 	// GraphQL is whether to wrap the body in a query param ({"query":"$body"})
 	GraphQL bool `yaml:"graphql,omitempty"`
 
+	// SOAPConfig is the configuration for calling a SOAP operation, in which case the body is wrapped in a SOAP envelope
+	SOAPConfig *soap.Config `yaml:"soap,omitempty"`
+
 	// Headers of the request
 	Headers map[string]string `yaml:"headers,omitempty"`
 
+	// FollowRedirects is whether to follow redirects. If set, it overrides the client's ignore-redirect parameter.
+	FollowRedirects *bool `yaml:"follow-redirects,omitempty"`
+
+	// MaxRedirects is the maximum number of redirects to follow before the request is considered as failed.
+	// Defaults to 10 if not set.
+	MaxRedirects int `yaml:"max-redirects,omitempty"`
+
 	// Interval is the duration to wait between every status check
 	Interval time.Duration `yaml:"interval,omitempty"`
 
+	// InitialDelay is the duration to wait after Gatus starts or its configuration is reloaded before the first status
+	// check, e.g. to give the endpoint time to start if it is deployed alongside Gatus
+	InitialDelay time.Duration `yaml:"initial-delay,omitempty"`
+
 	// Conditions used to determine the health of the endpoint
 	Conditions []Condition `yaml:"conditions"`
 
 	// Alerts is the alerting configuration for the endpoint in case of failure
 	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
 
+	// Tests are sample results with the expected outcome of the conditions for each of them, which are evaluated by
+	// the validate command
+	Tests []*ConditionTestCase `yaml:"tests,omitempty"`
+
 	// DNSConfig is the configuration for DNS monitoring
 	DNSConfig *dns.Config `yaml:"dns,omitempty"`
 
 	// SSH is the configuration for SSH monitoring
 	SSHConfig *sshconfig.Config `yaml:"ssh,omitempty"`
 
+	// ACMEConfig is the configuration for ACME monitoring
+	ACMEConfig *acme.Config `yaml:"acme,omitempty"`
+
+	// GRPCConfig is the configuration for gRPC health monitoring
+	GRPCConfig *grpcconfig.Config `yaml:"grpc,omitempty"`
+
+	// HLSConfig is the configuration for monitoring an HLS stream, in which case the URL is the one of its playlist
+	HLSConfig *hls.Config `yaml:"hls,omitempty"`
+
+	// ObjectStorageConfig is the configuration for object storage monitoring
+	ObjectStorageConfig *objectstorage.Config `yaml:"object-storage,omitempty"`
+
+	// DownloadConfig is the configuration for streaming the response body in order to measure the time to first byte
+	// and the download speed of the target
+	DownloadConfig *download.Config `yaml:"download,omitempty"`
+
+	// CacheBustingConfig is the configuration for bypassing the caches between Gatus and the target, so that the health
+	// of the origin is evaluated rather than that of a cached copy of the response
+	CacheBustingConfig *cachebusting.Config `yaml:"cache-busting,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the endpoint's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
 	// UIConfig is the configuration for the UI
 	UIConfig *ui.Config `yaml:"ui,omitempty"`
 
+	// SamplingConfig is the configuration for sampling the results stored, which is useful for endpoints with a
+	// very short interval
+	SamplingConfig *sampling.Config `yaml:"sampling,omitempty"`
+
+	// SLOConfig is the configuration of the service level objective of the endpoint, whose error budget and burn rate
+	// are tracked and can trigger alerts
+	SLOConfig *slo.Config `yaml:"slo,omitempty"`
+
+	// DoubleCheckConfig is the configuration for re-verifying failures through an alternate route before persisting
+	// them (optional)
+	DoubleCheckConfig *doublecheck.Config `yaml:"double-check,omitempty"`
+
+	// Runner is the name of the runner on which the check of the endpoint is executed through SSH (optional).
+	// If not set, the check is executed by Gatus itself.
+	Runner string `yaml:"runner,omitempty"`
+
+	// RunnerConfig is the configuration of the runner referenced by Runner, which is set when the configuration
+	// is validated
+	RunnerConfig *runner.Config `yaml:"-"`
+
 	// NumberOfFailuresInARow is the number of unsuccessful evaluations in a row
 	NumberOfFailuresInARow int `yaml:"-"`
 
 	// NumberOfSuccessesInARow is the number of successful evaluations in a row
 	NumberOfSuccessesInARow int `yaml:"-"`
+
+	// LastSuccessTimestamp is the timestamp of the last successful evaluation.
+	// If the endpoint hasn't been successful since the application started, this is set to the timestamp of its
+	// first failed evaluation instead.
+	LastSuccessTimestamp time.Time `yaml:"-"`
+
+	// DownSince is the timestamp of the first failed evaluation of the current outage, or zero if the endpoint isn't
+	// down. The outage only ends once none of the alerts of the endpoint are triggered anymore.
+	DownSince time.Time `yaml:"-"`
+
+	// Tenant is the name of the tenant the endpoint belongs to, if any.
+	// Set from the tenant configuration the endpoint is declared in rather than from the endpoint configuration itself.
+	Tenant string `yaml:"-"`
+
+	// PageURL is the URL of the page of the endpoint on the dashboard, which alerts link to.
+	// Set from web.Config.ExternalURL, and empty if it isn't configured or if the endpoint belongs to a tenant.
+	PageURL string `yaml:"-"`
+
+	// override is the temporary override of the configuration of the endpoint, if any. Guarded by overridesMutex.
+	override *Override
+
+	// overrideChanged is closed when the override changes. Guarded by overridesMutex.
+	overrideChanged chan struct{}
+}
+
+// ShouldStoreResult returns whether the result should be stored based on the sampling configuration of the endpoint.
+// If the endpoint has no sampling configuration, every result is stored.
+func (e *Endpoint) ShouldStoreResult(result *Result) bool {
+	if e.SamplingConfig == nil {
+		return true
+	}
+	return e.SamplingConfig.ShouldStore(result.Success)
 }
 
 // IsEnabled returns whether the endpoint is enabled or not
@@ -136,6 +288,8 @@ func (e *Endpoint) Type() Type {
 	switch {
 	case e.DNSConfig != nil:
 		return TypeDNS
+	case e.HLSConfig != nil:
+		return TypeHLS
 	case strings.HasPrefix(e.URL, "tcp://"):
 		return TypeTCP
 	case strings.HasPrefix(e.URL, "sctp://"):
@@ -154,6 +308,16 @@ func (e *Endpoint) Type() Type {
 		return TypeWS
 	case strings.HasPrefix(e.URL, "ssh://"):
 		return TypeSSH
+	case strings.HasPrefix(e.URL, "lambda://"):
+		return TypeLambda
+	case strings.HasPrefix(e.URL, "acme://"):
+		return TypeACME
+	case strings.HasPrefix(e.URL, "grpc://") || strings.HasPrefix(e.URL, "grpcs://"):
+		return TypeGRPC
+	case strings.HasPrefix(e.URL, "rtsp://") || strings.HasPrefix(e.URL, "rtsps://"):
+		return TypeRTSP
+	case objectstorage.IsObjectStorageURL(e.URL):
+		return TypeObjectStorage
 	default:
 		return TypeUNKNOWN
 	}
@@ -169,10 +333,41 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 	}
 	if e.ClientConfig == nil {
 		e.ClientConfig = client.GetDefaultConfig()
-	} else {
-		if err := e.ClientConfig.ValidateAndSetDefaults(); err != nil {
-			return err
+	}
+	if e.Type() == TypeLambda && !e.ClientConfig.HasAWSConfig() {
+		// Invoking a lambda function requires AWS credentials, which will be retrieved from the environment
+		e.ClientConfig.AWSConfig = &client.AWSConfig{}
+	}
+	if e.Type() == TypeObjectStorage {
+		if e.ObjectStorageConfig == nil {
+			e.ObjectStorageConfig = &objectstorage.Config{}
+		}
+		if strings.HasPrefix(e.URL, objectstorage.SchemeS3) && !e.ClientConfig.HasAWSConfig() {
+			// Accessing an object stored in S3 requires AWS credentials, which will be retrieved from the environment
+			e.ClientConfig.AWSConfig = &client.AWSConfig{}
+		}
+		if e.ClientConfig.HasAWSConfig() {
+			// Objects stored in GCS can be accessed through its S3-compatible API using HMAC keys
+			if len(e.ClientConfig.AWSConfig.Service) == 0 {
+				e.ClientConfig.AWSConfig.Service = client.AWSServiceS3
+			}
+			if len(e.ClientConfig.AWSConfig.Region) == 0 && strings.HasPrefix(e.URL, objectstorage.SchemeGCS) {
+				e.ClientConfig.AWSConfig.Region = "auto"
+			}
+		}
+	}
+	if e.MaxRedirects < 0 {
+		return ErrEndpointWithInvalidMaxRedirects
+	}
+	if e.FollowRedirects != nil {
+		if !*e.FollowRedirects && e.MaxRedirects > 0 {
+			return ErrEndpointWithMaxRedirectsButRedirectsNotFollowed
 		}
+		e.ClientConfig.IgnoreRedirect = !*e.FollowRedirects
+	}
+	e.ClientConfig.MaxRedirects = e.MaxRedirects
+	if err := e.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
 	}
 	if e.UIConfig == nil {
 		e.UIConfig = ui.GetDefaultConfig()
@@ -181,11 +376,32 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 			return err
 		}
 	}
+	if e.SOAPConfig != nil {
+		if e.GraphQL {
+			return ErrEndpointWithGraphQLAndSOAP
+		}
+		if err := e.SOAPConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
 	if e.Interval == 0 {
 		e.Interval = 1 * time.Minute
 	}
+	if e.InitialDelay < 0 {
+		return ErrEndpointWithInvalidInitialDelay
+	}
 	if len(e.Method) == 0 {
-		e.Method = http.MethodGet
+		if e.SOAPConfig != nil {
+			e.Method = http.MethodPost
+		} else if e.Type() == TypeObjectStorage {
+			// Retrieving the metadata of an object is enough, unless a condition requires its content
+			e.Method = http.MethodHead
+			if e.needsToReadBody() {
+				e.Method = http.MethodGet
+			}
+		} else {
+			e.Method = http.MethodGet
+		}
 	}
 	if len(e.Headers) == 0 {
 		e.Headers = make(map[string]string)
@@ -199,6 +415,17 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 	if _, contentTypeHeaderExists := e.Headers[ContentTypeHeader]; !contentTypeHeaderExists && e.GraphQL {
 		e.Headers[ContentTypeHeader] = "application/json"
 	}
+	// Automatically add the headers required by SOAP if they aren't set in the endpoint configuration
+	if e.SOAPConfig != nil {
+		if _, contentTypeHeaderExists := e.Headers[ContentTypeHeader]; !contentTypeHeaderExists {
+			e.Headers[ContentTypeHeader] = e.SOAPConfig.ContentType()
+		}
+		for k, v := range e.SOAPConfig.Headers() {
+			if _, headerExists := e.Headers[k]; !headerExists {
+				e.Headers[k] = v
+			}
+		}
+	}
 	if len(e.Conditions) == 0 {
 		return ErrEndpointWithNoCondition
 	}
@@ -209,6 +436,62 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 		if err := c.Validate(); err != nil {
 			return fmt.Errorf("%v: %w", ErrInvalidConditionFormat, err)
 		}
+		if e.DownloadConfig == nil && c.hasDownloadPlaceholder() {
+			return ErrEndpointWithDownloadPlaceholderButNoDownload
+		}
+		if e.HLSConfig == nil && c.hasHLSPlaceholder() {
+			return ErrEndpointWithHLSPlaceholderButNoHLS
+		}
+	}
+	for _, t := range e.Tests {
+		if err := t.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if len(e.Runner) > 0 && e.Type() != TypeHTTP && e.Type() != TypeICMP && e.Type() != TypeDNS {
+		return ErrEndpointWithUnsupportedRunnerType
+	}
+	if e.DoubleCheckConfig != nil {
+		if len(e.Runner) > 0 {
+			return ErrEndpointWithDoubleCheckOnRunner
+		}
+		if err := e.DoubleCheckConfig.ValidateAndSetDefaults(e.ClientConfig); err != nil {
+			return err
+		}
+	}
+	if e.SamplingConfig != nil {
+		if err := e.SamplingConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if e.SLOConfig != nil {
+		if err := e.SLOConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	} else if hasSLOBurnRateAlert(e.Alerts) {
+		return ErrEndpointWithSLOBurnRateAlertButNoSLO
+	}
+	if e.DownloadConfig != nil {
+		if e.Type() != TypeHTTP || len(e.Runner) > 0 {
+			return ErrEndpointWithUnsupportedDownloadType
+		}
+		if err := e.DownloadConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if e.CacheBustingConfig != nil {
+		if e.Type() != TypeHTTP {
+			return ErrEndpointWithUnsupportedCacheBustingType
+		}
+		if err := e.CacheBustingConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if e.GRPCConfig != nil && e.Type() != TypeGRPC {
+		return ErrEndpointWithUnsupportedGRPCType
+	}
+	if e.HLSConfig != nil && (!(strings.HasPrefix(e.URL, "http://") || strings.HasPrefix(e.URL, "https://")) || len(e.Runner) > 0) {
+		return ErrEndpointWithUnsupportedHLSType
 	}
 	if e.DNSConfig != nil {
 		return e.DNSConfig.ValidateAndSetDefault()
@@ -216,17 +499,107 @@ func (e *Endpoint) ValidateAndSetDefaults() error {
 	if e.SSHConfig != nil {
 		return e.SSHConfig.Validate()
 	}
+	if e.ACMEConfig != nil {
+		if err := e.ACMEConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	if e.ObjectStorageConfig != nil {
+		if err := e.ObjectStorageConfig.Validate(e.URL); err != nil {
+			return err
+		}
+	}
 	if e.Type() == TypeUNKNOWN {
 		return ErrUnknownEndpointType
 	}
+	if e.Type() == TypeLambda {
+		// The function name may be an ARN, which isn't a valid URL, so there's no request to validate
+		return nil
+	}
 	// Make sure that the request can be created
-	_, err := http.NewRequest(e.Method, e.URL, bytes.NewBuffer([]byte(e.Body)))
+	requestURL, err := e.requestURL()
+	if err != nil {
+		return err
+	}
+	_, err = http.NewRequest(e.Method, requestURL, bytes.NewBuffer([]byte(e.Body)))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// requestURL returns the URL the HTTP request must be sent to, which differs from the URL of the endpoint for objects
+// stored in an object storage
+func (e *Endpoint) requestURL() (string, error) {
+	if e.Type() != TypeObjectStorage {
+		return e.URL, nil
+	}
+	var region string
+	if e.ClientConfig != nil && e.ClientConfig.HasAWSConfig() {
+		region = e.ClientConfig.AWSConfig.Region
+	}
+	objectStorageConfig := e.ObjectStorageConfig
+	if objectStorageConfig == nil {
+		objectStorageConfig = &objectstorage.Config{}
+	}
+	return objectStorageConfig.HTTPURL(e.URL, region)
+}
+
+// AlertingState is the state used to determine whether the alerts of an endpoint should be triggered or resolved,
+// which is persisted so that restarting the application in the middle of an incident doesn't reset it
+type AlertingState struct {
+	NumberOfFailuresInARow  int
+	NumberOfSuccessesInARow int
+	LastSuccessTimestamp    time.Time
+	DownSince               time.Time
+}
+
+// AlertingState returns the current alerting state of the endpoint
+func (e *Endpoint) AlertingState() *AlertingState {
+	return &AlertingState{
+		NumberOfFailuresInARow:  e.NumberOfFailuresInARow,
+		NumberOfSuccessesInARow: e.NumberOfSuccessesInARow,
+		LastSuccessTimestamp:    e.LastSuccessTimestamp,
+		DownSince:               e.DownSince,
+	}
+}
+
+// RestoreAlertingState sets the alerting state of the endpoint, e.g. to the one persisted before a restart
+func (e *Endpoint) RestoreAlertingState(state *AlertingState) {
+	e.NumberOfFailuresInARow = state.NumberOfFailuresInARow
+	e.NumberOfSuccessesInARow = state.NumberOfSuccessesInARow
+	e.LastSuccessTimestamp = state.LastSuccessTimestamp
+	e.DownSince = state.DownSince
+}
+
+// DownFor returns for how long the endpoint has been down as of the timestamp passed, or 0 if it isn't down
+func (e *Endpoint) DownFor(now time.Time) time.Duration {
+	if e.DownSince.IsZero() || now.Before(e.DownSince) {
+		return 0
+	}
+	return now.Sub(e.DownSince)
+}
+
+// DowntimeBeforeResolution returns for how long the endpoint was down before the result resolving its outage was
+// observed, or 0 if it isn't down
+func (e *Endpoint) DowntimeBeforeResolution(result *Result) time.Duration {
+	if result == nil || result.Timestamp.IsZero() {
+		return e.DownFor(time.Now())
+	}
+	return e.DownFor(result.Timestamp)
+}
+
+// ResolvedDowntimeSuffix returns a description of for how long the endpoint was down before the result resolving its
+// outage was observed, e.g. " (down for 43 minutes)", or an empty string if it isn't down.
+// Meant to be appended to the message with which alerting providers notify that an alert has been resolved.
+func (e *Endpoint) ResolvedDowntimeSuffix(result *Result) string {
+	downFor := e.DowntimeBeforeResolution(result)
+	if downFor <= 0 {
+		return ""
+	}
+	return " (down for " + HumanizeDuration(downFor) + ")"
+}
+
 // DisplayName returns an identifier made up of the Name and, if not empty, the Group.
 func (e *Endpoint) DisplayName() string {
 	if len(e.Group) > 0 {
@@ -237,24 +610,46 @@ func (e *Endpoint) DisplayName() string {
 
 // Key returns the unique key for the Endpoint
 func (e *Endpoint) Key() string {
-	return ConvertGroupAndEndpointNameToKey(e.Group, e.Name)
+	return ConvertTenantGroupAndEndpointNameToKey(e.Tenant, e.Group, e.Name)
 }
 
 // Close HTTP connections between watchdog and endpoints to avoid dangling socket file descriptors
 // on configuration reload.
 // More context on https://github.com/TwiN/gatus/issues/536
 func (e *Endpoint) Close() {
-	if e.Type() == TypeHTTP {
+	if e.Type() == TypeHTTP || e.Type() == TypeObjectStorage {
 		client.GetHTTPClient(e.ClientConfig).CloseIdleConnections()
+		if e.DoubleCheckConfig != nil && e.DoubleCheckConfig.ClientConfig() != nil {
+			client.GetHTTPClient(e.DoubleCheckConfig.ClientConfig()).CloseIdleConnections()
+		}
 	}
 }
 
 // EvaluateHealth sends a request to the endpoint's URL and evaluates the conditions of the endpoint.
+//
+// If the endpoint has double-check set, a failure is re-verified through the alternate route configured, and discarded
+// in favor of the result of the double check if the latter is successful.
 func (e *Endpoint) EvaluateHealth() *Result {
+	result := e.evaluateHealth()
+	if !result.Success && e.DoubleCheckConfig != nil {
+		alternate := e.Copy()
+		alternate.ClientConfig = e.DoubleCheckConfig.ClientConfig()
+		if doubleCheckResult := alternate.evaluateHealth(); doubleCheckResult.Success {
+			doubleCheckResult.UnconfirmedErrors = result.Errors
+			return doubleCheckResult
+		}
+	}
+	return result
+}
+
+func (e *Endpoint) evaluateHealth() *Result {
 	result := &Result{Success: true, Errors: []string{}}
 	// Parse or extract hostname from URL
 	if e.DNSConfig != nil {
 		result.Hostname = strings.TrimSuffix(e.URL, ":53")
+	} else if e.Type() == TypeLambda {
+		// The function name may be an ARN, which can't be parsed as a URL
+		result.Hostname = strings.TrimPrefix(e.URL, "lambda://")
 	} else {
 		urlObject, err := url.Parse(e.URL)
 		if err != nil {
@@ -263,8 +658,8 @@ func (e *Endpoint) EvaluateHealth() *Result {
 			result.Hostname = urlObject.Hostname()
 		}
 	}
-	// Retrieve IP if necessary
-	if e.needsToRetrieveIP() {
+	// Retrieve IP if necessary. If the check is executed on a runner, the IP is the one the runner connected to instead.
+	if e.needsToRetrieveIP() && e.RunnerConfig == nil {
 		e.getIP(result)
 	}
 	// Retrieve domain expiration if necessary
@@ -281,13 +676,18 @@ func (e *Endpoint) EvaluateHealth() *Result {
 		result.Success = false
 	}
 	// Evaluate the conditions
-	for _, condition := range e.Conditions {
+	for _, condition := range e.EffectiveConditions() {
 		success := condition.evaluate(result, e.UIConfig.DontResolveFailedConditions)
 		if !success {
 			result.Success = false
 		}
 	}
 	result.Timestamp = time.Now()
+	if e.SLOConfig != nil && result.Success {
+		result.ExceededResponseTimeObjective = e.SLOConfig.IsTooSlow(result.Duration)
+	}
+	// The failure must be classified before the errors are redacted
+	result.ClassifyFailure()
 	// Clean up parameters that we don't need to keep in the results
 	if e.UIConfig.HideURL {
 		for errIdx, errorString := range result.Errors {
@@ -320,30 +720,35 @@ func (e *Endpoint) call(result *Result) {
 	var response *http.Response
 	var err error
 	var certificate *x509.Certificate
+	if e.RunnerConfig != nil {
+		e.callThroughRunner(result)
+		return
+	}
 	endpointType := e.Type()
-	if endpointType == TypeHTTP {
+	if endpointType == TypeHTTP || endpointType == TypeObjectStorage || endpointType == TypeHLS {
 		request = e.buildHTTPRequest()
 	}
 	startTime := time.Now()
 	if endpointType == TypeDNS {
-		result.Connected, result.DNSRCode, result.Body, err = client.QueryDNS(e.DNSConfig.QueryType, e.DNSConfig.QueryName, e.URL)
+		result.Connected, result.DNSRCode, result.Body, result.DNSRecords, err = client.QueryDNS(e.DNSConfig.QueryType, e.DNSConfig.QueryName, e.URL, e.ClientConfig)
 		if err != nil {
 			result.AddError(err.Error())
 			return
 		}
 		result.Duration = time.Since(startTime)
 	} else if endpointType == TypeSTARTTLS || endpointType == TypeTLS {
+		var state *tls.ConnectionState
 		if endpointType == TypeSTARTTLS {
-			result.Connected, certificate, err = client.CanPerformStartTLS(strings.TrimPrefix(e.URL, "starttls://"), e.ClientConfig)
+			result.Connected, state, err = client.CanPerformStartTLS(strings.TrimPrefix(e.URL, "starttls://"), e.ClientConfig)
 		} else {
-			result.Connected, certificate, err = client.CanPerformTLS(strings.TrimPrefix(e.URL, "tls://"), e.ClientConfig)
+			result.Connected, state, err = client.CanPerformTLS(strings.TrimPrefix(e.URL, "tls://"), e.ClientConfig)
 		}
 		if err != nil {
 			result.AddError(err.Error())
 			return
 		}
 		result.Duration = time.Since(startTime)
-		result.CertificateExpiration = time.Until(certificate.NotAfter)
+		result.setTLSConnectionState(state)
 	} else if endpointType == TypeTCP {
 		result.Connected = client.CanCreateTCPConnection(strings.TrimPrefix(e.URL, "tcp://"), e.ClientConfig)
 		result.Duration = time.Since(startTime)
@@ -375,7 +780,89 @@ func (e *Endpoint) call(result *Result) {
 			return
 		}
 		result.Duration = time.Since(startTime)
+	} else if endpointType == TypeLambda {
+		var functionError string
+		result.Connected, result.HTTPStatus, result.Body, functionError, err = client.InvokeLambdaFunction(strings.TrimPrefix(e.URL, "lambda://"), []byte(e.Body), e.ClientConfig)
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		result.Duration = time.Since(startTime)
+		if len(functionError) > 0 {
+			// The Invoke API returns a 200 even if the function failed, so we have to explicitly mark it as unhealthy
+			result.AddError("function returned an error: " + functionError)
+			result.Success = false
+		}
+	} else if endpointType == TypeGRPC {
+		var service string
+		if e.GRPCConfig != nil {
+			service = e.GRPCConfig.Service
+		}
+		var state *tls.ConnectionState
+		address := strings.TrimPrefix(strings.TrimPrefix(e.URL, "grpc://"), "grpcs://")
+		result.Connected, result.GRPCHealthStatus, state, err = client.CheckGRPCHealth(address, service, strings.HasPrefix(e.URL, "grpcs://"), e.ClientConfig)
+		result.Duration = time.Since(startTime)
+		if state != nil {
+			result.setTLSConnectionState(state)
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+	} else if endpointType == TypeRTSP {
+		var state *tls.ConnectionState
+		result.Connected, result.HTTPStatus, result.Body, state, err = client.DescribeRTSPStream(e.URL, e.ClientConfig)
+		result.Duration = time.Since(startTime)
+		if state != nil {
+			result.setTLSConnectionState(state)
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+	} else if endpointType == TypeHLS {
+		stream, err := client.CheckHLSStream(request, e.HLSConfig.FetchSegment, e.ClientConfig)
+		result.Duration = time.Since(startTime)
+		if stream != nil {
+			result.Connected, result.HTTPStatus, result.Body = stream.Status > 0, stream.Status, stream.Playlist
+			result.SegmentCount, result.LatestSegmentAt = stream.SegmentCount, stream.LatestSegmentAt
+			if stream.TLS != nil {
+				result.setTLSConnectionState(stream.TLS)
+			}
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
+	} else if endpointType == TypeACME {
+		var orderIdentifiers []string
+		var accountKey *ecdsa.PrivateKey
+		if e.ACMEConfig != nil && len(e.ACMEConfig.OrderIdentifiers) > 0 {
+			orderIdentifiers = e.ACMEConfig.OrderIdentifiers
+			if accountKey, err = e.ACMEConfig.AccountKey(); err != nil {
+				result.AddError(err.Error())
+				return
+			}
+		}
+		// The directory is always served over HTTPS, as required by RFC 8555
+		result.Connected, result.HTTPStatus, result.Body, certificate, err = client.CheckACMEDirectory("https://"+strings.TrimPrefix(e.URL, "acme://"), orderIdentifiers, accountKey, e.ClientConfig)
+		result.Duration = time.Since(startTime)
+		if certificate != nil {
+			result.setCertificate(certificate)
+		}
+		if err != nil {
+			result.AddError(err.Error())
+			return
+		}
 	} else {
+		var firstByteTime time.Time
+		if e.DownloadConfig != nil {
+			request = request.WithContext(httptrace.WithClientTrace(request.Context(), &httptrace.ClientTrace{
+				GotFirstResponseByte: func() {
+					firstByteTime = time.Now()
+				},
+			}))
+		}
 		response, err = client.GetHTTPClient(e.ClientConfig).Do(request)
 		result.Duration = time.Since(startTime)
 		if err != nil {
@@ -383,15 +870,30 @@ func (e *Endpoint) call(result *Result) {
 			return
 		}
 		defer response.Body.Close()
-		if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
-			certificate = response.TLS.PeerCertificates[0]
-			result.CertificateExpiration = time.Until(certificate.NotAfter)
+		if response.TLS != nil {
+			result.setTLSConnectionState(response.TLS)
 		}
 		result.HTTPStatus = response.StatusCode
+		result.Headers = response.Header
 		result.Connected = response.StatusCode > 0
-		// Only read the Body if there's a condition that uses the BodyPlaceholder
-		if e.needsToReadBody() {
+		result.RedirectLocation, result.RedirectCount = redirectsOf(response)
+		result.BodySize = response.ContentLength
+		if lastModified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil {
+			result.LastModified = lastModified
+		}
+		if e.DownloadConfig != nil {
+			e.download(result, response, startTime, firstByteTime)
+		} else if e.needsToReadBody() {
+			// Only read the Body if there's a condition that uses the BodyPlaceholder
 			result.Body, err = io.ReadAll(response.Body)
+			if err != nil {
+				result.AddError("error reading response body:" + err.Error())
+			} else {
+				result.BodySize = int64(len(result.Body))
+			}
+		} else if result.BodySize < 0 && request.Method != http.MethodHead && e.needsToComputeBodySize() {
+			// The size of the body isn't known in advance, so it has to be counted
+			result.BodySize, err = io.Copy(io.Discard, response.Body)
 			if err != nil {
 				result.AddError("error reading response body:" + err.Error())
 			}
@@ -399,6 +901,59 @@ func (e *Endpoint) call(result *Result) {
 	}
 }
 
+// download streams the body of the response until either its end or the maximum number of bytes to download is
+// reached, and sets the time to first byte, the number of bytes read and the download speed of the result.
+//
+// The body is only kept if there's a condition that uses the BodyPlaceholder.
+func (e *Endpoint) download(result *Result, response *http.Response, startTime, firstByteTime time.Time) {
+	if firstByteTime.IsZero() {
+		firstByteTime = time.Now()
+	}
+	result.TTFB = firstByteTime.Sub(startTime)
+	var body bytes.Buffer
+	keepBody := e.needsToReadBody()
+	writer := io.Discard
+	if keepBody {
+		writer = &body
+	}
+	// One more byte than the limit is read to know whether the whole body was downloaded
+	var err error
+	result.BytesRead, err = io.Copy(writer, io.LimitReader(response.Body, e.DownloadConfig.MaximumBytes+1))
+	isTruncated := result.BytesRead > e.DownloadConfig.MaximumBytes
+	if isTruncated {
+		result.BytesRead = e.DownloadConfig.MaximumBytes
+	}
+	if elapsed := time.Since(firstByteTime); elapsed > 0 {
+		result.DownloadSpeed = int64(float64(result.BytesRead) / elapsed.Seconds())
+	}
+	if err != nil {
+		result.AddError("error reading response body:" + err.Error())
+		return
+	}
+	if keepBody {
+		result.Body = body.Bytes()[:result.BytesRead]
+	}
+	if !isTruncated {
+		result.BodySize = result.BytesRead
+	}
+}
+
+// redirectsOf returns the Location header of the last redirect response received as well as the number of redirects
+// followed before receiving the response passed.
+//
+// If the response passed is itself a redirect that was not followed, its own Location header is returned.
+func redirectsOf(response *http.Response) (string, int) {
+	location := response.Header.Get("Location")
+	count := 0
+	for request := response.Request; request != nil && request.Response != nil; request = request.Response.Request {
+		if count == 0 && len(location) == 0 {
+			location = request.Response.Header.Get("Location")
+		}
+		count++
+	}
+	return location, count
+}
+
 func (e *Endpoint) buildHTTPRequest() *http.Request {
 	var bodyBuffer *bytes.Buffer
 	if e.GraphQL {
@@ -407,22 +962,28 @@ func (e *Endpoint) buildHTTPRequest() *http.Request {
 		}
 		body, _ := json.Marshal(graphQlBody)
 		bodyBuffer = bytes.NewBuffer(body)
+	} else if e.SOAPConfig != nil {
+		bodyBuffer = bytes.NewBufferString(e.SOAPConfig.Wrap(e.Body))
 	} else {
 		bodyBuffer = bytes.NewBuffer([]byte(e.Body))
 	}
-	request, _ := http.NewRequest(e.Method, e.URL, bodyBuffer)
+	requestURL, _ := e.requestURL()
+	request, _ := http.NewRequest(e.Method, requestURL, bodyBuffer)
 	for k, v := range e.Headers {
 		request.Header.Set(k, v)
 		if k == HostHeader {
 			request.Host = v
 		}
 	}
+	if e.CacheBustingConfig != nil {
+		e.CacheBustingConfig.Apply(request)
+	}
 	return request
 }
 
 // needsToReadBody checks if there's any condition that requires the response Body to be read
 func (e *Endpoint) needsToReadBody() bool {
-	for _, condition := range e.Conditions {
+	for _, condition := range e.EffectiveConditions() {
 		if condition.hasBodyPlaceholder() {
 			return true
 		}
@@ -430,9 +991,19 @@ func (e *Endpoint) needsToReadBody() bool {
 	return false
 }
 
+// needsToComputeBodySize checks if there's any condition that requires the size of the response Body
+func (e *Endpoint) needsToComputeBodySize() bool {
+	for _, condition := range e.EffectiveConditions() {
+		if condition.hasBodySizePlaceholder() {
+			return true
+		}
+	}
+	return false
+}
+
 // needsToRetrieveDomainExpiration checks if there's any condition that requires a whois query to be performed
 func (e *Endpoint) needsToRetrieveDomainExpiration() bool {
-	for _, condition := range e.Conditions {
+	for _, condition := range e.EffectiveConditions() {
 		if condition.hasDomainExpirationPlaceholder() {
 			return true
 		}
@@ -442,7 +1013,7 @@ func (e *Endpoint) needsToRetrieveDomainExpiration() bool {
 
 // needsToRetrieveIP checks if there's any condition that requires an IP lookup
 func (e *Endpoint) needsToRetrieveIP() bool {
-	for _, condition := range e.Conditions {
+	for _, condition := range e.EffectiveConditions() {
 		if condition.hasIPPlaceholder() {
 			return true
 		}
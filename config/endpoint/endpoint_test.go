@@ -6,17 +6,31 @@ import (
 	"crypto/x509"
 	"errors"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint/cachebusting"
 	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/config/endpoint/doublecheck"
+	"github.com/TwiN/gatus/v5/config/endpoint/download"
+	grpcconfig "github.com/TwiN/gatus/v5/config/endpoint/grpc"
+	"github.com/TwiN/gatus/v5/config/endpoint/hls"
+	"github.com/TwiN/gatus/v5/config/endpoint/objectstorage"
+	"github.com/TwiN/gatus/v5/config/endpoint/sampling"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/config/endpoint/soap"
 	"github.com/TwiN/gatus/v5/config/endpoint/ssh"
 	"github.com/TwiN/gatus/v5/config/endpoint/ui"
 	"github.com/TwiN/gatus/v5/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestEndpoint(t *testing.T) {
@@ -160,6 +174,137 @@ func TestEndpoint(t *testing.T) {
 				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
 			}),
 		},
+		{
+			Name: "lambda",
+			Endpoint: Endpoint{
+				Name:         "lambda-function",
+				URL:          "lambda://my-function",
+				Body:         `{"action":"health"}`,
+				Conditions:   []Condition{"[STATUS] == 200", "[BODY].status == UP", "[RESPONSE_TIME] < 1000"},
+				ClientConfig: &client.Config{AWSConfig: &client.AWSConfig{Region: "us-east-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}},
+			},
+			ExpectedResult: &Result{
+				Success:   true,
+				Connected: true,
+				Hostname:  "my-function",
+				ConditionResults: []*ConditionResult{
+					{Condition: "[STATUS] == 200", Success: true},
+					{Condition: "[BODY].status == UP", Success: true},
+					{Condition: "[RESPONSE_TIME] < 1000", Success: true},
+				},
+			},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != "https://lambda.us-east-1.amazonaws.com/2015-03-31/functions/my-function/invocations" {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"status": "UP"}`))}
+			}),
+		},
+		{
+			Name: "lambda-with-function-error",
+			Endpoint: Endpoint{
+				Name:         "lambda-function",
+				URL:          "lambda://arn:aws:lambda:us-east-1:123456789012:function:my-function",
+				Conditions:   []Condition{"[STATUS] == 200"},
+				ClientConfig: &client.Config{AWSConfig: &client.AWSConfig{Region: "us-east-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}},
+			},
+			ExpectedResult: &Result{
+				Success:   false,
+				Connected: true,
+				Hostname:  "arn:aws:lambda:us-east-1:123456789012:function:my-function",
+				ConditionResults: []*ConditionResult{
+					{Condition: "[STATUS] == 200", Success: true},
+				},
+				Errors: []string{"function returned an error: Unhandled"},
+			},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"X-Amz-Function-Error": []string{"Unhandled"}},
+					Body:       io.NopCloser(bytes.NewBufferString(`{"errorMessage": "boom"}`)),
+				}
+			}),
+		},
+		{
+			Name: "acme",
+			Endpoint: Endpoint{
+				Name:       "step-ca",
+				URL:        "acme://ca.example.internal/acme/acme/directory",
+				Conditions: []Condition{"[CONNECTED] == true", "[STATUS] == 200", "[BODY].directory.newOrder == https://ca.example.internal/acme/acme/new-order"},
+			},
+			ExpectedResult: &Result{
+				Success:   true,
+				Connected: true,
+				Hostname:  "ca.example.internal",
+				ConditionResults: []*ConditionResult{
+					{Condition: "[CONNECTED] == true", Success: true},
+					{Condition: "[STATUS] == 200", Success: true},
+					{Condition: "[BODY].directory.newOrder == https://ca.example.internal/acme/acme/new-order", Success: true},
+				},
+			},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				switch r.URL.String() {
+				case "https://ca.example.internal/acme/acme/directory":
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"newNonce":"https://ca.example.internal/acme/acme/new-nonce","newAccount":"https://ca.example.internal/acme/acme/new-account","newOrder":"https://ca.example.internal/acme/acme/new-order"}`))}
+				case "https://ca.example.internal/acme/acme/new-nonce":
+					return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Replay-Nonce": []string{"nonce"}}, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}
+			}),
+		},
+		{
+			Name: "object-storage",
+			Endpoint: Endpoint{
+				Name:       "nightly-backup",
+				URL:        "gs://backups/db.tar.gz",
+				Conditions: []Condition{"[STATUS] == 200", "[BODY_SIZE] > 1024", "[LAST_MODIFIED_AGE] < 24h"},
+			},
+			ExpectedResult: &Result{
+				Success:   true,
+				Connected: true,
+				Hostname:  "backups",
+				ConditionResults: []*ConditionResult{
+					{Condition: "[STATUS] == 200", Success: true},
+					{Condition: "[BODY_SIZE] > 1024", Success: true},
+					{Condition: "[LAST_MODIFIED_AGE] < 24h", Success: true},
+				},
+			},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.Method != http.MethodHead || r.URL.String() != "https://storage.googleapis.com/backups/db.tar.gz" {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				return &http.Response{
+					StatusCode:    http.StatusOK,
+					ContentLength: 4096,
+					Header:        http.Header{"Last-Modified": []string{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)}},
+					Body:          http.NoBody,
+				}
+			}),
+		},
+		{
+			Name: "object-storage-without-last-modified",
+			Endpoint: Endpoint{
+				Name:                "nightly-backup",
+				URL:                 "azblob://account/backups/db.tar.gz",
+				ObjectStorageConfig: &objectstorage.Config{SASToken: "sv=2022-11-02&sig=abc"},
+				Conditions:          []Condition{"[STATUS] == 200", "[LAST_MODIFIED_AGE] < 24h"},
+			},
+			ExpectedResult: &Result{
+				Success:   false,
+				Connected: true,
+				Hostname:  "account",
+				ConditionResults: []*ConditionResult{
+					{Condition: "[STATUS] == 200", Success: true},
+					{Condition: "[LAST_MODIFIED_AGE] (9223372036854775807) < 24h (86400000)", Success: false},
+				},
+			},
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != "https://account.blob.core.windows.net/backups/db.tar.gz?sv=2022-11-02&sig=abc" {
+					return &http.Response{StatusCode: http.StatusForbidden, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+		},
 		{
 			Name: "endpoint-that-will-time-out-and-hidden-hostname",
 			Endpoint: Endpoint{
@@ -283,6 +428,7 @@ func TestEndpoint_Type(t *testing.T) {
 		URL string
 		DNS *dns.Config
 		SSH *ssh.Config
+		HLS *hls.Config
 	}
 	tests := []struct {
 		args args
@@ -362,6 +508,61 @@ func TestEndpoint_Type(t *testing.T) {
 			},
 			want: TypeSSH,
 		},
+		{
+			args: args{
+				URL: "lambda://my-function",
+			},
+			want: TypeLambda,
+		},
+		{
+			args: args{
+				URL: "acme://ca.example.internal/acme/acme/directory",
+			},
+			want: TypeACME,
+		},
+		{
+			args: args{
+				URL: "grpc://127.0.0.1:50051",
+			},
+			want: TypeGRPC,
+		},
+		{
+			args: args{
+				URL: "grpcs://example.com:443",
+			},
+			want: TypeGRPC,
+		},
+		{
+			args: args{
+				URL: "rtsp://camera.example.internal:554/live",
+			},
+			want: TypeRTSP,
+		},
+		{
+			args: args{
+				URL: "rtsps://camera.example.internal/live",
+			},
+			want: TypeRTSP,
+		},
+		{
+			args: args{
+				URL: "https://cdn.example.com/live/playlist.m3u8",
+				HLS: &hls.Config{},
+			},
+			want: TypeHLS,
+		},
+		{
+			args: args{
+				URL: "s3://bucket/path/to/object",
+			},
+			want: TypeObjectStorage,
+		},
+		{
+			args: args{
+				URL: "azblob://account/container/blob",
+			},
+			want: TypeObjectStorage,
+		},
 		{
 			args: args{
 				URL: "invalid://example.org",
@@ -380,6 +581,7 @@ func TestEndpoint_Type(t *testing.T) {
 			endpoint := Endpoint{
 				URL:       tt.args.URL,
 				DNSConfig: tt.args.DNS,
+				HLSConfig: tt.args.HLS,
 			}
 			if got := endpoint.Type(); got != tt.want {
 				t.Errorf("Endpoint.Type() = %v, want %v", got, tt.want)
@@ -494,6 +696,32 @@ func TestEndpoint_ValidateAndSetDefaultsWithDNS(t *testing.T) {
 	}
 }
 
+func TestEndpoint_ValidateAndSetDefaultsWithSampling(t *testing.T) {
+	endpoint := &Endpoint{
+		Name:           "sampling-test",
+		URL:            "https://example.com",
+		Interval:       5 * time.Second,
+		SamplingConfig: &sampling.Config{EveryNthSuccess: 0},
+		Conditions:     []Condition{Condition("[STATUS] == 200")},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, sampling.ErrInvalidEveryNthSuccess) {
+		t.Errorf("expected error %v, got %v", sampling.ErrInvalidEveryNthSuccess, err)
+	}
+	endpoint.SamplingConfig.EveryNthSuccess = 2
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Error("did not expect an error, got", err)
+	}
+	if !endpoint.ShouldStoreResult(&Result{Success: true}) {
+		t.Error("the first result should have been stored")
+	}
+	if endpoint.ShouldStoreResult(&Result{Success: true}) {
+		t.Error("the second successful result in a row should not have been stored")
+	}
+	if !endpoint.ShouldStoreResult(&Result{Success: false}) {
+		t.Error("failed results should always be stored")
+	}
+}
+
 func TestEndpoint_ValidateAndSetDefaultsWithSSH(t *testing.T) {
 	scenarios := []struct {
 		name        string
@@ -541,6 +769,7 @@ func TestEndpoint_ValidateAndSetDefaultsWithSSH(t *testing.T) {
 }
 
 func TestEndpoint_ValidateAndSetDefaultsWithSimpleErrors(t *testing.T) {
+	doNotFollowRedirects := false
 	scenarios := []struct {
 		endpoint    *Endpoint
 		expectedErr error
@@ -578,6 +807,150 @@ func TestEndpoint_ValidateAndSetDefaultsWithSimpleErrors(t *testing.T) {
 			},
 			expectedErr: ErrInvalidEndpointIntervalForDomainExpirationPlaceholder,
 		},
+		{
+			endpoint: &Endpoint{
+				Name:         "negative-max-redirects",
+				URL:          "https://example.com",
+				MaxRedirects: -1,
+				Conditions:   []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: ErrEndpointWithInvalidMaxRedirects,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:         "negative-initial-delay",
+				URL:          "https://example.com",
+				InitialDelay: -time.Second,
+				Conditions:   []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: ErrEndpointWithInvalidInitialDelay,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:            "max-redirects-without-following-redirects",
+				URL:             "https://example.com",
+				FollowRedirects: &doNotFollowRedirects,
+				MaxRedirects:    3,
+				Conditions:      []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: ErrEndpointWithMaxRedirectsButRedirectsNotFollowed,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "download-placeholder-without-download",
+				URL:        "https://example.com",
+				Conditions: []Condition{Condition("[TTFB] < 200")},
+			},
+			expectedErr: ErrEndpointWithDownloadPlaceholderButNoDownload,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:           "download-with-unsupported-type",
+				URL:            "tcp://example.com:80",
+				DownloadConfig: &download.Config{},
+				Conditions:     []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrEndpointWithUnsupportedDownloadType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:           "download-with-negative-max-bytes",
+				URL:            "https://example.com",
+				DownloadConfig: &download.Config{MaximumBytes: -1},
+				Conditions:     []Condition{Condition("[BYTES_READ] > 0")},
+			},
+			expectedErr: download.ErrInvalidMaximumBytes,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:               "cache-busting-with-unsupported-type",
+				URL:                "tcp://example.com:80",
+				CacheBustingConfig: &cachebusting.Config{NoCache: true},
+				Conditions:         []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrEndpointWithUnsupportedCacheBustingType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:               "cache-busting-without-option",
+				URL:                "https://example.com",
+				CacheBustingConfig: &cachebusting.Config{},
+				Conditions:         []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: cachebusting.ErrNoCacheBustingOption,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "grpc-with-unsupported-type",
+				URL:        "https://example.com",
+				GRPCConfig: &grpcconfig.Config{Service: "my.Service"},
+				Conditions: []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: ErrEndpointWithUnsupportedGRPCType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "grpc",
+				URL:        "grpcs://example.com:443",
+				GRPCConfig: &grpcconfig.Config{Service: "my.Service"},
+				Conditions: []Condition{Condition("[STATUS] == SERVING")},
+			},
+			expectedErr: nil,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "hls-with-unsupported-url",
+				URL:        "rtsp://camera.example.internal/live",
+				HLSConfig:  &hls.Config{},
+				Conditions: []Condition{Condition("[SEGMENT_AGE] < 30000")},
+			},
+			expectedErr: ErrEndpointWithUnsupportedHLSType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "hls-placeholder-without-hls",
+				URL:        "https://cdn.example.com/live/playlist.m3u8",
+				Conditions: []Condition{Condition("[SEGMENT_COUNT] > 0")},
+			},
+			expectedErr: ErrEndpointWithHLSPlaceholderButNoHLS,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "hls",
+				URL:        "https://cdn.example.com/live/playlist.m3u8",
+				HLSConfig:  &hls.Config{FetchSegment: true},
+				Conditions: []Condition{Condition("[STATUS] == 200"), Condition("[SEGMENT_AGE] < 30000")},
+			},
+			expectedErr: nil,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "slo-with-invalid-objective",
+				URL:        "https://example.com",
+				SLOConfig:  &slo.Config{Objective: 100},
+				Conditions: []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: slo.ErrInvalidObjective,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "slo-burn-rate-alert-without-slo",
+				URL:        "https://example.com",
+				Alerts:     []*alert.Alert{{Type: alert.TypeSlack, TriggerIf: "slo-burn-rate-above 14.4"}},
+				Conditions: []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: ErrEndpointWithSLOBurnRateAlertButNoSLO,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "slo-burn-rate-alert",
+				URL:        "https://example.com",
+				SLOConfig:  &slo.Config{Objective: 99.9},
+				Alerts:     []*alert.Alert{{Type: alert.TypeSlack, TriggerIf: "slo-burn-rate-above 14.4"}},
+				Conditions: []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: nil,
+		},
 		{
 			endpoint: &Endpoint{
 				Name:       "domain-expiration-with-good-interval",
@@ -704,6 +1077,43 @@ func TestEndpoint_buildHTTPRequestWithGraphQLEnabled(t *testing.T) {
 	}
 }
 
+func TestEndpoint_buildHTTPRequestWithSOAP(t *testing.T) {
+	endpoint := Endpoint{
+		Name:       "soap",
+		URL:        "https://example.com/HealthService.asmx",
+		Conditions: []Condition{"[STATUS] == 200", "[BODY]//GetStatusResult == UP"},
+		SOAPConfig: &soap.Config{Action: "http://example.com/GetStatus"},
+		Body:       `<GetStatus xmlns="http://example.com/"/>`,
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("did not expect an error, got", err)
+	}
+	request := endpoint.buildHTTPRequest()
+	if request.Method != http.MethodPost {
+		t.Error("request.Method should've defaulted to POST, but was", request.Method)
+	}
+	if contentType := request.Header.Get(ContentTypeHeader); contentType != "text/xml; charset=utf-8" {
+		t.Error("request.Header.Content-Type should've been text/xml; charset=utf-8, but was", contentType)
+	}
+	if action := request.Header.Get(soap.ActionHeader); action != `"http://example.com/GetStatus"` {
+		t.Error("request.Header.SOAPAction should've been the quoted action, but was", action)
+	}
+	body, _ := io.ReadAll(request.Body)
+	if !strings.Contains(string(body), `<soap:Body><GetStatus xmlns="http://example.com/"/></soap:Body>`) {
+		t.Error("request.body should've been wrapped in a SOAP envelope, but it wasn't:", string(body))
+	}
+	endpoint = Endpoint{
+		Name:       "soap-and-graphql",
+		URL:        "https://example.com/HealthService.asmx",
+		Conditions: []Condition{"[STATUS] == 200"},
+		SOAPConfig: &soap.Config{},
+		GraphQL:    true,
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointWithGraphQLAndSOAP {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithGraphQLAndSOAP, err)
+	}
+}
+
 func TestIntegrationEvaluateHealth(t *testing.T) {
 	condition := Condition("[STATUS] == 200")
 	bodyCondition := Condition("[BODY].status == UP")
@@ -759,6 +1169,413 @@ func TestIntegrationEvaluateHealthWithErrorAndHideURL(t *testing.T) {
 	}
 }
 
+func TestEndpoint_EvaluateHealthWithRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			http.Redirect(w, r, "/login?next=%2F", http.StatusFound)
+		case "/login":
+			w.WriteHeader(http.StatusOK)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+	followRedirects, doNotFollowRedirects := true, false
+	scenarios := []struct {
+		name            string
+		path            string
+		followRedirects *bool
+		maxRedirects    int
+		conditions      []Condition
+		expectedSuccess bool
+		expectedError   string
+	}{
+		{
+			name:            "follow-redirects-by-default",
+			path:            "/",
+			conditions:      []Condition{"[STATUS] == 200", "[REDIRECT_COUNT] == 1", "[REDIRECT_LOCATION] == /login?next=%2F"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "follow-redirects-false",
+			path:            "/",
+			followRedirects: &doNotFollowRedirects,
+			conditions:      []Condition{"[STATUS] == 302", "[REDIRECT_COUNT] == 0", "[REDIRECT_LOCATION] == pat(/login*)"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "no-redirect",
+			path:            "/login",
+			conditions:      []Condition{"[STATUS] == 200", "[REDIRECT_COUNT] == 0", "[REDIRECT_LOCATION] != pat(*login*)"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "max-redirects-exceeded",
+			path:            "/loop",
+			followRedirects: &followRedirects,
+			maxRedirects:    3,
+			conditions:      []Condition{"[STATUS] == 302"},
+			expectedSuccess: false,
+			expectedError:   "stopped after 3 redirects",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:            scenario.name,
+				URL:             server.URL + scenario.path,
+				FollowRedirects: scenario.followRedirects,
+				MaxRedirects:    scenario.maxRedirects,
+				Conditions:      scenario.conditions,
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (conditions: %v, errors: %v)", scenario.expectedSuccess, result.Success, result.ConditionResults, result.Errors)
+			}
+			if len(scenario.expectedError) > 0 && (len(result.Errors) == 0 || !strings.Contains(result.Errors[0], scenario.expectedError)) {
+				t.Errorf("expected error containing %q, got %v", scenario.expectedError, result.Errors)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4096")
+		w.Write(bytes.Repeat([]byte("a"), 4096))
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name              string
+		maximumBytes      int64
+		conditions        []Condition
+		expectedBytesRead int64
+		expectedBodySize  int64
+		expectedBodyLen   int
+	}{
+		{
+			name:              "whole-body",
+			conditions:        []Condition{"[STATUS] == 200", "[BYTES_READ] == 4096", "[TTFB] < 5000", "[DOWNLOAD_SPEED] > 0"},
+			expectedBytesRead: 4096,
+			expectedBodySize:  4096,
+		},
+		{
+			name:              "truncated-body",
+			maximumBytes:      1024,
+			conditions:        []Condition{"[BYTES_READ] == 1024", "[BODY_SIZE] == 4096"},
+			expectedBytesRead: 1024,
+			expectedBodySize:  4096,
+		},
+		{
+			name:              "truncated-body-kept",
+			maximumBytes:      1024,
+			conditions:        []Condition{"[BYTES_READ] == 1024", "len([BODY]) == 1024"},
+			expectedBytesRead: 1024,
+			expectedBodySize:  4096,
+			expectedBodyLen:   1024,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:           scenario.name,
+				URL:            server.URL,
+				DownloadConfig: &download.Config{MaximumBytes: scenario.maximumBytes},
+				Conditions:     scenario.conditions,
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			result := endpoint.EvaluateHealth()
+			if !result.Success {
+				t.Errorf("expected success, got conditions %v and errors %v", result.ConditionResults, result.Errors)
+			}
+			if result.BytesRead != scenario.expectedBytesRead {
+				t.Errorf("expected %d bytes to be read, got %d", scenario.expectedBytesRead, result.BytesRead)
+			}
+			if result.BodySize != scenario.expectedBodySize {
+				t.Errorf("expected body size to be %d, got %d", scenario.expectedBodySize, result.BodySize)
+			}
+			if len(result.Body) != scenario.expectedBodyLen {
+				t.Errorf("expected body to have a length of %d, got %d", scenario.expectedBodyLen, len(result.Body))
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithCacheBusting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only requests that bypass the cache reach the origin
+		if r.Header.Get("Cache-Control") == "no-cache" && len(r.URL.Query().Get("cb")) > 0 && r.URL.Query().Get("a") == "b" {
+			w.Header().Set("X-Cache", "MISS")
+		} else {
+			w.Header().Set("X-Cache", "HIT")
+			w.Header().Set("Age", "3600")
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name               string
+		cacheBustingConfig *cachebusting.Config
+		expectedSuccess    bool
+	}{
+		{
+			name:               "cache-busting",
+			cacheBustingConfig: &cachebusting.Config{QueryParameter: "cb", NoCache: true},
+			expectedSuccess:    true,
+		},
+		{
+			name:            "no-cache-busting",
+			expectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:               scenario.name,
+				URL:                server.URL + "?a=b",
+				CacheBustingConfig: scenario.cacheBustingConfig,
+				Conditions:         []Condition{"[HEADER.X-Cache] == MISS", "[HEADER.Age] < 60"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			if result := endpoint.EvaluateHealth(); result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got conditions %v and errors %v", scenario.expectedSuccess, result.ConditionResults, result.Errors)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithHLS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fresh.m3u8":
+			programDateTime := time.Now().Add(-8 * time.Second).UTC().Format(time.RFC3339Nano)
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-TARGETDURATION:4\n#EXT-X-PROGRAM-DATE-TIME:" + programDateTime + "\n#EXTINF:4.0,\n1.ts\n#EXTINF:4.0,\n2.ts\n"))
+		case "/stale.m3u8":
+			_, _ = w.Write([]byte("#EXTM3U\n#EXT-X-TARGETDURATION:4\n#EXT-X-PROGRAM-DATE-TIME:2024-01-01T00:00:00Z\n#EXTINF:4.0,\n1.ts\n"))
+		case "/2.ts":
+			_, _ = w.Write([]byte("segment"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name            string
+		path            string
+		expectedSuccess bool
+	}{
+		{
+			name:            "fresh",
+			path:            "/fresh.m3u8",
+			expectedSuccess: true,
+		},
+		{
+			name:            "stale",
+			path:            "/stale.m3u8",
+			expectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       scenario.name,
+				URL:        server.URL + scenario.path,
+				HLSConfig:  &hls.Config{FetchSegment: true},
+				Conditions: []Condition{"[STATUS] == 200", "[SEGMENT_COUNT] > 0", "[SEGMENT_AGE] < 30000"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			if result := endpoint.EvaluateHealth(); result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got conditions %v and errors %v", scenario.expectedSuccess, result.ConditionResults, result.Errors)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithDoubleCheck(t *testing.T) {
+	// The host of the endpoint can't be resolved, so the endpoint can only be reached through the proxy
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "gatus-double-check.invalid" || r.URL.Query().Get("fail") == "true" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer proxy.Close()
+	scenarios := []struct {
+		name                     string
+		url                      string
+		expectedSuccess          bool
+		expectedUnconfirmedError bool
+	}{
+		{
+			name:                     "failure-not-confirmed",
+			url:                      "http://gatus-double-check.invalid/health",
+			expectedSuccess:          true,
+			expectedUnconfirmedError: true,
+		},
+		{
+			name:            "failure-confirmed",
+			url:             "http://gatus-double-check.invalid/health?fail=true",
+			expectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:              scenario.name,
+				URL:               scenario.url,
+				DoubleCheckConfig: &doublecheck.Config{ProxyURL: proxy.URL},
+				Conditions:        []Condition{"[STATUS] == 200"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got conditions %v and errors %v", scenario.expectedSuccess, result.ConditionResults, result.Errors)
+			}
+			if (len(result.UnconfirmedErrors) > 0) != scenario.expectedUnconfirmedError {
+				t.Errorf("expected unconfirmed errors=%v, got %v", scenario.expectedUnconfirmedError, result.UnconfirmedErrors)
+			}
+			if !scenario.expectedSuccess && len(result.Errors) == 0 {
+				t.Error("expected the errors of the failure to be kept")
+			}
+		})
+	}
+	t.Run("runner", func(t *testing.T) {
+		endpoint := Endpoint{
+			Name:              "runner",
+			URL:               "https://example.org/health",
+			Runner:            "eu-west",
+			DoubleCheckConfig: &doublecheck.Config{ProxyURL: proxy.URL},
+			Conditions:        []Condition{"[STATUS] == 200"},
+		}
+		if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithDoubleCheckOnRunner) {
+			t.Errorf("expected error %v, got %v", ErrEndpointWithDoubleCheckOnRunner, err)
+		}
+	})
+}
+
+func TestEndpoint_EvaluateHealthWithSLO(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		if r.URL.Query().Get("fail") == "true" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                                  string
+		url                                   string
+		sloConfig                             *slo.Config
+		expectedExceededResponseTimeObjective bool
+	}{
+		{
+			name:                                  "no-slo",
+			url:                                   server.URL,
+			expectedExceededResponseTimeObjective: false,
+		},
+		{
+			name:                                  "slo-without-response-time",
+			url:                                   server.URL,
+			sloConfig:                             &slo.Config{Objective: 99.9},
+			expectedExceededResponseTimeObjective: false,
+		},
+		{
+			name:                                  "slo-with-response-time-met",
+			url:                                   server.URL,
+			sloConfig:                             &slo.Config{Objective: 99.9, ResponseTime: time.Minute},
+			expectedExceededResponseTimeObjective: false,
+		},
+		{
+			name:                                  "slo-with-response-time-exceeded",
+			url:                                   server.URL,
+			sloConfig:                             &slo.Config{Objective: 99.9, ResponseTime: time.Millisecond},
+			expectedExceededResponseTimeObjective: true,
+		},
+		{
+			name:                                  "slo-with-response-time-exceeded-by-failure",
+			url:                                   server.URL + "?fail=true",
+			sloConfig:                             &slo.Config{Objective: 99.9, ResponseTime: time.Millisecond},
+			expectedExceededResponseTimeObjective: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       scenario.name,
+				URL:        scenario.url,
+				SLOConfig:  scenario.sloConfig,
+				Conditions: []Condition{"[STATUS] == 200"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			if result := endpoint.EvaluateHealth(); result.ExceededResponseTimeObjective != scenario.expectedExceededResponseTimeObjective {
+				t.Errorf("expected ExceededResponseTimeObjective to be %v, got %v with a duration of %s", scenario.expectedExceededResponseTimeObjective, result.ExceededResponseTimeObjective, result.Duration)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithGRPC(t *testing.T) {
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("serving.Service", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("not-serving.Service", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("failed to listen:", err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+	scenarios := []struct {
+		name            string
+		service         string
+		expectedSuccess bool
+	}{
+		{
+			name:            "serving",
+			service:         "serving.Service",
+			expectedSuccess: true,
+		},
+		{
+			name:            "not-serving",
+			service:         "not-serving.Service",
+			expectedSuccess: false,
+		},
+		{
+			name:            "unknown",
+			service:         "unknown.Service",
+			expectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       scenario.name,
+				URL:        "grpc://" + listener.Addr().String(),
+				GRPCConfig: &grpcconfig.Config{Service: scenario.service},
+				Conditions: []Condition{"[CONNECTED] == true", "[STATUS] == SERVING", "[RESPONSE_TIME] < 1000"},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("did not expect an error, got", err)
+			}
+			if result := endpoint.EvaluateHealth(); result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got conditions %v and errors %v", scenario.expectedSuccess, result.ConditionResults, result.Errors)
+			}
+		})
+	}
+}
+
 func TestIntegrationEvaluateHealthForDNS(t *testing.T) {
 	conditionSuccess := Condition("[DNS_RCODE] == NOERROR")
 	conditionBody := Condition("[BODY] == 93.184.215.14")
@@ -921,3 +1738,14 @@ func TestEndpoint_needsToRetrieveIP(t *testing.T) {
 		t.Error("expected true, got false")
 	}
 }
+
+func TestEndpoint_ResolvedDowntimeSuffix(t *testing.T) {
+	downSince := time.Now().Add(-time.Hour)
+	ep := &Endpoint{DownSince: downSince}
+	if suffix := ep.ResolvedDowntimeSuffix(&Result{Timestamp: downSince.Add(43 * time.Minute)}); suffix != " (down for 43 minutes)" {
+		t.Errorf("expected %q, got %q", " (down for 43 minutes)", suffix)
+	}
+	if suffix := (&Endpoint{}).ResolvedDowntimeSuffix(&Result{Timestamp: downSince}); len(suffix) != 0 {
+		t.Errorf("expected no suffix because the endpoint isn't down, got %q", suffix)
+	}
+}
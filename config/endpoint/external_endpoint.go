@@ -2,6 +2,8 @@ package endpoint
 
 import (
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 )
@@ -9,8 +11,15 @@ import (
 var (
 	// ErrExternalEndpointWithNoToken is the error with which Gatus will panic if an external endpoint is configured without a token.
 	ErrExternalEndpointWithNoToken = errors.New("you must specify a token for each external endpoint")
+
+	// ErrExternalEndpointWithInvalidHeartbeatInterval is the error with which Gatus will panic if an external endpoint
+	// is configured with a heartbeat interval lower than MinimumHeartbeatInterval.
+	ErrExternalEndpointWithInvalidHeartbeatInterval = errors.New("the heartbeat-interval of an external endpoint must be at least 10s")
 )
 
+// MinimumHeartbeatInterval is the lowest heartbeat interval an external endpoint can be configured with
+const MinimumHeartbeatInterval = 10 * time.Second
+
 // ExternalEndpoint is an endpoint whose result is pushed from outside Gatus, which means that
 // said endpoints are not monitored by Gatus itself; Gatus only displays their results and takes
 // care of alerting
@@ -27,14 +36,43 @@ type ExternalEndpoint struct {
 	// Token is the bearer token that must be provided through the Authorization header to push results to the endpoint
 	Token string `yaml:"token,omitempty"`
 
+	// PingUUID is the UUID with which results can be pushed to the endpoint through /ping/{uuid}, which is compatible
+	// with the clients of healthchecks.io. Must be kept secret, since no other authentication is required.
+	PingUUID string `yaml:"ping-uuid,omitempty"`
+
 	// Alerts is the alerting configuration for the endpoint in case of failure
 	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
 
+	// HeartbeatInterval is the maximum amount of time that may elapse between two results being pushed.
+	// If no result is received within that interval, a failed result is inserted on behalf of the client, which is
+	// repeated every interval until results are pushed again, so that a client that stopped pushing doesn't look
+	// healthy forever. Disabled if zero.
+	HeartbeatInterval time.Duration `yaml:"heartbeat-interval,omitempty"`
+
 	// NumberOfFailuresInARow is the number of unsuccessful evaluations in a row
 	NumberOfFailuresInARow int `yaml:"-"`
 
 	// NumberOfSuccessesInARow is the number of successful evaluations in a row
 	NumberOfSuccessesInARow int `yaml:"-"`
+
+	// LastSuccessTimestamp is the timestamp of the last successful evaluation.
+	// See Endpoint.LastSuccessTimestamp for more information.
+	LastSuccessTimestamp time.Time `yaml:"-"`
+
+	// DownSince is the timestamp of the first failed evaluation of the current outage.
+	// See Endpoint.DownSince for more information.
+	DownSince time.Time `yaml:"-"`
+
+	// Tenant is the name of the tenant the endpoint belongs to, if any.
+	// See Endpoint.Tenant for more information.
+	Tenant string `yaml:"-"`
+
+	// PageURL is the URL of the page of the endpoint on the dashboard.
+	// See Endpoint.PageURL for more information.
+	PageURL string `yaml:"-"`
+
+	lastResultReceivedAt      time.Time
+	lastResultReceivedAtMutex sync.RWMutex
 }
 
 // ValidateAndSetDefaults validates the ExternalEndpoint and sets the default values
@@ -45,9 +83,32 @@ func (externalEndpoint *ExternalEndpoint) ValidateAndSetDefaults() error {
 	if len(externalEndpoint.Token) == 0 {
 		return ErrExternalEndpointWithNoToken
 	}
+	if hasSLOBurnRateAlert(externalEndpoint.Alerts) {
+		return ErrEndpointWithSLOBurnRateAlertButNoSLO
+	}
+	if externalEndpoint.HeartbeatInterval != 0 && externalEndpoint.HeartbeatInterval < MinimumHeartbeatInterval {
+		return ErrExternalEndpointWithInvalidHeartbeatInterval
+	}
 	return nil
 }
 
+// MarkResultReceived records that a result was pushed to the endpoint at the time passed
+func (externalEndpoint *ExternalEndpoint) MarkResultReceived(receivedAt time.Time) {
+	externalEndpoint.lastResultReceivedAtMutex.Lock()
+	defer externalEndpoint.lastResultReceivedAtMutex.Unlock()
+	if receivedAt.After(externalEndpoint.lastResultReceivedAt) {
+		externalEndpoint.lastResultReceivedAt = receivedAt
+	}
+}
+
+// LastResultReceivedAt returns the time at which a result was last pushed to the endpoint, or the zero time if no
+// result has been pushed since Gatus started
+func (externalEndpoint *ExternalEndpoint) LastResultReceivedAt() time.Time {
+	externalEndpoint.lastResultReceivedAtMutex.RLock()
+	defer externalEndpoint.lastResultReceivedAtMutex.RUnlock()
+	return externalEndpoint.lastResultReceivedAt
+}
+
 // IsEnabled returns whether the endpoint is enabled or not
 func (externalEndpoint *ExternalEndpoint) IsEnabled() bool {
 	if externalEndpoint.Enabled == nil {
@@ -66,7 +127,7 @@ func (externalEndpoint *ExternalEndpoint) DisplayName() string {
 
 // Key returns the unique key for the Endpoint
 func (externalEndpoint *ExternalEndpoint) Key() string {
-	return ConvertGroupAndEndpointNameToKey(externalEndpoint.Group, externalEndpoint.Name)
+	return ConvertTenantGroupAndEndpointNameToKey(externalEndpoint.Tenant, externalEndpoint.Group, externalEndpoint.Name)
 }
 
 // ToEndpoint converts the ExternalEndpoint to an Endpoint
@@ -78,6 +139,10 @@ func (externalEndpoint *ExternalEndpoint) ToEndpoint() *Endpoint {
 		Alerts:                  externalEndpoint.Alerts,
 		NumberOfFailuresInARow:  externalEndpoint.NumberOfFailuresInARow,
 		NumberOfSuccessesInARow: externalEndpoint.NumberOfSuccessesInARow,
+		LastSuccessTimestamp:    externalEndpoint.LastSuccessTimestamp,
+		DownSince:               externalEndpoint.DownSince,
+		Tenant:                  externalEndpoint.Tenant,
+		PageURL:                 externalEndpoint.PageURL,
 	}
 	return endpoint
 }
@@ -0,0 +1,83 @@
+package endpoint
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/pattern"
+)
+
+var (
+	// ErrExternalEndpointAutoCreateRuleWithNoGroup is the error with which Gatus will panic if an external endpoint
+	// auto-create rule is configured without a group.
+	ErrExternalEndpointAutoCreateRuleWithNoGroup = errors.New("you must specify a group for each external endpoint auto-create rule")
+
+	// ErrExternalEndpointAutoCreateRuleWithNoToken is the error with which Gatus will panic if an external endpoint
+	// auto-create rule is configured without a token.
+	ErrExternalEndpointAutoCreateRuleWithNoToken = errors.New("you must specify a token for each external endpoint auto-create rule")
+)
+
+// ExternalEndpointAutoCreateRule is a rule under which an ExternalEndpoint that isn't configured is created the first
+// time a result is pushed to it, which saves from having to configure every external endpoint of a group one by one
+// (e.g. when there are hundreds of cron jobs reporting their executions)
+type ExternalEndpointAutoCreateRule struct {
+	// Group of the external endpoints created by the rule
+	Group string `yaml:"group"`
+
+	// Name is the pattern that the name of an external endpoint must match to be created by the rule, as it appears
+	// in its key (e.g. "backup-*"). Defaults to "*", which matches every name.
+	Name string `yaml:"name,omitempty"`
+
+	// Token is the bearer token that must be provided through the Authorization header to push results to the
+	// external endpoints created by the rule
+	Token string `yaml:"token"`
+
+	// Alerts is the alerting configuration of the external endpoints created by the rule
+	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the ExternalEndpointAutoCreateRule and sets the default values
+func (rule *ExternalEndpointAutoCreateRule) ValidateAndSetDefaults() error {
+	if len(rule.Group) == 0 {
+		return ErrExternalEndpointAutoCreateRuleWithNoGroup
+	}
+	if len(rule.Token) == 0 {
+		return ErrExternalEndpointAutoCreateRuleWithNoToken
+	}
+	if len(rule.Name) == 0 {
+		rule.Name = "*"
+	}
+	return validateEndpointNameGroupAndAlerts(rule.Name, rule.Group, rule.Alerts)
+}
+
+// NewExternalEndpoint returns the external endpoint with the given key if it matches the rule, or nil otherwise.
+//
+// Because the key is all there is to go by, the name of the external endpoint is the name as it appears in the key.
+func (rule *ExternalEndpointAutoCreateRule) NewExternalEndpoint(key string) *ExternalEndpoint {
+	// The endpoints of tenants are never created by a rule
+	if len(ExtractTenantFromKey(key)) > 0 {
+		return nil
+	}
+	name, found := strings.CutPrefix(key, sanitize(rule.Group)+"_")
+	if !found || len(name) == 0 || !pattern.Match(rule.Name, name) {
+		return nil
+	}
+	// Each external endpoint needs its own alerts, since their state is tracked per endpoint
+	alerts := make([]*alert.Alert, 0, len(rule.Alerts))
+	for _, ruleAlert := range rule.Alerts {
+		endpointAlert := *ruleAlert
+		alerts = append(alerts, &endpointAlert)
+	}
+	externalEndpoint := &ExternalEndpoint{
+		Name:   name,
+		Group:  rule.Group,
+		Token:  rule.Token,
+		Alerts: alerts,
+	}
+	// Keys that aren't sanitized (e.g. with uppercase letters) would lead to an external endpoint with another key
+	if externalEndpoint.Key() != key {
+		return nil
+	}
+	return externalEndpoint
+}
@@ -0,0 +1,93 @@
+package endpoint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+func TestExternalEndpointAutoCreateRule_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		rule          *ExternalEndpointAutoCreateRule
+		expectedName  string
+		expectedError error
+	}{
+		{
+			name:         "defaults",
+			rule:         &ExternalEndpointAutoCreateRule{Group: "jobs", Token: "token"},
+			expectedName: "*",
+		},
+		{
+			name:         "name",
+			rule:         &ExternalEndpointAutoCreateRule{Group: "jobs", Name: "backup-*", Token: "token"},
+			expectedName: "backup-*",
+		},
+		{
+			name:          "no-group",
+			rule:          &ExternalEndpointAutoCreateRule{Token: "token"},
+			expectedError: ErrExternalEndpointAutoCreateRuleWithNoGroup,
+		},
+		{
+			name:          "no-token",
+			rule:          &ExternalEndpointAutoCreateRule{Group: "jobs"},
+			expectedError: ErrExternalEndpointAutoCreateRuleWithNoToken,
+		},
+		{
+			name:          "invalid-group",
+			rule:          &ExternalEndpointAutoCreateRule{Group: `"jobs"`, Token: "token"},
+			expectedError: ErrEndpointWithInvalidNameOrGroup,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.rule.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if err == nil && scenario.rule.Name != scenario.expectedName {
+				t.Errorf("expected name %s, got %s", scenario.expectedName, scenario.rule.Name)
+			}
+		})
+	}
+}
+
+func TestExternalEndpointAutoCreateRule_NewExternalEndpoint(t *testing.T) {
+	rule := &ExternalEndpointAutoCreateRule{Group: "Cron Jobs", Name: "backup-*", Token: "token", Alerts: []*alert.Alert{{Type: alert.TypeSlack}}}
+	if err := rule.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	scenarios := []struct {
+		name         string
+		key          string
+		expectedName string
+	}{
+		{name: "match", key: "cron-jobs_backup-db", expectedName: "backup-db"},
+		{name: "other-name", key: "cron-jobs_cleanup"},
+		{name: "other-group", key: "jobs_backup-db"},
+		{name: "no-group", key: "_backup-db"},
+		{name: "tenant", key: "acme_cron-jobs_backup-db"},
+		{name: "unsanitized-key", key: "cron-jobs_backup-DB"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			externalEndpoint := rule.NewExternalEndpoint(scenario.key)
+			if len(scenario.expectedName) == 0 {
+				if externalEndpoint != nil {
+					t.Fatalf("expected no external endpoint, got %s", externalEndpoint.Key())
+				}
+				return
+			}
+			if externalEndpoint == nil {
+				t.Fatal("expected an external endpoint, got nil")
+			}
+			if externalEndpoint.Name != scenario.expectedName || externalEndpoint.Group != rule.Group || externalEndpoint.Key() != scenario.key || externalEndpoint.Token != rule.Token {
+				t.Errorf("unexpected external endpoint %+v", externalEndpoint)
+			}
+			if len(externalEndpoint.Alerts) != 1 || externalEndpoint.Alerts[0] == rule.Alerts[0] || externalEndpoint.Alerts[0].Type != alert.TypeSlack {
+				t.Errorf("expected the external endpoint to have a copy of the alerts of the rule, got %+v", externalEndpoint.Alerts)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package endpoint
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestExternalEndpoint_ToEndpoint(t *testing.T) {
@@ -23,3 +25,38 @@ func TestExternalEndpoint_ToEndpoint(t *testing.T) {
 		t.Errorf("expected %s, got %s", externalEndpoint.DisplayName(), convertedEndpoint.DisplayName())
 	}
 }
+
+func TestExternalEndpoint_ValidateAndSetDefaultsWithHeartbeatInterval(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		heartbeatInterval time.Duration
+		expectedErr       error
+	}{
+		{name: "disabled", heartbeatInterval: 0},
+		{name: "valid", heartbeatInterval: 5 * time.Minute},
+		{name: "too-low", heartbeatInterval: time.Second, expectedErr: ErrExternalEndpointWithInvalidHeartbeatInterval},
+		{name: "negative", heartbeatInterval: -time.Minute, expectedErr: ErrExternalEndpointWithInvalidHeartbeatInterval},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			externalEndpoint := &ExternalEndpoint{Name: "name", Token: "token", HeartbeatInterval: scenario.heartbeatInterval}
+			if err := externalEndpoint.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestExternalEndpoint_MarkResultReceived(t *testing.T) {
+	externalEndpoint := &ExternalEndpoint{Name: "name"}
+	if !externalEndpoint.LastResultReceivedAt().IsZero() {
+		t.Error("expected no result to have been received")
+	}
+	now := time.Now()
+	externalEndpoint.MarkResultReceived(now)
+	// Results may be processed out of order, in which case the most recent time must be kept
+	externalEndpoint.MarkResultReceived(now.Add(-time.Minute))
+	if !externalEndpoint.LastResultReceivedAt().Equal(now) {
+		t.Errorf("expected %s, got %s", now, externalEndpoint.LastResultReceivedAt())
+	}
+}
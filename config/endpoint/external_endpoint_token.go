@@ -0,0 +1,71 @@
+package endpoint
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// ExternalEndpointToken is a token created through the API with which results can be pushed to an external endpoint,
+// in addition to the token configured for it. Unlike the latter, such tokens can be created, expired and revoked
+// without changing the configuration, which allows rotating the credentials of agents one at a time.
+//
+// Only the hash of the token is persisted.
+type ExternalEndpointToken struct {
+	// ID of the token
+	ID int64 `json:"id"`
+
+	// EndpointKey is the key of the external endpoint the token grants access to
+	EndpointKey string `json:"endpointKey"`
+
+	// Name describes what the token is used by (e.g. the name of the agent)
+	Name string `json:"name"`
+
+	// Hash is the SHA-256 hash of the token
+	Hash string `json:"-"`
+
+	// CreatedAt is when the token was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt is when the token expires. If nil, the token never expires.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// RevokedAt is when the token was revoked. If nil, the token hasn't been revoked.
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// NewExternalEndpointToken generates a token for an external endpoint and returns the token itself along with the
+// ExternalEndpointToken to persist. If duration is 0, the token never expires.
+func NewExternalEndpointToken(externalEndpoint *ExternalEndpoint, name string, duration time.Duration) (string, *ExternalEndpointToken, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+	token := hex.EncodeToString(secret)
+	externalEndpointToken := &ExternalEndpointToken{
+		EndpointKey: externalEndpoint.Key(),
+		Name:        name,
+		Hash:        HashExternalEndpointToken(token),
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	if duration > 0 {
+		expiresAt := externalEndpointToken.CreatedAt.Add(duration)
+		externalEndpointToken.ExpiresAt = &expiresAt
+	}
+	return token, externalEndpointToken, nil
+}
+
+// HashExternalEndpointToken returns the hash of a token, which is what's persisted and compared
+func HashExternalEndpointToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// IsActive returns whether the token can be used to push results, which is the case unless it expired or was revoked
+func (t *ExternalEndpointToken) IsActive() bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || time.Now().Before(*t.ExpiresAt)
+}
@@ -0,0 +1,71 @@
+package endpoint
+
+import (
+	"strings"
+)
+
+// FailureReason is the category of the root cause of an unsuccessful Result
+type FailureReason string
+
+const (
+	// FailureReasonDNS is the reason of failures caused by the resolution of the hostname
+	FailureReasonDNS FailureReason = "DNS"
+
+	// FailureReasonConnectionRefused is the reason of failures caused by the host refusing the connection
+	FailureReasonConnectionRefused FailureReason = "CONNECTION_REFUSED"
+
+	// FailureReasonTimeout is the reason of failures caused by the host not responding in time
+	FailureReasonTimeout FailureReason = "TIMEOUT"
+
+	// FailureReasonTLS is the reason of failures caused by the TLS handshake or the certificate of the host
+	FailureReasonTLS FailureReason = "TLS"
+
+	// FailureReasonCondition is the reason of failures caused by one or more conditions not being met, while no error
+	// was encountered
+	FailureReasonCondition FailureReason = "CONDITION"
+
+	// FailureReasonOther is the reason of failures caused by an error that doesn't fall in any other category
+	FailureReasonOther FailureReason = "OTHER"
+)
+
+var (
+	// failureReasonsByErrorSubstring maps substrings of the errors to the reason of the failure they indicate.
+	//
+	// The order matters: a timeout during the TLS handshake, for instance, is classified as a timeout.
+	failureReasonsByErrorSubstring = []struct {
+		substrings []string
+		reason     FailureReason
+	}{
+		{substrings: []string{"no such host", "server misbehaving", "lookup "}, reason: FailureReasonDNS},
+		{substrings: []string{"connection refused"}, reason: FailureReasonConnectionRefused},
+		{substrings: []string{"timeout", "deadline exceeded", "timed out"}, reason: FailureReasonTimeout},
+		{substrings: []string{"tls:", "x509:", "certificate"}, reason: FailureReasonTLS},
+	}
+)
+
+// ClassifyFailure sets the FailureReason of the result based on its errors if the result is unsuccessful.
+//
+// Errors are classified from their message rather than from their type, because results executed on a runner or
+// pushed by an external endpoint only have the message of their errors.
+func (r *Result) ClassifyFailure() {
+	if r.Success {
+		r.FailureReason = ""
+		return
+	}
+	if len(r.Errors) == 0 {
+		r.FailureReason = FailureReasonCondition
+		return
+	}
+	for _, candidate := range failureReasonsByErrorSubstring {
+		for _, resultError := range r.Errors {
+			lowerCaseError := strings.ToLower(resultError)
+			for _, substring := range candidate.substrings {
+				if strings.Contains(lowerCaseError, substring) {
+					r.FailureReason = candidate.reason
+					return
+				}
+			}
+		}
+	}
+	r.FailureReason = FailureReasonOther
+}
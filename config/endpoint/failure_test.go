@@ -0,0 +1,62 @@
+package endpoint
+
+import (
+	"testing"
+)
+
+func TestResult_ClassifyFailure(t *testing.T) {
+	scenarios := []struct {
+		name                  string
+		result                *Result
+		expectedFailureReason FailureReason
+	}{
+		{
+			name:                  "success",
+			result:                &Result{Success: true, FailureReason: FailureReasonOther},
+			expectedFailureReason: "",
+		},
+		{
+			name:                  "dns",
+			result:                &Result{Errors: []string{"lookup example.invalid on 127.0.0.53:53: no such host"}},
+			expectedFailureReason: FailureReasonDNS,
+		},
+		{
+			name:                  "connection-refused",
+			result:                &Result{Errors: []string{`Get "http://127.0.0.1:1": dial tcp 127.0.0.1:1: connect: connection refused`}},
+			expectedFailureReason: FailureReasonConnectionRefused,
+		},
+		{
+			name:                  "timeout",
+			result:                &Result{Errors: []string{`Get "https://example.org": context deadline exceeded (Client.Timeout exceeded while awaiting headers)`}},
+			expectedFailureReason: FailureReasonTimeout,
+		},
+		{
+			name:                  "tls-handshake-timeout",
+			result:                &Result{Errors: []string{`Get "https://example.org": net/http: TLS handshake timeout`}},
+			expectedFailureReason: FailureReasonTimeout,
+		},
+		{
+			name:                  "tls",
+			result:                &Result{Errors: []string{`Get "https://expired.badssl.com": tls: failed to verify certificate: x509: certificate has expired or is not yet valid`}},
+			expectedFailureReason: FailureReasonTLS,
+		},
+		{
+			name:                  "condition",
+			result:                &Result{Errors: []string{}, ConditionResults: []*ConditionResult{{Condition: "[STATUS] == 200", Success: false}}},
+			expectedFailureReason: FailureReasonCondition,
+		},
+		{
+			name:                  "other",
+			result:                &Result{Errors: []string{"function returned an error: Unhandled"}},
+			expectedFailureReason: FailureReasonOther,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			scenario.result.ClassifyFailure()
+			if scenario.result.FailureReason != scenario.expectedFailureReason {
+				t.Errorf("expected failure reason %q, got %q", scenario.expectedFailureReason, scenario.result.FailureReason)
+			}
+		})
+	}
+}
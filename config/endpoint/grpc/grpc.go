@@ -0,0 +1,8 @@
+package grpc
+
+// Config is the configuration for monitoring a gRPC server through the gRPC Health Checking Protocol
+type Config struct {
+	// Service is the name of the service whose health must be checked (e.g. "my.package.MyService").
+	// If empty, the overall health of the server is checked.
+	Service string `yaml:"service,omitempty"`
+}
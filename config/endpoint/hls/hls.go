@@ -0,0 +1,8 @@
+package hls
+
+// Config is the configuration for monitoring an HTTP Live Streaming (HLS) stream
+type Config struct {
+	// FetchSegment is whether the most recent media segment of the playlist should be downloaded as well, to make sure
+	// that it is actually served and not just listed.
+	FetchSegment bool `yaml:"fetch-segment,omitempty"`
+}
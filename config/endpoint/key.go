@@ -7,6 +7,41 @@ func ConvertGroupAndEndpointNameToKey(groupName, endpointName string) string {
 	return sanitize(groupName) + "_" + sanitize(endpointName)
 }
 
+// ConvertTenantGroupAndEndpointNameToKey converts a tenant, a group and an endpoint to a key
+// If the tenant is empty, the key is the same as the one returned by ConvertGroupAndEndpointNameToKey
+func ConvertTenantGroupAndEndpointNameToKey(tenant, groupName, endpointName string) string {
+	if len(tenant) == 0 {
+		return ConvertGroupAndEndpointNameToKey(groupName, endpointName)
+	}
+	return sanitize(tenant) + "_" + ConvertGroupAndEndpointNameToKey(groupName, endpointName)
+}
+
+// ExtractTenantFromKey returns the tenant of the endpoint with the given key, or an empty string if the endpoint
+// doesn't belong to any tenant
+//
+// Because sanitize replaces underscores, a key has two underscores if and only if it has a tenant
+func ExtractTenantFromKey(key string) string {
+	if parts := strings.Split(key, "_"); len(parts) == 3 {
+		return parts[0]
+	}
+	return ""
+}
+
+// ExtractGroupFromKey returns the group of the endpoint with the given key as it appears in the key, which is the
+// sanitized group (e.g. "eu-core" for the group "EU Core"), or an empty string if the key has no group
+func ExtractGroupFromKey(key string) string {
+	if parts := strings.Split(key, "_"); len(parts) >= 2 {
+		return parts[len(parts)-2]
+	}
+	return ""
+}
+
+// SanitizeGroup returns the group as it appears in the key of its endpoints.
+// See ExtractGroupFromKey for more information.
+func SanitizeGroup(group string) string {
+	return sanitize(group)
+}
+
 func sanitize(s string) string {
 	s = strings.TrimSpace(strings.ToLower(s))
 	s = strings.ReplaceAll(s, "/", "-")
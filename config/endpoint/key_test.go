@@ -34,3 +34,46 @@ func TestConvertGroupAndEndpointNameToKey(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertTenantGroupAndEndpointNameToKey(t *testing.T) {
+	type Scenario struct {
+		Tenant         string
+		GroupName      string
+		EndpointName   string
+		ExpectedOutput string
+	}
+	scenarios := []Scenario{
+		{
+			Tenant:         "",
+			GroupName:      "Core",
+			EndpointName:   "Front End",
+			ExpectedOutput: "core_front-end",
+		},
+		{
+			Tenant:         "acme",
+			GroupName:      "Core",
+			EndpointName:   "Front End",
+			ExpectedOutput: "acme_core_front-end",
+		},
+		{
+			Tenant:         "acme",
+			GroupName:      "",
+			EndpointName:   "api",
+			ExpectedOutput: "acme__api",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.ExpectedOutput, func(t *testing.T) {
+			output := ConvertTenantGroupAndEndpointNameToKey(scenario.Tenant, scenario.GroupName, scenario.EndpointName)
+			if output != scenario.ExpectedOutput {
+				t.Errorf("expected '%s', got '%s'", scenario.ExpectedOutput, output)
+			}
+			if tenant := ExtractTenantFromKey(output); tenant != scenario.Tenant {
+				t.Errorf("expected tenant '%s', got '%s'", scenario.Tenant, tenant)
+			}
+			if group := ExtractGroupFromKey(output); group != SanitizeGroup(scenario.GroupName) {
+				t.Errorf("expected group '%s', got '%s'", SanitizeGroup(scenario.GroupName), group)
+			}
+		})
+	}
+}
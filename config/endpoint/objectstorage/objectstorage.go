@@ -0,0 +1,88 @@
+package objectstorage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SchemeS3 is the scheme of the URL of an object stored in Amazon S3 (e.g. s3://bucket/path/to/object)
+	SchemeS3 = "s3://"
+
+	// SchemeGCS is the scheme of the URL of an object stored in Google Cloud Storage (e.g. gs://bucket/path/to/object)
+	SchemeGCS = "gs://"
+
+	// SchemeAzureBlob is the scheme of the URL of a blob stored in Azure Blob Storage
+	// (e.g. azblob://account/container/path/to/blob)
+	SchemeAzureBlob = "azblob://"
+)
+
+var (
+	// ErrInvalidObjectURL is the error with which Gatus will panic if the URL of an object doesn't reference both a
+	// bucket (or a container) and an object
+	ErrInvalidObjectURL = errors.New("the url of an object must reference both a bucket and an object (e.g. s3://bucket/path/to/object)")
+
+	// ErrSASTokenWithoutAzureBlob is the error with which Gatus will panic if a SAS token is configured for an object
+	// not stored in Azure Blob Storage
+	ErrSASTokenWithoutAzureBlob = errors.New("sas-token is only supported for objects stored in Azure Blob Storage")
+)
+
+// Config is the configuration for monitoring an object in an object storage
+type Config struct {
+	// SASToken is the shared access signature used to access a blob stored in Azure Blob Storage
+	// (e.g. sv=2022-11-02&ss=b&srt=o&sp=r&se=2030-01-01T00:00:00Z&sig=...)
+	SASToken string `yaml:"sas-token,omitempty"`
+}
+
+// IsObjectStorageURL returns whether the URL passed references an object in an object storage
+func IsObjectStorageURL(url string) bool {
+	return strings.HasPrefix(url, SchemeS3) || strings.HasPrefix(url, SchemeGCS) || strings.HasPrefix(url, SchemeAzureBlob)
+}
+
+// Validate the object storage configuration against the URL of the object
+func (cfg *Config) Validate(url string) error {
+	if _, _, err := splitObjectURL(url); err != nil {
+		return err
+	}
+	if len(cfg.SASToken) > 0 && !strings.HasPrefix(url, SchemeAzureBlob) {
+		return ErrSASTokenWithoutAzureBlob
+	}
+	return nil
+}
+
+// HTTPURL returns the URL of the object passed on the HTTP API of its object storage.
+//
+// S3 objects use virtual-hosted-style URLs, which is why the region of the bucket is required.
+func (cfg *Config) HTTPURL(url, region string) (string, error) {
+	bucket, object, err := splitObjectURL(url)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case strings.HasPrefix(url, SchemeS3):
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, region, object), nil
+	case strings.HasPrefix(url, SchemeGCS):
+		return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, object), nil
+	default:
+		// For Azure Blob Storage, the bucket is the storage account and the object includes the container
+		httpURL := fmt.Sprintf("https://%s.blob.core.windows.net/%s", bucket, object)
+		if len(cfg.SASToken) > 0 {
+			httpURL += "?" + strings.TrimPrefix(cfg.SASToken, "?")
+		}
+		return httpURL, nil
+	}
+}
+
+func splitObjectURL(url string) (bucket, object string, err error) {
+	for _, scheme := range []string{SchemeS3, SchemeGCS, SchemeAzureBlob} {
+		if strings.HasPrefix(url, scheme) {
+			bucket, object, _ = strings.Cut(strings.TrimPrefix(url, scheme), "/")
+			if len(bucket) == 0 || len(object) == 0 || (scheme == SchemeAzureBlob && !strings.Contains(object, "/")) {
+				return "", "", ErrInvalidObjectURL
+			}
+			return bucket, object, nil
+		}
+	}
+	return "", "", ErrInvalidObjectURL
+}
@@ -0,0 +1,64 @@
+package objectstorage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		url           string
+		cfg           *Config
+		expectedError error
+	}{
+		{name: "s3", url: "s3://bucket/backups/db.tar.gz", cfg: &Config{}},
+		{name: "gcs", url: "gs://bucket/backups/db.tar.gz", cfg: &Config{}},
+		{name: "azure-blob", url: "azblob://account/container/db.tar.gz", cfg: &Config{SASToken: "sv=2022-11-02&sig=abc"}},
+		{name: "no-object", url: "s3://bucket", cfg: &Config{}, expectedError: ErrInvalidObjectURL},
+		{name: "no-bucket", url: "gs:///db.tar.gz", cfg: &Config{}, expectedError: ErrInvalidObjectURL},
+		{name: "azure-blob-without-container", url: "azblob://account/db.tar.gz", cfg: &Config{}, expectedError: ErrInvalidObjectURL},
+		{name: "sas-token-without-azure-blob", url: "s3://bucket/db.tar.gz", cfg: &Config{SASToken: "sig=abc"}, expectedError: ErrSASTokenWithoutAzureBlob},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.Validate(scenario.url); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestConfig_HTTPURL(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		url         string
+		cfg         *Config
+		expectedURL string
+	}{
+		{name: "s3", url: "s3://bucket/backups/db.tar.gz", cfg: &Config{}, expectedURL: "https://bucket.s3.eu-west-1.amazonaws.com/backups/db.tar.gz"},
+		{name: "gcs", url: "gs://bucket/backups/db.tar.gz", cfg: &Config{}, expectedURL: "https://storage.googleapis.com/bucket/backups/db.tar.gz"},
+		{name: "azure-blob", url: "azblob://account/container/db.tar.gz", cfg: &Config{}, expectedURL: "https://account.blob.core.windows.net/container/db.tar.gz"},
+		{name: "azure-blob-with-sas-token", url: "azblob://account/container/db.tar.gz", cfg: &Config{SASToken: "?sv=2022-11-02&sig=abc"}, expectedURL: "https://account.blob.core.windows.net/container/db.tar.gz?sv=2022-11-02&sig=abc"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			httpURL, err := scenario.cfg.HTTPURL(scenario.url, "eu-west-1")
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if httpURL != scenario.expectedURL {
+				t.Errorf("expected %s, got %s", scenario.expectedURL, httpURL)
+			}
+		})
+	}
+}
+
+func TestIsObjectStorageURL(t *testing.T) {
+	if !IsObjectStorageURL("s3://bucket/object") || !IsObjectStorageURL("gs://bucket/object") || !IsObjectStorageURL("azblob://account/container/blob") {
+		t.Error("expected object storage URLs to be detected")
+	}
+	if IsObjectStorageURL("https://example.org/object") {
+		t.Error("expected HTTP URL not to be detected as an object storage URL")
+	}
+}
@@ -0,0 +1,192 @@
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+const (
+	// DefaultOverrideDuration is the duration for which an override applies if no duration is specified
+	DefaultOverrideDuration = time.Hour
+
+	// MaximumOverrideDuration is the longest duration for which an override may apply
+	MaximumOverrideDuration = 7 * 24 * time.Hour
+)
+
+var (
+	// ErrOverrideWithNoChange is the error returned when an override doesn't override anything
+	ErrOverrideWithNoChange = errors.New("an override must specify at least one of interval, conditions, failure threshold or success threshold")
+
+	// ErrOverrideWithInvalidInterval is the error returned when an override has a negative interval
+	ErrOverrideWithInvalidInterval = errors.New("the interval of an override must be positive")
+
+	// ErrOverrideWithInvalidThreshold is the error returned when an override has a negative threshold
+	ErrOverrideWithInvalidThreshold = errors.New("the thresholds of an override must be positive")
+
+	// ErrOverrideWithInvalidExpiration is the error returned when an override has already expired or expires too far
+	// in the future
+	ErrOverrideWithInvalidExpiration = fmt.Errorf("an override must expire in the future and within %s", MaximumOverrideDuration)
+
+	// ErrOverrideWithThresholdsButNoAlerts is the error returned when an override changes the thresholds of an endpoint
+	// that has no alerts
+	ErrOverrideWithThresholdsButNoAlerts = errors.New("cannot override the thresholds of an endpoint with no alerts")
+)
+
+// overridesMutex guards the override of every endpoint, since overrides are set by the API while endpoints are
+// being monitored
+var overridesMutex sync.RWMutex
+
+// Override is a temporary change of the configuration of an endpoint, which is applied at runtime and automatically
+// discarded once it expires
+type Override struct {
+	// Interval overrides the interval of the endpoint
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// Conditions overrides the conditions of the endpoint
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// FailureThreshold overrides the failure threshold of every alert of the endpoint
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold overrides the success threshold of every alert of the endpoint
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+
+	// ExpiresAt is the time at which the override stops applying
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// IsExpired returns whether the override has expired
+func (o *Override) IsExpired() bool {
+	return !time.Now().Before(o.ExpiresAt)
+}
+
+// ValidateOverride validates an override against the configuration of the endpoint
+func (e *Endpoint) ValidateOverride(o *Override) error {
+	if o.Interval == 0 && len(o.Conditions) == 0 && o.FailureThreshold == 0 && o.SuccessThreshold == 0 {
+		return ErrOverrideWithNoChange
+	}
+	if o.Interval < 0 {
+		return ErrOverrideWithInvalidInterval
+	}
+	if o.FailureThreshold < 0 || o.SuccessThreshold < 0 {
+		return ErrOverrideWithInvalidThreshold
+	}
+	if (o.FailureThreshold > 0 || o.SuccessThreshold > 0) && len(e.Alerts) == 0 {
+		return ErrOverrideWithThresholdsButNoAlerts
+	}
+	if o.IsExpired() || time.Until(o.ExpiresAt) > MaximumOverrideDuration {
+		return ErrOverrideWithInvalidExpiration
+	}
+	interval, conditions := e.Interval, e.Conditions
+	if o.Interval > 0 {
+		interval = o.Interval
+	}
+	if len(o.Conditions) > 0 {
+		conditions = o.Conditions
+	}
+	for _, c := range conditions {
+		if interval < 5*time.Minute && c.hasDomainExpirationPlaceholder() {
+			return ErrInvalidEndpointIntervalForDomainExpirationPlaceholder
+		}
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("%v: %w", ErrInvalidConditionFormat, err)
+		}
+	}
+	return nil
+}
+
+// SetOverride applies an override to the endpoint, replacing the existing one, if any.
+// The override passed should've been validated using ValidateOverride beforehand.
+func (e *Endpoint) SetOverride(o *Override) {
+	overridesMutex.Lock()
+	defer overridesMutex.Unlock()
+	e.override = o
+	e.notifyOverrideChanged()
+}
+
+// ClearOverride discards the override of the endpoint, if any, and returns whether there was one
+func (e *Endpoint) ClearOverride() bool {
+	overridesMutex.Lock()
+	defer overridesMutex.Unlock()
+	if e.override == nil {
+		return false
+	}
+	hadOverride := !e.override.IsExpired()
+	e.override = nil
+	e.notifyOverrideChanged()
+	return hadOverride
+}
+
+// GetOverride returns the override of the endpoint, or nil if the endpoint has no override or if it expired
+func (e *Endpoint) GetOverride() *Override {
+	overridesMutex.RLock()
+	defer overridesMutex.RUnlock()
+	if e.override == nil || e.override.IsExpired() {
+		return nil
+	}
+	return e.override
+}
+
+// Copy returns a shallow copy of the endpoint
+func (e *Endpoint) Copy() *Endpoint {
+	overridesMutex.RLock()
+	defer overridesMutex.RUnlock()
+	endpointCopy := *e
+	// Only the endpoint itself may notify those waiting for its override to change
+	endpointCopy.overrideChanged = nil
+	return &endpointCopy
+}
+
+// OverrideChanged returns a channel that is closed the next time the override of the endpoint is set or cleared
+func (e *Endpoint) OverrideChanged() <-chan struct{} {
+	overridesMutex.Lock()
+	defer overridesMutex.Unlock()
+	if e.overrideChanged == nil {
+		e.overrideChanged = make(chan struct{})
+	}
+	return e.overrideChanged
+}
+
+// notifyOverrideChanged must be called while holding overridesMutex
+func (e *Endpoint) notifyOverrideChanged() {
+	if e.overrideChanged != nil {
+		close(e.overrideChanged)
+		e.overrideChanged = nil
+	}
+}
+
+// EffectiveInterval returns the interval of the endpoint, taking its override into account
+func (e *Endpoint) EffectiveInterval() time.Duration {
+	if o := e.GetOverride(); o != nil && o.Interval > 0 {
+		return o.Interval
+	}
+	return e.Interval
+}
+
+// EffectiveConditions returns the conditions of the endpoint, taking its override into account
+func (e *Endpoint) EffectiveConditions() []Condition {
+	if o := e.GetOverride(); o != nil && len(o.Conditions) > 0 {
+		return o.Conditions
+	}
+	return e.Conditions
+}
+
+// FailureThresholdOf returns the failure threshold of an alert of the endpoint, taking its override into account
+func (e *Endpoint) FailureThresholdOf(a *alert.Alert) int {
+	if o := e.GetOverride(); o != nil && o.FailureThreshold > 0 {
+		return o.FailureThreshold
+	}
+	return a.FailureThreshold
+}
+
+// SuccessThresholdOf returns the success threshold of an alert of the endpoint, taking its override into account
+func (e *Endpoint) SuccessThresholdOf(a *alert.Alert) int {
+	if o := e.GetOverride(); o != nil && o.SuccessThreshold > 0 {
+		return o.SuccessThreshold
+	}
+	return a.SuccessThreshold
+}
@@ -0,0 +1,156 @@
+package endpoint
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+func TestEndpoint_ValidateOverride(t *testing.T) {
+	in := time.Now().Add(time.Hour)
+	scenarios := []struct {
+		name          string
+		endpoint      *Endpoint
+		override      *Override
+		expectedError error
+	}{
+		{
+			name:          "interval",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{Interval: 10 * time.Second, ExpiresAt: in},
+			expectedError: nil,
+		},
+		{
+			name:          "conditions",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{Conditions: []Condition{"[RESPONSE_TIME] < 500"}, ExpiresAt: in},
+			expectedError: nil,
+		},
+		{
+			name:          "thresholds",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}, Alerts: []*alert.Alert{{Type: alert.TypeSlack}}},
+			override:      &Override{FailureThreshold: 1, SuccessThreshold: 1, ExpiresAt: in},
+			expectedError: nil,
+		},
+		{
+			name:          "no-change",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{ExpiresAt: in},
+			expectedError: ErrOverrideWithNoChange,
+		},
+		{
+			name:          "negative-interval",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{Interval: -time.Second, ExpiresAt: in},
+			expectedError: ErrOverrideWithInvalidInterval,
+		},
+		{
+			name:          "negative-threshold",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}, Alerts: []*alert.Alert{{Type: alert.TypeSlack}}},
+			override:      &Override{FailureThreshold: -1, ExpiresAt: in},
+			expectedError: ErrOverrideWithInvalidThreshold,
+		},
+		{
+			name:          "thresholds-without-alerts",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{FailureThreshold: 1, ExpiresAt: in},
+			expectedError: ErrOverrideWithThresholdsButNoAlerts,
+		},
+		{
+			name:          "expired",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{Interval: 10 * time.Second, ExpiresAt: time.Now().Add(-time.Second)},
+			expectedError: ErrOverrideWithInvalidExpiration,
+		},
+		{
+			name:          "expires-too-late",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{Interval: 10 * time.Second, ExpiresAt: time.Now().Add(MaximumOverrideDuration + time.Hour)},
+			expectedError: ErrOverrideWithInvalidExpiration,
+		},
+		{
+			name:          "invalid-condition",
+			endpoint:      &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}},
+			override:      &Override{Conditions: []Condition{"[STATUS] invalid"}, ExpiresAt: in},
+			expectedError: ErrInvalidConditionFormat,
+		},
+		{
+			name:          "interval-too-short-for-domain-expiration",
+			endpoint:      &Endpoint{Interval: 10 * time.Minute, Conditions: []Condition{"[DOMAIN_EXPIRATION] > 720h"}},
+			override:      &Override{Interval: time.Minute, ExpiresAt: in},
+			expectedError: ErrInvalidEndpointIntervalForDomainExpirationPlaceholder,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.endpoint.ValidateOverride(scenario.override)
+			if scenario.expectedError == nil && err != nil || scenario.expectedError != nil && (err == nil || !strings.HasPrefix(err.Error(), scenario.expectedError.Error())) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestEndpoint_SetOverride(t *testing.T) {
+	successAlert := &alert.Alert{FailureThreshold: 3, SuccessThreshold: 2}
+	ep := &Endpoint{Interval: time.Minute, Conditions: []Condition{"[STATUS] == 200"}, Alerts: []*alert.Alert{successAlert}}
+	if ep.GetOverride() != nil {
+		t.Error("expected no override")
+	}
+	changed := ep.OverrideChanged()
+	ep.SetOverride(&Override{Interval: 5 * time.Second, Conditions: []Condition{"[STATUS] == 201"}, FailureThreshold: 1, ExpiresAt: time.Now().Add(time.Hour)})
+	select {
+	case <-changed:
+	default:
+		t.Error("expected channel to be closed after the override was set")
+	}
+	if ep.EffectiveInterval() != 5*time.Second {
+		t.Errorf("expected effective interval to be 5s, got %s", ep.EffectiveInterval())
+	}
+	if conditions := ep.EffectiveConditions(); len(conditions) != 1 || conditions[0] != "[STATUS] == 201" {
+		t.Errorf("expected effective conditions to be overridden, got %v", conditions)
+	}
+	if ep.FailureThresholdOf(successAlert) != 1 {
+		t.Errorf("expected failure threshold to be 1, got %d", ep.FailureThresholdOf(successAlert))
+	}
+	if ep.SuccessThresholdOf(successAlert) != 2 {
+		t.Errorf("expected success threshold to be 2, got %d", ep.SuccessThresholdOf(successAlert))
+	}
+	changed = ep.OverrideChanged()
+	if !ep.ClearOverride() {
+		t.Error("expected ClearOverride to return true")
+	}
+	select {
+	case <-changed:
+	default:
+		t.Error("expected channel to be closed after the override was cleared")
+	}
+	if ep.ClearOverride() {
+		t.Error("expected ClearOverride to return false, because the override was already cleared")
+	}
+	if ep.EffectiveInterval() != time.Minute {
+		t.Errorf("expected effective interval to be 1m, got %s", ep.EffectiveInterval())
+	}
+	if conditions := ep.EffectiveConditions(); len(conditions) != 1 || conditions[0] != "[STATUS] == 200" {
+		t.Errorf("expected effective conditions to be the ones configured, got %v", conditions)
+	}
+	if ep.FailureThresholdOf(successAlert) != 3 {
+		t.Errorf("expected failure threshold to be 3, got %d", ep.FailureThresholdOf(successAlert))
+	}
+}
+
+func TestEndpoint_GetOverrideWhenExpired(t *testing.T) {
+	ep := &Endpoint{Interval: time.Minute}
+	ep.SetOverride(&Override{Interval: 5 * time.Second, ExpiresAt: time.Now().Add(-time.Second)})
+	if ep.GetOverride() != nil {
+		t.Error("expected expired override to be ignored")
+	}
+	if ep.EffectiveInterval() != time.Minute {
+		t.Errorf("expected effective interval to be 1m, got %s", ep.EffectiveInterval())
+	}
+	if ep.ClearOverride() {
+		t.Error("expected ClearOverride to return false, because the override had expired")
+	}
+}
@@ -1,7 +1,15 @@
 package endpoint
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"strings"
 	"time"
+
+	"github.com/TwiN/gatus/v5/client"
 )
 
 // Result of the evaluation of a Endpoint
@@ -14,6 +22,11 @@ type Result struct {
 	// Possible values: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
 	DNSRCode string `json:"-"`
 
+	// GRPCHealthStatus is the serving status reported by a gRPC server through the gRPC Health Checking Protocol
+	//
+	// Possible values: SERVING, NOT_SERVING, SERVICE_UNKNOWN, UNKNOWN
+	GRPCHealthStatus string `json:"-"`
+
 	// Hostname extracted from Endpoint.URL
 	Hostname string `json:"hostname,omitempty"`
 
@@ -35,15 +48,74 @@ type Result struct {
 	// Success whether the result signifies a success or not
 	Success bool `json:"success"`
 
+	// FailureReason is the category of the root cause of the failure, if the result is unsuccessful
+	FailureReason FailureReason `json:"failureReason,omitempty"`
+
 	// Timestamp when the request was sent
 	Timestamp time.Time `json:"timestamp"`
 
+	// ExceededResponseTimeObjective is whether the result is successful, but slower than the response time of the
+	// service level objective of the endpoint, which makes it a bad event for the service level objective
+	//
+	// Note that this field is not persisted in the storage. It is only accounted for in the uptime data.
+	ExceededResponseTimeObjective bool `json:"-"`
+
 	// CertificateExpiration is the duration before the certificate expires
 	CertificateExpiration time.Duration `json:"-"`
 
+	// DNSRecords are the records of the answer to the DNS query, if the endpoint is of type DNS
+	DNSRecords []client.DNSRecord `json:"-"`
+
+	// CertificateFingerprint is the SHA-256 fingerprint of the certificate, formatted as colon-separated pairs of
+	// uppercase hexadecimal digits like OpenSSL does
+	CertificateFingerprint string `json:"-"`
+
+	// TLSVersion is the version of TLS negotiated with the server (e.g. 1.3)
+	TLSVersion string `json:"-"`
+
 	// DomainExpiration is the duration before the domain expires
 	DomainExpiration time.Duration `json:"-"`
 
+	// RedirectLocation is the Location header of the last redirect response received
+	RedirectLocation string `json:"-"`
+
+	// RedirectCount is the number of redirects followed
+	RedirectCount int `json:"-"`
+
+	// BodySize is the size of the response body in bytes, or -1 if it is unknown
+	BodySize int64 `json:"-"`
+
+	// LastModified is the time at which the resource was last modified according to the Last-Modified header
+	LastModified time.Time `json:"-"`
+
+	// TTFB is the time between the moment the request was sent and the moment the first byte of the response was
+	// received, which is only measured if the endpoint has download set
+	TTFB time.Duration `json:"-"`
+
+	// BytesRead is the number of bytes of the response body that were downloaded, which is only set if the endpoint
+	// has download set
+	BytesRead int64 `json:"-"`
+
+	// DownloadSpeed is the speed at which the response body was downloaded, in bytes per second, which is only set if
+	// the endpoint has download set
+	DownloadSpeed int64 `json:"-"`
+
+	// LatestSegmentAt is the time at which the most recent media segment of an HLS stream ends, which is zero if it
+	// couldn't be determined
+	LatestSegmentAt time.Time `json:"-"`
+
+	// SegmentCount is the number of media segments listed in the playlist of an HLS stream
+	SegmentCount int `json:"-"`
+
+	// UnconfirmedErrors are the errors of the failure that preceded this result if the failure wasn't confirmed by
+	// the double check of the endpoint, in which case this is the result of the double check
+	//
+	// Note that this field is not persisted in the storage.
+	UnconfirmedErrors []string `json:"-"`
+
+	// Headers are the headers of the response
+	Headers http.Header `json:"-"`
+
 	// Body is the response body
 	//
 	// Note that this field is not persisted in the storage.
@@ -62,3 +134,23 @@ func (r *Result) AddError(error string) {
 	}
 	r.Errors = append(r.Errors, error)
 }
+
+// setTLSConnectionState sets the TLS version as well as the expiration and fingerprint of the certificate of the
+// server from the state of a TLS connection
+func (r *Result) setTLSConnectionState(state *tls.ConnectionState) {
+	r.TLSVersion = strings.TrimPrefix(tls.VersionName(state.Version), "TLS ")
+	if len(state.PeerCertificates) > 0 {
+		r.setCertificate(state.PeerCertificates[0])
+	}
+}
+
+// setCertificate sets the expiration and fingerprint of the certificate of the server
+func (r *Result) setCertificate(certificate *x509.Certificate) {
+	r.CertificateExpiration = time.Until(certificate.NotAfter)
+	fingerprint := sha256.Sum256(certificate.Raw)
+	pairs := make([]string, len(fingerprint))
+	for i, b := range fingerprint {
+		pairs[i] = strings.ToUpper(hex.EncodeToString([]byte{b}))
+	}
+	r.CertificateFingerprint = strings.Join(pairs, ":")
+}
@@ -0,0 +1,236 @@
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+var (
+	// ErrEndpointWithUnsupportedRunnerType is the error with which Gatus will panic if an endpoint that isn't of
+	// type HTTP, ICMP or DNS is configured to be executed on a runner
+	ErrEndpointWithUnsupportedRunnerType = errors.New("only endpoints of type HTTP, ICMP and DNS can be executed on a runner")
+
+	// errInvalidRunnerOutput is the error returned when the output of a probe executed on a runner cannot be parsed
+	errInvalidRunnerOutput = errors.New("invalid output received from runner")
+
+	pingTimePattern    = regexp.MustCompile(`time[=<]([0-9.]+) ?ms`)
+	pingAddressPattern = regexp.MustCompile(`^PING [^ ]+ \(([0-9a-fA-F:.]+)\)`)
+	digStatusPattern   = regexp.MustCompile(`status: ([A-Z]+)`)
+
+	// curlConfigValueReplacer escapes the characters that have a special meaning within a quoted value of a curl
+	// configuration
+	curlConfigValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+)
+
+// callThroughRunner executes the check of the endpoint on its runner rather than from Gatus itself
+func (e *Endpoint) callThroughRunner(result *Result) {
+	var command string
+	var stdin []byte
+	switch e.Type() {
+	case TypeHTTP:
+		command, stdin = e.buildCurlCommand()
+	case TypeICMP:
+		command = e.buildPingCommand()
+	case TypeDNS:
+		command = e.buildDigCommand()
+	default:
+		result.AddError(ErrEndpointWithUnsupportedRunnerType.Error())
+		return
+	}
+	startTime := time.Now()
+	stdout, stderr, exitStatus, err := e.RunnerConfig.Run(command, stdin, e.ClientConfig.Timeout)
+	if err != nil {
+		result.AddError(fmt.Sprintf("failed to execute check on runner %s: %s", e.RunnerConfig.Name, err.Error()))
+		return
+	}
+	switch e.Type() {
+	case TypeHTTP:
+		if exitStatus != 0 {
+			result.AddError(strings.TrimSpace(string(stderr)))
+			return
+		}
+		err = parseCurlOutput(stdout, result)
+	case TypeICMP:
+		result.Connected = exitStatus == 0
+		parsePingOutput(stdout, result)
+		if result.Duration == 0 {
+			result.Duration = time.Since(startTime)
+		}
+	case TypeDNS:
+		if exitStatus != 0 {
+			result.AddError(strings.TrimSpace(string(stdout) + " " + string(stderr)))
+			return
+		}
+		result.Connected = true
+		err = parseDigOutput(stdout, result)
+		result.Duration = time.Since(startTime)
+	}
+	if err != nil {
+		result.AddError(err.Error())
+	}
+}
+
+// buildCurlCommand returns the curl command sending the request of the endpoint as well as the curl configuration
+// that must be passed to the command through stdin.
+//
+// The headers, the URL and the body of the request are passed through the configuration rather than as arguments so
+// that secrets, such as the value of an Authorization header, are not exposed in the list of processes of the runner.
+func (e *Endpoint) buildCurlCommand() (string, []byte) {
+	request := e.buildHTTPRequest()
+	var body []byte
+	if request.Body != nil {
+		body, _ = io.ReadAll(request.Body)
+	}
+	arguments := []string{"curl", "--silent", "--show-error", "--output", "-",
+		"--max-time", strconv.FormatFloat(e.ClientConfig.Timeout.Seconds(), 'f', -1, 64),
+		"--request", request.Method,
+		"--write-out", `\n%{http_code} %{time_total} %{remote_ip}`,
+		"--config", "-",
+	}
+	if e.ClientConfig.Insecure {
+		arguments = append(arguments, "--insecure")
+	}
+	if !e.ClientConfig.IgnoreRedirect {
+		maxRedirects := e.ClientConfig.MaxRedirects
+		if maxRedirects == 0 {
+			maxRedirects = 10
+		}
+		arguments = append(arguments, "--location", "--max-redirs", strconv.Itoa(maxRedirects))
+	}
+	var curlConfig strings.Builder
+	for name, values := range request.Header {
+		for _, value := range values {
+			curlConfig.WriteString("header = " + quoteCurlConfigValue(name+": "+value) + "\n")
+		}
+	}
+	if len(request.Host) > 0 && request.Host != request.URL.Host {
+		curlConfig.WriteString("header = " + quoteCurlConfigValue(HostHeader+": "+request.Host) + "\n")
+	}
+	if len(body) > 0 {
+		// data-raw is used rather than data-binary, because the latter would read the body from a file if it started
+		// with @
+		curlConfig.WriteString("data-raw = " + quoteCurlConfigValue(string(body)) + "\n")
+	}
+	curlConfig.WriteString("url = " + quoteCurlConfigValue(request.URL.String()) + "\n")
+	return quoteShellArguments(arguments), []byte(curlConfig.String())
+}
+
+// buildPingCommand returns the ping command sending a single echo request to the host of the endpoint
+func (e *Endpoint) buildPingCommand() string {
+	arguments := []string{"ping", "-c", "1", "-W", strconv.Itoa(max(1, int(e.ClientConfig.Timeout.Seconds())))}
+	switch e.ClientConfig.Network {
+	case "ip4":
+		arguments = append(arguments, "-4")
+	case "ip6":
+		arguments = append(arguments, "-6")
+	}
+	arguments = append(arguments, strings.TrimPrefix(e.URL, "icmp://"))
+	return quoteShellArguments(arguments)
+}
+
+// buildDigCommand returns the dig command sending the DNS query of the endpoint to its DNS server
+func (e *Endpoint) buildDigCommand() string {
+	server, port := e.URL, "53"
+	if index := strings.LastIndex(e.URL, ":"); index != -1 && !strings.HasSuffix(e.URL, "]") {
+		server, port = e.URL[:index], e.URL[index+1:]
+	}
+	arguments := []string{"dig", "+noall", "+comments", "+answer", "+tries=1",
+		"+time=" + strconv.Itoa(max(1, int(e.ClientConfig.Timeout.Seconds()))),
+		"-p", port, "@" + strings.Trim(server, "[]"), e.DNSConfig.QueryName, e.DNSConfig.QueryType,
+	}
+	return quoteShellArguments(arguments)
+}
+
+// parseCurlOutput parses the output of the command built by buildCurlCommand into the result passed
+func parseCurlOutput(output []byte, result *Result) error {
+	separatorIndex := strings.LastIndex(string(output), "\n")
+	if separatorIndex == -1 {
+		return errInvalidRunnerOutput
+	}
+	fields := strings.Fields(string(output[separatorIndex+1:]))
+	if len(fields) < 2 {
+		return errInvalidRunnerOutput
+	}
+	status, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return errInvalidRunnerOutput
+	}
+	seconds, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return errInvalidRunnerOutput
+	}
+	result.HTTPStatus = status
+	result.Connected = status > 0
+	result.Duration = time.Duration(seconds * float64(time.Second))
+	result.Body = output[:separatorIndex]
+	result.BodySize = int64(len(result.Body))
+	if len(fields) > 2 {
+		result.IP = fields[2]
+	}
+	return nil
+}
+
+// parsePingOutput parses the output of the command built by buildPingCommand into the result passed
+func parsePingOutput(output []byte, result *Result) {
+	if matches := pingAddressPattern.FindSubmatch(output); len(matches) == 2 {
+		result.IP = string(matches[1])
+	}
+	if matches := pingTimePattern.FindSubmatch(output); len(matches) == 2 {
+		if milliseconds, err := strconv.ParseFloat(string(matches[1]), 64); err == nil {
+			result.Duration = time.Duration(milliseconds * float64(time.Millisecond))
+		}
+	}
+}
+
+// parseDigOutput parses the output of the command built by buildDigCommand into the result passed
+//
+// Like when the query is sent by Gatus itself, only the values of A, AAAA, CNAME, MX and NS records are supported.
+func parseDigOutput(output []byte, result *Result) error {
+	matches := digStatusPattern.FindSubmatch(output)
+	if len(matches) != 2 {
+		return errInvalidRunnerOutput
+	}
+	result.DNSRCode = string(matches[1])
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, ";") {
+			continue
+		}
+		// e.g. example.org.	300	IN	MX	10 mail.example.org.
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		switch fields[3] {
+		case "A", "AAAA", "CNAME", "MX", "NS":
+			ttl, _ := strconv.Atoi(fields[1])
+			value := fields[len(fields)-1]
+			result.Body = []byte(value)
+			result.DNSRecords = append(result.DNSRecords, client.DNSRecord{Value: value, TTL: time.Duration(ttl) * time.Second})
+		default:
+			result.Body = []byte("query type is not supported yet")
+		}
+	}
+	return nil
+}
+
+// quoteShellArguments joins the arguments passed into a command in which each argument is quoted for POSIX shells
+func quoteShellArguments(arguments []string) string {
+	quotedArguments := make([]string, len(arguments))
+	for i, argument := range arguments {
+		quotedArguments[i] = "'" + strings.ReplaceAll(argument, "'", `'\''`) + "'"
+	}
+	return strings.Join(quotedArguments, " ")
+}
+
+// quoteCurlConfigValue quotes a value so that it can be used in a curl configuration
+func quoteCurlConfigValue(value string) string {
+	return `"` + curlConfigValueReplacer.Replace(value) + `"`
+}
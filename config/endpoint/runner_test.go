@@ -0,0 +1,181 @@
+package endpoint
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/config/runner"
+)
+
+func TestEndpoint_ValidateAndSetDefaultsWithRunner(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		endpoint    *Endpoint
+		expectedErr error
+	}{
+		{
+			name:     "http",
+			endpoint: &Endpoint{Name: "http", URL: "https://example.org/health", Runner: "eu-west", Conditions: []Condition{"[STATUS] == 200"}},
+		},
+		{
+			name:     "icmp",
+			endpoint: &Endpoint{Name: "icmp", URL: "icmp://example.org", Runner: "eu-west", Conditions: []Condition{"[CONNECTED] == true"}},
+		},
+		{
+			name:     "dns",
+			endpoint: &Endpoint{Name: "dns", URL: "8.8.8.8", DNSConfig: &dns.Config{QueryName: "example.org", QueryType: "A"}, Runner: "eu-west", Conditions: []Condition{"[DNS_RCODE] == NOERROR"}},
+		},
+		{
+			name:        "tcp",
+			endpoint:    &Endpoint{Name: "tcp", URL: "tcp://example.org:443", Runner: "eu-west", Conditions: []Condition{"[CONNECTED] == true"}},
+			expectedErr: ErrEndpointWithUnsupportedRunnerType,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.endpoint.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEndpoint_buildCurlCommand(t *testing.T) {
+	ep := &Endpoint{
+		Name:       "api",
+		URL:        "https://example.org/health",
+		Method:     "POST",
+		Body:       `{"name":"it's me"}`,
+		Headers:    map[string]string{"Authorization": "Bearer token"},
+		Conditions: []Condition{"[STATUS] == 200"},
+		ClientConfig: &client.Config{
+			Insecure: true,
+			Timeout:  5 * time.Second,
+		},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	command, stdin := ep.buildCurlCommand()
+	for _, expectedArgument := range []string{"'curl'", "'--max-time' '5'", "'--request' 'POST'", "'--insecure'", "'--location' '--max-redirs' '10'", "'--config' '-'"} {
+		if !strings.Contains(command, expectedArgument) {
+			t.Errorf("expected command to contain %s, got %s", expectedArgument, command)
+		}
+	}
+	if strings.Contains(command, "Bearer token") || strings.Contains(command, "example.org") {
+		t.Errorf("expected the headers and the URL not to be passed as arguments, got %s", command)
+	}
+	for _, expectedLine := range []string{`header = "Authorization: Bearer token"`, `data-raw = "{\"name\":\"it's me\"}"`, `url = "https://example.org/health"`} {
+		if !strings.Contains(string(stdin), expectedLine+"\n") {
+			t.Errorf("expected the configuration passed through stdin to contain %s, got %s", expectedLine, string(stdin))
+		}
+	}
+}
+
+func TestQuoteCurlConfigValue(t *testing.T) {
+	if quoted := quoteCurlConfigValue("a \"b\"\\c\nd\te"); quoted != `"a \"b\"\\c\nd\te"` {
+		t.Errorf("unexpected quoted value: %s", quoted)
+	}
+}
+
+func TestEndpoint_buildPingAndDigCommands(t *testing.T) {
+	ep := &Endpoint{URL: "icmp://example.org", ClientConfig: &client.Config{Timeout: 3 * time.Second, Network: "ip4"}}
+	if command := ep.buildPingCommand(); command != "'ping' '-c' '1' '-W' '3' '-4' 'example.org'" {
+		t.Errorf("unexpected ping command: %s", command)
+	}
+	ep = &Endpoint{URL: "1.1.1.1:5353", DNSConfig: &dns.Config{QueryName: "example.org.", QueryType: "MX"}, ClientConfig: &client.Config{Timeout: 3 * time.Second}}
+	if command := ep.buildDigCommand(); command != "'dig' '+noall' '+comments' '+answer' '+tries=1' '+time=3' '-p' '5353' '@1.1.1.1' 'example.org.' 'MX'" {
+		t.Errorf("unexpected dig command: %s", command)
+	}
+}
+
+func TestParseCurlOutput(t *testing.T) {
+	result := &Result{}
+	if err := parseCurlOutput([]byte("{\"status\":\"UP\"}\n200 0.123456 93.184.215.14"), result); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if result.HTTPStatus != 200 || !result.Connected {
+		t.Errorf("expected status 200 and connected, got %d and %v", result.HTTPStatus, result.Connected)
+	}
+	if string(result.Body) != `{"status":"UP"}` {
+		t.Errorf("unexpected body: %s", string(result.Body))
+	}
+	if result.Duration != 123456*time.Microsecond {
+		t.Errorf("expected duration of 123.456ms, got %s", result.Duration)
+	}
+	if result.IP != "93.184.215.14" {
+		t.Errorf("expected IP 93.184.215.14, got %s", result.IP)
+	}
+	if err := parseCurlOutput([]byte("garbage"), &Result{}); err != errInvalidRunnerOutput {
+		t.Errorf("expected error %v, got %v", errInvalidRunnerOutput, err)
+	}
+}
+
+func TestParsePingOutput(t *testing.T) {
+	result := &Result{}
+	parsePingOutput([]byte(`PING example.org (93.184.215.14) 56(84) bytes of data.
+64 bytes from 93.184.215.14: icmp_seq=1 ttl=56 time=12.5 ms
+
+--- example.org ping statistics ---
+1 packets transmitted, 1 received, 0% packet loss, time 0ms`), result)
+	if result.IP != "93.184.215.14" {
+		t.Errorf("expected IP 93.184.215.14, got %s", result.IP)
+	}
+	if result.Duration != 12500*time.Microsecond {
+		t.Errorf("expected duration of 12.5ms, got %s", result.Duration)
+	}
+}
+
+func TestParseDigOutput(t *testing.T) {
+	result := &Result{}
+	err := parseDigOutput([]byte(`;; Got answer:
+;; ->>HEADER<<- opcode: QUERY, status: NOERROR, id: 1234
+;; flags: qr rd ra; QUERY: 1, ANSWER: 2, AUTHORITY: 0, ADDITIONAL: 1
+
+example.org.		300	IN	MX	10 mail1.example.org.
+example.org.		60	IN	MX	20 mail2.example.org.
+`), result)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if result.DNSRCode != "NOERROR" {
+		t.Errorf("expected rcode NOERROR, got %s", result.DNSRCode)
+	}
+	if len(result.DNSRecords) != 2 || result.DNSRecords[0].Value != "mail1.example.org." || result.DNSRecords[1].TTL != time.Minute {
+		t.Errorf("unexpected records: %+v", result.DNSRecords)
+	}
+	if string(result.Body) != "mail2.example.org." {
+		t.Errorf("expected body to be the value of the last record, got %s", string(result.Body))
+	}
+	if err := parseDigOutput([]byte(";; connection timed out; no servers could be reached"), &Result{}); err != errInvalidRunnerOutput {
+		t.Errorf("expected error %v, got %v", errInvalidRunnerOutput, err)
+	}
+}
+
+func TestQuoteShellArguments(t *testing.T) {
+	if quoted := quoteShellArguments([]string{"echo", "it's", "$HOME"}); quoted != `'echo' 'it'\''s' '$HOME'` {
+		t.Errorf("unexpected quoted arguments: %s", quoted)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithUnreachableRunner(t *testing.T) {
+	ep := &Endpoint{
+		Name:         "api",
+		URL:          "https://example.org/health",
+		Conditions:   []Condition{"[STATUS] == 200"},
+		RunnerConfig: &runner.Config{Name: "unreachable", Address: "127.0.0.1:1", Username: "gatus", Password: "password", HostKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILZ0jgNtIe6Kg6PVlUh6U6zdtwNvElA14g4A3AxK0gOK"},
+	}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	result := ep.EvaluateHealth()
+	if result.Success {
+		t.Error("expected the check to fail")
+	}
+	if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], "failed to execute check on runner unreachable") {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+}
@@ -0,0 +1,53 @@
+package sampling
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidEveryNthSuccess is the error with which Gatus will panic if every-nth-success is lower than 1
+	ErrInvalidEveryNthSuccess = errors.New("sampling every-nth-success must be greater than or equal to 1")
+)
+
+// Config is the storage sampling configuration for endpoint.Endpoint
+//
+// Sampling reduces the number of results stored for endpoints that are checked very frequently.
+// Failures and results that represent a change in health (e.g. the first success after a failure) are always stored,
+// but only one out of every EveryNthSuccess consecutive successful results is.
+type Config struct {
+	// EveryNthSuccess is the number of consecutive successful results that must be observed for one to be stored.
+	// Setting this to 1 stores every result.
+	EveryNthSuccess int `yaml:"every-nth-success"`
+
+	// lastSuccess is whether the last result observed was successful. Nil if no result has been observed yet.
+	lastSuccess *bool
+
+	// successesSinceLastStored is the number of successful results observed since the last one that was stored
+	successesSinceLastStored int
+}
+
+// ValidateAndSetDefaults validates the sampling configuration
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.EveryNthSuccess < 1 {
+		return ErrInvalidEveryNthSuccess
+	}
+	return nil
+}
+
+// ShouldStore returns whether a result should be stored based on whether it was successful.
+//
+// This function is stateful: it must be called exactly once per result evaluated.
+func (c *Config) ShouldStore(success bool) bool {
+	isTransition := c.lastSuccess == nil || *c.lastSuccess != success
+	c.lastSuccess = &success
+	if !success {
+		c.successesSinceLastStored = 0
+		return true
+	}
+	c.successesSinceLastStored++
+	if isTransition || c.successesSinceLastStored >= c.EveryNthSuccess {
+		c.successesSinceLastStored = 0
+		return true
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package sampling
+
+import (
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	if err := (&Config{EveryNthSuccess: 0}).ValidateAndSetDefaults(); err != ErrInvalidEveryNthSuccess {
+		t.Errorf("expected error %v, got %v", ErrInvalidEveryNthSuccess, err)
+	}
+	if err := (&Config{EveryNthSuccess: 1}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+}
+
+func TestConfig_ShouldStore(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		everyNthSuccess int
+		results         []bool
+		expected        []bool
+	}{
+		{
+			name:            "every-result",
+			everyNthSuccess: 1,
+			results:         []bool{true, true, true, false, true},
+			expected:        []bool{true, true, true, true, true},
+		},
+		{
+			name:            "every-third-success",
+			everyNthSuccess: 3,
+			results:         []bool{true, true, true, true, true, true, true},
+			expected:        []bool{true, false, false, true, false, false, true},
+		},
+		{
+			name:            "failures-are-always-stored",
+			everyNthSuccess: 5,
+			results:         []bool{false, false, false, false},
+			expected:        []bool{true, true, true, true},
+		},
+		{
+			name:            "transitions-are-always-stored",
+			everyNthSuccess: 3,
+			results:         []bool{true, true, false, true, true, true, true},
+			expected:        []bool{true, false, true, true, false, false, true},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{EveryNthSuccess: scenario.everyNthSuccess}
+			for i, success := range scenario.results {
+				if actual := cfg.ShouldStore(success); actual != scenario.expected[i] {
+					t.Errorf("expected result #%d (success=%v) to return %v, got %v", i, success, scenario.expected[i], actual)
+				}
+			}
+		})
+	}
+}
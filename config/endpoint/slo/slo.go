@@ -0,0 +1,88 @@
+package slo
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultWindow is the default rolling window over which the service level objective is evaluated
+	DefaultWindow = 30 * 24 * time.Hour
+
+	// MaximumWindow is the maximum rolling window over which the service level objective can be evaluated, which is
+	// bound by how long the uptime data is kept
+	MaximumWindow = 90 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidObjective is the error with which Gatus will panic if the objective is not between 0 and 100 exclusively
+	ErrInvalidObjective = errors.New("slo objective must be greater than 0 and lower than 100")
+
+	// ErrInvalidWindow is the error with which Gatus will panic if the window is shorter than an hour or longer than
+	// MaximumWindow
+	ErrInvalidWindow = errors.New("slo window must be at least 1h and at most 2160h")
+
+	// ErrInvalidResponseTime is the error with which Gatus will panic if the response time is negative
+	ErrInvalidResponseTime = errors.New("slo response-time must not be negative")
+)
+
+// Config is the configuration of the service level objective (SLO) of an endpoint.Endpoint
+//
+// The service level indicator is the percentage of good events, which are the successful results whose response
+// time, if ResponseTime is set, doesn't exceed ResponseTime. The error budget is the percentage of bad events allowed
+// over Window by Objective, e.g. 0.1% for an objective of 99.9%.
+type Config struct {
+	// Objective is the percentage of events that must be good over Window, e.g. 99.9
+	Objective float64 `yaml:"objective"`
+
+	// Window is the rolling window over which Objective is evaluated. Defaults to DefaultWindow.
+	Window time.Duration `yaml:"window,omitempty"`
+
+	// ResponseTime is the maximum response time of a successful result for it to be considered a good event.
+	// If not set, every successful result is a good event.
+	ResponseTime time.Duration `yaml:"response-time,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the service level objective and sets the default value of fields that have one
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Objective <= 0 || c.Objective >= 100 {
+		return ErrInvalidObjective
+	}
+	if c.Window == 0 {
+		c.Window = DefaultWindow
+	}
+	if c.Window < time.Hour || c.Window > MaximumWindow {
+		return ErrInvalidWindow
+	}
+	if c.ResponseTime < 0 {
+		return ErrInvalidResponseTime
+	}
+	return nil
+}
+
+// ErrorBudget returns the ratio of bad events allowed by the objective, as a value between 0 and 1
+func (c *Config) ErrorBudget() float64 {
+	return (100 - c.Objective) / 100
+}
+
+// IsTooSlow returns whether a successful result with the response time passed is a bad event nonetheless
+func (c *Config) IsTooSlow(responseTime time.Duration) bool {
+	return c.ResponseTime > 0 && responseTime > c.ResponseTime
+}
+
+// BurnRate returns the rate at which the error budget is consumed based on the number of events passed.
+//
+// A burn rate of 1 means that the error budget would be exactly exhausted at the end of the window if the ratio of bad
+// events remained the same, while a burn rate of 10 means that it would be exhausted ten times sooner.
+func (c *Config) BurnRate(totalEvents, goodEvents uint64) float64 {
+	if totalEvents == 0 || goodEvents >= totalEvents {
+		return 0
+	}
+	return float64(totalEvents-goodEvents) / float64(totalEvents) / c.ErrorBudget()
+}
+
+// ErrorBudgetRemaining returns the ratio of the error budget that hasn't been consumed based on the number of events
+// passed, which are expected to span the entire window. The value returned is negative once the budget is exhausted.
+func (c *Config) ErrorBudgetRemaining(totalEvents, goodEvents uint64) float64 {
+	return 1 - c.BurnRate(totalEvents, goodEvents)
+}
@@ -0,0 +1,123 @@
+package slo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         *Config
+		expectedErr    error
+		expectedWindow time.Duration
+	}{
+		{
+			name:           "default-window",
+			config:         &Config{Objective: 99.9},
+			expectedWindow: DefaultWindow,
+		},
+		{
+			name:           "custom-window",
+			config:         &Config{Objective: 99, Window: 7 * 24 * time.Hour, ResponseTime: 500 * time.Millisecond},
+			expectedWindow: 7 * 24 * time.Hour,
+		},
+		{
+			name:        "no-objective",
+			config:      &Config{},
+			expectedErr: ErrInvalidObjective,
+		},
+		{
+			name:        "objective-of-100",
+			config:      &Config{Objective: 100},
+			expectedErr: ErrInvalidObjective,
+		},
+		{
+			name:        "window-too-short",
+			config:      &Config{Objective: 99.9, Window: 30 * time.Minute},
+			expectedErr: ErrInvalidWindow,
+		},
+		{
+			name:        "window-too-long",
+			config:      &Config{Objective: 99.9, Window: MaximumWindow + time.Hour},
+			expectedErr: ErrInvalidWindow,
+		},
+		{
+			name:        "negative-response-time",
+			config:      &Config{Objective: 99.9, ResponseTime: -time.Second},
+			expectedErr: ErrInvalidResponseTime,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && scenario.config.Window != scenario.expectedWindow {
+				t.Errorf("expected window to be %s, got %s", scenario.expectedWindow, scenario.config.Window)
+			}
+		})
+	}
+}
+
+func TestConfig_BurnRate(t *testing.T) {
+	config := &Config{Objective: 99}
+	scenarios := []struct {
+		name                         string
+		totalEvents, goodEvents      uint64
+		expectedBurnRate             float64
+		expectedErrorBudgetRemaining float64
+	}{
+		{
+			name:                         "no-events",
+			expectedBurnRate:             0,
+			expectedErrorBudgetRemaining: 1,
+		},
+		{
+			name:                         "no-bad-events",
+			totalEvents:                  1000,
+			goodEvents:                   1000,
+			expectedBurnRate:             0,
+			expectedErrorBudgetRemaining: 1,
+		},
+		{
+			name:                         "half-of-budget-consumed",
+			totalEvents:                  1000,
+			goodEvents:                   995,
+			expectedBurnRate:             0.5,
+			expectedErrorBudgetRemaining: 0.5,
+		},
+		{
+			name:                         "budget-exhausted-ten-times-over",
+			totalEvents:                  100,
+			goodEvents:                   90,
+			expectedBurnRate:             10,
+			expectedErrorBudgetRemaining: -9,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if burnRate := config.BurnRate(scenario.totalEvents, scenario.goodEvents); math.Abs(burnRate-scenario.expectedBurnRate) > 1e-9 {
+				t.Errorf("expected burn rate to be %f, got %f", scenario.expectedBurnRate, burnRate)
+			}
+			if remaining := config.ErrorBudgetRemaining(scenario.totalEvents, scenario.goodEvents); math.Abs(remaining-scenario.expectedErrorBudgetRemaining) > 1e-9 {
+				t.Errorf("expected error budget remaining to be %f, got %f", scenario.expectedErrorBudgetRemaining, remaining)
+			}
+		})
+	}
+}
+
+func TestConfig_IsTooSlow(t *testing.T) {
+	if (&Config{Objective: 99.9}).IsTooSlow(time.Hour) {
+		t.Error("expected no result to be too slow without a response time")
+	}
+	config := &Config{Objective: 99.9, ResponseTime: 500 * time.Millisecond}
+	if config.IsTooSlow(500 * time.Millisecond) {
+		t.Error("expected a response time equal to the objective not to be too slow")
+	}
+	if !config.IsTooSlow(501 * time.Millisecond) {
+		t.Error("expected a response time above the objective to be too slow")
+	}
+}
@@ -0,0 +1,77 @@
+package soap
+
+import (
+	"errors"
+	"strconv"
+)
+
+const (
+	// Version11 is SOAP 1.1, which passes the action through the SOAPAction header
+	Version11 = "1.1"
+
+	// Version12 is SOAP 1.2, which passes the action as a parameter of the Content-Type header
+	Version12 = "1.2"
+
+	// ActionHeader is the header through which the action is passed with SOAP 1.1
+	ActionHeader = "SOAPAction"
+
+	envelopeNamespace11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	envelopeNamespace12 = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+var (
+	// ErrInvalidVersion is the error with which Gatus will panic if the SOAP version is neither 1.1 nor 1.2
+	ErrInvalidVersion = errors.New("soap version must be either " + Version11 + " or " + Version12)
+)
+
+// Config is the SOAP configuration for endpoint.Endpoint
+//
+// When set, the body of the endpoint is treated as the content of the SOAP body and wrapped in an envelope.
+type Config struct {
+	// Action is the SOAP action of the operation called (optional)
+	Action string `yaml:"action,omitempty"`
+
+	// Version is the version of SOAP used. Defaults to 1.1.
+	Version string `yaml:"version,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the SOAP configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Version) == 0 {
+		c.Version = Version11
+	}
+	if c.Version != Version11 && c.Version != Version12 {
+		return ErrInvalidVersion
+	}
+	return nil
+}
+
+// Wrap wraps the body passed in a SOAP envelope
+func (c *Config) Wrap(body string) string {
+	namespace := envelopeNamespace11
+	if c.Version == Version12 {
+		namespace = envelopeNamespace12
+	}
+	return `<?xml version="1.0" encoding="utf-8"?>` +
+		`<soap:Envelope xmlns:soap="` + namespace + `"><soap:Body>` + body + `</soap:Body></soap:Envelope>`
+}
+
+// ContentType returns the value of the Content-Type header to send with the request
+func (c *Config) ContentType() string {
+	if c.Version == Version12 {
+		if len(c.Action) > 0 {
+			return "application/soap+xml; charset=utf-8; action=" + strconv.Quote(c.Action)
+		}
+		return "application/soap+xml; charset=utf-8"
+	}
+	return "text/xml; charset=utf-8"
+}
+
+// Headers returns the SOAP-specific headers to send with the request, excluding the Content-Type header
+func (c *Config) Headers() map[string]string {
+	if c.Version == Version12 {
+		return nil
+	}
+	// With SOAP 1.1, the SOAPAction header must always be present and its value quoted, even if empty
+	return map[string]string{ActionHeader: strconv.Quote(c.Action)}
+}
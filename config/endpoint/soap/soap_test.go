@@ -0,0 +1,71 @@
+package soap
+
+import (
+	"testing"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if cfg.Version != Version11 {
+		t.Errorf("expected version to default to %s, got %s", Version11, cfg.Version)
+	}
+	if err := (&Config{Version: Version12}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if err := (&Config{Version: "2.0"}).ValidateAndSetDefaults(); err != ErrInvalidVersion {
+		t.Errorf("expected error %v, got %v", ErrInvalidVersion, err)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	scenarios := []struct {
+		name                string
+		config              Config
+		expectedEnvelope    string
+		expectedContentType string
+		expectedActionValue string
+	}{
+		{
+			name:                "1.1",
+			config:              Config{Version: Version11, Action: "http://example.com/GetStatus"},
+			expectedEnvelope:    `<?xml version="1.0" encoding="utf-8"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetStatus/></soap:Body></soap:Envelope>`,
+			expectedContentType: "text/xml; charset=utf-8",
+			expectedActionValue: `"http://example.com/GetStatus"`,
+		},
+		{
+			name:                "1.1-without-action",
+			config:              Config{Version: Version11},
+			expectedEnvelope:    `<?xml version="1.0" encoding="utf-8"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetStatus/></soap:Body></soap:Envelope>`,
+			expectedContentType: "text/xml; charset=utf-8",
+			expectedActionValue: `""`,
+		},
+		{
+			name:                "1.2",
+			config:              Config{Version: Version12, Action: "http://example.com/GetStatus"},
+			expectedEnvelope:    `<?xml version="1.0" encoding="utf-8"?><soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><GetStatus/></soap:Body></soap:Envelope>`,
+			expectedContentType: `application/soap+xml; charset=utf-8; action="http://example.com/GetStatus"`,
+		},
+		{
+			name:                "1.2-without-action",
+			config:              Config{Version: Version12},
+			expectedEnvelope:    `<?xml version="1.0" encoding="utf-8"?><soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><GetStatus/></soap:Body></soap:Envelope>`,
+			expectedContentType: "application/soap+xml; charset=utf-8",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if envelope := scenario.config.Wrap("<GetStatus/>"); envelope != scenario.expectedEnvelope {
+				t.Errorf("expected envelope %s, got %s", scenario.expectedEnvelope, envelope)
+			}
+			if contentType := scenario.config.ContentType(); contentType != scenario.expectedContentType {
+				t.Errorf("expected content type %s, got %s", scenario.expectedContentType, contentType)
+			}
+			if actionValue := scenario.config.Headers()[ActionHeader]; actionValue != scenario.expectedActionValue {
+				t.Errorf("expected %s header to be %s, got %s", ActionHeader, scenario.expectedActionValue, actionValue)
+			}
+		})
+	}
+}
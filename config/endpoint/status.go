@@ -1,5 +1,11 @@
 package endpoint
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 // Status contains the evaluation Results of an Endpoint
 type Status struct {
 	// Name of the endpoint
@@ -17,6 +23,12 @@ type Status struct {
 	// Events is a list of events
 	Events []*Event `json:"events,omitempty"`
 
+	// DownSince is the moment at which the endpoint became unhealthy, if it is currently unhealthy
+	DownSince *time.Time `json:"downSince,omitempty"`
+
+	// DownFor is a human-readable representation of for how long the endpoint has been unhealthy, e.g. "43 minutes"
+	DownFor string `json:"downFor,omitempty"`
+
 	// Uptime information on the endpoint's uptime
 	//
 	// Used by the memory store.
@@ -36,3 +48,63 @@ func NewStatus(group, name string) *Status {
 		Uptime:  NewUptime(),
 	}
 }
+
+// SetDowntime sets DownSince and DownFor based on the moment at which the endpoint became unhealthy, which is zero if
+// the endpoint is healthy
+func (s *Status) SetDowntime(downSince, now time.Time) {
+	if downSince.IsZero() {
+		s.DownSince, s.DownFor = nil, ""
+		return
+	}
+	s.DownSince = &downSince
+	s.DownFor = HumanizeDuration(now.Sub(downSince))
+}
+
+// SetDowntimeFromLastEvent sets DownSince and DownFor based on the last event of the endpoint
+//
+// Only used for endpoints without an alerting state, since the events are not aware of the alerting state of the
+// endpoint, which is what alerts rely on to determine for how long the endpoint has been down
+func (s *Status) SetDowntimeFromLastEvent(lastEvent *Event, now time.Time) {
+	if lastEvent == nil || lastEvent.Type != EventUnhealthy {
+		s.DownSince, s.DownFor = nil, ""
+		return
+	}
+	s.SetDowntime(lastEvent.Timestamp, now)
+}
+
+// HumanizeDuration returns a human-readable representation of a duration using its two most significant units,
+// e.g. "43 minutes" or "2 hours 5 minutes"
+func HumanizeDuration(d time.Duration) string {
+	if d < time.Minute {
+		return pluralize(int64(d/time.Second), "second")
+	}
+	units := []struct {
+		duration time.Duration
+		name     string
+	}{
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+	}
+	var parts []string
+	for _, unit := range units {
+		if len(parts) == 2 {
+			break
+		}
+		if value := int64(d / unit.duration); value > 0 {
+			parts = append(parts, pluralize(value, unit.name))
+			d -= time.Duration(value) * unit.duration
+		} else if len(parts) > 0 {
+			// Only use consecutive units, e.g. "1 day 5 minutes" would be misleading without the hours
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func pluralize(value int64, unit string) string {
+	if value == 1 {
+		return fmt.Sprintf("%d %s", value, unit)
+	}
+	return fmt.Sprintf("%d %ss", value, unit)
+}
@@ -2,6 +2,7 @@ package endpoint
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNewEndpointStatus(t *testing.T) {
@@ -17,3 +18,60 @@ func TestNewEndpointStatus(t *testing.T) {
 		t.Errorf("expected %s, got %s", "group_name", status.Key)
 	}
 }
+
+func TestStatus_SetDowntimeFromLastEvent(t *testing.T) {
+	now := time.Now()
+	status := NewStatus("group", "name")
+	status.SetDowntimeFromLastEvent(&Event{Type: EventUnhealthy, Timestamp: now.Add(-43 * time.Minute)}, now)
+	if status.DownSince == nil || !status.DownSince.Equal(now.Add(-43*time.Minute)) {
+		t.Errorf("expected DownSince to be the timestamp of the last event, got %v", status.DownSince)
+	}
+	if status.DownFor != "43 minutes" {
+		t.Errorf("expected DownFor to be %s, got %s", "43 minutes", status.DownFor)
+	}
+	status.SetDowntimeFromLastEvent(&Event{Type: EventHealthy, Timestamp: now}, now)
+	if status.DownSince != nil || len(status.DownFor) != 0 {
+		t.Error("expected DownSince and DownFor to be empty, because the endpoint is healthy")
+	}
+	status.SetDowntimeFromLastEvent(nil, now)
+	if status.DownSince != nil || len(status.DownFor) != 0 {
+		t.Error("expected DownSince and DownFor to be empty, because the endpoint has no events")
+	}
+}
+
+func TestStatus_SetDowntime(t *testing.T) {
+	now := time.Now()
+	status := NewStatus("group", "name")
+	status.SetDowntime(now.Add(-2*time.Hour), now)
+	if status.DownSince == nil || !status.DownSince.Equal(now.Add(-2*time.Hour)) || status.DownFor != "2 hours" {
+		t.Errorf("expected the endpoint to have been down for 2 hours, got %v (%s)", status.DownSince, status.DownFor)
+	}
+	status.SetDowntime(time.Time{}, now)
+	if status.DownSince != nil || len(status.DownFor) != 0 {
+		t.Error("expected DownSince and DownFor to be empty, because the endpoint is healthy")
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	scenarios := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 0, expected: "0 seconds"},
+		{duration: time.Second, expected: "1 second"},
+		{duration: 59 * time.Second, expected: "59 seconds"},
+		{duration: time.Minute + 30*time.Second, expected: "1 minute"},
+		{duration: 43 * time.Minute, expected: "43 minutes"},
+		{duration: 2*time.Hour + 5*time.Minute + 10*time.Second, expected: "2 hours 5 minutes"},
+		{duration: 3 * time.Hour, expected: "3 hours"},
+		{duration: 26*time.Hour + 30*time.Minute, expected: "1 day 2 hours"},
+		{duration: 48*time.Hour + 5*time.Minute, expected: "2 days"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.expected, func(t *testing.T) {
+			if output := HumanizeDuration(scenario.duration); output != scenario.expected {
+				t.Errorf("expected %s, got %s", scenario.expected, output)
+			}
+		})
+	}
+}
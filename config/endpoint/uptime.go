@@ -1,5 +1,7 @@
 package endpoint
 
+import "time"
+
 // Uptime is the struct that contains the relevant data for calculating the uptime as well as the uptime itself
 // and some other statistics
 type Uptime struct {
@@ -14,6 +16,44 @@ type HourlyUptimeStatistics struct {
 	TotalExecutions             uint64 // Total number of checks
 	SuccessfulExecutions        uint64 // Number of successful executions
 	TotalExecutionsResponseTime uint64 // Total response time for all executions in milliseconds
+	Incidents                   uint64 // Number of times the endpoint went from healthy to unhealthy
+	SlowExecutions              uint64 // Number of successful executions that exceeded the response time objective
+}
+
+// DailyUptimeStatistics is a struct containing the metrics collected over the course of a day
+type DailyUptimeStatistics struct {
+	TotalExecutions             uint64 // Total number of checks
+	SuccessfulExecutions        uint64 // Number of successful executions
+	TotalExecutionsResponseTime uint64 // Total response time for all executions in milliseconds
+	Incidents                   uint64 // Number of times the endpoint went from healthy to unhealthy
+}
+
+// Uptime returns the uptime percentage of the day, or 0 if there were no executions
+func (d *DailyUptimeStatistics) Uptime() float64 {
+	if d.TotalExecutions == 0 {
+		return 0
+	}
+	return float64(d.SuccessfulExecutions) / float64(d.TotalExecutions)
+}
+
+// AverageResponseTime returns the average response time of the day in milliseconds, or 0 if there were no executions
+func (d *DailyUptimeStatistics) AverageResponseTime() int {
+	if d.TotalExecutions == 0 {
+		return 0
+	}
+	return int(d.TotalExecutionsResponseTime / d.TotalExecutions)
+}
+
+// ServiceLevelStatistics is a struct containing the number of events relevant to the service level objective of an
+// endpoint over a time range
+type ServiceLevelStatistics struct {
+	TotalEvents uint64 // Total number of checks
+	GoodEvents  uint64 // Number of successful checks that didn't exceed the response time objective
+}
+
+// TruncateToDay returns the start of the day of the time passed in the location of the time passed
+func TruncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
 
 // NewUptime creates a new Uptime
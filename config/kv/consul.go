@@ -0,0 +1,105 @@
+package kv
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// consulBlockingQueryWait is the maximum duration of a single blocking query
+const consulBlockingQueryWait = 5 * time.Minute
+
+// ConsulBackend loads the configuration from Consul KV and uses blocking queries to be notified of changes
+type ConsulBackend struct {
+	address string
+	key     string
+	token   string
+
+	httpClient *http.Client
+}
+
+// NewConsulBackend creates a new ConsulBackend
+func NewConsulBackend(address, key, token string) *ConsulBackend {
+	return &ConsulBackend{
+		address: address,
+		key:     key,
+		token:   token,
+		// The timeout must be greater than the wait of blocking queries, plus the jitter added by Consul (wait/16)
+		httpClient: newHTTPClient(consulBlockingQueryWait + time.Minute),
+	}
+}
+
+type consulKVPair struct {
+	Value       string `json:"Value"`
+	ModifyIndex uint64 `json:"ModifyIndex"`
+}
+
+// Get retrieves the configuration from Consul KV
+func (b *ConsulBackend) Get(ctx context.Context) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	data, index, err := b.query(ctx, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, index, nil
+}
+
+// Wait performs a blocking query until the index of the key changes
+func (b *ConsulBackend) Wait(ctx context.Context, version string) (string, error) {
+	_, index, err := b.query(ctx, url.Values{"index": {version}, "wait": {consulBlockingQueryWait.String()}})
+	if err != nil {
+		return version, err
+	}
+	return index, nil
+}
+
+func (b *ConsulBackend) query(ctx context.Context, parameters url.Values) ([]byte, string, error) {
+	requestURL := fmt.Sprintf("%s/v1/kv/%s", b.address, b.key)
+	if len(parameters) > 0 {
+		requestURL += "?" + parameters.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, http.NoBody)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(b.token) > 0 {
+		request.Header.Set("X-Consul-Token", b.token)
+	}
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, "", ErrKeyNotFound
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("consul returned status code %d: %s", response.StatusCode, string(body))
+	}
+	var pairs []consulKVPair
+	if err = json.Unmarshal(body, &pairs); err != nil {
+		return nil, "", err
+	}
+	if len(pairs) == 0 {
+		return nil, "", ErrKeyNotFound
+	}
+	data, err := base64.StdEncoding.DecodeString(pairs[0].Value)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, fmt.Sprintf("%d", pairs[0].ModifyIndex), nil
+}
+
+func (b *ConsulBackend) String() string {
+	return fmt.Sprintf("consul (address=%s; key=%s)", b.address, b.key)
+}
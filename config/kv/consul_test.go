@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConsulBackend(t *testing.T) {
+	changed := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Consul-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/gatus/config" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		value, index := "endpoints: []", 10
+		if r.URL.Query().Get("index") == "10" {
+			if r.URL.Query().Get("wait") != consulBlockingQueryWait.String() {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			<-changed
+			value, index = "endpoints: [{}]", 11
+		}
+		w.Header().Set("X-Consul-Index", fmt.Sprintf("%d", index))
+		_, _ = fmt.Fprintf(w, `[{"Key":"gatus/config","Value":"%s","ModifyIndex":%d}]`, base64.StdEncoding.EncodeToString([]byte(value)), index)
+	}))
+	defer server.Close()
+	backend := NewConsulBackend(server.URL, "gatus/config", "token")
+	data, version, err := backend.Get(context.Background())
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if string(data) != "endpoints: []" || version != "10" {
+		t.Errorf("expected data=endpoints: [] and version=10, got data=%s and version=%s", data, version)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(changed)
+	}()
+	if version, err = backend.Wait(context.Background(), version); err != nil || version != "11" {
+		t.Errorf("expected version 11 and no error, got version=%s and err=%v", version, err)
+	}
+	if _, _, err = NewConsulBackend(server.URL, "missing", "token").Get(context.Background()); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected error %v, got %v", ErrKeyNotFound, err)
+	}
+	if _, _, err = NewConsulBackend(server.URL, "gatus/config", "").Get(context.Background()); err == nil {
+		t.Error("expected an error, because no token was provided")
+	}
+}
@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// EtcdBackend loads the configuration from etcd using the JSON gateway of the v3 API and polls it for changes
+type EtcdBackend struct {
+	address      string
+	key          string
+	token        string
+	pollInterval time.Duration
+
+	httpClient *http.Client
+}
+
+// NewEtcdBackend creates a new EtcdBackend
+func NewEtcdBackend(address, key, token string, pollInterval time.Duration) *EtcdBackend {
+	return &EtcdBackend{
+		address:      address,
+		key:          key,
+		token:        token,
+		pollInterval: pollInterval,
+		httpClient:   newHTTPClient(requestTimeout),
+	}
+}
+
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value       string `json:"value"`
+		ModRevision string `json:"mod_revision"`
+	} `json:"kvs"`
+}
+
+// Get retrieves the configuration from etcd
+func (b *EtcdBackend) Get(ctx context.Context) ([]byte, string, error) {
+	body, _ := json.Marshal(map[string]string{"key": base64.StdEncoding.EncodeToString([]byte(b.key))})
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, b.address+"/v3/kv/range", bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if len(b.token) > 0 {
+		request.Header.Set("Authorization", b.token)
+	}
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("etcd returned status code %d: %s", response.StatusCode, string(responseBody))
+	}
+	var rangeResponse etcdRangeResponse
+	if err = json.Unmarshal(responseBody, &rangeResponse); err != nil {
+		return nil, "", err
+	}
+	if len(rangeResponse.Kvs) == 0 {
+		return nil, "", ErrKeyNotFound
+	}
+	data, err := base64.StdEncoding.DecodeString(rangeResponse.Kvs[0].Value)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, rangeResponse.Kvs[0].ModRevision, nil
+}
+
+// Wait polls etcd until the revision of the key changes
+func (b *EtcdBackend) Wait(ctx context.Context, version string) (string, error) {
+	return pollUntilChanged(ctx, b, version, b.pollInterval)
+}
+
+func (b *EtcdBackend) String() string {
+	return fmt.Sprintf("etcd (address=%s; key=%s)", b.address, b.key)
+}
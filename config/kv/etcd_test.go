@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEtcdBackend(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/kv/range" || r.Header.Get("Authorization") != "token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if key, _ := base64.StdEncoding.DecodeString(body["key"]); string(key) != "gatus" {
+			_, _ = w.Write([]byte(`{"header":{"revision":"5"}}`))
+			return
+		}
+		revision := "3"
+		if requests.Add(1) > 2 {
+			revision = "4"
+		}
+		_, _ = fmt.Fprintf(w, `{"header":{"revision":"5"},"kvs":[{"key":"Z2F0dXM=","value":"%s","mod_revision":"%s"}],"count":"1"}`, base64.StdEncoding.EncodeToString([]byte("endpoints: []")), revision)
+	}))
+	defer server.Close()
+	backend := NewEtcdBackend(server.URL, "gatus", "token", 10*time.Millisecond)
+	data, version, err := backend.Get(context.Background())
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if string(data) != "endpoints: []" || version != "3" {
+		t.Errorf("expected data=endpoints: [] and version=3, got data=%s and version=%s", data, version)
+	}
+	if version, err = backend.Wait(context.Background(), version); err != nil || version != "4" {
+		t.Errorf("expected version 4 and no error, got version=%s and err=%v", version, err)
+	}
+	if _, _, err = NewEtcdBackend(server.URL, "missing", "token", time.Second).Get(context.Background()); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected error %v, got %v", ErrKeyNotFound, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = backend.Wait(ctx, "4"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,179 @@
+package kv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ProviderConsul = "consul"
+	ProviderEtcd   = "etcd"
+	ProviderVault  = "vault"
+
+	// DefaultVaultField is the default field of the Vault secret in which the configuration is stored
+	DefaultVaultField = "config"
+
+	// DefaultPollInterval is the default interval at which backends that don't support blocking queries are polled
+	DefaultPollInterval = 30 * time.Second
+
+	// requestTimeout is the timeout of the requests sent to the backends, except for blocking queries
+	requestTimeout = 10 * time.Second
+
+	// watchRetryDelay is the delay before watching a backend again after an error
+	watchRetryDelay = 10 * time.Second
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown configuration backend provider: must be one of consul, etcd or vault")
+	ErrMissingAddress  = errors.New("configuration backend requires GATUS_CONFIG_KV_ADDRESS to be set")
+	ErrMissingKey      = errors.New("configuration backend requires GATUS_CONFIG_KV_KEY to be set")
+	ErrKeyNotFound     = errors.New("configuration key not found in backend")
+)
+
+// Backend is a key-value store from which the configuration can be loaded and watched
+type Backend interface {
+	// Get retrieves the configuration as well as an opaque identifier of its current version
+	Get(ctx context.Context) (data []byte, version string, err error)
+
+	// Wait blocks until the version of the configuration is different from the version passed, or until the context
+	// is done. It may return early with the same version, in which case the caller is expected to call Wait again.
+	Wait(ctx context.Context, version string) (string, error)
+
+	// String returns a human-readable description of the backend, used for logging purposes
+	String() string
+}
+
+// NewBackendFromEnvironment creates a Backend based on the following environment variables:
+//   - GATUS_CONFIG_KV_PROVIDER: consul, etcd or vault (required to enable the backend)
+//   - GATUS_CONFIG_KV_ADDRESS: address of the backend, e.g. http://consul:8500 (required)
+//   - GATUS_CONFIG_KV_KEY: key or path under which the configuration is stored (required)
+//   - GATUS_CONFIG_KV_TOKEN: token used to authenticate with the backend (optional)
+//   - GATUS_CONFIG_KV_VAULT_FIELD: field of the Vault secret that contains the configuration (defaults to config)
+//   - GATUS_CONFIG_KV_POLL_INTERVAL: interval at which etcd and Vault are polled for changes (defaults to 30s)
+//
+// Returns nil if GATUS_CONFIG_KV_PROVIDER is not set.
+func NewBackendFromEnvironment() (Backend, error) {
+	provider := strings.ToLower(os.Getenv("GATUS_CONFIG_KV_PROVIDER"))
+	if len(provider) == 0 {
+		return nil, nil
+	}
+	address := strings.TrimSuffix(os.Getenv("GATUS_CONFIG_KV_ADDRESS"), "/")
+	if len(address) == 0 {
+		return nil, ErrMissingAddress
+	}
+	key := strings.Trim(os.Getenv("GATUS_CONFIG_KV_KEY"), "/")
+	if len(key) == 0 {
+		return nil, ErrMissingKey
+	}
+	token := os.Getenv("GATUS_CONFIG_KV_TOKEN")
+	pollInterval := DefaultPollInterval
+	if value := os.Getenv("GATUS_CONFIG_KV_POLL_INTERVAL"); len(value) > 0 {
+		var err error
+		if pollInterval, err = time.ParseDuration(value); err != nil || pollInterval <= 0 {
+			return nil, fmt.Errorf("invalid GATUS_CONFIG_KV_POLL_INTERVAL: %s", value)
+		}
+	}
+	switch provider {
+	case ProviderConsul:
+		return NewConsulBackend(address, key, token), nil
+	case ProviderEtcd:
+		return NewEtcdBackend(address, key, token, pollInterval), nil
+	case ProviderVault:
+		field := os.Getenv("GATUS_CONFIG_KV_VAULT_FIELD")
+		if len(field) == 0 {
+			field = DefaultVaultField
+		}
+		return NewVaultBackend(address, key, field, token, pollInterval), nil
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+// pollUntilChanged calls backend.Get at the interval passed until the version differs from the version passed
+func pollUntilChanged(ctx context.Context, backend Backend, version string, interval time.Duration) (string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return version, ctx.Err()
+		case <-time.After(interval):
+		}
+		_, newVersion, err := backend.Get(ctx)
+		if err != nil {
+			return version, err
+		}
+		if newVersion != version {
+			return newVersion, nil
+		}
+	}
+}
+
+func checksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+// Watcher keeps track of the latest version of the configuration stored in a Backend
+type Watcher struct {
+	backend Backend
+	cancel  context.CancelFunc
+
+	mutex         sync.RWMutex
+	latestVersion string
+}
+
+// Watch starts watching the backend for changes made after the version passed
+func Watch(backend Backend, version string) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher := &Watcher{backend: backend, cancel: cancel, latestVersion: version}
+	go watcher.watch(ctx, version)
+	return watcher
+}
+
+func (w *Watcher) watch(ctx context.Context, version string) {
+	for {
+		newVersion, err := w.backend.Wait(ctx, version)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("[kv.watch] Failed to watch configuration from %s: %s", w.backend.String(), err.Error())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryDelay):
+			}
+			continue
+		}
+		if newVersion != version {
+			w.mutex.Lock()
+			w.latestVersion = newVersion
+			w.mutex.Unlock()
+			version = newVersion
+		}
+	}
+}
+
+// LatestVersion returns the latest version of the configuration observed in the backend
+func (w *Watcher) LatestVersion() string {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.latestVersion
+}
+
+// Stop stops watching the backend
+func (w *Watcher) Stop() {
+	w.cancel()
+}
@@ -0,0 +1,136 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewBackendFromEnvironment(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		env           map[string]string
+		expectedType  Backend
+		expectedError error
+	}{
+		{
+			name:         "not-configured",
+			env:          map[string]string{},
+			expectedType: nil,
+		},
+		{
+			name:          "unknown-provider",
+			env:           map[string]string{"GATUS_CONFIG_KV_PROVIDER": "zookeeper", "GATUS_CONFIG_KV_ADDRESS": "http://localhost", "GATUS_CONFIG_KV_KEY": "gatus"},
+			expectedError: ErrUnknownProvider,
+		},
+		{
+			name:          "missing-address",
+			env:           map[string]string{"GATUS_CONFIG_KV_PROVIDER": "consul", "GATUS_CONFIG_KV_KEY": "gatus"},
+			expectedError: ErrMissingAddress,
+		},
+		{
+			name:          "missing-key",
+			env:           map[string]string{"GATUS_CONFIG_KV_PROVIDER": "consul", "GATUS_CONFIG_KV_ADDRESS": "http://localhost:8500"},
+			expectedError: ErrMissingKey,
+		},
+		{
+			name:         "consul",
+			env:          map[string]string{"GATUS_CONFIG_KV_PROVIDER": "consul", "GATUS_CONFIG_KV_ADDRESS": "http://localhost:8500/", "GATUS_CONFIG_KV_KEY": "/gatus/config"},
+			expectedType: &ConsulBackend{},
+		},
+		{
+			name:         "etcd",
+			env:          map[string]string{"GATUS_CONFIG_KV_PROVIDER": "etcd", "GATUS_CONFIG_KV_ADDRESS": "http://localhost:2379", "GATUS_CONFIG_KV_KEY": "gatus"},
+			expectedType: &EtcdBackend{},
+		},
+		{
+			name:         "vault",
+			env:          map[string]string{"GATUS_CONFIG_KV_PROVIDER": "VAULT", "GATUS_CONFIG_KV_ADDRESS": "http://localhost:8200", "GATUS_CONFIG_KV_KEY": "secret/data/gatus"},
+			expectedType: &VaultBackend{},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			for _, name := range []string{"GATUS_CONFIG_KV_PROVIDER", "GATUS_CONFIG_KV_ADDRESS", "GATUS_CONFIG_KV_KEY", "GATUS_CONFIG_KV_TOKEN", "GATUS_CONFIG_KV_VAULT_FIELD", "GATUS_CONFIG_KV_POLL_INTERVAL"} {
+				t.Setenv(name, scenario.env[name])
+			}
+			backend, err := NewBackendFromEnvironment()
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			switch expected := scenario.expectedType.(type) {
+			case nil:
+				if err == nil && backend != nil {
+					t.Errorf("expected no backend, got %s", backend.String())
+				}
+			case *ConsulBackend:
+				if consulBackend, ok := backend.(*ConsulBackend); !ok || consulBackend.address != "http://localhost:8500" || consulBackend.key != "gatus/config" {
+					t.Errorf("expected %T with trimmed address and key, got %#v", expected, backend)
+				}
+			case *EtcdBackend:
+				if etcdBackend, ok := backend.(*EtcdBackend); !ok || etcdBackend.pollInterval != DefaultPollInterval {
+					t.Errorf("expected %T with default poll interval, got %#v", expected, backend)
+				}
+			case *VaultBackend:
+				if vaultBackend, ok := backend.(*VaultBackend); !ok || vaultBackend.field != DefaultVaultField {
+					t.Errorf("expected %T with default field, got %#v", expected, backend)
+				}
+			}
+		})
+	}
+}
+
+func TestNewBackendFromEnvironmentWithInvalidPollInterval(t *testing.T) {
+	t.Setenv("GATUS_CONFIG_KV_PROVIDER", "etcd")
+	t.Setenv("GATUS_CONFIG_KV_ADDRESS", "http://localhost:2379")
+	t.Setenv("GATUS_CONFIG_KV_KEY", "gatus")
+	t.Setenv("GATUS_CONFIG_KV_POLL_INTERVAL", "-5s")
+	if _, err := NewBackendFromEnvironment(); err == nil {
+		t.Error("expected an error, because the poll interval is negative")
+	}
+}
+
+type mockBackend struct {
+	mutex   sync.Mutex
+	data    []byte
+	version string
+}
+
+func (b *mockBackend) set(data, version string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.data, b.version = []byte(data), version
+}
+
+func (b *mockBackend) Get(_ context.Context) ([]byte, string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.data, b.version, nil
+}
+
+func (b *mockBackend) Wait(ctx context.Context, version string) (string, error) {
+	return pollUntilChanged(ctx, b, version, 10*time.Millisecond)
+}
+
+func (b *mockBackend) String() string {
+	return "mock"
+}
+
+func TestWatch(t *testing.T) {
+	backend := &mockBackend{}
+	backend.set("a", "1")
+	watcher := Watch(backend, "1")
+	defer watcher.Stop()
+	if watcher.LatestVersion() != "1" {
+		t.Errorf("expected latest version to be 1, got %s", watcher.LatestVersion())
+	}
+	backend.set("b", "2")
+	for i := 0; i < 100 && watcher.LatestVersion() != "2"; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if watcher.LatestVersion() != "2" {
+		t.Errorf("expected latest version to be 2, got %s", watcher.LatestVersion())
+	}
+}
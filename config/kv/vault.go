@@ -0,0 +1,94 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// VaultBackend loads the configuration from a field of a Vault KV secret and polls it for changes
+//
+// Both version 1 and version 2 of the KV secrets engine are supported. For version 2, the path must include the
+// data prefix, e.g. secret/data/gatus.
+type VaultBackend struct {
+	address      string
+	path         string
+	field        string
+	token        string
+	pollInterval time.Duration
+
+	httpClient *http.Client
+}
+
+// NewVaultBackend creates a new VaultBackend
+func NewVaultBackend(address, path, field, token string, pollInterval time.Duration) *VaultBackend {
+	return &VaultBackend{
+		address:      address,
+		path:         path,
+		field:        field,
+		token:        token,
+		pollInterval: pollInterval,
+		httpClient:   newHTTPClient(requestTimeout),
+	}
+}
+
+type vaultSecretResponse struct {
+	Data map[string]any `json:"data"`
+}
+
+// Get retrieves the configuration from Vault
+func (b *VaultBackend) Get(ctx context.Context) ([]byte, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", b.address, b.path), http.NoBody)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(b.token) > 0 {
+		request.Header.Set("X-Vault-Token", b.token)
+	}
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, "", ErrKeyNotFound
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("vault returned status code %d: %s", response.StatusCode, string(body))
+	}
+	var secretResponse vaultSecretResponse
+	if err = json.Unmarshal(body, &secretResponse); err != nil {
+		return nil, "", err
+	}
+	fields := secretResponse.Data
+	// KV version 2 nests the fields of the secret under data.data
+	if nestedFields, ok := fields["data"].(map[string]any); ok {
+		if _, hasMetadata := fields["metadata"]; hasMetadata {
+			fields = nestedFields
+		}
+	}
+	value, ok := fields[b.field].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: field %s does not exist or is not a string", ErrKeyNotFound, b.field)
+	}
+	data := []byte(value)
+	// The content is used as version, because KV version 1 has no concept of versions, and because the version of
+	// a KV version 2 secret changes even if the field containing the configuration does not.
+	return data, checksum(data), nil
+}
+
+// Wait polls Vault until the configuration changes
+func (b *VaultBackend) Wait(ctx context.Context, version string) (string, error) {
+	return pollUntilChanged(ctx, b, version, b.pollInterval)
+}
+
+func (b *VaultBackend) String() string {
+	return fmt.Sprintf("vault (address=%s; path=%s; field=%s)", b.address, b.path, b.field)
+}
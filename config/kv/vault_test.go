@@ -0,0 +1,82 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVaultBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/gatus":
+			_, _ = w.Write([]byte(`{"data":{"data":{"config":"endpoints: []"},"metadata":{"version":2}}}`))
+		case "/v1/kv/gatus":
+			_, _ = w.Write([]byte(`{"data":{"config":"endpoints: []","other":"value"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name          string
+		path          string
+		field         string
+		token         string
+		expectedData  string
+		expectedError error
+	}{
+		{
+			name:         "kv-v2",
+			path:         "secret/data/gatus",
+			field:        "config",
+			token:        "token",
+			expectedData: "endpoints: []",
+		},
+		{
+			name:         "kv-v1",
+			path:         "kv/gatus",
+			field:        "config",
+			token:        "token",
+			expectedData: "endpoints: []",
+		},
+		{
+			name:          "missing-field",
+			path:          "secret/data/gatus",
+			field:         "missing",
+			token:         "token",
+			expectedError: ErrKeyNotFound,
+		},
+		{
+			name:          "missing-path",
+			path:          "secret/data/missing",
+			field:         "config",
+			token:         "token",
+			expectedError: ErrKeyNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			data, version, err := NewVaultBackend(server.URL, scenario.path, scenario.field, scenario.token, time.Second).Get(context.Background())
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if string(data) != scenario.expectedData {
+				t.Errorf("expected data %s, got %s", scenario.expectedData, data)
+			}
+			if err == nil && version != checksum([]byte(scenario.expectedData)) {
+				t.Errorf("expected version to be the checksum of the data, got %s", version)
+			}
+		})
+	}
+	if _, _, err := NewVaultBackend(server.URL, "secret/data/gatus", "config", "", time.Second).Get(context.Background()); err == nil {
+		t.Error("expected an error, because no token was provided")
+	}
+}
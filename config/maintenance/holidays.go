@@ -0,0 +1,209 @@
+package maintenance
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+const (
+	// icalRefreshInterval is how often the holidays of an iCalendar feed are retrieved again
+	icalRefreshInterval = 24 * time.Hour
+
+	// icalRetryInterval is how long to wait before retrying to retrieve an iCalendar feed that couldn't be retrieved
+	icalRetryInterval = 5 * time.Minute
+
+	// icalMaximumSize is the maximum size of an iCalendar feed
+	icalMaximumSize = 10 << 20
+
+	icalDateFormat = "20060102"
+)
+
+var (
+	errNoHolidayCalendar          = errors.New("invalid holidays configuration: country or ical-url must be specified")
+	errUnsupportedHolidayCountry  = fmt.Errorf("invalid holidays configuration: unsupported country. supported values are %s", supportedHolidayCountries())
+	errInvalidHolidayCalendarURL  = errors.New("invalid holidays configuration: ical-url must be an http:// or https:// url")
+	errInvalidHolidayCalendarFeed = errors.New("invalid iCalendar feed")
+)
+
+// HolidaysConfig is the configuration of the public holidays during which the maintenance period applies all day,
+// which allows the alerts of services that are only used on business days to be silenced on public holidays like
+// they are on weekends.
+//
+// The holidays are those of the embedded calendar of Country, as well as the all-day events of the iCalendar feed at
+// ICalURL. If both are set, the holidays of both calendars apply.
+type HolidaysConfig struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country whose public holidays apply (e.g. US), optionally followed
+	// by the ISO 3166-2 code of a region (e.g. GB-SCT). See holidayCalendars for the list of supported values.
+	Country string `yaml:"country,omitempty"`
+
+	// ICalURL is the URL of an iCalendar feed whose all-day events are holidays (e.g. the public holidays calendar of
+	// a calendar provider). The feed is retrieved again every day.
+	ICalURL string `yaml:"ical-url,omitempty"`
+
+	mutex         sync.Mutex
+	icalHolidays  map[string]bool
+	icalRecurring map[string]bool // month and day (e.g. 1225) of the yearly recurring all-day events of the feed
+	nextRefreshAt time.Time
+	refreshing    bool
+}
+
+// ValidateAndSetDefaults validates the holidays configuration and, if ICalURL is set, retrieves the iCalendar feed.
+// Failing to retrieve the feed isn't an error, since it is retried regularly.
+func (c *HolidaysConfig) ValidateAndSetDefaults() error {
+	if len(c.Country) == 0 && len(c.ICalURL) == 0 {
+		return errNoHolidayCalendar
+	}
+	if len(c.Country) > 0 {
+		c.Country = strings.ToUpper(c.Country)
+		if _, exists := holidayCalendars[c.Country]; !exists {
+			return errUnsupportedHolidayCountry
+		}
+	}
+	if len(c.ICalURL) > 0 {
+		if u, err := url.Parse(c.ICalURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return errInvalidHolidayCalendarURL
+		}
+		c.refresh()
+	}
+	return nil
+}
+
+// IsHoliday returns whether the day of the time passed, in the location of the time passed, is a holiday
+func (c *HolidaysConfig) IsHoliday(t time.Time) bool {
+	day := date(t.Year(), t.Month(), t.Day())
+	if calendar, exists := holidayCalendars[c.Country]; exists {
+		// The holidays of the next year are included, because New Year's Day may be observed on December 31st
+		for _, holiday := range append(calendar(day.Year()), calendar(day.Year()+1)...) {
+			if holiday.Equal(day) {
+				return true
+			}
+		}
+	}
+	if len(c.ICalURL) == 0 {
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.refreshing && time.Now().After(c.nextRefreshAt) {
+		// The feed is refreshed in the background, so that checking whether it's a holiday never blocks
+		c.refreshing = true
+		go c.refresh()
+	}
+	return c.icalHolidays[day.Format(icalDateFormat)] || c.icalRecurring[day.Format("0102")]
+}
+
+// supportedHolidayCountries returns the sorted list of the countries and regions with an embedded calendar
+func supportedHolidayCountries() []string {
+	countries := make([]string, 0, len(holidayCalendars))
+	for country := range holidayCalendars {
+		countries = append(countries, country)
+	}
+	slices.Sort(countries)
+	return countries
+}
+
+// refresh retrieves the holidays of the iCalendar feed
+func (c *HolidaysConfig) refresh() {
+	holidays, recurring, err := fetchICalHolidays(c.ICalURL)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.refreshing = false
+	if err != nil {
+		log.Printf("[maintenance.refresh] Failed to retrieve holidays from %s: %s", c.ICalURL, err.Error())
+		c.nextRefreshAt = time.Now().Add(icalRetryInterval)
+		return
+	}
+	c.icalHolidays, c.icalRecurring = holidays, recurring
+	c.nextRefreshAt = time.Now().Add(icalRefreshInterval)
+}
+
+func fetchICalHolidays(icalURL string) (map[string]bool, map[string]bool, error) {
+	response, err := client.GetHTTPClient(nil).Get(icalURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return parseICalHolidays(io.LimitReader(response.Body, icalMaximumSize))
+}
+
+// parseICalHolidays returns the days of the all-day events of an iCalendar feed (RFC 5545), as well as the month and
+// day of those that recur yearly. Events with a time are ignored, since holidays span whole days.
+func parseICalHolidays(reader io.Reader) (map[string]bool, map[string]bool, error) {
+	scanner := bufio.NewScanner(reader)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Long lines are folded by inserting a line break followed by a space or a tab
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(lines) == 0 || lines[0] != "BEGIN:VCALENDAR" {
+		return nil, nil, fmt.Errorf("%w: missing BEGIN:VCALENDAR", errInvalidHolidayCalendarFeed)
+	}
+	holidays, recurring := make(map[string]bool), make(map[string]bool)
+	var inEvent, recursYearly bool
+	var start, end time.Time
+	for _, line := range lines {
+		name, value, _ := strings.Cut(line, ":")
+		// Parameters (e.g. DTSTART;VALUE=DATE) are irrelevant
+		name, _, _ = strings.Cut(name, ";")
+		switch {
+		case line == "BEGIN:VEVENT":
+			inEvent, recursYearly, start, end = true, false, time.Time{}, time.Time{}
+		case line == "END:VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			if end.IsZero() || !end.After(start) {
+				// The end date is exclusive, and defaults to the day after the start date
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				holidays[day.Format(icalDateFormat)] = true
+				if recursYearly {
+					recurring[day.Format("0102")] = true
+				}
+			}
+		case !inEvent:
+		case name == "DTSTART" || name == "DTEND":
+			// Only all-day events, whose dates have no time (e.g. 20251225 rather than 20251225T090000Z), are holidays
+			if len(value) != len(icalDateFormat) {
+				start, inEvent = time.Time{}, false
+				continue
+			}
+			day, err := time.Parse(icalDateFormat, value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: invalid %s %q", errInvalidHolidayCalendarFeed, name, value)
+			}
+			if name == "DTSTART" {
+				start = day
+			} else {
+				end = day
+			}
+		case name == "RRULE":
+			recursYearly = value == "FREQ=YEARLY"
+		}
+	}
+	return holidays, recurring, nil
+}
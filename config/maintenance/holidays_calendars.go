@@ -0,0 +1,276 @@
+package maintenance
+
+import (
+	"time"
+)
+
+// holidayCalendar returns the public holidays of a year, which are the days off observed by most businesses.
+// When a holiday is moved to a weekday because it falls on a weekend, the day it is observed on is returned.
+type holidayCalendar func(year int) []time.Time
+
+// holidayCalendars are the calendars embedded in Gatus, by ISO 3166-1 alpha-2 code of the country, optionally
+// followed by the ISO 3166-2 code of a region
+var holidayCalendars = map[string]holidayCalendar{
+	"BE":     belgianHolidays,
+	"CA":     canadianHolidays,
+	"DE":     germanHolidays,
+	"FR":     frenchHolidays,
+	"GB":     englishHolidays,
+	"GB-ENG": englishHolidays,
+	"GB-WLS": englishHolidays,
+	"GB-SCT": scottishHolidays,
+	"GB-NIR": northernIrishHolidays,
+	"IE":     irishHolidays,
+	"NL":     dutchHolidays,
+	"US":     americanHolidays,
+}
+
+func americanHolidays(year int) []time.Time {
+	holidays := []time.Time{
+		observedOnNearestWeekday(date(year, time.January, 1)),
+		nthWeekday(year, time.January, time.Monday, 3),  // Martin Luther King Jr. Day
+		nthWeekday(year, time.February, time.Monday, 3), // Washington's Birthday
+		lastWeekday(year, time.May, time.Monday),        // Memorial Day
+		observedOnNearestWeekday(date(year, time.July, 4)),
+		nthWeekday(year, time.September, time.Monday, 1), // Labor Day
+		nthWeekday(year, time.October, time.Monday, 2),   // Columbus Day
+		observedOnNearestWeekday(date(year, time.November, 11)),
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving Day
+		observedOnNearestWeekday(date(year, time.December, 25)),
+	}
+	if year >= 2021 {
+		holidays = append(holidays, observedOnNearestWeekday(date(year, time.June, 19))) // Juneteenth
+	}
+	return holidays
+}
+
+func canadianHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	return []time.Time{
+		observedOnMonday(date(year, time.January, 1)),
+		easter.AddDate(0, 0, -2),               // Good Friday
+		mondayBefore(date(year, time.May, 25)), // Victoria Day
+		observedOnMonday(date(year, time.July, 1)),
+		nthWeekday(year, time.September, time.Monday, 1), // Labour Day
+		nthWeekday(year, time.October, time.Monday, 2),   // Thanksgiving
+		observedOnMonday(date(year, time.November, 11)),
+		observedOnMonday(date(year, time.December, 25)),
+	}
+}
+
+func englishHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	return append([]time.Time{
+		substitutedOnNextWeekday(date(year, time.January, 1)),
+		easter.AddDate(0, 0, -2),                    // Good Friday
+		easter.AddDate(0, 0, 1),                     // Easter Monday
+		nthWeekday(year, time.May, time.Monday, 1),  // Early May bank holiday
+		lastWeekday(year, time.May, time.Monday),    // Spring bank holiday
+		lastWeekday(year, time.August, time.Monday), // Summer bank holiday
+	}, christmasAndBoxingDay(year)...)
+}
+
+func scottishHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	newYear := substitutedOnNextWeekday(date(year, time.January, 1))
+	return append([]time.Time{
+		newYear,
+		substitutedOnNextWeekday(maxDate(date(year, time.January, 2), newYear.AddDate(0, 0, 1))),
+		easter.AddDate(0, 0, -2),                                // Good Friday
+		nthWeekday(year, time.May, time.Monday, 1),              // Early May bank holiday
+		lastWeekday(year, time.May, time.Monday),                // Spring bank holiday
+		nthWeekday(year, time.August, time.Monday, 1),           // Summer bank holiday
+		substitutedOnNextWeekday(date(year, time.November, 30)), // St Andrew's Day
+	}, christmasAndBoxingDay(year)...)
+}
+
+func northernIrishHolidays(year int) []time.Time {
+	return append(englishHolidays(year),
+		substitutedOnNextWeekday(date(year, time.March, 17)), // St Patrick's Day
+		substitutedOnNextWeekday(date(year, time.July, 12)),  // Battle of the Boyne
+	)
+}
+
+func irishHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	holidays := append([]time.Time{
+		substitutedOnNextWeekday(date(year, time.January, 1)),
+		substitutedOnNextWeekday(date(year, time.March, 17)), // St Patrick's Day
+		easter.AddDate(0, 0, 1),                              // Easter Monday
+		nthWeekday(year, time.May, time.Monday, 1),           // May bank holiday
+		nthWeekday(year, time.June, time.Monday, 1),          // June bank holiday
+		nthWeekday(year, time.August, time.Monday, 1),        // August bank holiday
+		lastWeekday(year, time.October, time.Monday),         // October bank holiday
+	}, christmasAndBoxingDay(year)...)
+	if year >= 2023 {
+		// St Brigid's Day is the first Monday of February, unless February 1st is a Friday
+		stBrigidsDay := date(year, time.February, 1)
+		if stBrigidsDay.Weekday() != time.Friday {
+			stBrigidsDay = nthWeekday(year, time.February, time.Monday, 1)
+		}
+		holidays = append(holidays, stBrigidsDay)
+	}
+	return holidays
+}
+
+func frenchHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	return []time.Time{
+		date(year, time.January, 1),
+		easter.AddDate(0, 0, 1),  // Easter Monday
+		date(year, time.May, 1),  // Labour Day
+		date(year, time.May, 8),  // Victory in Europe Day
+		easter.AddDate(0, 0, 39), // Ascension Day
+		easter.AddDate(0, 0, 50), // Whit Monday
+		date(year, time.July, 14),
+		date(year, time.August, 15),
+		date(year, time.November, 1),
+		date(year, time.November, 11),
+		date(year, time.December, 25),
+	}
+}
+
+func germanHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	return []time.Time{
+		date(year, time.January, 1),
+		easter.AddDate(0, 0, -2),    // Good Friday
+		easter.AddDate(0, 0, 1),     // Easter Monday
+		date(year, time.May, 1),     // Labour Day
+		easter.AddDate(0, 0, 39),    // Ascension Day
+		easter.AddDate(0, 0, 50),    // Whit Monday
+		date(year, time.October, 3), // German Unity Day
+		date(year, time.December, 25),
+		date(year, time.December, 26),
+	}
+}
+
+func dutchHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	kingsDay := date(year, time.April, 27)
+	if kingsDay.Weekday() == time.Sunday {
+		kingsDay = kingsDay.AddDate(0, 0, -1)
+	}
+	holidays := []time.Time{
+		date(year, time.January, 1),
+		easter.AddDate(0, 0, -2), // Good Friday
+		easter,
+		easter.AddDate(0, 0, 1), // Easter Monday
+		kingsDay,
+		easter.AddDate(0, 0, 39), // Ascension Day
+		easter.AddDate(0, 0, 49), // Whit Sunday
+		easter.AddDate(0, 0, 50), // Whit Monday
+		date(year, time.December, 25),
+		date(year, time.December, 26),
+	}
+	if year%5 == 0 {
+		// Liberation Day is a day off every five years
+		holidays = append(holidays, date(year, time.May, 5))
+	}
+	return holidays
+}
+
+func belgianHolidays(year int) []time.Time {
+	easter := easterSunday(year)
+	return []time.Time{
+		date(year, time.January, 1),
+		easter.AddDate(0, 0, 1),   // Easter Monday
+		date(year, time.May, 1),   // Labour Day
+		easter.AddDate(0, 0, 39),  // Ascension Day
+		easter.AddDate(0, 0, 50),  // Whit Monday
+		date(year, time.July, 21), // National Day
+		date(year, time.August, 15),
+		date(year, time.November, 1),
+		date(year, time.November, 11),
+		date(year, time.December, 25),
+	}
+}
+
+// christmasAndBoxingDay returns Christmas Day and Boxing Day, which are substituted by the following weekdays if
+// either falls on a weekend
+func christmasAndBoxingDay(year int) []time.Time {
+	christmas := substitutedOnNextWeekday(date(year, time.December, 25))
+	return []time.Time{christmas, substitutedOnNextWeekday(maxDate(date(year, time.December, 26), christmas.AddDate(0, 0, 1)))}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func maxDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar, computed with the anonymous Gregorian
+// algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
+
+// nthWeekday returns the nth occurrence of the weekday passed in the month passed
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last occurrence of the weekday passed in the month passed
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := date(year, month+1, 0)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// mondayBefore returns the last Monday before the day passed
+func mondayBefore(day time.Time) time.Time {
+	offset := (int(day.Weekday()) - int(time.Monday) + 7) % 7
+	if offset == 0 {
+		offset = 7
+	}
+	return day.AddDate(0, 0, -offset)
+}
+
+// observedOnNearestWeekday moves a holiday falling on a Saturday to the Friday before, and one falling on a Sunday to
+// the Monday after
+func observedOnNearestWeekday(day time.Time) time.Time {
+	switch day.Weekday() {
+	case time.Saturday:
+		return day.AddDate(0, 0, -1)
+	case time.Sunday:
+		return day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// observedOnMonday moves a holiday falling on a Sunday to the Monday after
+func observedOnMonday(day time.Time) time.Time {
+	if day.Weekday() == time.Sunday {
+		return day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// substitutedOnNextWeekday moves a holiday falling on a weekend to the Monday after
+func substitutedOnNextWeekday(day time.Time) time.Time {
+	switch day.Weekday() {
+	case time.Saturday:
+		return day.AddDate(0, 0, 2)
+	case time.Sunday:
+		return day.AddDate(0, 0, 1)
+	}
+	return day
+}
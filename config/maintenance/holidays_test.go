@@ -0,0 +1,211 @@
+package maintenance
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHolidaysConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *HolidaysConfig
+		expectedError error
+	}{
+		{
+			name:          "no-calendar",
+			cfg:           &HolidaysConfig{},
+			expectedError: errNoHolidayCalendar,
+		},
+		{
+			name: "country",
+			cfg:  &HolidaysConfig{Country: "US"},
+		},
+		{
+			name: "lowercase-region",
+			cfg:  &HolidaysConfig{Country: "gb-sct"},
+		},
+		{
+			name:          "unsupported-country",
+			cfg:           &HolidaysConfig{Country: "XX"},
+			expectedError: errUnsupportedHolidayCountry,
+		},
+		{
+			name:          "invalid-ical-url",
+			cfg:           &HolidaysConfig{ICalURL: "webcal://example.com/holidays.ics"},
+			expectedError: errInvalidHolidayCalendarURL,
+		},
+		{
+			// Failing to retrieve the feed isn't an error, since the feed is retrieved again later
+			name: "unreachable-ical-url",
+			cfg:  &HolidaysConfig{ICalURL: "http://127.0.0.1:1/holidays.ics"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestHolidaysConfig_IsHoliday(t *testing.T) {
+	scenarios := []struct {
+		country  string
+		day      string
+		expected bool
+	}{
+		{country: "US", day: "2025-01-20", expected: true}, // Martin Luther King Jr. Day
+		{country: "US", day: "2025-05-26", expected: true}, // Memorial Day
+		{country: "US", day: "2025-07-04", expected: true},
+		{country: "US", day: "2025-07-03", expected: false},
+		{country: "US", day: "2025-11-27", expected: true}, // Thanksgiving Day
+		{country: "US", day: "2021-07-05", expected: true}, // Independence Day falls on a Sunday
+		{country: "US", day: "2021-12-31", expected: true}, // New Year's Day of 2022 falls on a Saturday
+		{country: "CA", day: "2025-05-19", expected: true}, // Victoria Day
+		{country: "CA", day: "2025-04-18", expected: true}, // Good Friday
+		{country: "GB", day: "2025-04-21", expected: true}, // Easter Monday
+		{country: "GB", day: "2025-08-25", expected: true}, // Summer bank holiday
+		{country: "GB", day: "2021-12-27", expected: true}, // Christmas Day falls on a Saturday
+		{country: "GB", day: "2021-12-28", expected: true}, // Boxing Day falls on a Sunday
+		{country: "GB-SCT", day: "2022-01-03", expected: true},
+		{country: "GB-SCT", day: "2022-01-04", expected: true},
+		{country: "GB-SCT", day: "2025-08-04", expected: true},  // Summer bank holiday
+		{country: "GB-SCT", day: "2025-04-21", expected: false}, // Easter Monday isn't a bank holiday in Scotland
+		{country: "GB-NIR", day: "2025-03-17", expected: true},  // St Patrick's Day
+		{country: "IE", day: "2025-02-03", expected: true},      // St Brigid's Day
+		{country: "IE", day: "2030-02-01", expected: true},      // St Brigid's Day falls on a Friday
+		{country: "FR", day: "2025-07-14", expected: true},
+		{country: "DE", day: "2025-05-29", expected: true}, // Ascension Day
+		{country: "DE", day: "2025-06-09", expected: true}, // Whit Monday
+		{country: "DE", day: "2025-10-03", expected: true},
+		{country: "NL", day: "2025-04-26", expected: true}, // King's Day falls on a Sunday
+		{country: "NL", day: "2025-05-05", expected: true}, // Liberation Day
+		{country: "NL", day: "2026-05-05", expected: false},
+		{country: "BE", day: "2025-07-21", expected: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.country+"-"+scenario.day, func(t *testing.T) {
+			cfg := &HolidaysConfig{Country: scenario.country}
+			if err := cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			day, _ := time.Parse(time.DateOnly, scenario.day)
+			// The time of the day must not matter
+			if cfg.IsHoliday(day.Add(23*time.Hour)) != scenario.expected {
+				t.Errorf("expected %s to be a holiday=%v in %s", scenario.day, scenario.expected, scenario.country)
+			}
+		})
+	}
+}
+
+func TestEasterSunday(t *testing.T) {
+	for year, expected := range map[int]string{2019: "2019-04-21", 2024: "2024-03-31", 2025: "2025-04-20", 2038: "2038-04-25"} {
+		if easter := easterSunday(year).Format(time.DateOnly); easter != expected {
+			t.Errorf("expected Easter Sunday of %d to be %s, got %s", year, expected, easter)
+		}
+	}
+}
+
+func TestParseICalHolidays(t *testing.T) {
+	feed := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20251225",
+		"DTEND;VALUE=DATE:20251227",
+		"SUMMARY:Christmas and Boxing",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250101",
+		"SUMMARY:New Year's Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20200501",
+		"RRULE:FREQ=YEARLY",
+		"SUMMARY:Labour Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250310T090000Z",
+		"DTEND:20250310T100000Z",
+		"SUMMARY:Meeting",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	holidays, recurring, err := parseICalHolidays(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	for _, day := range []string{"20251225", "20251226", "20250101", "20200501"} {
+		if !holidays[day] {
+			t.Errorf("expected %s to be a holiday", day)
+		}
+	}
+	if holidays["20251227"] || holidays["20250310"] {
+		t.Error("expected the end date to be exclusive and the events with a time to be ignored")
+	}
+	if len(recurring) != 1 || !recurring["0501"] {
+		t.Errorf("expected only May 1st to recur yearly, got %v", recurring)
+	}
+	if _, _, err := parseICalHolidays(strings.NewReader("<html></html>")); !errors.Is(err, errInvalidHolidayCalendarFeed) {
+		t.Errorf("expected error %v, got %v", errInvalidHolidayCalendarFeed, err)
+	}
+}
+
+func TestConfig_IsUnderMaintenanceWithHolidays(t *testing.T) {
+	timezone, _ := time.LoadLocation("Pacific/Kiritimati")
+	today := time.Now().In(timezone).Format("20060102")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/holidays.ics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:" + today + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name     string
+		cfg      *Config
+		expected bool
+	}{
+		{
+			name:     "holiday",
+			cfg:      &Config{Timezone: "Pacific/Kiritimati", Holidays: &HolidaysConfig{ICalURL: server.URL + "/holidays.ics"}},
+			expected: true,
+		},
+		{
+			name:     "holiday-in-another-timezone",
+			cfg:      &Config{Timezone: "Pacific/Pago_Pago", Holidays: &HolidaysConfig{ICalURL: server.URL + "/holidays.ics"}},
+			expected: false,
+		},
+		{
+			name:     "feed-unavailable",
+			cfg:      &Config{Timezone: "Pacific/Kiritimati", Holidays: &HolidaysConfig{ICalURL: server.URL + "/unknown.ics"}},
+			expected: false,
+		},
+		{
+			name: "window-applies-on-other-days",
+			cfg: &Config{
+				Start:    time.Now().UTC().Format("15") + ":00",
+				Duration: 2 * time.Hour,
+				Holidays: &HolidaysConfig{ICalURL: server.URL + "/unknown.ics"},
+			},
+			expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if isUnderMaintenance := scenario.cfg.IsUnderMaintenance(); isUnderMaintenance != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, isUnderMaintenance)
+			}
+		})
+	}
+}
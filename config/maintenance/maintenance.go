@@ -40,6 +40,10 @@ type Config struct {
 	// Every day if empty.
 	Every []string `yaml:"every"`
 
+	// Holidays are the public holidays during which the maintenance period applies all day, in addition to the
+	// maintenance window. If set, Start and Duration may be omitted to only apply the maintenance period on holidays.
+	Holidays *HolidaysConfig `yaml:"holidays,omitempty"`
+
 	TimezoneLocation            *time.Location // Timezone in location format which the maintenance period is configured
 	durationToStartFromMidnight time.Duration
 }
@@ -81,12 +85,19 @@ func (c *Config) ValidateAndSetDefaults() error {
 		}
 	}
 	var err error
-	c.durationToStartFromMidnight, err = hhmmToDuration(c.Start)
-	if err != nil {
-		return err
+	if c.Holidays != nil {
+		if err = c.Holidays.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
 	}
-	if c.Duration <= 0 || c.Duration > 24*time.Hour {
-		return errInvalidMaintenanceDuration
+	if c.hasWindow() {
+		c.durationToStartFromMidnight, err = hhmmToDuration(c.Start)
+		if err != nil {
+			return err
+		}
+		if c.Duration <= 0 || c.Duration > 24*time.Hour {
+			return errInvalidMaintenanceDuration
+		}
 	}
 	if c.Timezone != "" {
 		c.TimezoneLocation, err = time.LoadLocation(c.Timezone)
@@ -100,7 +111,8 @@ func (c *Config) ValidateAndSetDefaults() error {
 	return nil
 }
 
-// IsUnderMaintenance checks whether the endpoints that Gatus monitors are within the configured maintenance window
+// IsUnderMaintenance checks whether the endpoints that Gatus monitors are within the configured maintenance window,
+// or if holidays are configured, whether it's a holiday in the timezone of the maintenance period
 func (c Config) IsUnderMaintenance() bool {
 	if !c.IsEnabled() {
 		return false
@@ -109,6 +121,12 @@ func (c Config) IsUnderMaintenance() bool {
 	if c.TimezoneLocation != nil {
 		now = now.In(c.TimezoneLocation)
 	}
+	if c.Holidays != nil && c.Holidays.IsHoliday(now) {
+		return true
+	}
+	if !c.hasWindow() {
+		return false
+	}
 	var dayWhereMaintenancePeriodWouldStart time.Time
 	if now.Hour() >= int(c.durationToStartFromMidnight.Hours()) {
 		dayWhereMaintenancePeriodWouldStart = now.Truncate(24 * time.Hour)
@@ -127,6 +145,12 @@ func (c Config) IsUnderMaintenance() bool {
 	return now.After(startOfMaintenancePeriod) && now.Before(endOfMaintenancePeriod)
 }
 
+// hasWindow returns whether the maintenance period has a maintenance window, which is always the case unless it only
+// applies on holidays
+func (c Config) hasWindow() bool {
+	return c.Holidays == nil || len(c.Start) > 0 || c.Duration != 0
+}
+
 func (c Config) hasDay(day string) bool {
 	for _, d := range c.Every {
 		if d == day {
@@ -0,0 +1,150 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+var (
+	// ErrRunnerWithNoName is the error with which Gatus will panic if a runner has no name
+	ErrRunnerWithNoName = errors.New("runner must have a name")
+
+	// ErrRunnerWithNoAddress is the error with which Gatus will panic if a runner has no address
+	ErrRunnerWithNoAddress = errors.New("runner must have an address")
+
+	// ErrRunnerWithNoUsername is the error with which Gatus will panic if a runner has no username
+	ErrRunnerWithNoUsername = errors.New("runner must have a username")
+
+	// ErrRunnerWithNoCredentials is the error with which Gatus will panic if a runner has neither a password nor a
+	// private key file
+	ErrRunnerWithNoCredentials = errors.New("runner must have a password or a private-key-file")
+
+	// ErrRunnerWithNoHostKey is the error with which Gatus will panic if a runner has neither a host key nor a known
+	// hosts file, which are necessary to verify the identity of the runner
+	ErrRunnerWithNoHostKey = errors.New("runner must have a host-key or a known-hosts-file")
+)
+
+// Config is the configuration of a remote host, called runner, on which the checks of endpoints can be executed
+// through SSH, which allows observing endpoints from the point of view of another network
+type Config struct {
+	// Name of the runner, which is used by endpoints to reference it
+	Name string `yaml:"name"`
+
+	// Address of the runner, in the format host:port. The port defaults to 22 if not specified.
+	Address string `yaml:"address"`
+
+	// Username used to authenticate with the runner
+	Username string `yaml:"username"`
+
+	// Password used to authenticate with the runner (optional if PrivateKeyFile is set)
+	Password string `yaml:"password,omitempty"`
+
+	// PrivateKeyFile is the path to the private key, in PEM format, used to authenticate with the runner
+	// (optional if Password is set)
+	PrivateKeyFile string `yaml:"private-key-file,omitempty"`
+
+	// HostKey is the public key of the runner, in the authorized_keys format (e.g. "ssh-ed25519 AAAA..."), used to
+	// verify the identity of the runner (optional if KnownHostsFile is set)
+	HostKey string `yaml:"host-key,omitempty"`
+
+	// KnownHostsFile is the path to a known_hosts file used to verify the identity of the runner
+	// (optional if HostKey is set)
+	KnownHostsFile string `yaml:"known-hosts-file,omitempty"`
+
+	signer          ssh.Signer
+	hostKeyCallback ssh.HostKeyCallback
+}
+
+// ValidateAndSetDefaults validates the runner configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Name) == 0 {
+		return ErrRunnerWithNoName
+	}
+	if len(c.Address) == 0 {
+		return ErrRunnerWithNoAddress
+	}
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		c.Address = net.JoinHostPort(c.Address, "22")
+	}
+	if len(c.Username) == 0 {
+		return ErrRunnerWithNoUsername
+	}
+	if len(c.Password) == 0 && len(c.PrivateKeyFile) == 0 {
+		return ErrRunnerWithNoCredentials
+	}
+	if len(c.PrivateKeyFile) > 0 {
+		privateKey, err := os.ReadFile(c.PrivateKeyFile)
+		if err != nil {
+			return err
+		}
+		if c.signer, err = ssh.ParsePrivateKey(privateKey); err != nil {
+			return err
+		}
+	}
+	if len(c.HostKey) == 0 && len(c.KnownHostsFile) == 0 {
+		return ErrRunnerWithNoHostKey
+	}
+	if len(c.HostKey) > 0 {
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.HostKey))
+		if err != nil {
+			return fmt.Errorf("invalid host-key for runner %s: %w", c.Name, err)
+		}
+		c.hostKeyCallback = ssh.FixedHostKey(hostKey)
+	} else {
+		hostKeyCallback, err := knownhosts.New(c.KnownHostsFile)
+		if err != nil {
+			return err
+		}
+		c.hostKeyCallback = hostKeyCallback
+	}
+	return nil
+}
+
+// Run executes a command on the runner with the input passed as stdin, and returns its output as well as its exit
+// status.
+//
+// An error is only returned if the command could not be executed, which means that a command that exits with a
+// non-zero status is not considered as an error.
+func (c *Config) Run(command string, stdin []byte, timeout time.Duration) (stdout, stderr []byte, exitStatus int, err error) {
+	var authMethods []ssh.AuthMethod
+	if c.signer != nil {
+		authMethods = append(authMethods, ssh.PublicKeys(c.signer))
+	}
+	if len(c.Password) > 0 {
+		authMethods = append(authMethods, ssh.Password(c.Password))
+	}
+	cli, err := ssh.Dial("tcp", c.Address, &ssh.ClientConfig{
+		HostKeyCallback: c.hostKeyCallback,
+		User:            c.Username,
+		Auth:            authMethods,
+		Timeout:         timeout,
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer cli.Close()
+	session, err := cli.NewSession()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer session.Close()
+	var stdoutBuffer, stderrBuffer bytes.Buffer
+	session.Stdin = bytes.NewReader(stdin)
+	session.Stdout = &stdoutBuffer
+	session.Stderr = &stderrBuffer
+	if err = session.Run(command); err != nil {
+		var exitError *ssh.ExitError
+		if !errors.As(err, &exitError) {
+			return nil, nil, 0, err
+		}
+		exitStatus = exitError.ExitStatus()
+	}
+	return stdoutBuffer.Bytes(), stderrBuffer.Bytes(), exitStatus, nil
+}
@@ -0,0 +1,235 @@
+package runner
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const testHostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILZ0jgNtIe6Kg6PVlUh6U6zdtwNvElA14g4A3AxK0gOK"
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		config          Config
+		expectedErr     error
+		expectedAddress string
+	}{
+		{
+			name:        "no-name",
+			config:      Config{Address: "10.0.0.1", Username: "gatus", Password: "password", HostKey: testHostKey},
+			expectedErr: ErrRunnerWithNoName,
+		},
+		{
+			name:        "no-address",
+			config:      Config{Name: "eu-west", Username: "gatus", Password: "password", HostKey: testHostKey},
+			expectedErr: ErrRunnerWithNoAddress,
+		},
+		{
+			name:        "no-username",
+			config:      Config{Name: "eu-west", Address: "10.0.0.1", Password: "password", HostKey: testHostKey},
+			expectedErr: ErrRunnerWithNoUsername,
+		},
+		{
+			name:        "no-credentials",
+			config:      Config{Name: "eu-west", Address: "10.0.0.1", Username: "gatus", HostKey: testHostKey},
+			expectedErr: ErrRunnerWithNoCredentials,
+		},
+		{
+			name:        "no-host-key",
+			config:      Config{Name: "eu-west", Address: "10.0.0.1", Username: "gatus", Password: "password"},
+			expectedErr: ErrRunnerWithNoHostKey,
+		},
+		{
+			name:            "default-port",
+			config:          Config{Name: "eu-west", Address: "10.0.0.1", Username: "gatus", Password: "password", HostKey: testHostKey},
+			expectedAddress: "10.0.0.1:22",
+		},
+		{
+			name:            "custom-port",
+			config:          Config{Name: "eu-west", Address: "10.0.0.1:2222", Username: "gatus", Password: "password", HostKey: testHostKey},
+			expectedAddress: "10.0.0.1:2222",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.config.ValidateAndSetDefaults()
+			if err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && scenario.config.Address != scenario.expectedAddress {
+				t.Errorf("expected address %s, got %s", scenario.expectedAddress, scenario.config.Address)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithPrivateKeyFile(t *testing.T) {
+	cfg := &Config{Name: "eu-west", Address: "10.0.0.1", Username: "gatus", PrivateKeyFile: filepath.Join(t.TempDir(), "missing"), HostKey: testHostKey}
+	if err := cfg.ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for a private key file that doesn't exist")
+	}
+	invalidPrivateKeyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(invalidPrivateKeyFile, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg.PrivateKeyFile = invalidPrivateKeyFile
+	if err := cfg.ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for an invalid private key")
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithHostKey(t *testing.T) {
+	cfg := &Config{Name: "eu-west", Address: "10.0.0.1", Username: "gatus", Password: "password", HostKey: "invalid"}
+	if err := cfg.ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for an invalid host key")
+	}
+	cfg.HostKey, cfg.KnownHostsFile = "", filepath.Join(t.TempDir(), "missing")
+	if err := cfg.ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for a known hosts file that doesn't exist")
+	}
+}
+
+func TestConfig_Run(t *testing.T) {
+	address, hostKey := startTestSSHServer(t, "gatus", "password")
+	cfg := &Config{Name: "local", Address: address, Username: "gatus", Password: "password", HostKey: hostKey}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	stdout, _, exitStatus, err := cfg.Run("echo", []byte("hello"), 5*time.Second)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if string(stdout) != "hello" {
+		t.Errorf("expected stdout to be the stdin echoed back, got %s", string(stdout))
+	}
+	if exitStatus != 0 {
+		t.Errorf("expected exit status 0, got %d", exitStatus)
+	}
+	_, stderr, exitStatus, err := cfg.Run("fail", nil, 5*time.Second)
+	if err != nil {
+		t.Fatal("expected no error for a command exiting with a non-zero status, got", err)
+	}
+	if exitStatus != 1 || string(stderr) != "failed" {
+		t.Errorf("expected exit status 1 and stderr 'failed', got %d and %s", exitStatus, string(stderr))
+	}
+	cfg.Password = "wrong-password"
+	if _, _, _, err = cfg.Run("echo", nil, 5*time.Second); err == nil {
+		t.Error("expected an error when authentication fails")
+	}
+}
+
+func TestConfig_RunWithKnownHostsFile(t *testing.T) {
+	address, hostKey := startTestSSHServer(t, "gatus", "password")
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(knownHostsFile, []byte(knownhosts.Line([]string{address}, mustParseAuthorizedKey(t, hostKey))+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{Name: "local", Address: address, Username: "gatus", Password: "password", KnownHostsFile: knownHostsFile}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if _, _, _, err := cfg.Run("echo", nil, 5*time.Second); err != nil {
+		t.Error("expected no error, got", err)
+	}
+}
+
+func TestConfig_RunWithUnexpectedHostKey(t *testing.T) {
+	address, _ := startTestSSHServer(t, "gatus", "password")
+	cfg := &Config{Name: "local", Address: address, Username: "gatus", Password: "password", HostKey: testHostKey}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if _, _, _, err := cfg.Run("echo", nil, 5*time.Second); err == nil {
+		t.Error("expected an error when the host key of the runner isn't the one expected")
+	}
+}
+
+func mustParseAuthorizedKey(t *testing.T, authorizedKey string) ssh.PublicKey {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return publicKey
+}
+
+// startTestSSHServer starts an SSH server supporting two commands: "echo", which writes its stdin to stdout, and
+// "fail", which writes "failed" to stderr and exits with the status 1.
+//
+// Returns the address of the server and its host key in the authorized_keys format.
+func startTestSSHServer(t *testing.T, username, password string) (string, string) {
+	_, hostPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(metadata ssh.ConnMetadata, providedPassword []byte) (*ssh.Permissions, error) {
+			if metadata.User() == username && string(providedPassword) == password {
+				return nil, nil
+			}
+			return nil, io.EOF
+		},
+	}
+	serverConfig.AddHostKey(hostSigner)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handleTestSSHConnection(connection, serverConfig)
+		}
+	}()
+	return listener.Addr().String(), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostSigner.PublicKey())))
+}
+
+func handleTestSSHConnection(connection net.Conn, serverConfig *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(connection, serverConfig)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for request := range channelRequests {
+				if request.Type != "exec" {
+					request.Reply(false, nil)
+					continue
+				}
+				request.Reply(true, nil)
+				// The payload of an exec request is the command prefixed by its length
+				command := string(request.Payload[4:])
+				exitStatus := make([]byte, 4)
+				if command == "echo" {
+					io.Copy(channel, channel)
+				} else {
+					channel.Stderr().Write([]byte("failed"))
+					binary.BigEndian.PutUint32(exitStatus, 1)
+				}
+				channel.SendRequest("exit-status", false, exitStatus)
+				return
+			}
+		}()
+	}
+}
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Source is the source from which the configuration of a Snapshot was applied
+type Source string
+
+const (
+	// SourceFile means that the configuration was loaded from a file or a directory
+	SourceFile Source = "file"
+
+	// SourceKV means that the configuration was loaded from a KV backend
+	SourceKV Source = "kv"
+
+	// SourceRollback means that the configuration was applied by rolling back to a previous Snapshot
+	SourceRollback Source = "rollback"
+)
+
+// Snapshot is a configuration that has been applied, which can be rolled back to
+type Snapshot struct {
+	// Version is the unique identifier of the snapshot, which is set by the store and increases with each snapshot
+	Version int64 `json:"version"`
+
+	// Checksum is the SHA-256 checksum of Data
+	Checksum string `json:"checksum"`
+
+	// Source is the source from which the configuration was applied
+	Source Source `json:"source"`
+
+	// RollbackOf is the version of the snapshot that was rolled back to, if Source is SourceRollback
+	RollbackOf int64 `json:"rollbackOf,omitempty"`
+
+	// AppliedAt is the time at which the configuration was applied
+	AppliedAt time.Time `json:"appliedAt"`
+
+	// Data is the raw configuration, before environment variables are expanded.
+	// It is never returned by the API, since it may contain secrets.
+	Data []byte `json:"-"`
+}
+
+// New creates a snapshot of a configuration that has just been applied
+func New(data []byte, source Source, rollbackOf int64) *Snapshot {
+	return &Snapshot{
+		Checksum:   Checksum(data),
+		Source:     source,
+		RollbackOf: rollbackOf,
+		AppliedAt:  time.Now().UTC().Truncate(time.Second),
+		Data:       data,
+	}
+}
+
+// Checksum returns the checksum of a raw configuration
+func Checksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
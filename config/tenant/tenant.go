@@ -0,0 +1,65 @@
+package tenant
+
+import (
+	"crypto/subtle"
+	"errors"
+	"regexp"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+var (
+	// ErrTenantWithInvalidName is the error with which Gatus will panic if a tenant has a name that cannot be used as
+	// a prefix for the keys of its endpoints
+	ErrTenantWithInvalidName = errors.New("tenant name must be non-empty and only contain lowercase letters, digits and hyphens")
+
+	// ErrTenantWithNoToken is the error with which Gatus will panic if a tenant that isn't public has no token
+	ErrTenantWithNoToken = errors.New("tenant must have a token unless it is public")
+
+	validNamePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// Config is the configuration of a tenant, which is a namespace isolating a set of endpoints from the endpoints of
+// other tenants. This allows a single Gatus instance to monitor the endpoints of several customers.
+type Config struct {
+	// Name of the tenant. Used as a prefix for the key of the endpoints of the tenant.
+	Name string `yaml:"name"`
+
+	// Token is the bearer token that must be provided through the Authorization header to access the API and the
+	// status page of the tenant
+	Token string `yaml:"token,omitempty"`
+
+	// Public is whether the API and the status page of the tenant are accessible without a token
+	Public bool `yaml:"public,omitempty"`
+
+	// Endpoints is the list of endpoints of the tenant
+	Endpoints []*endpoint.Endpoint `yaml:"endpoints,omitempty"`
+
+	// ExternalEndpoints is the list of external endpoints of the tenant
+	ExternalEndpoints []*endpoint.ExternalEndpoint `yaml:"external-endpoints,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the tenant configuration and assigns the tenant to each of its endpoints
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if !validNamePattern.MatchString(cfg.Name) {
+		return ErrTenantWithInvalidName
+	}
+	if !cfg.Public && len(cfg.Token) == 0 {
+		return ErrTenantWithNoToken
+	}
+	for _, ep := range cfg.Endpoints {
+		ep.Tenant = cfg.Name
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		ee.Tenant = cfg.Name
+	}
+	return nil
+}
+
+// HasAccess returns whether the token provided grants access to the tenant
+func (cfg *Config) HasAccess(token string) bool {
+	if cfg.Public {
+		return true
+	}
+	return len(token) > 0 && subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) == 1
+}
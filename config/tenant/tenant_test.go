@@ -0,0 +1,96 @@
+package tenant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedError error
+	}{
+		{
+			name:          "valid",
+			cfg:           &Config{Name: "acme", Token: "secret"},
+			expectedError: nil,
+		},
+		{
+			name:          "public-without-token",
+			cfg:           &Config{Name: "acme", Public: true},
+			expectedError: nil,
+		},
+		{
+			name:          "no-name",
+			cfg:           &Config{Token: "secret"},
+			expectedError: ErrTenantWithInvalidName,
+		},
+		{
+			name:          "name-with-underscore",
+			cfg:           &Config{Name: "acme_corp", Token: "secret"},
+			expectedError: ErrTenantWithInvalidName,
+		},
+		{
+			name:          "name-with-uppercase-letters",
+			cfg:           &Config{Name: "Acme", Token: "secret"},
+			expectedError: ErrTenantWithInvalidName,
+		},
+		{
+			name:          "private-without-token",
+			cfg:           &Config{Name: "acme"},
+			expectedError: ErrTenantWithNoToken,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsAssignsTenantToEndpoints(t *testing.T) {
+	cfg := &Config{
+		Name:              "acme",
+		Token:             "secret",
+		Endpoints:         []*endpoint.Endpoint{{Name: "website", Group: "core"}},
+		ExternalEndpoints: []*endpoint.ExternalEndpoint{{Name: "backup", Group: "core"}},
+	}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if key := cfg.Endpoints[0].Key(); key != "acme_core_website" {
+		t.Errorf("expected key of endpoint to be acme_core_website, got %s", key)
+	}
+	if key := cfg.ExternalEndpoints[0].Key(); key != "acme_core_backup" {
+		t.Errorf("expected key of external endpoint to be acme_core_backup, got %s", key)
+	}
+	if key := cfg.ExternalEndpoints[0].ToEndpoint().Key(); key != "acme_core_backup" {
+		t.Errorf("expected key of converted external endpoint to be acme_core_backup, got %s", key)
+	}
+}
+
+func TestConfig_HasAccess(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		cfg      *Config
+		token    string
+		expected bool
+	}{
+		{name: "valid-token", cfg: &Config{Name: "acme", Token: "secret"}, token: "secret", expected: true},
+		{name: "invalid-token", cfg: &Config{Name: "acme", Token: "secret"}, token: "wrong", expected: false},
+		{name: "no-token", cfg: &Config{Name: "acme", Token: "secret"}, token: "", expected: false},
+		{name: "public", cfg: &Config{Name: "acme", Public: true}, token: "", expected: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.cfg.HasAccess(scenario.token); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
@@ -28,6 +28,11 @@ type Config struct {
 	Logo        string   `yaml:"logo,omitempty"`        // Logo to display on the page
 	Link        string   `yaml:"link,omitempty"`        // Link to open when clicking on the logo
 	Buttons     []Button `yaml:"buttons,omitempty"`     // Buttons to display below the header
+
+	// BasePath is the path under which the UI is served (e.g. /status).
+	// It cannot be configured directly, as it's populated from web.Config.BasePath and the X-Forwarded-Prefix header
+	// when the page is rendered.
+	BasePath string `yaml:"-"`
 }
 
 // Button is the configuration for a button on the UI
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"strings"
+	"time"
 )
 
 const (
@@ -22,6 +25,24 @@ const (
 	MinimumReadBufferSize = 4096
 )
 
+var (
+	// DefaultCORSAllowedMethods is the default value for CORSConfig.AllowedMethods
+	DefaultCORSAllowedMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "PATCH"}
+
+	// ErrCORSNoAllowedOrigins is the error with which Gatus will panic if CORS is configured without allowed origins
+	ErrCORSNoAllowedOrigins = errors.New("allowed-origins must contain at least one origin")
+
+	// ErrCORSInvalidOrigin is the error with which Gatus will panic if one of the CORS allowed origins is invalid
+	ErrCORSInvalidOrigin = errors.New("allowed-origins must only contain '*' or origins in the format scheme://host[:port]")
+
+	// ErrCORSWildcardOriginWithCredentials is the error with which Gatus will panic if credentials are allowed for
+	// any origin, which browsers reject anyway
+	ErrCORSWildcardOriginWithCredentials = errors.New("allow-credentials cannot be enabled when allowed-origins contains '*'")
+
+	// ErrInvalidHSTSMaxAge is the error with which Gatus will panic if the HSTS max age is not a positive whole number of seconds
+	ErrInvalidHSTSMaxAge = errors.New("hsts max-age must be a positive whole number of seconds")
+)
+
 // Config is the structure which supports the configuration of the server listening to requests
 type Config struct {
 	// Address to listen on (defaults to 0.0.0.0 specified by DefaultAddress)
@@ -40,6 +61,86 @@ type Config struct {
 
 	// TLS configuration (optional)
 	TLS *TLSConfig `yaml:"tls,omitempty"`
+
+	// BasePath is the path under which all routes are served, which is useful when Gatus is served behind a reverse
+	// proxy that routes requests based on their path without stripping the prefix, e.g. /status.
+	//
+	// If the reverse proxy strips the prefix instead, the X-Forwarded-Prefix header should be set by the reverse proxy.
+	BasePath string `yaml:"base-path,omitempty"`
+
+	// ExternalURL is the URL at which users reach Gatus, e.g. https://example.com/status, which is used to link alerts
+	// to the page of their endpoint.
+	//
+	// If the URL has no path, BasePath is appended to it. Otherwise, the path of the URL is expected to already include
+	// the prefix under which Gatus is served, whether the reverse proxy strips it or not.
+	ExternalURL string `yaml:"external-url,omitempty"`
+
+	// GRPC configuration (optional)
+	GRPC *GRPCConfig `yaml:"grpc,omitempty"`
+
+	// CORS configuration (optional), which allows applications served from other origins to consume the API
+	CORS *CORSConfig `yaml:"cors,omitempty"`
+
+	// SecurityHeaders configuration (optional)
+	SecurityHeaders *SecurityHeadersConfig `yaml:"security-headers,omitempty"`
+}
+
+// CORSConfig is the configuration of the Cross-Origin Resource Sharing headers returned by the server
+type CORSConfig struct {
+	// AllowedOrigins is the list of origins allowed to access the API, e.g. https://dashboard.example.com.
+	// "*" allows every origin.
+	AllowedOrigins []string `yaml:"allowed-origins"`
+
+	// AllowedMethods is the list of methods allowed when accessing the API (defaults to DefaultCORSAllowedMethods)
+	AllowedMethods []string `yaml:"allowed-methods,omitempty"`
+
+	// AllowedHeaders is the list of request headers that can be used when accessing the API
+	AllowedHeaders []string `yaml:"allowed-headers,omitempty"`
+
+	// AllowCredentials defines whether requests with credentials, such as cookies or the Authorization header, are allowed
+	AllowCredentials bool `yaml:"allow-credentials,omitempty"`
+
+	// MaxAge is how long the result of a preflight request can be cached by the browser
+	MaxAge time.Duration `yaml:"max-age,omitempty"`
+}
+
+// SecurityHeadersConfig is the configuration of the security headers added to every response
+type SecurityHeadersConfig struct {
+	// ContentSecurityPolicy is the value of the Content-Security-Policy header
+	ContentSecurityPolicy string `yaml:"content-security-policy,omitempty"`
+
+	// FrameOptions is the value of the X-Frame-Options header, e.g. DENY or SAMEORIGIN
+	FrameOptions string `yaml:"frame-options,omitempty"`
+
+	// ReferrerPolicy is the value of the Referrer-Policy header, e.g. no-referrer
+	ReferrerPolicy string `yaml:"referrer-policy,omitempty"`
+
+	// HSTS configuration (optional). Should only be enabled if Gatus is exclusively served over HTTPS.
+	HSTS *HSTSConfig `yaml:"hsts,omitempty"`
+}
+
+// HSTSConfig is the configuration of the Strict-Transport-Security header
+type HSTSConfig struct {
+	// MaxAge is how long browsers should only access Gatus over HTTPS
+	MaxAge time.Duration `yaml:"max-age"`
+
+	// IncludeSubdomains defines whether the policy also applies to all subdomains
+	IncludeSubdomains bool `yaml:"include-subdomains,omitempty"`
+
+	// Preload defines whether to consent to the domain being included in the HSTS preload list of browsers
+	Preload bool `yaml:"preload,omitempty"`
+}
+
+// GRPCConfig is the configuration of the gRPC API
+type GRPCConfig struct {
+	// Port the gRPC server will listen on. The address used is the same as Config.Address.
+	//
+	// Must be different from Config.Port.
+	Port int `yaml:"port"`
+
+	// Gateway defines whether to expose the gRPC API as a JSON API through grpc-gateway under /api/gateway on
+	// the same port as the rest of the application
+	Gateway bool `yaml:"gateway,omitempty"`
 }
 
 type TLSConfig struct {
@@ -77,6 +178,48 @@ func (web *Config) ValidateAndSetDefaults() error {
 	} else if web.ReadBufferSize < MinimumReadBufferSize {
 		web.ReadBufferSize = MinimumReadBufferSize // Below the minimum? Use the minimum value.
 	}
+	// Validate the BasePath
+	if len(web.BasePath) > 0 {
+		if strings.ContainsAny(web.BasePath, "?#:* ") {
+			return fmt.Errorf("invalid base-path: %s", web.BasePath)
+		}
+		if !strings.HasPrefix(web.BasePath, "/") {
+			web.BasePath = "/" + web.BasePath
+		}
+		web.BasePath = strings.TrimRight(web.BasePath, "/")
+	}
+	// Validate the ExternalURL
+	if len(web.ExternalURL) > 0 {
+		externalURL, err := url.Parse(web.ExternalURL)
+		if err != nil || (externalURL.Scheme != "http" && externalURL.Scheme != "https") || len(externalURL.Host) == 0 {
+			return fmt.Errorf("invalid external-url: %s", web.ExternalURL)
+		}
+		web.ExternalURL = strings.TrimRight(web.ExternalURL, "/")
+		if len(strings.Trim(externalURL.Path, "/")) == 0 {
+			web.ExternalURL += web.BasePath
+		}
+	}
+	// Validate the gRPC configuration
+	if web.GRPC != nil {
+		if web.GRPC.Port <= 0 || web.GRPC.Port > math.MaxUint16 {
+			return fmt.Errorf("invalid grpc port: value should be between %d and %d", 1, math.MaxUint16)
+		}
+		if web.GRPC.Port == web.Port {
+			return fmt.Errorf("invalid grpc port: must be different from the web port (%d)", web.Port)
+		}
+	}
+	// Validate the CORS configuration
+	if web.CORS != nil {
+		if err := web.CORS.validateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid cors config: %w", err)
+		}
+	}
+	// Validate the security headers configuration
+	if web.SecurityHeaders != nil && web.SecurityHeaders.HSTS != nil {
+		if err := web.SecurityHeaders.HSTS.isValid(); err != nil {
+			return fmt.Errorf("invalid security-headers config: %w", err)
+		}
+	}
 	// Try to load the TLS certificates
 	if web.TLS != nil {
 		if err := web.TLS.isValid(); err != nil {
@@ -86,6 +229,15 @@ func (web *Config) ValidateAndSetDefaults() error {
 	return nil
 }
 
+// EndpointPageURL returns the URL of the page of the endpoint with the given key, or an empty string if no
+// ExternalURL is configured
+func (web *Config) EndpointPageURL(key string) string {
+	if len(web.ExternalURL) == 0 {
+		return ""
+	}
+	return web.ExternalURL + "/endpoints/" + url.PathEscape(key)
+}
+
 func (web *Config) HasTLS() bool {
 	return web.TLS != nil && len(web.TLS.CertificateFile) > 0 && len(web.TLS.PrivateKeyFile) > 0
 }
@@ -95,6 +247,11 @@ func (web *Config) SocketAddress() string {
 	return fmt.Sprintf("%s:%d", web.Address, web.Port)
 }
 
+// GRPCSocketAddress returns the combination of the Address and the port of the gRPC server
+func (web *Config) GRPCSocketAddress() string {
+	return fmt.Sprintf("%s:%d", web.Address, web.GRPC.Port)
+}
+
 func (t *TLSConfig) isValid() error {
 	if len(t.CertificateFile) > 0 && len(t.PrivateKeyFile) > 0 {
 		_, err := tls.LoadX509KeyPair(t.CertificateFile, t.PrivateKeyFile)
@@ -105,3 +262,44 @@ func (t *TLSConfig) isValid() error {
 	}
 	return errors.New("certificate-file and private-key-file must be specified")
 }
+
+func (c *CORSConfig) validateAndSetDefaults() error {
+	if len(c.AllowedOrigins) == 0 {
+		return ErrCORSNoAllowedOrigins
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			if c.AllowCredentials {
+				return ErrCORSWildcardOriginWithCredentials
+			}
+			continue
+		}
+		parsedOrigin, err := url.Parse(origin)
+		if err != nil || len(parsedOrigin.Scheme) == 0 || len(parsedOrigin.Host) == 0 || len(strings.TrimSuffix(parsedOrigin.Path, "/")) > 0 || len(parsedOrigin.RawQuery) > 0 {
+			return fmt.Errorf("%w: %s", ErrCORSInvalidOrigin, origin)
+		}
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = DefaultCORSAllowedMethods
+	}
+	return nil
+}
+
+func (h *HSTSConfig) isValid() error {
+	if h.MaxAge < time.Second || h.MaxAge%time.Second != 0 {
+		return ErrInvalidHSTSMaxAge
+	}
+	return nil
+}
+
+// Value returns the value of the Strict-Transport-Security header
+func (h *HSTSConfig) Value() string {
+	value := fmt.Sprintf("max-age=%d", int64(h.MaxAge/time.Second))
+	if h.IncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if h.Preload {
+		value += "; preload"
+	}
+	return value
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"testing"
+	"time"
 )
 
 func TestGetDefaultConfig(t *testing.T) {
@@ -27,6 +28,7 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 		expectedAddress        string
 		expectedPort           int
 		expectedReadBufferSize int
+		expectedBasePath       string
 		expectedErr            bool
 	}{
 		{
@@ -66,6 +68,43 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 			expectedReadBufferSize: 65536,
 			expectedErr:            false,
 		},
+		{
+			name:                   "base-path",
+			cfg:                    &Config{BasePath: "/status"},
+			expectedAddress:        "0.0.0.0",
+			expectedPort:           8080,
+			expectedReadBufferSize: 8192,
+			expectedBasePath:       "/status",
+			expectedErr:            false,
+		},
+		{
+			name:                   "base-path-without-leading-slash-and-with-trailing-slash",
+			cfg:                    &Config{BasePath: "status/"},
+			expectedAddress:        "0.0.0.0",
+			expectedPort:           8080,
+			expectedReadBufferSize: 8192,
+			expectedBasePath:       "/status",
+			expectedErr:            false,
+		},
+		{
+			name:                   "base-path-root",
+			cfg:                    &Config{BasePath: "/"},
+			expectedAddress:        "0.0.0.0",
+			expectedPort:           8080,
+			expectedReadBufferSize: 8192,
+			expectedBasePath:       "",
+			expectedErr:            false,
+		},
+		{
+			name:        "invalid-base-path",
+			cfg:         &Config{BasePath: "/status?page=1"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-external-url",
+			cfg:         &Config{ExternalURL: "example.org/status"},
+			expectedErr: true,
+		},
 		{
 			name:                   "with-good-tls-config",
 			cfg:                    &Config{Port: 443, TLS: &TLSConfig{CertificateFile: "../../testdata/cert.pem", PrivateKeyFile: "../../testdata/cert.key"}},
@@ -90,6 +129,60 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 			expectedReadBufferSize: 8192,
 			expectedErr:            true,
 		},
+		{
+			name:                   "grpc",
+			cfg:                    &Config{GRPC: &GRPCConfig{Port: 8082}},
+			expectedAddress:        "0.0.0.0",
+			expectedPort:           8080,
+			expectedReadBufferSize: 8192,
+			expectedErr:            false,
+		},
+		{
+			name:        "grpc-without-port",
+			cfg:         &Config{GRPC: &GRPCConfig{Gateway: true}},
+			expectedErr: true,
+		},
+		{
+			name:        "grpc-with-same-port-as-web",
+			cfg:         &Config{Port: 8082, GRPC: &GRPCConfig{Port: 8082}},
+			expectedErr: true,
+		},
+		{
+			name:                   "cors",
+			cfg:                    &Config{CORS: &CORSConfig{AllowedOrigins: []string{"https://example.com", "http://localhost:8081"}, AllowCredentials: true}},
+			expectedAddress:        "0.0.0.0",
+			expectedPort:           8080,
+			expectedReadBufferSize: 8192,
+			expectedErr:            false,
+		},
+		{
+			name:        "cors-without-allowed-origins",
+			cfg:         &Config{CORS: &CORSConfig{}},
+			expectedErr: true,
+		},
+		{
+			name:        "cors-with-origin-containing-path",
+			cfg:         &Config{CORS: &CORSConfig{AllowedOrigins: []string{"https://example.com/dashboard"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "cors-with-wildcard-origin-and-credentials",
+			cfg:         &Config{CORS: &CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}},
+			expectedErr: true,
+		},
+		{
+			name:                   "security-headers-with-hsts",
+			cfg:                    &Config{SecurityHeaders: &SecurityHeadersConfig{HSTS: &HSTSConfig{MaxAge: 365 * 24 * time.Hour}}},
+			expectedAddress:        "0.0.0.0",
+			expectedPort:           8080,
+			expectedReadBufferSize: 8192,
+			expectedErr:            false,
+		},
+		{
+			name:        "security-headers-with-hsts-without-max-age",
+			cfg:         &Config{SecurityHeaders: &SecurityHeadersConfig{HSTS: &HSTSConfig{}}},
+			expectedErr: true,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
@@ -108,6 +201,48 @@ func TestConfig_ValidateAndSetDefaults(t *testing.T) {
 				if scenario.cfg.Address != scenario.expectedAddress {
 					t.Errorf("expected Address to be %s, got %s", scenario.expectedAddress, scenario.cfg.Address)
 				}
+				if scenario.cfg.BasePath != scenario.expectedBasePath {
+					t.Errorf("expected BasePath to be %s, got %s", scenario.expectedBasePath, scenario.cfg.BasePath)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_EndpointPageURL(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedURL string
+	}{
+		{
+			name:        "no-external-url",
+			cfg:         &Config{BasePath: "/status"},
+			expectedURL: "",
+		},
+		{
+			name:        "external-url-without-path",
+			cfg:         &Config{ExternalURL: "https://example.org/"},
+			expectedURL: "https://example.org/endpoints/core_frontend",
+		},
+		{
+			name:        "external-url-without-path-and-base-path",
+			cfg:         &Config{ExternalURL: "https://example.org", BasePath: "/status"},
+			expectedURL: "https://example.org/status/endpoints/core_frontend",
+		},
+		{
+			name:        "external-url-with-path",
+			cfg:         &Config{ExternalURL: "https://example.org/monitoring/", BasePath: "/status"},
+			expectedURL: "https://example.org/monitoring/endpoints/core_frontend",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if pageURL := scenario.cfg.EndpointPageURL("core_frontend"); pageURL != scenario.expectedURL {
+				t.Errorf("expected %s, got %s", scenario.expectedURL, pageURL)
 			}
 		})
 	}
@@ -185,3 +320,42 @@ func TestConfig_isValid(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_GRPCSocketAddress(t *testing.T) {
+	web := &Config{
+		Address: "127.0.0.1",
+		Port:    8080,
+		GRPC:    &GRPCConfig{Port: 8082},
+	}
+	if web.GRPCSocketAddress() != "127.0.0.1:8082" {
+		t.Errorf("expected %s, got %s", "127.0.0.1:8082", web.GRPCSocketAddress())
+	}
+}
+
+func TestCORSConfig_validateAndSetDefaults(t *testing.T) {
+	cfg := &CORSConfig{AllowedOrigins: []string{"*"}}
+	if err := cfg.validateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(cfg.AllowedMethods) != len(DefaultCORSAllowedMethods) {
+		t.Errorf("expected AllowedMethods to default to %v, got %v", DefaultCORSAllowedMethods, cfg.AllowedMethods)
+	}
+}
+
+func TestHSTSConfig_Value(t *testing.T) {
+	scenarios := []struct {
+		cfg      *HSTSConfig
+		expected string
+	}{
+		{cfg: &HSTSConfig{MaxAge: time.Hour}, expected: "max-age=3600"},
+		{cfg: &HSTSConfig{MaxAge: time.Hour, IncludeSubdomains: true}, expected: "max-age=3600; includeSubDomains"},
+		{cfg: &HSTSConfig{MaxAge: time.Hour, IncludeSubdomains: true, Preload: true}, expected: "max-age=3600; includeSubDomains; preload"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.expected, func(t *testing.T) {
+			if value := scenario.cfg.Value(); value != scenario.expected {
+				t.Errorf("expected %s, got %s", scenario.expected, value)
+			}
+		})
+	}
+}
@@ -1,20 +1,29 @@
 package controller
 
 import (
+	"crypto/tls"
 	"log"
 	"os"
 	"time"
 
 	"github.com/TwiN/gatus/v5/api"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/handover"
 	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
 	app *fiber.App
+
+	grpcServer *grpc.Server
 )
 
-// Handle creates the router and starts the server
+// Handle creates the router and starts the server.
+//
+// The listeners are created, or inherited from the previous process if Gatus is being upgraded, before Handle returns,
+// whereas the requests are served in the background.
 func Handle(cfg *config.Config) {
 	api := api.New(cfg)
 	app = api.Router()
@@ -25,19 +34,51 @@ func Handle(cfg *config.Config) {
 	if os.Getenv("ROUTER_TEST") == "true" {
 		return
 	}
-	log.Println("[controller.Handle] Listening on " + cfg.Web.SocketAddress())
+	if cfg.Web.GRPC != nil {
+		handleGRPC(cfg)
+	}
+	listener, err := handover.Listen(cfg.Web.SocketAddress())
+	if err != nil {
+		log.Fatal("[controller.Handle]", err)
+	}
 	if cfg.Web.HasTLS() {
-		err := app.ListenTLS(cfg.Web.SocketAddress(), cfg.Web.TLS.CertificateFile, cfg.Web.TLS.PrivateKeyFile)
+		certificate, err := tls.LoadX509KeyPair(cfg.Web.TLS.CertificateFile, cfg.Web.TLS.PrivateKeyFile)
 		if err != nil {
 			log.Fatal("[controller.Handle]", err)
 		}
-	} else {
-		err := app.Listen(cfg.Web.SocketAddress())
-		if err != nil {
+		listener = tls.NewListener(listener, &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{certificate}})
+	}
+	log.Println("[controller.Handle] Listening on " + cfg.Web.SocketAddress())
+	go func(app *fiber.App) {
+		if err := app.Listener(listener); err != nil {
 			log.Fatal("[controller.Handle]", err)
 		}
+		log.Println("[controller.Handle] Server has shut down successfully")
+	}(app)
+}
+
+// handleGRPC creates the gRPC server and starts listening
+func handleGRPC(cfg *config.Config) {
+	var options []grpc.ServerOption
+	if cfg.Web.HasTLS() {
+		tlsCredentials, err := credentials.NewServerTLSFromFile(cfg.Web.TLS.CertificateFile, cfg.Web.TLS.PrivateKeyFile)
+		if err != nil {
+			log.Fatal("[controller.handleGRPC]", err)
+		}
+		options = append(options, grpc.Creds(tlsCredentials))
 	}
-	log.Println("[controller.Handle] Server has shut down successfully")
+	listener, err := handover.Listen(cfg.Web.GRPCSocketAddress())
+	if err != nil {
+		log.Fatal("[controller.handleGRPC]", err)
+	}
+	grpcServer = api.NewGRPCServer(cfg).Register(options...)
+	log.Println("[controller.handleGRPC] Listening on " + cfg.Web.GRPCSocketAddress())
+	go func(grpcServer *grpc.Server) {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Println("[controller.handleGRPC]", err)
+		}
+		log.Println("[controller.handleGRPC] gRPC server has shut down successfully")
+	}(grpcServer)
 }
 
 // Shutdown stops the server
@@ -46,4 +87,8 @@ func Shutdown() {
 		_ = app.Shutdown()
 		app = nil
 	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+		grpcServer = nil
+	}
 }
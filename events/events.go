@@ -0,0 +1,342 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/google/uuid"
+)
+
+// Type is the type of an Event
+type Type string
+
+const (
+	// TypeEndpointUp is the type of the event emitted when an endpoint becomes healthy
+	TypeEndpointUp Type = "endpoint.up"
+
+	// TypeEndpointDown is the type of the event emitted when an endpoint becomes unhealthy
+	TypeEndpointDown Type = "endpoint.down"
+
+	// TypeAlertTriggered is the type of the event emitted when an alert is triggered
+	TypeAlertTriggered Type = "alert.triggered"
+
+	// TypeAlertResolved is the type of the event emitted when an alert is resolved
+	TypeAlertResolved Type = "alert.resolved"
+
+	// TypeMaintenanceStarted is the type of the event emitted when the maintenance window starts
+	TypeMaintenanceStarted Type = "maintenance.started"
+
+	// TypeMaintenanceEnded is the type of the event emitted when the maintenance window ends
+	TypeMaintenanceEnded Type = "maintenance.ended"
+)
+
+const (
+	// maximumQueuedEvents is the maximum number of events waiting to be sent. Once reached, new events are dropped
+	// until some are sent, which prevents the memory from growing indefinitely if every sink is unreachable
+	maximumQueuedEvents = 1000
+
+	// maintenanceCheckInterval is the interval at which the maintenance window is checked for transitions
+	maintenanceCheckInterval = 30 * time.Second
+
+	// sendTimeout is how long a sink has to send an event before giving up
+	sendTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNoSink is the error with which Gatus will panic if events are configured without any sink
+	ErrNoSink = errors.New("events requires at least one webhook, nats or kafka sink")
+
+	// ErrUnknownType is the error with which Gatus will panic if one of the configured event types isn't supported
+	ErrUnknownType = errors.New("unknown event type")
+
+	activeConfig      *Config
+	activeConfigMutex sync.RWMutex
+
+	// lastSuccessByKey is whether the latest result of each endpoint was successful, by key, which is used to detect
+	// the transitions between healthy and unhealthy
+	lastSuccessByKey      = make(map[string]bool)
+	lastSuccessByKeyMutex sync.Mutex
+)
+
+// Event is a structured event emitted when the status of an endpoint, of an alert or of the maintenance window changes
+type Event struct {
+	// ID is the unique identifier of the event, which can be used by consumers to deduplicate events
+	ID string `json:"id"`
+
+	// Type is the type of the event
+	Type Type `json:"type"`
+
+	// Timestamp is the time at which the event occurred
+	Timestamp time.Time `json:"timestamp"`
+
+	// Endpoint is the endpoint concerned by the event, if any
+	Endpoint *Endpoint `json:"endpoint,omitempty"`
+
+	// Alert is the alert concerned by the event, if any
+	Alert *Alert `json:"alert,omitempty"`
+
+	// Result is the result that caused the event, if any
+	Result *Result `json:"result,omitempty"`
+}
+
+// Endpoint is the endpoint concerned by an Event
+type Endpoint struct {
+	Key   string `json:"key"`
+	Group string `json:"group,omitempty"`
+	Name  string `json:"name"`
+}
+
+// Alert is the alert concerned by an Event
+type Alert struct {
+	Type        alert.Type     `json:"type"`
+	Description string         `json:"description,omitempty"`
+	Severity    alert.Severity `json:"severity,omitempty"`
+	Checksum    string         `json:"checksum"`
+}
+
+// Result is the result that caused an Event
+type Result struct {
+	Success       bool                   `json:"success"`
+	Status        int                    `json:"status,omitempty"`
+	Duration      time.Duration          `json:"duration"`
+	Errors        []string               `json:"errors,omitempty"`
+	FailureReason endpoint.FailureReason `json:"failureReason,omitempty"`
+}
+
+// sink is a destination to which events are sent
+type sink interface {
+	// send sends the JSON encoding of an event
+	send(ctx context.Context, event *Event, body []byte) error
+
+	// String returns a description of the sink that doesn't include any credentials, used in logs
+	String() string
+}
+
+// Config is the configuration for emitting events to external systems, such as incident automation tools
+type Config struct {
+	// Types are the types of the events to emit. If empty, every type of event is emitted.
+	Types []Type `yaml:"types,omitempty"`
+
+	// Webhooks are the HTTP endpoints to which each event is sent as a JSON payload
+	Webhooks []*WebhookConfig `yaml:"webhooks,omitempty"`
+
+	// NATS are the NATS subjects on which each event is published
+	NATS []*NATSConfig `yaml:"nats,omitempty"`
+
+	// Kafka are the Kafka topics to which each event is produced, through a Kafka REST Proxy
+	Kafka []*KafkaConfig `yaml:"kafka,omitempty"`
+
+	sinks []sink
+	queue chan *Event
+}
+
+// ValidateAndSetDefaults validates the events configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	for _, eventType := range c.Types {
+		switch eventType {
+		case TypeEndpointUp, TypeEndpointDown, TypeAlertTriggered, TypeAlertResolved, TypeMaintenanceStarted, TypeMaintenanceEnded:
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownType, eventType)
+		}
+	}
+	c.sinks = nil
+	for _, webhook := range c.Webhooks {
+		if err := webhook.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		c.sinks = append(c.sinks, webhook)
+	}
+	for _, nats := range c.NATS {
+		if err := nats.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		c.sinks = append(c.sinks, nats)
+	}
+	for _, kafka := range c.Kafka {
+		if err := kafka.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		c.sinks = append(c.sinks, kafka)
+	}
+	if len(c.sinks) == 0 {
+		return ErrNoSink
+	}
+	c.queue = make(chan *Event, maximumQueuedEvents)
+	return nil
+}
+
+// Run sends the events queued to every sink and watches the maintenance window for transitions until the context is
+// canceled, at which point the remaining events are sent one last time.
+//
+// Events are only queued while Run is running.
+func (c *Config) Run(ctx context.Context, maintenanceConfig *maintenance.Config) {
+	activeConfigMutex.Lock()
+	activeConfig = c
+	activeConfigMutex.Unlock()
+	// The initial state of the maintenance window isn't an event, since it is the same as before the configuration
+	// was reloaded more often than not
+	isUnderMaintenance := maintenanceConfig != nil && maintenanceConfig.IsUnderMaintenance()
+	ticker := time.NewTicker(maintenanceCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			activeConfigMutex.Lock()
+			if activeConfig == c {
+				activeConfig = nil
+			}
+			activeConfigMutex.Unlock()
+			c.drain()
+			return
+		case <-ticker.C:
+			isUnderMaintenance = c.checkMaintenance(maintenanceConfig, isUnderMaintenance)
+		case event := <-c.queue:
+			c.send(event)
+		}
+	}
+}
+
+// checkMaintenance emits an event if the maintenance window started or ended since it was last checked, and returns
+// whether the endpoints are currently under maintenance
+func (c *Config) checkMaintenance(maintenanceConfig *maintenance.Config, wasUnderMaintenance bool) bool {
+	isUnderMaintenance := maintenanceConfig != nil && maintenanceConfig.IsUnderMaintenance()
+	if isUnderMaintenance && !wasUnderMaintenance {
+		c.enqueue(newEvent(TypeMaintenanceStarted))
+	} else if !isUnderMaintenance && wasUnderMaintenance {
+		c.enqueue(newEvent(TypeMaintenanceEnded))
+	}
+	return isUnderMaintenance
+}
+
+// PublishResult emits an event if the result passed changes the health of its endpoint, if configured.
+//
+// The first result of each endpoint since Gatus started doesn't emit any event, since its previous health is unknown.
+func PublishResult(ep *endpoint.Endpoint, result *endpoint.Result) {
+	key := ep.Key()
+	lastSuccessByKeyMutex.Lock()
+	wasSuccessful, exists := lastSuccessByKey[key]
+	lastSuccessByKey[key] = result.Success
+	lastSuccessByKeyMutex.Unlock()
+	c := getActiveConfig()
+	if c == nil || !exists || wasSuccessful == result.Success {
+		return
+	}
+	event := newEvent(TypeEndpointDown)
+	if result.Success {
+		event.Type = TypeEndpointUp
+	}
+	if !result.Timestamp.IsZero() {
+		event.Timestamp = result.Timestamp.UTC()
+	}
+	event.Endpoint, event.Result = newEndpoint(ep), newResult(result)
+	c.enqueue(event)
+}
+
+// PublishAlertEvent emits an event for an alert that has just been triggered or resolved, if configured
+func PublishAlertEvent(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, resolved bool) {
+	c := getActiveConfig()
+	if c == nil {
+		return
+	}
+	event := newEvent(TypeAlertTriggered)
+	if resolved {
+		event.Type = TypeAlertResolved
+	}
+	event.Endpoint, event.Result = newEndpoint(ep), newResult(result)
+	event.Alert = &Alert{
+		Type:        endpointAlert.Type,
+		Description: endpointAlert.GetDescription(),
+		Severity:    endpointAlert.Severity,
+		Checksum:    endpointAlert.Checksum(),
+	}
+	c.enqueue(event)
+}
+
+func getActiveConfig() *Config {
+	activeConfigMutex.RLock()
+	defer activeConfigMutex.RUnlock()
+	return activeConfig
+}
+
+func newEvent(eventType Type) *Event {
+	return &Event{ID: uuid.NewString(), Type: eventType, Timestamp: time.Now().UTC()}
+}
+
+func newEndpoint(ep *endpoint.Endpoint) *Endpoint {
+	return &Endpoint{Key: ep.Key(), Group: ep.Group, Name: ep.Name}
+}
+
+func newResult(result *endpoint.Result) *Result {
+	if result == nil {
+		return nil
+	}
+	return &Result{
+		Success:       result.Success,
+		Status:        result.HTTPStatus,
+		Duration:      result.Duration,
+		Errors:        result.Errors,
+		FailureReason: result.FailureReason,
+	}
+}
+
+// isEmitted returns whether the events of the type passed are emitted
+func (c *Config) isEmitted(eventType Type) bool {
+	if len(c.Types) == 0 {
+		return true
+	}
+	for _, t := range c.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue queues an event to be sent by Run, unless its type isn't emitted
+func (c *Config) enqueue(event *Event) {
+	if !c.isEmitted(event.Type) {
+		return
+	}
+	select {
+	case c.queue <- event:
+	default:
+		log.Printf("[events.enqueue] Dropping event of type %s because %d events are already waiting to be sent", event.Type, maximumQueuedEvents)
+	}
+}
+
+// drain sends the events that are still queued
+func (c *Config) drain() {
+	for {
+		select {
+		case event := <-c.queue:
+			c.send(event)
+		default:
+			return
+		}
+	}
+}
+
+// send sends an event to every sink. Events that failed to be sent are dropped, since retrying them would delay more
+// recent ones.
+func (c *Config) send(event *Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[events.send] Failed to encode event of type %s: %s", event.Type, err.Error())
+		return
+	}
+	for _, s := range c.sinks {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		if err = s.send(ctx, event, body); err != nil {
+			log.Printf("[events.send] Failed to send event of type %s to %s: %s", event.Type, s.String(), err.Error())
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+const (
+	// kafkaRESTProxyContentType is the content type of requests producing JSON records through the v2 API of the
+	// Kafka REST Proxy
+	kafkaRESTProxyContentType = "application/vnd.kafka.json.v2+json"
+)
+
+var (
+	// ErrKafkaWithoutRESTProxyURL is the error with which Gatus will panic if a Kafka sink has no REST Proxy URL or an
+	// invalid one
+	ErrKafkaWithoutRESTProxyURL = errors.New("events.kafka[].rest-proxy-url must be a valid http or https URL")
+
+	// ErrKafkaWithoutTopic is the error with which Gatus will panic if a Kafka sink has no topic
+	ErrKafkaWithoutTopic = errors.New("events.kafka[].topic must not be empty")
+)
+
+// KafkaConfig is the configuration of a Kafka topic to which each event is produced.
+//
+// Events are produced through a Kafka REST Proxy (https://github.com/confluentinc/kafka-rest), which avoids having to
+// speak the Kafka protocol. The key of each record is the key of the endpoint concerned, if any, so that the events of
+// an endpoint are all in the same partition.
+type KafkaConfig struct {
+	// RESTProxyURL is the URL of the Kafka REST Proxy (e.g. http://kafka-rest:8082)
+	RESTProxyURL string `yaml:"rest-proxy-url"`
+
+	// Topic is the topic to which events are produced
+	Topic string `yaml:"topic"`
+
+	// Username is the username used to authenticate with the REST Proxy through basic authentication, if any
+	Username string `yaml:"username,omitempty"`
+
+	// Password is the password used to authenticate with the REST Proxy through basic authentication, if any
+	Password string `yaml:"password,omitempty"`
+}
+
+// kafkaProduceRequest is the body of a request to produce records through the Kafka REST Proxy
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   *string         `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaProduceResponse is the body of the response to a request to produce records through the Kafka REST Proxy
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// ValidateAndSetDefaults validates the Kafka configuration
+func (c *KafkaConfig) ValidateAndSetDefaults() error {
+	u, err := url.Parse(c.RESTProxyURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return ErrKafkaWithoutRESTProxyURL
+	}
+	if len(c.Topic) == 0 {
+		return ErrKafkaWithoutTopic
+	}
+	c.RESTProxyURL = strings.TrimSuffix(c.RESTProxyURL, "/")
+	return nil
+}
+
+func (c *KafkaConfig) send(ctx context.Context, event *Event, body []byte) error {
+	record := kafkaRecord{Value: body}
+	if event.Endpoint != nil {
+		record.Key = &event.Endpoint.Key
+	}
+	requestBody, err := json.Marshal(kafkaProduceRequest{Records: []kafkaRecord{record}})
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.RESTProxyURL+"/topics/"+url.PathEscape(c.Topic), bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", kafkaRESTProxyContentType)
+	request.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if len(c.Username) > 0 || len(c.Password) > 0 {
+		request.SetBasicAuth(c.Username, c.Password)
+	}
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, _ := io.ReadAll(response.Body)
+	if response.StatusCode > 299 {
+		return fmt.Errorf("call to kafka rest proxy returned status code %d: %s", response.StatusCode, string(responseBody))
+	}
+	// The REST Proxy may accept the request even though a record couldn't be produced
+	var produceResponse kafkaProduceResponse
+	if err = json.Unmarshal(responseBody, &produceResponse); err != nil {
+		return fmt.Errorf("failed to decode response of kafka rest proxy: %w", err)
+	}
+	for _, offset := range produceResponse.Offsets {
+		if offset.ErrorCode != nil || len(offset.Error) > 0 {
+			return fmt.Errorf("kafka rest proxy failed to produce record: %s", offset.Error)
+		}
+	}
+	return nil
+}
+
+func (c *KafkaConfig) String() string {
+	return "kafka topic " + c.Topic
+}
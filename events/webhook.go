@@ -0,0 +1,65 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+var (
+	// ErrWebhookWithoutURL is the error with which Gatus will panic if a webhook has no URL or an invalid one
+	ErrWebhookWithoutURL = errors.New("events.webhooks[].url must be a valid http or https URL")
+)
+
+// WebhookConfig is the configuration of an HTTP endpoint to which each event is sent with a POST request
+type WebhookConfig struct {
+	// URL is the URL to which events are sent
+	URL string `yaml:"url"`
+
+	// Headers are the headers added to each request, e.g. to authenticate
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the webhook configuration
+func (c *WebhookConfig) ValidateAndSetDefaults() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return ErrWebhookWithoutURL
+	}
+	return nil
+}
+
+func (c *WebhookConfig) send(ctx context.Context, _ *Event, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range c.Headers {
+		request.Header.Set(name, value)
+	}
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to webhook returned status code %d: %s", response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+func (c *WebhookConfig) String() string {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return "webhook"
+	}
+	return "webhook " + u.Redacted()
+}
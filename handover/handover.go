@@ -0,0 +1,266 @@
+package handover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// listenersEnvironmentVariable is the environment variable through which the addresses of the listeners handed
+	// over are passed to the new process, in the same order as their file descriptors
+	listenersEnvironmentVariable = "GATUS_HANDOVER_LISTENERS"
+
+	// readyFileDescriptor is the file descriptor of the pipe through which the new process signals that it's ready
+	readyFileDescriptor = 3
+
+	// stateFileDescriptor is the file descriptor of the pipe from which the new process reads the State
+	stateFileDescriptor = 4
+
+	// firstListenerFileDescriptor is the file descriptor of the first listener handed over
+	firstListenerFileDescriptor = 5
+
+	// DefaultReadyTimeout is how long to wait for the new process to be ready before giving up on the upgrade
+	DefaultReadyTimeout = 30 * time.Second
+)
+
+var (
+	// ErrNewProcessNotReady is the error returned by Upgrade if the new process exited or timed out before being ready
+	ErrNewProcessNotReady = errors.New("new process exited or timed out before being ready")
+
+	// listeners are the listeners created by Listen, by address, which are handed over to the new process on Upgrade
+	listeners      = make(map[string]net.Listener)
+	listenersMutex sync.Mutex
+
+	// inherited are the listeners handed over by the previous process that haven't been claimed through Listen yet
+	inherited map[string]net.Listener
+
+	// inheritedState is the state handed over by the previous process, if any
+	inheritedState *State
+
+	// readyPipe is the pipe through which the previous process is notified once this process is ready
+	readyPipe *os.File
+
+	inheritOnce sync.Once
+
+	// command returns the command with which the new process is started, which is the current executable with the
+	// same arguments
+	command = func() (*exec.Cmd, error) {
+		executable, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		return exec.Command(executable, os.Args[1:]...), nil
+	}
+)
+
+// State is the state handed over from the previous process to the new one
+type State struct {
+	// NextRunAt is the time at which each endpoint was scheduled to run next in the previous process, by key
+	NextRunAt map[string]time.Time `json:"nextRunAt,omitempty"`
+}
+
+// inherit retrieves the listeners and the state handed over by the previous process, if this process was started by
+// Upgrade
+func inherit() {
+	inheritOnce.Do(func() {
+		addresses, handedOver := os.LookupEnv(listenersEnvironmentVariable)
+		if !handedOver {
+			return
+		}
+		// The environment variable must not be passed down to the processes started by this one
+		_ = os.Unsetenv(listenersEnvironmentVariable)
+		readyPipe = os.NewFile(readyFileDescriptor, "ready")
+		statePipe := os.NewFile(stateFileDescriptor, "state")
+		inheritedState = &State{}
+		if err := json.NewDecoder(statePipe).Decode(inheritedState); err != nil {
+			log.Printf("[handover.inherit] Failed to read the state handed over: %s", err.Error())
+		}
+		_ = statePipe.Close()
+		inherited = make(map[string]net.Listener)
+		for i, address := range strings.Split(addresses, ",") {
+			if len(address) == 0 {
+				continue
+			}
+			file := os.NewFile(uintptr(firstListenerFileDescriptor+i), address)
+			listener, err := net.FileListener(file)
+			// The listener is a duplicate of the file, so the file is no longer needed
+			_ = file.Close()
+			if err != nil {
+				log.Printf("[handover.inherit] Failed to inherit listener on %s: %s", address, err.Error())
+				continue
+			}
+			inherited[address] = listener
+		}
+		log.Printf("[handover.inherit] Inherited %d listeners from the previous process", len(inherited))
+	})
+}
+
+// IsInherited returns whether this process was started by another Gatus process through Upgrade
+func IsInherited() bool {
+	inherit()
+	return inheritedState != nil
+}
+
+// InheritedState returns the state handed over by the previous process, or nil if this process wasn't started
+// through Upgrade
+func InheritedState() *State {
+	inherit()
+	return inheritedState
+}
+
+// Listen returns the listener on the TCP address passed that was handed over by the previous process, if any, and
+// otherwise starts listening on that address.
+//
+// Listeners returned by Listen are handed over to the new process on Upgrade, which is why they must be used for
+// every address Gatus listens on.
+func Listen(address string) (net.Listener, error) {
+	inherit()
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+	listener, exists := inherited[address]
+	if exists {
+		delete(inherited, address)
+	} else {
+		var err error
+		if listener, err = net.Listen("tcp", address); err != nil {
+			return nil, err
+		}
+	}
+	listeners[address] = &trackedListener{Listener: listener, address: address}
+	return listeners[address], nil
+}
+
+// trackedListener is a listener that removes itself from the listeners handed over once closed
+type trackedListener struct {
+	net.Listener
+	address string
+}
+
+func (l *trackedListener) Close() error {
+	listenersMutex.Lock()
+	if listeners[l.address] == net.Listener(l) {
+		delete(listeners, l.address)
+	}
+	listenersMutex.Unlock()
+	return l.Listener.Close()
+}
+
+// Ready notifies the previous process, if any, that this process is ready to take over, at which point the previous
+// process stops serving requests and exits. Inherited listeners that weren't claimed through Listen (e.g. because
+// the address changed) are closed.
+func Ready() {
+	inherit()
+	listenersMutex.Lock()
+	for address, listener := range inherited {
+		log.Printf("[handover.Ready] Closing inherited listener on %s, which is no longer used", address)
+		_ = listener.Close()
+	}
+	inherited = nil
+	listenersMutex.Unlock()
+	if readyPipe != nil {
+		if _, err := readyPipe.Write([]byte{1}); err != nil {
+			log.Printf("[handover.Ready] Failed to notify the previous process: %s", err.Error())
+		}
+		_ = readyPipe.Close()
+		readyPipe = nil
+	}
+}
+
+// Upgrade starts a new process from the current executable, which may have been replaced by a new version, and hands
+// over the listeners created by Listen as well as the state passed. It returns once the new process is ready, which
+// means that the caller must then stop serving requests and exit, or with an error if the new process couldn't be
+// started or exited before being ready, in which case the caller must resume.
+//
+// Since the listening sockets are shared rather than closed, connections are never refused during the upgrade.
+func Upgrade(state *State, readyTimeout time.Duration) (*os.Process, error) {
+	cmd, err := command()
+	if err != nil {
+		return nil, err
+	}
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer readyReader.Close()
+	stateReader, stateWriter, err := os.Pipe()
+	if err != nil {
+		_ = readyWriter.Close()
+		return nil, err
+	}
+	files := []*os.File{readyWriter, stateReader}
+	defer func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}()
+	listenersMutex.Lock()
+	var addresses []string
+	for address, listener := range listeners {
+		filer, ok := listener.(*trackedListener).Listener.(interface{ File() (*os.File, error) })
+		if !ok {
+			listenersMutex.Unlock()
+			_ = stateWriter.Close()
+			return nil, fmt.Errorf("listener on %s cannot be handed over", address)
+		}
+		file, err := filer.File()
+		if err != nil {
+			listenersMutex.Unlock()
+			_ = stateWriter.Close()
+			return nil, err
+		}
+		files = append(files, file)
+		addresses = append(addresses, address)
+	}
+	listenersMutex.Unlock()
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), listenersEnvironmentVariable+"="+strings.Join(addresses, ","))
+	cmd.ExtraFiles = files
+	if err := cmd.Start(); err != nil {
+		_ = stateWriter.Close()
+		return nil, err
+	}
+	// The state is written in the background, since the pipe blocks once its buffer is full until the new process reads it
+	go func() {
+		if err := json.NewEncoder(stateWriter).Encode(state); err != nil {
+			log.Printf("[handover.Upgrade] Failed to hand over the state: %s", err.Error())
+		}
+		_ = stateWriter.Close()
+	}()
+	// The new process holds its own copies of the files, which must be closed in this process so that the ready pipe
+	// reaches EOF if the new process exits before being ready
+	for _, file := range files {
+		_ = file.Close()
+	}
+	files = nil
+	ready := make(chan error, 1)
+	go func() {
+		buffer := make([]byte, 1)
+		if _, err := io.ReadFull(readyReader, buffer); err != nil {
+			ready <- ErrNewProcessNotReady
+			return
+		}
+		ready <- nil
+	}()
+	select {
+	case err = <-ready:
+	case <-time.After(readyTimeout):
+		err = ErrNewProcessNotReady
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	// Reaps the new process if it exits before this one
+	go cmd.Wait()
+	return cmd.Process, nil
+}
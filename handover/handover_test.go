@@ -0,0 +1,119 @@
+package handover
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// TestMain allows the test binary to be started as the new process of an upgrade, in which case it runs
+// runNewProcess instead of the tests
+func TestMain(m *testing.M) {
+	if os.Getenv("GATUS_HANDOVER_TEST_NEW_PROCESS") == "true" {
+		runNewProcess()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// runNewProcess inherits the listener and the state handed over, reports them through the listener and exits
+func runNewProcess() {
+	address := os.Getenv("GATUS_HANDOVER_TEST_ADDRESS")
+	if os.Getenv("GATUS_HANDOVER_TEST_EXIT_EARLY") == "true" {
+		os.Exit(1)
+	}
+	listener, err := Listen(address)
+	if err != nil {
+		os.Exit(2)
+	}
+	state := InheritedState()
+	if state == nil || !IsInherited() {
+		os.Exit(3)
+	}
+	Ready()
+	_ = http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%d-%s", os.Getpid(), state.NextRunAt["core_frontend"].UTC().Format(time.RFC3339))
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			os.Exit(0)
+		}()
+	}))
+}
+
+func useTestBinaryAsNewProcess(t *testing.T, environment ...string) {
+	command = func() (*exec.Cmd, error) {
+		cmd := exec.Command(os.Args[0])
+		cmd.Env = append(os.Environ(), append(environment, "GATUS_HANDOVER_TEST_NEW_PROCESS=true")...)
+		return cmd, nil
+	}
+	t.Cleanup(func() {
+		command = func() (*exec.Cmd, error) {
+			return exec.Command(os.Args[0]), nil
+		}
+	})
+}
+
+func TestUpgrade(t *testing.T) {
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	defer listener.Close()
+	useTestBinaryAsNewProcess(t, "GATUS_HANDOVER_TEST_ADDRESS=127.0.0.1:0")
+	nextRunAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	process, err := Upgrade(&State{NextRunAt: map[string]time.Time{"core_frontend": nextRunAt}}, 10*time.Second)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	// This process stops accepting connections, so the new process is the only one left to accept them
+	address := listener.Addr().String()
+	_ = listener.Close()
+	response, err := http.Get("http://" + address)
+	if err != nil {
+		t.Fatal("expected the new process to accept connections on the listener handed over, got", err)
+	}
+	defer response.Body.Close()
+	var body string
+	_, _ = fmt.Fscan(response.Body, &body)
+	if expected := fmt.Sprintf("%d-%s", process.Pid, nextRunAt.Format(time.RFC3339)); body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestUpgradeWhenNewProcessExitsBeforeBeingReady(t *testing.T) {
+	useTestBinaryAsNewProcess(t, "GATUS_HANDOVER_TEST_EXIT_EARLY=true")
+	if _, err := Upgrade(&State{}, 10*time.Second); !errors.Is(err, ErrNewProcessNotReady) {
+		t.Errorf("expected error %v, got %v", ErrNewProcessNotReady, err)
+	}
+}
+
+func TestListen(t *testing.T) {
+	listener, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	listenersMutex.Lock()
+	_, exists := listeners["127.0.0.1:0"]
+	listenersMutex.Unlock()
+	if !exists {
+		t.Error("expected the listener to be handed over on upgrade")
+	}
+	_ = listener.Close()
+	listenersMutex.Lock()
+	_, exists = listeners["127.0.0.1:0"]
+	listenersMutex.Unlock()
+	if exists {
+		t.Error("expected the listener to no longer be handed over once closed")
+	}
+	if _, err := net.Dial("tcp", listener.Addr().String()); err == nil {
+		t.Error("expected the listener to be closed")
+	}
+	if IsInherited() {
+		t.Error("expected the listeners not to be inherited")
+	}
+}
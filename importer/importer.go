@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SourceUptimeKuma is the source used to import data from an Uptime Kuma backup
+	SourceUptimeKuma = "uptime-kuma"
+)
+
+var (
+	// ErrUnsupportedSource is the error returned when importing data from a monitoring tool that isn't supported
+	ErrUnsupportedSource = errors.New("unsupported import source: must be uptime-kuma")
+
+	// ErrEndpointAlreadyConfigured is the error returned when persisting the data of an imported endpoint whose key is
+	// the same as the key of an endpoint that is already configured, since their results would be mixed up
+	ErrEndpointAlreadyConfigured = errors.New("imported endpoint has the same key as a configured endpoint")
+)
+
+// Data is the data imported from another monitoring tool
+type Data struct {
+	// Endpoints are the endpoints mapped from the monitors of the other monitoring tool
+	Endpoints []*endpoint.Endpoint
+
+	// Results are the results mapped from the history of the monitors, indexed by endpoint key
+	Results map[string][]*endpoint.Result
+
+	// Skipped contains a description of each monitor that could not be mapped to an endpoint
+	Skipped []string
+
+	// configurations are the endpoints as they should be configured, that is, without the defaults set by
+	// endpoint.Endpoint.ValidateAndSetDefaults
+	configurations []*endpoint.Endpoint
+}
+
+// Import maps the data exported from another monitoring tool to endpoints and results
+func Import(source string, data []byte) (*Data, error) {
+	var importedData *Data
+	var err error
+	switch source {
+	case SourceUptimeKuma:
+		importedData, err = importFromUptimeKuma(data)
+	default:
+		return nil, ErrUnsupportedSource
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Results must be inserted in chronological order
+	for _, results := range importedData.Results {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Timestamp.Before(results[j].Timestamp)
+		})
+	}
+	return importedData, nil
+}
+
+// Persist inserts the results of the imported data in the store and returns the number of results inserted.
+//
+// Nothing is inserted if the key of an imported endpoint is one of the keys of the configured endpoints passed.
+//
+// Note that the results of endpoints that aren't configured are deleted from the store when Gatus starts (see
+// store.Store.DeleteAllEndpointStatusesNotInKeys), so the imported endpoints must be added to the configuration before
+// Gatus is restarted, otherwise the results inserted are lost.
+func (d *Data) Persist(configuredEndpointKeys []string) (int, error) {
+	isConfigured := make(map[string]bool, len(configuredEndpointKeys))
+	for _, key := range configuredEndpointKeys {
+		isConfigured[key] = true
+	}
+	for _, ep := range d.Endpoints {
+		if isConfigured[ep.Key()] {
+			return 0, fmt.Errorf("%w: %s", ErrEndpointAlreadyConfigured, ep.Key())
+		}
+	}
+	numberOfResultsInserted := 0
+	for _, ep := range d.Endpoints {
+		for _, result := range d.Results[ep.Key()] {
+			if err := store.Get().Insert(ep, result); err != nil {
+				log.Printf("[importer.Persist] Failed to insert result for endpoint with key=%s: %s", ep.Key(), err.Error())
+				return numberOfResultsInserted, err
+			}
+			numberOfResultsInserted++
+		}
+	}
+	return numberOfResultsInserted, nil
+}
+
+// EndpointsConfiguration returns the configuration of the imported endpoints in YAML, which can be added to the
+// configuration file of Gatus
+func (d *Data) EndpointsConfiguration() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(map[string]any{"endpoints": d.configurations}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
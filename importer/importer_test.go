@@ -0,0 +1,49 @@
+package importer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestData_Persist(t *testing.T) {
+	defer store.Get().Clear()
+	data, err := Import(SourceUptimeKuma, []byte(testUptimeKumaBackup))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	numberOfResultsInserted, err := data.Persist([]string{"core_api"})
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if numberOfResultsInserted != 3 {
+		t.Errorf("expected 3 results to be inserted, got %d", numberOfResultsInserted)
+	}
+	status, err := store.Get().GetEndpointStatusByKey("core_website", paging.NewEndpointStatusParams().WithResults(1, 20))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(status.Results) != 2 || !status.Results[0].Success || status.Results[1].Success {
+		t.Errorf("expected results to be inserted in chronological order, got %+v", status.Results)
+	}
+}
+
+func TestData_PersistWithConfiguredEndpoint(t *testing.T) {
+	defer store.Get().Clear()
+	data, err := Import(SourceUptimeKuma, []byte(testUptimeKumaBackup))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	numberOfResultsInserted, err := data.Persist([]string{"core_website"})
+	if !errors.Is(err, ErrEndpointAlreadyConfigured) {
+		t.Fatalf("expected error %v, got %v", ErrEndpointAlreadyConfigured, err)
+	}
+	if numberOfResultsInserted != 0 {
+		t.Errorf("expected no results to be inserted, got %d", numberOfResultsInserted)
+	}
+	if statuses, _ := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams()); len(statuses) != 0 {
+		t.Errorf("expected nothing to be inserted, got %d endpoint statuses", len(statuses))
+	}
+}
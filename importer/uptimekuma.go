@@ -0,0 +1,260 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+)
+
+const (
+	uptimeKumaHeartbeatStatusDown    = 0
+	uptimeKumaHeartbeatStatusUp      = 1
+	uptimeKumaDefaultDNSResolver     = "1.1.1.1"
+	uptimeKumaDefaultDNSResolveType  = "A"
+	uptimeKumaDefaultIntervalSeconds = 60
+)
+
+var (
+	ErrInvalidUptimeKumaBackup = errors.New("invalid uptime kuma backup: no monitor found")
+
+	uptimeKumaTimeLayouts = []string{"2006-01-02 15:04:05.000", "2006-01-02 15:04:05", time.RFC3339Nano}
+)
+
+// uptimeKumaBackup is the format of the JSON file generated by the backup feature of Uptime Kuma
+type uptimeKumaBackup struct {
+	Version       string                            `json:"version"`
+	MonitorList   []*uptimeKumaMonitor              `json:"monitorList"`
+	HeartbeatList map[string][]*uptimeKumaHeartbeat `json:"heartbeatList"`
+}
+
+type uptimeKumaMonitor struct {
+	ID                  int            `json:"id"`
+	Name                string         `json:"name"`
+	Type                string         `json:"type"`
+	Active              uptimeKumaBool `json:"active"`
+	Parent              *int           `json:"parent"`
+	Interval            int            `json:"interval"`
+	URL                 string         `json:"url"`
+	Method              string         `json:"method"`
+	Body                string         `json:"body"`
+	Headers             string         `json:"headers"`
+	Hostname            string         `json:"hostname"`
+	Port                int            `json:"port"`
+	Keyword             string         `json:"keyword"`
+	InvertKeyword       uptimeKumaBool `json:"invertKeyword"`
+	AcceptedStatusCodes []string       `json:"accepted_statuscodes"`
+	DNSResolveType      string         `json:"dns_resolve_type"`
+	DNSResolveServer    string         `json:"dns_resolve_server"`
+}
+
+type uptimeKumaHeartbeat struct {
+	Status  int      `json:"status"`
+	Message string   `json:"msg"`
+	Time    string   `json:"time"`
+	Ping    *float64 `json:"ping"`
+}
+
+// uptimeKumaBool is a boolean that may be serialized as either a boolean or a number, depending on the version of
+// Uptime Kuma that generated the backup
+type uptimeKumaBool bool
+
+func (b *uptimeKumaBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean: %s", data)
+	}
+	return nil
+}
+
+func importFromUptimeKuma(data []byte) (*Data, error) {
+	var backup uptimeKumaBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, err
+	}
+	if len(backup.MonitorList) == 0 {
+		return nil, ErrInvalidUptimeKumaBackup
+	}
+	// Monitors of type group are mapped to the group of their children
+	groups := make(map[int]string)
+	for _, monitor := range backup.MonitorList {
+		if monitor.Type == "group" {
+			groups[monitor.ID] = monitor.Name
+		}
+	}
+	importedData := &Data{Results: make(map[string][]*endpoint.Result)}
+	for _, monitor := range backup.MonitorList {
+		if monitor.Type == "group" {
+			continue
+		}
+		ep, err := monitor.toEndpoint(groups)
+		if err != nil {
+			importedData.Skipped = append(importedData.Skipped, fmt.Sprintf("%s: %s", monitor.Name, err.Error()))
+			continue
+		}
+		// The configuration is generated from an endpoint without defaults to keep it as concise as possible
+		configuration, _ := monitor.toEndpoint(groups)
+		if err = ep.ValidateAndSetDefaults(); err != nil {
+			importedData.Skipped = append(importedData.Skipped, fmt.Sprintf("%s: %s", monitor.Name, err.Error()))
+			continue
+		}
+		importedData.Endpoints = append(importedData.Endpoints, ep)
+		importedData.configurations = append(importedData.configurations, configuration)
+		for _, heartbeat := range backup.HeartbeatList[strconv.Itoa(monitor.ID)] {
+			if result := heartbeat.toResult(); result != nil {
+				importedData.Results[ep.Key()] = append(importedData.Results[ep.Key()], result)
+			}
+		}
+	}
+	return importedData, nil
+}
+
+func (monitor *uptimeKumaMonitor) toEndpoint(groups map[int]string) (*endpoint.Endpoint, error) {
+	ep := &endpoint.Endpoint{Name: monitor.Name}
+	if monitor.Parent != nil {
+		ep.Group = groups[*monitor.Parent]
+	}
+	if !monitor.Active {
+		enabled := false
+		ep.Enabled = &enabled
+	}
+	interval := monitor.Interval
+	if interval <= 0 {
+		interval = uptimeKumaDefaultIntervalSeconds
+	}
+	ep.Interval = time.Duration(interval) * time.Second
+	switch monitor.Type {
+	case "http", "keyword":
+		ep.URL = monitor.URL
+		ep.Method = strings.ToUpper(monitor.Method)
+		ep.Body = monitor.Body
+		if len(monitor.Headers) > 0 {
+			if err := json.Unmarshal([]byte(monitor.Headers), &ep.Headers); err != nil {
+				return nil, fmt.Errorf("invalid headers: %w", err)
+			}
+		}
+		conditions, err := statusCodeConditions(monitor.AcceptedStatusCodes)
+		if err != nil {
+			return nil, err
+		}
+		ep.Conditions = conditions
+		if monitor.Type == "keyword" {
+			operator := "=="
+			if monitor.InvertKeyword {
+				operator = "!="
+			}
+			ep.Conditions = append(ep.Conditions, endpoint.Condition(fmt.Sprintf("[BODY] %s pat(*%s*)", operator, monitor.Keyword)))
+		}
+	case "port":
+		ep.URL = fmt.Sprintf("tcp://%s:%d", monitor.Hostname, monitor.Port)
+		ep.Conditions = []endpoint.Condition{"[CONNECTED] == true"}
+	case "ping":
+		ep.URL = "icmp://" + monitor.Hostname
+		ep.Conditions = []endpoint.Condition{"[CONNECTED] == true"}
+	case "dns":
+		ep.URL = monitor.DNSResolveServer
+		if len(ep.URL) == 0 {
+			ep.URL = uptimeKumaDefaultDNSResolver
+		}
+		queryType := monitor.DNSResolveType
+		if len(queryType) == 0 {
+			queryType = uptimeKumaDefaultDNSResolveType
+		}
+		ep.DNSConfig = &dns.Config{QueryName: monitor.Hostname, QueryType: queryType}
+		ep.Conditions = []endpoint.Condition{"[DNS_RCODE] == NOERROR"}
+	default:
+		return nil, fmt.Errorf("unsupported monitor type %s", monitor.Type)
+	}
+	return ep, nil
+}
+
+// toResult maps the heartbeat to a result. Returns nil if the heartbeat was neither up nor down, such as when the
+// monitor was pending or under maintenance.
+func (heartbeat *uptimeKumaHeartbeat) toResult() *endpoint.Result {
+	if heartbeat.Status != uptimeKumaHeartbeatStatusUp && heartbeat.Status != uptimeKumaHeartbeatStatusDown {
+		return nil
+	}
+	var timestamp time.Time
+	for _, layout := range uptimeKumaTimeLayouts {
+		var err error
+		// Uptime Kuma stores the time of heartbeats in UTC
+		if timestamp, err = time.ParseInLocation(layout, heartbeat.Time, time.UTC); err == nil {
+			break
+		}
+	}
+	if timestamp.IsZero() {
+		return nil
+	}
+	result := &endpoint.Result{
+		Success:   heartbeat.Status == uptimeKumaHeartbeatStatusUp,
+		Timestamp: timestamp,
+		Errors:    []string{},
+	}
+	if heartbeat.Ping != nil {
+		result.Duration = time.Duration(*heartbeat.Ping * float64(time.Millisecond))
+	}
+	if !result.Success && len(heartbeat.Message) > 0 {
+		result.Errors = append(result.Errors, heartbeat.Message)
+	}
+	return result
+}
+
+// statusCodeConditions maps the accepted status codes of a monitor (e.g. 200-299) to conditions
+func statusCodeConditions(acceptedStatusCodes []string) ([]endpoint.Condition, error) {
+	if len(acceptedStatusCodes) == 0 {
+		acceptedStatusCodes = []string{"200-299"}
+	}
+	var ranges [][2]int
+	for _, acceptedStatusCode := range acceptedStatusCodes {
+		low, high, isRange := strings.Cut(acceptedStatusCode, "-")
+		lowValue, err := strconv.Atoi(strings.TrimSpace(low))
+		if err != nil {
+			return nil, fmt.Errorf("invalid accepted status code %s", acceptedStatusCode)
+		}
+		highValue := lowValue
+		if isRange {
+			if highValue, err = strconv.Atoi(strings.TrimSpace(high)); err != nil || highValue < lowValue {
+				return nil, fmt.Errorf("invalid accepted status code %s", acceptedStatusCode)
+			}
+		}
+		ranges = append(ranges, [2]int{lowValue, highValue})
+	}
+	// Merge overlapping and adjacent ranges, since conditions can't be combined with a logical OR
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+	merged := [][2]int{ranges[0]}
+	for _, r := range ranges[1:] {
+		if last := &merged[len(merged)-1]; r[0] <= last[1]+1 {
+			last[1] = max(last[1], r[1])
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	if len(merged) == 1 {
+		if merged[0][0] == merged[0][1] {
+			return []endpoint.Condition{endpoint.Condition(fmt.Sprintf("[STATUS] == %d", merged[0][0]))}, nil
+		}
+		return []endpoint.Condition{
+			endpoint.Condition(fmt.Sprintf("[STATUS] >= %d", merged[0][0])),
+			endpoint.Condition(fmt.Sprintf("[STATUS] <= %d", merged[0][1])),
+		}, nil
+	}
+	var statusCodes []string
+	for _, r := range merged {
+		for statusCode := r[0]; statusCode <= r[1]; statusCode++ {
+			statusCodes = append(statusCodes, strconv.Itoa(statusCode))
+		}
+	}
+	return []endpoint.Condition{endpoint.Condition(fmt.Sprintf("[STATUS] == any(%s)", strings.Join(statusCodes, ", ")))}, nil
+}
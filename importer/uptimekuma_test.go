@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const testUptimeKumaBackup = `{
+  "version": "1.23.0",
+  "monitorList": [
+    {"id": 1, "name": "core", "type": "group", "active": true, "parent": null},
+    {"id": 2, "name": "website", "type": "http", "active": true, "parent": 1, "interval": 30, "url": "https://example.org", "method": "get", "headers": "{\"Authorization\":\"Bearer token\"}", "accepted_statuscodes": ["200-299"]},
+    {"id": 3, "name": "search", "type": "keyword", "active": 0, "parent": null, "interval": 60, "url": "https://example.org/search", "method": "GET", "keyword": "results", "invertKeyword": false, "accepted_statuscodes": ["200"]},
+    {"id": 4, "name": "database", "type": "port", "active": 1, "hostname": "db.example.org", "port": 5432},
+    {"id": 5, "name": "gateway", "type": "ping", "active": true, "hostname": "10.0.0.1", "interval": 120},
+    {"id": 6, "name": "domain", "type": "dns", "active": true, "hostname": "example.org", "dns_resolve_type": "AAAA", "dns_resolve_server": "8.8.8.8"},
+    {"id": 7, "name": "event-stream", "type": "mqtt", "active": true}
+  ],
+  "heartbeatList": {
+    "2": [
+      {"status": 0, "msg": "connect ECONNREFUSED", "time": "2024-01-01 00:01:00.000", "ping": null},
+      {"status": 1, "msg": "200 - OK", "time": "2024-01-01 00:00:00.000", "ping": 150},
+      {"status": 2, "msg": "pending", "time": "2024-01-01 00:00:30.000", "ping": null}
+    ],
+    "4": [
+      {"status": 1, "msg": "", "time": "2024-01-01 00:00:00", "ping": 3}
+    ]
+  }
+}`
+
+func TestImport_UptimeKuma(t *testing.T) {
+	data, err := Import(SourceUptimeKuma, []byte(testUptimeKumaBackup))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(data.Skipped) != 1 || !strings.HasPrefix(data.Skipped[0], "event-stream") {
+		t.Errorf("expected the mqtt monitor to be skipped, got %v", data.Skipped)
+	}
+	expectedEndpoints := map[string]*endpoint.Endpoint{
+		"core_website": {URL: "https://example.org", Method: "GET", Interval: 30 * time.Second, Conditions: []endpoint.Condition{"[STATUS] >= 200", "[STATUS] <= 299"}},
+		"_search":      {URL: "https://example.org/search", Method: "GET", Interval: time.Minute, Conditions: []endpoint.Condition{"[STATUS] == 200", "[BODY] == pat(*results*)"}},
+		"_database":    {URL: "tcp://db.example.org:5432", Interval: time.Minute, Conditions: []endpoint.Condition{"[CONNECTED] == true"}},
+		"_gateway":     {URL: "icmp://10.0.0.1", Interval: 2 * time.Minute, Conditions: []endpoint.Condition{"[CONNECTED] == true"}},
+		"_domain":      {URL: "8.8.8.8", Interval: time.Minute, Conditions: []endpoint.Condition{"[DNS_RCODE] == NOERROR"}},
+	}
+	if len(data.Endpoints) != len(expectedEndpoints) {
+		t.Fatalf("expected %d endpoints, got %d", len(expectedEndpoints), len(data.Endpoints))
+	}
+	for _, ep := range data.Endpoints {
+		expected, exists := expectedEndpoints[ep.Key()]
+		if !exists {
+			t.Errorf("unexpected endpoint with key %s", ep.Key())
+			continue
+		}
+		if ep.URL != expected.URL || ep.Interval != expected.Interval || !reflect.DeepEqual(ep.Conditions, expected.Conditions) {
+			t.Errorf("expected endpoint %s to have url=%s, interval=%s and conditions=%v, got url=%s, interval=%s and conditions=%v", ep.Key(), expected.URL, expected.Interval, expected.Conditions, ep.URL, ep.Interval, ep.Conditions)
+		}
+		if len(expected.Method) > 0 && ep.Method != expected.Method {
+			t.Errorf("expected endpoint %s to have method %s, got %s", ep.Key(), expected.Method, ep.Method)
+		}
+		switch ep.Key() {
+		case "core_website":
+			if ep.Headers["Authorization"] != "Bearer token" {
+				t.Errorf("expected headers to be imported, got %v", ep.Headers)
+			}
+		case "_search":
+			if ep.IsEnabled() {
+				t.Error("expected inactive monitor to be imported as a disabled endpoint")
+			}
+		case "_domain":
+			if ep.DNSConfig == nil || ep.DNSConfig.QueryName != "example.org." || ep.DNSConfig.QueryType != "AAAA" {
+				t.Errorf("expected dns configuration to be imported, got %+v", ep.DNSConfig)
+			}
+		}
+	}
+	results := data.Results["core_website"]
+	if len(results) != 2 {
+		t.Fatalf("expected pending heartbeats to be ignored, got %d results", len(results))
+	}
+	if !results[0].Success || results[0].Duration != 150*time.Millisecond || !results[0].Timestamp.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected first result to be the successful heartbeat, got %+v", results[0])
+	}
+	if results[1].Success || len(results[1].Errors) != 1 || results[1].Errors[0] != "connect ECONNREFUSED" {
+		t.Errorf("expected second result to be the failed heartbeat, got %+v", results[1])
+	}
+	if len(data.Results["_database"]) != 1 {
+		t.Errorf("expected 1 result for database, got %d", len(data.Results["_database"]))
+	}
+	configuration, err := data.EndpointsConfiguration()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	for _, expected := range []string{"endpoints:", "name: website", "group: core", "interval: 30s", "url: tcp://db.example.org:5432", "query-type: AAAA"} {
+		if !strings.Contains(string(configuration), expected) {
+			t.Errorf("expected configuration to contain %q, got:\n%s", expected, configuration)
+		}
+	}
+}
+
+func TestImport_InvalidData(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		source string
+		data   string
+	}{
+		{name: "unsupported-source", source: "nagios", data: testUptimeKumaBackup},
+		{name: "invalid-json", source: SourceUptimeKuma, data: "not json"},
+		{name: "no-monitors", source: SourceUptimeKuma, data: `{"version": "1.23.0", "monitorList": []}`},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if _, err := Import(scenario.source, []byte(scenario.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestStatusCodeConditions(t *testing.T) {
+	scenarios := []struct {
+		acceptedStatusCodes []string
+		expectedConditions  []endpoint.Condition
+		expectedErr         bool
+	}{
+		{acceptedStatusCodes: nil, expectedConditions: []endpoint.Condition{"[STATUS] >= 200", "[STATUS] <= 299"}},
+		{acceptedStatusCodes: []string{"200"}, expectedConditions: []endpoint.Condition{"[STATUS] == 200"}},
+		{acceptedStatusCodes: []string{"200-299", "300-399"}, expectedConditions: []endpoint.Condition{"[STATUS] >= 200", "[STATUS] <= 399"}},
+		{acceptedStatusCodes: []string{"200", "204", "201-202"}, expectedConditions: []endpoint.Condition{"[STATUS] == any(200, 201, 202, 204)"}},
+		{acceptedStatusCodes: []string{"abc"}, expectedErr: true},
+		{acceptedStatusCodes: []string{"299-200"}, expectedErr: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(strings.Join(scenario.acceptedStatusCodes, ","), func(t *testing.T) {
+			conditions, err := statusCodeConditions(scenario.acceptedStatusCodes)
+			if (err != nil) != scenario.expectedErr {
+				t.Fatalf("expected error=%v, got %v", scenario.expectedErr, err)
+			}
+			if !reflect.DeepEqual(conditions, scenario.expectedConditions) {
+				t.Errorf("expected conditions %v, got %v", scenario.expectedConditions, conditions)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package loganalytics
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	DefaultResultLogType = "GatusResult"
+	DefaultAlertLogType  = "GatusAlert"
+	DefaultFlushInterval = 10 * time.Second
+
+	// maximumBufferedRecords is the maximum number of records buffered per log type. Once reached, new records are
+	// dropped until the buffer is flushed, which prevents the memory from growing indefinitely if Azure is unreachable
+	maximumBufferedRecords = 10000
+
+	// dataCollectorURLFormat is the format of the URL of the Data Collector API, which takes the workspace ID as parameter
+	dataCollectorURLFormat = "https://%s.ods.opinsights.azure.com/api/logs?api-version=2016-04-01"
+
+	// logsIngestionURLFormat is the format of the URL of the Logs Ingestion API, which takes the data collection
+	// endpoint, the immutable ID of the data collection rule and the name of the stream as parameters
+	logsIngestionURLFormat = "%s/dataCollectionRules/%s/streams/%s?api-version=2023-01-01"
+
+	// tokenURLFormat is the format of the URL used to retrieve an access token, which takes the tenant ID as parameter
+	tokenURLFormat = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+
+	// logsIngestionScope is the OAuth2 scope required to send logs through the Logs Ingestion API
+	logsIngestionScope = "https://monitor.azure.com//.default"
+)
+
+var (
+	// ErrNoAPIConfigured is the error with which Gatus will panic if neither the Data Collector API nor the Logs
+	// Ingestion API is configured
+	ErrNoAPIConfigured = errors.New("log-analytics requires either workspace-id and shared-key, or endpoint, rule-id, tenant-id, client-id and client-secret")
+
+	// ErrBothAPIsConfigured is the error with which Gatus will panic if both the Data Collector API and the Logs
+	// Ingestion API are configured
+	ErrBothAPIsConfigured = errors.New("log-analytics cannot be configured with both workspace-id and endpoint")
+
+	// ErrInvalidSharedKey is the error with which Gatus will panic if the shared key isn't base64 encoded
+	ErrInvalidSharedKey = errors.New("log-analytics.shared-key must be base64 encoded")
+
+	// ErrInvalidFlushInterval is the error with which Gatus will panic if the flush interval is lower than 1s
+	ErrInvalidFlushInterval = errors.New("log-analytics.flush-interval must be 1s or higher")
+
+	activeConfig      *Config
+	activeConfigMutex sync.RWMutex
+)
+
+// Config is the configuration for sending results and alert events to Azure Log Analytics, through either the
+// Data Collector API or the Logs Ingestion API
+type Config struct {
+	// WorkspaceID is the ID of the Log Analytics workspace to send logs to through the Data Collector API
+	WorkspaceID string `yaml:"workspace-id,omitempty"`
+
+	// SharedKey is the primary or secondary key of the workspace, used to sign requests to the Data Collector API
+	SharedKey string `yaml:"shared-key,omitempty"`
+
+	// Endpoint is the URL of the data collection endpoint to send logs to through the Logs Ingestion API
+	// (e.g. https://gatus-abcd.westeurope-1.ingest.monitor.azure.com)
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// RuleID is the immutable ID of the data collection rule, used with the Logs Ingestion API
+	RuleID string `yaml:"rule-id,omitempty"`
+
+	// TenantID is the ID of the Microsoft Entra tenant of the application, used with the Logs Ingestion API
+	TenantID string `yaml:"tenant-id,omitempty"`
+
+	// ClientID is the ID of the application allowed to send logs, used with the Logs Ingestion API
+	ClientID string `yaml:"client-id,omitempty"`
+
+	// ClientSecret is the secret of the application allowed to send logs, used with the Logs Ingestion API
+	ClientSecret string `yaml:"client-secret,omitempty"`
+
+	// ResultLogType is the log type of the results. With the Data Collector API, results are sent to the table
+	// <ResultLogType>_CL, whereas with the Logs Ingestion API, they're sent to the stream Custom-<ResultLogType>_CL
+	ResultLogType string `yaml:"result-log-type,omitempty"`
+
+	// AlertLogType is the log type of the alert events, which works the same way as ResultLogType
+	AlertLogType string `yaml:"alert-log-type,omitempty"`
+
+	// FlushInterval is the interval at which the buffered records are sent
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
+
+	sharedKey   []byte
+	tokenSource oauth2.TokenSource
+
+	records      map[string][]any // buffered records by log type
+	recordsMutex sync.Mutex
+}
+
+// ValidateAndSetDefaults validates the Log Analytics configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	usesDataCollectorAPI := len(c.WorkspaceID) > 0 || len(c.SharedKey) > 0
+	usesLogsIngestionAPI := len(c.Endpoint) > 0 || len(c.RuleID) > 0 || len(c.TenantID) > 0 || len(c.ClientID) > 0 || len(c.ClientSecret) > 0
+	if usesDataCollectorAPI && usesLogsIngestionAPI {
+		return ErrBothAPIsConfigured
+	}
+	if usesDataCollectorAPI {
+		if len(c.WorkspaceID) == 0 || len(c.SharedKey) == 0 {
+			return ErrNoAPIConfigured
+		}
+		sharedKey, err := base64.StdEncoding.DecodeString(c.SharedKey)
+		if err != nil {
+			return ErrInvalidSharedKey
+		}
+		c.sharedKey = sharedKey
+	} else if len(c.Endpoint) == 0 || len(c.RuleID) == 0 || len(c.TenantID) == 0 || len(c.ClientID) == 0 || len(c.ClientSecret) == 0 {
+		return ErrNoAPIConfigured
+	} else {
+		c.Endpoint = strings.TrimSuffix(c.Endpoint, "/")
+	}
+	if len(c.ResultLogType) == 0 {
+		c.ResultLogType = DefaultResultLogType
+	}
+	if len(c.AlertLogType) == 0 {
+		c.AlertLogType = DefaultAlertLogType
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = DefaultFlushInterval
+	} else if c.FlushInterval < time.Second {
+		return ErrInvalidFlushInterval
+	}
+	return nil
+}
+
+// Run sends the buffered records to Log Analytics at every flush interval until the context is canceled, at which
+// point the remaining records are sent one last time.
+//
+// Results and alert events are only buffered while Run is running.
+func (c *Config) Run(ctx context.Context) {
+	activeConfigMutex.Lock()
+	activeConfig = c
+	activeConfigMutex.Unlock()
+	ticker := time.NewTicker(c.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			activeConfigMutex.Lock()
+			if activeConfig == c {
+				activeConfig = nil
+			}
+			activeConfigMutex.Unlock()
+			c.flush()
+			return
+		case <-ticker.C:
+			c.flush()
+		}
+	}
+}
+
+// resultRecord is the record sent for each result
+type resultRecord struct {
+	TimeGenerated time.Time `json:"TimeGenerated"`
+	Key           string    `json:"Key"`
+	Group         string    `json:"Group"`
+	Name          string    `json:"Name"`
+	Hostname      string    `json:"Hostname"`
+	Success       bool      `json:"Success"`
+	Status        int       `json:"Status"`
+	DurationMs    int64     `json:"DurationMs"`
+	Errors        string    `json:"Errors"`
+}
+
+// alertRecord is the record sent for each alert that is triggered or resolved
+type alertRecord struct {
+	TimeGenerated time.Time `json:"TimeGenerated"`
+	Key           string    `json:"Key"`
+	Group         string    `json:"Group"`
+	Name          string    `json:"Name"`
+	AlertType     string    `json:"AlertType"`
+	Description   string    `json:"Description"`
+	State         string    `json:"State"`
+}
+
+// PublishResult buffers a result to be sent to Log Analytics, if configured
+func PublishResult(ep *endpoint.Endpoint, result *endpoint.Result) {
+	c := getActiveConfig()
+	if c == nil {
+		return
+	}
+	timestamp := result.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	c.buffer(c.ResultLogType, resultRecord{
+		TimeGenerated: timestamp.UTC(),
+		Key:           ep.Key(),
+		Group:         ep.Group,
+		Name:          ep.Name,
+		Hostname:      result.Hostname,
+		Success:       result.Success,
+		Status:        result.HTTPStatus,
+		DurationMs:    result.Duration.Milliseconds(),
+		Errors:        strings.Join(result.Errors, "\n"),
+	})
+}
+
+// PublishAlertEvent buffers an alert event to be sent to Log Analytics, if configured
+func PublishAlertEvent(ep *endpoint.Endpoint, endpointAlert *alert.Alert, resolved bool) {
+	c := getActiveConfig()
+	if c == nil {
+		return
+	}
+	state := "triggered"
+	if resolved {
+		state = "resolved"
+	}
+	c.buffer(c.AlertLogType, alertRecord{
+		TimeGenerated: time.Now().UTC(),
+		Key:           ep.Key(),
+		Group:         ep.Group,
+		Name:          ep.Name,
+		AlertType:     string(endpointAlert.Type),
+		Description:   endpointAlert.GetDescription(),
+		State:         state,
+	})
+}
+
+func getActiveConfig() *Config {
+	activeConfigMutex.RLock()
+	defer activeConfigMutex.RUnlock()
+	return activeConfig
+}
+
+func (c *Config) buffer(logType string, record any) {
+	c.recordsMutex.Lock()
+	defer c.recordsMutex.Unlock()
+	if c.records == nil {
+		c.records = make(map[string][]any)
+	}
+	if len(c.records[logType]) >= maximumBufferedRecords {
+		log.Printf("[loganalytics.buffer] Dropping record of log type %s because %d records are already waiting to be sent", logType, maximumBufferedRecords)
+		return
+	}
+	c.records[logType] = append(c.records[logType], record)
+}
+
+// flush sends the buffered records of each log type. Records that failed to be sent are dropped, since retrying them
+// would delay more recent ones.
+func (c *Config) flush() {
+	c.recordsMutex.Lock()
+	records := c.records
+	c.records = nil
+	c.recordsMutex.Unlock()
+	for logType, recordsOfLogType := range records {
+		if err := c.send(logType, recordsOfLogType); err != nil {
+			log.Printf("[loganalytics.flush] Failed to send %d records of log type %s: %s", len(recordsOfLogType), logType, err.Error())
+		}
+	}
+}
+
+// send sends records of a specific log type to Log Analytics
+func (c *Config) send(logType string, records []any) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	var request *http.Request
+	if len(c.WorkspaceID) > 0 {
+		request, err = c.newDataCollectorRequest(logType, body)
+	} else {
+		request, err = c.newLogsIngestionRequest(logType, body)
+	}
+	if err != nil {
+		return err
+	}
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to log analytics returned status code %d: %s", response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+// newDataCollectorRequest creates a request to the Data Collector API, which is signed with the shared key
+// Reference doc: https://learn.microsoft.com/en-us/azure/azure-monitor/logs/data-collector-api
+func (c *Config) newDataCollectorRequest(logType string, body []byte) (*http.Request, error) {
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(dataCollectorURLFormat, c.WorkspaceID), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	date := time.Now().UTC().Format(http.TimeFormat)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Log-Type", logType)
+	request.Header.Set("x-ms-date", date)
+	request.Header.Set("time-generated-field", "TimeGenerated")
+	request.Header.Set("Authorization", "SharedKey "+c.WorkspaceID+":"+c.sign(date, len(body)))
+	return request, nil
+}
+
+// sign returns the signature of a request to the Data Collector API
+func (c *Config) sign(date string, contentLength int) string {
+	stringToSign := "POST\n" + strconv.Itoa(contentLength) + "\napplication/json\nx-ms-date:" + date + "\n/api/logs"
+	mac := hmac.New(sha256.New, c.sharedKey)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newLogsIngestionRequest creates a request to the Logs Ingestion API, which is authenticated with an access token
+// retrieved through the client credentials of the application
+// Reference doc: https://learn.microsoft.com/en-us/azure/azure-monitor/logs/logs-ingestion-api-overview
+func (c *Config) newLogsIngestionRequest(logType string, body []byte) (*http.Request, error) {
+	if c.tokenSource == nil {
+		clientCredentialsConfig := clientcredentials.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			TokenURL:     fmt.Sprintf(tokenURLFormat, c.TenantID),
+			Scopes:       []string{logsIngestionScope},
+		}
+		c.tokenSource = clientCredentialsConfig.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, client.GetHTTPClient(nil)))
+	}
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve access token: %w", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(logsIngestionURLFormat, c.Endpoint, c.RuleID, "Custom-"+logType+"_CL"), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return request, nil
+}
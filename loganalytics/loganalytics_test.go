@@ -0,0 +1,219 @@
+package loganalytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Config        *Config
+		ExpectedError error
+	}{
+		{
+			Name:   "data-collector",
+			Config: &Config{WorkspaceID: "workspace-id", SharedKey: "c2hhcmVkLWtleQ=="},
+		},
+		{
+			Name:   "logs-ingestion",
+			Config: &Config{Endpoint: "https://gatus.westeurope-1.ingest.monitor.azure.com/", RuleID: "dcr-1", TenantID: "tenant", ClientID: "client", ClientSecret: "secret"},
+		},
+		{
+			Name:          "empty",
+			Config:        &Config{},
+			ExpectedError: ErrNoAPIConfigured,
+		},
+		{
+			Name:          "data-collector-without-shared-key",
+			Config:        &Config{WorkspaceID: "workspace-id"},
+			ExpectedError: ErrNoAPIConfigured,
+		},
+		{
+			Name:          "logs-ingestion-without-client-secret",
+			Config:        &Config{Endpoint: "https://gatus.westeurope-1.ingest.monitor.azure.com", RuleID: "dcr-1", TenantID: "tenant", ClientID: "client"},
+			ExpectedError: ErrNoAPIConfigured,
+		},
+		{
+			Name:          "both",
+			Config:        &Config{WorkspaceID: "workspace-id", SharedKey: "c2hhcmVkLWtleQ==", Endpoint: "https://gatus.westeurope-1.ingest.monitor.azure.com"},
+			ExpectedError: ErrBothAPIsConfigured,
+		},
+		{
+			Name:          "invalid-shared-key",
+			Config:        &Config{WorkspaceID: "workspace-id", SharedKey: "not base64!"},
+			ExpectedError: ErrInvalidSharedKey,
+		},
+		{
+			Name:          "invalid-flush-interval",
+			Config:        &Config{WorkspaceID: "workspace-id", SharedKey: "c2hhcmVkLWtleQ==", FlushInterval: time.Millisecond},
+			ExpectedError: ErrInvalidFlushInterval,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := scenario.Config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.ExpectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.Config.ResultLogType != DefaultResultLogType || scenario.Config.AlertLogType != DefaultAlertLogType || scenario.Config.FlushInterval != DefaultFlushInterval {
+				t.Errorf("expected default values to be set, got %#v", scenario.Config)
+			}
+			if strings.HasSuffix(scenario.Config.Endpoint, "/") {
+				t.Errorf("expected trailing slash of the endpoint to be removed, got %s", scenario.Config.Endpoint)
+			}
+		})
+	}
+}
+
+// recordRequests injects an HTTP client recording every request it receives and responding with the responses
+// returned by respond
+func recordRequests(t *testing.T, respond func(r *http.Request) *http.Response) func() []*http.Request {
+	var mutex sync.Mutex
+	var requests []*http.Request
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, r)
+		return respond(r)
+	})})
+	t.Cleanup(func() { client.InjectHTTPClient(nil) })
+	return func() []*http.Request {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]*http.Request(nil), requests...)
+	}
+}
+
+// publish runs the configuration, publishes a result and an alert event, and stops it so that they're flushed
+func publish(t *testing.T, cfg *Config) {
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	// Nothing is buffered until the configuration is running
+	PublishResult(ep, &endpoint.Result{Success: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		cfg.Run(ctx)
+		done <- true
+	}()
+	for getActiveConfig() != cfg {
+		time.Sleep(time.Millisecond)
+	}
+	PublishResult(ep, &endpoint.Result{Success: false, HTTPStatus: 500, Duration: 150 * time.Millisecond, Errors: []string{"error-1"}, Timestamp: time.Now()})
+	PublishAlertEvent(ep, &alert.Alert{Type: alert.TypeSlack}, false)
+	cancel()
+	<-done
+	if getActiveConfig() != nil {
+		t.Error("expected no active configuration once stopped")
+	}
+}
+
+func TestConfig_RunWithDataCollectorAPI(t *testing.T) {
+	getRequests := recordRequests(t, func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})
+	cfg := &Config{WorkspaceID: "workspace-id", SharedKey: "c2hhcmVkLWtleQ=="}
+	publish(t, cfg)
+	requests := getRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	logTypes := make(map[string]bool)
+	for _, request := range requests {
+		if request.URL.String() != "https://workspace-id.ods.opinsights.azure.com/api/logs?api-version=2016-04-01" {
+			t.Errorf("unexpected URL %s", request.URL.String())
+		}
+		body, _ := io.ReadAll(request.Body)
+		expectedAuthorization := "SharedKey workspace-id:" + cfg.sign(request.Header.Get("x-ms-date"), len(body))
+		if request.Header.Get("Authorization") != expectedAuthorization {
+			t.Errorf("expected authorization %s, got %s", expectedAuthorization, request.Header.Get("Authorization"))
+		}
+		if request.Header.Get("time-generated-field") != "TimeGenerated" {
+			t.Errorf("expected time-generated-field to be TimeGenerated, got %s", request.Header.Get("time-generated-field"))
+		}
+		var records []map[string]any
+		if err := json.Unmarshal(body, &records); err != nil || len(records) != 1 {
+			t.Fatalf("expected a single record, got %s", string(body))
+		}
+		logType := request.Header.Get("Log-Type")
+		logTypes[logType] = true
+		if logType == DefaultResultLogType && (records[0]["Key"] != "core_frontend" || records[0]["Success"] != false || records[0]["Status"] != float64(500) || records[0]["DurationMs"] != float64(150) || records[0]["Errors"] != "error-1") {
+			t.Errorf("unexpected result record %s", string(body))
+		}
+		if logType == DefaultAlertLogType && (records[0]["AlertType"] != "slack" || records[0]["State"] != "triggered") {
+			t.Errorf("unexpected alert record %s", string(body))
+		}
+	}
+	if !logTypes[DefaultResultLogType] || !logTypes[DefaultAlertLogType] {
+		t.Errorf("expected records of both log types to be sent, got %v", logTypes)
+	}
+}
+
+func TestConfig_RunWithLogsIngestionAPI(t *testing.T) {
+	getRequests := recordRequests(t, func(r *http.Request) *http.Response {
+		if r.URL.Host == "login.microsoftonline.com" {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(strings.NewReader(`{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`))}
+		}
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+		}
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}
+	})
+	publish(t, &Config{Endpoint: "https://gatus.westeurope-1.ingest.monitor.azure.com", RuleID: "dcr-1", TenantID: "tenant", ClientID: "client", ClientSecret: "secret"})
+	urls := make(map[string]bool)
+	for _, request := range getRequests() {
+		urls[request.URL.String()] = true
+	}
+	expectedURLs := []string{
+		"https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+		"https://gatus.westeurope-1.ingest.monitor.azure.com/dataCollectionRules/dcr-1/streams/Custom-GatusResult_CL?api-version=2023-01-01",
+		"https://gatus.westeurope-1.ingest.monitor.azure.com/dataCollectionRules/dcr-1/streams/Custom-GatusAlert_CL?api-version=2023-01-01",
+	}
+	if len(urls) != len(expectedURLs) {
+		t.Errorf("expected requests to %v, got %v", expectedURLs, urls)
+	}
+	for _, expectedURL := range expectedURLs {
+		if !urls[expectedURL] {
+			t.Errorf("expected a request to %s, got %v", expectedURL, urls)
+		}
+	}
+}
+
+func TestConfig_send(t *testing.T) {
+	recordRequests(t, func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader("invalid signature"))}
+	})
+	cfg := &Config{WorkspaceID: "workspace-id", SharedKey: "c2hhcmVkLWtleQ=="}
+	_ = cfg.ValidateAndSetDefaults()
+	if err := cfg.send(DefaultResultLogType, []any{resultRecord{}}); err == nil || !strings.Contains(err.Error(), "invalid signature") {
+		t.Errorf("expected an error containing the body of the response, got %v", err)
+	}
+}
+
+func TestConfig_buffer(t *testing.T) {
+	cfg := &Config{}
+	for i := 0; i < maximumBufferedRecords+10; i++ {
+		cfg.buffer(DefaultResultLogType, resultRecord{})
+	}
+	if len(cfg.records[DefaultResultLogType]) != maximumBufferedRecords {
+		t.Errorf("expected %d records to be buffered, got %d", maximumBufferedRecords, len(cfg.records[DefaultResultLogType]))
+	}
+}
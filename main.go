@@ -1,20 +1,47 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/kv"
+	"github.com/TwiN/gatus/v5/config/snapshot"
 	"github.com/TwiN/gatus/v5/controller"
+	"github.com/TwiN/gatus/v5/handover"
+	"github.com/TwiN/gatus/v5/importer"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gatus/v5/watchdog"
 )
 
+var (
+	// currentConfig is the configuration being applied, which changes every time the configuration is reloaded
+	currentConfig      *config.Config
+	currentConfigMutex sync.RWMutex
+)
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := importData(os.Args[2:]); err != nil {
+			log.Fatalln("[main.importData] Failed to import data:", err.Error())
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		if err := validateConfiguration(); err != nil {
+			log.Fatalln("[main.validateConfiguration] Validation failed:", err.Error())
+		}
+		return
+	}
 	if delayInSeconds, _ := strconv.Atoi(os.Getenv("GATUS_DELAY_START_SECONDS")); delayInSeconds > 0 {
 		log.Printf("Delaying start by %d seconds", delayInSeconds)
 		time.Sleep(time.Duration(delayInSeconds) * time.Second)
@@ -24,24 +51,50 @@ func main() {
 		panic(err)
 	}
 	initializeStorage(cfg)
+	if state := handover.InheritedState(); state != nil {
+		// Gatus is being upgraded, so the endpoints are monitored on the schedule of the previous process
+		watchdog.RestoreSchedule(state.NextRunAt)
+	}
 	start(cfg)
-	// Wait for termination signal
+	// Wait for termination signal, or for SIGHUP to hand over to a new process
 	signalChannel := make(chan os.Signal, 1)
 	done := make(chan bool, 1)
-	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 	go func() {
-		<-signalChannel
-		log.Println("Received termination signal, attempting to gracefully shut down")
-		stop(cfg)
-		save()
-		done <- true
+		for receivedSignal := range signalChannel {
+			if receivedSignal == syscall.SIGHUP {
+				currentConfigMutex.RLock()
+				cfg := currentConfig
+				currentConfigMutex.RUnlock()
+				if upgrade(cfg) {
+					done <- true
+					return
+				}
+				continue
+			}
+			log.Println("Received termination signal, attempting to gracefully shut down")
+			stop(cfg)
+			save()
+			done <- true
+			return
+		}
 	}()
 	<-done
 	log.Println("Shutting down")
 }
 
 func start(cfg *config.Config) {
-	go controller.Handle(cfg)
+	if err := client.SetGlobalCABundleFiles(cfg.CABundleFiles); err != nil {
+		log.Println("[main.start] Failed to load global CA bundle:", err.Error())
+	}
+	recordConfigurationSnapshot(cfg)
+	currentConfigMutex.Lock()
+	currentConfig = cfg
+	currentConfigMutex.Unlock()
+	controller.Handle(cfg)
+	// If Gatus is being upgraded, the previous process can stop serving requests now that the listeners are inherited,
+	// which must not wait for the endpoints to be started one after the other
+	handover.Ready()
 	watchdog.Monitor(cfg)
 	go listenToConfigurationFileChanges(cfg)
 }
@@ -51,12 +104,115 @@ func stop(cfg *config.Config) {
 	controller.Shutdown()
 }
 
+// upgrade hands over the listeners and the schedule of the endpoints to a new process started from the executable of
+// this process, which may have been replaced by a new version, and returns whether the new process took over, in which
+// case this process must exit. Otherwise, this process resumes monitoring the endpoints.
+func upgrade(cfg *config.Config) bool {
+	log.Println("[main.upgrade] Received SIGHUP, handing over to a new process")
+	state := &handover.State{NextRunAt: make(map[string]time.Time)}
+	for _, schedule := range watchdog.GetEndpointSchedules() {
+		// Endpoints being evaluated have no next run, so the new process evaluates them right away
+		if schedule.NextRunAt != nil {
+			state.NextRunAt[schedule.Key] = *schedule.NextRunAt
+		}
+	}
+	// The endpoints must not be monitored by both processes at the same time, and the new process must start from the
+	// latest results, so the monitoring stops and the storage is saved before the new process starts
+	watchdog.Shutdown(cfg)
+	save()
+	process, err := handover.Upgrade(state, handover.DefaultReadyTimeout)
+	if err != nil {
+		log.Println("[main.upgrade] Failed to hand over to a new process, resuming:", err.Error())
+		watchdog.RestoreSchedule(state.NextRunAt)
+		watchdog.Monitor(cfg)
+		return false
+	}
+	log.Printf("[main.upgrade] Handed over to new process with pid=%d, shutting down", process.Pid)
+	// Requests being served are completed before the server shuts down, while new connections go to the new process
+	controller.Shutdown()
+	store.Get().Close()
+	return true
+}
+
 func save() {
 	if err := store.Get().Save(); err != nil {
 		log.Println("Failed to save storage provider:", err.Error())
 	}
 }
 
+// importData imports the monitors and history of another monitoring tool into the configured storage, and writes
+// the configuration of the imported endpoints to stdout or to the file specified by the --output flag.
+//
+// Usage: gatus import --from uptime-kuma --file backup.json [--output endpoints.yaml]
+func importData(arguments []string) error {
+	flagSet := flag.NewFlagSet("import", flag.ContinueOnError)
+	source := flagSet.String("from", importer.SourceUptimeKuma, "Monitoring tool to import from (uptime-kuma)")
+	file := flagSet.String("file", "", "Path to the file exported from the monitoring tool")
+	output := flagSet.String("output", "", "Path of the file to write the configuration of the imported endpoints to (defaults to stdout)")
+	if err := flagSet.Parse(arguments); err != nil {
+		return err
+	}
+	if len(*file) == 0 {
+		return errors.New("--file is required")
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	importedData, err := importer.Import(*source, data)
+	if err != nil {
+		return err
+	}
+	for _, skipped := range importedData.Skipped {
+		log.Printf("[main.importData] Skipped monitor %s", skipped)
+	}
+	cfg, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	initializeStorage(cfg)
+	defer store.Get().Close()
+	numberOfResultsImported, err := importedData.Persist(cfg.GetEndpointKeys())
+	if err != nil {
+		return err
+	}
+	save()
+	log.Printf("[main.importData] Imported %d endpoints and %d results", len(importedData.Endpoints), numberOfResultsImported)
+	endpointsConfiguration, err := importedData.EndpointsConfiguration()
+	if err != nil {
+		return err
+	}
+	if len(*output) == 0 {
+		_, err = os.Stdout.Write(endpointsConfiguration)
+		return err
+	}
+	return os.WriteFile(*output, endpointsConfiguration, 0644)
+}
+
+// validateConfiguration validates the configuration and evaluates the conditions of every endpoint against the sample
+// results of its tests, without monitoring anything.
+//
+// Usage: gatus validate
+func validateConfiguration() error {
+	cfg, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	numberOfTests := 0
+	for _, ep := range cfg.Endpoints {
+		numberOfTests += len(ep.Tests)
+		if err := ep.RunTests(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+	log.Printf("[main.validateConfiguration] Configuration is valid and all %d tests passed", numberOfTests)
+	return nil
+}
+
 func loadConfiguration() (*config.Config, error) {
 	configPath := os.Getenv("GATUS_CONFIG_PATH")
 	// Backwards compatibility
@@ -65,6 +221,13 @@ func loadConfiguration() (*config.Config, error) {
 			log.Println("WARNING: GATUS_CONFIG_FILE is deprecated. Please use GATUS_CONFIG_PATH instead.")
 		}
 	}
+	backend, err := kv.NewBackendFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	if backend != nil {
+		return config.LoadConfigurationFromBackend(backend)
+	}
 	return config.LoadConfiguration(configPath)
 }
 
@@ -78,20 +241,14 @@ func initializeStorage(cfg *config.Config) {
 	if err != nil {
 		panic(err)
 	}
+	restoreAutoCreatedExternalEndpoints(cfg)
 	// Remove all EndpointStatus that represent endpoints which no longer exist in the configuration
-	var keys []string
-	for _, ep := range cfg.Endpoints {
-		keys = append(keys, ep.Key())
-	}
-	for _, ee := range cfg.ExternalEndpoints {
-		keys = append(keys, ee.Key())
-	}
-	numberOfEndpointStatusesDeleted := store.Get().DeleteAllEndpointStatusesNotInKeys(keys)
+	numberOfEndpointStatusesDeleted := store.Get().DeleteAllEndpointStatusesNotInKeys(cfg.GetEndpointKeys())
 	if numberOfEndpointStatusesDeleted > 0 {
 		log.Printf("[main.initializeStorage] Deleted %d endpoint statuses because their matching endpoints no longer existed", numberOfEndpointStatusesDeleted)
 	}
 	// Clean up the triggered alerts from the storage provider and load valid triggered endpoint alerts
-	numberOfPersistedTriggeredAlertsLoaded := 0
+	numberOfPersistedTriggeredAlertsLoaded, numberOfPersistedAlertingStatesLoaded := 0, 0
 	for _, ep := range cfg.Endpoints {
 		var checksums []string
 		for _, alert := range ep.Alerts {
@@ -103,6 +260,17 @@ func initializeStorage(cfg *config.Config) {
 		if cfg.Debug && numberOfTriggeredAlertsDeleted > 0 {
 			log.Printf("[main.initializeStorage] Deleted %d triggered alerts for endpoint with key=%s because their configurations have been changed or deleted", numberOfTriggeredAlertsDeleted, ep.Key())
 		}
+		alertingStateRestored := false
+		if len(ep.Alerts) > 0 {
+			state, err := store.Get().GetEndpointAlertingState(ep)
+			if err != nil {
+				log.Printf("[main.initializeStorage] Failed to get alerting state for endpoint with key=%s: %s", ep.Key(), err.Error())
+			} else if state != nil {
+				ep.RestoreAlertingState(state)
+				alertingStateRestored = true
+				numberOfPersistedAlertingStatesLoaded++
+			}
+		}
 		for _, alert := range ep.Alerts {
 			exists, resolveKey, numberOfSuccessesInARow, err := store.Get().GetTriggeredEndpointAlert(ep, alert)
 			if err != nil {
@@ -111,7 +279,10 @@ func initializeStorage(cfg *config.Config) {
 			}
 			if exists {
 				alert.Triggered, alert.ResolveKey = true, resolveKey
-				ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				if !alertingStateRestored {
+					// Without a persisted alerting state, assume the endpoint has just reached the failure threshold
+					ep.NumberOfSuccessesInARow, ep.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				}
 				numberOfPersistedTriggeredAlertsLoaded++
 			}
 		}
@@ -128,6 +299,18 @@ func initializeStorage(cfg *config.Config) {
 		if cfg.Debug && numberOfTriggeredAlertsDeleted > 0 {
 			log.Printf("[main.initializeStorage] Deleted %d triggered alerts for endpoint with key=%s because their configurations have been changed or deleted", numberOfTriggeredAlertsDeleted, ee.Key())
 		}
+		alertingStateRestored := false
+		if len(ee.Alerts) > 0 {
+			state, err := store.Get().GetEndpointAlertingState(convertedEndpoint)
+			if err != nil {
+				log.Printf("[main.initializeStorage] Failed to get alerting state for endpoint with key=%s: %s", ee.Key(), err.Error())
+			} else if state != nil {
+				ee.NumberOfFailuresInARow, ee.NumberOfSuccessesInARow, ee.LastSuccessTimestamp = state.NumberOfFailuresInARow, state.NumberOfSuccessesInARow, state.LastSuccessTimestamp
+				ee.DownSince = state.DownSince
+				alertingStateRestored = true
+				numberOfPersistedAlertingStatesLoaded++
+			}
+		}
 		for _, alert := range ee.Alerts {
 			exists, resolveKey, numberOfSuccessesInARow, err := store.Get().GetTriggeredEndpointAlert(convertedEndpoint, alert)
 			if err != nil {
@@ -136,7 +319,9 @@ func initializeStorage(cfg *config.Config) {
 			}
 			if exists {
 				alert.Triggered, alert.ResolveKey = true, resolveKey
-				ee.NumberOfSuccessesInARow, ee.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				if !alertingStateRestored {
+					ee.NumberOfSuccessesInARow, ee.NumberOfFailuresInARow = numberOfSuccessesInARow, alert.FailureThreshold
+				}
 				numberOfPersistedTriggeredAlertsLoaded++
 			}
 		}
@@ -144,28 +329,84 @@ func initializeStorage(cfg *config.Config) {
 	if numberOfPersistedTriggeredAlertsLoaded > 0 {
 		log.Printf("[main.initializeStorage] Loaded %d persisted triggered alerts", numberOfPersistedTriggeredAlertsLoaded)
 	}
+	if numberOfPersistedAlertingStatesLoaded > 0 {
+		log.Printf("[main.initializeStorage] Loaded %d persisted alerting states", numberOfPersistedAlertingStatesLoaded)
+	}
+}
+
+// restoreAutoCreatedExternalEndpoints recreates the external endpoints that had been created by an auto-create rule,
+// which must be done before the endpoint statuses of the endpoints that are no longer configured are removed
+func restoreAutoCreatedExternalEndpoints(cfg *config.Config) {
+	if len(cfg.ExternalEndpointsAutoCreate) == 0 {
+		return
+	}
+	endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams())
+	if err != nil {
+		log.Printf("[main.restoreAutoCreatedExternalEndpoints] Failed to retrieve endpoint statuses: %s", err.Error())
+		return
+	}
+	keys := make([]string, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		keys = append(keys, endpointStatus.Key)
+	}
+	if numberOfExternalEndpointsRestored := cfg.RestoreAutoCreatedExternalEndpoints(keys); numberOfExternalEndpointsRestored > 0 {
+		log.Printf("[main.restoreAutoCreatedExternalEndpoints] Restored %d external endpoints created by an auto-create rule", numberOfExternalEndpointsRestored)
+	}
+}
+
+// recordConfigurationSnapshot persists a snapshot of the configuration applied so that it can be rolled back to
+// through the API, unless the configuration is identical to the last configuration applied
+func recordConfigurationSnapshot(cfg *config.Config) {
+	configurationSnapshot := cfg.Snapshot()
+	snapshots, err := store.Get().GetConfigurationSnapshots()
+	if err != nil {
+		log.Printf("[main.recordConfigurationSnapshot] Failed to retrieve configuration snapshots: %s", err.Error())
+		return
+	}
+	if len(snapshots) > 0 && snapshots[0].Checksum == configurationSnapshot.Checksum && configurationSnapshot.Source != snapshot.SourceRollback {
+		return
+	}
+	if err = store.Get().InsertConfigurationSnapshot(configurationSnapshot); err != nil {
+		log.Printf("[main.recordConfigurationSnapshot] Failed to persist configuration snapshot: %s", err.Error())
+		return
+	}
+	log.Printf("[main.recordConfigurationSnapshot] Recorded configuration snapshot with version=%d and checksum=%s", configurationSnapshot.Version, configurationSnapshot.Checksum)
 }
 
 func listenToConfigurationFileChanges(cfg *config.Config) {
 	for {
-		time.Sleep(30 * time.Second)
-		if cfg.HasLoadedConfigurationBeenModified() {
-			log.Println("[main.listenToConfigurationFileChanges] Configuration file has been modified")
+		select {
+		case <-time.After(30 * time.Second):
+		case <-cfg.ReloadRequested():
+		}
+		// A rollback requested through the API takes precedence over the changes made to the configuration file
+		rolledBackConfig := cfg.PendingRollback()
+		if rolledBackConfig != nil || cfg.HasLoadedConfigurationBeenModified() {
+			if rolledBackConfig != nil {
+				log.Println("[main.listenToConfigurationFileChanges] Rolling back to a previous configuration")
+			} else {
+				log.Println("[main.listenToConfigurationFileChanges] Configuration file has been modified")
+			}
 			stop(cfg)
 			time.Sleep(time.Second) // Wait a bit to make sure everything is done.
 			save()
-			updatedConfig, err := loadConfiguration()
-			if err != nil {
-				if cfg.SkipInvalidConfigUpdate {
-					log.Println("[main.listenToConfigurationFileChanges] Failed to load new configuration:", err.Error())
-					log.Println("[main.listenToConfigurationFileChanges] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
-					// Update the last file modification time to avoid trying to process the same invalid configuration again
-					cfg.UpdateLastFileModTime()
-					continue
-				} else {
-					panic(err)
+			updatedConfig := rolledBackConfig
+			if updatedConfig == nil {
+				var err error
+				updatedConfig, err = loadConfiguration()
+				if err != nil {
+					if cfg.SkipInvalidConfigUpdate {
+						log.Println("[main.listenToConfigurationFileChanges] Failed to load new configuration:", err.Error())
+						log.Println("[main.listenToConfigurationFileChanges] The configuration file was updated, but it is not valid. The old configuration will continue being used.")
+						// Update the last file modification time to avoid trying to process the same invalid configuration again
+						cfg.UpdateLastFileModTime()
+						continue
+					} else {
+						panic(err)
+					}
 				}
 			}
+			cfg.Close()
 			store.Get().Close()
 			initializeStorage(updatedConfig)
 			start(updatedConfig)
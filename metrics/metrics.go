@@ -18,6 +18,7 @@ var (
 	resultConnectedTotal               *prometheus.CounterVec
 	resultCodeTotal                    *prometheus.CounterVec
 	resultCertificateExpirationSeconds *prometheus.GaugeVec
+	resultUnconfirmedFailureTotal      *prometheus.CounterVec
 )
 
 func initializePrometheusMetrics() {
@@ -46,6 +47,11 @@ func initializePrometheusMetrics() {
 		Name:      "results_certificate_expiration_seconds",
 		Help:      "Number of seconds until the certificate expires",
 	}, []string{"key", "group", "name", "type"})
+	resultUnconfirmedFailureTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "results_unconfirmed_failure_total",
+		Help:      "Total number of failures not confirmed by the double check",
+	}, []string{"key", "group", "name", "type"})
 }
 
 // PublishMetricsForEndpoint publishes metrics for the given endpoint and its result.
@@ -64,10 +70,16 @@ func PublishMetricsForEndpoint(ep *endpoint.Endpoint, result *endpoint.Result) {
 	if result.DNSRCode != "" {
 		resultCodeTotal.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType), result.DNSRCode).Inc()
 	}
+	if result.GRPCHealthStatus != "" {
+		resultCodeTotal.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType), result.GRPCHealthStatus).Inc()
+	}
 	if result.HTTPStatus != 0 {
 		resultCodeTotal.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType), strconv.Itoa(result.HTTPStatus)).Inc()
 	}
 	if result.CertificateExpiration != 0 {
 		resultCertificateExpirationSeconds.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Set(result.CertificateExpiration.Seconds())
 	}
+	if len(result.UnconfirmedErrors) > 0 {
+		resultUnconfirmedFailureTotal.WithLabelValues(ep.Key(), ep.Group, ep.Name, string(endpointType)).Inc()
+	}
 }
@@ -0,0 +1,352 @@
+package metricspush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/klauspost/compress/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// TypePrometheusRemoteWrite is the type for pushing metrics through the Prometheus remote write protocol
+	TypePrometheusRemoteWrite = "prometheus-remote-write"
+
+	// TypeOTLP is the type for pushing metrics to an OpenTelemetry collector through OTLP/HTTP with JSON encoding
+	TypeOTLP = "otlp"
+
+	DefaultFlushInterval = 15 * time.Second
+
+	// maximumBufferedSamples is the maximum number of samples buffered. Once reached, new samples are dropped until
+	// the buffer is flushed, which prevents the memory from growing indefinitely if the receiver is unreachable
+	maximumBufferedSamples = 10000
+
+	namespace = "gatus" // The prefix of the metrics, which is the same as the one of the metrics exposed at /metrics
+)
+
+var (
+	// ErrInvalidType is the error with which Gatus will panic if the type isn't supported
+	ErrInvalidType = fmt.Errorf("metrics-push.type must be either %s or %s", TypePrometheusRemoteWrite, TypeOTLP)
+
+	// ErrInvalidURL is the error with which Gatus will panic if the URL isn't an HTTP(S) URL
+	ErrInvalidURL = errors.New("metrics-push.url must be an http:// or https:// url")
+
+	// ErrInvalidFlushInterval is the error with which Gatus will panic if the flush interval is lower than 1s
+	ErrInvalidFlushInterval = errors.New("metrics-push.flush-interval must be 1s or higher")
+
+	activeConfig      *Config
+	activeConfigMutex sync.RWMutex
+)
+
+// Config is the configuration for pushing the metrics of results to a Prometheus-compatible remote write endpoint or
+// to an OpenTelemetry collector, which is useful when Gatus cannot be scraped
+type Config struct {
+	// Type of the receiver. Either TypePrometheusRemoteWrite or TypeOTLP.
+	Type string `yaml:"type"`
+
+	// URL to push the metrics to (e.g. https://prometheus.example.com/api/v1/write or
+	// https://collector.example.com:4318/v1/metrics)
+	URL string `yaml:"url"`
+
+	// Headers to add to each request (e.g. Authorization)
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// FlushInterval is the interval at which the buffered samples are pushed
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
+
+	// ClientConfig is the configuration of the client used to push the metrics
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	samples      []*sample
+	samplesMutex sync.Mutex
+}
+
+// sample is the value of a metric at a given time
+type sample struct {
+	name      string
+	labels    []label // sorted by name
+	value     float64
+	timestamp time.Time
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+// ValidateAndSetDefaults validates the configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Type != TypePrometheusRemoteWrite && c.Type != TypeOTLP {
+		return ErrInvalidType
+	}
+	if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return ErrInvalidURL
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = DefaultFlushInterval
+	} else if c.FlushInterval < time.Second {
+		return ErrInvalidFlushInterval
+	}
+	if c.ClientConfig == nil {
+		c.ClientConfig = client.GetDefaultConfig()
+	} else if err := c.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run pushes the buffered samples at every flush interval until the context is canceled, at which point the remaining
+// samples are pushed one last time.
+//
+// Results are only buffered while Run is running.
+func (c *Config) Run(ctx context.Context) {
+	activeConfigMutex.Lock()
+	activeConfig = c
+	activeConfigMutex.Unlock()
+	ticker := time.NewTicker(c.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			activeConfigMutex.Lock()
+			if activeConfig == c {
+				activeConfig = nil
+			}
+			activeConfigMutex.Unlock()
+			c.flush()
+			return
+		case <-ticker.C:
+			c.flush()
+		}
+	}
+}
+
+// PublishResult buffers the metrics of a result to be pushed, if configured
+func PublishResult(ep *endpoint.Endpoint, result *endpoint.Result) {
+	c := getActiveConfig()
+	if c == nil {
+		return
+	}
+	timestamp := result.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	labels := []label{{"group", ep.Group}, {"key", ep.Key()}, {"name", ep.Name}, {"type", string(ep.Type())}}
+	success := 0.0
+	if result.Success {
+		success = 1
+	}
+	samples := []*sample{
+		{name: namespace + "_results_success", labels: labels, value: success, timestamp: timestamp},
+		{name: namespace + "_results_duration_seconds", labels: labels, value: result.Duration.Seconds(), timestamp: timestamp},
+	}
+	if result.CertificateExpiration != 0 {
+		samples = append(samples, &sample{name: namespace + "_results_certificate_expiration_seconds", labels: labels, value: result.CertificateExpiration.Seconds(), timestamp: timestamp})
+	}
+	if result.DNSRCode != "" {
+		samples = append(samples, &sample{name: namespace + "_results_dns_rcode", labels: withLabel(labels, "rcode", result.DNSRCode), value: 1, timestamp: timestamp})
+	}
+	c.buffer(samples)
+}
+
+func getActiveConfig() *Config {
+	activeConfigMutex.RLock()
+	defer activeConfigMutex.RUnlock()
+	return activeConfig
+}
+
+// withLabel returns a copy of the labels passed with an additional label, sorted by name
+func withLabel(labels []label, name, value string) []label {
+	labelsWithLabel := append(append(make([]label, 0, len(labels)+1), labels...), label{name, value})
+	sort.Slice(labelsWithLabel, func(i, j int) bool {
+		return labelsWithLabel[i].name < labelsWithLabel[j].name
+	})
+	return labelsWithLabel
+}
+
+func (c *Config) buffer(samples []*sample) {
+	c.samplesMutex.Lock()
+	defer c.samplesMutex.Unlock()
+	if len(c.samples)+len(samples) > maximumBufferedSamples {
+		log.Printf("[metricspush.buffer] Dropping %d samples because %d samples are already waiting to be pushed", len(samples), len(c.samples))
+		return
+	}
+	c.samples = append(c.samples, samples...)
+}
+
+// flush pushes the buffered samples. Samples that failed to be pushed are dropped, since retrying them would delay
+// more recent ones.
+func (c *Config) flush() {
+	c.samplesMutex.Lock()
+	samples := c.samples
+	c.samples = nil
+	c.samplesMutex.Unlock()
+	if len(samples) == 0 {
+		return
+	}
+	if err := c.push(samples); err != nil {
+		log.Printf("[metricspush.flush] Failed to push %d samples to %s: %s", len(samples), c.URL, err.Error())
+	}
+}
+
+// push sends samples to the receiver
+func (c *Config) push(samples []*sample) error {
+	var body []byte
+	var err error
+	if c.Type == TypePrometheusRemoteWrite {
+		body = snappy.Encode(nil, encodeRemoteWriteRequest(samples))
+	} else if body, err = json.Marshal(newOTLPMetricsRequest(samples)); err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	if c.Type == TypePrometheusRemoteWrite {
+		request.Header.Set("Content-Type", "application/x-protobuf")
+		request.Header.Set("Content-Encoding", "snappy")
+		request.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	} else {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("User-Agent", "Gatus")
+	for name, value := range c.Headers {
+		request.Header.Set(name, value)
+	}
+	response, err := client.GetHTTPClient(c.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("call to %s returned status code %d: %s", c.Type, response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+// encodeRemoteWriteRequest encodes the samples as the WriteRequest protobuf message of the remote write protocol, in
+// which each sample is its own time series.
+// Reference doc: https://prometheus.io/docs/specs/remote_write_spec/
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+func encodeRemoteWriteRequest(samples []*sample) []byte {
+	var writeRequest []byte
+	for _, s := range samples {
+		var timeSeries []byte
+		// The __name__ label sorts before the others, since labels must be sorted by name
+		for _, l := range append([]label{{"__name__", s.name}}, s.labels...) {
+			var encodedLabel []byte
+			encodedLabel = protowire.AppendTag(encodedLabel, 1, protowire.BytesType)
+			encodedLabel = protowire.AppendString(encodedLabel, l.name)
+			encodedLabel = protowire.AppendTag(encodedLabel, 2, protowire.BytesType)
+			encodedLabel = protowire.AppendString(encodedLabel, l.value)
+			timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, encodedLabel)
+		}
+		var encodedSample []byte
+		encodedSample = protowire.AppendTag(encodedSample, 1, protowire.Fixed64Type)
+		encodedSample = protowire.AppendFixed64(encodedSample, math.Float64bits(s.value))
+		encodedSample = protowire.AppendTag(encodedSample, 2, protowire.VarintType)
+		encodedSample = protowire.AppendVarint(encodedSample, uint64(s.timestamp.UnixMilli()))
+		timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+		timeSeries = protowire.AppendBytes(timeSeries, encodedSample)
+		writeRequest = protowire.AppendTag(writeRequest, 1, protowire.BytesType)
+		writeRequest = protowire.AppendBytes(writeRequest, timeSeries)
+	}
+	return writeRequest
+}
+
+// otlpMetricsRequest is the ExportMetricsServiceRequest of OTLP, with JSON encoding
+// Reference doc: https://opentelemetry.io/docs/specs/otlp/#otlphttp
+type otlpMetricsRequest struct {
+	ResourceMetrics []*otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     *otlpResource       `json:"resource"`
+	ScopeMetrics []*otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   *otlpScope    `json:"scope"`
+	Metrics []*otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpMetric struct {
+	Name  string     `json:"name"`
+	Gauge *otlpGauge `json:"gauge"`
+}
+
+type otlpGauge struct {
+	DataPoints []*otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpDataPoint struct {
+	Attributes   []*otlpAttribute `json:"attributes"`
+	TimeUnixNano string           `json:"timeUnixNano"` // 64-bit integers are encoded as strings
+	AsDouble     float64          `json:"asDouble"`
+}
+
+type otlpAttribute struct {
+	Key   string              `json:"key"`
+	Value *otlpAttributeValue `json:"value"`
+}
+
+type otlpAttributeValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// newOTLPMetricsRequest creates an OTLP request in which the samples are the data points of gauges, grouped by metric
+func newOTLPMetricsRequest(samples []*sample) *otlpMetricsRequest {
+	scopeMetrics := &otlpScopeMetrics{Scope: &otlpScope{Name: "gatus"}}
+	metricByName := make(map[string]*otlpMetric)
+	for _, s := range samples {
+		metric, exists := metricByName[s.name]
+		if !exists {
+			metric = &otlpMetric{Name: s.name, Gauge: &otlpGauge{}}
+			metricByName[s.name] = metric
+			scopeMetrics.Metrics = append(scopeMetrics.Metrics, metric)
+		}
+		attributes := make([]*otlpAttribute, 0, len(s.labels))
+		for _, l := range s.labels {
+			attributes = append(attributes, &otlpAttribute{Key: l.name, Value: &otlpAttributeValue{StringValue: l.value}})
+		}
+		metric.Gauge.DataPoints = append(metric.Gauge.DataPoints, &otlpDataPoint{
+			Attributes:   attributes,
+			TimeUnixNano: strconv.FormatInt(s.timestamp.UnixNano(), 10),
+			AsDouble:     s.value,
+		})
+	}
+	return &otlpMetricsRequest{
+		ResourceMetrics: []*otlpResourceMetrics{{
+			Resource:     &otlpResource{Attributes: []*otlpAttribute{{Key: "service.name", Value: &otlpAttributeValue{StringValue: "gatus"}}}},
+			ScopeMetrics: []*otlpScopeMetrics{scopeMetrics},
+		}},
+	}
+}
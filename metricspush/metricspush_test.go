@@ -0,0 +1,226 @@
+package metricspush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/dns"
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/klauspost/compress/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		Config        *Config
+		ExpectedError error
+	}{
+		{
+			Name:   "prometheus-remote-write",
+			Config: &Config{Type: TypePrometheusRemoteWrite, URL: "https://prometheus.example.com/api/v1/write"},
+		},
+		{
+			Name:   "otlp",
+			Config: &Config{Type: TypeOTLP, URL: "http://collector:4318/v1/metrics"},
+		},
+		{
+			Name:          "invalid-type",
+			Config:        &Config{Type: "graphite", URL: "https://example.com"},
+			ExpectedError: ErrInvalidType,
+		},
+		{
+			Name:          "missing-url",
+			Config:        &Config{Type: TypeOTLP},
+			ExpectedError: ErrInvalidURL,
+		},
+		{
+			Name:          "invalid-url",
+			Config:        &Config{Type: TypeOTLP, URL: "collector:4318"},
+			ExpectedError: ErrInvalidURL,
+		},
+		{
+			Name:          "invalid-flush-interval",
+			Config:        &Config{Type: TypeOTLP, URL: "http://collector:4318/v1/metrics", FlushInterval: time.Millisecond},
+			ExpectedError: ErrInvalidFlushInterval,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := scenario.Config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.ExpectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.Config.FlushInterval != DefaultFlushInterval || scenario.Config.ClientConfig == nil {
+				t.Errorf("expected default values to be set, got %#v", scenario.Config)
+			}
+		})
+	}
+}
+
+// recordRequests injects an HTTP client recording the body and headers of every request it receives
+func recordRequests(t *testing.T) func() ([]http.Header, [][]byte) {
+	var mutex sync.Mutex
+	var headers []http.Header
+	var bodies [][]byte
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		mutex.Lock()
+		defer mutex.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		headers, bodies = append(headers, r.Header), append(bodies, body)
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}
+	})})
+	t.Cleanup(func() { client.InjectHTTPClient(nil) })
+	return func() ([]http.Header, [][]byte) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return headers, bodies
+	}
+}
+
+// publish runs the configuration, publishes a result and stops the configuration so that the samples are pushed
+func publish(t *testing.T, cfg *Config, timestamp time.Time) {
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	ep := &endpoint.Endpoint{Name: "dns", Group: "core", URL: "8.8.8.8", DNSConfig: &dns.Config{QueryType: "A", QueryName: "example.com"}}
+	// Nothing is buffered until the configuration is running
+	PublishResult(ep, &endpoint.Result{Success: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() {
+		cfg.Run(ctx)
+		done <- true
+	}()
+	for getActiveConfig() != cfg {
+		time.Sleep(time.Millisecond)
+	}
+	PublishResult(ep, &endpoint.Result{Success: true, Duration: 250 * time.Millisecond, DNSRCode: "NOERROR", Timestamp: timestamp})
+	cancel()
+	<-done
+}
+
+func TestConfig_RunWithPrometheusRemoteWrite(t *testing.T) {
+	getRequests := recordRequests(t)
+	timestamp := time.UnixMilli(1700000000000)
+	publish(t, &Config{Type: TypePrometheusRemoteWrite, URL: "https://prometheus.example.com/api/v1/write", Headers: map[string]string{"Authorization": "Bearer token"}}, timestamp)
+	headers, bodies := getRequests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(bodies))
+	}
+	if headers[0].Get("Content-Encoding") != "snappy" || headers[0].Get("Content-Type") != "application/x-protobuf" || headers[0].Get("Authorization") != "Bearer token" {
+		t.Errorf("unexpected headers %v", headers[0])
+	}
+	writeRequest, err := snappy.Decode(nil, bodies[0])
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	timeSeries := decodeRemoteWriteRequest(t, writeRequest)
+	if len(timeSeries) != 3 {
+		t.Fatalf("expected 3 time series, got %d", len(timeSeries))
+	}
+	expectedLabels := map[string]string{"__name__": "gatus_results_duration_seconds", "group": "core", "key": "core_dns", "name": "dns", "type": "DNS"}
+	for name, value := range expectedLabels {
+		if timeSeries[1].labels[name] != value {
+			t.Errorf("expected label %s to be %s, got %s", name, value, timeSeries[1].labels[name])
+		}
+	}
+	if timeSeries[1].value != 0.25 || timeSeries[1].timestamp != timestamp.UnixMilli() {
+		t.Errorf("expected a value of 0.25 at %d, got %v at %d", timestamp.UnixMilli(), timeSeries[1].value, timeSeries[1].timestamp)
+	}
+	if timeSeries[0].labels["__name__"] != "gatus_results_success" || timeSeries[0].value != 1 {
+		t.Errorf("expected gatus_results_success to be 1, got %#v", timeSeries[0])
+	}
+	if timeSeries[2].labels["__name__"] != "gatus_results_dns_rcode" || timeSeries[2].labels["rcode"] != "NOERROR" {
+		t.Errorf("expected gatus_results_dns_rcode with rcode NOERROR, got %#v", timeSeries[2])
+	}
+}
+
+func TestConfig_RunWithOTLP(t *testing.T) {
+	getRequests := recordRequests(t)
+	timestamp := time.Unix(1700000000, 0)
+	publish(t, &Config{Type: TypeOTLP, URL: "http://collector:4318/v1/metrics"}, timestamp)
+	headers, bodies := getRequests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(bodies))
+	}
+	if headers[0].Get("Content-Type") != "application/json" {
+		t.Errorf("expected content type application/json, got %s", headers[0].Get("Content-Type"))
+	}
+	var request otlpMetricsRequest
+	if err := json.Unmarshal(bodies[0], &request); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	metrics := request.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	if len(metrics) != 3 {
+		t.Fatalf("expected 3 metrics, got %d", len(metrics))
+	}
+	dataPoint := metrics[1].Gauge.DataPoints[0]
+	if metrics[1].Name != "gatus_results_duration_seconds" || dataPoint.AsDouble != 0.25 || dataPoint.TimeUnixNano != "1700000000000000000" {
+		t.Errorf("unexpected metric %s with data point %#v", metrics[1].Name, dataPoint)
+	}
+	if len(dataPoint.Attributes) != 4 || dataPoint.Attributes[1].Key != "key" || dataPoint.Attributes[1].Value.StringValue != "core_dns" {
+		t.Errorf("unexpected attributes %#v", dataPoint.Attributes)
+	}
+}
+
+type decodedTimeSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeRemoteWriteRequest decodes the time series of a WriteRequest, which contain a single sample each
+func decodeRemoteWriteRequest(t *testing.T, writeRequest []byte) []*decodedTimeSeries {
+	var timeSeries []*decodedTimeSeries
+	for _, encodedTimeSeries := range decodeMessage(t, writeRequest)[1] {
+		fields := decodeMessage(t, encodedTimeSeries)
+		decoded := &decodedTimeSeries{labels: make(map[string]string)}
+		for _, encodedLabel := range fields[1] {
+			labelFields := decodeMessage(t, encodedLabel)
+			decoded.labels[string(labelFields[1][0])] = string(labelFields[2][0])
+		}
+		sampleFields := decodeMessage(t, fields[2][0])
+		value, _ := protowire.ConsumeFixed64(sampleFields[1][0])
+		timestamp, _ := protowire.ConsumeVarint(sampleFields[2][0])
+		decoded.value, decoded.timestamp = math.Float64frombits(value), int64(timestamp)
+		timeSeries = append(timeSeries, decoded)
+	}
+	return timeSeries
+}
+
+// decodeMessage returns the raw values of each field of a protobuf message, by field number. The values of
+// length-delimited fields are returned without their length.
+func decodeMessage(t *testing.T, message []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(message) > 0 {
+		number, fieldType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			t.Fatal("invalid tag")
+		}
+		message = message[n:]
+		n = protowire.ConsumeFieldValue(number, fieldType, message)
+		if n < 0 {
+			t.Fatal("invalid field value")
+		}
+		value := message[:n]
+		if fieldType == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		fields[number] = append(fields[number], value)
+		message = message[n:]
+	}
+	return fields
+}
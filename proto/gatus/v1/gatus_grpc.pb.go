@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v4.25.3
+// source: gatus/v1/gatus.proto
+
+package gatusv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	GatusService_GetEndpointStatuses_FullMethodName        = "/gatus.v1.GatusService/GetEndpointStatuses"
+	GatusService_GetEndpointStatus_FullMethodName          = "/gatus.v1.GatusService/GetEndpointStatus"
+	GatusService_PushExternalEndpointResult_FullMethodName = "/gatus.v1.GatusService/PushExternalEndpointResult"
+	GatusService_GetSilences_FullMethodName                = "/gatus.v1.GatusService/GetSilences"
+	GatusService_SilenceEndpointAlerts_FullMethodName      = "/gatus.v1.GatusService/SilenceEndpointAlerts"
+	GatusService_UnsilenceEndpointAlerts_FullMethodName    = "/gatus.v1.GatusService/UnsilenceEndpointAlerts"
+)
+
+// GatusServiceClient is the client API for GatusService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GatusService exposes the status of monitored endpoints and allows pushing the results of external endpoints.
+//
+// If basic authentication is configured, status queries require an "authorization" metadata entry with the
+// same credentials as the REST API (e.g. "Basic am9obi5kb2U6aHVudGVyMg==").
+// Silences require the same credentials as status queries.
+// Pushing external endpoint results requires an "authorization" metadata entry with the bearer token of the
+// external endpoint (e.g. "Bearer <token>").
+type GatusServiceClient interface {
+	// GetEndpointStatuses returns the status of all endpoints
+	GetEndpointStatuses(ctx context.Context, in *GetEndpointStatusesRequest, opts ...grpc.CallOption) (*GetEndpointStatusesResponse, error)
+	// GetEndpointStatus returns the status of a single endpoint by its key
+	GetEndpointStatus(ctx context.Context, in *GetEndpointStatusRequest, opts ...grpc.CallOption) (*GetEndpointStatusResponse, error)
+	// PushExternalEndpointResult pushes the result of an external endpoint
+	PushExternalEndpointResult(ctx context.Context, in *PushExternalEndpointResultRequest, opts ...grpc.CallOption) (*PushExternalEndpointResultResponse, error)
+	// GetSilences returns the silences that haven't expired yet
+	GetSilences(ctx context.Context, in *GetSilencesRequest, opts ...grpc.CallOption) (*GetSilencesResponse, error)
+	// SilenceEndpointAlerts prevents the alerts of an endpoint from being sent for a duration, replacing the existing
+	// silence of the endpoint if there's one
+	SilenceEndpointAlerts(ctx context.Context, in *SilenceEndpointAlertsRequest, opts ...grpc.CallOption) (*SilenceEndpointAlertsResponse, error)
+	// UnsilenceEndpointAlerts removes the silence of an endpoint
+	UnsilenceEndpointAlerts(ctx context.Context, in *UnsilenceEndpointAlertsRequest, opts ...grpc.CallOption) (*UnsilenceEndpointAlertsResponse, error)
+}
+
+type gatusServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGatusServiceClient(cc grpc.ClientConnInterface) GatusServiceClient {
+	return &gatusServiceClient{cc}
+}
+
+func (c *gatusServiceClient) GetEndpointStatuses(ctx context.Context, in *GetEndpointStatusesRequest, opts ...grpc.CallOption) (*GetEndpointStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEndpointStatusesResponse)
+	err := c.cc.Invoke(ctx, GatusService_GetEndpointStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatusServiceClient) GetEndpointStatus(ctx context.Context, in *GetEndpointStatusRequest, opts ...grpc.CallOption) (*GetEndpointStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEndpointStatusResponse)
+	err := c.cc.Invoke(ctx, GatusService_GetEndpointStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatusServiceClient) PushExternalEndpointResult(ctx context.Context, in *PushExternalEndpointResultRequest, opts ...grpc.CallOption) (*PushExternalEndpointResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushExternalEndpointResultResponse)
+	err := c.cc.Invoke(ctx, GatusService_PushExternalEndpointResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatusServiceClient) GetSilences(ctx context.Context, in *GetSilencesRequest, opts ...grpc.CallOption) (*GetSilencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSilencesResponse)
+	err := c.cc.Invoke(ctx, GatusService_GetSilences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatusServiceClient) SilenceEndpointAlerts(ctx context.Context, in *SilenceEndpointAlertsRequest, opts ...grpc.CallOption) (*SilenceEndpointAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SilenceEndpointAlertsResponse)
+	err := c.cc.Invoke(ctx, GatusService_SilenceEndpointAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatusServiceClient) UnsilenceEndpointAlerts(ctx context.Context, in *UnsilenceEndpointAlertsRequest, opts ...grpc.CallOption) (*UnsilenceEndpointAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnsilenceEndpointAlertsResponse)
+	err := c.cc.Invoke(ctx, GatusService_UnsilenceEndpointAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GatusServiceServer is the server API for GatusService service.
+// All implementations must embed UnimplementedGatusServiceServer
+// for forward compatibility
+//
+// GatusService exposes the status of monitored endpoints and allows pushing the results of external endpoints.
+//
+// If basic authentication is configured, status queries require an "authorization" metadata entry with the
+// same credentials as the REST API (e.g. "Basic am9obi5kb2U6aHVudGVyMg==").
+// Silences require the same credentials as status queries.
+// Pushing external endpoint results requires an "authorization" metadata entry with the bearer token of the
+// external endpoint (e.g. "Bearer <token>").
+type GatusServiceServer interface {
+	// GetEndpointStatuses returns the status of all endpoints
+	GetEndpointStatuses(context.Context, *GetEndpointStatusesRequest) (*GetEndpointStatusesResponse, error)
+	// GetEndpointStatus returns the status of a single endpoint by its key
+	GetEndpointStatus(context.Context, *GetEndpointStatusRequest) (*GetEndpointStatusResponse, error)
+	// PushExternalEndpointResult pushes the result of an external endpoint
+	PushExternalEndpointResult(context.Context, *PushExternalEndpointResultRequest) (*PushExternalEndpointResultResponse, error)
+	// GetSilences returns the silences that haven't expired yet
+	GetSilences(context.Context, *GetSilencesRequest) (*GetSilencesResponse, error)
+	// SilenceEndpointAlerts prevents the alerts of an endpoint from being sent for a duration, replacing the existing
+	// silence of the endpoint if there's one
+	SilenceEndpointAlerts(context.Context, *SilenceEndpointAlertsRequest) (*SilenceEndpointAlertsResponse, error)
+	// UnsilenceEndpointAlerts removes the silence of an endpoint
+	UnsilenceEndpointAlerts(context.Context, *UnsilenceEndpointAlertsRequest) (*UnsilenceEndpointAlertsResponse, error)
+	mustEmbedUnimplementedGatusServiceServer()
+}
+
+// UnimplementedGatusServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGatusServiceServer struct {
+}
+
+func (UnimplementedGatusServiceServer) GetEndpointStatuses(context.Context, *GetEndpointStatusesRequest) (*GetEndpointStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEndpointStatuses not implemented")
+}
+func (UnimplementedGatusServiceServer) GetEndpointStatus(context.Context, *GetEndpointStatusRequest) (*GetEndpointStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEndpointStatus not implemented")
+}
+func (UnimplementedGatusServiceServer) PushExternalEndpointResult(context.Context, *PushExternalEndpointResultRequest) (*PushExternalEndpointResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushExternalEndpointResult not implemented")
+}
+func (UnimplementedGatusServiceServer) GetSilences(context.Context, *GetSilencesRequest) (*GetSilencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSilences not implemented")
+}
+func (UnimplementedGatusServiceServer) SilenceEndpointAlerts(context.Context, *SilenceEndpointAlertsRequest) (*SilenceEndpointAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SilenceEndpointAlerts not implemented")
+}
+func (UnimplementedGatusServiceServer) UnsilenceEndpointAlerts(context.Context, *UnsilenceEndpointAlertsRequest) (*UnsilenceEndpointAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsilenceEndpointAlerts not implemented")
+}
+func (UnimplementedGatusServiceServer) mustEmbedUnimplementedGatusServiceServer() {}
+
+// UnsafeGatusServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GatusServiceServer will
+// result in compilation errors.
+type UnsafeGatusServiceServer interface {
+	mustEmbedUnimplementedGatusServiceServer()
+}
+
+func RegisterGatusServiceServer(s grpc.ServiceRegistrar, srv GatusServiceServer) {
+	s.RegisterService(&GatusService_ServiceDesc, srv)
+}
+
+func _GatusService_GetEndpointStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEndpointStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatusServiceServer).GetEndpointStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatusService_GetEndpointStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatusServiceServer).GetEndpointStatuses(ctx, req.(*GetEndpointStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatusService_GetEndpointStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEndpointStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatusServiceServer).GetEndpointStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatusService_GetEndpointStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatusServiceServer).GetEndpointStatus(ctx, req.(*GetEndpointStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatusService_PushExternalEndpointResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushExternalEndpointResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatusServiceServer).PushExternalEndpointResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatusService_PushExternalEndpointResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatusServiceServer).PushExternalEndpointResult(ctx, req.(*PushExternalEndpointResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatusService_GetSilences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSilencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatusServiceServer).GetSilences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatusService_GetSilences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatusServiceServer).GetSilences(ctx, req.(*GetSilencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatusService_SilenceEndpointAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SilenceEndpointAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatusServiceServer).SilenceEndpointAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatusService_SilenceEndpointAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatusServiceServer).SilenceEndpointAlerts(ctx, req.(*SilenceEndpointAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatusService_UnsilenceEndpointAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsilenceEndpointAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatusServiceServer).UnsilenceEndpointAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatusService_UnsilenceEndpointAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatusServiceServer).UnsilenceEndpointAlerts(ctx, req.(*UnsilenceEndpointAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GatusService_ServiceDesc is the grpc.ServiceDesc for GatusService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GatusService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gatus.v1.GatusService",
+	HandlerType: (*GatusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEndpointStatuses",
+			Handler:    _GatusService_GetEndpointStatuses_Handler,
+		},
+		{
+			MethodName: "GetEndpointStatus",
+			Handler:    _GatusService_GetEndpointStatus_Handler,
+		},
+		{
+			MethodName: "PushExternalEndpointResult",
+			Handler:    _GatusService_PushExternalEndpointResult_Handler,
+		},
+		{
+			MethodName: "GetSilences",
+			Handler:    _GatusService_GetSilences_Handler,
+		},
+		{
+			MethodName: "SilenceEndpointAlerts",
+			Handler:    _GatusService_SilenceEndpointAlerts_Handler,
+		},
+		{
+			MethodName: "UnsilenceEndpointAlerts",
+			Handler:    _GatusService_UnsilenceEndpointAlerts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gatus/v1/gatus.proto",
+}
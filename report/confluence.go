@@ -0,0 +1,157 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const DefaultConfluenceTemplate = `<p>Availability of the endpoints from {{ .From.Format "2006-01-02" }} to {{ .To.Format "2006-01-02" }}, last updated on {{ .GeneratedAt.Format "2006-01-02 15:04 MST" }}.</p>
+<table><tbody>
+<tr><th>Group</th><th>Endpoint</th><th>Uptime</th><th>Incidents</th><th>Average response time</th></tr>
+{{- range .Endpoints }}
+<tr><td>{{ .Group }}</td><td>{{ .Name }}</td>{{ if .HasData }}<td>{{ percentage .Uptime }}</td><td>{{ .Incidents }}</td><td>{{ .AverageResponseTime }}ms</td>{{ else }}<td colspan="3">No data</td>{{ end }}</tr>
+{{- end }}
+</tbody></table>`
+
+// ErrConfluenceWithMissingFields is the error with which Gatus will panic if a field required to publish a report
+// to Confluence is missing
+var ErrConfluenceWithMissingFields = errors.New("url, token and space-key are required")
+
+// ConfluenceConfig is the configuration for publishing a report to Confluence
+type ConfluenceConfig struct {
+	// URL of the Confluence instance (e.g. https://example.atlassian.net/wiki)
+	URL string `yaml:"url"`
+
+	// Username with which to authenticate along with the API token, for Confluence Cloud.
+	// If not set, the token is used as a personal access token, for Confluence Data Center.
+	Username string `yaml:"username,omitempty"`
+
+	// Token is the API token or the personal access token with which to authenticate
+	Token string `yaml:"token"`
+
+	// SpaceKey is the key of the space in which the pages are published
+	SpaceKey string `yaml:"space-key"`
+
+	// ParentPageID is the ID of the page under which new pages are created
+	ParentPageID string `yaml:"parent-page-id,omitempty"`
+
+	// Template of the content of each page, in the storage format of Confluence (XHTML)
+	Template string `yaml:"template,omitempty"`
+
+	contentTemplate templateRenderer
+}
+
+// confluencePage is a page as represented by the content API of Confluence
+type confluencePage struct {
+	ID        string              `json:"id,omitempty"`
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Space     map[string]string   `json:"space"`
+	Ancestors []map[string]string `json:"ancestors,omitempty"`
+	Version   *confluenceVersion  `json:"version,omitempty"`
+	Body      *confluenceBody     `json:"body,omitempty"`
+}
+
+type confluenceVersion struct {
+	Number int `json:"number"`
+}
+
+type confluenceBody struct {
+	Storage confluenceStorage `json:"storage"`
+}
+
+type confluenceStorage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+// ValidateAndSetDefaults validates the Confluence configuration and sets the default values if necessary
+func (c *ConfluenceConfig) ValidateAndSetDefaults() error {
+	if len(c.URL) == 0 || len(c.Token) == 0 || len(c.SpaceKey) == 0 {
+		return ErrConfluenceWithMissingFields
+	}
+	c.URL = strings.TrimSuffix(c.URL, "/")
+	if len(c.Template) == 0 {
+		c.Template = DefaultConfluenceTemplate
+	}
+	var err error
+	if c.contentTemplate, err = parseContentTemplate(c.Template, true); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
+
+// publish creates the page with the title passed, or updates it if it already exists
+// Reference doc: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-content/
+func (c *ConfluenceConfig) publish(title string, data *Data) error {
+	content, err := render(c.contentTemplate, data)
+	if err != nil {
+		return err
+	}
+	existingPage, err := c.getPage(title)
+	if err != nil {
+		return err
+	}
+	page := &confluencePage{
+		Type:  "page",
+		Title: title,
+		Space: map[string]string{"key": c.SpaceKey},
+		Body:  &confluenceBody{Storage: confluenceStorage{Value: content, Representation: "storage"}},
+	}
+	if existingPage == nil {
+		if len(c.ParentPageID) > 0 {
+			page.Ancestors = []map[string]string{{"id": c.ParentPageID}}
+		}
+		return c.send(http.MethodPost, c.URL+"/rest/api/content", page, nil)
+	}
+	page.ID = existingPage.ID
+	page.Version = &confluenceVersion{Number: existingPage.Version.Number + 1}
+	return c.send(http.MethodPut, c.URL+"/rest/api/content/"+url.PathEscape(existingPage.ID), page, nil)
+}
+
+// getPage returns the page of the space with the title passed, or nil if there is none
+func (c *ConfluenceConfig) getPage(title string) (*confluencePage, error) {
+	query := url.Values{"spaceKey": {c.SpaceKey}, "title": {title}, "type": {"page"}, "expand": {"version"}}
+	var response struct {
+		Results []*confluencePage `json:"results"`
+	}
+	if err := c.send(http.MethodGet, c.URL+"/rest/api/content?"+query.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+	for _, page := range response.Results {
+		if page.Version != nil {
+			return page, nil
+		}
+	}
+	return nil, nil
+}
+
+// send sends a request to the API of Confluence and decodes the response into the value passed, if any
+func (c *ConfluenceConfig) send(method, requestURL string, body, response any) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyAsJSON, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewBuffer(bodyAsJSON)
+	}
+	request, err := http.NewRequest(method, requestURL, requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+	if len(c.Username) > 0 {
+		request.SetBasicAuth(c.Username, c.Token)
+	} else {
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return sendRequest(request, response)
+}
@@ -0,0 +1,215 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	DefaultNotionTemplate = `Availability of the endpoints from {{ .From.Format "2006-01-02" }} to {{ .To.Format "2006-01-02" }}, last updated on {{ .GeneratedAt.Format "2006-01-02 15:04 MST" }}.
+{{ range .Endpoints }}
+- {{ .Key }}: {{ if .HasData }}{{ percentage .Uptime }} uptime, {{ .Incidents }} incidents, {{ .AverageResponseTime }}ms average response time{{ else }}no data{{ end }}
+{{- end }}`
+
+	notionAPIURL  = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+
+	// notionMaximumBlocksPerRequest is the maximum number of blocks that can be appended to a page in a single request
+	notionMaximumBlocksPerRequest = 100
+
+	// notionMaximumTextLength is the maximum length of the content of a rich text object
+	notionMaximumTextLength = 2000
+)
+
+// ErrNotionWithMissingFields is the error with which Gatus will panic if a field required to publish a report to
+// Notion is missing
+var ErrNotionWithMissingFields = errors.New("token and parent-page-id are required")
+
+// NotionConfig is the configuration for publishing a report to Notion
+type NotionConfig struct {
+	// Token is the secret of the internal integration with which to authenticate, which must have access to the
+	// parent page
+	Token string `yaml:"token"`
+
+	// ParentPageID is the ID of the page under which the pages are created
+	ParentPageID string `yaml:"parent-page-id"`
+
+	// Template of the content of each page. Each line is converted to a block: lines starting with "# " and "## "
+	// are converted to headings, lines starting with "- " to bulleted list items and other lines to paragraphs.
+	Template string `yaml:"template,omitempty"`
+
+	contentTemplate templateRenderer
+}
+
+type notionBlock map[string]any
+
+// ValidateAndSetDefaults validates the Notion configuration and sets the default values if necessary
+func (c *NotionConfig) ValidateAndSetDefaults() error {
+	if len(c.Token) == 0 || len(c.ParentPageID) == 0 {
+		return ErrNotionWithMissingFields
+	}
+	if len(c.Template) == 0 {
+		c.Template = DefaultNotionTemplate
+	}
+	var err error
+	if c.contentTemplate, err = parseContentTemplate(c.Template, false); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
+
+// publish creates the page with the title passed under the parent page, or replaces its content if it already exists
+// Reference doc: https://developers.notion.com/reference/intro
+func (c *NotionConfig) publish(title string, data *Data) error {
+	content, err := render(c.contentTemplate, data)
+	if err != nil {
+		return err
+	}
+	blocks := toNotionBlocks(content)
+	pageID, err := c.getPageID(title)
+	if err != nil {
+		return err
+	}
+	if len(pageID) == 0 {
+		var page struct {
+			ID string `json:"id"`
+		}
+		err = c.send(http.MethodPost, "/pages", map[string]any{
+			"parent":     map[string]string{"page_id": c.ParentPageID},
+			"properties": map[string]any{"title": map[string]any{"title": toNotionRichText(title)}},
+		}, &page)
+		if err != nil {
+			return err
+		}
+		pageID = page.ID
+	} else if err = c.deleteChildren(pageID); err != nil {
+		return err
+	}
+	for start := 0; start < len(blocks); start += notionMaximumBlocksPerRequest {
+		end := min(start+notionMaximumBlocksPerRequest, len(blocks))
+		if err = c.send(http.MethodPatch, "/blocks/"+pageID+"/children", map[string]any{"children": blocks[start:end]}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPageID returns the ID of the child page of the parent page with the title passed, or an empty string if there
+// is none
+func (c *NotionConfig) getPageID(title string) (string, error) {
+	var response struct {
+		Results []struct {
+			ID       string `json:"id"`
+			Archived bool   `json:"archived"`
+			Parent   struct {
+				PageID string `json:"page_id"`
+			} `json:"parent"`
+			Properties struct {
+				Title struct {
+					Title []struct {
+						PlainText string `json:"plain_text"`
+					} `json:"title"`
+				} `json:"title"`
+			} `json:"properties"`
+		} `json:"results"`
+	}
+	err := c.send(http.MethodPost, "/search", map[string]any{
+		"query":  title,
+		"filter": map[string]string{"property": "object", "value": "page"},
+	}, &response)
+	if err != nil {
+		return "", err
+	}
+	for _, page := range response.Results {
+		var pageTitle string
+		for _, text := range page.Properties.Title.Title {
+			pageTitle += text.PlainText
+		}
+		// IDs are returned with dashes, but may be configured without them
+		if !page.Archived && pageTitle == title && strings.ReplaceAll(page.Parent.PageID, "-", "") == strings.ReplaceAll(c.ParentPageID, "-", "") {
+			return page.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// deleteChildren deletes every block of a page
+func (c *NotionConfig) deleteChildren(pageID string) error {
+	var children struct {
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+		HasMore bool `json:"has_more"`
+	}
+	for {
+		if err := c.send(http.MethodGet, fmt.Sprintf("/blocks/%s/children?page_size=%d", pageID, notionMaximumBlocksPerRequest), nil, &children); err != nil {
+			return err
+		}
+		for _, child := range children.Results {
+			if err := c.send(http.MethodDelete, "/blocks/"+child.ID, nil, nil); err != nil {
+				return err
+			}
+		}
+		// Since the blocks are deleted, the next blocks are always in the first page of results
+		if !children.HasMore || len(children.Results) == 0 {
+			return nil
+		}
+	}
+}
+
+// send sends a request to the API of Notion and decodes the response into the value passed, if any
+func (c *NotionConfig) send(method, path string, body, response any) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyAsJSON, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewBuffer(bodyAsJSON)
+	}
+	request, err := http.NewRequest(method, notionAPIURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+c.Token)
+	request.Header.Set("Notion-Version", notionVersion)
+	return sendRequest(request, response)
+}
+
+// toNotionBlocks converts each non-empty line of the content passed to a block
+func toNotionBlocks(content string) []notionBlock {
+	var blocks []notionBlock
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		blockType := "paragraph"
+		if text, found := strings.CutPrefix(line, "# "); found {
+			blockType, line = "heading_1", text
+		} else if text, found = strings.CutPrefix(line, "## "); found {
+			blockType, line = "heading_2", text
+		} else if text, found = strings.CutPrefix(line, "- "); found {
+			blockType, line = "bulleted_list_item", text
+		}
+		blocks = append(blocks, notionBlock{
+			"object":  "block",
+			"type":    blockType,
+			blockType: map[string]any{"rich_text": toNotionRichText(line)},
+		})
+	}
+	return blocks
+}
+
+func toNotionRichText(text string) []map[string]any {
+	if runes := []rune(text); len(runes) > notionMaximumTextLength {
+		text = string(runes[:notionMaximumTextLength])
+	}
+	return []map[string]any{{"type": "text", "text": map[string]string{"content": text}}}
+}
@@ -0,0 +1,339 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+const (
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+
+	DefaultPeriod   = PeriodMonthly
+	DefaultInterval = time.Hour
+	DefaultTitle    = `{{ .Name }} ({{ .From.Format "2006-01-02" }} to {{ .To.Format "2006-01-02" }})`
+
+	// minimumInterval is the minimum interval at which a report can be published, which prevents reaching the rate
+	// limits of the APIs of Confluence and Notion
+	minimumInterval = time.Minute
+)
+
+var (
+	// ErrReportWithNoName is the error with which Gatus will panic if a report has no name
+	ErrReportWithNoName = errors.New("report must have a name")
+
+	// ErrReportWithInvalidPeriod is the error with which Gatus will panic if the period of a report isn't supported
+	ErrReportWithInvalidPeriod = errors.New("report period must be one of " + PeriodDaily + ", " + PeriodWeekly + " or " + PeriodMonthly)
+
+	// ErrReportWithInvalidInterval is the error with which Gatus will panic if the interval of a report is too low
+	ErrReportWithInvalidInterval = errors.New("report interval must be 1m or higher")
+
+	// ErrReportWithNoTarget is the error with which Gatus will panic if a report is configured to be published nowhere
+	ErrReportWithNoTarget = errors.New("report must be published to confluence, notion or both")
+
+	// templateFuncs are the functions available in the templates of reports
+	templateFuncs = map[string]any{
+		// percentage formats an uptime, which is between 0 and 1, as a percentage (e.g. 99.95%)
+		"percentage": func(uptime float64) string {
+			return strconv.FormatFloat(uptime*100, 'f', 2, 64) + "%"
+		},
+	}
+)
+
+// Config is the configuration of a report on the availability of the endpoints during a period, which is rendered
+// from a template and published to Confluence and/or Notion at a regular interval.
+//
+// Each period has its own page, which is updated until the period is over, leaving a written record of every period.
+type Config struct {
+	// Name of the report, which is available in the templates
+	Name string `yaml:"name"`
+
+	// Period covered by each page of the report, which is a calendar day, week (starting on Monday) or month
+	Period string `yaml:"period,omitempty"`
+
+	// Interval at which the page of the current period is published
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Title is the template of the title of each page, which identifies the page to update
+	Title string `yaml:"title,omitempty"`
+
+	// Confluence is the configuration for publishing the report to Confluence
+	Confluence *ConfluenceConfig `yaml:"confluence,omitempty"`
+
+	// Notion is the configuration for publishing the report to Notion
+	Notion *NotionConfig `yaml:"notion,omitempty"`
+
+	titleTemplate *template.Template
+
+	// lastPublishedFrom is the start of the last period published, which is used to publish the page of a period one
+	// last time once the period is over
+	//
+	// Since it isn't persisted, the previous period is published one last time on startup if there's data for it, in
+	// case Gatus was stopped before it could do so.
+	lastPublishedFrom time.Time
+}
+
+// Data is the data with which the templates of a report are rendered
+type Data struct {
+	// Name of the report
+	Name string
+
+	// From is the start of the period
+	From time.Time
+
+	// To is the end of the period
+	To time.Time
+
+	// GeneratedAt is the time at which the report was generated
+	GeneratedAt time.Time
+
+	// Endpoints is the availability of each endpoint during the period
+	Endpoints []*EndpointData
+}
+
+// EndpointData is the availability of an endpoint during the period of a report
+type EndpointData struct {
+	Key   string
+	Group string
+	Name  string
+
+	// HasData is whether the availability of the endpoint during the period could be retrieved and whether the
+	// endpoint was checked at least once during the period. If false, Uptime, Incidents and AverageResponseTime are 0.
+	HasData bool
+
+	// Uptime is the uptime of the endpoint during the period, between 0 and 1
+	Uptime float64
+
+	// Incidents is the number of times the endpoint went from healthy to unhealthy during the period
+	Incidents uint64
+
+	// AverageResponseTime is the average response time of the endpoint during the period, in milliseconds
+	AverageResponseTime int
+}
+
+// ValidateAndSetDefaults validates the report and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Name) == 0 {
+		return ErrReportWithNoName
+	}
+	if len(c.Period) == 0 {
+		c.Period = DefaultPeriod
+	} else if c.Period != PeriodDaily && c.Period != PeriodWeekly && c.Period != PeriodMonthly {
+		return ErrReportWithInvalidPeriod
+	}
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	} else if c.Interval < minimumInterval {
+		return ErrReportWithInvalidInterval
+	}
+	if len(c.Title) == 0 {
+		c.Title = DefaultTitle
+	}
+	var err error
+	if c.titleTemplate, err = template.New("title").Funcs(templateFuncs).Parse(c.Title); err != nil {
+		return fmt.Errorf("invalid title of report %s: %w", c.Name, err)
+	}
+	if c.Confluence == nil && c.Notion == nil {
+		return ErrReportWithNoTarget
+	}
+	if c.Confluence != nil {
+		if err = c.Confluence.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid confluence configuration of report %s: %w", c.Name, err)
+		}
+	}
+	if c.Notion != nil {
+		if err = c.Notion.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid notion configuration of report %s: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Run publishes the report of the current period right away and then at every interval, until the context is canceled
+func (c *Config) Run(ctx context.Context, endpoints []*endpoint.Endpoint) {
+	c.publish(endpoints, time.Now())
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.publish(endpoints, now)
+		}
+	}
+}
+
+// publish publishes the page of the period containing now. If the previous period published is over, its page is
+// published one last time first, so that it covers the entire period.
+//
+// On the first publication, the page of the period preceding the current one is also published one last time if any
+// endpoint has data for it, since Gatus may have been stopped before the end of that period.
+func (c *Config) publish(endpoints []*endpoint.Endpoint, now time.Time) {
+	from, to := c.getPeriod(now)
+	if c.lastPublishedFrom.IsZero() {
+		previousFrom, previousTo := c.getPeriod(from.Add(-time.Nanosecond))
+		if data := c.buildData(endpoints, previousFrom, previousTo, now); data.hasData() {
+			if err := c.publishData(data); err != nil {
+				log.Printf("[report.publish] Failed to publish the final version of report %s: %s", c.Name, err.Error())
+			}
+		}
+	} else if c.lastPublishedFrom.Before(from) {
+		previousFrom, previousTo := c.getPeriod(c.lastPublishedFrom)
+		if err := c.publishData(c.buildData(endpoints, previousFrom, previousTo, now)); err != nil {
+			log.Printf("[report.publish] Failed to publish the final version of report %s: %s", c.Name, err.Error())
+		}
+	}
+	if err := c.publishData(c.buildData(endpoints, from, to, now)); err != nil {
+		log.Printf("[report.publish] Failed to publish report %s: %s", c.Name, err.Error())
+		return
+	}
+	c.lastPublishedFrom = from
+}
+
+// publishData publishes the page rendered from the data passed to every target of the report
+func (c *Config) publishData(data *Data) error {
+	title, err := render(c.titleTemplate, data)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	if c.Confluence != nil {
+		if err = c.Confluence.publish(title, data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish to confluence: %w", err))
+		}
+	}
+	if c.Notion != nil {
+		if err = c.Notion.publish(title, data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish to notion: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getPeriod returns the start and the end of the period containing the time passed
+func (c *Config) getPeriod(t time.Time) (time.Time, time.Time) {
+	day := endpoint.TruncateToDay(t)
+	var from, next time.Time
+	switch c.Period {
+	case PeriodDaily:
+		from, next = day, day.AddDate(0, 0, 1)
+	case PeriodWeekly:
+		// time.Sunday is 0, but weeks start on Monday
+		from = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		next = from.AddDate(0, 0, 7)
+	default:
+		from = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		next = from.AddDate(0, 1, 0)
+	}
+	return from, next.Add(-time.Nanosecond)
+}
+
+// buildData retrieves the availability of each endpoint from the beginning of the period until now, or until the end
+// of the period if it's over
+func (c *Config) buildData(endpoints []*endpoint.Endpoint, from, to, now time.Time) *Data {
+	data := &Data{Name: c.Name, From: from, To: to, GeneratedAt: now}
+	until := to
+	if now.Before(until) {
+		until = now
+	}
+	for _, ep := range endpoints {
+		data.Endpoints = append(data.Endpoints, buildEndpointData(ep, from, until))
+	}
+	return data
+}
+
+// buildEndpointData retrieves the availability of an endpoint during a time range.
+//
+// Endpoints without any result during the time range, or whose availability couldn't be retrieved, are still part of
+// the report, but without data.
+func buildEndpointData(ep *endpoint.Endpoint, from, until time.Time) *EndpointData {
+	key := ep.Key()
+	endpointData := &EndpointData{Key: key, Group: ep.Group, Name: ep.Name}
+	dailyUptimeStatistics, err := store.Get().GetDailyUptimeStatisticsByKey(key, from, until)
+	if err != nil {
+		return endpointData
+	}
+	var totalExecutions, incidents uint64
+	for _, statistics := range dailyUptimeStatistics {
+		totalExecutions += statistics.TotalExecutions
+		incidents += statistics.Incidents
+	}
+	if totalExecutions == 0 {
+		return endpointData
+	}
+	uptime, err := store.Get().GetUptimeByKey(key, from, until)
+	if err != nil {
+		return endpointData
+	}
+	averageResponseTime, err := store.Get().GetAverageResponseTimeByKey(key, from, until)
+	if err != nil {
+		return endpointData
+	}
+	endpointData.HasData = true
+	endpointData.Uptime, endpointData.Incidents, endpointData.AverageResponseTime = uptime, incidents, averageResponseTime
+	return endpointData
+}
+
+// hasData returns whether at least one endpoint has data
+func (d *Data) hasData() bool {
+	for _, endpointData := range d.Endpoints {
+		if endpointData.HasData {
+			return true
+		}
+	}
+	return false
+}
+
+// parseContentTemplate parses the template of the content of a page, which is escaped for HTML if html is true
+func parseContentTemplate(text string, html bool) (templateRenderer, error) {
+	if html {
+		return htmltemplate.New("content").Funcs(templateFuncs).Parse(text)
+	}
+	return template.New("content").Funcs(templateFuncs).Parse(text)
+}
+
+// templateRenderer is implemented by both text/template.Template and html/template.Template
+type templateRenderer interface {
+	Execute(wr io.Writer, data any) error
+}
+
+func render(t templateRenderer, data *Data) (string, error) {
+	var buffer bytes.Buffer
+	if err := t.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// sendRequest sends a request and decodes the response into the value passed, if any
+func sendRequest(request *http.Request, response any) error {
+	httpResponse, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(httpResponse.Body)
+		return fmt.Errorf("call to %s %s returned status code %d: %s", request.Method, request.URL.Path, httpResponse.StatusCode, string(responseBody))
+	}
+	if response != nil {
+		return json.NewDecoder(httpResponse.Body).Decode(response)
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	confluence := func() *ConfluenceConfig {
+		return &ConfluenceConfig{URL: "https://example.atlassian.net/wiki/", Username: "john.doe@example.org", Token: "token", SpaceKey: "OPS"}
+	}
+	scenarios := []struct {
+		Name          string
+		Config        *Config
+		ExpectedError error
+	}{
+		{
+			Name:   "confluence",
+			Config: &Config{Name: "availability", Confluence: confluence()},
+		},
+		{
+			Name:   "notion",
+			Config: &Config{Name: "availability", Period: PeriodWeekly, Notion: &NotionConfig{Token: "secret", ParentPageID: "page"}},
+		},
+		{
+			Name:          "no-name",
+			Config:        &Config{Confluence: confluence()},
+			ExpectedError: ErrReportWithNoName,
+		},
+		{
+			Name:          "invalid-period",
+			Config:        &Config{Name: "availability", Period: "yearly", Confluence: confluence()},
+			ExpectedError: ErrReportWithInvalidPeriod,
+		},
+		{
+			Name:          "invalid-interval",
+			Config:        &Config{Name: "availability", Interval: time.Second, Confluence: confluence()},
+			ExpectedError: ErrReportWithInvalidInterval,
+		},
+		{
+			Name:          "no-target",
+			Config:        &Config{Name: "availability"},
+			ExpectedError: ErrReportWithNoTarget,
+		},
+		{
+			Name:          "confluence-without-space-key",
+			Config:        &Config{Name: "availability", Confluence: &ConfluenceConfig{URL: "https://example.atlassian.net/wiki", Token: "token"}},
+			ExpectedError: ErrConfluenceWithMissingFields,
+		},
+		{
+			Name:          "notion-without-parent-page-id",
+			Config:        &Config{Name: "availability", Notion: &NotionConfig{Token: "secret"}},
+			ExpectedError: ErrNotionWithMissingFields,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := scenario.Config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.ExpectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(scenario.Config.Period) == 0 || scenario.Config.Interval != DefaultInterval || scenario.Config.Title != DefaultTitle {
+				t.Errorf("expected default values to be set, got %#v", scenario.Config)
+			}
+			if scenario.Config.Confluence != nil && strings.HasSuffix(scenario.Config.Confluence.URL, "/") {
+				t.Errorf("expected trailing slash of the url to be removed, got %s", scenario.Config.Confluence.URL)
+			}
+		})
+	}
+	if err := (&Config{Name: "availability", Title: "{{ .Name", Confluence: confluence()}).ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for an invalid title template")
+	}
+	if err := (&Config{Name: "availability", Notion: &NotionConfig{Token: "secret", ParentPageID: "page", Template: "{{ range }}"}}).ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for an invalid content template")
+	}
+}
+
+func TestConfig_getPeriod(t *testing.T) {
+	now := time.Date(2024, 3, 13, 15, 4, 5, 0, time.UTC) // Wednesday
+	scenarios := []struct {
+		Period       string
+		ExpectedFrom time.Time
+		ExpectedTo   time.Time
+	}{
+		{
+			Period:       PeriodDaily,
+			ExpectedFrom: time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC),
+			ExpectedTo:   time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			Period:       PeriodWeekly,
+			ExpectedFrom: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+			ExpectedTo:   time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			Period:       PeriodMonthly,
+			ExpectedFrom: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedTo:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Period, func(t *testing.T) {
+			from, to := (&Config{Period: scenario.Period}).getPeriod(now)
+			if !from.Equal(scenario.ExpectedFrom) || !to.Equal(scenario.ExpectedTo) {
+				t.Errorf("expected period from %s to %s, got from %s to %s", scenario.ExpectedFrom, scenario.ExpectedTo, from, to)
+			}
+		})
+	}
+	// Sundays are the last day of the week
+	if from, _ := (&Config{Period: PeriodWeekly}).getPeriod(time.Date(2024, 3, 17, 12, 0, 0, 0, time.UTC)); from.Day() != 11 {
+		t.Errorf("expected the week of a sunday to start on the previous monday, got %s", from)
+	}
+}
+
+// recordedRequest is a request received by the HTTP client injected by recordRequests, along with its body
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// recordRequests injects an HTTP client recording every request it receives and responding with the body returned
+// by respond
+func recordRequests(t *testing.T, respond func(r *http.Request) string) func() []recordedRequest {
+	var mutex sync.Mutex
+	var requests []recordedRequest
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		mutex.Lock()
+		defer mutex.Unlock()
+		var body []byte
+		if r.Body != nil {
+			body, _ = io.ReadAll(r.Body)
+		}
+		requests = append(requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Body: string(body)})
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(respond(r)))}
+	})})
+	t.Cleanup(func() { client.InjectHTTPClient(nil) })
+	return func() []recordedRequest {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+func TestConfig_publishToConfluence(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	now := time.Now()
+	_ = store.Get().Insert(ep, &endpoint.Result{Success: true, Duration: 100 * time.Millisecond, Timestamp: now})
+	_ = store.Get().Insert(ep, &endpoint.Result{Success: false, Duration: 300 * time.Millisecond, Timestamp: now})
+	existingPage := ""
+	getRequests := recordRequests(t, func(r *http.Request) string {
+		if r.Method == http.MethodGet {
+			return `{"results":[` + existingPage + `]}`
+		}
+		return `{}`
+	})
+	cfg := &Config{
+		Name:       "Availability",
+		Period:     PeriodDaily,
+		Confluence: &ConfluenceConfig{URL: "https://example.atlassian.net/wiki", Username: "john.doe@example.org", Token: "token", SpaceKey: "OPS", ParentPageID: "123"},
+	}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cfg.publish([]*endpoint.Endpoint{ep}, now)
+	requests := getRequests()
+	if len(requests) != 2 || requests[1].Method != http.MethodPost || requests[1].Path != "/wiki/rest/api/content" {
+		t.Fatalf("expected the page to be searched and then created, got %v", requests)
+	}
+	var page confluencePage
+	if err := json.Unmarshal([]byte(requests[1].Body), &page); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	expectedTitle := "Availability (" + now.Format("2006-01-02") + " to " + now.Format("2006-01-02") + ")"
+	if page.Title != expectedTitle {
+		t.Errorf("expected title %s, got %s", expectedTitle, page.Title)
+	}
+	if len(page.Ancestors) != 1 || page.Ancestors[0]["id"] != "123" {
+		t.Errorf("expected the page to be created under the parent page, got %v", page.Ancestors)
+	}
+	if !strings.Contains(page.Body.Storage.Value, "<td>frontend</td><td>50.00%</td><td>1</td><td>200ms</td>") {
+		t.Errorf("expected the availability of the endpoint to be in the page, got %s", page.Body.Storage.Value)
+	}
+	// If the page exists, it must be updated instead
+	existingPage = `{"id":"456","version":{"number":3}}`
+	cfg.publish([]*endpoint.Endpoint{ep}, now)
+	requests = getRequests()
+	if len(requests) != 4 || requests[3].Method != http.MethodPut || requests[3].Path != "/wiki/rest/api/content/456" {
+		t.Fatalf("expected the page to be searched and then updated, got %v", requests[2:])
+	}
+	if err := json.Unmarshal([]byte(requests[3].Body), &page); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if page.Version == nil || page.Version.Number != 4 {
+		t.Errorf("expected the version of the page to be incremented, got %v", page.Version)
+	}
+	// Once the period is over, the page of the previous period is published one last time before the new one
+	cfg.publish([]*endpoint.Endpoint{ep}, now.AddDate(0, 0, 1))
+	requests = getRequests()
+	if len(requests) != 8 {
+		t.Fatalf("expected the pages of both periods to be published, got %v", requests[4:])
+	}
+	if err := json.Unmarshal([]byte(requests[5].Body), &page); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if page.Title != expectedTitle {
+		t.Errorf("expected the page of the previous period to be published first, got %s", page.Title)
+	}
+}
+
+func TestConfig_publishToNotion(t *testing.T) {
+	existingPage := ""
+	getRequests := recordRequests(t, func(r *http.Request) string {
+		switch {
+		case r.URL.Path == "/v1/search":
+			return `{"results":[` + existingPage + `]}`
+		case r.URL.Path == "/v1/pages":
+			return `{"id":"new-page"}`
+		case r.Method == http.MethodGet:
+			return `{"results":[{"id":"block-1"},{"id":"block-2"}],"has_more":false}`
+		}
+		return `{}`
+	})
+	cfg := &Config{
+		Name:   "Availability",
+		Title:  "Report",
+		Notion: &NotionConfig{Token: "secret", ParentPageID: "11111111222233334444555555555555", Template: "# {{ .Name }}\n\n- first\nsecond"},
+	}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	cfg.publish(nil, time.Now())
+	requests := getRequests()
+	if len(requests) != 3 || requests[1].Path != "/v1/pages" || requests[2].Path != "/v1/blocks/new-page/children" {
+		t.Fatalf("expected the page to be searched, created and then filled, got %v", requests)
+	}
+	var children struct {
+		Children []map[string]any `json:"children"`
+	}
+	if err := json.Unmarshal([]byte(requests[2].Body), &children); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(children.Children) != 3 || children.Children[0]["type"] != "heading_1" || children.Children[1]["type"] != "bulleted_list_item" || children.Children[2]["type"] != "paragraph" {
+		t.Errorf("unexpected blocks: %s", requests[2].Body)
+	}
+	// If the page exists, its blocks must be replaced
+	existingPage = `{"id":"existing-page","parent":{"page_id":"11111111-2222-3333-4444-555555555555"},"properties":{"title":{"title":[{"plain_text":"Report"}]}}}`
+	cfg.publish(nil, time.Now())
+	requests = getRequests()[3:]
+	if len(requests) != 5 || requests[1].Path != "/v1/blocks/existing-page/children" || requests[2].Method != http.MethodDelete || requests[3].Path != "/v1/blocks/block-2" || requests[4].Method != http.MethodPatch {
+		t.Errorf("expected the blocks of the existing page to be replaced, got %v", requests)
+	}
+}
+
+func TestConfig_buildData(t *testing.T) {
+	defer store.Get().Clear()
+	checked := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	notChecked := &endpoint.Endpoint{Name: "backend", Group: "core"}
+	now := time.Now()
+	_ = store.Get().Insert(checked, &endpoint.Result{Success: true, Duration: 100 * time.Millisecond, Timestamp: now})
+	from, to := (&Config{Period: PeriodDaily}).getPeriod(now)
+	data := (&Config{Name: "Availability"}).buildData([]*endpoint.Endpoint{checked, notChecked}, from, to, now)
+	if len(data.Endpoints) != 2 {
+		t.Fatalf("expected both endpoints to be part of the report, got %d", len(data.Endpoints))
+	}
+	if !data.Endpoints[0].HasData || data.Endpoints[0].Uptime != 1 || data.Endpoints[0].AverageResponseTime != 100 {
+		t.Errorf("expected the availability of the endpoint checked, got %+v", data.Endpoints[0])
+	}
+	if data.Endpoints[1].HasData {
+		t.Errorf("expected the endpoint never checked to have no data, got %+v", data.Endpoints[1])
+	}
+	// The previous period has no data, since the endpoint was only checked during the current period
+	if previousData := (&Config{Name: "Availability"}).buildData([]*endpoint.Endpoint{checked}, from.AddDate(0, 0, -1), from.Add(-time.Nanosecond), now); previousData.hasData() {
+		t.Errorf("expected no data for the previous period, got %+v", previousData.Endpoints[0])
+	}
+}
+
+func TestConfig_publishAfterRestart(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core"}
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	_ = store.Get().Insert(ep, &endpoint.Result{Success: true, Duration: 100 * time.Millisecond, Timestamp: yesterday})
+	getRequests := recordRequests(t, func(r *http.Request) string {
+		if r.Method == http.MethodGet {
+			return `{"results":[]}`
+		}
+		return `{}`
+	})
+	cfg := &Config{
+		Name:       "Availability",
+		Period:     PeriodDaily,
+		Confluence: &ConfluenceConfig{URL: "https://example.atlassian.net/wiki", Username: "john.doe@example.org", Token: "token", SpaceKey: "OPS"},
+	}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	// Gatus was stopped before the page of yesterday could be published one last time
+	cfg.publish([]*endpoint.Endpoint{ep}, now)
+	requests := getRequests()
+	if len(requests) != 4 {
+		t.Fatalf("expected the pages of both periods to be published, got %v", requests)
+	}
+	var page confluencePage
+	if err := json.Unmarshal([]byte(requests[1].Body), &page); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !strings.Contains(page.Title, yesterday.Format("2006-01-02")) || !strings.Contains(page.Body.Storage.Value, "<td>100.00%</td>") {
+		t.Errorf("expected the page of the previous period to be published first, got %+v", page)
+	}
+	if err := json.Unmarshal([]byte(requests[3].Body), &page); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if !strings.Contains(page.Body.Storage.Value, `<td colspan="3">No data</td>`) {
+		t.Errorf("expected the endpoint to have no data for the current period, got %s", page.Body.Storage.Value)
+	}
+	// The previous period is only published again on startup
+	cfg.publish([]*endpoint.Endpoint{ep}, now)
+	if requests = getRequests(); len(requests) != 6 {
+		t.Errorf("expected only the page of the current period to be published, got %v", requests[4:])
+	}
+}
@@ -0,0 +1,147 @@
+package resultlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+const (
+	FieldTimestamp        = "timestamp"
+	FieldKey              = "key"
+	FieldGroup            = "group"
+	FieldName             = "name"
+	FieldType             = "type"
+	FieldHostname         = "hostname"
+	FieldIP               = "ip"
+	FieldSuccess          = "success"
+	FieldConnected        = "connected"
+	FieldStatus           = "status"
+	FieldResponseTime     = "responseTime"
+	FieldErrors           = "errors"
+	FieldConditionResults = "conditionResults"
+)
+
+var (
+	// DefaultFields are the fields of each line when no fields are configured
+	DefaultFields = []string{FieldTimestamp, FieldKey, FieldGroup, FieldName, FieldSuccess, FieldStatus, FieldResponseTime, FieldErrors}
+
+	// ErrUnknownField is the error with which Gatus will panic if one of the configured fields isn't supported
+	ErrUnknownField = errors.New("unknown result-log field")
+
+	activeConfig      *Config
+	activeConfigMutex sync.RWMutex
+)
+
+// Config is the configuration for writing every result as a single JSON line to stdout, which allows platforms relying
+// on log pipelines to derive metrics and alerts from the results
+type Config struct {
+	// Fields are the fields of each line, in the order in which they're written
+	Fields []string `yaml:"fields,omitempty"`
+
+	writer      io.Writer
+	writerMutex sync.Mutex
+}
+
+// ValidateAndSetDefaults validates the result log configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Fields) == 0 {
+		c.Fields = DefaultFields
+	}
+	for _, field := range c.Fields {
+		switch field {
+		case FieldTimestamp, FieldKey, FieldGroup, FieldName, FieldType, FieldHostname, FieldIP, FieldSuccess,
+			FieldConnected, FieldStatus, FieldResponseTime, FieldErrors, FieldConditionResults:
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+	}
+	if c.writer == nil {
+		c.writer = os.Stdout
+	}
+	return nil
+}
+
+// SetConfig sets the configuration used by PublishResult. If nil is passed, results are no longer written.
+func SetConfig(c *Config) {
+	activeConfigMutex.Lock()
+	defer activeConfigMutex.Unlock()
+	activeConfig = c
+}
+
+// PublishResult writes a result as a single JSON line, if configured
+func PublishResult(ep *endpoint.Endpoint, result *endpoint.Result) {
+	activeConfigMutex.RLock()
+	c := activeConfig
+	activeConfigMutex.RUnlock()
+	if c == nil {
+		return
+	}
+	c.write(ep, result)
+}
+
+func (c *Config) write(ep *endpoint.Endpoint, result *endpoint.Result) {
+	// The line is built field by field rather than from a map, so that the fields are written in the configured order
+	line := bytes.NewBufferString("{")
+	for i, field := range c.Fields {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		value, _ := json.Marshal(getFieldValue(field, ep, result))
+		line.WriteString(`"` + field + `":`)
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	c.writerMutex.Lock()
+	defer c.writerMutex.Unlock()
+	_, _ = c.writer.Write(line.Bytes())
+}
+
+func getFieldValue(field string, ep *endpoint.Endpoint, result *endpoint.Result) any {
+	switch field {
+	case FieldTimestamp:
+		timestamp := result.Timestamp
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		return timestamp.UTC().Format(time.RFC3339Nano)
+	case FieldKey:
+		return ep.Key()
+	case FieldGroup:
+		return ep.Group
+	case FieldName:
+		return ep.Name
+	case FieldType:
+		return string(ep.Type())
+	case FieldHostname:
+		return result.Hostname
+	case FieldIP:
+		return result.IP
+	case FieldSuccess:
+		return result.Success
+	case FieldConnected:
+		return result.Connected
+	case FieldStatus:
+		return result.HTTPStatus
+	case FieldResponseTime:
+		return result.Duration.Milliseconds()
+	case FieldErrors:
+		if result.Errors == nil {
+			return []string{}
+		}
+		return result.Errors
+	case FieldConditionResults:
+		if result.ConditionResults == nil {
+			return []*endpoint.ConditionResult{}
+		}
+		return result.ConditionResults
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package resultlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		Name           string
+		Config         *Config
+		ExpectedFields []string
+		ExpectedError  error
+	}{
+		{
+			Name:           "default-fields",
+			Config:         &Config{},
+			ExpectedFields: DefaultFields,
+		},
+		{
+			Name:           "custom-fields",
+			Config:         &Config{Fields: []string{FieldKey, FieldSuccess}},
+			ExpectedFields: []string{FieldKey, FieldSuccess},
+		},
+		{
+			Name:          "unknown-field",
+			Config:        &Config{Fields: []string{FieldKey, "body"}},
+			ExpectedError: ErrUnknownField,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := scenario.Config.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.ExpectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedError, err)
+			}
+			if err == nil && len(scenario.Config.Fields) != len(scenario.ExpectedFields) {
+				t.Errorf("expected fields %v, got %v", scenario.ExpectedFields, scenario.Config.Fields)
+			}
+		})
+	}
+}
+
+func TestPublishResult(t *testing.T) {
+	defer SetConfig(nil)
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core", URL: "https://example.org"}
+	result := &endpoint.Result{
+		Success:    false,
+		HTTPStatus: 500,
+		Duration:   150 * time.Millisecond,
+		Errors:     []string{"error"},
+		Timestamp:  time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC),
+		ConditionResults: []*endpoint.ConditionResult{
+			{Condition: "[STATUS] (500) == 200", Success: false},
+		},
+	}
+	// No line must be written if there's no configuration
+	PublishResult(ep, result)
+	output := &bytes.Buffer{}
+	cfg := &Config{writer: output}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	SetConfig(cfg)
+	PublishResult(ep, result)
+	expectedLine := `{"timestamp":"2024-03-11T12:00:00Z","key":"core_frontend","group":"core","name":"frontend","success":false,"status":500,"responseTime":150,"errors":["error"]}` + "\n"
+	if output.String() != expectedLine {
+		t.Errorf("expected line %s, got %s", expectedLine, output.String())
+	}
+	output.Reset()
+	cfg.Fields = []string{FieldType, FieldConditionResults}
+	PublishResult(ep, &endpoint.Result{})
+	line := make(map[string]any)
+	if err := json.Unmarshal(output.Bytes(), &line); err != nil {
+		t.Fatal("expected the line to be valid JSON, got", err)
+	}
+	if len(line) != 2 || line[FieldType] != string(endpoint.TypeHTTP) {
+		t.Errorf("unexpected line: %s", output.String())
+	}
+	if conditionResults, ok := line[FieldConditionResults].([]any); !ok || len(conditionResults) != 0 {
+		t.Errorf("expected an empty list of condition results, got %s", output.String())
+	}
+}
@@ -0,0 +1,191 @@
+package security
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// APIKeyScopeReadOnly is the scope granting access to the routes that don't modify anything
+	APIKeyScopeReadOnly APIKeyScope = "read-only"
+
+	// APIKeyScopePushExternal is the scope granting access to the route pushing the results of external endpoints
+	APIKeyScopePushExternal APIKeyScope = "push-external"
+
+	// APIKeyScopeAdmin is the scope granting access to every route
+	APIKeyScopeAdmin APIKeyScope = "admin"
+
+	// minimumAPIKeyLength is the minimum length of an API key
+	minimumAPIKeyLength = 32
+
+	localsKeyAPIKey = "gatus_api_key"
+)
+
+// APIKeyScope is a permission granted by an API key
+type APIKeyScope string
+
+// APIKeyConfig is the configuration of an API key, which authenticates a client passing it as a bearer token in the
+// Authorization header of its requests to the API.
+//
+// Removing an API key from the configuration revokes it as soon as the configuration is reloaded.
+type APIKeyConfig struct {
+	// Name identifies the API key (e.g. the name of the client using it). Must be unique.
+	Name string `yaml:"name"`
+
+	// Key is the secret passed by the client. Must be unique and at least 32 characters long.
+	Key string `yaml:"key"`
+
+	// Scopes are the permissions granted by the API key
+	Scopes []APIKeyScope `yaml:"scopes"`
+
+	// RateLimit is the maximum number of requests per minute that can be made with the API key. 0 means unlimited.
+	RateLimit int `yaml:"rate-limit,omitempty"`
+
+	keyHash [sha256.Size]byte
+	limiter *rateLimiter
+}
+
+// isValid returns whether the API key configuration is valid or not
+func (c *APIKeyConfig) isValid() bool {
+	if len(c.Name) == 0 || len(c.Key) < minimumAPIKeyLength || len(c.Scopes) == 0 || c.RateLimit < 0 {
+		return false
+	}
+	for _, scope := range c.Scopes {
+		if scope != APIKeyScopeReadOnly && scope != APIKeyScopePushExternal && scope != APIKeyScopeAdmin {
+			return false
+		}
+	}
+	return true
+}
+
+// grants returns whether the API key grants the scope passed
+func (c *APIKeyConfig) grants(scope APIKeyScope) bool {
+	return slices.Contains(c.Scopes, APIKeyScopeAdmin) || slices.Contains(c.Scopes, scope)
+}
+
+// areAPIKeysValid returns whether every API key passed is valid, and whether their names and keys are all unique
+func areAPIKeysValid(apiKeys []*APIKeyConfig) bool {
+	names := make(map[string]bool, len(apiKeys))
+	keys := make(map[string]bool, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		if apiKey == nil || !apiKey.isValid() || names[apiKey.Name] || keys[apiKey.Key] {
+			return false
+		}
+		names[apiKey.Name], keys[apiKey.Key] = true, true
+	}
+	return true
+}
+
+// ApplyAPIKeyMiddleware applies a middleware authenticating the requests passing one of the API keys configured as a
+// bearer token to the router passed, which should be the router in charge of all API routes, protected or not.
+//
+// Requests made with an API key are rejected if the key doesn't grant the scope required by the route requested, or if
+// the rate limit of the key has been reached. Requests with any other bearer token are left untouched, since the
+// token may be meant for an external endpoint or a tenant.
+//
+// Because the middleware applied by ApplySecurityMiddleware relies on this one, this must be called beforehand.
+func (c *Config) ApplyAPIKeyMiddleware(router fiber.Router) {
+	if len(c.APIKeys) == 0 {
+		return
+	}
+	for _, apiKey := range c.APIKeys {
+		apiKey.keyHash = sha256.Sum256([]byte(apiKey.Key))
+		if apiKey.RateLimit > 0 {
+			apiKey.limiter = newRateLimiter(apiKey.RateLimit, time.Minute)
+		}
+	}
+	router.Use(func(ctx *fiber.Ctx) error {
+		token, found := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !found {
+			return ctx.Next()
+		}
+		apiKey := c.getAPIKey(strings.TrimSpace(token))
+		if apiKey == nil {
+			return ctx.Next()
+		}
+		if requiredScope := requiredAPIKeyScope(ctx.Method(), ctx.Path()); !apiKey.grants(requiredScope) {
+			return ctx.Status(403).SendString("api key does not grant the " + string(requiredScope) + " scope")
+		}
+		if apiKey.limiter != nil {
+			if allowed, retryAfter := apiKey.limiter.allow(time.Now()); !allowed {
+				ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				return ctx.Status(429).SendString("rate limit of api key exceeded")
+			}
+		}
+		ctx.Locals(localsKeyAPIKey, apiKey)
+		return ctx.Next()
+	})
+}
+
+// GetAPIKeyFromContext returns the API key with which the request was authenticated by the middleware applied by
+// ApplyAPIKeyMiddleware, or nil if the request wasn't made with an API key
+func GetAPIKeyFromContext(ctx *fiber.Ctx) *APIKeyConfig {
+	apiKey, _ := ctx.Locals(localsKeyAPIKey).(*APIKeyConfig)
+	return apiKey
+}
+
+// getAPIKey returns the API key matching the token passed, or nil if there's none.
+// The hashes of the keys are compared in constant time to avoid leaking the keys through timing attacks.
+func (c *Config) getAPIKey(token string) *APIKeyConfig {
+	tokenHash := sha256.Sum256([]byte(token))
+	var match *APIKeyConfig
+	for _, apiKey := range c.APIKeys {
+		if subtle.ConstantTimeCompare(tokenHash[:], apiKey.keyHash[:]) == 1 {
+			match = apiKey
+		}
+	}
+	return match
+}
+
+// requiredAPIKeyScope returns the scope an API key must grant to make a request with the method and path passed
+func requiredAPIKeyScope(method, path string) APIKeyScope {
+	path = strings.TrimSuffix(path, "/")
+	switch {
+	case method == fiber.MethodGet || method == fiber.MethodHead:
+		return APIKeyScopeReadOnly
+	case method == fiber.MethodPost && strings.Contains(path, "/v1/endpoints/") && strings.HasSuffix(path, "/external"):
+		return APIKeyScopePushExternal
+	case method == fiber.MethodPost && strings.Contains(path, "/v1/grafana/"):
+		// The queries of the Grafana data source are sent with POST, but don't modify anything
+		return APIKeyScopeReadOnly
+	default:
+		return APIKeyScopeAdmin
+	}
+}
+
+// rateLimiter is a token bucket allowing up to limit requests per interval, with bursts of up to limit requests
+type rateLimiter struct {
+	mutex     sync.Mutex
+	limit     float64
+	interval  time.Duration
+	tokens    float64
+	updatedAt time.Time
+}
+
+func newRateLimiter(limit int, interval time.Duration) *rateLimiter {
+	return &rateLimiter{limit: float64(limit), interval: interval, tokens: float64(limit)}
+}
+
+// allow returns whether a request can be made at the time passed and, if it can't, how long to wait until it can
+func (r *rateLimiter) allow(now time.Time) (bool, time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.updatedAt.IsZero() {
+		elapsed := now.Sub(r.updatedAt)
+		r.tokens = min(r.limit, r.tokens+r.limit*float64(elapsed)/float64(r.interval))
+	}
+	r.updatedAt = now
+	if r.tokens < 1 {
+		return false, time.Duration((1 - r.tokens) / r.limit * float64(r.interval))
+	}
+	r.tokens--
+	return true, 0
+}
@@ -0,0 +1,233 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	testAdminAPIKey    = "admin-0123456789abcdefghijklmnopqrstuvwxyz"
+	testReadOnlyAPIKey = "read-only-0123456789abcdefghijklmnopqrstuvwxyz"
+	testPushAPIKey     = "push-0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+func TestConfig_IsValidWithAPIKeys(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		apiKeys  []*APIKeyConfig
+		expected bool
+	}{
+		{
+			name: "valid",
+			apiKeys: []*APIKeyConfig{
+				{Name: "admin", Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopeAdmin}},
+				{Name: "agent", Key: testPushAPIKey, Scopes: []APIKeyScope{APIKeyScopeReadOnly, APIKeyScopePushExternal}, RateLimit: 60},
+			},
+			expected: true,
+		},
+		{
+			name:     "no-name",
+			apiKeys:  []*APIKeyConfig{{Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopeAdmin}}},
+			expected: false,
+		},
+		{
+			name:     "key-too-short",
+			apiKeys:  []*APIKeyConfig{{Name: "admin", Key: "hunter2", Scopes: []APIKeyScope{APIKeyScopeAdmin}}},
+			expected: false,
+		},
+		{
+			name:     "no-scope",
+			apiKeys:  []*APIKeyConfig{{Name: "admin", Key: testAdminAPIKey}},
+			expected: false,
+		},
+		{
+			name:     "unknown-scope",
+			apiKeys:  []*APIKeyConfig{{Name: "admin", Key: testAdminAPIKey, Scopes: []APIKeyScope{"write"}}},
+			expected: false,
+		},
+		{
+			name:     "negative-rate-limit",
+			apiKeys:  []*APIKeyConfig{{Name: "admin", Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopeAdmin}, RateLimit: -1}},
+			expected: false,
+		},
+		{
+			name: "duplicate-name",
+			apiKeys: []*APIKeyConfig{
+				{Name: "admin", Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopeAdmin}},
+				{Name: "admin", Key: testPushAPIKey, Scopes: []APIKeyScope{APIKeyScopePushExternal}},
+			},
+			expected: false,
+		},
+		{
+			name: "duplicate-key",
+			apiKeys: []*APIKeyConfig{
+				{Name: "admin", Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopeAdmin}},
+				{Name: "agent", Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopePushExternal}},
+			},
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if (&Config{APIKeys: scenario.apiKeys}).IsValid() != scenario.expected {
+				t.Errorf("expected security configuration with API keys only to be valid=%v", scenario.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyAPIKeyMiddleware(t *testing.T) {
+	c := &Config{
+		Basic: &BasicConfig{
+			Username:                        "john.doe",
+			PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+		},
+		APIKeys: []*APIKeyConfig{
+			{Name: "admin", Key: testAdminAPIKey, Scopes: []APIKeyScope{APIKeyScopeAdmin}},
+			{Name: "dashboard", Key: testReadOnlyAPIKey, Scopes: []APIKeyScope{APIKeyScopeReadOnly}},
+			{Name: "agent", Key: testPushAPIKey, Scopes: []APIKeyScope{APIKeyScopePushExternal}},
+		},
+	}
+	app := fiber.New()
+	apiRouter := app.Group("/api")
+	c.ApplyAPIKeyMiddleware(apiRouter)
+	// Unprotected routes
+	apiRouter.Get("/v1/endpoints/:key/health/badge.svg", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	apiRouter.Post("/v1/endpoints/:key/external", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	protectedAPIRouter := apiRouter.Group("/")
+	if err := c.ApplySecurityMiddleware(protectedAPIRouter); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	protectedAPIRouter.Get("/v1/endpoints/statuses", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	protectedAPIRouter.Post("/v1/grafana/query", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	protectedAPIRouter.Post("/v1/import", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	scenarios := []struct {
+		name         string
+		method       string
+		path         string
+		token        string
+		expectedCode int
+	}{
+		{name: "admin-can-read", method: "GET", path: "/api/v1/endpoints/statuses", token: testAdminAPIKey, expectedCode: 200},
+		{name: "admin-can-push", method: "POST", path: "/api/v1/endpoints/core_ext/external", token: testAdminAPIKey, expectedCode: 200},
+		{name: "admin-can-import", method: "POST", path: "/api/v1/import", token: testAdminAPIKey, expectedCode: 200},
+		{name: "read-only-can-read", method: "GET", path: "/api/v1/endpoints/statuses", token: testReadOnlyAPIKey, expectedCode: 200},
+		{name: "read-only-can-query-grafana", method: "POST", path: "/api/v1/grafana/query", token: testReadOnlyAPIKey, expectedCode: 200},
+		{name: "read-only-cannot-push", method: "POST", path: "/api/v1/endpoints/core_ext/external", token: testReadOnlyAPIKey, expectedCode: 403},
+		{name: "read-only-cannot-import", method: "POST", path: "/api/v1/import", token: testReadOnlyAPIKey, expectedCode: 403},
+		{name: "push-external-can-push", method: "POST", path: "/api/v1/endpoints/core_ext/external", token: testPushAPIKey, expectedCode: 200},
+		{name: "push-external-cannot-read", method: "GET", path: "/api/v1/endpoints/statuses", token: testPushAPIKey, expectedCode: 403},
+		{name: "push-external-cannot-read-unprotected-route", method: "GET", path: "/api/v1/endpoints/core_ext/health/badge.svg", token: testPushAPIKey, expectedCode: 403},
+		{name: "unknown-key-cannot-read", method: "GET", path: "/api/v1/endpoints/statuses", token: testAdminAPIKey + "x", expectedCode: 401},
+		{name: "unknown-key-is-passed-to-unprotected-route", method: "POST", path: "/api/v1/endpoints/core_ext/external", token: "external-endpoint-token", expectedCode: 200},
+		{name: "no-key-cannot-read", method: "GET", path: "/api/v1/endpoints/statuses", expectedCode: 401},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.method, scenario.path, http.NoBody)
+			if len(scenario.token) > 0 {
+				request.Header.Set("Authorization", "Bearer "+scenario.token)
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if response.StatusCode != scenario.expectedCode {
+				t.Errorf("expected code to be %d, but was %d", scenario.expectedCode, response.StatusCode)
+			}
+		})
+	}
+	t.Run("basic-authentication-is-still-accepted", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/api/v1/endpoints/statuses", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter2")
+		if response, _ := app.Test(request); response.StatusCode != 200 {
+			t.Error("expected basic authentication to still be accepted, but got", response.StatusCode)
+		}
+	})
+}
+
+func TestConfig_ApplyAPIKeyMiddlewareWithRateLimit(t *testing.T) {
+	c := &Config{APIKeys: []*APIKeyConfig{{Name: "agent", Key: testReadOnlyAPIKey, Scopes: []APIKeyScope{APIKeyScopeReadOnly}, RateLimit: 2}}}
+	app := fiber.New()
+	c.ApplyAPIKeyMiddleware(app)
+	if err := c.ApplySecurityMiddleware(app); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	app.Get("/api/v1/endpoints/statuses", func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	for i, expectedCode := range []int{200, 200, 429} {
+		request := httptest.NewRequest("GET", "/api/v1/endpoints/statuses", http.NoBody)
+		request.Header.Set("Authorization", "Bearer "+testReadOnlyAPIKey)
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if response.StatusCode != expectedCode {
+			t.Errorf("expected request #%d to return %d, but got %d", i+1, expectedCode, response.StatusCode)
+		}
+		if expectedCode == 429 && response.Header.Get("Retry-After") != "30" {
+			t.Errorf("expected Retry-After to be 30, got %q", response.Header.Get("Retry-After"))
+		}
+	}
+}
+
+func TestRateLimiter_allow(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(3, time.Minute)
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.allow(now); !allowed {
+			t.Fatalf("expected request #%d to be allowed", i+1)
+		}
+	}
+	if allowed, retryAfter := limiter.allow(now); allowed || retryAfter != 20*time.Second {
+		t.Errorf("expected request to be rejected with a retry after 20s, got allowed=%v and retryAfter=%s", allowed, retryAfter)
+	}
+	if allowed, _ := limiter.allow(now.Add(20 * time.Second)); !allowed {
+		t.Error("expected request to be allowed once a token has been refilled")
+	}
+	if allowed, _ := limiter.allow(now.Add(20 * time.Second)); allowed {
+		t.Error("expected request to be rejected since the only token refilled has been used")
+	}
+	// The bucket can't hold more than the limit, no matter how long it's been since the last request
+	for i := 0; i < 4; i++ {
+		allowed, _ := limiter.allow(now.Add(time.Hour))
+		if expected := i < 3; allowed != expected {
+			t.Errorf("expected request #%d to be allowed=%v after an hour", i+1, expected)
+		}
+	}
+}
+
+func TestRequiredAPIKeyScope(t *testing.T) {
+	scenarios := map[string]APIKeyScope{
+		"GET /api/v1/endpoints/statuses":                  APIKeyScopeReadOnly,
+		"HEAD /api/v1/endpoints/core_frontend/statuses":   APIKeyScopeReadOnly,
+		"POST /status/api/v1/endpoints/core_ext/external": APIKeyScopePushExternal,
+		"POST /api/v1/endpoints/core_ext/external/":       APIKeyScopePushExternal,
+		"POST /api/v1/endpoints/core_ext/external/tokens": APIKeyScopeAdmin,
+		"POST /api/v1/grafana/search":                     APIKeyScopeReadOnly,
+		"PATCH /api/v1/endpoints/core_frontend":           APIKeyScopeAdmin,
+		"DELETE /api/v1/endpoints/core_frontend/override": APIKeyScopeAdmin,
+	}
+	for request, expected := range scenarios {
+		method, path, _ := strings.Cut(request, " ")
+		if scope := requiredAPIKeyScope(method, path); scope != expected {
+			t.Errorf("expected %s to require %s, got %s", request, expected, scope)
+		}
+	}
+}
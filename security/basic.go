@@ -1,5 +1,11 @@
 package security
 
+import (
+	"encoding/base64"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
 // BasicConfig is the configuration for Basic authentication
 type BasicConfig struct {
 	// Username is the name which will need to be used for a successful authentication
@@ -14,3 +20,12 @@ type BasicConfig struct {
 func (c *BasicConfig) isValid() bool {
 	return len(c.Username) > 0 && len(c.PasswordBcryptHashBase64Encoded) > 0
 }
+
+// IsAuthorized returns whether the username and password passed match the basic security configuration
+func (c *BasicConfig) IsAuthorized(username, password string) bool {
+	decodedBcryptHash, err := base64.URLEncoding.DecodeString(c.PasswordBcryptHashBase64Encoded)
+	if err != nil {
+		return false
+	}
+	return username == c.Username && bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) == nil
+}
@@ -21,3 +21,19 @@ func TestBasicConfig_IsValidWhenPasswordIsInvalidUsingBcrypt(t *testing.T) {
 		t.Error("basicConfig shouldn't have been valid")
 	}
 }
+
+func TestBasicConfig_IsAuthorized(t *testing.T) {
+	basicConfig := &BasicConfig{
+		Username:                        "john.doe",
+		PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+	}
+	if !basicConfig.IsAuthorized("john.doe", "hunter2") {
+		t.Error("expected credentials to be authorized")
+	}
+	if basicConfig.IsAuthorized("john.doe", "hunter3") {
+		t.Error("expected credentials with the wrong password not to be authorized")
+	}
+	if basicConfig.IsAuthorized("jane.doe", "hunter2") {
+		t.Error("expected credentials with the wrong username not to be authorized")
+	}
+}
@@ -1,9 +1,11 @@
 package security
 
 import (
+	"context"
 	"encoding/base64"
 	"log"
 	"net/http"
+	"strings"
 
 	g8 "github.com/TwiN/g8/v2"
 	"github.com/gofiber/fiber/v2"
@@ -23,12 +25,31 @@ type Config struct {
 	Basic *BasicConfig `yaml:"basic,omitempty"`
 	OIDC  *OIDCConfig  `yaml:"oidc,omitempty"`
 
+	// ServiceAccounts is the configuration for authenticating machine-to-machine clients through the access tokens
+	// issued to them by an OpenID Connect provider, as an alternative to Basic and OIDC
+	ServiceAccounts *ServiceAccountsConfig `yaml:"service-accounts,omitempty"`
+
+	// ShareLinks is the configuration for share links, which can only be generated by authenticated users
+	ShareLinks *ShareLinksConfig `yaml:"share-links,omitempty"`
+
+	// APIKeys are the keys with which clients can authenticate to the API, each granting its own scopes
+	APIKeys []*APIKeyConfig `yaml:"api-keys,omitempty"`
+
 	gate *g8.Gate
 }
 
 // IsValid returns whether the security configuration is valid or not
 func (c *Config) IsValid() bool {
-	return (c.Basic != nil && c.Basic.isValid()) || (c.OIDC != nil && c.OIDC.isValid())
+	if c.ShareLinks != nil && !c.ShareLinks.isValid() {
+		return false
+	}
+	if c.ServiceAccounts != nil && !c.ServiceAccounts.isValid() {
+		return false
+	}
+	if !areAPIKeysValid(c.APIKeys) {
+		return false
+	}
+	return (c.Basic != nil && c.Basic.isValid()) || (c.OIDC != nil && c.OIDC.isValid()) || c.ServiceAccounts != nil || len(c.APIKeys) > 0
 }
 
 // RegisterHandlers registers all handlers required based on the security configuration
@@ -40,12 +61,22 @@ func (c *Config) RegisterHandlers(router fiber.Router) error {
 		router.All("/oidc/login", c.OIDC.loginHandler)
 		router.All("/authorization-code/callback", adaptor.HTTPHandlerFunc(c.OIDC.callbackHandler))
 	}
+	if c.ServiceAccounts != nil {
+		if err := c.ServiceAccounts.initialize(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // ApplySecurityMiddleware applies an authentication middleware to the router passed.
 // The router passed should be a sub-router in charge of handlers that require authentication.
+//
+// If service accounts are configured, requests with a valid access token in their Authorization header are
+// authenticated regardless of the other authentication methods configured. The same goes for requests authenticated
+// with an API key by the middleware applied by ApplyAPIKeyMiddleware.
 func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
+	var authenticationMiddleware fiber.Handler
 	if c.OIDC != nil {
 		// We're going to use g8 for session handling
 		clientProvider := g8.NewClientProvider(func(token string) *g8.Client {
@@ -64,7 +95,7 @@ func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
 		// TODO: g8: Add a way to update cookie after? would need the writer
 		authorizationService := g8.NewAuthorizationService().WithClientProvider(clientProvider)
 		c.gate = g8.New().WithAuthorizationService(authorizationService).WithCustomTokenExtractor(customTokenExtractorFunc)
-		router.Use(adaptor.HTTPMiddleware(c.gate.Protect))
+		authenticationMiddleware = adaptor.HTTPMiddleware(c.gate.Protect)
 	} else if c.Basic != nil {
 		var decodedBcryptHash []byte
 		if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
@@ -74,7 +105,7 @@ func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
 				return err
 			}
 		}
-		router.Use(basicauth.New(basicauth.Config{
+		authenticationMiddleware = basicauth.New(basicauth.Config{
 			Authorizer: func(username, password string) bool {
 				if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
 					if username != c.Basic.Username || bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) != nil {
@@ -87,11 +118,44 @@ func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
 				ctx.Set("WWW-Authenticate", "Basic")
 				return ctx.Status(401).SendString("Unauthorized")
 			},
-		}))
+		})
+	}
+	if c.ServiceAccounts != nil || len(c.APIKeys) > 0 {
+		fallbackMiddleware := authenticationMiddleware
+		authenticationMiddleware = func(ctx *fiber.Ctx) error {
+			if GetAPIKeyFromContext(ctx) != nil {
+				return ctx.Next()
+			}
+			token, found := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+			if found && c.IsAuthorizedServiceAccountToken(ctx.UserContext(), strings.TrimSpace(token)) {
+				return ctx.Next()
+			}
+			if fallbackMiddleware == nil {
+				// Service accounts and API keys are the only authentication methods configured
+				return ctx.Status(401).SendString("Unauthorized")
+			}
+			return fallbackMiddleware(ctx)
+		}
+	}
+	if authenticationMiddleware != nil {
+		router.Use(authenticationMiddleware)
 	}
 	return nil
 }
 
+// IsAuthorizedServiceAccountToken returns whether the token passed is a valid access token of a service account.
+// If service accounts aren't configured, it always returns false.
+func (c *Config) IsAuthorizedServiceAccountToken(ctx context.Context, token string) bool {
+	if c.ServiceAccounts == nil || !isJWT(token) {
+		return false
+	}
+	if err := c.ServiceAccounts.authenticate(ctx, token); err != nil {
+		log.Printf("[security.IsAuthorizedServiceAccountToken] Rejected access token: %v", err)
+		return false
+	}
+	return true
+}
+
 // IsAuthenticated checks whether the user is authenticated
 // If the Config does not warrant authentication, it will always return true.
 func (c *Config) IsAuthenticated(ctx *fiber.Ctx) bool {
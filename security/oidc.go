@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -114,18 +115,28 @@ func (c *OIDCConfig) callbackHandler(w http.ResponseWriter, r *http.Request) { /
 	if len(c.AllowedSubjects) == 0 {
 		// If there's no allowed subjects, all subjects are allowed.
 		c.setSessionCookie(w, idToken)
-		http.Redirect(w, r, "/", http.StatusFound)
+		http.Redirect(w, r, c.getBasePath()+"/", http.StatusFound)
 		return
 	}
 	for _, subject := range c.AllowedSubjects {
 		if strings.ToLower(subject) == strings.ToLower(idToken.Subject) {
 			c.setSessionCookie(w, idToken)
-			http.Redirect(w, r, "/", http.StatusFound)
+			http.Redirect(w, r, c.getBasePath()+"/", http.StatusFound)
 			return
 		}
 	}
 	log.Printf("[security.callbackHandler] Subject %s is not in the list of allowed subjects", idToken.Subject)
-	http.Redirect(w, r, "/?error=access_denied", http.StatusFound)
+	http.Redirect(w, r, c.getBasePath()+"/?error=access_denied", http.StatusFound)
+}
+
+// getBasePath returns the path under which Gatus is served from the perspective of the user, which is derived from
+// the RedirectURL (e.g. /status for https://example.com/status/authorization-code/callback)
+func (c *OIDCConfig) getBasePath() string {
+	redirectURL, err := url.Parse(c.RedirectURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(redirectURL.Path, "/authorization-code/callback")
 }
 
 func (c *OIDCConfig) setSessionCookie(w http.ResponseWriter, idToken *oidc.IDToken) {
@@ -68,3 +68,22 @@ func TestOIDCConfig_setSessionCookie(t *testing.T) {
 		t.Error("expected cookie to be set")
 	}
 }
+
+func TestOIDCConfig_getBasePath(t *testing.T) {
+	scenarios := []struct {
+		redirectURL      string
+		expectedBasePath string
+	}{
+		{redirectURL: "https://example.com/authorization-code/callback", expectedBasePath: ""},
+		{redirectURL: "https://example.com/status/authorization-code/callback", expectedBasePath: "/status"},
+		{redirectURL: "http://localhost:8080/a/b/authorization-code/callback", expectedBasePath: "/a/b"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.redirectURL, func(t *testing.T) {
+			c := &OIDCConfig{RedirectURL: scenario.redirectURL}
+			if basePath := c.getBasePath(); basePath != scenario.expectedBasePath {
+				t.Errorf("expected base path to be %s, got %s", scenario.expectedBasePath, basePath)
+			}
+		})
+	}
+}
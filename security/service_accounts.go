@@ -0,0 +1,101 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+var (
+	// ErrServiceAccountsNotInitialized is the error returned when a token is validated before the configuration of
+	// the service accounts has been initialized
+	ErrServiceAccountsNotInitialized = errors.New("service accounts configuration has not been initialized")
+
+	// ErrServiceAccountTokenMissingScope is the error returned when a token doesn't grant one of the required scopes
+	ErrServiceAccountTokenMissingScope = errors.New("token is missing a required scope")
+)
+
+// ServiceAccountsConfig is the configuration for authenticating machine-to-machine clients (e.g. CI pipelines or
+// agents) through the access tokens issued to them by an OpenID Connect provider with the client credentials flow,
+// which are short-lived as opposed to static tokens.
+//
+// The access tokens must be JWTs signed by the provider.
+type ServiceAccountsConfig struct {
+	IssuerURL      string   `yaml:"issuer-url"`                // e.g. https://dev-12345678.okta.com
+	Audience       string   `yaml:"audience"`                  // e.g. api://gatus. Must be one of the audiences of the tokens.
+	RequiredScopes []string `yaml:"required-scopes,omitempty"` // e.g. ["gatus.api"]. If empty, no scope is required.
+
+	verifier *oidc.IDTokenVerifier
+}
+
+// isValid returns whether the service accounts configuration is valid or not
+func (c *ServiceAccountsConfig) isValid() bool {
+	return len(c.IssuerURL) > 0 && len(c.Audience) > 0
+}
+
+func (c *ServiceAccountsConfig) initialize() error {
+	provider, err := oidc.NewProvider(context.Background(), c.IssuerURL)
+	if err != nil {
+		return err
+	}
+	// Access tokens issued through the client credentials flow have the API as audience rather than the client
+	c.verifier = provider.Verifier(&oidc.Config{ClientID: c.Audience})
+	return nil
+}
+
+// authenticate validates the signature, the issuer, the audience, the expiration and the scopes of the token passed
+func (c *ServiceAccountsConfig) authenticate(ctx context.Context, rawToken string) error {
+	if c.verifier == nil {
+		return ErrServiceAccountsNotInitialized
+	}
+	token, err := c.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return err
+	}
+	if len(c.RequiredScopes) > 0 {
+		grantedScopes, err := scopesOf(token)
+		if err != nil {
+			return err
+		}
+		for _, requiredScope := range c.RequiredScopes {
+			if !slices.Contains(grantedScopes, requiredScope) {
+				return fmt.Errorf("%w: %s (subject=%s)", ErrServiceAccountTokenMissingScope, requiredScope, token.Subject)
+			}
+		}
+	}
+	return nil
+}
+
+// scopesOf returns the scopes granted by a token, which providers put either in a space-delimited "scope" claim as
+// described by RFC 9068, or in a "scp" claim that may be a list
+func scopesOf(token *oidc.IDToken) ([]string, error) {
+	var claims struct {
+		Scope string          `json:"scope"`
+		Scp   json.RawMessage `json:"scp"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return nil, err
+	}
+	scopes := strings.Fields(claims.Scope)
+	if len(claims.Scp) > 0 {
+		var scpList []string
+		var scpString string
+		if err := json.Unmarshal(claims.Scp, &scpList); err == nil {
+			scopes = append(scopes, scpList...)
+		} else if err := json.Unmarshal(claims.Scp, &scpString); err == nil {
+			scopes = append(scopes, strings.Fields(scpString)...)
+		}
+	}
+	return scopes, nil
+}
+
+// isJWT returns whether the token passed has the structure of a JWT, which allows to tell the access tokens of service
+// accounts apart from other bearer tokens without attempting to verify the latter
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
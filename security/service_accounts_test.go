@@ -0,0 +1,195 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestServiceAccountsConfig_isValid(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		config   *ServiceAccountsConfig
+		expected bool
+	}{
+		{
+			name:     "valid",
+			config:   &ServiceAccountsConfig{IssuerURL: "https://sso.example.com", Audience: "api://gatus"},
+			expected: true,
+		},
+		{
+			name:     "no-issuer-url",
+			config:   &ServiceAccountsConfig{Audience: "api://gatus"},
+			expected: false,
+		},
+		{
+			name:     "no-audience",
+			config:   &ServiceAccountsConfig{IssuerURL: "https://sso.example.com"},
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.config.isValid() != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, !scenario.expected)
+			}
+			if (&Config{ServiceAccounts: scenario.config}).IsValid() != scenario.expected {
+				t.Errorf("expected security configuration with service accounts only to be valid=%v", scenario.expected)
+			}
+		})
+	}
+}
+
+func TestServiceAccountsConfig_authenticate(t *testing.T) {
+	issuer := test.NewOIDCIssuer(t)
+	otherIssuer := test.NewOIDCIssuer(t)
+	c := &ServiceAccountsConfig{IssuerURL: issuer.URL, Audience: "api://gatus", RequiredScopes: []string{"gatus.write"}}
+	if err := c.authenticate(context.Background(), "token"); !errors.Is(err, ErrServiceAccountsNotInitialized) {
+		t.Errorf("expected %v before initialization, got %v", ErrServiceAccountsNotInitialized, err)
+	}
+	if err := c.initialize(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	claims := func(overrides map[string]any) map[string]any {
+		claims := map[string]any{
+			"iss":   issuer.URL,
+			"sub":   "ci-pipeline",
+			"aud":   "api://gatus",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"iat":   time.Now().Unix(),
+			"scope": "gatus.read gatus.write",
+		}
+		for key, value := range overrides {
+			if value == nil {
+				delete(claims, key)
+			} else {
+				claims[key] = value
+			}
+		}
+		return claims
+	}
+	scenarios := []struct {
+		name          string
+		token         string
+		expectedErr   error
+		expectedValid bool
+	}{
+		{
+			name:          "valid",
+			token:         issuer.IssueToken(t, claims(nil)),
+			expectedValid: true,
+		},
+		{
+			name:          "valid-with-scp-list",
+			token:         issuer.IssueToken(t, claims(map[string]any{"scope": nil, "scp": []string{"gatus.write"}})),
+			expectedValid: true,
+		},
+		{
+			name:          "valid-with-audience-list",
+			token:         issuer.IssueToken(t, claims(map[string]any{"aud": []string{"other", "api://gatus"}})),
+			expectedValid: true,
+		},
+		{
+			name:        "missing-scope",
+			token:       issuer.IssueToken(t, claims(map[string]any{"scope": "gatus.read"})),
+			expectedErr: ErrServiceAccountTokenMissingScope,
+		},
+		{
+			name:  "wrong-audience",
+			token: issuer.IssueToken(t, claims(map[string]any{"aud": "api://other"})),
+		},
+		{
+			name:  "expired",
+			token: issuer.IssueToken(t, claims(map[string]any{"exp": time.Now().Add(-time.Minute).Unix()})),
+		},
+		{
+			name:  "wrong-issuer",
+			token: issuer.IssueToken(t, claims(map[string]any{"iss": otherIssuer.URL})),
+		},
+		{
+			name:  "signed-by-other-issuer",
+			token: otherIssuer.IssueToken(t, claims(nil)),
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := c.authenticate(context.Background(), scenario.token)
+			if scenario.expectedValid != (err == nil) {
+				t.Errorf("expected token to be valid=%v, got error %v", scenario.expectedValid, err)
+			}
+			if scenario.expectedErr != nil && !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplySecurityMiddlewareWithServiceAccounts(t *testing.T) {
+	issuer := test.NewOIDCIssuer(t)
+	validToken := issuer.IssueToken(t, map[string]any{"iss": issuer.URL, "sub": "ci-pipeline", "aud": "api://gatus", "exp": time.Now().Add(time.Hour).Unix()})
+	scenarios := []struct {
+		name   string
+		config *Config
+	}{
+		{
+			name:   "service-accounts-only",
+			config: &Config{ServiceAccounts: &ServiceAccountsConfig{IssuerURL: issuer.URL, Audience: "api://gatus"}},
+		},
+		{
+			name: "service-accounts-and-basic",
+			config: &Config{
+				Basic: &BasicConfig{
+					Username:                        "john.doe",
+					PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+				},
+				ServiceAccounts: &ServiceAccountsConfig{IssuerURL: issuer.URL, Audience: "api://gatus"},
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			app := fiber.New()
+			if err := scenario.config.RegisterHandlers(app); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if err := scenario.config.ApplySecurityMiddleware(app); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			app.Get("/test", func(c *fiber.Ctx) error {
+				return c.SendStatus(200)
+			})
+			for authorization, expectedCode := range map[string]int{
+				"":                               401,
+				"Bearer " + validToken:           200,
+				"Bearer " + validToken + "x":     401,
+				"Bearer not-a-jwt":               401,
+				"Basic am9obi5kb2U6d3JvbmdwYXNz": 401,
+			} {
+				request := httptest.NewRequest("GET", "/test", http.NoBody)
+				if len(authorization) > 0 {
+					request.Header.Set("Authorization", authorization)
+				}
+				response, err := app.Test(request)
+				if err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				if response.StatusCode != expectedCode {
+					t.Errorf("expected code to be %d with Authorization=%q, but was %d", expectedCode, authorization, response.StatusCode)
+				}
+			}
+			if scenario.config.Basic != nil {
+				request := httptest.NewRequest("GET", "/test", http.NoBody)
+				request.SetBasicAuth("john.doe", "hunter2")
+				if response, _ := app.Test(request); response.StatusCode != 200 {
+					t.Error("expected basic authentication to still be accepted, but got", response.StatusCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultShareLinkMaxDuration is the default maximum duration for which a share link can be valid
+	DefaultShareLinkMaxDuration = 7 * 24 * time.Hour
+
+	// minimumShareLinkSecretLength is the minimum length of the secret used to sign share links
+	minimumShareLinkSecretLength = 32
+)
+
+var (
+	ErrInvalidShareLinkToken     = errors.New("invalid share link token")
+	ErrShareLinkExpired          = errors.New("share link has expired")
+	ErrShareLinkWithoutGroups    = errors.New("share link must grant access to at least one group")
+	ErrShareLinkDurationTooShort = errors.New("share link duration must be greater than 0")
+	ErrShareLinkDurationTooLong  = errors.New("share link duration must not exceed the maximum duration configured")
+)
+
+// ShareLinksConfig is the configuration for share links, which grant read-only access to the status of a subset of
+// groups for a limited time without requiring authentication
+type ShareLinksConfig struct {
+	// Secret is the secret used to sign share links. Changing it invalidates all share links generated beforehand.
+	Secret string `yaml:"secret"`
+
+	// MaxDuration is the maximum duration for which a share link can be valid
+	MaxDuration time.Duration `yaml:"max-duration,omitempty"`
+}
+
+// ShareLink is the content of a share link token
+type ShareLink struct {
+	// Groups are the groups whose endpoints can be viewed using the share link
+	Groups []string `json:"groups"`
+
+	// ExpiresAt is the unix timestamp at which the share link expires
+	ExpiresAt int64 `json:"expires-at"`
+}
+
+// isValid returns whether the share links configuration is valid or not
+func (c *ShareLinksConfig) isValid() bool {
+	return len(c.Secret) >= minimumShareLinkSecretLength && c.MaxDuration >= 0
+}
+
+// GetMaxDuration returns the maximum duration for which a share link can be valid
+func (c *ShareLinksConfig) GetMaxDuration() time.Duration {
+	if c.MaxDuration == 0 {
+		return DefaultShareLinkMaxDuration
+	}
+	return c.MaxDuration
+}
+
+// GenerateToken generates a signed token granting access to the groups passed for the duration passed
+func (c *ShareLinksConfig) GenerateToken(groups []string, duration time.Duration) (string, *ShareLink, error) {
+	if len(groups) == 0 {
+		return "", nil, ErrShareLinkWithoutGroups
+	}
+	if duration <= 0 {
+		return "", nil, ErrShareLinkDurationTooShort
+	}
+	if duration > c.GetMaxDuration() {
+		return "", nil, ErrShareLinkDurationTooLong
+	}
+	shareLink := &ShareLink{Groups: groups, ExpiresAt: time.Now().Add(duration).Unix()}
+	payload, err := json.Marshal(shareLink)
+	if err != nil {
+		return "", nil, err
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + c.sign(encodedPayload), shareLink, nil
+}
+
+// ParseToken validates the signature and the expiration of the token passed, and returns its content
+func (c *ShareLinksConfig) ParseToken(token string) (*ShareLink, error) {
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(c.sign(encodedPayload))) {
+		return nil, ErrInvalidShareLinkToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidShareLinkToken
+	}
+	var shareLink ShareLink
+	if err = json.Unmarshal(payload, &shareLink); err != nil {
+		return nil, ErrInvalidShareLinkToken
+	}
+	if time.Now().Unix() >= shareLink.ExpiresAt {
+		return nil, ErrShareLinkExpired
+	}
+	return &shareLink, nil
+}
+
+// HasAccessToGroup returns whether the share link grants access to the group passed
+func (s *ShareLink) HasAccessToGroup(group string) bool {
+	for _, g := range s.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ShareLinksConfig) sign(encodedPayload string) string {
+	mac := hmac.New(sha256.New, []byte(c.Secret))
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,133 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShareLinksConfig_isValid(t *testing.T) {
+	if (&ShareLinksConfig{Secret: "too-short"}).isValid() {
+		t.Error("expected config with short secret to be invalid")
+	}
+	if (&ShareLinksConfig{Secret: strings.Repeat("a", 32), MaxDuration: -time.Hour}).isValid() {
+		t.Error("expected config with negative max duration to be invalid")
+	}
+	if !(&ShareLinksConfig{Secret: strings.Repeat("a", 32)}).isValid() {
+		t.Error("expected config to be valid")
+	}
+	if (&Config{Basic: &BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "hash"}, ShareLinks: &ShareLinksConfig{Secret: "too-short"}}).IsValid() {
+		t.Error("expected security config with invalid share links config to be invalid")
+	}
+}
+
+func TestShareLinksConfig_GenerateToken(t *testing.T) {
+	c := &ShareLinksConfig{Secret: strings.Repeat("a", 32), MaxDuration: 24 * time.Hour}
+	scenarios := []struct {
+		name          string
+		groups        []string
+		duration      time.Duration
+		expectedError error
+	}{
+		{
+			name:     "valid",
+			groups:   []string{"core", "frontend"},
+			duration: time.Hour,
+		},
+		{
+			name:          "no-groups",
+			groups:        nil,
+			duration:      time.Hour,
+			expectedError: ErrShareLinkWithoutGroups,
+		},
+		{
+			name:          "duration-zero",
+			groups:        []string{"core"},
+			duration:      0,
+			expectedError: ErrShareLinkDurationTooShort,
+		},
+		{
+			name:          "duration-exceeds-max-duration",
+			groups:        []string{"core"},
+			duration:      25 * time.Hour,
+			expectedError: ErrShareLinkDurationTooLong,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			token, shareLink, err := c.GenerateToken(scenario.groups, scenario.duration)
+			if !errors.Is(err, scenario.expectedError) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			parsedShareLink, err := c.ParseToken(token)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if parsedShareLink.ExpiresAt != shareLink.ExpiresAt || len(parsedShareLink.Groups) != len(scenario.groups) {
+				t.Errorf("expected parsed share link %+v to match generated share link %+v", parsedShareLink, shareLink)
+			}
+			for _, group := range scenario.groups {
+				if !parsedShareLink.HasAccessToGroup(group) {
+					t.Errorf("expected share link to grant access to group %s", group)
+				}
+			}
+			if parsedShareLink.HasAccessToGroup("backend") {
+				t.Error("expected share link not to grant access to group backend")
+			}
+		})
+	}
+}
+
+func TestShareLinksConfig_ParseToken(t *testing.T) {
+	c := &ShareLinksConfig{Secret: strings.Repeat("a", 32)}
+	token, _, _ := c.GenerateToken([]string{"core"}, time.Hour)
+	payload, signature, _ := strings.Cut(token, ".")
+	otherToken, _, _ := (&ShareLinksConfig{Secret: strings.Repeat("b", 32)}).GenerateToken([]string{"backend"}, time.Hour)
+	otherPayload, _, _ := strings.Cut(otherToken, ".")
+	scenarios := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{
+			name:  "valid",
+			token: token,
+		},
+		{
+			name:          "no-signature",
+			token:         payload,
+			expectedError: ErrInvalidShareLinkToken,
+		},
+		{
+			name:          "signed-with-another-secret",
+			token:         otherToken,
+			expectedError: ErrInvalidShareLinkToken,
+		},
+		{
+			name:          "tampered-payload",
+			token:         otherPayload + "." + signature,
+			expectedError: ErrInvalidShareLinkToken,
+		},
+		{
+			name:          "expired",
+			token:         c.generateExpiredToken(),
+			expectedError: ErrShareLinkExpired,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if _, err := c.ParseToken(scenario.token); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func (c *ShareLinksConfig) generateExpiredToken() string {
+	encodedPayload := "eyJncm91cHMiOlsiY29yZSJdLCJleHBpcmVzLWF0IjoxfQ" // {"groups":["core"],"expires-at":1}
+	return encodedPayload + "." + c.sign(encodedPayload)
+}
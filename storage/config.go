@@ -1,12 +1,30 @@
 package storage
 
 import (
+	"encoding/base64"
 	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
 )
 
 var (
 	ErrSQLStorageRequiresPath          = errors.New("sql storage requires a non-empty path to be defined")
 	ErrMemoryStorageDoesNotSupportPath = errors.New("memory storage does not support persistence, use sqlite if you want persistence on file")
+	ErrInvalidBatchSize                = errors.New("storage batch-size must not be negative")
+	ErrEncryptionRequiresSQLStorage    = errors.New("storage encryption is only supported by the sqlite, postgres and mysql storage types")
+	ErrEncryptionKeyNotSpecified       = errors.New("storage encryption requires exactly one of key or key-file to be defined")
+	ErrInvalidEncryptionKey            = errors.New("storage encryption key must be a base64-encoded 32-byte key")
+	ErrRetentionRequiresSQLStorage     = errors.New("storage retention is only supported by the sqlite, postgres and mysql storage types")
+	ErrInvalidRetention                = errors.New("storage retention must be a positive duration, e.g. 36h, 7d or 2y")
+	ErrHourlyRetentionTooShort         = errors.New("storage retention hourly must be at least 2d")
+	ErrDailyRetentionTooShort          = errors.New("storage retention daily must not be shorter than hourly")
+	ErrStorageRouteWithoutGroups       = errors.New("storage route must have at least one group")
+	ErrNestedStorageRoutes             = errors.New("storage route cannot have routes of its own")
+	ErrGroupInMultipleStorageRoutes    = errors.New("storage route group cannot be routed to more than one storage")
 )
 
 // Config is the configuration for storage
@@ -23,8 +41,173 @@ type Config struct {
 	// Caching is whether to enable caching.
 	// This is used to drastically decrease read latency by pre-emptively caching writes
 	// as they happen, also known as the write-through caching strategy.
-	// Does not apply if Config.Type is not TypePostgres or TypeSQLite.
+	// Does not apply if Config.Type is not TypePostgres, TypeMySQL or TypeSQLite.
 	Caching bool `yaml:"caching,omitempty"`
+
+	// Compression is whether to compress large text columns, such as the errors of a result, using zstd.
+	// Does not apply if Config.Type is not TypePostgres, TypeMySQL or TypeSQLite.
+	Compression bool `yaml:"compression,omitempty"`
+
+	// BatchSize is the maximum number of results that may be written in a single transaction.
+	// If greater than 1, results inserted in quick succession are grouped into a single transaction.
+	// Does not apply if Config.Type is not TypePostgres, TypeMySQL or TypeSQLite.
+	BatchSize int `yaml:"batch-size,omitempty"`
+
+	// Encryption is the configuration for encrypting sensitive columns, such as the errors of a result, at rest.
+	// Does not apply if Config.Type is not TypePostgres, TypeMySQL or TypeSQLite.
+	Encryption *EncryptionConfig `yaml:"encryption,omitempty"`
+
+	// Retention is the configuration for how long results and the hourly and daily aggregates of results are kept.
+	// If nil, the last 100 results of each endpoint are kept, along with hourly aggregates for 2 days and daily
+	// aggregates for 90 days.
+	// Does not apply if Config.Type is not TypePostgres, TypeMySQL or TypeSQLite.
+	Retention *RetentionConfig `yaml:"retention,omitempty"`
+
+	// Routes is the list of storages to persist the data of specific endpoint groups in instead of this one,
+	// e.g. for data locality requirements.
+	// The data of endpoints whose group isn't routed anywhere is persisted in the storage defined by this Config.
+	Routes []*RouteConfig `yaml:"routes,omitempty"`
+}
+
+// RouteConfig is the configuration of the storage in which the data of specific endpoint groups is persisted
+type RouteConfig struct {
+	// Groups is the list of endpoint groups whose data is persisted in this storage
+	Groups []string `yaml:"groups"`
+
+	// Config is the configuration of the storage
+	Config `yaml:",inline"`
+}
+
+// EncryptionConfig is the configuration for the encryption of sensitive columns using AES-256-GCM
+type EncryptionConfig struct {
+	// Key is the base64-encoded 32-byte key used to encrypt sensitive columns.
+	// Since environment variables are expanded in the configuration, this can be set to e.g. ${GATUS_STORAGE_KEY}
+	Key string `yaml:"key,omitempty"`
+
+	// KeyFile is the path to a file containing the base64-encoded key, such as a secret mounted by a KMS
+	KeyFile string `yaml:"key-file,omitempty"`
+
+	decodedKey []byte
+}
+
+// ValidateAndSetDefaults validates the encryption configuration and decodes the key
+func (c *EncryptionConfig) ValidateAndSetDefaults() error {
+	if (len(c.Key) == 0) == (len(c.KeyFile) == 0) {
+		return ErrEncryptionKeyNotSpecified
+	}
+	encodedKey := c.Key
+	if len(c.KeyFile) > 0 {
+		data, err := os.ReadFile(c.KeyFile)
+		if err != nil {
+			return err
+		}
+		encodedKey = strings.TrimSpace(string(data))
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != 32 {
+		return ErrInvalidEncryptionKey
+	}
+	c.decodedKey = key
+	return nil
+}
+
+// DecodedKey returns the key used to encrypt sensitive columns.
+// ValidateAndSetDefaults must have been called beforehand.
+func (c *EncryptionConfig) DecodedKey() []byte {
+	return c.decodedKey
+}
+
+// RetentionConfig is the configuration for the retention of results, which are rolled up into hourly aggregates as they
+// are inserted. Hourly aggregates are then merged into daily aggregates once they're older than Hourly, and daily
+// aggregates are deleted once they're older than Daily.
+//
+// Durations are in the format of time.ParseDuration, with support for days (d) and years (y, i.e. 365 days) as well.
+type RetentionConfig struct {
+	// Raw is how long results are kept, e.g. 7d
+	// If blank, the last 100 results of each endpoint are kept, regardless of their age.
+	Raw string `yaml:"raw,omitempty"`
+
+	// Hourly is how long hourly aggregates are kept before being merged into daily aggregates, e.g. 90d
+	// Defaults to 2d, which is also the minimum.
+	Hourly string `yaml:"hourly,omitempty"`
+
+	// Daily is how long daily aggregates are kept, e.g. 2y
+	// Defaults to 90d.
+	Daily string `yaml:"daily,omitempty"`
+
+	raw, hourly, daily time.Duration
+}
+
+const (
+	defaultHourlyRetention = 2 * 24 * time.Hour
+	defaultDailyRetention  = 90 * 24 * time.Hour
+)
+
+// ValidateAndSetDefaults validates the retention configuration and parses its durations
+func (c *RetentionConfig) ValidateAndSetDefaults() error {
+	var err error
+	if len(c.Raw) > 0 {
+		if c.raw, err = parseRetention(c.Raw); err != nil {
+			return err
+		}
+	}
+	c.hourly, c.daily = defaultHourlyRetention, defaultDailyRetention
+	if len(c.Hourly) > 0 {
+		if c.hourly, err = parseRetention(c.Hourly); err != nil {
+			return err
+		}
+		if c.hourly < defaultHourlyRetention {
+			return ErrHourlyRetentionTooShort
+		}
+	}
+	if len(c.Daily) > 0 {
+		if c.daily, err = parseRetention(c.Daily); err != nil {
+			return err
+		}
+	}
+	if c.daily < c.hourly {
+		return ErrDailyRetentionTooShort
+	}
+	return nil
+}
+
+// RawDuration returns how long results are kept, or 0 if results are kept based on their number instead.
+// ValidateAndSetDefaults must have been called beforehand.
+func (c *RetentionConfig) RawDuration() time.Duration {
+	return c.raw
+}
+
+// HourlyDuration returns how long hourly aggregates are kept before being merged into daily aggregates.
+// ValidateAndSetDefaults must have been called beforehand.
+func (c *RetentionConfig) HourlyDuration() time.Duration {
+	return c.hourly
+}
+
+// DailyDuration returns how long daily aggregates are kept.
+// ValidateAndSetDefaults must have been called beforehand.
+func (c *RetentionConfig) DailyDuration() time.Duration {
+	return c.daily
+}
+
+// parseRetention parses a duration that may be expressed in days (e.g. 7d) or years (e.g. 2y)
+func parseRetention(value string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	if unit := value[len(value)-1]; unit == 'd' || unit == 'y' {
+		var number int
+		if number, err = strconv.Atoi(value[:len(value)-1]); err == nil {
+			duration = time.Duration(number) * 24 * time.Hour
+			if unit == 'y' {
+				duration *= 365
+			}
+		}
+	} else {
+		duration, err = time.ParseDuration(value)
+	}
+	if err != nil || duration <= 0 {
+		return 0, ErrInvalidRetention
+	}
+	return duration, nil
 }
 
 // ValidateAndSetDefaults validates the configuration and sets the default values (if applicable)
@@ -32,11 +215,51 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if c.Type == "" {
 		c.Type = TypeMemory
 	}
-	if (c.Type == TypePostgres || c.Type == TypeSQLite) && len(c.Path) == 0 {
+	if (c.Type == TypePostgres || c.Type == TypeMySQL || c.Type == TypeSQLite) && len(c.Path) == 0 {
 		return ErrSQLStorageRequiresPath
 	}
 	if c.Type == TypeMemory && len(c.Path) > 0 {
 		return ErrMemoryStorageDoesNotSupportPath
 	}
+	if c.BatchSize < 0 {
+		return ErrInvalidBatchSize
+	}
+	if c.Encryption != nil {
+		if c.Type != TypePostgres && c.Type != TypeMySQL && c.Type != TypeSQLite {
+			return ErrEncryptionRequiresSQLStorage
+		}
+		if err := c.Encryption.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	if c.Retention != nil {
+		if c.Type != TypePostgres && c.Type != TypeMySQL && c.Type != TypeSQLite {
+			return ErrRetentionRequiresSQLStorage
+		}
+		if err := c.Retention.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	routedGroups := make(map[string]bool)
+	for _, route := range c.Routes {
+		if len(route.Groups) == 0 {
+			return ErrStorageRouteWithoutGroups
+		}
+		if len(route.Routes) > 0 {
+			return ErrNestedStorageRoutes
+		}
+		for _, group := range route.Groups {
+			// Groups are routed based on how they appear in the key of their endpoints, so "EU Core" and "eu-core"
+			// are the same group as far as routing goes
+			sanitizedGroup := endpoint.SanitizeGroup(group)
+			if routedGroups[sanitizedGroup] {
+				return ErrGroupInMultipleStorageRoutes
+			}
+			routedGroups[sanitizedGroup] = true
+		}
+		if err := route.Config.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
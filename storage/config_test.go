@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	validKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedError error
+	}{
+		{
+			name:          "blank",
+			cfg:           &Config{},
+			expectedError: nil,
+		},
+		{
+			name:          "sqlite-without-path",
+			cfg:           &Config{Type: TypeSQLite},
+			expectedError: ErrSQLStorageRequiresPath,
+		},
+		{
+			name:          "routes",
+			cfg:           &Config{Type: TypeSQLite, Path: "us.db", Routes: []*RouteConfig{{Groups: []string{"eu-core", "eu-edge"}, Config: Config{Type: TypeSQLite, Path: "eu.db"}}}},
+			expectedError: nil,
+		},
+		{
+			name:          "route-without-groups",
+			cfg:           &Config{Routes: []*RouteConfig{{Config: Config{Type: TypeSQLite, Path: "eu.db"}}}},
+			expectedError: ErrStorageRouteWithoutGroups,
+		},
+		{
+			name:          "route-with-routes",
+			cfg:           &Config{Routes: []*RouteConfig{{Groups: []string{"eu"}, Config: Config{Routes: []*RouteConfig{{Groups: []string{"fr"}}}}}}},
+			expectedError: ErrNestedStorageRoutes,
+		},
+		{
+			name:          "group-in-multiple-routes",
+			cfg:           &Config{Routes: []*RouteConfig{{Groups: []string{"eu"}}, {Groups: []string{"eu"}}}},
+			expectedError: ErrGroupInMultipleStorageRoutes,
+		},
+		{
+			name:          "group-in-multiple-routes-once-sanitized",
+			cfg:           &Config{Routes: []*RouteConfig{{Groups: []string{"EU Core"}}, {Groups: []string{"eu-core"}}}},
+			expectedError: ErrGroupInMultipleStorageRoutes,
+		},
+		{
+			name:          "route-with-invalid-config",
+			cfg:           &Config{Routes: []*RouteConfig{{Groups: []string{"eu"}, Config: Config{Type: TypeSQLite}}}},
+			expectedError: ErrSQLStorageRequiresPath,
+		},
+		{
+			name:          "memory-with-path",
+			cfg:           &Config{Type: TypeMemory, Path: "data.db"},
+			expectedError: ErrMemoryStorageDoesNotSupportPath,
+		},
+		{
+			name:          "negative-batch-size",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", BatchSize: -1},
+			expectedError: ErrInvalidBatchSize,
+		},
+		{
+			name:          "memory-with-encryption",
+			cfg:           &Config{Type: TypeMemory, Encryption: &EncryptionConfig{Key: validKey}},
+			expectedError: ErrEncryptionRequiresSQLStorage,
+		},
+		{
+			name:          "encryption-without-key",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Encryption: &EncryptionConfig{}},
+			expectedError: ErrEncryptionKeyNotSpecified,
+		},
+		{
+			name:          "encryption-with-key-and-key-file",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Encryption: &EncryptionConfig{Key: validKey, KeyFile: "key"}},
+			expectedError: ErrEncryptionKeyNotSpecified,
+		},
+		{
+			name:          "encryption-with-key-that-is-not-base64",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Encryption: &EncryptionConfig{Key: "not base64"}},
+			expectedError: ErrInvalidEncryptionKey,
+		},
+		{
+			name:          "encryption-with-key-of-invalid-length",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Encryption: &EncryptionConfig{Key: base64.StdEncoding.EncodeToString([]byte("16-bytes-is-bad!"))}},
+			expectedError: ErrInvalidEncryptionKey,
+		},
+		{
+			name:          "encryption-with-valid-key",
+			cfg:           &Config{Type: TypePostgres, Path: "postgres://localhost", Encryption: &EncryptionConfig{Key: validKey}},
+			expectedError: nil,
+		},
+		{
+			name:          "mysql-without-path",
+			cfg:           &Config{Type: TypeMySQL},
+			expectedError: ErrSQLStorageRequiresPath,
+		},
+		{
+			name:          "mysql-with-encryption",
+			cfg:           &Config{Type: TypeMySQL, Path: "gatus:password@tcp(localhost:3306)/gatus", Encryption: &EncryptionConfig{Key: validKey}},
+			expectedError: nil,
+		},
+		{
+			name:          "memory-with-retention",
+			cfg:           &Config{Type: TypeMemory, Retention: &RetentionConfig{Raw: "7d"}},
+			expectedError: ErrRetentionRequiresSQLStorage,
+		},
+		{
+			name:          "retention",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Retention: &RetentionConfig{Raw: "7d", Hourly: "90d", Daily: "2y"}},
+			expectedError: nil,
+		},
+		{
+			name:          "retention-with-invalid-duration",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Retention: &RetentionConfig{Raw: "7 days"}},
+			expectedError: ErrInvalidRetention,
+		},
+		{
+			name:          "retention-with-negative-duration",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Retention: &RetentionConfig{Daily: "-1y"}},
+			expectedError: ErrInvalidRetention,
+		},
+		{
+			name:          "retention-with-hourly-too-short",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Retention: &RetentionConfig{Hourly: "24h"}},
+			expectedError: ErrHourlyRetentionTooShort,
+		},
+		{
+			name:          "retention-with-daily-shorter-than-hourly",
+			cfg:           &Config{Type: TypeSQLite, Path: "data.db", Retention: &RetentionConfig{Hourly: "180d"}},
+			expectedError: ErrDailyRetentionTooShort,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedError {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestEncryptionConfig_ValidateAndSetDefaultsWithKeyFile(t *testing.T) {
+	key := bytes.Repeat([]byte{2}, 32)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	cfg := &EncryptionConfig{KeyFile: keyFile}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !bytes.Equal(cfg.DecodedKey(), key) {
+		t.Error("expected the key to be read from the key file")
+	}
+	if err := (&EncryptionConfig{KeyFile: filepath.Join(t.TempDir(), "missing")}).ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for a key file that does not exist")
+	}
+}
+
+func TestRetentionConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		cfg            *RetentionConfig
+		expectedRaw    time.Duration
+		expectedHourly time.Duration
+		expectedDaily  time.Duration
+	}{
+		{
+			name:           "defaults",
+			cfg:            &RetentionConfig{},
+			expectedRaw:    0,
+			expectedHourly: 2 * 24 * time.Hour,
+			expectedDaily:  90 * 24 * time.Hour,
+		},
+		{
+			name:           "days-and-years",
+			cfg:            &RetentionConfig{Raw: "7d", Hourly: "90d", Daily: "2y"},
+			expectedRaw:    7 * 24 * time.Hour,
+			expectedHourly: 90 * 24 * time.Hour,
+			expectedDaily:  2 * 365 * 24 * time.Hour,
+		},
+		{
+			name:           "go-durations",
+			cfg:            &RetentionConfig{Raw: "36h", Hourly: "72h"},
+			expectedRaw:    36 * time.Hour,
+			expectedHourly: 72 * time.Hour,
+			expectedDaily:  90 * 24 * time.Hour,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if scenario.cfg.RawDuration() != scenario.expectedRaw {
+				t.Errorf("expected raw retention to be %s, got %s", scenario.expectedRaw, scenario.cfg.RawDuration())
+			}
+			if scenario.cfg.HourlyDuration() != scenario.expectedHourly {
+				t.Errorf("expected hourly retention to be %s, got %s", scenario.expectedHourly, scenario.cfg.HourlyDuration())
+			}
+			if scenario.cfg.DailyDuration() != scenario.expectedDaily {
+				t.Errorf("expected daily retention to be %s, got %s", scenario.expectedDaily, scenario.cfg.DailyDuration())
+			}
+		})
+	}
+}
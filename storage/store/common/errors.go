@@ -3,6 +3,10 @@ package common
 import "errors"
 
 var (
-	ErrEndpointNotFound = errors.New("endpoint not found")               // When an endpoint does not exist in the store
-	ErrInvalidTimeRange = errors.New("'from' cannot be older than 'to'") // When an invalid time range is provided
+	ErrEndpointNotFound              = errors.New("endpoint not found")                // When an endpoint does not exist in the store
+	ErrInvalidTimeRange              = errors.New("'from' cannot be older than 'to'")  // When an invalid time range is provided
+	ErrAlertDeliveryNotFound         = errors.New("alert delivery not found")          // When an alert delivery does not exist in the store
+	ErrAlertHistoryEntryNotFound     = errors.New("alert history entry not found")     // When an alert history entry does not exist in the store
+	ErrExternalEndpointTokenNotFound = errors.New("external endpoint token not found") // When an external endpoint token does not exist in the store
+	ErrConfigurationSnapshotNotFound = errors.New("configuration snapshot not found")  // When a configuration snapshot does not exist in the store
 )
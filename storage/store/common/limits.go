@@ -6,4 +6,7 @@ const (
 
 	// MaximumNumberOfEvents is the maximum number of events that an endpoint can have
 	MaximumNumberOfEvents = 50
+
+	// MaximumNumberOfConfigurationSnapshots is the maximum number of configuration snapshots that are kept
+	MaximumNumberOfConfigurationSnapshots = 20
 )
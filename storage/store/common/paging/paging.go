@@ -1,11 +1,27 @@
 package paging
 
+import "time"
+
+// Order defines from which end the results are paged
+type Order string
+
+const (
+	// OrderDescending means that the first page contains the most recent results
+	OrderDescending Order = "desc"
+
+	// OrderAscending means that the first page contains the oldest results
+	OrderAscending Order = "asc"
+)
+
 // EndpointStatusParams represents all parameters that can be used for paging purposes
 type EndpointStatusParams struct {
-	EventsPage      int // Number of the event page
-	EventsPageSize  int // Size of the event page
-	ResultsPage     int // Number of the result page
-	ResultsPageSize int // Size of the result page
+	EventsPage      int       // Number of the event page
+	EventsPageSize  int       // Size of the event page
+	ResultsPage     int       // Number of the result page
+	ResultsPageSize int       // Size of the result page
+	ResultsFrom     time.Time // Results older than this are excluded, unless it's the zero value
+	ResultsTo       time.Time // Results newer than this are excluded, unless it's the zero value
+	ResultsOrder    Order     // Order in which the results are paged. Defaults to OrderDescending if empty
 }
 
 // NewEndpointStatusParams creates a new EndpointStatusParams
@@ -26,3 +42,39 @@ func (params *EndpointStatusParams) WithResults(page, pageSize int) *EndpointSta
 	params.ResultsPageSize = pageSize
 	return params
 }
+
+// WithResultsTimeRange sets the values for ResultsFrom and ResultsTo
+//
+// A zero value for either of them means that the range is unbounded on that side
+func (params *EndpointStatusParams) WithResultsTimeRange(from, to time.Time) *EndpointStatusParams {
+	params.ResultsFrom = from
+	params.ResultsTo = to
+	return params
+}
+
+// WithResultsOrder sets the value for ResultsOrder
+func (params *EndpointStatusParams) WithResultsOrder(order Order) *EndpointStatusParams {
+	params.ResultsOrder = order
+	return params
+}
+
+// HasResultsTimeRange returns whether the results are filtered by ResultsFrom or ResultsTo
+func (params *EndpointStatusParams) HasResultsTimeRange() bool {
+	return !params.ResultsFrom.IsZero() || !params.ResultsTo.IsZero()
+}
+
+// IsResultsTimeRangeValid returns false if ResultsFrom is after ResultsTo
+func (params *EndpointStatusParams) IsResultsTimeRangeValid() bool {
+	return params.ResultsFrom.IsZero() || params.ResultsTo.IsZero() || !params.ResultsFrom.After(params.ResultsTo)
+}
+
+// IsResultInTimeRange returns whether the given timestamp is within the range defined by ResultsFrom and ResultsTo
+func (params *EndpointStatusParams) IsResultInTimeRange(timestamp time.Time) bool {
+	if !params.ResultsFrom.IsZero() && timestamp.Before(params.ResultsFrom) {
+		return false
+	}
+	if !params.ResultsTo.IsZero() && timestamp.After(params.ResultsTo) {
+		return false
+	}
+	return true
+}
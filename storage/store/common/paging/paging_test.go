@@ -1,6 +1,9 @@
 package paging
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestNewEndpointStatusParams(t *testing.T) {
 	type Scenario struct {
@@ -70,3 +73,69 @@ func TestNewEndpointStatusParams(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointStatusParams_WithResultsTimeRange(t *testing.T) {
+	now := time.Now()
+	scenarios := []struct {
+		Name              string
+		Params            *EndpointStatusParams
+		Timestamp         time.Time
+		ExpectedValid     bool
+		ExpectedInRange   bool
+		ExpectedHasFilter bool
+	}{
+		{
+			Name:              "no-time-range",
+			Params:            NewEndpointStatusParams(),
+			Timestamp:         now,
+			ExpectedValid:     true,
+			ExpectedInRange:   true,
+			ExpectedHasFilter: false,
+		},
+		{
+			Name:              "within-time-range",
+			Params:            NewEndpointStatusParams().WithResultsTimeRange(now.Add(-time.Hour), now),
+			Timestamp:         now.Add(-time.Minute),
+			ExpectedValid:     true,
+			ExpectedInRange:   true,
+			ExpectedHasFilter: true,
+		},
+		{
+			Name:              "before-time-range",
+			Params:            NewEndpointStatusParams().WithResultsTimeRange(now.Add(-time.Hour), now),
+			Timestamp:         now.Add(-2 * time.Hour),
+			ExpectedValid:     true,
+			ExpectedInRange:   false,
+			ExpectedHasFilter: true,
+		},
+		{
+			Name:              "after-time-range-without-from",
+			Params:            NewEndpointStatusParams().WithResultsTimeRange(time.Time{}, now.Add(-time.Hour)),
+			Timestamp:         now,
+			ExpectedValid:     true,
+			ExpectedInRange:   false,
+			ExpectedHasFilter: true,
+		},
+		{
+			Name:              "from-after-to",
+			Params:            NewEndpointStatusParams().WithResultsTimeRange(now, now.Add(-time.Hour)),
+			Timestamp:         now,
+			ExpectedValid:     false,
+			ExpectedInRange:   false,
+			ExpectedHasFilter: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if valid := scenario.Params.IsResultsTimeRangeValid(); valid != scenario.ExpectedValid {
+				t.Errorf("expected IsResultsTimeRangeValid to return %v, got %v", scenario.ExpectedValid, valid)
+			}
+			if inRange := scenario.Params.IsResultInTimeRange(scenario.Timestamp); inRange != scenario.ExpectedInRange {
+				t.Errorf("expected IsResultInTimeRange to return %v, got %v", scenario.ExpectedInRange, inRange)
+			}
+			if hasFilter := scenario.Params.HasResultsTimeRange(); hasFilter != scenario.ExpectedHasFilter {
+				t.Errorf("expected HasResultsTimeRange to return %v, got %v", scenario.ExpectedHasFilter, hasFilter)
+			}
+		})
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/snapshot"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gocache/v2"
@@ -17,6 +20,23 @@ type Store struct {
 	sync.RWMutex
 
 	cache *gocache.Cache
+
+	// alertingStates is the alerting state of each endpoint, by key, which the downtime of endpoint statuses is
+	// based on
+	alertingStates map[string]*endpoint.AlertingState
+
+	alertDeliveries     map[int64]*delivery.Delivery
+	lastAlertDeliveryID int64
+
+	alertHistory            map[int64]*history.Entry
+	lastAlertHistoryEntryID int64
+
+	externalEndpointTokens      map[int64]*endpoint.ExternalEndpointToken
+	lastExternalEndpointTokenID int64
+
+	// configurationSnapshots is ordered from the least recent to the most recent snapshot
+	configurationSnapshots           []*snapshot.Snapshot
+	lastConfigurationSnapshotVersion int64
 }
 
 // NewStore creates a new store using gocache.Cache
@@ -25,7 +45,12 @@ type Store struct {
 // supports eventual persistence.
 func NewStore() (*Store, error) {
 	store := &Store{
-		cache: gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		cache:           gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		alertingStates:  make(map[string]*endpoint.AlertingState),
+		alertDeliveries: make(map[int64]*delivery.Delivery),
+		alertHistory:    make(map[int64]*history.Entry),
+
+		externalEndpointTokens: make(map[int64]*endpoint.ExternalEndpointToken),
 	}
 	return store, nil
 }
@@ -33,10 +58,13 @@ func NewStore() (*Store, error) {
 // GetAllEndpointStatuses returns all monitored endpoint.Status
 // with a subset of endpoint.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
+	if !params.IsResultsTimeRangeValid() {
+		return nil, common.ErrInvalidTimeRange
+	}
 	endpointStatuses := s.cache.GetAll()
 	pagedEndpointStatuses := make([]*endpoint.Status, 0, len(endpointStatuses))
 	for _, v := range endpointStatuses {
-		pagedEndpointStatuses = append(pagedEndpointStatuses, ShallowCopyEndpointStatus(v.(*endpoint.Status), params))
+		pagedEndpointStatuses = append(pagedEndpointStatuses, s.setDowntime(ShallowCopyEndpointStatus(v.(*endpoint.Status), params)))
 	}
 	sort.Slice(pagedEndpointStatuses, func(i, j int) bool {
 		return pagedEndpointStatuses[i].Key < pagedEndpointStatuses[j].Key
@@ -51,11 +79,25 @@ func (s *Store) GetEndpointStatus(groupName, endpointName string, params *paging
 
 // GetEndpointStatusByKey returns the endpoint status for a given key
 func (s *Store) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	if !params.IsResultsTimeRangeValid() {
+		return nil, common.ErrInvalidTimeRange
+	}
 	endpointStatus := s.cache.GetValue(key)
 	if endpointStatus == nil {
 		return nil, common.ErrEndpointNotFound
 	}
-	return ShallowCopyEndpointStatus(endpointStatus.(*endpoint.Status), params), nil
+	return s.setDowntime(ShallowCopyEndpointStatus(endpointStatus.(*endpoint.Status), params)), nil
+}
+
+// setDowntime sets the downtime of an endpoint status based on the alerting state of the endpoint, if it has one
+func (s *Store) setDowntime(endpointStatus *endpoint.Status) *endpoint.Status {
+	s.RLock()
+	state, exists := s.alertingStates[endpointStatus.Key]
+	s.RUnlock()
+	if exists {
+		endpointStatus.SetDowntime(state.DownSince, time.Now())
+	}
+	return endpointStatus
 }
 
 // GetUptimeByKey returns the uptime percentage during a time range
@@ -139,6 +181,127 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// GetHourlyUptimeByKey returns a map of hourly (key) uptime (value), as a value between 0 and 1, during a time range
+func (s *Store) GetHourlyUptimeByKey(key string, from, to time.Time) (map[int64]float64, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	hourlyUptimes := make(map[int64]float64)
+	current := from
+	for to.Sub(current) >= 0 {
+		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
+		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
+		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
+			current = current.Add(time.Hour)
+			continue
+		}
+		hourlyUptimes[hourlyUnixTimestamp] = float64(hourlyStats.SuccessfulExecutions) / float64(hourlyStats.TotalExecutions)
+		current = current.Add(time.Hour)
+	}
+	return hourlyUptimes, nil
+}
+
+// GetDailyUptimeStatisticsByKey returns a map of daily (key) uptime statistics (value) during a time range
+func (s *Store) GetDailyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.DailyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	dailyUptimeStatistics := make(map[int64]*endpoint.DailyUptimeStatistics)
+	current := from
+	for to.Sub(current) >= 0 {
+		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
+		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
+		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
+			current = current.Add(time.Hour)
+			continue
+		}
+		dailyUnixTimestamp := endpoint.TruncateToDay(current).Unix()
+		dailyStats := dailyUptimeStatistics[dailyUnixTimestamp]
+		if dailyStats == nil {
+			dailyStats = &endpoint.DailyUptimeStatistics{}
+			dailyUptimeStatistics[dailyUnixTimestamp] = dailyStats
+		}
+		dailyStats.TotalExecutions += hourlyStats.TotalExecutions
+		dailyStats.SuccessfulExecutions += hourlyStats.SuccessfulExecutions
+		dailyStats.TotalExecutionsResponseTime += hourlyStats.TotalExecutionsResponseTime
+		dailyStats.Incidents += hourlyStats.Incidents
+		current = current.Add(time.Hour)
+	}
+	return dailyUptimeStatistics, nil
+}
+
+// GetServiceLevelStatisticsByKey returns the number of events relevant to the service level objective of an endpoint during a time range
+func (s *Store) GetServiceLevelStatisticsByKey(key string, from, to time.Time) (*endpoint.ServiceLevelStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*endpoint.Status).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	statistics := &endpoint.ServiceLevelStatistics{}
+	current := from
+	for to.Sub(current) >= 0 {
+		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
+		hourlyStats := endpointStatus.(*endpoint.Status).Uptime.HourlyStatistics[hourlyUnixTimestamp]
+		if hourlyStats == nil || hourlyStats.TotalExecutions == 0 {
+			current = current.Add(time.Hour)
+			continue
+		}
+		statistics.TotalEvents += hourlyStats.TotalExecutions
+		statistics.GoodEvents += hourlyStats.SuccessfulExecutions - hourlyStats.SlowExecutions
+		current = current.Add(time.Hour)
+	}
+	return statistics, nil
+}
+
+// GetFailureBreakdownByKey returns the number of unsuccessful results (value) by failure reason (key) during a time range
+func (s *Store) GetFailureBreakdownByKey(key string, from, to time.Time) (map[endpoint.FailureReason]int, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	s.RLock()
+	defer s.RUnlock()
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	failureBreakdown := make(map[endpoint.FailureReason]int)
+	for _, result := range endpointStatus.(*endpoint.Status).Results {
+		// Results stored before failures were classified don't have a reason
+		if result.Success || len(result.FailureReason) == 0 || result.Timestamp.Before(from) || result.Timestamp.After(to) {
+			continue
+		}
+		failureBreakdown[result.FailureReason]++
+	}
+	return failureBreakdown, nil
+}
+
+// InsertUptime adds the observed result for the specified endpoint into the uptime data without storing the result
+// itself. If the endpoint isn't in the store yet, the result is inserted as it would be by Insert.
+func (s *Store) InsertUptime(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	key := ep.Key()
+	s.Lock()
+	status, exists := s.cache.Get(key)
+	if !exists {
+		s.Unlock()
+		return s.Insert(ep, result)
+	}
+	// Results discarded by sampling are never a change in health, so they can't be an incident
+	processUptimeAfterResult(status.(*endpoint.Status).Uptime, result, false)
+	s.cache.Set(key, status)
+	s.Unlock()
+	return nil
+}
+
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	key := ep.Key()
@@ -146,6 +309,7 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	status, exists := s.cache.Get(key)
 	if !exists {
 		status = endpoint.NewStatus(ep.Group, ep.Name)
+		status.(*endpoint.Status).Key = key
 		status.(*endpoint.Status).Events = append(status.(*endpoint.Status).Events, &endpoint.Event{
 			Type:      endpoint.EventStart,
 			Timestamp: time.Now(),
@@ -172,6 +336,11 @@ func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 			keysToDelete = append(keysToDelete, existingKey)
 		}
 	}
+	s.Lock()
+	for _, key := range keysToDelete {
+		delete(s.alertingStates, key)
+	}
+	s.Unlock()
 	return s.cache.DeleteAll(keysToDelete)
 }
 
@@ -206,9 +375,294 @@ func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.En
 	return 0
 }
 
+// GetEndpointAlertingState returns the alerting state persisted for the specified endpoint, or nil if there is none
+//
+// Note that for the in-memory store, the alerting state is lost if the application restarts
+func (s *Store) GetEndpointAlertingState(ep *endpoint.Endpoint) (*endpoint.AlertingState, error) {
+	s.RLock()
+	defer s.RUnlock()
+	state, exists := s.alertingStates[ep.Key()]
+	if !exists {
+		return nil, nil
+	}
+	stateCopy := *state
+	return &stateCopy, nil
+}
+
+// UpsertEndpointAlertingState inserts/updates the alerting state of an endpoint
+// Used for persistence of the number of failures and successes in a row across application restarts
+//
+// Note that for the in-memory store, the alerting state is lost if the application restarts
+func (s *Store) UpsertEndpointAlertingState(ep *endpoint.Endpoint) error {
+	s.Lock()
+	defer s.Unlock()
+	s.alertingStates[ep.Key()] = ep.AlertingState()
+	return nil
+}
+
+// InsertAlertDelivery queues an alert to be delivered and sets the ID of the delivery passed
+//
+// Note that for the in-memory store, queued alerts are lost if the application restarts
+func (s *Store) InsertAlertDelivery(d *delivery.Delivery) error {
+	s.Lock()
+	defer s.Unlock()
+	s.lastAlertDeliveryID++
+	d.ID = s.lastAlertDeliveryID
+	deliveryCopy := *d
+	s.alertDeliveries[d.ID] = &deliveryCopy
+	return nil
+}
+
+// UpdateAlertDelivery updates the state of an alert delivery and the attempts made to deliver it
+func (s *Store) UpdateAlertDelivery(d *delivery.Delivery) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.alertDeliveries[d.ID]; !exists {
+		return common.ErrAlertDeliveryNotFound
+	}
+	deliveryCopy := *d
+	s.alertDeliveries[d.ID] = &deliveryCopy
+	return nil
+}
+
+// DeleteAlertDelivery removes an alert delivery, which is done once the alert has been delivered
+func (s *Store) DeleteAlertDelivery(id int64) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.alertDeliveries[id]; !exists {
+		return common.ErrAlertDeliveryNotFound
+	}
+	delete(s.alertDeliveries, id)
+	return nil
+}
+
+// GetAlertDeliveryByID returns the alert delivery with the ID passed
+func (s *Store) GetAlertDeliveryByID(id int64) (*delivery.Delivery, error) {
+	s.RLock()
+	defer s.RUnlock()
+	d, exists := s.alertDeliveries[id]
+	if !exists {
+		return nil, common.ErrAlertDeliveryNotFound
+	}
+	deliveryCopy := *d
+	return &deliveryCopy, nil
+}
+
+// GetAlertDeliveries returns every alert delivery, including the ones that have been dead-lettered, ordered by ID
+func (s *Store) GetAlertDeliveries() ([]*delivery.Delivery, error) {
+	s.RLock()
+	defer s.RUnlock()
+	deliveries := make([]*delivery.Delivery, 0, len(s.alertDeliveries))
+	for _, d := range s.alertDeliveries {
+		deliveryCopy := *d
+		deliveries = append(deliveries, &deliveryCopy)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	return deliveries, nil
+}
+
+// InsertAlertHistoryEntry persists an entry of the alert history and sets the ID of the entry passed
+//
+// Note that for the in-memory store, the alert history is lost if the application restarts
+func (s *Store) InsertAlertHistoryEntry(e *history.Entry) error {
+	s.Lock()
+	defer s.Unlock()
+	s.lastAlertHistoryEntryID++
+	e.ID = s.lastAlertHistoryEntryID
+	s.alertHistory[e.ID] = copyAlertHistoryEntry(e)
+	return nil
+}
+
+// UpdateAlertHistoryEntry updates the resolution and the delivery summaries of an entry of the alert history
+func (s *Store) UpdateAlertHistoryEntry(e *history.Entry) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.alertHistory[e.ID]; !exists {
+		return common.ErrAlertHistoryEntryNotFound
+	}
+	s.alertHistory[e.ID] = copyAlertHistoryEntry(e)
+	return nil
+}
+
+// DeleteAlertHistoryEntry removes an entry of the alert history
+func (s *Store) DeleteAlertHistoryEntry(id int64) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, exists := s.alertHistory[id]; !exists {
+		return common.ErrAlertHistoryEntryNotFound
+	}
+	delete(s.alertHistory, id)
+	return nil
+}
+
+// GetAlertHistoryEntryByID returns the entry of the alert history with the ID passed
+func (s *Store) GetAlertHistoryEntryByID(id int64) (*history.Entry, error) {
+	s.RLock()
+	defer s.RUnlock()
+	e, exists := s.alertHistory[id]
+	if !exists {
+		return nil, common.ErrAlertHistoryEntryNotFound
+	}
+	return copyAlertHistoryEntry(e), nil
+}
+
+// GetUnresolvedAlertHistoryEntry returns the entry of the alert history of an alert of an endpoint that is still
+// triggered
+func (s *Store) GetUnresolvedAlertHistoryEntry(endpointKey, alertChecksum string) (*history.Entry, error) {
+	s.RLock()
+	defer s.RUnlock()
+	var unresolvedEntry *history.Entry
+	for _, e := range s.alertHistory {
+		if e.ResolvedAt == nil && e.EndpointKey == endpointKey && e.AlertChecksum == alertChecksum && (unresolvedEntry == nil || e.ID > unresolvedEntry.ID) {
+			unresolvedEntry = e
+		}
+	}
+	if unresolvedEntry == nil {
+		return nil, common.ErrAlertHistoryEntryNotFound
+	}
+	return copyAlertHistoryEntry(unresolvedEntry), nil
+}
+
+// GetAlertHistory returns the entries of the alert history of the alerts that were triggered at some point within
+// the time range passed, ordered from the most recently triggered to the least recently triggered
+func (s *Store) GetAlertHistory(from, to time.Time) ([]*history.Entry, error) {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	s.RLock()
+	defer s.RUnlock()
+	entries := make([]*history.Entry, 0, len(s.alertHistory))
+	for _, e := range s.alertHistory {
+		if e.IsInTimeRange(from, to) {
+			entries = append(entries, copyAlertHistoryEntry(e))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].TriggeredAt.Equal(entries[j].TriggeredAt) {
+			return entries[i].ID > entries[j].ID
+		}
+		return entries[i].TriggeredAt.After(entries[j].TriggeredAt)
+	})
+	return entries, nil
+}
+
+// copyAlertHistoryEntry returns a copy of an entry of the alert history that doesn't share its resolution with the
+// original
+func copyAlertHistoryEntry(e *history.Entry) *history.Entry {
+	entryCopy := *e
+	if e.ResolvedAt != nil {
+		resolvedAt := *e.ResolvedAt
+		entryCopy.ResolvedAt = &resolvedAt
+	}
+	if e.ResolvedDelivery != nil {
+		resolvedDelivery := *e.ResolvedDelivery
+		entryCopy.ResolvedDelivery = &resolvedDelivery
+	}
+	return &entryCopy
+}
+
+// InsertExternalEndpointToken persists a token of an external endpoint and sets the ID of the token passed
+//
+// Note that for the in-memory store, tokens are lost if the application restarts
+func (s *Store) InsertExternalEndpointToken(t *endpoint.ExternalEndpointToken) error {
+	s.Lock()
+	defer s.Unlock()
+	s.lastExternalEndpointTokenID++
+	t.ID = s.lastExternalEndpointTokenID
+	tokenCopy := *t
+	s.externalEndpointTokens[t.ID] = &tokenCopy
+	return nil
+}
+
+// GetExternalEndpointTokens returns every token of an external endpoint, including the ones that expired or were
+// revoked, ordered by ID
+func (s *Store) GetExternalEndpointTokens(endpointKey string) ([]*endpoint.ExternalEndpointToken, error) {
+	s.RLock()
+	defer s.RUnlock()
+	tokens := make([]*endpoint.ExternalEndpointToken, 0)
+	for _, t := range s.externalEndpointTokens {
+		if t.EndpointKey == endpointKey {
+			tokenCopy := *t
+			tokens = append(tokens, &tokenCopy)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].ID < tokens[j].ID
+	})
+	return tokens, nil
+}
+
+// RevokeExternalEndpointToken revokes a token of an external endpoint
+func (s *Store) RevokeExternalEndpointToken(endpointKey string, id int64) error {
+	s.Lock()
+	defer s.Unlock()
+	t, exists := s.externalEndpointTokens[id]
+	if !exists || t.EndpointKey != endpointKey {
+		return common.ErrExternalEndpointTokenNotFound
+	}
+	if t.RevokedAt == nil {
+		revokedAt := time.Now().UTC().Truncate(time.Second)
+		tokenCopy := *t
+		tokenCopy.RevokedAt = &revokedAt
+		s.externalEndpointTokens[id] = &tokenCopy
+	}
+	return nil
+}
+
+// InsertConfigurationSnapshot persists a snapshot of a configuration that has been applied and sets the version of
+// the snapshot passed
+//
+// Note that for the in-memory store, snapshots are lost if the application restarts or if the configuration is reloaded
+func (s *Store) InsertConfigurationSnapshot(configurationSnapshot *snapshot.Snapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	s.lastConfigurationSnapshotVersion++
+	configurationSnapshot.Version = s.lastConfigurationSnapshotVersion
+	snapshotCopy := *configurationSnapshot
+	s.configurationSnapshots = append(s.configurationSnapshots, &snapshotCopy)
+	if len(s.configurationSnapshots) > common.MaximumNumberOfConfigurationSnapshots {
+		s.configurationSnapshots = s.configurationSnapshots[len(s.configurationSnapshots)-common.MaximumNumberOfConfigurationSnapshots:]
+	}
+	return nil
+}
+
+// GetConfigurationSnapshots returns every configuration snapshot, ordered from the most recent to the least recent
+func (s *Store) GetConfigurationSnapshots() ([]*snapshot.Snapshot, error) {
+	s.RLock()
+	defer s.RUnlock()
+	snapshots := make([]*snapshot.Snapshot, 0, len(s.configurationSnapshots))
+	for i := len(s.configurationSnapshots) - 1; i >= 0; i-- {
+		snapshotCopy := *s.configurationSnapshots[i]
+		snapshots = append(snapshots, &snapshotCopy)
+	}
+	return snapshots, nil
+}
+
+// GetConfigurationSnapshot returns the configuration snapshot with the version passed
+func (s *Store) GetConfigurationSnapshot(version int64) (*snapshot.Snapshot, error) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, configurationSnapshot := range s.configurationSnapshots {
+		if configurationSnapshot.Version == version {
+			snapshotCopy := *configurationSnapshot
+			return &snapshotCopy, nil
+		}
+	}
+	return nil, common.ErrConfigurationSnapshotNotFound
+}
+
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	s.cache.Clear()
+	s.Lock()
+	s.alertingStates = make(map[string]*endpoint.AlertingState)
+	s.alertDeliveries = make(map[int64]*delivery.Delivery)
+	s.alertHistory = make(map[int64]*history.Entry)
+	s.externalEndpointTokens = make(map[int64]*endpoint.ExternalEndpointToken)
+	s.configurationSnapshots = nil
+	s.Unlock()
 }
 
 // Save persists the cache to the store file
@@ -7,13 +7,13 @@ import (
 )
 
 const (
-	uptimeCleanUpThreshold = 32 * 24
-	uptimeRetention        = 30 * 24 * time.Hour
+	uptimeCleanUpThreshold = 92 * 24
+	uptimeRetention        = 90 * 24 * time.Hour
 )
 
 // processUptimeAfterResult processes the result by extracting the relevant from the result and recalculating the uptime
-// if necessary
-func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result) {
+// if necessary. isIncident must be true if the result marks the endpoint going from healthy to unhealthy.
+func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result, isIncident bool) {
 	if uptime.HourlyStatistics == nil {
 		uptime.HourlyStatistics = make(map[int64]*endpoint.HourlyUptimeStatistics)
 	}
@@ -27,11 +27,17 @@ func processUptimeAfterResult(uptime *endpoint.Uptime, result *endpoint.Result)
 		hourlyStats.SuccessfulExecutions++
 	}
 	hourlyStats.TotalExecutions++
+	if isIncident {
+		hourlyStats.Incidents++
+	}
+	if result.ExceededResponseTimeObjective {
+		hourlyStats.SlowExecutions++
+	}
 	hourlyStats.TotalExecutionsResponseTime += uint64(result.Duration.Milliseconds())
 	// Clean up only when we're starting to have too many useless keys
 	// Note that this is only triggered when there are more entries than there should be after
-	// 32 days, despite the fact that we are deleting everything that's older than 30 days.
-	// This is to prevent re-iterating on every `processUptimeAfterResult` as soon as the uptime has been logged for 30 days.
+	// 92 days, despite the fact that we are deleting everything that's older than 90 days.
+	// This is to prevent re-iterating on every `processUptimeAfterResult` as soon as the uptime has been logged for 90 days.
 	if len(uptime.HourlyStatistics) > uptimeCleanUpThreshold {
 		sevenDaysAgo := time.Now().Add(-(uptimeRetention + time.Hour)).Unix()
 		for hourlyUnixTimestamp := range uptime.HourlyStatistics {
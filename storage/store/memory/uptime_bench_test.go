@@ -18,7 +18,7 @@ func BenchmarkProcessUptimeAfterResult(b *testing.B) {
 			Duration:  18 * time.Millisecond,
 			Success:   n%15 == 0,
 			Timestamp: timestamp,
-		})
+		}, false)
 		// Simulate an endpoint with an interval of 3 minutes
 		timestamp = timestamp.Add(3 * time.Minute)
 	}
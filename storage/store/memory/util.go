@@ -1,13 +1,15 @@
 package memory
 
 import (
+	"time"
+
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
 // ShallowCopyEndpointStatus returns a shallow copy of a Status with only the results
-// within the range defined by the page and pageSize parameters
+// within the range defined by the page and pageSize parameters, as well as the time range and the order, if any
 func ShallowCopyEndpointStatus(ss *endpoint.Status, params *paging.EndpointStatusParams) *endpoint.Status {
 	shallowCopy := &endpoint.Status{
 		Name:   ss.Name,
@@ -15,12 +17,26 @@ func ShallowCopyEndpointStatus(ss *endpoint.Status, params *paging.EndpointStatu
 		Key:    ss.Key,
 		Uptime: endpoint.NewUptime(),
 	}
-	numberOfResults := len(ss.Results)
-	resultsStart, resultsEnd := getStartAndEndIndex(numberOfResults, params.ResultsPage, params.ResultsPageSize)
+	results := ss.Results
+	if params.HasResultsTimeRange() {
+		results = make([]*endpoint.Result, 0, len(ss.Results))
+		for _, result := range ss.Results {
+			if params.IsResultInTimeRange(result.Timestamp) {
+				results = append(results, result)
+			}
+		}
+	}
+	numberOfResults := len(results)
+	var resultsStart, resultsEnd int
+	if params.ResultsOrder == paging.OrderAscending {
+		resultsStart, resultsEnd = getStartAndEndIndexFromOldest(numberOfResults, params.ResultsPage, params.ResultsPageSize)
+	} else {
+		resultsStart, resultsEnd = getStartAndEndIndex(numberOfResults, params.ResultsPage, params.ResultsPageSize)
+	}
 	if resultsStart < 0 || resultsEnd < 0 {
 		shallowCopy.Results = []*endpoint.Result{}
 	} else {
-		shallowCopy.Results = ss.Results[resultsStart:resultsEnd]
+		shallowCopy.Results = results[resultsStart:resultsEnd]
 	}
 	numberOfEvents := len(ss.Events)
 	eventsStart, eventsEnd := getStartAndEndIndex(numberOfEvents, params.EventsPage, params.EventsPageSize)
@@ -29,6 +45,9 @@ func ShallowCopyEndpointStatus(ss *endpoint.Status, params *paging.EndpointStatu
 	} else {
 		shallowCopy.Events = ss.Events[eventsStart:eventsEnd]
 	}
+	if numberOfEvents > 0 {
+		shallowCopy.SetDowntimeFromLastEvent(ss.Events[numberOfEvents-1], time.Now())
+	}
 	return shallowCopy
 }
 
@@ -49,12 +68,31 @@ func getStartAndEndIndex(numberOfResults int, page, pageSize int) (int, int) {
 	return start, end
 }
 
+// getStartAndEndIndexFromOldest is the same as getStartAndEndIndex, except that the first page starts from the
+// oldest element instead of the most recent one
+func getStartAndEndIndexFromOldest(numberOfResults int, page, pageSize int) (int, int) {
+	if page < 1 || pageSize < 0 {
+		return -1, -1
+	}
+	start := (page - 1) * pageSize
+	end := page * pageSize
+	if start >= numberOfResults {
+		return -1, -1
+	}
+	if end > numberOfResults {
+		end = numberOfResults
+	}
+	return start, end
+}
+
 // AddResult adds a Result to Status.Results and makes sure that there are
 // no more than MaximumNumberOfResults results in the Results slice
 func AddResult(ss *endpoint.Status, result *endpoint.Result) {
 	if ss == nil {
 		return
 	}
+	// The endpoint is considered to have had an incident if it was healthy, or if it had no result yet, and it no longer is
+	isIncident := !result.Success && (len(ss.Results) == 0 || ss.Results[len(ss.Results)-1].Success)
 	if len(ss.Results) > 0 {
 		// Check if there's any change since the last result
 		if ss.Results[len(ss.Results)-1].Success != result.Success {
@@ -77,5 +115,5 @@ func AddResult(ss *endpoint.Status, result *endpoint.Result) {
 		// MaximumNumberOfResults by using ss.Results[len(ss.Results)-MaximumNumberOfResults:] instead
 		ss.Results = ss.Results[len(ss.Results)-common.MaximumNumberOfResults:]
 	}
-	processUptimeAfterResult(ss.Uptime, result)
+	processUptimeAfterResult(ss.Uptime, result, isIncident)
 }
@@ -0,0 +1,389 @@
+package store
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/snapshot"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+// Router is a Store that persists the data of each endpoint, including its alert deliveries, alert history and
+// external endpoint tokens, in the store its group is routed to, or in the default store if its group isn't routed anywhere.
+//
+// Since keys only contain the sanitized group of their endpoint (see endpoint.ExtractGroupFromKey), groups are routed
+// based on their sanitized form.
+type Router struct {
+	defaultStore  Store
+	storesByGroup map[string]Store
+
+	// stores is the list of every store, starting with the default store.
+	// The index of a store in this list is used to make the IDs of alert deliveries and of alert history entries
+	// unique across stores.
+	stores []Store
+}
+
+// NewRouter creates a new Router
+func NewRouter(defaultStore Store, storesByGroup map[string]Store) *Router {
+	router := &Router{
+		defaultStore:  defaultStore,
+		storesByGroup: make(map[string]Store),
+		stores:        []Store{defaultStore},
+	}
+	// The groups are sorted so that the order of the stores, and thus the IDs of alert deliveries, are stable
+	groups := make([]string, 0, len(storesByGroup))
+	for group := range storesByGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		s := storesByGroup[group]
+		router.storesByGroup[endpoint.SanitizeGroup(group)] = s
+		isKnown := false
+		for _, knownStore := range router.stores {
+			if knownStore == s {
+				isKnown = true
+				break
+			}
+		}
+		if !isKnown {
+			router.stores = append(router.stores, s)
+		}
+	}
+	return router
+}
+
+// storeOf returns the store in which the data of the endpoints in the given group is persisted
+func (r *Router) storeOf(group string) Store {
+	if s, exists := r.storesByGroup[endpoint.SanitizeGroup(group)]; exists {
+		return s
+	}
+	return r.defaultStore
+}
+
+// storeOfKey returns the store in which the data of the endpoint with the given key is persisted
+func (r *Router) storeOfKey(key string) Store {
+	if s, exists := r.storesByGroup[endpoint.ExtractGroupFromKey(key)]; exists {
+		return s
+	}
+	return r.defaultStore
+}
+
+// toRoutedID converts the ID of an alert delivery or of an alert history entry in the store at the given index to an
+// ID that is unique across all stores
+func (r *Router) toRoutedID(storeIndex int, id int64) int64 {
+	return id*int64(len(r.stores)) + int64(storeIndex)
+}
+
+// fromRoutedID returns the store of an alert delivery or of an alert history entry and its ID in that store based on
+// the ID returned by toRoutedID
+func (r *Router) fromRoutedID(id int64) (s Store, storeIndex int, storeID int64) {
+	storeIndex = int(id % int64(len(r.stores)))
+	return r.stores[storeIndex], storeIndex, id / int64(len(r.stores))
+}
+
+// indexOf returns the index of a store in the list of stores
+func (r *Router) indexOf(s Store) int {
+	for i, knownStore := range r.stores {
+		if knownStore == s {
+			return i
+		}
+	}
+	return 0
+}
+
+// GetAllEndpointStatuses returns the JSON encoding of all monitored endpoint.Status
+// with a subset of endpoint.Result defined by the page and pageSize parameters
+func (r *Router) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
+	var endpointStatuses []*endpoint.Status
+	for _, s := range r.stores {
+		statuses, err := s.GetAllEndpointStatuses(params)
+		if err != nil {
+			return nil, err
+		}
+		endpointStatuses = append(endpointStatuses, statuses...)
+	}
+	sort.Slice(endpointStatuses, func(i, j int) bool {
+		return endpointStatuses[i].Key < endpointStatuses[j].Key
+	})
+	return endpointStatuses, nil
+}
+
+// GetEndpointStatus returns the endpoint status for a given endpoint name in the given group
+func (r *Router) GetEndpointStatus(groupName, endpointName string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	return r.storeOf(groupName).GetEndpointStatus(groupName, endpointName, params)
+}
+
+// GetEndpointStatusByKey returns the endpoint status for a given key
+func (r *Router) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	return r.storeOfKey(key).GetEndpointStatusByKey(key, params)
+}
+
+// GetUptimeByKey returns the uptime percentage during a time range
+func (r *Router) GetUptimeByKey(key string, from, to time.Time) (float64, error) {
+	return r.storeOfKey(key).GetUptimeByKey(key, from, to)
+}
+
+// GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
+func (r *Router) GetAverageResponseTimeByKey(key string, from, to time.Time) (int, error) {
+	return r.storeOfKey(key).GetAverageResponseTimeByKey(key, from, to)
+}
+
+// GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
+func (r *Router) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error) {
+	return r.storeOfKey(key).GetHourlyAverageResponseTimeByKey(key, from, to)
+}
+
+// GetHourlyUptimeByKey returns a map of hourly (key) uptime (value), as a value between 0 and 1, during a time range
+func (r *Router) GetHourlyUptimeByKey(key string, from, to time.Time) (map[int64]float64, error) {
+	return r.storeOfKey(key).GetHourlyUptimeByKey(key, from, to)
+}
+
+// GetDailyUptimeStatisticsByKey returns a map of daily (key) uptime statistics (value) during a time range
+func (r *Router) GetDailyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.DailyUptimeStatistics, error) {
+	return r.storeOfKey(key).GetDailyUptimeStatisticsByKey(key, from, to)
+}
+
+// GetServiceLevelStatisticsByKey returns the number of events relevant to the service level objective of an endpoint during a time range
+func (r *Router) GetServiceLevelStatisticsByKey(key string, from, to time.Time) (*endpoint.ServiceLevelStatistics, error) {
+	return r.storeOfKey(key).GetServiceLevelStatisticsByKey(key, from, to)
+}
+
+// GetFailureBreakdownByKey returns the number of unsuccessful results (value) by failure reason (key) during a time range
+func (r *Router) GetFailureBreakdownByKey(key string, from, to time.Time) (map[endpoint.FailureReason]int, error) {
+	return r.storeOfKey(key).GetFailureBreakdownByKey(key, from, to)
+}
+
+// Insert adds the observed result for the specified endpoint into the store its group is routed to
+func (r *Router) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	return r.storeOf(ep.Group).Insert(ep, result)
+}
+
+// InsertUptime adds the observed result for the specified endpoint into the uptime data of the store its group is
+// routed to, without storing the result itself
+func (r *Router) InsertUptime(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	return r.storeOf(ep.Group).InsertUptime(ep, result)
+}
+
+// DeleteAllEndpointStatusesNotInKeys removes all Status that are not within the keys provided from every store
+func (r *Router) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
+	numberOfEndpointStatusesDeleted := 0
+	for _, s := range r.stores {
+		numberOfEndpointStatusesDeleted += s.DeleteAllEndpointStatusesNotInKeys(keys)
+	}
+	return numberOfEndpointStatusesDeleted
+}
+
+// GetTriggeredEndpointAlert returns whether the triggered alert for the specified endpoint as well as the necessary information to resolve it
+func (r *Router) GetTriggeredEndpointAlert(ep *endpoint.Endpoint, alert *alert.Alert) (exists bool, resolveKey string, numberOfSuccessesInARow int, err error) {
+	return r.storeOf(ep.Group).GetTriggeredEndpointAlert(ep, alert)
+}
+
+// UpsertTriggeredEndpointAlert inserts/updates a triggered alert for an endpoint
+func (r *Router) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
+	return r.storeOf(ep.Group).UpsertTriggeredEndpointAlert(ep, triggeredAlert)
+}
+
+// DeleteTriggeredEndpointAlert deletes a triggered alert for an endpoint
+func (r *Router) DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAlert *alert.Alert) error {
+	return r.storeOf(ep.Group).DeleteTriggeredEndpointAlert(ep, triggeredAlert)
+}
+
+// DeleteAllTriggeredAlertsNotInChecksumsByEndpoint removes all triggered alerts owned by an endpoint whose alert
+// configurations are not provided in the checksums list.
+func (r *Router) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.Endpoint, checksums []string) int {
+	return r.storeOf(ep.Group).DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep, checksums)
+}
+
+// GetEndpointAlertingState returns the alerting state persisted for the specified endpoint, or nil if there is none
+func (r *Router) GetEndpointAlertingState(ep *endpoint.Endpoint) (*endpoint.AlertingState, error) {
+	return r.storeOf(ep.Group).GetEndpointAlertingState(ep)
+}
+
+// UpsertEndpointAlertingState inserts/updates the alerting state of an endpoint
+func (r *Router) UpsertEndpointAlertingState(ep *endpoint.Endpoint) error {
+	return r.storeOf(ep.Group).UpsertEndpointAlertingState(ep)
+}
+
+// InsertAlertDelivery queues an alert to be delivered and sets the ID of the delivery passed
+func (r *Router) InsertAlertDelivery(d *delivery.Delivery) error {
+	s := r.storeOfKey(d.EndpointKey)
+	if err := s.InsertAlertDelivery(d); err != nil {
+		return err
+	}
+	d.ID = r.toRoutedID(r.indexOf(s), d.ID)
+	return nil
+}
+
+// UpdateAlertDelivery updates the state of an alert delivery and the attempts made to deliver it
+func (r *Router) UpdateAlertDelivery(d *delivery.Delivery) error {
+	s, _, storeID := r.fromRoutedID(d.ID)
+	deliveryCopy := *d
+	deliveryCopy.ID = storeID
+	return s.UpdateAlertDelivery(&deliveryCopy)
+}
+
+// DeleteAlertDelivery removes an alert delivery
+func (r *Router) DeleteAlertDelivery(id int64) error {
+	s, _, storeID := r.fromRoutedID(id)
+	return s.DeleteAlertDelivery(storeID)
+}
+
+// GetAlertDeliveryByID returns the alert delivery with the ID passed
+func (r *Router) GetAlertDeliveryByID(id int64) (*delivery.Delivery, error) {
+	s, storeIndex, storeID := r.fromRoutedID(id)
+	d, err := s.GetAlertDeliveryByID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	d.ID = r.toRoutedID(storeIndex, d.ID)
+	return d, nil
+}
+
+// GetAlertDeliveries returns every alert delivery, including the ones that have been dead-lettered, ordered by ID
+func (r *Router) GetAlertDeliveries() ([]*delivery.Delivery, error) {
+	var deliveries []*delivery.Delivery
+	for storeIndex, s := range r.stores {
+		storeDeliveries, err := s.GetAlertDeliveries()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range storeDeliveries {
+			d.ID = r.toRoutedID(storeIndex, d.ID)
+		}
+		deliveries = append(deliveries, storeDeliveries...)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	return deliveries, nil
+}
+
+// InsertAlertHistoryEntry persists an entry of the alert history and sets the ID of the entry passed
+func (r *Router) InsertAlertHistoryEntry(e *history.Entry) error {
+	s := r.storeOfKey(e.EndpointKey)
+	if err := s.InsertAlertHistoryEntry(e); err != nil {
+		return err
+	}
+	e.ID = r.toRoutedID(r.indexOf(s), e.ID)
+	return nil
+}
+
+// UpdateAlertHistoryEntry updates the resolution and the delivery summaries of an entry of the alert history
+func (r *Router) UpdateAlertHistoryEntry(e *history.Entry) error {
+	s, _, storeID := r.fromRoutedID(e.ID)
+	entryCopy := *e
+	entryCopy.ID = storeID
+	return s.UpdateAlertHistoryEntry(&entryCopy)
+}
+
+// DeleteAlertHistoryEntry removes an entry of the alert history
+func (r *Router) DeleteAlertHistoryEntry(id int64) error {
+	s, _, storeID := r.fromRoutedID(id)
+	return s.DeleteAlertHistoryEntry(storeID)
+}
+
+// GetAlertHistoryEntryByID returns the entry of the alert history with the ID passed
+func (r *Router) GetAlertHistoryEntryByID(id int64) (*history.Entry, error) {
+	s, storeIndex, storeID := r.fromRoutedID(id)
+	e, err := s.GetAlertHistoryEntryByID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	e.ID = r.toRoutedID(storeIndex, e.ID)
+	return e, nil
+}
+
+// GetUnresolvedAlertHistoryEntry returns the entry of the alert history of an alert of an endpoint that is still
+// triggered
+func (r *Router) GetUnresolvedAlertHistoryEntry(endpointKey, alertChecksum string) (*history.Entry, error) {
+	s := r.storeOfKey(endpointKey)
+	e, err := s.GetUnresolvedAlertHistoryEntry(endpointKey, alertChecksum)
+	if err != nil {
+		return nil, err
+	}
+	e.ID = r.toRoutedID(r.indexOf(s), e.ID)
+	return e, nil
+}
+
+// GetAlertHistory returns the entries of the alert history of every store of the alerts that were triggered at some
+// point within the time range passed, ordered from the most recently triggered to the least recently triggered
+func (r *Router) GetAlertHistory(from, to time.Time) ([]*history.Entry, error) {
+	entries := make([]*history.Entry, 0)
+	for storeIndex, s := range r.stores {
+		storeEntries, err := s.GetAlertHistory(from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range storeEntries {
+			e.ID = r.toRoutedID(storeIndex, e.ID)
+		}
+		entries = append(entries, storeEntries...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].TriggeredAt.After(entries[j].TriggeredAt)
+	})
+	return entries, nil
+}
+
+// InsertExternalEndpointToken persists a token of an external endpoint and sets the ID of the token passed
+func (r *Router) InsertExternalEndpointToken(t *endpoint.ExternalEndpointToken) error {
+	return r.storeOfKey(t.EndpointKey).InsertExternalEndpointToken(t)
+}
+
+// GetExternalEndpointTokens returns every token of an external endpoint, ordered by ID
+func (r *Router) GetExternalEndpointTokens(endpointKey string) ([]*endpoint.ExternalEndpointToken, error) {
+	return r.storeOfKey(endpointKey).GetExternalEndpointTokens(endpointKey)
+}
+
+// RevokeExternalEndpointToken revokes a token of an external endpoint
+func (r *Router) RevokeExternalEndpointToken(endpointKey string, id int64) error {
+	return r.storeOfKey(endpointKey).RevokeExternalEndpointToken(endpointKey, id)
+}
+
+// InsertConfigurationSnapshot persists a snapshot of a configuration that has been applied in the default store,
+// since configurations don't belong to any group
+func (r *Router) InsertConfigurationSnapshot(s *snapshot.Snapshot) error {
+	return r.defaultStore.InsertConfigurationSnapshot(s)
+}
+
+// GetConfigurationSnapshots returns every configuration snapshot of the default store, ordered from the most recent
+// to the least recent
+func (r *Router) GetConfigurationSnapshots() ([]*snapshot.Snapshot, error) {
+	return r.defaultStore.GetConfigurationSnapshots()
+}
+
+// GetConfigurationSnapshot returns the configuration snapshot of the default store with the version passed
+func (r *Router) GetConfigurationSnapshot(version int64) (*snapshot.Snapshot, error) {
+	return r.defaultStore.GetConfigurationSnapshot(version)
+}
+
+// Clear deletes everything from every store
+func (r *Router) Clear() {
+	for _, s := range r.stores {
+		s.Clear()
+	}
+}
+
+// Save persists the data of every store if and where it needs to be persisted
+func (r *Router) Save() error {
+	var errs []error
+	for _, s := range r.stores {
+		if err := s.Save(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every store
+func (r *Router) Close() {
+	for _, s := range r.stores {
+		s.Close()
+	}
+}
@@ -0,0 +1,128 @@
+package sql
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// batchMaxWait is the maximum amount of time an insert may wait for other inserts to join its batch
+const batchMaxWait = 50 * time.Millisecond
+
+type insertRequest struct {
+	ep     *endpoint.Endpoint
+	result *endpoint.Result
+	done   chan error
+}
+
+// insertBatcher groups inserts happening in quick succession into a single transaction, which drastically reduces
+// the number of commits (and thus, disk syncs) when a large number of endpoints are being monitored.
+type insertBatcher struct {
+	store     *Store
+	batchSize int
+
+	queue   chan *insertRequest
+	stopped chan struct{}
+
+	mutex  sync.RWMutex
+	closed bool
+}
+
+func newInsertBatcher(store *Store, batchSize int) *insertBatcher {
+	batcher := &insertBatcher{
+		store:     store,
+		batchSize: batchSize,
+		queue:     make(chan *insertRequest, batchSize),
+		stopped:   make(chan struct{}),
+	}
+	go batcher.run()
+	return batcher
+}
+
+// insert queues the result for insertion and waits until the batch it is part of has been committed.
+// Returns false if the batcher has been closed, in which case the caller is responsible for inserting the result.
+func (b *insertBatcher) insert(ep *endpoint.Endpoint, result *endpoint.Result) (bool, error) {
+	b.mutex.RLock()
+	if b.closed {
+		b.mutex.RUnlock()
+		return false, nil
+	}
+	request := &insertRequest{ep: ep, result: result, done: make(chan error, 1)}
+	b.queue <- request
+	b.mutex.RUnlock()
+	return true, <-request.done
+}
+
+// close stops accepting new inserts and waits until all queued inserts have been flushed
+func (b *insertBatcher) close() {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return
+	}
+	b.closed = true
+	close(b.queue)
+	b.mutex.Unlock()
+	<-b.stopped
+}
+
+func (b *insertBatcher) run() {
+	defer close(b.stopped)
+	for {
+		request, ok := <-b.queue
+		if !ok {
+			return
+		}
+		batch := []*insertRequest{request}
+		timer := time.NewTimer(batchMaxWait)
+	collect:
+		for len(batch) < b.batchSize {
+			select {
+			case request, ok = <-b.queue:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, request)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+		b.flush(batch)
+	}
+}
+
+// flush inserts all requests of the batch in a single transaction.
+// If the transaction fails, each request is retried in its own transaction so that a single bad result
+// doesn't cause the entire batch to be lost.
+func (b *insertBatcher) flush(batch []*insertRequest) {
+	tx, err := b.store.db.Begin()
+	if err == nil {
+		for _, request := range batch {
+			if err = b.store.insert(tx, request.ep, request.result); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = tx.Commit()
+		}
+		if err == nil {
+			for _, request := range batch {
+				b.store.refreshCache(request.ep.Key())
+				request.done <- nil
+			}
+			return
+		}
+		_ = tx.Rollback()
+	}
+	if len(batch) == 1 {
+		batch[0].done <- err
+		return
+	}
+	log.Printf("[sql.flush] Failed to insert batch of %d results, falling back to individual inserts: %s", len(batch), err.Error())
+	for _, request := range batch {
+		request.done <- b.store.insertInNewTransaction(request.ep, request.result)
+	}
+}
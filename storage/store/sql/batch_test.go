@@ -0,0 +1,98 @@
+package sql
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestStore_InsertWithBatchedWrites(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithBatchedWrites.db", false)
+	defer store.Close()
+	store.EnableBatchedWrites(10)
+	numberOfEndpoints := 25
+	wg := sync.WaitGroup{}
+	for i := 0; i < numberOfEndpoints; i++ {
+		ep := testEndpoint
+		ep.Name = fmt.Sprintf("endpoint-%d", i)
+		wg.Add(1)
+		go func(ep *endpoint.Endpoint) {
+			defer wg.Done()
+			if err := store.Insert(ep, &testSuccessfulResult); err != nil {
+				t.Errorf("expected no error, got %s", err.Error())
+			}
+			if err := store.Insert(ep, &testUnsuccessfulResult); err != nil {
+				t.Errorf("expected no error, got %s", err.Error())
+			}
+		}(&ep)
+	}
+	wg.Wait()
+	endpointStatuses, _ := store.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 20))
+	if len(endpointStatuses) != numberOfEndpoints {
+		t.Fatalf("expected %d endpoint statuses, got %d", numberOfEndpoints, len(endpointStatuses))
+	}
+	for _, endpointStatus := range endpointStatuses {
+		if len(endpointStatus.Results) != 2 {
+			t.Errorf("expected endpoint with key=%s to have 2 results, got %d", endpointStatus.Key, len(endpointStatus.Results))
+		}
+	}
+}
+
+func TestStore_InsertWithBatchedWritesAfterClose(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithBatchedWritesAfterClose.db", false)
+	store.EnableBatchedWrites(10)
+	if err := store.Insert(&testEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+	store.Close()
+	// Inserting after the store has been closed must not panic, but it should return an error
+	if err := store.Insert(&testEndpoint, &testSuccessfulResult); err == nil {
+		t.Error("expected an error, because the store has been closed")
+	}
+}
+
+func TestStore_InsertWithCompression(t *testing.T) {
+	path := t.TempDir() + "/TestStore_InsertWithCompression.db"
+	store, _ := NewStore("sqlite", path, false)
+	store.EnableCompression()
+	longError := fmt.Sprintf("%0512d", 0)
+	result := testUnsuccessfulResult
+	result.Errors = []string{longError, "error-2"}
+	// The body of a response may be part of the resolved values of a condition
+	result.ConditionResults = []*endpoint.ConditionResult{
+		{Condition: "[BODY] == pat(*ok*)", Success: false, ResolvedValues: &endpoint.ResolvedConditionValues{Left: longError, Right: "pat(*ok*)"}},
+	}
+	if err := store.Insert(&testEndpoint, &result); err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+	var joinedErrors, resolvedLeft string
+	if err := store.db.QueryRow("SELECT errors FROM endpoint_results").Scan(&joinedErrors); err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+	if len(joinedErrors) >= len(longError) {
+		t.Errorf("expected errors to have been compressed, but stored value has a length of %d", len(joinedErrors))
+	}
+	if err := store.db.QueryRow("SELECT resolved_left FROM endpoint_result_conditions").Scan(&resolvedLeft); err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+	if len(resolvedLeft) >= len(longError) {
+		t.Errorf("expected resolved values to have been compressed, but stored value has a length of %d", len(resolvedLeft))
+	}
+	store.Close()
+	// Compressed values must still be readable by a store that doesn't have compression enabled
+	store, _ = NewStore("sqlite", path, false)
+	defer store.Close()
+	endpointStatus, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20))
+	if len(endpointStatus.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(endpointStatus.Results))
+	}
+	if errors := endpointStatus.Results[0].Errors; len(errors) != 2 || errors[0] != longError || errors[1] != "error-2" {
+		t.Errorf("expected errors to have been decompressed, got %v", errors)
+	}
+	if conditionResults := endpointStatus.Results[0].ConditionResults; len(conditionResults) != 1 || conditionResults[0].ResolvedValues.Left != longError {
+		t.Errorf("expected resolved values to have been decompressed, got %v", conditionResults)
+	}
+}
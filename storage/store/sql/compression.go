@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// compressedValuePrefix is the prefix used to identify a column value that has been compressed.
+	// Values without this prefix are returned as-is, which allows compressed and uncompressed rows to coexist.
+	compressedValuePrefix = "~zstd~"
+
+	// compressionThreshold is the minimum length a value must have before compression is attempted.
+	// Below this, the overhead of the zstd frame and the base64 encoding outweighs the gain.
+	compressionThreshold = 256
+)
+
+var (
+	zstdEncoder     *zstd.Encoder
+	zstdDecoder     *zstd.Decoder
+	zstdInitializer sync.Once
+)
+
+func initializeZstd() {
+	zstdInitializer.Do(func() {
+		zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+		zstdDecoder, _ = zstd.NewReader(nil)
+	})
+}
+
+// compressValue compresses a value using zstd if it's long enough for it to be worth it.
+// The compressed value is base64 encoded so that it can safely be stored in a TEXT column.
+func compressValue(value string) string {
+	if len(value) < compressionThreshold {
+		return value
+	}
+	initializeZstd()
+	compressed := compressedValuePrefix + base64.StdEncoding.EncodeToString(zstdEncoder.EncodeAll([]byte(value), nil))
+	if len(compressed) >= len(value) {
+		// Not worth it
+		return value
+	}
+	return compressed
+}
+
+// decompressValue decompresses a value previously compressed by compressValue.
+// If the value isn't compressed, or if it cannot be decompressed, it is returned as-is.
+func decompressValue(value string) string {
+	if !strings.HasPrefix(value, compressedValuePrefix) {
+		return value
+	}
+	initializeZstd()
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, compressedValuePrefix))
+	if err != nil {
+		return value
+	}
+	decompressed, err := zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return value
+	}
+	return string(decompressed)
+}
@@ -0,0 +1,75 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompressValue(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		value            string
+		expectCompressed bool
+	}{
+		{
+			name:             "empty",
+			value:            "",
+			expectCompressed: false,
+		},
+		{
+			name:             "below-threshold",
+			value:            "error-1|~|error-2",
+			expectCompressed: false,
+		},
+		{
+			name:             "above-threshold-and-compressible",
+			value:            strings.Repeat("connection refused|~|", 100),
+			expectCompressed: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			compressed := compressValue(scenario.value)
+			if isCompressed := strings.HasPrefix(compressed, compressedValuePrefix); isCompressed != scenario.expectCompressed {
+				t.Errorf("expected compressed to be %v, got %v", scenario.expectCompressed, isCompressed)
+			}
+			if scenario.expectCompressed && len(compressed) >= len(scenario.value) {
+				t.Errorf("expected compressed value to be shorter than %d, got %d", len(scenario.value), len(compressed))
+			}
+			if decompressed := decompressValue(compressed); decompressed != scenario.value {
+				t.Errorf("expected decompressed value to be %q, got %q", scenario.value, decompressed)
+			}
+		})
+	}
+}
+
+func TestDecompressValue(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "uncompressed",
+			value:    "error-1|~|error-2",
+			expected: "error-1|~|error-2",
+		},
+		{
+			name:     "invalid-base64",
+			value:    compressedValuePrefix + "!!!",
+			expected: compressedValuePrefix + "!!!",
+		},
+		{
+			name:     "invalid-zstd-frame",
+			value:    compressedValuePrefix + "aGVsbG8=",
+			expected: compressedValuePrefix + "aGVsbG8=",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if decompressed := decompressValue(scenario.value); decompressed != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, decompressed)
+			}
+		})
+	}
+}
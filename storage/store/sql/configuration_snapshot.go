@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TwiN/gatus/v5/config/snapshot"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// InsertConfigurationSnapshot persists a snapshot of a configuration that has been applied and sets the version of
+// the snapshot passed, then deletes the snapshots beyond common.MaximumNumberOfConfigurationSnapshots.
+//
+// The configuration is encoded like other sensitive columns, since it may contain secrets.
+func (s *Store) InsertConfigurationSnapshot(configurationSnapshot *snapshot.Snapshot) error {
+	var err error
+	configurationSnapshot.Version, err = s.insertAndReturnID(
+		s.db,
+		`
+			INSERT INTO configuration_snapshots (checksum, source, rollback_of, applied_at, data)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING configuration_snapshot_id
+		`,
+		configurationSnapshot.Checksum,
+		configurationSnapshot.Source,
+		configurationSnapshot.RollbackOf,
+		configurationSnapshot.AppliedAt.UTC(),
+		s.encodeValue(string(configurationSnapshot.Data)),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`
+			DELETE FROM configuration_snapshots
+			WHERE configuration_snapshot_id NOT IN (
+				SELECT configuration_snapshot_id FROM (
+					SELECT configuration_snapshot_id
+					FROM configuration_snapshots
+					ORDER BY configuration_snapshot_id DESC
+					LIMIT $1
+				) AS recent_configuration_snapshots
+			)
+		`,
+		common.MaximumNumberOfConfigurationSnapshots,
+	)
+	return err
+}
+
+const configurationSnapshotQuery = `
+	SELECT configuration_snapshot_id, checksum, source, rollback_of, applied_at, data
+	FROM configuration_snapshots
+`
+
+// GetConfigurationSnapshots returns every configuration snapshot, ordered from the most recent to the least recent
+func (s *Store) GetConfigurationSnapshots() ([]*snapshot.Snapshot, error) {
+	rows, err := s.db.Query(configurationSnapshotQuery + " ORDER BY configuration_snapshot_id DESC")
+	if err != nil {
+		return nil, err
+	}
+	return s.scanConfigurationSnapshots(rows)
+}
+
+// GetConfigurationSnapshot returns the configuration snapshot with the version passed
+func (s *Store) GetConfigurationSnapshot(version int64) (*snapshot.Snapshot, error) {
+	rows, err := s.db.Query(configurationSnapshotQuery+" WHERE configuration_snapshot_id = $1", version)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := s.scanConfigurationSnapshots(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, common.ErrConfigurationSnapshotNotFound
+	}
+	return snapshots[0], nil
+}
+
+func (s *Store) scanConfigurationSnapshots(rows *sql.Rows) (snapshots []*snapshot.Snapshot, err error) {
+	defer rows.Close()
+	snapshots = make([]*snapshot.Snapshot, 0)
+	for rows.Next() {
+		configurationSnapshot := &snapshot.Snapshot{}
+		var source, encodedData string
+		if err = rows.Scan(&configurationSnapshot.Version, &configurationSnapshot.Checksum, &source, &configurationSnapshot.RollbackOf, &configurationSnapshot.AppliedAt, &encodedData); err != nil {
+			return nil, err
+		}
+		configurationSnapshot.Source = snapshot.Source(source)
+		configurationSnapshot.Data = []byte(s.decodeValue(encodedData))
+		snapshots = append(snapshots, configurationSnapshot)
+	}
+	return snapshots, errors.Join(err, rows.Err())
+}
@@ -0,0 +1,140 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// InsertAlertDelivery queues an alert to be delivered and sets the ID of the delivery passed
+func (s *Store) InsertAlertDelivery(d *delivery.Delivery) error {
+	encodedResult, err := json.Marshal(d.Result)
+	if err != nil {
+		return err
+	}
+	encodedState, err := json.Marshal(d.State)
+	if err != nil {
+		return err
+	}
+	d.ID, err = s.insertAndReturnID(
+		s.db,
+		`
+			INSERT INTO alert_deliveries (endpoint_key, alert_type, alert_checksum, resolved, result, state, attempts, last_error, dead_lettered, created_at, next_attempt_at, history_entry_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING alert_delivery_id
+		`,
+		d.EndpointKey,
+		string(d.AlertType),
+		d.AlertChecksum,
+		d.Resolved,
+		s.encryptValue(string(encodedResult)),
+		s.encryptValue(string(encodedState)),
+		d.Attempts,
+		s.encryptValue(d.LastError),
+		d.DeadLettered,
+		d.CreatedAt.UTC(),
+		d.NextAttemptAt.UTC(),
+		d.HistoryEntryID,
+	)
+	return err
+}
+
+// UpdateAlertDelivery updates the state of an alert delivery and the attempts made to deliver it
+func (s *Store) UpdateAlertDelivery(d *delivery.Delivery) error {
+	encodedState, err := json.Marshal(d.State)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.Exec(
+		"UPDATE alert_deliveries SET state = $1, attempts = $2, last_error = $3, dead_lettered = $4, next_attempt_at = $5 WHERE alert_delivery_id = $6",
+		s.encryptValue(string(encodedState)),
+		d.Attempts,
+		s.encryptValue(d.LastError),
+		d.DeadLettered,
+		d.NextAttemptAt.UTC(),
+		d.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrAlertDeliveryNotFound
+	}
+	return nil
+}
+
+// DeleteAlertDelivery removes an alert delivery, which is done once the alert has been delivered
+func (s *Store) DeleteAlertDelivery(id int64) error {
+	result, err := s.db.Exec("DELETE FROM alert_deliveries WHERE alert_delivery_id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrAlertDeliveryNotFound
+	}
+	return nil
+}
+
+// GetAlertDeliveryByID returns the alert delivery with the ID passed
+func (s *Store) GetAlertDeliveryByID(id int64) (*delivery.Delivery, error) {
+	rows, err := s.db.Query(alertDeliveriesQuery+" WHERE alert_delivery_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.scanAlertDeliveries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, common.ErrAlertDeliveryNotFound
+	}
+	return deliveries[0], nil
+}
+
+// GetAlertDeliveries returns every alert delivery, including the ones that have been dead-lettered, ordered by ID
+func (s *Store) GetAlertDeliveries() ([]*delivery.Delivery, error) {
+	rows, err := s.db.Query(alertDeliveriesQuery + " ORDER BY alert_delivery_id")
+	if err != nil {
+		return nil, err
+	}
+	return s.scanAlertDeliveries(rows)
+}
+
+const alertDeliveriesQuery = `
+	SELECT alert_delivery_id, endpoint_key, alert_type, alert_checksum, resolved, result, state, attempts, last_error, dead_lettered, created_at, next_attempt_at, history_entry_id
+	FROM alert_deliveries
+`
+
+func (s *Store) scanAlertDeliveries(rows *sql.Rows) (deliveries []*delivery.Delivery, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		d := &delivery.Delivery{}
+		var alertType, encodedResult, encodedState, lastError string
+		var createdAt, nextAttemptAt time.Time
+		if err = rows.Scan(&d.ID, &d.EndpointKey, &alertType, &d.AlertChecksum, &d.Resolved, &encodedResult, &encodedState, &d.Attempts, &lastError, &d.DeadLettered, &createdAt, &nextAttemptAt, &d.HistoryEntryID); err != nil {
+			return nil, err
+		}
+		d.AlertType = alert.Type(alertType)
+		d.LastError = s.decryptValue(lastError)
+		d.CreatedAt, d.NextAttemptAt = createdAt, nextAttemptAt
+		d.Result = &endpoint.Result{}
+		if err = json.Unmarshal([]byte(s.decryptValue(encodedResult)), d.Result); err != nil {
+			// The result can't be decrypted, for instance because the encryption key changed, but the alert can
+			// still be delivered
+			d.Result = &endpoint.Result{}
+		}
+		if err = json.Unmarshal([]byte(s.decryptValue(encodedState)), &d.State); err != nil {
+			// Same as above, the alert is delivered with an empty state instead
+			d.State = delivery.State{}
+		}
+		err = nil
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, errors.Join(err, rows.Err())
+}
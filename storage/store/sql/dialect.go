@@ -0,0 +1,55 @@
+package sql
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/storage/store/sql/mysql"
+)
+
+// The queries of the store are written for SQLite and Postgres, which support the same syntax for the few statements
+// that aren't standard. The functions in this file adapt these statements for MySQL.
+
+var (
+	// onConflictPattern matches the clause of an upsert specifying what to do when the row already exists
+	onConflictPattern = regexp.MustCompile(`ON CONFLICT\s*\([^)]*\)\s*DO UPDATE SET`)
+
+	// excludedColumnPattern matches the references to the columns of the row that couldn't be inserted in an upsert
+	excludedColumnPattern = regexp.MustCompile(`\bexcluded\.(\w+)`)
+)
+
+// queryRowExecer is either a *sql.DB or a *sql.Tx
+type queryRowExecer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// insertAndReturnID executes an INSERT statement ending with a RETURNING clause for the generated id of the row
+// inserted, and returns said id.
+//
+// MySQL doesn't support RETURNING, so the clause is removed and the id is retrieved from the result instead.
+func (s *Store) insertAndReturnID(q queryRowExecer, query string, args ...any) (int64, error) {
+	var id int64
+	if s.driver != mysql.DriverName {
+		err := q.QueryRow(query, args...).Scan(&id)
+		return id, err
+	}
+	result, err := q.Exec(query[:strings.LastIndex(query, "RETURNING")], args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// upsert returns an INSERT statement with an ON CONFLICT clause in the dialect of the driver.
+//
+// For MySQL, the clause is replaced by ON DUPLICATE KEY UPDATE, which applies to the unique indexes of the table rather
+// than to specific columns, and references to the row that couldn't be inserted become VALUES(column).
+func (s *Store) upsert(query string) string {
+	if s.driver != mysql.DriverName {
+		return query
+	}
+	query = onConflictPattern.ReplaceAllString(query, "ON DUPLICATE KEY UPDATE")
+	return excludedColumnPattern.ReplaceAllString(query, "VALUES(${1})")
+}
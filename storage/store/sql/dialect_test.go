@@ -0,0 +1,45 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStore_upsert(t *testing.T) {
+	query := `
+		INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions)
+		VALUES ($1, $2, $3)
+		ON CONFLICT(endpoint_id, hour_unix_timestamp) DO UPDATE SET
+			total_executions = excluded.total_executions + endpoint_uptimes.total_executions
+	`
+	scenarios := []struct {
+		driver        string
+		expectedQuery string
+	}{
+		{
+			driver:        "sqlite",
+			expectedQuery: query,
+		},
+		{
+			driver:        "postgres",
+			expectedQuery: query,
+		},
+		{
+			driver: "mysql",
+			expectedQuery: `
+		INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions)
+		VALUES ($1, $2, $3)
+		ON DUPLICATE KEY UPDATE
+			total_executions = VALUES(total_executions) + endpoint_uptimes.total_executions
+	`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.driver, func(t *testing.T) {
+			store := &Store{driver: scenario.driver}
+			if actualQuery := store.upsert(query); strings.TrimSpace(actualQuery) != strings.TrimSpace(scenario.expectedQuery) {
+				t.Errorf("expected query:\n%s\ngot:\n%s", scenario.expectedQuery, actualQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+)
+
+// encryptedValuePrefix is the prefix used to identify a column value that has been encrypted.
+// Values without this prefix are returned as-is, which allows encrypted and plain text rows to coexist.
+const encryptedValuePrefix = "~aes256gcm~"
+
+// encryptValue encrypts a value if encryption is enabled.
+// The nonce is prepended to the ciphertext, and the result is base64 encoded so that it can safely be stored in a
+// TEXT column.
+func (s *Store) encryptValue(value string) string {
+	if s.encryption == nil || len(value) == 0 {
+		return value
+	}
+	nonce := make([]byte, s.encryption.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		// This should never happen, but persisting the value in plain text would defeat the purpose of encryption
+		panic("failed to generate nonce: " + err.Error())
+	}
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(s.encryption.Seal(nonce, nonce, []byte(value), nil))
+}
+
+// decryptValue decrypts a value previously encrypted by encryptValue.
+// If the value isn't encrypted, it is returned as-is. If it is encrypted but cannot be decrypted, for instance
+// because encryption is disabled or because the key changed, an empty string is returned.
+func (s *Store) decryptValue(value string) string {
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return value
+	}
+	if s.encryption == nil {
+		return ""
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil || len(data) < s.encryption.NonceSize() {
+		return ""
+	}
+	nonceSize := s.encryption.NonceSize()
+	decrypted, err := s.encryption.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return ""
+	}
+	return string(decrypted)
+}
@@ -0,0 +1,189 @@
+package sql
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+var testEncryptionKey = bytes.Repeat([]byte{42}, 32)
+
+func TestStore_EnableEncryption(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_EnableEncryption.db", false)
+	defer store.Close()
+	if err := store.EnableEncryption([]byte("too-short")); err == nil {
+		t.Error("expected an error")
+	}
+	if err := store.EnableEncryption(testEncryptionKey); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+}
+
+func TestStore_encryptValue(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_encryptValue.db", false)
+	defer store.Close()
+	if encrypted := store.encryptValue("secret"); encrypted != "secret" {
+		t.Errorf("expected value to be returned as-is when encryption is disabled, got %q", encrypted)
+	}
+	_ = store.EnableEncryption(testEncryptionKey)
+	scenarios := []struct {
+		name            string
+		value           string
+		expectEncrypted bool
+	}{
+		{
+			name:            "empty",
+			value:           "",
+			expectEncrypted: false,
+		},
+		{
+			name:            "short",
+			value:           "connection refused",
+			expectEncrypted: true,
+		},
+		{
+			name:            "compressed",
+			value:           compressValue(strings.Repeat("connection refused|~|", 100)),
+			expectEncrypted: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			encrypted := store.encryptValue(scenario.value)
+			if isEncrypted := strings.HasPrefix(encrypted, encryptedValuePrefix); isEncrypted != scenario.expectEncrypted {
+				t.Errorf("expected encrypted to be %v, got %v", scenario.expectEncrypted, isEncrypted)
+			}
+			if scenario.expectEncrypted && strings.Contains(encrypted, scenario.value) {
+				t.Error("expected encrypted value not to contain the plain text value")
+			}
+			if decrypted := store.decryptValue(encrypted); decrypted != scenario.value {
+				t.Errorf("expected decrypted value to be %q, got %q", scenario.value, decrypted)
+			}
+		})
+	}
+	if store.encryptValue("secret") == store.encryptValue("secret") {
+		t.Error("expected encrypting the same value twice to produce different ciphertexts")
+	}
+}
+
+func TestStore_decryptValue(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_decryptValue.db", false)
+	defer store.Close()
+	_ = store.EnableEncryption(testEncryptionKey)
+	encrypted := store.encryptValue("secret")
+	otherStore, _ := NewStore("sqlite", t.TempDir()+"/TestStore_decryptValueWithOtherKey.db", false)
+	defer otherStore.Close()
+	_ = otherStore.EnableEncryption(bytes.Repeat([]byte{7}, 32))
+	scenarios := []struct {
+		name     string
+		store    *Store
+		value    string
+		expected string
+	}{
+		{
+			name:     "plain-text",
+			store:    store,
+			value:    "error-1|~|error-2",
+			expected: "error-1|~|error-2",
+		},
+		{
+			name:     "invalid-base64",
+			store:    store,
+			value:    encryptedValuePrefix + "!!!",
+			expected: "",
+		},
+		{
+			name:     "too-short",
+			store:    store,
+			value:    encryptedValuePrefix + "aGVsbG8=",
+			expected: "",
+		},
+		{
+			name:     "wrong-key",
+			store:    otherStore,
+			value:    encrypted,
+			expected: "",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if decrypted := scenario.store.decryptValue(scenario.value); decrypted != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, decrypted)
+			}
+		})
+	}
+}
+
+func TestStore_InsertWithEncryption(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithEncryption.db", false)
+	defer store.Close()
+	store.EnableCompression()
+	_ = store.EnableEncryption(testEncryptionKey)
+	result := &endpoint.Result{
+		Success:   false,
+		Timestamp: time.Now(),
+		Errors:    []string{"token=super-secret rejected", strings.Repeat("connection refused", 50)},
+		ConditionResults: []*endpoint.ConditionResult{
+			{Condition: "[BODY].token (super-secret) == expected", Success: false, ResolvedValues: &endpoint.ResolvedConditionValues{Left: "super-secret", Right: "expected"}},
+		},
+	}
+	if err := store.Insert(&testEndpoint, result); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Make sure that the sensitive columns aren't persisted in plain text
+	var persistedErrors, persistedCondition, persistedResolvedLeft string
+	if err := store.db.QueryRow("SELECT errors FROM endpoint_results").Scan(&persistedErrors); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := store.db.QueryRow("SELECT condition, resolved_left FROM endpoint_result_conditions").Scan(&persistedCondition, &persistedResolvedLeft); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for _, persisted := range []string{persistedErrors, persistedCondition, persistedResolvedLeft} {
+		if !strings.HasPrefix(persisted, encryptedValuePrefix) || strings.Contains(persisted, "super-secret") {
+			t.Errorf("expected %q to be encrypted", persisted)
+		}
+	}
+	// Make sure that the values are decrypted on read
+	status, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(status.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(status.Results))
+	}
+	retrievedResult := status.Results[0]
+	if len(retrievedResult.Errors) != 2 || retrievedResult.Errors[0] != result.Errors[0] || retrievedResult.Errors[1] != result.Errors[1] {
+		t.Errorf("expected errors %v, got %v", result.Errors, retrievedResult.Errors)
+	}
+	if len(retrievedResult.ConditionResults) != 1 || retrievedResult.ConditionResults[0].Condition != result.ConditionResults[0].Condition || *retrievedResult.ConditionResults[0].ResolvedValues != *result.ConditionResults[0].ResolvedValues {
+		t.Errorf("expected condition results %+v, got %+v", result.ConditionResults[0], retrievedResult.ConditionResults[0])
+	}
+}
+
+func TestStore_TriggeredEndpointAlertWithEncryption(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_TriggeredEndpointAlertWithEncryption.db", false)
+	defer store.Close()
+	_ = store.EnableEncryption(testEncryptionKey)
+	triggeredAlert := alert.Alert{Type: alert.TypePagerDuty, Triggered: true, ResolveKey: "resolve-key"}
+	if err := store.UpsertTriggeredEndpointAlert(&testEndpoint, &triggeredAlert); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	var persistedResolveKey sql.NullString
+	_ = store.db.QueryRow("SELECT resolve_key FROM endpoint_alerts_triggered").Scan(&persistedResolveKey)
+	if !strings.HasPrefix(persistedResolveKey.String, encryptedValuePrefix) {
+		t.Errorf("expected resolve key to be encrypted, got %q", persistedResolveKey.String)
+	}
+	exists, resolveKey, _, err := store.GetTriggeredEndpointAlert(&testEndpoint, &triggeredAlert)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !exists || resolveKey != "resolve-key" {
+		t.Errorf("expected resolve key %q, got %q", "resolve-key", resolveKey)
+	}
+}
@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// InsertExternalEndpointToken persists a token of an external endpoint and sets the ID of the token passed
+func (s *Store) InsertExternalEndpointToken(t *endpoint.ExternalEndpointToken) error {
+	var expiresAt sql.NullTime
+	if t.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: t.ExpiresAt.UTC(), Valid: true}
+	}
+	var err error
+	t.ID, err = s.insertAndReturnID(
+		s.db,
+		`
+			INSERT INTO external_endpoint_tokens (endpoint_key, name, token_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING external_endpoint_token_id
+		`,
+		t.EndpointKey,
+		t.Name,
+		t.Hash,
+		t.CreatedAt.UTC(),
+		expiresAt,
+	)
+	return err
+}
+
+// GetExternalEndpointTokens returns every token of an external endpoint, including the ones that expired or were
+// revoked, ordered by ID
+func (s *Store) GetExternalEndpointTokens(endpointKey string) (tokens []*endpoint.ExternalEndpointToken, err error) {
+	rows, err := s.db.Query(
+		`
+			SELECT external_endpoint_token_id, endpoint_key, name, token_hash, created_at, expires_at, revoked_at
+			FROM external_endpoint_tokens
+			WHERE endpoint_key = $1
+			ORDER BY external_endpoint_token_id
+		`,
+		endpointKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tokens = make([]*endpoint.ExternalEndpointToken, 0)
+	for rows.Next() {
+		t := &endpoint.ExternalEndpointToken{}
+		var expiresAt, revokedAt sql.NullTime
+		if err = rows.Scan(&t.ID, &t.EndpointKey, &t.Name, &t.Hash, &t.CreatedAt, &expiresAt, &revokedAt); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			t.ExpiresAt = &expiresAt.Time
+		}
+		if revokedAt.Valid {
+			t.RevokedAt = &revokedAt.Time
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, errors.Join(err, rows.Err())
+}
+
+// RevokeExternalEndpointToken revokes a token of an external endpoint
+func (s *Store) RevokeExternalEndpointToken(endpointKey string, id int64) error {
+	var revokedAt sql.NullTime
+	err := s.db.QueryRow("SELECT revoked_at FROM external_endpoint_tokens WHERE external_endpoint_token_id = $1 AND endpoint_key = $2", id, endpointKey).Scan(&revokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return common.ErrExternalEndpointTokenNotFound
+		}
+		return err
+	}
+	if revokedAt.Valid {
+		return nil
+	}
+	_, err = s.db.Exec("UPDATE external_endpoint_tokens SET revoked_at = $1 WHERE external_endpoint_token_id = $2", time.Now().UTC().Truncate(time.Second), id)
+	return err
+}
@@ -0,0 +1,194 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// InsertAlertHistoryEntry persists an entry of the alert history and sets the ID of the entry passed
+func (s *Store) InsertAlertHistoryEntry(e *history.Entry) error {
+	encodedTriggeredDelivery, encodedResolvedDelivery, err := s.encodeAlertHistoryEntryDeliveries(e)
+	if err != nil {
+		return err
+	}
+	e.ID, err = s.insertAndReturnID(
+		s.db,
+		`
+			INSERT INTO alert_history (endpoint_key, endpoint_group, endpoint_name, alert_type, alert_checksum, description, triggered_at, resolved_at, triggered_delivery, resolved_delivery)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING alert_history_entry_id
+		`,
+		e.EndpointKey,
+		e.EndpointGroup,
+		e.EndpointName,
+		string(e.AlertType),
+		e.AlertChecksum,
+		e.Description,
+		e.TriggeredAt.UTC(),
+		toNullTime(e.ResolvedAt),
+		encodedTriggeredDelivery,
+		encodedResolvedDelivery,
+	)
+	return err
+}
+
+// UpdateAlertHistoryEntry updates the resolution and the delivery summaries of an entry of the alert history
+func (s *Store) UpdateAlertHistoryEntry(e *history.Entry) error {
+	encodedTriggeredDelivery, encodedResolvedDelivery, err := s.encodeAlertHistoryEntryDeliveries(e)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.Exec(
+		"UPDATE alert_history SET resolved_at = $1, triggered_delivery = $2, resolved_delivery = $3 WHERE alert_history_entry_id = $4",
+		toNullTime(e.ResolvedAt),
+		encodedTriggeredDelivery,
+		encodedResolvedDelivery,
+		e.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrAlertHistoryEntryNotFound
+	}
+	return nil
+}
+
+// DeleteAlertHistoryEntry removes an entry of the alert history
+func (s *Store) DeleteAlertHistoryEntry(id int64) error {
+	result, err := s.db.Exec("DELETE FROM alert_history WHERE alert_history_entry_id = $1", id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return common.ErrAlertHistoryEntryNotFound
+	}
+	return nil
+}
+
+// GetAlertHistoryEntryByID returns the entry of the alert history with the ID passed
+func (s *Store) GetAlertHistoryEntryByID(id int64) (*history.Entry, error) {
+	rows, err := s.db.Query(alertHistoryQuery+" WHERE alert_history_entry_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanFirstAlertHistoryEntry(rows)
+}
+
+// GetUnresolvedAlertHistoryEntry returns the entry of the alert history of an alert of an endpoint that is still
+// triggered
+func (s *Store) GetUnresolvedAlertHistoryEntry(endpointKey, alertChecksum string) (*history.Entry, error) {
+	rows, err := s.db.Query(
+		alertHistoryQuery+" WHERE endpoint_key = $1 AND alert_checksum = $2 AND resolved_at IS NULL ORDER BY alert_history_entry_id DESC LIMIT 1",
+		endpointKey,
+		alertChecksum,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanFirstAlertHistoryEntry(rows)
+}
+
+// GetAlertHistory returns the entries of the alert history of the alerts that were triggered at some point within
+// the time range passed, ordered from the most recently triggered to the least recently triggered
+func (s *Store) GetAlertHistory(from, to time.Time) ([]*history.Entry, error) {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	var args []interface{}
+	query := alertHistoryQuery + " WHERE 1 = 1"
+	if !to.IsZero() {
+		args = append(args, to.UTC())
+		query += ` AND triggered_at <= $` + strconv.Itoa(len(args))
+	}
+	if !from.IsZero() {
+		args = append(args, from.UTC())
+		query += ` AND (resolved_at IS NULL OR resolved_at >= $` + strconv.Itoa(len(args)) + `)`
+	}
+	rows, err := s.db.Query(query+" ORDER BY triggered_at DESC, alert_history_entry_id DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanAlertHistoryEntries(rows)
+}
+
+const alertHistoryQuery = `
+	SELECT alert_history_entry_id, endpoint_key, endpoint_group, endpoint_name, alert_type, alert_checksum, description, triggered_at, resolved_at, triggered_delivery, resolved_delivery
+	FROM alert_history
+`
+
+// encodeAlertHistoryEntryDeliveries encodes the delivery summaries of an entry of the alert history, which are
+// encrypted since the errors returned by alerting providers may contain sensitive information
+func (s *Store) encodeAlertHistoryEntryDeliveries(e *history.Entry) (string, sql.NullString, error) {
+	encodedTriggeredDelivery, err := json.Marshal(e.TriggeredDelivery)
+	if err != nil {
+		return "", sql.NullString{}, err
+	}
+	var encodedResolvedDelivery sql.NullString
+	if e.ResolvedDelivery != nil {
+		encoded, err := json.Marshal(e.ResolvedDelivery)
+		if err != nil {
+			return "", sql.NullString{}, err
+		}
+		encodedResolvedDelivery = sql.NullString{String: s.encryptValue(string(encoded)), Valid: true}
+	}
+	return s.encryptValue(string(encodedTriggeredDelivery)), encodedResolvedDelivery, nil
+}
+
+func (s *Store) scanFirstAlertHistoryEntry(rows *sql.Rows) (*history.Entry, error) {
+	entries, err := s.scanAlertHistoryEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, common.ErrAlertHistoryEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (s *Store) scanAlertHistoryEntries(rows *sql.Rows) (entries []*history.Entry, err error) {
+	defer rows.Close()
+	entries = make([]*history.Entry, 0)
+	for rows.Next() {
+		e := &history.Entry{}
+		var alertType, encodedTriggeredDelivery string
+		var resolvedAt sql.NullTime
+		var encodedResolvedDelivery sql.NullString
+		if err = rows.Scan(&e.ID, &e.EndpointKey, &e.EndpointGroup, &e.EndpointName, &alertType, &e.AlertChecksum, &e.Description, &e.TriggeredAt, &resolvedAt, &encodedTriggeredDelivery, &encodedResolvedDelivery); err != nil {
+			return nil, err
+		}
+		e.AlertType = alert.Type(alertType)
+		if resolvedAt.Valid {
+			e.ResolvedAt = &resolvedAt.Time
+		}
+		if err = json.Unmarshal([]byte(s.decryptValue(encodedTriggeredDelivery)), &e.TriggeredDelivery); err != nil {
+			// The summary can't be decrypted, for instance because the encryption key changed, but the rest of the
+			// entry is still relevant
+			e.TriggeredDelivery = history.DeliverySummary{}
+		}
+		if encodedResolvedDelivery.Valid {
+			e.ResolvedDelivery = &history.DeliverySummary{}
+			if err = json.Unmarshal([]byte(s.decryptValue(encodedResolvedDelivery.String)), e.ResolvedDelivery); err != nil {
+				// Same as above
+				e.ResolvedDelivery = &history.DeliverySummary{}
+			}
+		}
+		err = nil
+		entries = append(entries, e)
+	}
+	return entries, errors.Join(err, rows.Err())
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,665 @@
+package mysql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// Reference doc: https://dev.mysql.com/doc/dev/mysql-server/latest/PAGE_PROTOCOL.html
+
+const (
+	maximumPacketSize = 1<<24 - 1
+
+	clientLongPassword               uint32 = 0x00000001
+	clientFoundRows                  uint32 = 0x00000002
+	clientLongFlag                   uint32 = 0x00000004
+	clientConnectWithDB              uint32 = 0x00000008
+	clientProtocol41                 uint32 = 0x00000200
+	clientSSL                        uint32 = 0x00000800
+	clientTransactions               uint32 = 0x00002000
+	clientSecureConnection           uint32 = 0x00008000
+	clientPluginAuth                 uint32 = 0x00080000
+	clientPluginAuthLenencClientData uint32 = 0x00200000
+
+	serverStatusNoBackslashEscapes uint16 = 0x0200
+
+	commandQuit  byte = 0x01
+	commandQuery byte = 0x03
+	commandPing  byte = 0x0e
+
+	packetOK           byte = 0x00
+	packetAuthMoreData byte = 0x01
+	packetLocalInfile  byte = 0xfb
+	packetEOF          byte = 0xfe
+	packetERR          byte = 0xff
+
+	// utf8mb4GeneralCI is the id of the utf8mb4_general_ci collation, which both MySQL and MariaDB support
+	utf8mb4GeneralCI byte = 45
+
+	nativePasswordPlugin      = "mysql_native_password"
+	cachingSHA2PasswordPlugin = "caching_sha2_password"
+)
+
+// sessionInitializationQuery is the query executed upon connecting for the queries of the SQL store to behave the same
+// way as with the other drivers
+const sessionInitializationQuery = "SET time_zone = '+00:00', sql_mode = CONCAT_WS(',', NULLIF(@@SESSION.sql_mode, ''), 'ANSI_QUOTES')"
+
+// connection is a connection to a MySQL or MariaDB server.
+//
+// Like every driver.Conn, it is never used concurrently.
+type connection struct {
+	netConn  net.Conn
+	reader   *bufio.Reader
+	sequence byte
+
+	capabilities uint32
+	secure       bool
+
+	// status is the status of the server sent with the last OK or EOF packet
+	status uint16
+
+	// activeRows are the rows that must be read until the end before sending the next command, if any
+	activeRows *rows
+
+	closed bool
+}
+
+func connect(ctx context.Context, cfg *config) (*connection, error) {
+	dialer := net.Dialer{Timeout: cfg.timeout}
+	netConn, err := dialer.DialContext(ctx, cfg.network, cfg.address)
+	if err != nil {
+		return nil, err
+	}
+	c := &connection{netConn: netConn, reader: bufio.NewReader(netConn), secure: cfg.network == "unix"}
+	if cfg.timeout > 0 {
+		_ = netConn.SetDeadline(time.Now().Add(cfg.timeout))
+	}
+	if err = c.handshake(cfg); err != nil {
+		c.close()
+		return nil, err
+	}
+	_ = c.netConn.SetDeadline(time.Time{})
+	if _, err = c.exec(sessionInitializationQuery); err != nil {
+		c.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// handshake reads the initial handshake packet sent by the server, upgrades the connection to TLS if configured to,
+// and authenticates
+func (c *connection) handshake(cfg *config) error {
+	packet, err := c.readPacket()
+	if err != nil {
+		return err
+	}
+	if packet[0] == packetERR {
+		return parseError(packet)
+	}
+	if packet[0] != 10 {
+		return fmt.Errorf("%w: unsupported protocol version %d", ErrMalformedPacket, packet[0])
+	}
+	position := bytes.IndexByte(packet[1:], 0) + 2 // Skip the version of the server
+	if position == 1 || len(packet) < position+15 {
+		return ErrMalformedPacket
+	}
+	position += 4 // Skip the id of the connection
+	scramble := append([]byte{}, packet[position:position+8]...)
+	position += 9
+	serverCapabilities := uint32(binary.LittleEndian.Uint16(packet[position:]))
+	position += 2
+	plugin := nativePasswordPlugin
+	if len(packet) >= position+16 {
+		position += 3 // Skip the character set and the status
+		serverCapabilities |= uint32(binary.LittleEndian.Uint16(packet[position:])) << 16
+		position += 13 // Skip the upper capabilities, the length of the authentication data and the reserved bytes
+		if serverCapabilities&clientSecureConnection != 0 {
+			// The rest of the scramble is 12 bytes long, followed by a null byte
+			end := min(position+12, len(packet))
+			scramble = append(scramble, packet[position:end]...)
+			position = min(end+1, len(packet))
+		}
+		if serverCapabilities&clientPluginAuth != 0 && position < len(packet) {
+			name := packet[position:]
+			if end := bytes.IndexByte(name, 0); end != -1 {
+				name = name[:end]
+			}
+			if len(name) > 0 {
+				plugin = string(name)
+			}
+		}
+	}
+	if serverCapabilities&clientProtocol41 == 0 {
+		return fmt.Errorf("%w: server is too old", ErrMalformedPacket)
+	}
+	c.capabilities = clientLongPassword | clientFoundRows | clientLongFlag | clientProtocol41 | clientTransactions | clientSecureConnection | clientPluginAuth | clientPluginAuthLenencClientData
+	if len(cfg.database) > 0 {
+		c.capabilities |= clientConnectWithDB
+	}
+	c.capabilities &= serverCapabilities
+	header := make([]byte, 32)
+	if cfg.tls != nil {
+		if serverCapabilities&clientSSL != 0 {
+			c.capabilities |= clientSSL
+			binary.LittleEndian.PutUint32(header, c.capabilities)
+			binary.LittleEndian.PutUint32(header[4:], maximumPacketSize)
+			header[8] = utf8mb4GeneralCI
+			if err = c.writePacket(header); err != nil {
+				return err
+			}
+			tlsConn := tls.Client(c.netConn, cfg.tls)
+			if err = tlsConn.Handshake(); err != nil {
+				return err
+			}
+			c.netConn, c.reader, c.secure = tlsConn, bufio.NewReader(tlsConn), true
+		} else if !cfg.tlsPreferred {
+			return ErrTLSNotSupported
+		}
+	}
+	if plugin != nativePasswordPlugin && plugin != cachingSHA2PasswordPlugin {
+		// The server will ask to switch to the plugin it requires, which will fail then if it isn't supported either
+		plugin = nativePasswordPlugin
+	}
+	authenticationResponse, _ := scramblePassword(plugin, scramble, cfg.password)
+	binary.LittleEndian.PutUint32(header, c.capabilities)
+	binary.LittleEndian.PutUint32(header[4:], maximumPacketSize)
+	header[8] = utf8mb4GeneralCI
+	response := append(header, cfg.username...)
+	response = append(response, 0)
+	if c.capabilities&clientPluginAuthLenencClientData != 0 {
+		response = appendLengthEncodedInteger(response, uint64(len(authenticationResponse)))
+	} else {
+		response = append(response, byte(len(authenticationResponse)))
+	}
+	response = append(response, authenticationResponse...)
+	if c.capabilities&clientConnectWithDB != 0 {
+		response = append(response, cfg.database...)
+		response = append(response, 0)
+	}
+	if c.capabilities&clientPluginAuth != 0 {
+		response = append(response, plugin...)
+		response = append(response, 0)
+	}
+	if err = c.writePacket(response); err != nil {
+		return err
+	}
+	return c.completeAuthentication(cfg, plugin, scramble)
+}
+
+// completeAuthentication handles the packets sent by the server after the handshake response until the client is
+// either authenticated or rejected
+func (c *connection) completeAuthentication(cfg *config, plugin string, scramble []byte) error {
+	for {
+		packet, err := c.readPacket()
+		if err != nil {
+			return err
+		}
+		switch packet[0] {
+		case packetOK:
+			c.parseOK(packet)
+			return nil
+		case packetERR:
+			return parseError(packet)
+		case packetEOF:
+			// The server asks to authenticate with another plugin, with a new scramble
+			name, data, found := bytes.Cut(packet[1:], []byte{0})
+			if !found {
+				return fmt.Errorf("%w: old password authentication", ErrUnsupportedAuthPlugin)
+			}
+			plugin, scramble = string(name), bytes.TrimSuffix(data, []byte{0})
+			response, err := scramblePassword(plugin, scramble, cfg.password)
+			if err != nil {
+				return err
+			}
+			if err = c.writePacket(response); err != nil {
+				return err
+			}
+		case packetAuthMoreData:
+			if plugin != cachingSHA2PasswordPlugin || len(packet) < 2 {
+				return ErrMalformedPacket
+			}
+			switch packet[1] {
+			case 3:
+				// Fast authentication succeeded, the OK packet follows
+			case 4:
+				// Full authentication is required, for which the password is sent in clear text over secure
+				// connections, or encrypted with the public key of the server otherwise
+				if c.secure {
+					err = c.writePacket(append([]byte(cfg.password), 0))
+				} else if err = c.writePacket([]byte{2}); err == nil {
+					var publicKey, encryptedPassword []byte
+					if publicKey, err = c.readPacket(); err != nil {
+						return err
+					}
+					if publicKey[0] != packetAuthMoreData {
+						return ErrMalformedPacket
+					}
+					if encryptedPassword, err = encryptPassword(cfg.password, scramble, publicKey[1:]); err != nil {
+						return err
+					}
+					err = c.writePacket(encryptedPassword)
+				}
+				if err != nil {
+					return err
+				}
+			default:
+				return ErrMalformedPacket
+			}
+		default:
+			return ErrMalformedPacket
+		}
+	}
+}
+
+// scramblePassword computes the response to the scramble sent by the server with the authentication plugin passed
+func scramblePassword(plugin string, scramble []byte, password string) ([]byte, error) {
+	if len(scramble) > 20 {
+		scramble = scramble[:20]
+	}
+	switch plugin {
+	case nativePasswordPlugin:
+		if len(password) == 0 {
+			return nil, nil
+		}
+		// SHA1(password) XOR SHA1(scramble + SHA1(SHA1(password)))
+		hash := sha1.Sum([]byte(password))
+		hashOfHash := sha1.Sum(hash[:])
+		h := sha1.New()
+		h.Write(scramble)
+		h.Write(hashOfHash[:])
+		response := h.Sum(nil)
+		for i := range response {
+			response[i] ^= hash[i]
+		}
+		return response, nil
+	case cachingSHA2PasswordPlugin:
+		if len(password) == 0 {
+			return nil, nil
+		}
+		// SHA256(password) XOR SHA256(SHA256(SHA256(password)) + scramble)
+		hash := sha256.Sum256([]byte(password))
+		hashOfHash := sha256.Sum256(hash[:])
+		h := sha256.New()
+		h.Write(hashOfHash[:])
+		h.Write(scramble)
+		response := h.Sum(nil)
+		for i := range response {
+			response[i] ^= hash[i]
+		}
+		return response, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAuthPlugin, plugin)
+}
+
+// encryptPassword encrypts the password with the PEM-encoded public key of the server, for caching_sha2_password's
+// full authentication in plain text connections
+func encryptPassword(password string, scramble, publicKey []byte) ([]byte, error) {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return nil, fmt.Errorf("%w: invalid public key", ErrMalformedPacket)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: public key isn't an rsa key", ErrMalformedPacket)
+	}
+	if len(scramble) == 0 {
+		return nil, ErrMalformedPacket
+	}
+	plainText := append([]byte(password), 0)
+	for i := range plainText {
+		plainText[i] ^= scramble[i%len(scramble)]
+	}
+	return rsa.EncryptOAEP(sha1.New(), rand.Reader, rsaKey, plainText, nil)
+}
+
+// readPacket reads a packet, or several ones if its payload doesn't fit in a single one, and returns its payload
+func (c *connection) readPacket() ([]byte, error) {
+	var payload []byte
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+			c.close()
+			return nil, err
+		}
+		length := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
+		if header[3] != c.sequence {
+			c.close()
+			return nil, fmt.Errorf("%w: expected sequence %d, got %d", ErrMalformedPacket, c.sequence, header[3])
+		}
+		c.sequence++
+		chunk := make([]byte, length)
+		if _, err := io.ReadFull(c.reader, chunk); err != nil {
+			c.close()
+			return nil, err
+		}
+		payload = append(payload, chunk...)
+		if length < maximumPacketSize {
+			break
+		}
+	}
+	if len(payload) == 0 {
+		c.close()
+		return nil, ErrMalformedPacket
+	}
+	return payload, nil
+}
+
+// writePacket writes a payload, split in as many packets as necessary
+func (c *connection) writePacket(payload []byte) error {
+	for {
+		length := min(len(payload), maximumPacketSize)
+		packet := make([]byte, 4+length)
+		packet[0], packet[1], packet[2], packet[3] = byte(length), byte(length>>8), byte(length>>16), c.sequence
+		copy(packet[4:], payload[:length])
+		if _, err := c.netConn.Write(packet); err != nil {
+			c.close()
+			return err
+		}
+		c.sequence++
+		payload = payload[length:]
+		if length < maximumPacketSize {
+			return nil
+		}
+	}
+}
+
+// writeCommand sends a command to the server, after reading the remaining rows of the previous query if necessary
+func (c *connection) writeCommand(command byte, argument string) error {
+	if c.closed {
+		return driver.ErrBadConn
+	}
+	if c.activeRows != nil {
+		if err := c.activeRows.Close(); err != nil {
+			return err
+		}
+	}
+	c.sequence = 0
+	payload := make([]byte, 1+len(argument))
+	payload[0] = command
+	copy(payload[1:], argument)
+	if err := c.writePacket(payload); err != nil {
+		// The connection has been closed, presumably by the server, so database/sql may retry with another one
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// query sends a query and returns either the rows returned, or the result of the query if it didn't return any
+func (c *connection) query(query string) (*rows, *result, error) {
+	if err := c.writeCommand(commandQuery, query); err != nil {
+		return nil, nil, err
+	}
+	packet, err := c.readPacket()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch packet[0] {
+	case packetOK:
+		return nil, c.parseOK(packet), nil
+	case packetERR:
+		return nil, nil, parseError(packet)
+	case packetLocalInfile:
+		c.close()
+		return nil, nil, errors.New("mysql driver does not support LOAD DATA LOCAL INFILE")
+	}
+	numberOfColumns, _, n := readLengthEncodedInteger(packet)
+	if n == 0 {
+		c.close()
+		return nil, nil, ErrMalformedPacket
+	}
+	r := &rows{connection: c, columns: make([]column, numberOfColumns)}
+	for i := range r.columns {
+		if packet, err = c.readPacket(); err != nil {
+			return nil, nil, err
+		}
+		if r.columns[i], err = parseColumn(packet); err != nil {
+			c.close()
+			return nil, nil, err
+		}
+	}
+	// The definitions of the columns are followed by an EOF packet
+	if packet, err = c.readPacket(); err != nil {
+		return nil, nil, err
+	}
+	if !isEOF(packet) {
+		c.close()
+		return nil, nil, ErrMalformedPacket
+	}
+	c.activeRows = r
+	return r, nil, nil
+}
+
+// exec sends a query and returns its result, discarding the rows returned if any
+func (c *connection) exec(query string) (*result, error) {
+	r, res, err := c.query(query)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		return &result{}, r.Close()
+	}
+	return res, nil
+}
+
+func (c *connection) parseOK(packet []byte) *result {
+	res := &result{}
+	position := 1
+	affectedRows, _, n := readLengthEncodedInteger(packet[position:])
+	position += n
+	lastInsertID, _, n := readLengthEncodedInteger(packet[position:])
+	position += n
+	if len(packet) >= position+2 {
+		c.status = binary.LittleEndian.Uint16(packet[position:])
+	}
+	res.affectedRows, res.lastInsertID = int64(affectedRows), int64(lastInsertID)
+	return res
+}
+
+func parseError(packet []byte) error {
+	if len(packet) < 3 {
+		return ErrMalformedPacket
+	}
+	e := &Error{Number: binary.LittleEndian.Uint16(packet[1:])}
+	message := packet[3:]
+	if len(message) >= 6 && message[0] == '#' {
+		e.SQLState, message = string(message[1:6]), message[6:]
+	}
+	e.Message = string(message)
+	return e
+}
+
+func isEOF(packet []byte) bool {
+	return packet[0] == packetEOF && len(packet) < 9
+}
+
+// withContext applies the deadline of the context passed, if any, to the commands sent until the next call
+func (c *connection) withContext(ctx context.Context) {
+	deadline, _ := ctx.Deadline()
+	_ = c.netConn.SetDeadline(deadline)
+}
+
+func (c *connection) noBackslashEscapes() bool {
+	return c.status&serverStatusNoBackslashEscapes != 0
+}
+
+func (c *connection) close() {
+	if !c.closed {
+		c.closed = true
+		c.activeRows = nil
+		_ = c.netConn.Close()
+	}
+}
+
+func (c *connection) Prepare(query string) (driver.Stmt, error) {
+	return &statement{connection: c, query: query}, nil
+}
+
+func (c *connection) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.activeRows = nil
+	_ = c.writeCommand(commandQuit, "")
+	c.close()
+	return nil
+}
+
+func (c *connection) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *connection) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if opts.Isolation != driver.IsolationLevel(0) {
+		return nil, errors.New("mysql driver only supports the default isolation level")
+	}
+	c.withContext(ctx)
+	query := "START TRANSACTION"
+	if opts.ReadOnly {
+		query += " READ ONLY"
+	}
+	if _, err := c.exec(query); err != nil {
+		return nil, err
+	}
+	return &transaction{connection: c}, nil
+}
+
+func (c *connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.withContext(ctx)
+	query, err := interpolate(query, args, c.noBackslashEscapes())
+	if err != nil {
+		return nil, err
+	}
+	return c.exec(query)
+}
+
+func (c *connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.withContext(ctx)
+	query, err := interpolate(query, args, c.noBackslashEscapes())
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := c.query(query)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		// The query didn't return any row (e.g. an UPDATE)
+		return &rows{connection: c, done: true}, nil
+	}
+	return r, nil
+}
+
+func (c *connection) Ping(ctx context.Context) error {
+	c.withContext(ctx)
+	if err := c.writeCommand(commandPing, ""); err != nil {
+		return err
+	}
+	packet, err := c.readPacket()
+	if err != nil {
+		return err
+	}
+	switch packet[0] {
+	case packetOK:
+		c.parseOK(packet)
+		return nil
+	case packetERR:
+		return parseError(packet)
+	}
+	c.close()
+	return ErrMalformedPacket
+}
+
+func (c *connection) ResetSession(_ context.Context) error {
+	if c.closed {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *connection) IsValid() bool {
+	return !c.closed
+}
+
+type transaction struct {
+	connection *connection
+}
+
+func (t *transaction) Commit() error {
+	_, err := t.connection.exec("COMMIT")
+	return err
+}
+
+func (t *transaction) Rollback() error {
+	_, err := t.connection.exec("ROLLBACK")
+	return err
+}
+
+// statement is a query that is only sent once executed, since server-side prepared statements aren't supported
+type statement struct {
+	connection *connection
+	query      string
+}
+
+func (s *statement) Close() error {
+	return nil
+}
+
+func (s *statement) NumInput() int {
+	return -1
+}
+
+func (s *statement) Exec(args []driver.Value) (driver.Result, error) {
+	return s.connection.ExecContext(context.Background(), s.query, toNamedValues(args))
+}
+
+func (s *statement) Query(args []driver.Value) (driver.Rows, error) {
+	return s.connection.QueryContext(context.Background(), s.query, toNamedValues(args))
+}
+
+func (s *statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.connection.ExecContext(ctx, s.query, args)
+}
+
+func (s *statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.connection.QueryContext(ctx, s.query, args)
+}
+
+func toNamedValues(args []driver.Value) []driver.NamedValue {
+	namedValues := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedValues[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return namedValues
+}
+
+type result struct {
+	affectedRows int64
+	lastInsertID int64
+}
+
+func (r *result) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r *result) RowsAffected() (int64, error) {
+	return r.affectedRows, nil
+}
@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// interpolate replaces each placeholder of the query passed (e.g. $1) by the literal of the corresponding argument.
+//
+// Placeholders inside string literals, quoted identifiers and comments are left untouched.
+func interpolate(query string, args []driver.NamedValue, noBackslashEscapes bool) (string, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+	values := make([]driver.Value, len(args))
+	for _, arg := range args {
+		if len(arg.Name) > 0 || arg.Ordinal < 1 || arg.Ordinal > len(args) {
+			return "", fmt.Errorf("%w: only ordinal arguments are supported", ErrInvalidArgument)
+		}
+		values[arg.Ordinal-1] = arg.Value
+	}
+	buffer := make([]byte, 0, len(query)+len(args)*8)
+	var err error
+	for i := 0; i < len(query); i++ {
+		switch character := query[i]; {
+		case character == '\'' || character == '"' || character == '`':
+			end := endOfQuotedString(query, i, character == '\'' && !noBackslashEscapes)
+			buffer = append(buffer, query[i:end]...)
+			i = end - 1
+		case character == '#' || (character == '-' && strings.HasPrefix(query[i:], "-- ")):
+			end := len(query)
+			for j := i; j < len(query); j++ {
+				if query[j] == '\n' {
+					end = j
+					break
+				}
+			}
+			buffer = append(buffer, query[i:end]...)
+			i = end - 1
+		case character == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := len(query)
+			for j := i + 2; j+1 < len(query); j++ {
+				if query[j] == '*' && query[j+1] == '/' {
+					end = j + 2
+					break
+				}
+			}
+			buffer = append(buffer, query[i:end]...)
+			i = end - 1
+		case character == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			end := i + 1
+			for end < len(query) && isDigit(query[end]) {
+				end++
+			}
+			number, _ := strconv.Atoi(query[i+1 : end])
+			if number < 1 || number > len(values) {
+				return "", fmt.Errorf("%w: no argument for placeholder $%d", ErrInvalidArgument, number)
+			}
+			if buffer, err = appendValue(buffer, values[number-1], noBackslashEscapes); err != nil {
+				return "", err
+			}
+			i = end - 1
+		default:
+			buffer = append(buffer, character)
+		}
+	}
+	return string(buffer), nil
+}
+
+// endOfQuotedString returns the position following the quote closing the quoted string starting at the position passed
+func endOfQuotedString(query string, start int, backslashEscapes bool) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		if backslashEscapes && query[i] == '\\' {
+			i++
+		} else if query[i] == quote {
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func isDigit(character byte) bool {
+	return character >= '0' && character <= '9'
+}
+
+// appendValue appends the SQL literal of a value
+func appendValue(buffer []byte, value driver.Value, noBackslashEscapes bool) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buffer, "NULL"...), nil
+	case int64:
+		return strconv.AppendInt(buffer, v, 10), nil
+	case uint64:
+		return strconv.AppendUint(buffer, v, 10), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%w: %v cannot be persisted", ErrInvalidArgument, v)
+		}
+		return strconv.AppendFloat(buffer, v, 'g', -1, 64), nil
+	case bool:
+		if v {
+			return append(buffer, '1'), nil
+		}
+		return append(buffer, '0'), nil
+	case time.Time:
+		buffer = append(buffer, '\'')
+		buffer = v.UTC().AppendFormat(buffer, "2006-01-02 15:04:05.999999")
+		return append(buffer, '\''), nil
+	case string:
+		return appendString(buffer, v, noBackslashEscapes), nil
+	case []byte:
+		if v == nil {
+			return append(buffer, "NULL"...), nil
+		}
+		buffer = append(buffer, "X'"...)
+		buffer = hex.AppendEncode(buffer, v)
+		return append(buffer, '\''), nil
+	}
+	return nil, fmt.Errorf("%w: unsupported type %T", ErrInvalidArgument, value)
+}
+
+// appendString appends the string literal of a value, escaped according to whether the NO_BACKSLASH_ESCAPES SQL mode
+// is enabled
+func appendString(buffer []byte, value string, noBackslashEscapes bool) []byte {
+	buffer = append(buffer, '\'')
+	for i := 0; i < len(value); i++ {
+		character := value[i]
+		if noBackslashEscapes {
+			if character == '\'' {
+				buffer = append(buffer, '\'')
+			}
+			buffer = append(buffer, character)
+			continue
+		}
+		switch character {
+		case 0:
+			buffer = append(buffer, '\\', '0')
+		case '\n':
+			buffer = append(buffer, '\\', 'n')
+		case '\r':
+			buffer = append(buffer, '\\', 'r')
+		case '\x1a':
+			buffer = append(buffer, '\\', 'Z')
+		case '\'', '"', '\\':
+			buffer = append(buffer, '\\', character)
+		default:
+			buffer = append(buffer, character)
+		}
+	}
+	return append(buffer, '\'')
+}
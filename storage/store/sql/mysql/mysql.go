@@ -0,0 +1,207 @@
+// Package mysql is a minimal database/sql driver for MySQL and MariaDB, registered as "mysql".
+//
+// Only what the SQL store needs is supported: queries are sent through the text protocol with their arguments
+// interpolated client-side, and there's no support for server-side prepared statements, multiple statements nor
+// LOAD DATA LOCAL INFILE.
+//
+// So that the same queries can be used with every driver of the SQL store, placeholders are numbered like PostgreSQL's
+// (e.g. $1) and may be used several times in the same query, and each session uses the ANSI_QUOTES SQL mode, which
+// makes double quotes delimit identifiers rather than strings. Each session also uses the UTC time zone.
+//
+// The data source name has the same format as the one of github.com/go-sql-driver/mysql:
+//
+//	[username[:password]@][protocol[(address)]]/dbname[?param1=value1&paramN=valueN]
+//
+// The supported protocols are tcp, which is the default, and unix. The supported parameters are:
+//   - tls: whether to use TLS, with true, false (default), skip-verify (don't verify the certificate of the server) or
+//     preferred (use TLS without verifying the certificate of the server if the server supports it)
+//   - timeout: timeout for establishing the connection, as a duration (e.g. 10s; default 30s)
+package mysql
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DriverName is the name with which the driver is registered
+	DriverName = "mysql"
+
+	defaultPort        = "3306"
+	defaultSocket      = "/tmp/mysql.sock"
+	defaultDialTimeout = 30 * time.Second
+)
+
+var (
+	// ErrInvalidDSN is the error returned when the data source name cannot be parsed
+	ErrInvalidDSN = errors.New("invalid mysql data source name")
+
+	// ErrTLSNotSupported is the error returned when TLS is required, but the server doesn't support it
+	ErrTLSNotSupported = errors.New("mysql server does not support tls")
+
+	// ErrUnsupportedAuthPlugin is the error returned when the server requires an authentication plugin that isn't
+	// supported. Only mysql_native_password and caching_sha2_password are supported.
+	ErrUnsupportedAuthPlugin = errors.New("unsupported mysql authentication plugin")
+
+	// ErrMalformedPacket is the error returned when a packet received from the server cannot be parsed
+	ErrMalformedPacket = errors.New("malformed mysql packet")
+
+	// ErrInvalidArgument is the error returned when the arguments of a query don't match its placeholders, or when
+	// an argument's type isn't supported
+	ErrInvalidArgument = errors.New("invalid mysql query argument")
+)
+
+// Error is an error returned by the server
+type Error struct {
+	// Number is the error code (e.g. 1062 for duplicate entries)
+	Number uint16
+
+	// SQLState is the SQLSTATE of the error, if sent by the server
+	SQLState string
+
+	// Message is the message describing the error
+	Message string
+}
+
+func (e *Error) Error() string {
+	if len(e.SQLState) > 0 {
+		return fmt.Sprintf("mysql error %d (%s): %s", e.Number, e.SQLState, e.Message)
+	}
+	return fmt.Sprintf("mysql error %d: %s", e.Number, e.Message)
+}
+
+func init() {
+	sql.Register(DriverName, Driver{})
+}
+
+// Driver is the database/sql driver for MySQL and MariaDB
+type Driver struct{}
+
+// Open opens a new connection to the database identified by the data source name passed
+func (d Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect(context.Background())
+}
+
+// OpenConnector parses the data source name passed once, for all the connections opened by the connector returned
+func (d Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{config: cfg}, nil
+}
+
+type connector struct {
+	config *config
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return connect(ctx, c.config)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return Driver{}
+}
+
+// config is the parsed data source name
+type config struct {
+	username string
+	password string
+	network  string
+	address  string
+	database string
+
+	// tls is the configuration used to establish TLS connections, or nil if TLS is disabled
+	tls *tls.Config
+
+	// tlsPreferred is whether the connection may fall back to plain text if the server doesn't support TLS
+	tlsPreferred bool
+
+	timeout time.Duration
+}
+
+func parseDSN(dsn string) (*config, error) {
+	cfg := &config{network: "tcp", address: net.JoinHostPort("127.0.0.1", defaultPort), timeout: defaultDialTimeout}
+	// The database name cannot contain slashes, unlike the password
+	slash := strings.LastIndex(dsn, "/")
+	if slash == -1 {
+		return nil, fmt.Errorf("%w: missing the slash preceding the database name", ErrInvalidDSN)
+	}
+	prefix, suffix := dsn[:slash], dsn[slash+1:]
+	if at := strings.LastIndex(prefix, "@"); at != -1 {
+		cfg.username, cfg.password, _ = strings.Cut(prefix[:at], ":")
+		prefix = prefix[at+1:]
+	}
+	if len(prefix) > 0 {
+		network, address, hasAddress := strings.Cut(prefix, "(")
+		if hasAddress {
+			if !strings.HasSuffix(address, ")") {
+				return nil, fmt.Errorf("%w: missing the closing parenthesis of the address", ErrInvalidDSN)
+			}
+			address = strings.TrimSuffix(address, ")")
+		}
+		cfg.network = network
+		switch network {
+		case "tcp":
+			if len(address) > 0 {
+				cfg.address = address
+				if _, _, err := net.SplitHostPort(address); err != nil {
+					cfg.address = net.JoinHostPort(address, defaultPort)
+				}
+			}
+		case "unix":
+			cfg.address = defaultSocket
+			if len(address) > 0 {
+				cfg.address = address
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported protocol %s", ErrInvalidDSN, network)
+		}
+	}
+	database, rawQuery, _ := strings.Cut(suffix, "?")
+	cfg.database = database
+	parameters, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDSN, err.Error())
+	}
+	for name, values := range parameters {
+		value := values[len(values)-1]
+		switch name {
+		case "tls":
+			host := cfg.address
+			if cfg.network == "tcp" {
+				host, _, _ = net.SplitHostPort(cfg.address)
+			}
+			switch value {
+			case "true":
+				cfg.tls = &tls.Config{ServerName: host}
+			case "skip-verify", "preferred":
+				cfg.tls = &tls.Config{ServerName: host, InsecureSkipVerify: true}
+				cfg.tlsPreferred = value == "preferred"
+			case "false":
+				cfg.tls = nil
+			default:
+				return nil, fmt.Errorf("%w: invalid value %s for parameter tls", ErrInvalidDSN, value)
+			}
+		case "timeout":
+			if cfg.timeout, err = time.ParseDuration(value); err != nil {
+				return nil, fmt.Errorf("%w: invalid value %s for parameter timeout", ErrInvalidDSN, value)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported parameter %s", ErrInvalidDSN, name)
+		}
+	}
+	return cfg, nil
+}
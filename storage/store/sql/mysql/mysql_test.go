@@ -0,0 +1,485 @@
+package mysql
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDSN(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		dsn              string
+		expectedErr      error
+		expectedUsername string
+		expectedPassword string
+		expectedNetwork  string
+		expectedAddress  string
+		expectedDatabase string
+		expectedTLS      bool
+	}{
+		{
+			name:             "full",
+			dsn:              "gatus:p@ss/w@rd@tcp(mysql:3307)/gatus?tls=true&timeout=5s",
+			expectedUsername: "gatus",
+			expectedPassword: "p@ss/w@rd",
+			expectedNetwork:  "tcp",
+			expectedAddress:  "mysql:3307",
+			expectedDatabase: "gatus",
+			expectedTLS:      true,
+		},
+		{
+			name:             "tcp-without-port",
+			dsn:              "gatus@tcp(mysql)/gatus",
+			expectedUsername: "gatus",
+			expectedNetwork:  "tcp",
+			expectedAddress:  "mysql:3306",
+			expectedDatabase: "gatus",
+		},
+		{
+			name:             "defaults",
+			dsn:              "/gatus",
+			expectedNetwork:  "tcp",
+			expectedAddress:  "127.0.0.1:3306",
+			expectedDatabase: "gatus",
+		},
+		{
+			name:             "unix",
+			dsn:              "root:password@unix(/var/run/mysqld/mysqld.sock)/gatus?tls=false",
+			expectedUsername: "root",
+			expectedPassword: "password",
+			expectedNetwork:  "unix",
+			expectedAddress:  "/var/run/mysqld/mysqld.sock",
+			expectedDatabase: "gatus",
+		},
+		{
+			name:        "no-slash",
+			dsn:         "root:password@tcp(mysql:3306)",
+			expectedErr: ErrInvalidDSN,
+		},
+		{
+			name:        "unsupported-protocol",
+			dsn:         "root@udp(mysql:3306)/gatus",
+			expectedErr: ErrInvalidDSN,
+		},
+		{
+			name:        "unclosed-address",
+			dsn:         "root@tcp(mysql:3306/gatus",
+			expectedErr: ErrInvalidDSN,
+		},
+		{
+			name:        "unsupported-parameter",
+			dsn:         "root@tcp(mysql:3306)/gatus?parseTime=true",
+			expectedErr: ErrInvalidDSN,
+		},
+		{
+			name:        "invalid-tls",
+			dsn:         "root@tcp(mysql:3306)/gatus?tls=maybe",
+			expectedErr: ErrInvalidDSN,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg, err := parseDSN(scenario.dsn)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.username != scenario.expectedUsername || cfg.password != scenario.expectedPassword {
+				t.Errorf("expected credentials %s:%s, got %s:%s", scenario.expectedUsername, scenario.expectedPassword, cfg.username, cfg.password)
+			}
+			if cfg.network != scenario.expectedNetwork || cfg.address != scenario.expectedAddress {
+				t.Errorf("expected %s(%s), got %s(%s)", scenario.expectedNetwork, scenario.expectedAddress, cfg.network, cfg.address)
+			}
+			if cfg.database != scenario.expectedDatabase {
+				t.Errorf("expected database %s, got %s", scenario.expectedDatabase, cfg.database)
+			}
+			if (cfg.tls != nil) != scenario.expectedTLS {
+				t.Errorf("expected tls to be %v", scenario.expectedTLS)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	timestamp := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.FixedZone("UTC+2", 2*60*60))
+	scenarios := []struct {
+		name               string
+		query              string
+		args               []driver.Value
+		noBackslashEscapes bool
+		expectedQuery      string
+		expectedErr        error
+	}{
+		{
+			name:          "no-arguments",
+			query:         "SELECT 1",
+			expectedQuery: "SELECT 1",
+		},
+		{
+			name:          "types",
+			query:         "INSERT INTO t VALUES ($1, $2, $3, $4, $5, $6, $7)",
+			args:          []driver.Value{int64(-5), 1.5, true, false, nil, timestamp, []byte{0xde, 0xad}},
+			expectedQuery: "INSERT INTO t VALUES (-5, 1.5, 1, 0, NULL, '2024-05-06 05:08:09.123456', X'dead')",
+		},
+		{
+			name:          "reused-placeholder",
+			query:         "INSERT INTO t (a, b) VALUES ($1, $2) ON DUPLICATE KEY UPDATE b = $2",
+			args:          []driver.Value{int64(1), "x"},
+			expectedQuery: "INSERT INTO t (a, b) VALUES (1, 'x') ON DUPLICATE KEY UPDATE b = 'x'",
+		},
+		{
+			name:          "more-than-nine-placeholders",
+			query:         "SELECT $1, $10, $11",
+			args:          []driver.Value{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(7), int64(8), int64(9), int64(10), int64(11)},
+			expectedQuery: "SELECT 1, 10, 11",
+		},
+		{
+			name:          "escaped-string",
+			query:         "SELECT $1",
+			args:          []driver.Value{"it's a \"test\"\\\n\x00"},
+			expectedQuery: `SELECT 'it\'s a \"test\"\\\n\0'`,
+		},
+		{
+			name:               "escaped-string-without-backslash-escapes",
+			query:              "SELECT $1",
+			args:               []driver.Value{`it's \`},
+			noBackslashEscapes: true,
+			expectedQuery:      `SELECT 'it''s \'`,
+		},
+		{
+			name:          "placeholders-in-literals-and-comments",
+			query:         "SELECT '$1', 'it\\'s $1', \"$1\", `$1` /* $1 */, $1 -- $1\n, $1 # $1",
+			args:          []driver.Value{int64(7)},
+			expectedQuery: "SELECT '$1', 'it\\'s $1', \"$1\", `$1` /* $1 */, 7 -- $1\n, 7 # $1",
+		},
+		{
+			name:          "dollar-without-number",
+			query:         "SELECT '$' AS a, $1",
+			args:          []driver.Value{"$"},
+			expectedQuery: "SELECT '$' AS a, '$'",
+		},
+		{
+			name:        "missing-argument",
+			query:       "SELECT $1, $2",
+			args:        []driver.Value{int64(1)},
+			expectedErr: ErrInvalidArgument,
+		},
+		{
+			name:        "unsupported-type",
+			query:       "SELECT $1",
+			args:        []driver.Value{struct{}{}},
+			expectedErr: ErrInvalidArgument,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			query, err := interpolate(scenario.query, toNamedValues(scenario.args), scenario.noBackslashEscapes)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if query != scenario.expectedQuery {
+				t.Errorf("expected query:\n%s\ngot:\n%s", scenario.expectedQuery, query)
+			}
+		})
+	}
+}
+
+func TestScramblePassword(t *testing.T) {
+	scramble := []byte("abcdefghijklmnopqrst")
+	// The server verifies the response by recovering the hash of the password using the hash of the hash it stores
+	t.Run(nativePasswordPlugin, func(t *testing.T) {
+		response, err := scramblePassword(nativePasswordPlugin, scramble, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha1.Sum([]byte("password"))
+		stored := sha1.Sum(hash[:])
+		h := sha1.New()
+		h.Write(scramble)
+		h.Write(stored[:])
+		candidate := h.Sum(nil)
+		for i := range candidate {
+			candidate[i] ^= response[i]
+		}
+		if sha1.Sum(candidate) != stored {
+			t.Error("server would have rejected the response")
+		}
+	})
+	t.Run(cachingSHA2PasswordPlugin, func(t *testing.T) {
+		response, err := scramblePassword(cachingSHA2PasswordPlugin, scramble, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256([]byte("password"))
+		stored := sha256.Sum256(hash[:])
+		h := sha256.New()
+		h.Write(stored[:])
+		h.Write(scramble)
+		candidate := h.Sum(nil)
+		for i := range candidate {
+			candidate[i] ^= response[i]
+		}
+		if sha256.Sum256(candidate) != stored {
+			t.Error("server would have rejected the response")
+		}
+	})
+	t.Run("empty-password", func(t *testing.T) {
+		if response, _ := scramblePassword(nativePasswordPlugin, scramble, ""); len(response) != 0 {
+			t.Error("expected empty response for empty password")
+		}
+	})
+	t.Run("unsupported-plugin", func(t *testing.T) {
+		if _, err := scramblePassword("client_ed25519", scramble, "password"); !errors.Is(err, ErrUnsupportedAuthPlugin) {
+			t.Errorf("expected %v, got %v", ErrUnsupportedAuthPlugin, err)
+		}
+	})
+}
+
+func TestDriver(t *testing.T) {
+	for _, plugin := range []string{nativePasswordPlugin, cachingSHA2PasswordPlugin} {
+		t.Run(plugin, func(t *testing.T) {
+			server := newFakeServer(t, plugin, "password")
+			db, err := sql.Open(DriverName, "gatus:password@tcp("+server.address+")/gatus")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			db.SetMaxOpenConns(1)
+			if err = db.Ping(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result, err := db.Exec("INSERT INTO endpoints (endpoint_key) VALUES ($1)", "core_frontend")
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if id, _ := result.LastInsertId(); id != 42 {
+				t.Errorf("expected last insert id 42, got %d", id)
+			}
+			if affectedRows, _ := result.RowsAffected(); affectedRows != 1 {
+				t.Errorf("expected 1 affected row, got %d", affectedRows)
+			}
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			rows, err := tx.Query("SELECT endpoint_id, endpoint_key, created_at, deleted_at FROM endpoints WHERE endpoint_id > $1", 0)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			var numberOfRows int
+			for rows.Next() {
+				var id int64
+				var key string
+				var createdAt time.Time
+				var deletedAt sql.NullTime
+				if err = rows.Scan(&id, &key, &createdAt, &deletedAt); err != nil {
+					t.Fatal("expected no error, got", err.Error())
+				}
+				if id != 42 || key != "core_frontend" || !createdAt.Equal(time.Date(2024, 5, 6, 7, 8, 9, 500000000, time.UTC)) || deletedAt.Valid {
+					t.Errorf("unexpected row %d, %s, %s, %v", id, key, createdAt, deletedAt)
+				}
+				numberOfRows++
+			}
+			if err = rows.Err(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if numberOfRows != 2 {
+				t.Errorf("expected 2 rows, got %d", numberOfRows)
+			}
+			if err = tx.Commit(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			// Rows that aren't read until the end mustn't prevent the next query from being sent
+			var id int64
+			if err = db.QueryRow("SELECT endpoint_id, endpoint_key, created_at, deleted_at FROM endpoints").Scan(&id, new(string), new(time.Time), new(sql.NullTime)); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			_, err = db.Exec("SELECT broken")
+			var mysqlErr *Error
+			if !errors.As(err, &mysqlErr) || mysqlErr.Number != 1064 || mysqlErr.SQLState != "42000" {
+				t.Errorf("expected mysql error 1064, got %v", err)
+			}
+			if err = db.Ping(); err != nil {
+				t.Error("expected connection to still be usable, got", err.Error())
+			}
+			expectedQueries := []string{
+				sessionInitializationQuery,
+				"INSERT INTO endpoints (endpoint_key) VALUES ('core_frontend')",
+				"START TRANSACTION",
+				"SELECT endpoint_id, endpoint_key, created_at, deleted_at FROM endpoints WHERE endpoint_id > 0",
+				"COMMIT",
+				"SELECT endpoint_id, endpoint_key, created_at, deleted_at FROM endpoints",
+				"SELECT broken",
+			}
+			if queries := server.getQueries(); strings.Join(queries, "\n") != strings.Join(expectedQueries, "\n") {
+				t.Errorf("expected queries:\n%s\ngot:\n%s", strings.Join(expectedQueries, "\n"), strings.Join(queries, "\n"))
+			}
+		})
+	}
+	t.Run("wrong-password", func(t *testing.T) {
+		server := newFakeServer(t, nativePasswordPlugin, "password")
+		db, err := sql.Open(DriverName, "gatus:wrong@tcp("+server.address+")/gatus")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		var mysqlErr *Error
+		if err = db.Ping(); !errors.As(err, &mysqlErr) || mysqlErr.Number != 1045 {
+			t.Errorf("expected mysql error 1045, got %v", err)
+		}
+	})
+}
+
+// fakeServer is a server speaking just enough of the MySQL protocol to test the driver
+type fakeServer struct {
+	t        *testing.T
+	address  string
+	plugin   string
+	password string
+	queries  chan string
+}
+
+func newFakeServer(t *testing.T, plugin, password string) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	server := &fakeServer{t: t, address: listener.Addr().String(), plugin: plugin, password: password, queries: make(chan string, 100)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeServer) getQueries() []string {
+	var queries []string
+	for {
+		select {
+		case query := <-s.queries:
+			queries = append(queries, query)
+		default:
+			return queries
+		}
+	}
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	scramble := []byte("01234567890123456789")
+	capabilities := clientLongPassword | clientFoundRows | clientLongFlag | clientConnectWithDB | clientProtocol41 | clientTransactions | clientSecureConnection | clientPluginAuth | clientPluginAuthLenencClientData
+	handshake := append([]byte{10}, "8.0.36\x00"...)
+	handshake = append(handshake, 1, 0, 0, 0)
+	handshake = append(handshake, scramble[:8]...)
+	handshake = append(handshake, 0, byte(capabilities), byte(capabilities>>8), utf8mb4GeneralCI, 2, 0, byte(capabilities>>16), byte(capabilities>>24), 21)
+	handshake = append(handshake, make([]byte, 10)...)
+	handshake = append(handshake, scramble[8:]...)
+	handshake = append(handshake, 0)
+	handshake = append(handshake, s.plugin+"\x00"...)
+	writeFakePacket(conn, 0, handshake)
+	response, sequence, err := readFakePacket(conn)
+	if err != nil {
+		return
+	}
+	username, rest, _ := bytes.Cut(response[32:], []byte{0})
+	authenticationResponse := rest[1 : 1+rest[0]]
+	expectedResponse, _ := scramblePassword(s.plugin, scramble, s.password)
+	if string(username) != "gatus" || !bytes.Equal(authenticationResponse, expectedResponse) {
+		writeFakePacket(conn, sequence+1, fakeError(1045, "28000", "Access denied"))
+		return
+	}
+	if s.plugin == cachingSHA2PasswordPlugin {
+		sequence++
+		writeFakePacket(conn, sequence, []byte{packetAuthMoreData, 3})
+	}
+	writeFakePacket(conn, sequence+1, []byte{packetOK, 0, 0, 2, 0, 0, 0})
+	for {
+		command, _, err := readFakePacket(conn)
+		if err != nil {
+			return
+		}
+		switch command[0] {
+		case commandQuit:
+			return
+		case commandPing:
+			writeFakePacket(conn, 1, []byte{packetOK, 0, 0, 2, 0, 0, 0})
+			continue
+		}
+		query := string(command[1:])
+		s.queries <- query
+		switch {
+		case strings.HasPrefix(query, "INSERT"):
+			writeFakePacket(conn, 1, []byte{packetOK, 1, 42, 2, 0, 0, 0})
+		case query == "SELECT broken":
+			writeFakePacket(conn, 1, fakeError(1064, "42000", "You have an error in your SQL syntax"))
+		case strings.HasPrefix(query, "SELECT"):
+			writeFakePacket(conn, 1, []byte{4})
+			writeFakePacket(conn, 2, fakeColumn("endpoint_id", fieldTypeLongLong))
+			writeFakePacket(conn, 3, fakeColumn("endpoint_key", 0xfd))
+			writeFakePacket(conn, 4, fakeColumn("created_at", fieldTypeDatetime))
+			writeFakePacket(conn, 5, fakeColumn("deleted_at", fieldTypeDatetime))
+			writeFakePacket(conn, 6, []byte{packetEOF, 0, 0, 2, 0})
+			for i := byte(0); i < 2; i++ {
+				row := appendFakeString(nil, "42")
+				row = appendFakeString(row, "core_frontend")
+				row = appendFakeString(row, "2024-05-06 07:08:09.500000")
+				row = append(row, 0xfb)
+				writeFakePacket(conn, 7+i, row)
+			}
+			writeFakePacket(conn, 9, []byte{packetEOF, 0, 0, 2, 0})
+		default:
+			writeFakePacket(conn, 1, []byte{packetOK, 0, 0, 2, 0, 0, 0})
+		}
+	}
+}
+
+func readFakePacket(conn net.Conn) ([]byte, byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		return nil, 0, err
+	}
+	payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	_, err := io.ReadFull(conn, payload)
+	return payload, header[3], err
+}
+
+func writeFakePacket(conn net.Conn, sequence byte, payload []byte) {
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), sequence}
+	_, _ = conn.Write(append(header, payload...))
+}
+
+func fakeError(number uint16, state, message string) []byte {
+	packet := []byte{packetERR, 0, 0}
+	binary.LittleEndian.PutUint16(packet[1:], number)
+	return append(packet, "#"+state+message...)
+}
+
+func fakeColumn(name string, fieldType byte) []byte {
+	var packet []byte
+	for _, value := range []string{"def", "gatus", "endpoints", "endpoints", name, name} {
+		packet = appendFakeString(packet, value)
+	}
+	return append(packet, 0x0c, 45, 0, 0, 1, 0, 0, fieldType, 0, 0, 0, 0, 0)
+}
+
+func appendFakeString(b []byte, value string) []byte {
+	return append(appendLengthEncodedInteger(b, uint64(len(value))), value...)
+}
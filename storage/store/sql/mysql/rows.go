@@ -0,0 +1,221 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fieldTypeTiny      byte = 0x01
+	fieldTypeShort     byte = 0x02
+	fieldTypeLong      byte = 0x03
+	fieldTypeFloat     byte = 0x04
+	fieldTypeDouble    byte = 0x05
+	fieldTypeTimestamp byte = 0x07
+	fieldTypeLongLong  byte = 0x08
+	fieldTypeInt24     byte = 0x09
+	fieldTypeDate      byte = 0x0a
+	fieldTypeDatetime  byte = 0x0c
+	fieldTypeYear      byte = 0x0d
+
+	fieldFlagUnsigned uint16 = 0x0020
+)
+
+// column is the definition of a column of a result set
+type column struct {
+	name      string
+	fieldType byte
+	flags     uint16
+}
+
+func parseColumn(packet []byte) (column, error) {
+	var col column
+	position := 0
+	// The name of the column is preceded by the catalog, the schema, the table and the original table, and followed
+	// by the original name
+	for i := 0; i < 6; i++ {
+		value, _, n := readLengthEncodedString(packet[position:])
+		if n == 0 {
+			return col, ErrMalformedPacket
+		}
+		if i == 4 {
+			col.name = string(value)
+		}
+		position += n
+	}
+	// The length of the fixed-length fields (always 0x0c) is followed by the character set (2), the length of the
+	// column (4), its type (1) and its flags (2)
+	if len(packet) < position+10 {
+		return col, ErrMalformedPacket
+	}
+	col.fieldType = packet[position+7]
+	col.flags = binary.LittleEndian.Uint16(packet[position+8:])
+	return col, nil
+}
+
+// convert converts a value of the column in the text protocol to the closest driver.Value
+func (col *column) convert(value []byte) (driver.Value, error) {
+	switch col.fieldType {
+	case fieldTypeTiny, fieldTypeShort, fieldTypeInt24, fieldTypeLong, fieldTypeLongLong, fieldTypeYear:
+		if col.flags&fieldFlagUnsigned != 0 {
+			if unsigned, err := strconv.ParseUint(string(value), 10, 64); err != nil || unsigned > math.MaxInt64 {
+				return value, nil
+			}
+		}
+		return strconv.ParseInt(string(value), 10, 64)
+	case fieldTypeFloat, fieldTypeDouble:
+		return strconv.ParseFloat(string(value), 64)
+	case fieldTypeDate, fieldTypeDatetime, fieldTypeTimestamp:
+		return parseTime(string(value))
+	}
+	return value, nil
+}
+
+// parseTime parses a DATE, DATETIME or TIMESTAMP value, which is in UTC since that's the time zone of the session
+func parseTime(value string) (time.Time, error) {
+	if strings.HasPrefix(value, "0000-00-00") {
+		return time.Time{}, nil
+	}
+	if len(value) == len(time.DateOnly) {
+		return time.ParseInLocation(time.DateOnly, value, time.UTC)
+	}
+	// Fractional seconds are accepted even though the layout doesn't have any
+	return time.ParseInLocation(time.DateTime, value, time.UTC)
+}
+
+// rows are the rows of a result set, which are read from the connection as they're iterated over
+type rows struct {
+	connection *connection
+	columns    []column
+	done       bool
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, col := range r.columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+// Close reads the remaining rows, since the next command cannot be sent until the end of the result set
+func (r *rows) Close() error {
+	for !r.done {
+		if err := r.next(nil); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	return r.next(dest)
+}
+
+// next reads the next row into dest, or skips it if dest is nil
+func (r *rows) next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	packet, err := r.connection.readPacket()
+	if err != nil {
+		r.finish()
+		return err
+	}
+	if isEOF(packet) {
+		if len(packet) >= 5 {
+			r.connection.status = binary.LittleEndian.Uint16(packet[3:])
+		}
+		r.finish()
+		return io.EOF
+	}
+	if packet[0] == packetERR {
+		r.finish()
+		return parseError(packet)
+	}
+	if dest == nil {
+		return nil
+	}
+	position := 0
+	for i := range dest {
+		value, isNull, n := readLengthEncodedString(packet[position:])
+		if n == 0 {
+			r.finish()
+			r.connection.close()
+			return ErrMalformedPacket
+		}
+		position += n
+		if isNull {
+			dest[i] = nil
+			continue
+		}
+		if dest[i], err = r.columns[i].convert(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *rows) finish() {
+	r.done = true
+	if r.connection.activeRows == r {
+		r.connection.activeRows = nil
+	}
+}
+
+// readLengthEncodedInteger reads a length-encoded integer and returns its value, whether it's the NULL marker of rows,
+// and its length, which is 0 if the integer is truncated
+func readLengthEncodedInteger(b []byte) (value uint64, isNull bool, n int) {
+	if len(b) == 0 {
+		return 0, false, 0
+	}
+	switch b[0] {
+	case 0xfb:
+		return 0, true, 1
+	case 0xfc:
+		n = 3
+	case 0xfd:
+		n = 4
+	case 0xfe:
+		n = 9
+	default:
+		return uint64(b[0]), false, 1
+	}
+	if len(b) < n {
+		return 0, false, 0
+	}
+	for i := n - 1; i > 0; i-- {
+		value = value<<8 | uint64(b[i])
+	}
+	return value, false, n
+}
+
+// readLengthEncodedString reads a length-encoded string and returns its value, whether it's the NULL marker of rows,
+// and its length, which is 0 if the string is truncated
+func readLengthEncodedString(b []byte) (value []byte, isNull bool, n int) {
+	length, isNull, n := readLengthEncodedInteger(b)
+	if n == 0 || isNull {
+		return nil, isNull, n
+	}
+	if uint64(len(b)-n) < length {
+		return nil, false, 0
+	}
+	return b[n : n+int(length)], false, n + int(length)
+}
+
+func appendLengthEncodedInteger(b []byte, value uint64) []byte {
+	switch {
+	case value < 0xfb:
+		return append(b, byte(value))
+	case value <= 0xffff:
+		return append(b, 0xfc, byte(value), byte(value>>8))
+	case value <= 0xffffff:
+		return append(b, 0xfd, byte(value), byte(value>>8), byte(value>>16))
+	}
+	return append(b, 0xfe, byte(value), byte(value>>8), byte(value>>16), byte(value>>24), byte(value>>32), byte(value>>40), byte(value>>48), byte(value>>56))
+}
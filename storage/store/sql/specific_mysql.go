@@ -0,0 +1,174 @@
+package sql
+
+// MySQL can neither index TEXT columns without a prefix length nor give them a default value, so such columns use
+// VARCHAR instead, and the columns that may contain large values (e.g. the body of a response) use MEDIUMTEXT, since
+// TEXT is limited to 64KB.
+func (s *Store) createMySQLSchema() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoints (
+			endpoint_id    BIGINT       NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_key   VARCHAR(255) UNIQUE,
+			endpoint_name  VARCHAR(255) NOT NULL,
+			endpoint_group VARCHAR(255) NOT NULL,
+			UNIQUE(endpoint_name, endpoint_group)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_events (
+			endpoint_event_id  BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_id        BIGINT      NOT NULL,
+			event_type         TEXT        NOT NULL,
+			event_timestamp    DATETIME(6) NOT NULL,
+			FOREIGN KEY (endpoint_id) REFERENCES endpoints(endpoint_id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_results (
+			endpoint_result_id     BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_id            BIGINT      NOT NULL,
+			success                BOOLEAN     NOT NULL,
+			errors                 MEDIUMTEXT  NOT NULL,
+			connected              BOOLEAN     NOT NULL,
+			status                 BIGINT      NOT NULL,
+			dns_rcode              TEXT        NOT NULL,
+			certificate_expiration BIGINT      NOT NULL,
+			domain_expiration      BIGINT      NOT NULL,
+			hostname               TEXT        NOT NULL,
+			ip                     TEXT        NOT NULL,
+			duration               BIGINT      NOT NULL,
+			timestamp              DATETIME(6) NOT NULL,
+			failure_reason         VARCHAR(64) NOT NULL DEFAULT '',
+			FOREIGN KEY (endpoint_id) REFERENCES endpoints(endpoint_id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_result_conditions (
+			endpoint_result_condition_id  BIGINT     NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_result_id            BIGINT     NOT NULL,
+			"condition"                   MEDIUMTEXT NOT NULL,
+			success                       BOOLEAN    NOT NULL,
+			resolved_left                 MEDIUMTEXT,
+			resolved_right                MEDIUMTEXT,
+			FOREIGN KEY (endpoint_result_id) REFERENCES endpoint_results(endpoint_result_id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_uptimes (
+			endpoint_uptime_id     BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_id            BIGINT NOT NULL,
+			hour_unix_timestamp    BIGINT NOT NULL,
+			total_executions       BIGINT NOT NULL,
+			successful_executions  BIGINT NOT NULL,
+			total_response_time    BIGINT NOT NULL,
+			incidents              BIGINT NOT NULL DEFAULT 0,
+			slow_executions        BIGINT NOT NULL DEFAULT 0,
+			UNIQUE(endpoint_id, hour_unix_timestamp),
+			FOREIGN KEY (endpoint_id) REFERENCES endpoints(endpoint_id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_alerts_triggered (
+			endpoint_alert_trigger_id     BIGINT       NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_id                   BIGINT       NOT NULL,
+			configuration_checksum        VARCHAR(255) NOT NULL,
+			resolve_key                   TEXT         NOT NULL,
+			number_of_successes_in_a_row  INTEGER      NOT NULL,
+			UNIQUE(endpoint_id, configuration_checksum),
+			FOREIGN KEY (endpoint_id) REFERENCES endpoints(endpoint_id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_alerting_states (
+			endpoint_id                   BIGINT      NOT NULL PRIMARY KEY,
+			number_of_failures_in_a_row   INTEGER     NOT NULL,
+			number_of_successes_in_a_row  INTEGER     NOT NULL,
+			last_success_timestamp        DATETIME(6) NOT NULL,
+			down_since                    DATETIME(6) NOT NULL,
+			FOREIGN KEY (endpoint_id) REFERENCES endpoints(endpoint_id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alert_deliveries (
+			alert_delivery_id  BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_key       TEXT        NOT NULL,
+			alert_type         TEXT        NOT NULL,
+			alert_checksum     TEXT        NOT NULL,
+			resolved           BOOLEAN     NOT NULL,
+			result             MEDIUMTEXT  NOT NULL,
+			state              TEXT        NOT NULL,
+			attempts           INTEGER     NOT NULL,
+			last_error         TEXT        NOT NULL,
+			dead_lettered      BOOLEAN     NOT NULL,
+			created_at         DATETIME(6) NOT NULL,
+			next_attempt_at    DATETIME(6) NOT NULL,
+			history_entry_id   BIGINT      NOT NULL DEFAULT 0
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alert_history (
+			alert_history_entry_id  BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_key            TEXT        NOT NULL,
+			endpoint_group          TEXT        NOT NULL,
+			endpoint_name           TEXT        NOT NULL,
+			alert_type              TEXT        NOT NULL,
+			alert_checksum          TEXT        NOT NULL,
+			description             TEXT        NOT NULL,
+			triggered_at            DATETIME(6) NOT NULL,
+			resolved_at             DATETIME(6),
+			triggered_delivery      TEXT        NOT NULL,
+			resolved_delivery       TEXT
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS external_endpoint_tokens (
+			external_endpoint_token_id  BIGINT       NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			endpoint_key                VARCHAR(255) NOT NULL,
+			name                        TEXT         NOT NULL,
+			token_hash                  VARCHAR(255) NOT NULL UNIQUE,
+			created_at                  DATETIME(6)  NOT NULL,
+			expires_at                  DATETIME(6),
+			revoked_at                  DATETIME(6)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS configuration_snapshots (
+			configuration_snapshot_id  BIGINT      NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			checksum                   TEXT        NOT NULL,
+			source                     TEXT        NOT NULL,
+			rollback_of                BIGINT      NOT NULL DEFAULT 0,
+			applied_at                 DATETIME(6) NOT NULL,
+			data                       MEDIUMTEXT  NOT NULL
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
+	`)
+	return err
+}
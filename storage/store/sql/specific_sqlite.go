@@ -38,7 +38,8 @@ func (s *Store) createSQLiteSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               INTEGER   NOT NULL,
-			timestamp              TIMESTAMP NOT NULL
+			timestamp              TIMESTAMP NOT NULL,
+			failure_reason         TEXT      NOT NULL DEFAULT ''
 		)
 	`)
 	if err != nil {
@@ -63,6 +64,8 @@ func (s *Store) createSQLiteSchema() error {
 			total_executions      INTEGER NOT NULL,
 			successful_executions INTEGER NOT NULL,
 			total_response_time   INTEGER NOT NULL,
+			incidents             INTEGER NOT NULL DEFAULT 0,
+			slow_executions       INTEGER NOT NULL DEFAULT 0,
 			UNIQUE(endpoint_id, hour_unix_timestamp)
 		)
 	`)
@@ -79,7 +82,90 @@ func (s *Store) createSQLiteSchema() error {
 			UNIQUE(endpoint_id, configuration_checksum)
 		)
 	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_alerting_states (
+			endpoint_id                   INTEGER   PRIMARY KEY REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			number_of_failures_in_a_row   INTEGER   NOT NULL,
+			number_of_successes_in_a_row  INTEGER   NOT NULL,
+			last_success_timestamp        TIMESTAMP NOT NULL,
+			down_since                    TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alert_deliveries (
+			alert_delivery_id  INTEGER PRIMARY KEY,
+			endpoint_key       TEXT      NOT NULL,
+			alert_type         TEXT      NOT NULL,
+			alert_checksum     TEXT      NOT NULL,
+			resolved           INTEGER   NOT NULL,
+			result             TEXT      NOT NULL,
+			state              TEXT      NOT NULL,
+			attempts           INTEGER   NOT NULL,
+			last_error         TEXT      NOT NULL,
+			dead_lettered      INTEGER   NOT NULL,
+			created_at         TIMESTAMP NOT NULL,
+			next_attempt_at    TIMESTAMP NOT NULL,
+			history_entry_id   INTEGER   NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alert_history (
+			alert_history_entry_id  INTEGER PRIMARY KEY,
+			endpoint_key            TEXT      NOT NULL,
+			endpoint_group          TEXT      NOT NULL,
+			endpoint_name           TEXT      NOT NULL,
+			alert_type              TEXT      NOT NULL,
+			alert_checksum          TEXT      NOT NULL,
+			description             TEXT      NOT NULL,
+			triggered_at            TIMESTAMP NOT NULL,
+			resolved_at             TIMESTAMP,
+			triggered_delivery      TEXT      NOT NULL,
+			resolved_delivery       TEXT
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS external_endpoint_tokens (
+			external_endpoint_token_id  INTEGER PRIMARY KEY,
+			endpoint_key                TEXT      NOT NULL,
+			name                        TEXT      NOT NULL,
+			token_hash                  TEXT      NOT NULL UNIQUE,
+			created_at                  TIMESTAMP NOT NULL,
+			expires_at                  TIMESTAMP,
+			revoked_at                  TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS configuration_snapshots (
+			configuration_snapshot_id  INTEGER PRIMARY KEY,
+			checksum                   TEXT      NOT NULL,
+			source                     TEXT      NOT NULL,
+			rollback_of                INTEGER   NOT NULL DEFAULT 0,
+			applied_at                 TIMESTAMP NOT NULL,
+			data                       TEXT      NOT NULL
+		)
+	`)
 	// Silent table modifications TODO: Remove this in v6.0.0
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD domain_expiration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_result_conditions ADD resolved_left TEXT`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_result_conditions ADD resolved_right TEXT`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_uptimes ADD incidents INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE alert_deliveries ADD history_entry_id INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD failure_reason TEXT NOT NULL DEFAULT ''`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_uptimes ADD slow_executions INTEGER NOT NULL DEFAULT 0`)
 	return err
 }
@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -13,6 +15,7 @@ import (
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/storage/store/sql/mysql"
 	"github.com/TwiN/gocache/v2"
 	_ "github.com/lib/pq"
 	_ "modernc.org/sqlite"
@@ -31,11 +34,13 @@ const (
 	eventsCleanUpThreshold  = common.MaximumNumberOfEvents + 10  // Maximum number of events before triggering a cleanup
 	resultsCleanUpThreshold = common.MaximumNumberOfResults + 10 // Maximum number of results before triggering a cleanup
 
-	uptimeTotalEntriesMergeThreshold = 100                 // Maximum number of uptime entries before triggering a merge
-	uptimeAgeCleanUpThreshold        = 32 * 24 * time.Hour // Maximum uptime age before triggering a cleanup
-	uptimeRetention                  = 30 * 24 * time.Hour // Minimum duration that must be kept to operate as intended
+	uptimeTotalEntriesMergeThreshold = 200                 // Maximum number of uptime entries before triggering a merge
+	uptimeAgeCleanUpThreshold        = 92 * 24 * time.Hour // Maximum uptime age before triggering a cleanup
+	uptimeRetention                  = 90 * 24 * time.Hour // Minimum duration that must be kept to operate as intended
 	uptimeHourlyBuffer               = 48 * time.Hour      // Number of hours to buffer from now when determining which hourly uptime entries can be merged into daily uptime entries
 
+	resultsAgeCleanUpBuffer = time.Hour // Duration by which the oldest result may exceed the retention of results before triggering a cleanup
+
 	cacheTTL = 10 * time.Minute
 )
 
@@ -58,6 +63,31 @@ type Store struct {
 	// writeThroughCache is a cache used to drastically decrease read latency by pre-emptively
 	// caching writes as they happen. If nil, writes are not cached.
 	writeThroughCache *gocache.Cache
+
+	// compression is whether large text columns (i.e. errors and the values of conditions, which may contain the
+	// body of responses) are compressed before being persisted.
+	// Note that compressed values are always decompressed on read, regardless of this value.
+	compression bool
+
+	// encryption is the cipher used to encrypt sensitive columns (i.e. errors, conditions and resolve keys) before
+	// they are persisted. If nil, values are persisted in plain text.
+	// Note that encrypted values can only be read if encryption is enabled with the same key.
+	encryption cipher.AEAD
+
+	// batch is the batcher used to group multiple inserts into a single transaction. If nil, every insert is
+	// committed in its own transaction.
+	batch *insertBatcher
+
+	// resultRetention is the duration for which results are kept. If zero, the last MaximumNumberOfResults results of
+	// each endpoint are kept instead, regardless of their age.
+	resultRetention time.Duration
+
+	// hourlyUptimeRetention is the duration for which hourly uptime entries are kept before being merged into daily
+	// uptime entries
+	hourlyUptimeRetention time.Duration
+
+	// dailyUptimeRetention is the duration for which uptime entries are kept
+	dailyUptimeRetention time.Duration
 }
 
 // NewStore initializes the database and creates the schema if it doesn't already exist in the path specified
@@ -68,7 +98,7 @@ func NewStore(driver, path string, caching bool) (*Store, error) {
 	if len(path) == 0 {
 		return nil, ErrPathNotSpecified
 	}
-	store := &Store{driver: driver, path: path}
+	store := &Store{driver: driver, path: path, hourlyUptimeRetention: uptimeHourlyBuffer, dailyUptimeRetention: uptimeRetention}
 	var err error
 	if store.db, err = sql.Open(driver, path); err != nil {
 		return nil, err
@@ -94,10 +124,50 @@ func NewStore(driver, path string, caching bool) (*Store, error) {
 	return store, nil
 }
 
+// EnableCompression makes the store compress large text columns (i.e. errors and the values of conditions) using zstd
+// before persisting them
+func (s *Store) EnableCompression() {
+	s.compression = true
+}
+
+// EnableEncryption makes the store encrypt sensitive columns (i.e. errors, conditions and resolve keys) using
+// AES-256-GCM with the key passed before persisting them
+func (s *Store) EnableEncryption(key []byte) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	s.encryption, err = cipher.NewGCM(block)
+	return err
+}
+
+// EnableBatchedWrites makes the store group inserts happening in quick succession into a single transaction, up to
+// batchSize inserts per transaction. Insert still blocks until the transaction containing the result is committed.
+func (s *Store) EnableBatchedWrites(batchSize int) {
+	if batchSize <= 1 || s.batch != nil {
+		return
+	}
+	s.batch = newInsertBatcher(s, batchSize)
+}
+
+// SetRetention overrides how long results, hourly uptime entries and daily uptime entries are kept.
+// If resultRetention is 0, the last MaximumNumberOfResults results of each endpoint are kept instead.
+// The retention of hourly uptime entries can't be shorter than 48 hours, as the hourly entries of the last 24 hours are
+// required to compute the uptime of the last 24 hours, and that of daily entries can't be shorter than that of hourly
+// entries.
+func (s *Store) SetRetention(resultRetention, hourlyUptimeRetention, dailyUptimeRetention time.Duration) {
+	s.resultRetention = resultRetention
+	s.hourlyUptimeRetention = max(hourlyUptimeRetention, uptimeHourlyBuffer)
+	s.dailyUptimeRetention = max(dailyUptimeRetention, s.hourlyUptimeRetention)
+}
+
 // createSchema creates the schema required to perform all database operations.
 func (s *Store) createSchema() error {
-	if s.driver == "sqlite" {
+	switch s.driver {
+	case "sqlite":
 		return s.createSQLiteSchema()
+	case mysql.DriverName:
+		return s.createMySQLSchema()
 	}
 	return s.createPostgresSchema()
 }
@@ -105,6 +175,9 @@ func (s *Store) createSchema() error {
 // GetAllEndpointStatuses returns all monitored endpoint.Status
 // with a subset of endpoint.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*endpoint.Status, error) {
+	if !params.IsResultsTimeRangeValid() {
+		return nil, common.ErrInvalidTimeRange
+	}
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
@@ -135,6 +208,9 @@ func (s *Store) GetEndpointStatus(groupName, endpointName string, params *paging
 
 // GetEndpointStatusByKey returns the endpoint status for a given key
 func (s *Store) GetEndpointStatusByKey(key string, params *paging.EndpointStatusParams) (*endpoint.Status, error) {
+	if !params.IsResultsTimeRangeValid() {
+		return nil, common.ErrInvalidTimeRange
+	}
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
@@ -225,23 +301,210 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// GetHourlyUptimeByKey returns a map of hourly (key) uptime (value), as a value between 0 and 1, during a time range
+func (s *Store) GetHourlyUptimeByKey(key string, from, to time.Time) (map[int64]float64, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	hourlyUptimes, err := s.getEndpointHourlyUptimes(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return hourlyUptimes, nil
+}
+
+// GetDailyUptimeStatisticsByKey returns a map of daily (key) uptime statistics (value) during a time range
+func (s *Store) GetDailyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.DailyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	dailyUptimeStatistics, err := s.getEndpointDailyUptimeStatistics(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return dailyUptimeStatistics, nil
+}
+
+// GetServiceLevelStatisticsByKey returns the number of events relevant to the service level objective of an endpoint during a time range
+func (s *Store) GetServiceLevelStatisticsByKey(key string, from, to time.Time) (*endpoint.ServiceLevelStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	statistics, err := s.getEndpointServiceLevelStatistics(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return statistics, nil
+}
+
+// GetFailureBreakdownByKey returns the number of unsuccessful results (value) by failure reason (key) during a time range
+func (s *Store) GetFailureBreakdownByKey(key string, from, to time.Time) (map[endpoint.FailureReason]int, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	failureBreakdown, err := s.getEndpointFailureBreakdown(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return failureBreakdown, nil
+}
+
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	if s.batch != nil {
+		if queued, err := s.batch.insert(ep, result); queued {
+			return err
+		}
+	}
+	return s.insertInNewTransaction(ep, result)
+}
+
+// InsertUptime adds the observed result for the specified endpoint into the uptime data without storing the result
+// itself. If the endpoint isn't in the store yet, the result is inserted as it would be by Insert.
+func (s *Store) InsertUptime(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
+	endpointID, err := s.getEndpointID(tx, ep)
+	if err != nil {
+		_ = tx.Rollback()
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			return s.Insert(ep, result)
+		}
+		return err
+	}
+	// Results discarded by sampling are never a change in health, so they can't be an incident
+	if err = s.updateEndpointUptime(tx, endpointID, result, false); err != nil {
+		log.Printf("[sql.InsertUptime] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return err
+}
+
+// insertInNewTransaction inserts the result for the specified endpoint in its own transaction, and then refreshes the
+// cache of the endpoint if the transaction was committed
+func (s *Store) insertInNewTransaction(ep *endpoint.Endpoint, result *endpoint.Result) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = s.insert(tx, ep, result); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	s.refreshCache(ep.Key())
+	return nil
+}
+
+// refreshCache refreshes the cached statuses of the endpoint with the key passed.
+//
+// This must only be called once the transaction in which the endpoint was modified has been committed, since the
+// cache would otherwise contain data that may still be rolled back.
+func (s *Store) refreshCache(key string) {
+	if s.writeThroughCache == nil {
+		return
+	}
+	cacheKeysToRefresh := s.writeThroughCache.GetKeysByPattern(key+"*", 0)
+	if len(cacheKeysToRefresh) == 0 {
+		return
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		log.Printf("[sql.refreshCache] Silently deleting cache keys of endpoint with key=%s instead of refreshing due to error: %s", key, err.Error())
+		for _, cacheKey := range cacheKeysToRefresh {
+			s.writeThroughCache.Delete(cacheKey)
+		}
+		return
+	}
+	for _, cacheKey := range cacheKeysToRefresh {
+		s.writeThroughCache.Delete(cacheKey)
+		endpointKey, params, err := extractKeyAndParamsFromCacheKey(cacheKey)
+		if err != nil {
+			log.Printf("[sql.refreshCache] Silently deleting cache key %s instead of refreshing due to error: %s", cacheKey, err.Error())
+			continue
+		}
+		// Retrieve the endpoint status by key, which will in turn refresh the cache
+		_, _ = s.getEndpointStatusByKey(tx, endpointKey, params)
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+}
+
+// insert adds the observed result for the specified endpoint into the store using the transaction passed.
+// If an error is returned, the transaction should be rolled back by the caller. Otherwise, the caller must refresh the
+// cache of the endpoint with refreshCache once the transaction has been committed.
+func (s *Store) insert(tx *sql.Tx, ep *endpoint.Endpoint, result *endpoint.Result) error {
 	endpointID, err := s.getEndpointID(tx, ep)
 	if err != nil {
 		if errors.Is(err, common.ErrEndpointNotFound) {
 			// Endpoint doesn't exist in the database, insert it
 			if endpointID, err = s.insertEndpoint(tx, ep); err != nil {
-				_ = tx.Rollback()
 				log.Printf("[sql.Insert] Failed to create endpoint with key=%s: %s", ep.Key(), err.Error())
 				return err
 			}
 		} else {
-			_ = tx.Rollback()
 			log.Printf("[sql.Insert] Failed to retrieve id of endpoint with key=%s: %s", ep.Key(), err.Error())
 			return err
 		}
@@ -260,7 +523,10 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 		// Silently fail
 		log.Printf("[sql.Insert] Failed to retrieve total number of events for endpoint with key=%s: %s", ep.Key(), err.Error())
 	}
+	// Whether the endpoint went from healthy to unhealthy, which is tracked in the uptime data as an incident
+	var isIncident bool
 	if numberOfEvents == 0 {
+		isIncident = !result.Success
 		// There's no events yet, which means we need to add the EventStart and the first healthy/unhealthy event
 		err = s.insertEndpointEvent(tx, endpointID, &endpoint.Event{
 			Type:      endpoint.EventStart,
@@ -286,6 +552,7 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 			// that the endpoint either went from Healthy to Unhealthy or Unhealthy -> Healthy, therefore, we'll add
 			// an event to mark the change in state
 			if lastResultSuccess != result.Success {
+				isIncident = !result.Success
 				event := endpoint.NewEventFromResult(result)
 				if err = s.insertEndpointEvent(tx, endpointID, event); err != nil {
 					// Silently fail
@@ -305,23 +572,33 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	// Second, we need to insert the result.
 	if err = s.insertEndpointResult(tx, endpointID, result); err != nil {
 		log.Printf("[sql.Insert] Failed to insert result for endpoint with key=%s: %s", ep.Key(), err.Error())
-		_ = tx.Rollback() // If we can't insert the result, we'll rollback now since there's no point continuing
-		return err
+		return err // If we can't insert the result, the caller will rollback since there's no point continuing
 	}
-	// Clean up old results
-	numberOfResults, err := s.getNumberOfResultsByEndpointID(tx, endpointID)
-	if err != nil {
-		log.Printf("[sql.Insert] Failed to retrieve total number of results for endpoint with key=%s: %s", ep.Key(), err.Error())
-	} else {
-		if numberOfResults > resultsCleanUpThreshold {
-			if err = s.deleteOldEndpointResults(tx, endpointID); err != nil {
+	// Clean up old results, based on either their age or their number depending on the retention of results
+	if s.resultRetention > 0 {
+		ageOfOldestResult, err := s.getAgeOfOldestEndpointResult(tx, endpointID)
+		if err != nil {
+			log.Printf("[sql.Insert] Failed to retrieve oldest result for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else if ageOfOldestResult > s.resultRetention+resultsAgeCleanUpBuffer {
+			if err = s.deleteEndpointResultsOlderThan(tx, endpointID, time.Now().Add(-s.resultRetention)); err != nil {
 				log.Printf("[sql.Insert] Failed to delete old results for endpoint with key=%s: %s", ep.Key(), err.Error())
 			}
 		}
+	} else {
+		numberOfResults, err := s.getNumberOfResultsByEndpointID(tx, endpointID)
+		if err != nil {
+			log.Printf("[sql.Insert] Failed to retrieve total number of results for endpoint with key=%s: %s", ep.Key(), err.Error())
+		} else {
+			if numberOfResults > resultsCleanUpThreshold {
+				if err = s.deleteOldEndpointResults(tx, endpointID); err != nil {
+					log.Printf("[sql.Insert] Failed to delete old results for endpoint with key=%s: %s", ep.Key(), err.Error())
+				}
+			}
+		}
 	}
 	// Finally, we need to insert the uptime data.
 	// Because the uptime data significantly outlives the results, we can't rely on the results for determining the uptime
-	if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
+	if err = s.updateEndpointUptime(tx, endpointID, result, isIncident); err != nil {
 		log.Printf("[sql.Insert] Failed to update uptime for endpoint with key=%s: %s", ep.Key(), err.Error())
 	}
 	// Merge hourly uptime entries that can be merged into daily entries and clean up old uptime entries
@@ -329,8 +606,8 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	if err != nil {
 		log.Printf("[sql.Insert] Failed to retrieve total number of uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
 	} else {
-		// Merge older hourly uptime entries into daily uptime entries if we have more than uptimeTotalEntriesMergeThreshold
-		if numberOfUptimeEntries >= uptimeTotalEntriesMergeThreshold {
+		// Merge older hourly uptime entries into daily uptime entries if we have more than the merge threshold
+		if numberOfUptimeEntries >= s.uptimeEntriesMergeThreshold() {
 			log.Printf("[sql.Insert] Merging hourly uptime entries for endpoint with key=%s; This is a lot of work, it shouldn't happen too often", ep.Key())
 			if err = s.mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries(tx, endpointID); err != nil {
 				log.Printf("[sql.Insert] Failed to merge hourly uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
@@ -344,29 +621,13 @@ func (s *Store) Insert(ep *endpoint.Endpoint, result *endpoint.Result) error {
 	if err != nil {
 		log.Printf("[sql.Insert] Failed to retrieve oldest endpoint uptime entry for endpoint with key=%s: %s", ep.Key(), err.Error())
 	} else {
-		if ageOfOldestUptimeEntry > uptimeAgeCleanUpThreshold {
-			if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(uptimeRetention + time.Hour))); err != nil {
+		if ageOfOldestUptimeEntry > s.dailyUptimeRetention+(uptimeAgeCleanUpThreshold-uptimeRetention) {
+			if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(s.dailyUptimeRetention + time.Hour))); err != nil {
 				log.Printf("[sql.Insert] Failed to delete old uptime entries for endpoint with key=%s: %s", ep.Key(), err.Error())
 			}
 		}
 	}
-	if s.writeThroughCache != nil {
-		cacheKeysToRefresh := s.writeThroughCache.GetKeysByPattern(ep.Key()+"*", 0)
-		for _, cacheKey := range cacheKeysToRefresh {
-			s.writeThroughCache.Delete(cacheKey)
-			endpointKey, params, err := extractKeyAndParamsFromCacheKey(cacheKey)
-			if err != nil {
-				log.Printf("[sql.Insert] Silently deleting cache key %s instead of refreshing due to error: %s", cacheKey, err.Error())
-				continue
-			}
-			// Retrieve the endpoint status by key, which will in turn refresh the cache
-			_, _ = s.getEndpointStatusByKey(tx, endpointKey, params)
-		}
-	}
-	if err = tx.Commit(); err != nil {
-		_ = tx.Rollback()
-	}
-	return err
+	return nil
 }
 
 // DeleteAllEndpointStatusesNotInKeys removes all rows owned by an endpoint whose key is not within the keys provided
@@ -415,7 +676,7 @@ func (s *Store) GetTriggeredEndpointAlert(ep *endpoint.Endpoint, alert *alert.Al
 		}
 		return false, "", 0, err
 	}
-	return true, resolveKey, numberOfSuccessesInARow, nil
+	return true, s.decryptValue(resolveKey), numberOfSuccessesInARow, nil
 }
 
 // UpsertTriggeredEndpointAlert inserts/updates a triggered alert for an endpoint
@@ -443,16 +704,16 @@ func (s *Store) UpsertTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAle
 		}
 	}
 	_, err = tx.Exec(
-		`
+		s.upsert(`
 			INSERT INTO endpoint_alerts_triggered (endpoint_id, configuration_checksum, resolve_key, number_of_successes_in_a_row) 
 			VALUES ($1, $2, $3, $4)
 			ON CONFLICT(endpoint_id, configuration_checksum) DO UPDATE SET
 				resolve_key = $3,
 				number_of_successes_in_a_row = $4
-		`,
+		`),
 		endpointID,
 		triggeredAlert.Checksum(),
-		triggeredAlert.ResolveKey,
+		s.encryptValue(triggeredAlert.ResolveKey),
 		ep.NumberOfSuccessesInARow, // We only persist NumberOfSuccessesInARow, because all alerts in this table are already triggered
 	)
 	if err != nil {
@@ -473,6 +734,75 @@ func (s *Store) DeleteTriggeredEndpointAlert(ep *endpoint.Endpoint, triggeredAle
 	return err
 }
 
+// GetEndpointAlertingState returns the alerting state persisted for the specified endpoint, or nil if there is none
+func (s *Store) GetEndpointAlertingState(ep *endpoint.Endpoint) (*endpoint.AlertingState, error) {
+	state := &endpoint.AlertingState{}
+	err := s.db.QueryRow(
+		"SELECT number_of_failures_in_a_row, number_of_successes_in_a_row, last_success_timestamp, down_since FROM endpoint_alerting_states WHERE endpoint_id = (SELECT endpoint_id FROM endpoints WHERE endpoint_key = $1 LIMIT 1)",
+		ep.Key(),
+	).Scan(&state.NumberOfFailuresInARow, &state.NumberOfSuccessesInARow, &state.LastSuccessTimestamp, &state.DownSince)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return state, nil
+}
+
+// UpsertEndpointAlertingState inserts/updates the alerting state of an endpoint
+// Used for persistence of the number of failures and successes in a row across application restarts
+func (s *Store) UpsertEndpointAlertingState(ep *endpoint.Endpoint) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	endpointID, err := s.getEndpointID(tx, ep)
+	if err != nil {
+		if errors.Is(err, common.ErrEndpointNotFound) {
+			// Endpoint doesn't exist in the database, insert it
+			if endpointID, err = s.insertEndpoint(tx, ep); err != nil {
+				_ = tx.Rollback()
+				log.Printf("[sql.UpsertEndpointAlertingState] Failed to create endpoint with key=%s: %s", ep.Key(), err.Error())
+				return err
+			}
+		} else {
+			_ = tx.Rollback()
+			log.Printf("[sql.UpsertEndpointAlertingState] Failed to retrieve id of endpoint with key=%s: %s", ep.Key(), err.Error())
+			return err
+		}
+	}
+	_, err = tx.Exec(
+		s.upsert(`
+			INSERT INTO endpoint_alerting_states (endpoint_id, number_of_failures_in_a_row, number_of_successes_in_a_row, last_success_timestamp, down_since) 
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT(endpoint_id) DO UPDATE SET
+				number_of_failures_in_a_row = $2,
+				number_of_successes_in_a_row = $3,
+				last_success_timestamp = $4,
+				down_since = $5
+		`),
+		endpointID,
+		ep.NumberOfFailuresInARow,
+		ep.NumberOfSuccessesInARow,
+		ep.LastSuccessTimestamp.UTC(),
+		ep.DownSince.UTC(),
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		log.Printf("[sql.UpsertEndpointAlertingState] Failed to persist alerting state for endpoint with key=%s: %s", ep.Key(), err.Error())
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	if s.writeThroughCache != nil {
+		// The downtime of the endpoint status is based on the alerting state
+		_ = s.writeThroughCache.DeleteKeysByPattern(ep.Key() + "*")
+	}
+	return nil
+}
+
 // DeleteAllTriggeredAlertsNotInChecksumsByEndpoint removes all triggered alerts owned by an endpoint whose alert
 // configurations are not provided in the checksums list.
 // This prevents triggered alerts that have been removed or modified from lingering in the database.
@@ -509,6 +839,10 @@ func (s *Store) DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.En
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	_, _ = s.db.Exec("DELETE FROM endpoints")
+	_, _ = s.db.Exec("DELETE FROM alert_deliveries")
+	_, _ = s.db.Exec("DELETE FROM alert_history")
+	_, _ = s.db.Exec("DELETE FROM external_endpoint_tokens")
+	_, _ = s.db.Exec("DELETE FROM configuration_snapshots")
 	if s.writeThroughCache != nil {
 		_ = s.writeThroughCache.DeleteKeysByPattern("*")
 	}
@@ -521,6 +855,10 @@ func (s *Store) Save() error {
 
 // Close the database handle
 func (s *Store) Close() {
+	if s.batch != nil {
+		// Flush pending inserts before closing the database
+		s.batch.close()
+	}
 	_ = s.db.Close()
 	if s.writeThroughCache != nil {
 		// Clear the cache too. If the store's been closed, we don't want to keep the cache around.
@@ -531,17 +869,13 @@ func (s *Store) Close() {
 // insertEndpoint inserts an endpoint in the store and returns the generated id of said endpoint
 func (s *Store) insertEndpoint(tx *sql.Tx, ep *endpoint.Endpoint) (int64, error) {
 	//log.Printf("[sql.insertEndpoint] Inserting endpoint with group=%s and name=%s", ep.Group, ep.Name)
-	var id int64
-	err := tx.QueryRow(
+	return s.insertAndReturnID(
+		tx,
 		"INSERT INTO endpoints (endpoint_key, endpoint_name, endpoint_group) VALUES ($1, $2, $3) RETURNING endpoint_id",
 		ep.Key(),
 		ep.Name,
 		ep.Group,
-	).Scan(&id)
-	if err != nil {
-		return 0, err
-	}
-	return id, nil
+	)
 }
 
 // insertEndpointEvent inserts en event in the store
@@ -560,16 +894,16 @@ func (s *Store) insertEndpointEvent(tx *sql.Tx, endpointID int64, event *endpoin
 
 // insertEndpointResult inserts a result in the store
 func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpoint.Result) error {
-	var endpointResultID int64
-	err := tx.QueryRow(
+	endpointResultID, err := s.insertAndReturnID(
+		tx,
 		`
-			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, failure_reason)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 			RETURNING endpoint_result_id
 		`,
 		endpointID,
 		result.Success,
-		strings.Join(result.Errors, arraySeparator),
+		s.encodeErrors(result.Errors),
 		result.Connected,
 		result.HTTPStatus,
 		result.DNSRCode,
@@ -579,20 +913,55 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *endpo
 		result.IP,
 		result.Duration,
 		result.Timestamp.UTC(),
-	).Scan(&endpointResultID)
+		result.FailureReason,
+	)
 	if err != nil {
 		return err
 	}
 	return s.insertConditionResults(tx, endpointResultID, result.ConditionResults)
 }
 
+// encodeValue compresses and/or encrypts a value if compression and/or encryption are enabled
+func (s *Store) encodeValue(value string) string {
+	if s.compression {
+		value = compressValue(value)
+	}
+	return s.encryptValue(value)
+}
+
+// decodeValue reverses encodeValue
+func (s *Store) decodeValue(encodedValue string) string {
+	return decompressValue(s.decryptValue(encodedValue))
+}
+
+// encodeErrors joins the errors into a single string and encodes it with encodeValue
+func (s *Store) encodeErrors(errors []string) string {
+	return s.encodeValue(strings.Join(errors, arraySeparator))
+}
+
+// decodeErrors reverses encodeErrors
+func (s *Store) decodeErrors(encodedErrors string) []string {
+	joinedErrors := s.decodeValue(encodedErrors)
+	if len(joinedErrors) == 0 {
+		return nil
+	}
+	return strings.Split(joinedErrors, arraySeparator)
+}
+
 func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, conditionResults []*endpoint.ConditionResult) error {
 	var err error
 	for _, cr := range conditionResults {
-		_, err = tx.Exec("INSERT INTO endpoint_result_conditions (endpoint_result_id, condition, success) VALUES ($1, $2, $3)",
+		var resolvedLeft, resolvedRight sql.NullString
+		if cr.ResolvedValues != nil {
+			resolvedLeft = sql.NullString{String: s.encodeValue(cr.ResolvedValues.Left), Valid: true}
+			resolvedRight = sql.NullString{String: s.encodeValue(cr.ResolvedValues.Right), Valid: true}
+		}
+		_, err = tx.Exec(`INSERT INTO endpoint_result_conditions (endpoint_result_id, "condition", success, resolved_left, resolved_right) VALUES ($1, $2, $3, $4, $5)`,
 			endpointResultID,
-			cr.Condition,
+			s.encodeValue(cr.Condition),
 			cr.Success,
+			resolvedLeft,
+			resolvedRight,
 		)
 		if err != nil {
 			return err
@@ -601,26 +970,36 @@ func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, condi
 	return nil
 }
 
-func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *endpoint.Result) error {
+func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *endpoint.Result, isIncident bool) error {
 	unixTimestampFlooredAtHour := result.Timestamp.Truncate(time.Hour).Unix()
-	var successfulExecutions int
+	var successfulExecutions, incidents, slowExecutions int
 	if result.Success {
 		successfulExecutions = 1
 	}
+	if isIncident {
+		incidents = 1
+	}
+	if result.ExceededResponseTimeObjective {
+		slowExecutions = 1
+	}
 	_, err := tx.Exec(
-		`
-			INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time) 
-			VALUES ($1, $2, $3, $4, $5)
+		s.upsert(`
+			INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time, incidents, slow_executions) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 			ON CONFLICT(endpoint_id, hour_unix_timestamp) DO UPDATE SET
 				total_executions = excluded.total_executions + endpoint_uptimes.total_executions,
 				successful_executions = excluded.successful_executions + endpoint_uptimes.successful_executions,
-				total_response_time = excluded.total_response_time + endpoint_uptimes.total_response_time
-		`,
+				total_response_time = excluded.total_response_time + endpoint_uptimes.total_response_time,
+				incidents = excluded.incidents + endpoint_uptimes.incidents,
+				slow_executions = excluded.slow_executions + endpoint_uptimes.slow_executions
+		`),
 		endpointID,
 		unixTimestampFlooredAtHour,
 		1,
 		successfulExecutions,
 		result.Duration.Milliseconds(),
+		incidents,
+		slowExecutions,
 	)
 	return err
 }
@@ -640,7 +1019,9 @@ func (s *Store) getAllEndpointKeys(tx *sql.Tx) (keys []string, err error) {
 
 func (s *Store) getEndpointStatusByKey(tx *sql.Tx, key string, parameters *paging.EndpointStatusParams) (*endpoint.Status, error) {
 	var cacheKey string
-	if s.writeThroughCache != nil {
+	// Results filtered by time range or paged from the oldest are rarely requested twice, so they're not cached
+	useCache := s.writeThroughCache != nil && !parameters.HasResultsTimeRange() && parameters.ResultsOrder != paging.OrderAscending
+	if useCache {
 		cacheKey = generateCacheKey(key, parameters)
 		if cachedEndpointStatus, exists := s.writeThroughCache.Get(cacheKey); exists {
 			if castedCachedEndpointStatus, ok := cachedEndpointStatus.(*endpoint.Status); ok {
@@ -653,17 +1034,27 @@ func (s *Store) getEndpointStatusByKey(tx *sql.Tx, key string, parameters *pagin
 		return nil, err
 	}
 	endpointStatus := endpoint.NewStatus(group, endpointName)
+	endpointStatus.Key = key
 	if parameters.EventsPageSize > 0 {
 		if endpointStatus.Events, err = s.getEndpointEventsByEndpointID(tx, endpointID, parameters.EventsPage, parameters.EventsPageSize); err != nil {
 			log.Printf("[sql.getEndpointStatusByKey] Failed to retrieve events for key=%s: %s", key, err.Error())
 		}
 	}
 	if parameters.ResultsPageSize > 0 {
-		if endpointStatus.Results, err = s.getEndpointResultsByEndpointID(tx, endpointID, parameters.ResultsPage, parameters.ResultsPageSize); err != nil {
+		if endpointStatus.Results, err = s.getEndpointResultsByEndpointID(tx, endpointID, parameters); err != nil {
 			log.Printf("[sql.getEndpointStatusByKey] Failed to retrieve results for key=%s: %s", key, err.Error())
 		}
 	}
-	if s.writeThroughCache != nil {
+	if downSince, exists, err := s.getEndpointDownSinceByEndpointID(tx, endpointID); err != nil {
+		log.Printf("[sql.getEndpointStatusByKey] Failed to retrieve alerting state for key=%s: %s", key, err.Error())
+	} else if exists {
+		endpointStatus.SetDowntime(downSince, time.Now())
+	} else if lastEvent, err := s.getLastEndpointEventByEndpointID(tx, endpointID); err != nil {
+		log.Printf("[sql.getEndpointStatusByKey] Failed to retrieve last event for key=%s: %s", key, err.Error())
+	} else {
+		endpointStatus.SetDowntimeFromLastEvent(lastEvent, time.Now())
+	}
+	if useCache {
 		s.writeThroughCache.SetWithTTL(cacheKey, endpointStatus, cacheTTL)
 	}
 	return endpointStatus, nil
@@ -712,19 +1103,64 @@ func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page
 	return
 }
 
-func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, page, pageSize int) (results []*endpoint.Result, err error) {
-	rows, err := tx.Query(
+// getLastEndpointEventByEndpointID returns the most recent event of an endpoint, or nil if it has none
+func (s *Store) getLastEndpointEventByEndpointID(tx *sql.Tx, endpointID int64) (*endpoint.Event, error) {
+	event := &endpoint.Event{}
+	err := tx.QueryRow(
 		`
-			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp
-			FROM endpoint_results
+			SELECT event_type, event_timestamp
+			FROM endpoint_events
 			WHERE endpoint_id = $1
-			ORDER BY endpoint_result_id DESC -- Normally, we'd sort by timestamp, but sorting by endpoint_result_id is faster
-			LIMIT $2 OFFSET $3
+			ORDER BY endpoint_event_id DESC
+			LIMIT 1
 		`,
 		endpointID,
-		pageSize,
-		(page-1)*pageSize,
-	)
+	).Scan(&event.Type, &event.Timestamp)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+// getEndpointDownSinceByEndpointID returns the moment at which the endpoint became unhealthy according to its alerting
+// state, as well as whether the endpoint has an alerting state at all
+func (s *Store) getEndpointDownSinceByEndpointID(tx *sql.Tx, endpointID int64) (downSince time.Time, exists bool, err error) {
+	err = tx.QueryRow("SELECT down_since FROM endpoint_alerting_states WHERE endpoint_id = $1", endpointID).Scan(&downSince)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+	return downSince, true, nil
+}
+
+func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, params *paging.EndpointStatusParams) (results []*endpoint.Result, err error) {
+	args := []interface{}{endpointID}
+	query := `SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, failure_reason
+				FROM endpoint_results
+				WHERE endpoint_id = $1`
+	if !params.ResultsFrom.IsZero() {
+		args = append(args, params.ResultsFrom)
+		query += ` AND timestamp >= $` + strconv.Itoa(len(args))
+	}
+	if !params.ResultsTo.IsZero() {
+		args = append(args, params.ResultsTo)
+		query += ` AND timestamp <= $` + strconv.Itoa(len(args))
+	}
+	ascending := params.ResultsOrder == paging.OrderAscending
+	// Normally, we'd sort by timestamp, but sorting by endpoint_result_id is faster
+	if ascending {
+		query += ` ORDER BY endpoint_result_id ASC`
+	} else {
+		query += ` ORDER BY endpoint_result_id DESC`
+	}
+	args = append(args, params.ResultsPageSize, (params.ResultsPage-1)*params.ResultsPageSize)
+	query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	rows, err := tx.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -733,16 +1169,20 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		result := &endpoint.Result{}
 		var id int64
 		var joinedErrors string
-		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp)
+		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp, &result.FailureReason)
 		if err != nil {
 			log.Printf("[sql.getEndpointResultsByEndpointID] Silently failed to retrieve endpoint result for endpointID=%d: %s", endpointID, err.Error())
 			err = nil
 		}
 		if len(joinedErrors) != 0 {
-			result.Errors = strings.Split(joinedErrors, arraySeparator)
+			result.Errors = s.decodeErrors(joinedErrors)
+		}
+		if ascending {
+			results = append(results, result)
+		} else {
+			// This is faster than using a subselect
+			results = append([]*endpoint.Result{result}, results...)
 		}
-		// This is faster than using a subselect
-		results = append([]*endpoint.Result{result}, results...)
 		idResultMap[id] = result
 	}
 	if len(idResultMap) == 0 {
@@ -750,8 +1190,8 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		return
 	}
 	// Get condition results
-	args := make([]interface{}, 0, len(idResultMap))
-	query := `SELECT endpoint_result_id, condition, success
+	args = make([]interface{}, 0, len(idResultMap))
+	query = `SELECT endpoint_result_id, "condition", success, resolved_left, resolved_right
 				FROM endpoint_result_conditions
 				WHERE endpoint_result_id IN (`
 	index := 1
@@ -769,9 +1209,14 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	for rows.Next() {
 		conditionResult := &endpoint.ConditionResult{}
 		var endpointResultID int64
-		if err = rows.Scan(&endpointResultID, &conditionResult.Condition, &conditionResult.Success); err != nil {
+		var resolvedLeft, resolvedRight sql.NullString
+		if err = rows.Scan(&endpointResultID, &conditionResult.Condition, &conditionResult.Success, &resolvedLeft, &resolvedRight); err != nil {
 			return
 		}
+		conditionResult.Condition = s.decodeValue(conditionResult.Condition)
+		if resolvedLeft.Valid && resolvedRight.Valid {
+			conditionResult.ResolvedValues = &endpoint.ResolvedConditionValues{Left: s.decodeValue(resolvedLeft.String), Right: s.decodeValue(resolvedRight.String)}
+		}
 		idResultMap[endpointResultID].ConditionResults = append(idResultMap[endpointResultID].ConditionResults, conditionResult)
 	}
 	return
@@ -858,6 +1303,129 @@ func (s *Store) getEndpointHourlyAverageResponseTimes(tx *sql.Tx, endpointID int
 	return hourlyAverageResponseTimes, nil
 }
 
+func (s *Store) getEndpointHourlyUptimes(tx *sql.Tx, endpointID int64, from, to time.Time) (map[int64]float64, error) {
+	rows, err := tx.Query(
+		`
+			SELECT hour_unix_timestamp, total_executions, successful_executions
+			FROM endpoint_uptimes
+			WHERE endpoint_id = $1
+				AND total_executions > 0
+				AND hour_unix_timestamp >= $2
+				AND hour_unix_timestamp <= $3
+		`,
+		endpointID,
+		from.Unix(),
+		to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var totalExecutions, successfulExecutions int
+	var unixTimestampFlooredAtHour int64
+	hourlyUptimes := make(map[int64]float64)
+	for rows.Next() {
+		_ = rows.Scan(&unixTimestampFlooredAtHour, &totalExecutions, &successfulExecutions)
+		hourlyUptimes[unixTimestampFlooredAtHour] = float64(successfulExecutions) / float64(totalExecutions)
+	}
+	return hourlyUptimes, nil
+}
+
+func (s *Store) getEndpointServiceLevelStatistics(tx *sql.Tx, endpointID int64, from, to time.Time) (*endpoint.ServiceLevelStatistics, error) {
+	var totalExecutions, successfulExecutions, slowExecutions sql.NullInt64
+	err := tx.QueryRow(
+		`
+			SELECT SUM(total_executions), SUM(successful_executions), SUM(slow_executions)
+			FROM endpoint_uptimes
+			WHERE endpoint_id = $1
+				AND hour_unix_timestamp >= $2
+				AND hour_unix_timestamp <= $3
+		`,
+		endpointID,
+		from.Truncate(time.Hour).Unix(),
+		to.Unix(),
+	).Scan(&totalExecutions, &successfulExecutions, &slowExecutions)
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint.ServiceLevelStatistics{
+		TotalEvents: uint64(totalExecutions.Int64),
+		GoodEvents:  uint64(successfulExecutions.Int64 - slowExecutions.Int64),
+	}, nil
+}
+
+func (s *Store) getEndpointDailyUptimeStatistics(tx *sql.Tx, endpointID int64, from, to time.Time) (map[int64]*endpoint.DailyUptimeStatistics, error) {
+	rows, err := tx.Query(
+		`
+			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time, incidents
+			FROM endpoint_uptimes
+			WHERE endpoint_id = $1
+				AND total_executions > 0
+				AND hour_unix_timestamp >= $2
+				AND hour_unix_timestamp <= $3
+		`,
+		endpointID,
+		from.Truncate(time.Hour).Unix(),
+		to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// Entries older than hourlyUptimeRetention have been merged into daily entries already, but the most recent ones are
+	// still hourly entries, so they have to be summed up by day
+	dailyUptimeStatistics := make(map[int64]*endpoint.DailyUptimeStatistics)
+	for rows.Next() {
+		var unixTimestamp int64
+		var totalExecutions, successfulExecutions, totalResponseTime, incidents uint64
+		if err = rows.Scan(&unixTimestamp, &totalExecutions, &successfulExecutions, &totalResponseTime, &incidents); err != nil {
+			return nil, err
+		}
+		unixTimestampFlooredAtDay := endpoint.TruncateToDay(time.Unix(unixTimestamp, 0)).Unix()
+		dailyStats := dailyUptimeStatistics[unixTimestampFlooredAtDay]
+		if dailyStats == nil {
+			dailyStats = &endpoint.DailyUptimeStatistics{}
+			dailyUptimeStatistics[unixTimestampFlooredAtDay] = dailyStats
+		}
+		dailyStats.TotalExecutions += totalExecutions
+		dailyStats.SuccessfulExecutions += successfulExecutions
+		dailyStats.TotalExecutionsResponseTime += totalResponseTime
+		dailyStats.Incidents += incidents
+	}
+	return dailyUptimeStatistics, nil
+}
+
+func (s *Store) getEndpointFailureBreakdown(tx *sql.Tx, endpointID int64, from, to time.Time) (map[endpoint.FailureReason]int, error) {
+	// Results stored before failures were classified don't have a reason
+	rows, err := tx.Query(
+		`
+			SELECT failure_reason, COUNT(1)
+			FROM endpoint_results
+			WHERE endpoint_id = $1
+				AND success = $2
+				AND failure_reason <> ''
+				AND timestamp >= $3
+				AND timestamp <= $4
+			GROUP BY failure_reason
+		`,
+		endpointID,
+		false,
+		from.UTC(),
+		to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	failureBreakdown := make(map[endpoint.FailureReason]int)
+	for rows.Next() {
+		var failureReason string
+		var numberOfFailures int
+		if err = rows.Scan(&failureReason, &numberOfFailures); err != nil {
+			return nil, err
+		}
+		failureBreakdown[endpoint.FailureReason(failureReason)] = numberOfFailures
+	}
+	return failureBreakdown, nil
+}
+
 func (s *Store) getEndpointID(tx *sql.Tx, ep *endpoint.Endpoint) (int64, error) {
 	var id int64
 	err := tx.QueryRow("SELECT endpoint_id FROM endpoints WHERE endpoint_key = $1", ep.Key()).Scan(&id)
@@ -914,6 +1482,27 @@ func (s *Store) getAgeOfOldestEndpointUptimeEntry(tx *sql.Tx, endpointID int64)
 	return time.Since(time.Unix(oldestEndpointUptimeUnixTimestamp, 0)), nil
 }
 
+func (s *Store) getAgeOfOldestEndpointResult(tx *sql.Tx, endpointID int64) (time.Duration, error) {
+	var oldestEndpointResultTimestamp time.Time
+	err := tx.QueryRow(
+		`
+			SELECT timestamp
+			FROM endpoint_results
+			WHERE endpoint_id = $1
+			ORDER BY endpoint_result_id
+			LIMIT 1
+		`,
+		endpointID,
+	).Scan(&oldestEndpointResultTimestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(oldestEndpointResultTimestamp), nil
+}
+
 func (s *Store) getLastEndpointResultSuccessValue(tx *sql.Tx, endpointID int64) (bool, error) {
 	var success bool
 	err := tx.QueryRow("SELECT success FROM endpoint_results WHERE endpoint_id = $1 ORDER BY endpoint_result_id DESC LIMIT 1", endpointID).Scan(&success)
@@ -933,11 +1522,13 @@ func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64) error {
 			DELETE FROM endpoint_events 
 			WHERE endpoint_id = $1
 				AND endpoint_event_id NOT IN (
-					SELECT endpoint_event_id 
-					FROM endpoint_events
-					WHERE endpoint_id = $1
-					ORDER BY endpoint_event_id DESC
-					LIMIT $2
+					SELECT endpoint_event_id FROM (
+						SELECT endpoint_event_id
+						FROM endpoint_events
+						WHERE endpoint_id = $1
+						ORDER BY endpoint_event_id DESC
+						LIMIT $2
+					) AS recent_endpoint_events
 				)
 		`,
 		endpointID,
@@ -953,11 +1544,13 @@ func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64) error {
 			DELETE FROM endpoint_results
 			WHERE endpoint_id = $1 
 				AND endpoint_result_id NOT IN (
-					SELECT endpoint_result_id
-					FROM endpoint_results
-					WHERE endpoint_id = $1
-					ORDER BY endpoint_result_id DESC
-					LIMIT $2
+					SELECT endpoint_result_id FROM (
+						SELECT endpoint_result_id
+						FROM endpoint_results
+						WHERE endpoint_id = $1
+						ORDER BY endpoint_result_id DESC
+						LIMIT $2
+					) AS recent_endpoint_results
 				)
 		`,
 		endpointID,
@@ -966,18 +1559,32 @@ func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64) error {
 	return err
 }
 
+func (s *Store) deleteEndpointResultsOlderThan(tx *sql.Tx, endpointID int64, maxAge time.Time) error {
+	_, err := tx.Exec("DELETE FROM endpoint_results WHERE endpoint_id = $1 AND timestamp < $2", endpointID, maxAge)
+	return err
+}
+
 func (s *Store) deleteOldUptimeEntries(tx *sql.Tx, endpointID int64, maxAge time.Time) error {
 	_, err := tx.Exec("DELETE FROM endpoint_uptimes WHERE endpoint_id = $1 AND hour_unix_timestamp < $2", endpointID, maxAge.Unix())
 	return err
 }
 
+// uptimeEntriesMergeThreshold returns the number of uptime entries an endpoint may have before its hourly uptime entries
+// are merged into daily uptime entries, which grows with the retention of hourly and daily uptime entries so that the
+// merge isn't triggered on every insert when the retention is longer than the default one
+func (s *Store) uptimeEntriesMergeThreshold() int64 {
+	extraHourlyEntries := (s.hourlyUptimeRetention - uptimeHourlyBuffer) / time.Hour
+	extraDailyEntries := max(s.dailyUptimeRetention-uptimeRetention, 0) / (24 * time.Hour)
+	return uptimeTotalEntriesMergeThreshold + int64(extraHourlyEntries) + int64(extraDailyEntries)
+}
+
 // mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries merges all hourly uptime entries older than
-// uptimeHourlyMergeThreshold from now into daily uptime entries by summing all hourly entries of the same day into a
+// hourlyUptimeRetention from now into daily uptime entries by summing all hourly entries of the same day into a
 // single entry.
 //
 // This effectively limits the number of uptime entries to (48+(n-2)) where 48 is for the first 48 entries with hourly
-// entries (defined by uptimeHourlyBuffer) and n is the number of days for all entries older than 48 hours.
-// Supporting 30d of entries would then result in far less than 24*30=720 entries.
+// entries (defined by hourlyUptimeRetention, which defaults to uptimeHourlyBuffer) and n is the number of days for all
+// entries older than 48 hours. Supporting 90d of entries would then result in far less than 24*90=2160 entries.
 func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEntries(tx *sql.Tx, endpointID int64) error {
 	// Calculate timestamp of the first full day of uptime entries that would not impact the uptime calculation for 24h badges
 	// The logic is that once at least 48 hours passed, we:
@@ -986,13 +1593,13 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 	// which implies that no matter at what hour of the day we are, any timestamp + 48h floored to the current day
 	// will never impact the 24h uptime badge calculation
 	now := time.Now()
-	minThreshold := now.Add(-uptimeHourlyBuffer)
+	minThreshold := now.Add(-s.hourlyUptimeRetention)
 	minThreshold = time.Date(minThreshold.Year(), minThreshold.Month(), minThreshold.Day(), 0, 0, 0, 0, minThreshold.Location())
-	maxThreshold := now.Add(-uptimeRetention)
+	maxThreshold := now.Add(-s.dailyUptimeRetention)
 	// Get all uptime entries older than uptimeHourlyMergeThreshold
 	rows, err := tx.Query(
 		`
-			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time
+			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time, incidents, slow_executions
 			FROM endpoint_uptimes
 			WHERE endpoint_id = $1
 				AND hour_unix_timestamp < $2
@@ -1009,12 +1616,14 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 		totalExecutions      int
 		successfulExecutions int
 		totalResponseTime    int
+		incidents            int
+		slowExecutions       int
 	}
 	dailyEntries := make(map[int64]*Entry)
 	for rows.Next() {
 		var unixTimestamp int64
 		entry := Entry{}
-		if err = rows.Scan(&unixTimestamp, &entry.totalExecutions, &entry.successfulExecutions, &entry.totalResponseTime); err != nil {
+		if err = rows.Scan(&unixTimestamp, &entry.totalExecutions, &entry.successfulExecutions, &entry.totalResponseTime, &entry.incidents, &entry.slowExecutions); err != nil {
 			return err
 		}
 		timestamp := time.Unix(unixTimestamp, 0)
@@ -1025,6 +1634,8 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 			dailyEntries[unixTimestampFlooredAtDay].totalExecutions += entry.totalExecutions
 			dailyEntries[unixTimestampFlooredAtDay].successfulExecutions += entry.successfulExecutions
 			dailyEntries[unixTimestampFlooredAtDay].totalResponseTime += entry.totalResponseTime
+			dailyEntries[unixTimestampFlooredAtDay].incidents += entry.incidents
+			dailyEntries[unixTimestampFlooredAtDay].slowExecutions += entry.slowExecutions
 		}
 	}
 	// Delete older hourly uptime entries
@@ -1035,19 +1646,23 @@ func (s *Store) mergeHourlyUptimeEntriesOlderThanMergeThresholdIntoDailyUptimeEn
 	// Insert new daily uptime entries
 	for unixTimestamp, entry := range dailyEntries {
 		_, err = tx.Exec(
-			`
-					INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time)
-					VALUES ($1, $2, $3, $4, $5)
+			s.upsert(`
+					INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time, incidents, slow_executions)
+					VALUES ($1, $2, $3, $4, $5, $6, $7)
 					ON CONFLICT(endpoint_id, hour_unix_timestamp) DO UPDATE SET
 						total_executions = $3,
 						successful_executions = $4,
-						total_response_time = $5
-				`,
+						total_response_time = $5,
+						incidents = $6,
+						slow_executions = $7
+				`),
 			endpointID,
 			unixTimestamp,
 			entry.totalExecutions,
 			entry.successfulExecutions,
 			entry.totalResponseTime,
+			entry.incidents,
+			entry.slowExecutions,
 		)
 		if err != nil {
 			return err
@@ -169,10 +169,12 @@ func TestStore_HourlyUptimeEntriesAreMergedIntoDailyUptimeEntriesProperly(t *tes
 		{numberOfHours: 50, expectedMaxUptimeEntries: 50},
 		{numberOfHours: 75, expectedMaxUptimeEntries: 75},
 		{numberOfHours: 99, expectedMaxUptimeEntries: 99},
-		{numberOfHours: 150, expectedMaxUptimeEntries: 100},
-		{numberOfHours: 300, expectedMaxUptimeEntries: 100},
-		{numberOfHours: 768, expectedMaxUptimeEntries: 100}, // 32 days (in hours), which means anything beyond that won't be persisted anyway
-		{numberOfHours: 1000, expectedMaxUptimeEntries: 100},
+		{numberOfHours: 150, expectedMaxUptimeEntries: 150},
+		{numberOfHours: 199, expectedMaxUptimeEntries: 199},
+		{numberOfHours: 300, expectedMaxUptimeEntries: 200},
+		{numberOfHours: 1000, expectedMaxUptimeEntries: 200},
+		{numberOfHours: 2208, expectedMaxUptimeEntries: 200}, // 92 days (in hours), which means anything beyond that won't be persisted anyway
+		{numberOfHours: 3000, expectedMaxUptimeEntries: 200},
 	}
 	// Note that is not technically an accurate real world representation, because uptime entries are always added in
 	// the present, while this test is inserting results from the past to simulate long term uptime entries.
@@ -290,6 +292,74 @@ func TestStore_InsertCleansUpEventsAndResultsProperly(t *testing.T) {
 	}
 }
 
+func TestStore_InsertCleansUpResultsBasedOnRetention(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertCleansUpResultsBasedOnRetention.db", false)
+	defer store.Clear()
+	defer store.Close()
+	store.SetRetention(24*time.Hour, 0, 0)
+	// Results must no longer be truncated based on their number
+	for i := 0; i < resultsCleanUpThreshold+10; i++ {
+		store.Insert(&testEndpoint, &endpoint.Result{Timestamp: time.Now().Add(-time.Duration(resultsCleanUpThreshold+10-i) * time.Minute), Success: true})
+	}
+	tx, _ := store.db.Begin()
+	numberOfResults, _ := store.getNumberOfResultsByEndpointID(tx, 1)
+	_ = tx.Commit()
+	if numberOfResults != resultsCleanUpThreshold+10 {
+		t.Errorf("expected %d results to be kept, got %d", resultsCleanUpThreshold+10, numberOfResults)
+	}
+	// Results older than the retention must be deleted once the oldest result exceeds it
+	store.Clear()
+	store.Insert(&testEndpoint, &endpoint.Result{Timestamp: time.Now().Add(-48 * time.Hour), Success: true})
+	store.Insert(&testEndpoint, &endpoint.Result{Timestamp: time.Now().Add(-12 * time.Hour), Success: true})
+	store.Insert(&testEndpoint, &endpoint.Result{Timestamp: time.Now(), Success: true})
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if len(ss.Results) != 2 {
+		t.Errorf("expected 2 results to be kept, got %d", len(ss.Results))
+	}
+}
+
+func TestStore_SetRetention(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_SetRetention.db", false)
+	defer store.Close()
+	if threshold := store.uptimeEntriesMergeThreshold(); threshold != uptimeTotalEntriesMergeThreshold {
+		t.Errorf("expected merge threshold to be %d by default, got %d", uptimeTotalEntriesMergeThreshold, threshold)
+	}
+	// The retention of hourly entries can't be shorter than uptimeHourlyBuffer
+	store.SetRetention(0, time.Hour, 0)
+	if store.hourlyUptimeRetention != uptimeHourlyBuffer || store.dailyUptimeRetention != uptimeHourlyBuffer {
+		t.Errorf("expected retention of uptime entries to be %s, got %s and %s", uptimeHourlyBuffer, store.hourlyUptimeRetention, store.dailyUptimeRetention)
+	}
+	store.SetRetention(7*24*time.Hour, 90*24*time.Hour, 2*365*24*time.Hour)
+	// 88 days of additional hourly entries and 640 days of additional daily entries
+	if threshold := store.uptimeEntriesMergeThreshold(); threshold != uptimeTotalEntriesMergeThreshold+88*24+640 {
+		t.Errorf("expected merge threshold to be %d, got %d", uptimeTotalEntriesMergeThreshold+88*24+640, threshold)
+	}
+}
+
+func TestStore_HourlyUptimeEntriesAreKeptBasedOnRetention(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_HourlyUptimeEntriesAreKeptBasedOnRetention.db", false)
+	defer store.Clear()
+	defer store.Close()
+	store.SetRetention(0, 30*24*time.Hour, 365*24*time.Hour)
+	now := time.Now().Truncate(time.Hour)
+	// 10 days of hourly entries would be merged into daily entries with the default retention, and entries older than
+	// 90 days would be deleted
+	for i := 10 * 24; i > 0; i-- {
+		store.Insert(&testEndpoint, &endpoint.Result{Timestamp: now.Add(-time.Duration(i) * time.Hour), Duration: time.Second, Success: true})
+	}
+	store.Insert(&testEndpoint, &endpoint.Result{Timestamp: now.Add(-200 * 24 * time.Hour), Duration: time.Second, Success: true})
+	tx, _ := store.db.Begin()
+	numberOfUptimeEntries, _ := store.getNumberOfUptimeEntriesByEndpointID(tx, 1)
+	oldest, _ := store.getAgeOfOldestEndpointUptimeEntry(tx, 1)
+	_ = tx.Commit()
+	if numberOfUptimeEntries != 10*24+1 {
+		t.Errorf("expected %d uptime entries, got %d", 10*24+1, numberOfUptimeEntries)
+	}
+	if oldest.Truncate(24*time.Hour) != 200*24*time.Hour {
+		t.Errorf("expected oldest uptime entry to be ~200 days old, was %s", oldest)
+	}
+}
+
 func TestStore_InsertWithCaching(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithCaching.db", true)
 	defer store.Close()
@@ -324,6 +394,35 @@ func TestStore_InsertWithCaching(t *testing.T) {
 	}
 }
 
+func TestStore_InsertWithCachingAndRolledBackTransaction(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithCachingAndRolledBackTransaction.db", true)
+	defer store.Close()
+	store.Insert(&testEndpoint, &testSuccessfulResult)
+	// Populate the cache
+	params := paging.NewEndpointStatusParams().WithResults(1, 20)
+	if endpointStatus, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), params); len(endpointStatus.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(endpointStatus.Results))
+	}
+	tx, err := store.db.Begin()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err = store.insert(tx, &testEndpoint, &testUnsuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	_ = tx.Rollback()
+	// The result that was rolled back must not have made its way to the cache
+	if endpointStatus, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), params); len(endpointStatus.Results) != 1 {
+		t.Errorf("expected 1 result, got %d", len(endpointStatus.Results))
+	}
+	// Results committed through batched writes must refresh the cache as well
+	store.EnableBatchedWrites(10)
+	store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	if endpointStatus, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), params); len(endpointStatus.Results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(endpointStatus.Results))
+	}
+}
+
 func TestStore_Persistence(t *testing.T) {
 	path := t.TempDir() + "/TestStore_Persistence.db"
 	store, _ := NewStore("sqlite", path, false)
@@ -410,6 +509,36 @@ func TestStore_Persistence(t *testing.T) {
 	}
 }
 
+func TestStore_ConditionResultsResolvedValues(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_ConditionResultsResolvedValues.db", false)
+	defer store.Close()
+	result := &endpoint.Result{
+		Success:   false,
+		Timestamp: time.Now(),
+		ConditionResults: []*endpoint.ConditionResult{
+			{Condition: "[RESPONSE_TIME] (1532) < 1000", Success: false, ResolvedValues: &endpoint.ResolvedConditionValues{Left: "1532", Right: "1000"}},
+			{Condition: "[STATUS] == 200", Success: true},
+		},
+	}
+	if err := store.Insert(&testEndpoint, result); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	status, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(status.Results) != 1 || len(status.Results[0].ConditionResults) != 2 {
+		t.Fatalf("expected 1 result with 2 condition results, got %+v", status.Results)
+	}
+	conditionResults := status.Results[0].ConditionResults
+	if conditionResults[0].ResolvedValues == nil || *conditionResults[0].ResolvedValues != *result.ConditionResults[0].ResolvedValues {
+		t.Errorf("expected resolved values %+v, got %+v", result.ConditionResults[0].ResolvedValues, conditionResults[0].ResolvedValues)
+	}
+	if conditionResults[1].ResolvedValues != nil {
+		t.Errorf("expected no resolved values, got %+v", conditionResults[1].ResolvedValues)
+	}
+}
+
 func TestStore_Save(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_Save.db", false)
 	defer store.Close()
@@ -481,7 +610,7 @@ func TestStore_InvalidTransaction(t *testing.T) {
 	if err := store.insertConditionResults(tx, 1, testSuccessfulResult.ConditionResults); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if err := store.updateEndpointUptime(tx, 1, &testSuccessfulResult); err == nil {
+	if err := store.updateEndpointUptime(tx, 1, &testSuccessfulResult, false); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
 	if _, err := store.getAllEndpointKeys(tx); err == nil {
@@ -493,7 +622,7 @@ func TestStore_InvalidTransaction(t *testing.T) {
 	if _, err := store.getEndpointEventsByEndpointID(tx, 1, 1, 50); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if _, err := store.getEndpointResultsByEndpointID(tx, 1, 1, 50); err == nil {
+	if _, err := store.getEndpointResultsByEndpointID(tx, 1, paging.NewEndpointStatusParams().WithResults(1, 50)); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
 	if err := store.deleteOldEndpointEvents(tx, 1); err == nil {
@@ -790,6 +919,55 @@ func TestTriggeredEndpointAlertsPersistence(t *testing.T) {
 	}
 }
 
+func TestEndpointAlertingStatePersistence(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestEndpointAlertingStatePersistence.db", false)
+	defer store.Close()
+	ep := testEndpoint
+	state, err := store.GetEndpointAlertingState(&ep)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if state != nil {
+		t.Fatal("expected no alerting state to have been persisted yet")
+	}
+	// Endpoint failed 3 times in a row after having been successful
+	ep.NumberOfFailuresInARow, ep.NumberOfSuccessesInARow, ep.LastSuccessTimestamp = 3, 0, time.Now().Add(-time.Minute).Truncate(time.Second)
+	ep.DownSince = time.Now().Add(-30 * time.Second).Truncate(time.Second)
+	if err := store.UpsertEndpointAlertingState(&ep); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	state, err = store.GetEndpointAlertingState(&ep)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if state == nil {
+		t.Fatal("expected alerting state to have been persisted")
+	}
+	if state.NumberOfFailuresInARow != 3 || state.NumberOfSuccessesInARow != 0 || !state.LastSuccessTimestamp.Equal(ep.LastSuccessTimestamp) || !state.DownSince.Equal(ep.DownSince) {
+		t.Errorf("expected persisted alerting state to match the endpoint's, got %+v", state)
+	}
+	// Endpoint just had a successful evaluation
+	ep.NumberOfFailuresInARow, ep.NumberOfSuccessesInARow, ep.LastSuccessTimestamp = 0, 1, time.Now().Truncate(time.Second)
+	if err := store.UpsertEndpointAlertingState(&ep); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	state, _ = store.GetEndpointAlertingState(&ep)
+	if state == nil || state.NumberOfFailuresInARow != 0 || state.NumberOfSuccessesInARow != 1 || !state.LastSuccessTimestamp.Equal(ep.LastSuccessTimestamp) {
+		t.Errorf("expected persisted alerting state to have been updated, got %+v", state)
+	}
+	// Restoring the state on a fresh copy of the endpoint, as is done on startup, should restore the counters
+	restoredEndpoint := testEndpoint
+	restoredEndpoint.RestoreAlertingState(state)
+	if restoredEndpoint.NumberOfSuccessesInARow != 1 || restoredEndpoint.NumberOfFailuresInARow != 0 {
+		t.Errorf("expected restored endpoint to have 1 success and 0 failures in a row, got %d and %d", restoredEndpoint.NumberOfSuccessesInARow, restoredEndpoint.NumberOfFailuresInARow)
+	}
+	// Deleting the endpoint should delete its alerting state as well
+	store.Clear()
+	if state, _ = store.GetEndpointAlertingState(&ep); state != nil {
+		t.Errorf("expected alerting state to have been deleted along with the endpoint, got %+v", state)
+	}
+}
+
 func TestStore_DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_DeleteAllTriggeredAlertsNotInChecksumsByEndpoint.db", false)
 	defer store.Close()
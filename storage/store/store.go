@@ -3,10 +3,14 @@ package store
 import (
 	"context"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/snapshot"
 	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gatus/v5/storage/store/memory"
@@ -34,9 +38,31 @@ type Store interface {
 	// GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
 	GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error)
 
+	// GetHourlyUptimeByKey returns a map of hourly (key) uptime (value), as a value between 0 and 1, during a time range
+	GetHourlyUptimeByKey(key string, from, to time.Time) (map[int64]float64, error)
+
+	// GetDailyUptimeStatisticsByKey returns a map of daily (key) uptime statistics (value) during a time range
+	//
+	// The keys are the unix timestamps of the start of each day in the local time zone
+	GetDailyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*endpoint.DailyUptimeStatistics, error)
+
+	// GetServiceLevelStatisticsByKey returns the number of events relevant to the service level objective of an
+	// endpoint during a time range, from which its error budget and burn rate are computed
+	GetServiceLevelStatisticsByKey(key string, from, to time.Time) (*endpoint.ServiceLevelStatistics, error)
+
+	// GetFailureBreakdownByKey returns the number of unsuccessful results (value) by failure reason (key) during a
+	// time range. Only the results that are still stored are taken into account.
+	GetFailureBreakdownByKey(key string, from, to time.Time) (map[endpoint.FailureReason]int, error)
+
 	// Insert adds the observed result for the specified endpoint into the store
 	Insert(ep *endpoint.Endpoint, result *endpoint.Result) error
 
+	// InsertUptime adds the observed result for the specified endpoint into the uptime data without storing the result
+	// itself, which is used for the results discarded by sampling so that they're still accounted for in the uptime.
+	//
+	// If the endpoint isn't in the store yet, the result is inserted as it would be by Insert.
+	InsertUptime(ep *endpoint.Endpoint, result *endpoint.Result) error
+
 	// DeleteAllEndpointStatusesNotInKeys removes all Status that are not within the keys provided
 	//
 	// Used to delete endpoints that have been persisted but are no longer part of the configured endpoints
@@ -57,6 +83,70 @@ type Store interface {
 	// This prevents triggered alerts that have been removed or modified from lingering in the database.
 	DeleteAllTriggeredAlertsNotInChecksumsByEndpoint(ep *endpoint.Endpoint, checksums []string) int
 
+	// GetEndpointAlertingState returns the alerting state persisted for the specified endpoint, or nil if there is none
+	GetEndpointAlertingState(ep *endpoint.Endpoint) (*endpoint.AlertingState, error)
+
+	// UpsertEndpointAlertingState inserts/updates the alerting state of an endpoint
+	// Used for persistence of the number of failures and successes in a row across application restarts
+	UpsertEndpointAlertingState(ep *endpoint.Endpoint) error
+
+	// InsertAlertDelivery queues an alert to be delivered and sets the ID of the delivery passed
+	InsertAlertDelivery(d *delivery.Delivery) error
+
+	// UpdateAlertDelivery updates the state of an alert delivery and the attempts made to deliver it
+	UpdateAlertDelivery(d *delivery.Delivery) error
+
+	// DeleteAlertDelivery removes an alert delivery, which is done once the alert has been delivered
+	DeleteAlertDelivery(id int64) error
+
+	// GetAlertDeliveryByID returns the alert delivery with the ID passed
+	GetAlertDeliveryByID(id int64) (*delivery.Delivery, error)
+
+	// GetAlertDeliveries returns every alert delivery, including the ones that have been dead-lettered, ordered by ID
+	GetAlertDeliveries() ([]*delivery.Delivery, error)
+
+	// InsertAlertHistoryEntry persists an entry of the alert history and sets the ID of the entry passed
+	InsertAlertHistoryEntry(e *history.Entry) error
+
+	// UpdateAlertHistoryEntry updates the resolution and the delivery summaries of an entry of the alert history
+	UpdateAlertHistoryEntry(e *history.Entry) error
+
+	// DeleteAlertHistoryEntry removes an entry of the alert history
+	DeleteAlertHistoryEntry(id int64) error
+
+	// GetAlertHistoryEntryByID returns the entry of the alert history with the ID passed
+	GetAlertHistoryEntryByID(id int64) (*history.Entry, error)
+
+	// GetUnresolvedAlertHistoryEntry returns the entry of the alert history of an alert of an endpoint that is still
+	// triggered
+	GetUnresolvedAlertHistoryEntry(endpointKey, alertChecksum string) (*history.Entry, error)
+
+	// GetAlertHistory returns the entries of the alert history of the alerts that were triggered at some point within
+	// the time range passed, ordered from the most recently triggered to the least recently triggered.
+	// A zero time means that the range is unbounded on that side.
+	GetAlertHistory(from, to time.Time) ([]*history.Entry, error)
+
+	// InsertExternalEndpointToken persists a token of an external endpoint and sets the ID of the token passed
+	InsertExternalEndpointToken(t *endpoint.ExternalEndpointToken) error
+
+	// GetExternalEndpointTokens returns every token of an external endpoint, including the ones that expired or were
+	// revoked, ordered by ID
+	GetExternalEndpointTokens(endpointKey string) ([]*endpoint.ExternalEndpointToken, error)
+
+	// RevokeExternalEndpointToken revokes a token of an external endpoint. Revoking a token that was already revoked
+	// does nothing.
+	RevokeExternalEndpointToken(endpointKey string, id int64) error
+
+	// InsertConfigurationSnapshot persists a snapshot of a configuration that has been applied and sets the version of
+	// the snapshot passed. Only the last common.MaximumNumberOfConfigurationSnapshots snapshots are kept.
+	InsertConfigurationSnapshot(s *snapshot.Snapshot) error
+
+	// GetConfigurationSnapshots returns every configuration snapshot, ordered from the most recent to the least recent
+	GetConfigurationSnapshots() ([]*snapshot.Snapshot, error)
+
+	// GetConfigurationSnapshot returns the configuration snapshot with the version passed
+	GetConfigurationSnapshot(version int64) (*snapshot.Snapshot, error)
+
 	// Clear deletes everything from the store
 	Clear()
 
@@ -74,6 +164,7 @@ var (
 	// Validate interface implementation on compile
 	_ Store = (*memory.Store)(nil)
 	_ Store = (*sql.Store)(nil)
+	_ Store = (*Router)(nil)
 )
 
 var (
@@ -103,7 +194,6 @@ func Get() Store {
 // Initialize instantiates the storage provider based on the Config provider
 func Initialize(cfg *storage.Config) error {
 	initialized = true
-	var err error
 	if cancelFunc != nil {
 		// Stop the active autoSave task, if there's already one
 		cancelFunc()
@@ -113,22 +203,66 @@ func Initialize(cfg *storage.Config) error {
 		log.Println("[store.Initialize] nil storage config passed as parameter. This should only happen in tests. Defaulting to an empty config.")
 		cfg = &storage.Config{}
 	}
-	if len(cfg.Path) == 0 && cfg.Type != storage.TypePostgres {
+	if len(cfg.Path) == 0 && cfg.Type != storage.TypePostgres && cfg.Type != storage.TypeMySQL {
 		log.Printf("[store.Initialize] Creating storage provider of type=%s", cfg.Type)
 	}
 	ctx, cancelFunc = context.WithCancel(context.Background())
-	switch cfg.Type {
-	case storage.TypeSQLite, storage.TypePostgres:
-		store, err = sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching)
+	defaultStore, err := newStore(cfg)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Routes) == 0 {
+		store = defaultStore
+		return nil
+	}
+	storesByGroup := make(map[string]Store)
+	for _, route := range cfg.Routes {
+		log.Printf("[store.Initialize] Creating storage provider of type=%s for groups=%s", route.Type, strings.Join(route.Groups, ","))
+		routedStore, err := newStore(&route.Config)
 		if err != nil {
+			defaultStore.Close()
+			for _, s := range storesByGroup {
+				s.Close()
+			}
 			return err
 		}
+		for _, group := range route.Groups {
+			storesByGroup[group] = routedStore
+		}
+	}
+	store = NewRouter(defaultStore, storesByGroup)
+	return nil
+}
+
+// newStore creates the store described by the Config provided
+func newStore(cfg *storage.Config) (Store, error) {
+	switch cfg.Type {
+	case storage.TypeSQLite, storage.TypePostgres, storage.TypeMySQL:
+		sqlStore, err := sql.NewStore(string(cfg.Type), cfg.Path, cfg.Caching)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Compression {
+			sqlStore.EnableCompression()
+		}
+		if cfg.Encryption != nil {
+			if err = sqlStore.EnableEncryption(cfg.Encryption.DecodedKey()); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.BatchSize > 1 {
+			sqlStore.EnableBatchedWrites(cfg.BatchSize)
+		}
+		if cfg.Retention != nil {
+			sqlStore.SetRetention(cfg.Retention.RawDuration(), cfg.Retention.HourlyDuration(), cfg.Retention.DailyDuration())
+		}
+		return sqlStore, nil
 	case storage.TypeMemory:
 		fallthrough
 	default:
-		store, _ = memory.NewStore()
+		memoryStore, _ := memory.NewStore()
+		return memoryStore, nil
 	}
-	return nil
 }
 
 // autoSave automatically calls the Save function of the provider at every interval
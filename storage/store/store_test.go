@@ -2,11 +2,16 @@ package store
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/snapshot"
 	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
@@ -170,6 +175,40 @@ func TestStore_GetEndpointStatusByKey(t *testing.T) {
 	}
 }
 
+func TestStore_GetEndpointStatusByKeyWithDowntimeFromAlertingState(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetEndpointStatusByKeyWithDowntimeFromAlertingState")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			ep := testEndpoint
+			failedResult := testUnsuccessfulResult
+			failedResult.Timestamp = now
+			scenario.Store.Insert(&ep, &failedResult)
+			// The alerting state, rather than the unhealthy event, is what determines since when the endpoint is down
+			ep.DownSince = now.Add(-time.Hour).Truncate(time.Second)
+			if err := scenario.Store.UpsertEndpointAlertingState(&ep); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			endpointStatus, err := scenario.Store.GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams())
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if endpointStatus.DownSince == nil || !endpointStatus.DownSince.Equal(ep.DownSince) {
+				t.Errorf("expected DownSince to be %s, got %v", ep.DownSince, endpointStatus.DownSince)
+			}
+			ep.DownSince = time.Time{}
+			if err := scenario.Store.UpsertEndpointAlertingState(&ep); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			endpointStatus, _ = scenario.Store.GetEndpointStatusByKey(ep.Key(), paging.NewEndpointStatusParams())
+			if endpointStatus.DownSince != nil {
+				t.Errorf("expected DownSince to be nil once the outage is over, got %v", endpointStatus.DownSince)
+			}
+			scenario.Store.Clear()
+		})
+	}
+}
+
 func TestStore_GetEndpointStatusForMissingStatusReturnsNil(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetEndpointStatusForMissingStatusReturnsNil")
 	defer cleanUp(scenarios)
@@ -333,6 +372,60 @@ func TestStore_GetEndpointStatusPage1IsHasMoreRecentResultsThanPage2(t *testing.
 	}
 }
 
+func TestStore_GetEndpointStatusWithResultsTimeRangeAndOrder(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetEndpointStatusWithResultsTimeRangeAndOrder")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			for i := 4; i >= 0; i-- {
+				result := testSuccessfulResult
+				result.Timestamp = now.Add(-time.Duration(i) * time.Hour)
+				result.Duration = time.Duration(i) * time.Millisecond
+				scenario.Store.Insert(&testEndpoint, &result)
+			}
+			// Only the results from 3 hours ago, 2 hours ago and 1 hour ago are within the range
+			from, to := now.Add(-3*time.Hour-time.Minute), now.Add(-time.Hour+time.Minute)
+			endpointStatus, err := scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithResultsTimeRange(from, to))
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatus.Results) != 3 {
+				t.Fatalf("expected 3 results, got %d", len(endpointStatus.Results))
+			}
+			if endpointStatus.Results[0].Duration != 3*time.Millisecond || endpointStatus.Results[2].Duration != time.Millisecond {
+				t.Error("expected results to be sorted from oldest to newest")
+			}
+			endpointStatus, err = scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 2).WithResultsTimeRange(from, to))
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatus.Results) != 2 || endpointStatus.Results[0].Duration != 2*time.Millisecond || endpointStatus.Results[1].Duration != time.Millisecond {
+				t.Error("expected the first page to contain the 2 most recent results within the range")
+			}
+			endpointStatus, err = scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 2).WithResultsTimeRange(from, to).WithResultsOrder(paging.OrderAscending))
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatus.Results) != 2 || endpointStatus.Results[0].Duration != 3*time.Millisecond || endpointStatus.Results[1].Duration != 2*time.Millisecond {
+				t.Error("expected the first page to contain the 2 oldest results within the range when the order is ascending")
+			}
+			endpointStatus, err = scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(2, 2).WithResultsOrder(paging.OrderAscending))
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatus.Results) != 2 || endpointStatus.Results[0].Duration != 2*time.Millisecond || endpointStatus.Results[1].Duration != time.Millisecond {
+				t.Error("expected the second page to contain the third and fourth oldest results when the order is ascending")
+			}
+			if _, err = scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithResultsTimeRange(to, from)); !errors.Is(err, common.ErrInvalidTimeRange) {
+				t.Error("expected ErrInvalidTimeRange, got", err)
+			}
+			if _, err = scenario.Store.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, 20).WithResultsTimeRange(to, from)); !errors.Is(err, common.ErrInvalidTimeRange) {
+				t.Error("expected ErrInvalidTimeRange, got", err)
+			}
+		})
+	}
+}
+
 func TestStore_GetUptimeByKey(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetUptimeByKey")
 	defer cleanUp(scenarios)
@@ -459,6 +552,201 @@ func TestStore_GetHourlyAverageResponseTimeByKey(t *testing.T) {
 	}
 }
 
+func TestStore_GetHourlyUptimeByKey(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetHourlyUptimeByKey")
+	defer cleanUp(scenarios)
+	firstResult := testSuccessfulResult
+	firstResult.Timestamp = now.Add(-(2 * time.Hour))
+	secondResult := testUnsuccessfulResult
+	secondResult.Timestamp = now.Add(-(1*time.Hour + 30*time.Minute))
+	thirdResult := testSuccessfulResult
+	thirdResult.Timestamp = now.Add(-(1 * time.Hour))
+	fourthResult := testUnsuccessfulResult
+	fourthResult.Timestamp = now
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			scenario.Store.Insert(&testEndpoint, &firstResult)
+			scenario.Store.Insert(&testEndpoint, &secondResult)
+			scenario.Store.Insert(&testEndpoint, &thirdResult)
+			scenario.Store.Insert(&testEndpoint, &fourthResult)
+			hourlyUptime, err := scenario.Store.GetHourlyUptimeByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now)
+			if err != nil {
+				t.Error("shouldn't have returned an error, got", err)
+			}
+			if key := now.Truncate(time.Hour).Unix(); hourlyUptime[key] != 0 {
+				t.Errorf("expected uptime to be 0 at %d, got %v", key, hourlyUptime[key])
+			}
+			if key := now.Truncate(time.Hour).Add(-time.Hour).Unix(); hourlyUptime[key] != 1 {
+				t.Errorf("expected uptime to be 1 at %d, got %v", key, hourlyUptime[key])
+			}
+			if key := now.Truncate(time.Hour).Add(-2 * time.Hour).Unix(); hourlyUptime[key] != 0.5 {
+				t.Errorf("expected uptime to be 0.5 at %d, got %v", key, hourlyUptime[key])
+			}
+			if _, err := scenario.Store.GetHourlyUptimeByKey("nonexistent", now.Add(-24*time.Hour), now); err == nil {
+				t.Error("should've returned an error for an endpoint that doesn't exist")
+			}
+			scenario.Store.Clear()
+		})
+	}
+}
+
+func TestStore_GetDailyUptimeStatisticsByKey(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetDailyUptimeStatisticsByKey")
+	defer cleanUp(scenarios)
+	today := endpoint.TruncateToDay(now)
+	firstResult := testSuccessfulResult
+	firstResult.Timestamp = today.AddDate(0, 0, -1).Add(time.Hour)
+	secondResult := testUnsuccessfulResult
+	secondResult.Timestamp = today.AddDate(0, 0, -1).Add(2 * time.Hour)
+	thirdResult := testSuccessfulResult
+	thirdResult.Timestamp = today
+	fourthResult := testUnsuccessfulResult
+	fourthResult.Timestamp = today.Add(time.Minute)
+	fifthResult := testUnsuccessfulResult
+	fifthResult.Timestamp = today.Add(2 * time.Minute)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			scenario.Store.Insert(&testEndpoint, &firstResult)
+			scenario.Store.Insert(&testEndpoint, &secondResult)
+			scenario.Store.Insert(&testEndpoint, &thirdResult)
+			scenario.Store.Insert(&testEndpoint, &fourthResult)
+			scenario.Store.Insert(&testEndpoint, &fifthResult)
+			dailyUptimeStatistics, err := scenario.Store.GetDailyUptimeStatisticsByKey(testEndpoint.Key(), today.AddDate(0, 0, -2), now.Add(time.Hour))
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err)
+			}
+			if len(dailyUptimeStatistics) != 2 {
+				t.Fatalf("expected statistics for 2 days, got %d", len(dailyUptimeStatistics))
+			}
+			yesterdayStats := dailyUptimeStatistics[today.AddDate(0, 0, -1).Unix()]
+			if yesterdayStats == nil || yesterdayStats.TotalExecutions != 2 || yesterdayStats.Uptime() != 0.5 || yesterdayStats.Incidents != 1 {
+				t.Errorf("expected 2 executions, an uptime of 0.5 and 1 incident yesterday, got %+v", yesterdayStats)
+			}
+			todayStats := dailyUptimeStatistics[today.Unix()]
+			if todayStats == nil || todayStats.TotalExecutions != 3 || todayStats.SuccessfulExecutions != 1 || todayStats.Incidents != 1 {
+				t.Errorf("expected 3 executions, 1 successful execution and 1 incident today, got %+v", todayStats)
+			}
+			if _, err := scenario.Store.GetDailyUptimeStatisticsByKey("invalid_key", today, now); err == nil {
+				t.Error("expected an error because the endpoint doesn't exist, got nil")
+			}
+			if _, err := scenario.Store.GetDailyUptimeStatisticsByKey(testEndpoint.Key(), now, today); err == nil {
+				t.Error("expected an error because from > to, got nil")
+			}
+			scenario.Store.Clear()
+		})
+	}
+}
+
+func TestStore_GetServiceLevelStatisticsByKey(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetServiceLevelStatisticsByKey")
+	defer cleanUp(scenarios)
+	firstResult := testSuccessfulResult
+	firstResult.Timestamp = now.Add(-(3 * time.Hour))
+	secondResult := testUnsuccessfulResult
+	secondResult.Timestamp = now.Add(-(2 * time.Hour))
+	thirdResult := testSuccessfulResult
+	thirdResult.Timestamp = now.Add(-(1 * time.Hour))
+	thirdResult.ExceededResponseTimeObjective = true
+	fourthResult := testSuccessfulResult
+	fourthResult.Timestamp = now
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			scenario.Store.Insert(&testEndpoint, &firstResult)
+			scenario.Store.Insert(&testEndpoint, &secondResult)
+			scenario.Store.Insert(&testEndpoint, &thirdResult)
+			scenario.Store.Insert(&testEndpoint, &fourthResult)
+			statistics, err := scenario.Store.GetServiceLevelStatisticsByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now)
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err)
+			}
+			if statistics.TotalEvents != 4 || statistics.GoodEvents != 2 {
+				t.Errorf("expected 4 events of which 2 are good, got %+v", statistics)
+			}
+			if statistics, _ = scenario.Store.GetServiceLevelStatisticsByKey(testEndpoint.Key(), now.Add(-time.Hour), now); statistics.TotalEvents != 2 || statistics.GoodEvents != 1 {
+				t.Errorf("expected 2 events of which 1 is good over the last hour, got %+v", statistics)
+			}
+			if _, err := scenario.Store.GetServiceLevelStatisticsByKey("invalid_key", now.Add(-time.Hour), now); err == nil {
+				t.Error("expected an error because the endpoint doesn't exist, got nil")
+			}
+			if _, err := scenario.Store.GetServiceLevelStatisticsByKey(testEndpoint.Key(), now, now.Add(-time.Hour)); err == nil {
+				t.Error("expected an error because from > to, got nil")
+			}
+			scenario.Store.Clear()
+		})
+	}
+}
+
+func TestStore_GetFailureBreakdownByKey(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetFailureBreakdownByKey")
+	defer cleanUp(scenarios)
+	firstResult := testUnsuccessfulResult
+	firstResult.FailureReason = endpoint.FailureReasonTimeout
+	firstResult.Timestamp = now.Add(-2 * time.Hour)
+	secondResult := testUnsuccessfulResult
+	secondResult.FailureReason = endpoint.FailureReasonDNS
+	secondResult.Timestamp = now.Add(-time.Minute)
+	thirdResult := testSuccessfulResult
+	thirdResult.Timestamp = now.Add(-time.Minute)
+	fourthResult := testUnsuccessfulResult
+	fourthResult.FailureReason = endpoint.FailureReasonDNS
+	fourthResult.Timestamp = now
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			scenario.Store.Insert(&testEndpoint, &firstResult)
+			scenario.Store.Insert(&testEndpoint, &secondResult)
+			scenario.Store.Insert(&testEndpoint, &thirdResult)
+			scenario.Store.Insert(&testEndpoint, &fourthResult)
+			if failureBreakdown, err := scenario.Store.GetFailureBreakdownByKey(testEndpoint.Key(), now.Add(-3*time.Hour), now.Add(time.Minute)); err != nil {
+				t.Fatal("shouldn't have returned an error, got", err)
+			} else if len(failureBreakdown) != 2 || failureBreakdown[endpoint.FailureReasonDNS] != 2 || failureBreakdown[endpoint.FailureReasonTimeout] != 1 {
+				t.Errorf("expected 2 DNS failures and 1 timeout, got %v", failureBreakdown)
+			}
+			if failureBreakdown, err := scenario.Store.GetFailureBreakdownByKey(testEndpoint.Key(), now.Add(-time.Hour), now.Add(time.Minute)); err != nil {
+				t.Fatal("shouldn't have returned an error, got", err)
+			} else if len(failureBreakdown) != 1 || failureBreakdown[endpoint.FailureReasonDNS] != 2 {
+				t.Errorf("expected only 2 DNS failures in the last hour, got %v", failureBreakdown)
+			}
+			if _, err := scenario.Store.GetFailureBreakdownByKey("invalid_key", now.Add(-time.Hour), now); err == nil {
+				t.Error("expected an error because the endpoint doesn't exist, got nil")
+			}
+			if _, err := scenario.Store.GetFailureBreakdownByKey(testEndpoint.Key(), now, now.Add(-time.Hour)); err == nil {
+				t.Error("expected an error because from > to, got nil")
+			}
+			scenario.Store.Clear()
+		})
+	}
+}
+
+func TestStore_InsertUptime(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_InsertUptime")
+	defer cleanUp(scenarios)
+	failedResult := testUnsuccessfulResult
+	failedResult.Timestamp = now.Add(-time.Minute)
+	sampledOutResult := testSuccessfulResult
+	sampledOutResult.Timestamp = now
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			// The endpoint isn't in the store yet, so the result must be inserted like it would be by Insert
+			if err := scenario.Store.InsertUptime(&testEndpoint, &failedResult); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if err := scenario.Store.InsertUptime(&testEndpoint, &sampledOutResult); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			ss, err := scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithEvents(1, common.MaximumNumberOfEvents).WithResults(1, common.MaximumNumberOfResults))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if len(ss.Results) != 1 || ss.Results[0].Success {
+				t.Errorf("expected only the failed result to be stored, got %d results", len(ss.Results))
+			}
+			if uptime, _ := scenario.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-time.Hour), now.Add(time.Hour)); uptime != 0.5 {
+				t.Errorf("expected the result that wasn't stored to be accounted for in the uptime, got uptime %f", uptime)
+			}
+		})
+	}
+}
+
 func TestStore_Insert(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_Insert")
 	defer cleanUp(scenarios)
@@ -570,6 +858,391 @@ func TestStore_DeleteAllEndpointStatusesNotInKeys(t *testing.T) {
 	}
 }
 
+func TestStore_AlertDeliveries(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_AlertDeliveries")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			result := testUnsuccessfulResult
+			first := delivery.NewDelivery(&testEndpoint, &alert.Alert{Type: alert.TypeSlack}, &result, false)
+			second := delivery.NewDelivery(&testEndpoint, &alert.Alert{Type: alert.TypePagerDuty}, &result, true)
+			if err := scenario.Store.InsertAlertDelivery(first); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if err := scenario.Store.InsertAlertDelivery(second); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if first.ID == 0 || second.ID <= first.ID {
+				t.Fatalf("expected increasing IDs to be set, got %d and %d", first.ID, second.ID)
+			}
+			first.RecordFailure(errors.New("provider unavailable"), &delivery.Config{MaximumAttempts: 1})
+			if err := scenario.Store.UpdateAlertDelivery(first); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			deliveries, err := scenario.Store.GetAlertDeliveries()
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if len(deliveries) != 2 || deliveries[0].ID != first.ID || deliveries[1].ID != second.ID {
+				t.Fatalf("expected both deliveries ordered by ID, got %v", deliveries)
+			}
+			if !deliveries[0].DeadLettered || deliveries[0].Attempts != 1 || deliveries[0].LastError != "provider unavailable" {
+				t.Errorf("expected first delivery to be dead-lettered, got %+v", deliveries[0])
+			}
+			if deliveries[1].AlertType != alert.TypePagerDuty || !deliveries[1].Resolved || deliveries[1].EndpointKey != testEndpoint.Key() {
+				t.Errorf("expected second delivery to be a resolved pagerduty alert, got %+v", deliveries[1])
+			}
+			if deliveries[1].Result == nil || len(deliveries[1].Result.ConditionResults) != len(result.ConditionResults) || deliveries[1].Result.Success {
+				t.Errorf("expected result of the second delivery to be persisted, got %+v", deliveries[1].Result)
+			}
+			if err := scenario.Store.DeleteAlertDelivery(second.ID); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if _, err := scenario.Store.GetAlertDeliveryByID(second.ID); !errors.Is(err, common.ErrAlertDeliveryNotFound) {
+				t.Errorf("expected error %v, got %v", common.ErrAlertDeliveryNotFound, err)
+			}
+			if err := scenario.Store.DeleteAlertDelivery(second.ID); !errors.Is(err, common.ErrAlertDeliveryNotFound) {
+				t.Errorf("expected error %v, got %v", common.ErrAlertDeliveryNotFound, err)
+			}
+			if err := scenario.Store.UpdateAlertDelivery(second); !errors.Is(err, common.ErrAlertDeliveryNotFound) {
+				t.Errorf("expected error %v, got %v", common.ErrAlertDeliveryNotFound, err)
+			}
+			if d, err := scenario.Store.GetAlertDeliveryByID(first.ID); err != nil || !d.DeadLettered {
+				t.Errorf("expected first delivery to still exist, got %+v and error %v", d, err)
+			}
+		})
+	}
+}
+
+func TestStore_AlertHistory(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_AlertHistory")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			slackAlert, pagerDutyAlert := &alert.Alert{Type: alert.TypeSlack}, &alert.Alert{Type: alert.TypePagerDuty}
+			resolved := history.NewEntry(&testEndpoint, slackAlert, now.Add(-3*time.Hour), history.DeliverySummary{Status: history.DeliveryStatusSent, Attempts: 1})
+			unresolved := history.NewEntry(&testEndpoint, pagerDutyAlert, now.Add(-time.Hour), history.DeliverySummary{Status: history.DeliveryStatusQueued})
+			for _, entry := range []*history.Entry{resolved, unresolved} {
+				if err := scenario.Store.InsertAlertHistoryEntry(entry); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+			}
+			if resolved.ID == 0 || unresolved.ID <= resolved.ID {
+				t.Fatalf("expected increasing IDs to be set, got %d and %d", resolved.ID, unresolved.ID)
+			}
+			resolved.Resolve(now.Add(-2*time.Hour), &history.DeliverySummary{Status: history.DeliveryStatusFailed, Attempts: 1, LastError: "provider unavailable"})
+			if err := scenario.Store.UpdateAlertHistoryEntry(resolved); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if entry, err := scenario.Store.GetUnresolvedAlertHistoryEntry(testEndpoint.Key(), pagerDutyAlert.Checksum()); err != nil || entry.ID != unresolved.ID {
+				t.Errorf("expected the unresolved entry, got %+v and error %v", entry, err)
+			}
+			if _, err := scenario.Store.GetUnresolvedAlertHistoryEntry(testEndpoint.Key(), slackAlert.Checksum()); !errors.Is(err, common.ErrAlertHistoryEntryNotFound) {
+				t.Errorf("expected error %v, got %v", common.ErrAlertHistoryEntryNotFound, err)
+			}
+			entry, err := scenario.Store.GetAlertHistoryEntryByID(resolved.ID)
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if entry.ResolvedAt == nil || !entry.ResolvedAt.Equal(now.Add(-2*time.Hour)) || entry.Duration() != time.Hour {
+				t.Errorf("expected the entry to have been resolved after an hour, got %+v", entry)
+			}
+			if entry.ResolvedDelivery == nil || entry.ResolvedDelivery.LastError != "provider unavailable" || entry.TriggeredDelivery.Status != history.DeliveryStatusSent {
+				t.Errorf("expected the delivery summaries to be persisted, got %+v and %+v", entry.TriggeredDelivery, entry.ResolvedDelivery)
+			}
+			if entry.EndpointKey != testEndpoint.Key() || entry.EndpointName != testEndpoint.Name || entry.EndpointGroup != testEndpoint.Group || entry.AlertType != alert.TypeSlack {
+				t.Errorf("expected the entry to be persisted, got %+v", entry)
+			}
+			timeRangeScenarios := []struct {
+				name        string
+				from, to    time.Time
+				expectedIDs []int64
+			}{
+				{name: "unbounded", expectedIDs: []int64{unresolved.ID, resolved.ID}},
+				{name: "after-resolution", from: now.Add(-90 * time.Minute), expectedIDs: []int64{unresolved.ID}},
+				{name: "before-trigger", to: now.Add(-2 * time.Hour), expectedIDs: []int64{resolved.ID}},
+				{name: "none", to: now.Add(-4 * time.Hour), expectedIDs: []int64{}},
+			}
+			for _, timeRangeScenario := range timeRangeScenarios {
+				entries, err := scenario.Store.GetAlertHistory(timeRangeScenario.from, timeRangeScenario.to)
+				if err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				if len(entries) != len(timeRangeScenario.expectedIDs) {
+					t.Fatalf("[%s] expected %d entries, got %d", timeRangeScenario.name, len(timeRangeScenario.expectedIDs), len(entries))
+				}
+				for i, e := range entries {
+					if e.ID != timeRangeScenario.expectedIDs[i] {
+						t.Errorf("[%s] expected entry at index %d to have ID %d, got %d", timeRangeScenario.name, i, timeRangeScenario.expectedIDs[i], e.ID)
+					}
+				}
+			}
+			if _, err := scenario.Store.GetAlertHistory(now, now.Add(-time.Hour)); !errors.Is(err, common.ErrInvalidTimeRange) {
+				t.Errorf("expected error %v, got %v", common.ErrInvalidTimeRange, err)
+			}
+			if err := scenario.Store.DeleteAlertHistoryEntry(unresolved.ID); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if err := scenario.Store.DeleteAlertHistoryEntry(unresolved.ID); !errors.Is(err, common.ErrAlertHistoryEntryNotFound) {
+				t.Errorf("expected error %v, got %v", common.ErrAlertHistoryEntryNotFound, err)
+			}
+			if err := scenario.Store.UpdateAlertHistoryEntry(unresolved); !errors.Is(err, common.ErrAlertHistoryEntryNotFound) {
+				t.Errorf("expected error %v, got %v", common.ErrAlertHistoryEntryNotFound, err)
+			}
+		})
+	}
+}
+
+func TestStore_ExternalEndpointTokens(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_ExternalEndpointTokens")
+	defer cleanUp(scenarios)
+	externalEndpoint := &endpoint.ExternalEndpoint{Name: "agent", Group: "external"}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			_, first, _ := endpoint.NewExternalEndpointToken(externalEndpoint, "first", 0)
+			_, second, _ := endpoint.NewExternalEndpointToken(externalEndpoint, "second", time.Hour)
+			_, other, _ := endpoint.NewExternalEndpointToken(&endpoint.ExternalEndpoint{Name: "other"}, "other", 0)
+			for _, token := range []*endpoint.ExternalEndpointToken{first, second, other} {
+				if err := scenario.Store.InsertExternalEndpointToken(token); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+			}
+			if first.ID == 0 || second.ID <= first.ID {
+				t.Fatalf("expected increasing IDs to be set, got %d and %d", first.ID, second.ID)
+			}
+			tokens, err := scenario.Store.GetExternalEndpointTokens(externalEndpoint.Key())
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if len(tokens) != 2 || tokens[0].ID != first.ID || tokens[1].ID != second.ID {
+				t.Fatalf("expected the tokens of the external endpoint ordered by ID, got %v", tokens)
+			}
+			if tokens[0].Hash != first.Hash || tokens[0].Name != "first" || tokens[0].ExpiresAt != nil || !tokens[0].CreatedAt.Equal(first.CreatedAt) {
+				t.Errorf("expected first token to be persisted, got %+v", tokens[0])
+			}
+			if tokens[1].ExpiresAt == nil || !tokens[1].ExpiresAt.Equal(*second.ExpiresAt) || !tokens[1].IsActive() {
+				t.Errorf("expected second token to be persisted with its expiration, got %+v", tokens[1])
+			}
+			if err := scenario.Store.RevokeExternalEndpointToken(externalEndpoint.Key(), first.ID); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			// Revoking a token that was already revoked does nothing
+			if err := scenario.Store.RevokeExternalEndpointToken(externalEndpoint.Key(), first.ID); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if err := scenario.Store.RevokeExternalEndpointToken(externalEndpoint.Key(), other.ID); !errors.Is(err, common.ErrExternalEndpointTokenNotFound) {
+				t.Errorf("expected error %v for a token of another endpoint, got %v", common.ErrExternalEndpointTokenNotFound, err)
+			}
+			tokens, _ = scenario.Store.GetExternalEndpointTokens(externalEndpoint.Key())
+			if tokens[0].RevokedAt == nil || tokens[0].IsActive() || tokens[1].RevokedAt != nil {
+				t.Errorf("expected only the first token to be revoked, got %+v and %+v", tokens[0], tokens[1])
+			}
+			scenario.Store.Clear()
+			if tokens, _ = scenario.Store.GetExternalEndpointTokens(externalEndpoint.Key()); len(tokens) != 0 {
+				t.Errorf("expected tokens to be cleared, got %v", tokens)
+			}
+		})
+	}
+}
+
+func TestStore_ConfigurationSnapshots(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_ConfigurationSnapshots")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var versions []int64
+			for i := 0; i < common.MaximumNumberOfConfigurationSnapshots+2; i++ {
+				configurationSnapshot := snapshot.New([]byte(fmt.Sprintf("endpoints:\n  - name: endpoint-%d", i)), snapshot.SourceFile, 0)
+				if err := scenario.Store.InsertConfigurationSnapshot(configurationSnapshot); err != nil {
+					t.Fatal("expected no error, got", err)
+				}
+				if len(versions) > 0 && configurationSnapshot.Version <= versions[len(versions)-1] {
+					t.Fatalf("expected increasing versions, got %d after %d", configurationSnapshot.Version, versions[len(versions)-1])
+				}
+				versions = append(versions, configurationSnapshot.Version)
+			}
+			rollback := snapshot.New([]byte("endpoints:\n  - name: endpoint-5"), snapshot.SourceRollback, versions[5])
+			if err := scenario.Store.InsertConfigurationSnapshot(rollback); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			snapshots, err := scenario.Store.GetConfigurationSnapshots()
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if len(snapshots) != common.MaximumNumberOfConfigurationSnapshots {
+				t.Fatalf("expected only the last %d snapshots to be kept, got %d", common.MaximumNumberOfConfigurationSnapshots, len(snapshots))
+			}
+			if snapshots[0].Version != rollback.Version || snapshots[0].Source != snapshot.SourceRollback || snapshots[0].RollbackOf != versions[5] || !snapshots[0].AppliedAt.Equal(rollback.AppliedAt) {
+				t.Errorf("expected the most recent snapshot to be the rollback, got %+v", snapshots[0])
+			}
+			if snapshots[len(snapshots)-1].Version != versions[3] {
+				t.Errorf("expected the least recent snapshot to be version %d, got %d", versions[3], snapshots[len(snapshots)-1].Version)
+			}
+			if _, err := scenario.Store.GetConfigurationSnapshot(versions[2]); !errors.Is(err, common.ErrConfigurationSnapshotNotFound) {
+				t.Errorf("expected error %v for a snapshot that was deleted, got %v", common.ErrConfigurationSnapshotNotFound, err)
+			}
+			configurationSnapshot, err := scenario.Store.GetConfigurationSnapshot(versions[5])
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if string(configurationSnapshot.Data) != "endpoints:\n  - name: endpoint-5" || configurationSnapshot.Checksum != rollback.Checksum || configurationSnapshot.Source != snapshot.SourceFile {
+				t.Errorf("expected the configuration of version %d to be persisted, got %+v", versions[5], configurationSnapshot)
+			}
+			scenario.Store.Clear()
+			if snapshots, _ = scenario.Store.GetConfigurationSnapshots(); len(snapshots) != 0 {
+				t.Errorf("expected snapshots to be cleared, got %v", snapshots)
+			}
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	defaultStore, _ := memory.NewStore()
+	euStore, err := sql.NewStore("sqlite", t.TempDir()+"/TestRouter.db", false)
+	if err != nil {
+		t.Fatal("failed to create store:", err.Error())
+	}
+	router := NewRouter(defaultStore, map[string]Store{"eu-core": euStore, "eu-edge": euStore})
+	defer router.Close()
+	if len(router.stores) != 2 {
+		t.Fatalf("expected 2 distinct stores, got %d", len(router.stores))
+	}
+	defaultEndpoint := testEndpoint
+	euEndpoint := testEndpoint
+	euEndpoint.Group = "eu-core"
+	if err := router.Insert(&defaultEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := router.Insert(&euEndpoint, &testUnsuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Each endpoint should only have been persisted in the store its group is routed to
+	if _, err := defaultStore.GetEndpointStatusByKey(euEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected endpoint in routed group to not be in the default store, got %v", err)
+	}
+	if _, err := euStore.GetEndpointStatusByKey(defaultEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected endpoint in non-routed group to not be in the routed store, got %v", err)
+	}
+	// Reads should transparently go through all stores
+	endpointStatuses, err := router.GetAllEndpointStatuses(paging.NewEndpointStatusParams())
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatuses) != 2 || endpointStatuses[0].Key != euEndpoint.Key() || endpointStatuses[1].Key != defaultEndpoint.Key() {
+		t.Errorf("expected the statuses of both endpoints sorted by key, got %d statuses", len(endpointStatuses))
+	}
+	status, err := router.GetEndpointStatusByKey(euEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(status.Results) != 1 || status.Results[0].Success {
+		t.Error("expected the unsuccessful result of the endpoint in the routed group")
+	}
+	if _, err := router.GetEndpointStatus(euEndpoint.Group, euEndpoint.Name, paging.NewEndpointStatusParams()); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if uptime, _ := router.GetUptimeByKey(defaultEndpoint.Key(), time.Now().Add(-24*time.Hour), time.Now()); uptime != 1 {
+		t.Errorf("expected uptime of endpoint in the default store to be 1, got %f", uptime)
+	}
+	if _, err := router.GetUptimeByKey("missing", time.Now().Add(-24*time.Hour), time.Now()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrEndpointNotFound, err)
+	}
+	// The alerting state should be persisted in the store the group of the endpoint is routed to
+	euEndpoint.NumberOfFailuresInARow = 2
+	if err := router.UpsertEndpointAlertingState(&euEndpoint); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if state, _ := euStore.GetEndpointAlertingState(&euEndpoint); state == nil || state.NumberOfFailuresInARow != 2 {
+		t.Error("expected the alerting state to have been persisted in the routed store")
+	}
+	// Lookups by key should go through the route of the group of the endpoint rather than to the first store that has
+	// the key, which may have stale data from before the group was routed
+	if err := defaultStore.Insert(&euEndpoint, &testSuccessfulResult); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if uptime, _ := router.GetUptimeByKey(euEndpoint.Key(), time.Now().Add(-24*time.Hour), time.Now()); uptime != 0 {
+		t.Errorf("expected uptime of endpoint in the routed store to be 0, got %f", uptime)
+	}
+	defaultStore.DeleteAllEndpointStatusesNotInKeys([]string{defaultEndpoint.Key()})
+	// Alert deliveries and external endpoint tokens should be persisted in the store the group of their endpoint is
+	// routed to, and the IDs of alert deliveries should be unique across stores
+	defaultDelivery := delivery.NewDelivery(&defaultEndpoint, &alert.Alert{Type: alert.TypeSlack}, &testUnsuccessfulResult, false)
+	euDelivery := delivery.NewDelivery(&euEndpoint, &alert.Alert{Type: alert.TypeSlack}, &testUnsuccessfulResult, false)
+	if err := router.InsertAlertDelivery(defaultDelivery); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := router.InsertAlertDelivery(euDelivery); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if euDeliveries, _ := euStore.GetAlertDeliveries(); len(euDeliveries) != 1 || euDeliveries[0].EndpointKey != euEndpoint.Key() {
+		t.Errorf("expected the alert delivery to have been persisted in the routed store, got %v", euDeliveries)
+	}
+	if defaultDelivery.ID == euDelivery.ID {
+		t.Errorf("expected alert deliveries in different stores to have different IDs, got %d", defaultDelivery.ID)
+	}
+	if d, err := router.GetAlertDeliveryByID(euDelivery.ID); err != nil || d.EndpointKey != euEndpoint.Key() || d.ID != euDelivery.ID {
+		t.Errorf("expected the alert delivery of the endpoint in the routed group, got %v (%v)", d, err)
+	}
+	if deliveries, _ := router.GetAlertDeliveries(); len(deliveries) != 2 {
+		t.Errorf("expected 2 alert deliveries, got %d", len(deliveries))
+	}
+	if err := router.DeleteAlertDelivery(euDelivery.ID); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if deliveries, _ := router.GetAlertDeliveries(); len(deliveries) != 1 || deliveries[0].ID != defaultDelivery.ID {
+		t.Errorf("expected only the alert delivery of the endpoint in the default store to be left, got %v", deliveries)
+	}
+	// The same goes for the entries of the alert history
+	defaultEntry := history.NewEntry(&defaultEndpoint, &alert.Alert{Type: alert.TypeSlack}, time.Now().Add(-time.Hour), history.DeliverySummary{Status: history.DeliveryStatusSent})
+	euEntry := history.NewEntry(&euEndpoint, &alert.Alert{Type: alert.TypeSlack}, time.Now(), history.DeliverySummary{Status: history.DeliveryStatusSent})
+	if err := router.InsertAlertHistoryEntry(defaultEntry); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := router.InsertAlertHistoryEntry(euEntry); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if defaultEntry.ID == euEntry.ID {
+		t.Errorf("expected alert history entries in different stores to have different IDs, got %d", defaultEntry.ID)
+	}
+	if euEntries, _ := euStore.GetAlertHistory(time.Time{}, time.Time{}); len(euEntries) != 1 || euEntries[0].EndpointKey != euEndpoint.Key() {
+		t.Errorf("expected the alert history entry to have been persisted in the routed store, got %v", euEntries)
+	}
+	euEntry.Resolve(time.Now(), nil)
+	if err := router.UpdateAlertHistoryEntry(euEntry); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if e, err := router.GetAlertHistoryEntryByID(euEntry.ID); err != nil || e.ResolvedAt == nil || e.ID != euEntry.ID {
+		t.Errorf("expected the resolved alert history entry of the endpoint in the routed group, got %v (%v)", e, err)
+	}
+	if e, err := router.GetUnresolvedAlertHistoryEntry(defaultEndpoint.Key(), defaultEntry.AlertChecksum); err != nil || e.ID != defaultEntry.ID {
+		t.Errorf("expected the unresolved alert history entry of the endpoint in the default store, got %v (%v)", e, err)
+	}
+	if entries, _ := router.GetAlertHistory(time.Time{}, time.Time{}); len(entries) != 2 || entries[0].ID != euEntry.ID || entries[1].ID != defaultEntry.ID {
+		t.Errorf("expected both alert history entries from the most recently triggered, got %v", entries)
+	}
+	if err := router.DeleteAlertHistoryEntry(euEntry.ID); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	euExternalEndpoint := &endpoint.ExternalEndpoint{Name: "ext", Group: "EU Core"}
+	_, token, _ := endpoint.NewExternalEndpointToken(euExternalEndpoint, "agent", 0)
+	if err := router.InsertExternalEndpointToken(token); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if tokens, _ := euStore.GetExternalEndpointTokens(euExternalEndpoint.Key()); len(tokens) != 1 {
+		t.Errorf("expected the token to have been persisted in the routed store, got %v", tokens)
+	}
+	if tokens, _ := router.GetExternalEndpointTokens(euExternalEndpoint.Key()); len(tokens) != 1 {
+		t.Errorf("expected 1 token, got %v", tokens)
+	}
+	// Deleting endpoint statuses should apply to every store
+	if deleted := router.DeleteAllEndpointStatusesNotInKeys([]string{defaultEndpoint.Key()}); deleted != 1 {
+		t.Errorf("expected 1 endpoint status to have been deleted, got %d", deleted)
+	}
+	if _, err := router.GetEndpointStatusByKey(euEndpoint.Key(), paging.NewEndpointStatusParams()); !errors.Is(err, common.ErrEndpointNotFound) {
+		t.Errorf("expected %v, got %v", common.ErrEndpointNotFound, err)
+	}
+}
+
 func TestGet(t *testing.T) {
 	store := Get()
 	if store == nil {
@@ -610,6 +1283,20 @@ func TestInitialize(t *testing.T) {
 			Cfg:         &storage.Config{Type: storage.TypeSQLite, Path: filepath.Join(dir, "TestInitialize_sqlite-with-path.db")},
 			ExpectedErr: nil,
 		},
+		{
+			Name: "sqlite-with-routes",
+			Cfg: &storage.Config{Type: storage.TypeSQLite, Path: filepath.Join(dir, "TestInitialize_sqlite-with-routes.db"), Routes: []*storage.RouteConfig{
+				{Groups: []string{"eu"}, Config: storage.Config{Type: storage.TypeSQLite, Path: filepath.Join(dir, "TestInitialize_sqlite-with-routes-eu.db")}},
+			}},
+			ExpectedErr: nil,
+		},
+		{
+			Name: "sqlite-with-route-without-path",
+			Cfg: &storage.Config{Type: storage.TypeSQLite, Path: filepath.Join(dir, "TestInitialize_sqlite-with-route-without-path.db"), Routes: []*storage.RouteConfig{
+				{Groups: []string{"eu"}, Config: storage.Config{Type: storage.TypeSQLite}},
+			}},
+			ExpectedErr: sql.ErrPathNotSpecified,
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -4,67 +4,177 @@ import (
 	"errors"
 	"log"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/alerting/silence"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/events"
+	"github.com/TwiN/gatus/v5/loganalytics"
 	"github.com/TwiN/gatus/v5/storage/store"
 )
 
+// alertingStateMutex guards the alerting state of endpoints and of their alerts (e.g. NumberOfFailuresInARow,
+// Triggered, ResolveKey) when alert delivery is configured, since queued alerts are delivered by another goroutine
+// than the one monitoring the endpoint
+var alertingStateMutex sync.RWMutex
+
+var (
+	// gracePeriodEndsAt is the time until which alerts are withheld, which is set every time Gatus starts monitoring
+	gracePeriodEndsAt      time.Time
+	gracePeriodEndsAtMutex sync.RWMutex
+)
+
+// startGracePeriod withholds alerts for the duration passed, starting now
+func startGracePeriod(gracePeriod time.Duration) {
+	gracePeriodEndsAtMutex.Lock()
+	defer gracePeriodEndsAtMutex.Unlock()
+	gracePeriodEndsAt = time.Now().Add(gracePeriod)
+}
+
+// isInGracePeriod returns whether alerts are currently withheld by the grace period
+func isInGracePeriod() bool {
+	gracePeriodEndsAtMutex.RLock()
+	defer gracePeriodEndsAtMutex.RUnlock()
+	return time.Now().Before(gracePeriodEndsAt)
+}
+
+// lockAlertingState locks the alerting state if alert delivery is configured and returns the function to unlock it
+func lockAlertingState(alertingConfig *alerting.Config) (unlock func()) {
+	if alertingConfig.Delivery == nil {
+		return func() {}
+	}
+	alertingStateMutex.Lock()
+	return alertingStateMutex.Unlock
+}
+
 // HandleAlerting takes care of alerts to resolve and alerts to trigger based on result success or failure
 func HandleAlerting(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
 	if alertingConfig == nil {
 		return
 	}
+	defer lockAlertingState(alertingConfig)()
+	handleAlerting(ep, result, alertingConfig, debug)
+}
+
+// HandleExternalEndpointAlerting takes care of alerts to resolve and alerts to trigger for an external endpoint, whose
+// alerting state is kept by the external endpoint rather than by the endpoint it is converted to
+func HandleExternalEndpointAlerting(externalEndpoint *endpoint.ExternalEndpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
+	if alertingConfig == nil {
+		return
+	}
+	defer lockAlertingState(alertingConfig)()
+	convertedEndpoint := externalEndpoint.ToEndpoint()
+	handleAlerting(convertedEndpoint, result, alertingConfig, debug)
+	externalEndpoint.NumberOfSuccessesInARow = convertedEndpoint.NumberOfSuccessesInARow
+	externalEndpoint.NumberOfFailuresInARow = convertedEndpoint.NumberOfFailuresInARow
+	externalEndpoint.LastSuccessTimestamp = convertedEndpoint.LastSuccessTimestamp
+	externalEndpoint.DownSince = convertedEndpoint.DownSince
+}
+
+func handleAlerting(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
 	if result.Success {
 		handleAlertsToResolve(ep, result, alertingConfig, debug)
 	} else {
 		handleAlertsToTrigger(ep, result, alertingConfig, debug)
 	}
+	handleSLOBurnRateAlerts(ep, result, alertingConfig, debug)
+	if len(ep.Alerts) > 0 {
+		// Persist the alerting state so that restarting in the middle of an incident doesn't reset it
+		if err := store.Get().UpsertEndpointAlertingState(ep); err != nil {
+			log.Printf("[watchdog.HandleAlerting] Failed to persist alerting state for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+	}
 }
 
 func handleAlertsToTrigger(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
 	ep.NumberOfSuccessesInARow = 0
 	ep.NumberOfFailuresInARow++
+	if ep.LastSuccessTimestamp.IsZero() {
+		// The endpoint hasn't been successful since the application started, so we'll use the first failure instead
+		ep.LastSuccessTimestamp = getResultTimestamp(result)
+	}
+	if ep.DownSince.IsZero() {
+		ep.DownSince = getResultTimestamp(result)
+	}
+	timeSinceLastSuccess := getResultTimestamp(result).Sub(ep.LastSuccessTimestamp)
 	for _, endpointAlert := range ep.Alerts {
 		// If the alert hasn't been triggered, move to the next one
-		if !endpointAlert.IsEnabled() || endpointAlert.FailureThreshold > ep.NumberOfFailuresInARow {
+		if !endpointAlert.IsEnabled() || !endpointAlert.ShouldBeTriggeredWithFailureThreshold(ep.NumberOfFailuresInARow, timeSinceLastSuccess, ep.FailureThresholdOf(endpointAlert)) {
 			continue
 		}
-		if endpointAlert.Triggered {
-			if debug {
-				log.Printf("[watchdog.handleAlertsToTrigger] Alert for endpoint=%s with description='%s' has already been TRIGGERED, skipping", ep.Name, endpointAlert.GetDescription())
+		triggerAlert(ep, endpointAlert, result, alertingConfig, debug)
+	}
+}
+
+// triggerAlert sends an alert whose trigger condition has been met, unless it has already been triggered or the
+// alerts of the endpoint are silenced
+func triggerAlert(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
+	if endpointAlert.Triggered {
+		if debug {
+			log.Printf("[watchdog.handleAlertsToTrigger] Alert for endpoint=%s with description='%s' has already been TRIGGERED, skipping", ep.Name, endpointAlert.GetDescription())
+		}
+		return
+	}
+	// The alert isn't marked as triggered, so that it is triggered once the silence expires if the endpoint is
+	// still unhealthy
+	if silence.IsSilenced(ep.Key()) {
+		if debug {
+			log.Printf("[watchdog.handleAlertsToTrigger] Not sending alert for endpoint=%s with description='%s' because its alerts are silenced", ep.Name, endpointAlert.GetDescription())
+		}
+		return
+	}
+	// Same goes for the grace period, so that the alert is triggered once it ends if the endpoint is still unhealthy
+	if isInGracePeriod() {
+		if debug {
+			log.Printf("[watchdog.handleAlertsToTrigger] Not sending alert for endpoint=%s with description='%s' because of the grace period", ep.Name, endpointAlert.GetDescription())
+		}
+		return
+	}
+	alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
+	if alertProvider != nil {
+		log.Printf("[watchdog.handleAlertsToTrigger] Sending %s alert because alert for endpoint=%s with description='%s' has been TRIGGERED", endpointAlert.Type, ep.Name, endpointAlert.GetDescription())
+		var err error
+		if alertingConfig.Delivery != nil {
+			// The alert is marked as triggered as soon as it is queued, since the queue takes care of retrying
+			err = queueTriggeredAlertDelivery(ep, endpointAlert, result)
+		} else if os.Getenv("MOCK_ALERT_PROVIDER") == "true" {
+			if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
+				err = errors.New("error")
 			}
-			continue
+		} else {
+			err = alertProvider.Send(ep, endpointAlert, result, false)
 		}
-		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
-		if alertProvider != nil {
-			log.Printf("[watchdog.handleAlertsToTrigger] Sending %s alert because alert for endpoint=%s with description='%s' has been TRIGGERED", endpointAlert.Type, ep.Name, endpointAlert.GetDescription())
-			var err error
-			if os.Getenv("MOCK_ALERT_PROVIDER") == "true" {
-				if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
-					err = errors.New("error")
-				}
-			} else {
-				err = alertProvider.Send(ep, endpointAlert, result, false)
+		if err != nil {
+			log.Printf("[watchdog.handleAlertsToTrigger] Failed to send an alert for endpoint=%s: %s", ep.Name, err.Error())
+		} else {
+			endpointAlert.Triggered = true
+			if alertingConfig.Delivery == nil {
+				recordTriggeredAlert(ep, endpointAlert, result, history.DeliverySummary{Status: history.DeliveryStatusSent, Attempts: 1})
 			}
-			if err != nil {
-				log.Printf("[watchdog.handleAlertsToTrigger] Failed to send an alert for endpoint=%s: %s", ep.Name, err.Error())
-			} else {
-				endpointAlert.Triggered = true
-				if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
-					log.Printf("[watchdog.handleAlertsToTrigger] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
-				}
+			loganalytics.PublishAlertEvent(ep, endpointAlert, false)
+			events.PublishAlertEvent(ep, endpointAlert, result, false)
+			if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
+				log.Printf("[watchdog.handleAlertsToTrigger] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
 			}
-		} else {
-			log.Printf("[watchdog.handleAlertsToTrigger] Not sending alert of type=%s despite being TRIGGERED, because the provider wasn't configured properly", endpointAlert.Type)
 		}
+	} else {
+		log.Printf("[watchdog.handleAlertsToTrigger] Not sending alert of type=%s despite being TRIGGERED, because the provider wasn't configured properly", endpointAlert.Type)
 	}
 }
 
 func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
 	ep.NumberOfSuccessesInARow++
+	ep.LastSuccessTimestamp = getResultTimestamp(result)
 	for _, endpointAlert := range ep.Alerts {
-		isStillBelowSuccessThreshold := endpointAlert.SuccessThreshold > ep.NumberOfSuccessesInARow
+		if endpointAlert.IsTriggeredBySLOBurnRate() {
+			// Alerts triggered by the burn rate are resolved by handleSLOBurnRateAlerts instead
+			continue
+		}
+		isStillBelowSuccessThreshold := ep.SuccessThresholdOf(endpointAlert) > ep.NumberOfSuccessesInARow
 		if isStillBelowSuccessThreshold && endpointAlert.IsEnabled() && endpointAlert.Triggered {
 			// Persist NumberOfSuccessesInARow
 			if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
@@ -74,25 +184,112 @@ func handleAlertsToResolve(ep *endpoint.Endpoint, result *endpoint.Result, alert
 		if !endpointAlert.IsEnabled() || !endpointAlert.Triggered || isStillBelowSuccessThreshold {
 			continue
 		}
-		// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
-		// Further explanation can be found on Alert's Triggered field.
-		endpointAlert.Triggered = false
-		if err := store.Get().DeleteTriggeredEndpointAlert(ep, endpointAlert); err != nil {
-			log.Printf("[watchdog.handleAlertsToResolve] Failed to delete persisted triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		resolveAlert(ep, endpointAlert, result, alertingConfig)
+	}
+	ep.NumberOfFailuresInARow = 0
+	// The outage is only over once none of the alerts are triggered anymore, which is also why this is done after
+	// sending the resolutions, since they may include for how long the endpoint was down. Alerts triggered by the burn
+	// rate don't count, since the endpoint may be healthy while its error budget is consumed.
+	hasTriggeredAlert := false
+	for _, endpointAlert := range ep.Alerts {
+		if endpointAlert.IsEnabled() && endpointAlert.Triggered && !endpointAlert.IsTriggeredBySLOBurnRate() {
+			hasTriggeredAlert = true
+			break
+		}
+	}
+	if !hasTriggeredAlert {
+		ep.DownSince = time.Time{}
+	}
+}
+
+// resolveAlert marks a triggered alert as resolved and sends its resolution if the alert is configured to
+func resolveAlert(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config) {
+	// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
+	// Further explanation can be found on Alert's Triggered field.
+	endpointAlert.Triggered = false
+	loganalytics.PublishAlertEvent(ep, endpointAlert, true)
+	events.PublishAlertEvent(ep, endpointAlert, result, true)
+	historyEntry := getUnresolvedAlertHistoryEntry(ep, endpointAlert)
+	var resolvedDelivery *history.DeliverySummary
+	if endpointAlert.IsSendingOnResolved() {
+		if silence.IsSilenced(ep.Key()) {
+			log.Printf("[watchdog.handleAlertsToResolve] Not sending resolution of alert for endpoint with key=%s with description='%s' because its alerts are silenced", ep.Key(), endpointAlert.GetDescription())
+			resolvedDelivery = &history.DeliverySummary{Status: history.DeliveryStatusSilenced}
+		} else {
+			var historyEntryID int64
+			if historyEntry != nil {
+				historyEntryID = historyEntry.ID
+			}
+			resolvedDelivery = sendResolvedAlert(ep, endpointAlert, result, alertingConfig, historyEntryID)
+		}
+	}
+	recordResolvedAlert(historyEntry, result, resolvedDelivery)
+	// The persisted triggered alert is only deleted once the resolution has been sent, so that if the application
+	// stops before that, the alert is restored as triggered and the resolution is sent after the restart
+	if err := store.Get().DeleteTriggeredEndpointAlert(ep, endpointAlert); err != nil {
+		log.Printf("[watchdog.handleAlertsToResolve] Failed to delete persisted triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+	}
+}
+
+// handleSLOBurnRateAlerts triggers the alerts of the endpoint that are based on the burn rate of the error budget of its
+// service level objective once the burn rate exceeds their threshold, and resolves them once it no longer does.
+//
+// Unlike other alerts, these are evaluated after every result, whether it is successful or not, since the error budget
+// may be consumed by successful results that exceed the response time objective.
+func handleSLOBurnRateAlerts(ep *endpoint.Endpoint, result *endpoint.Result, alertingConfig *alerting.Config, debug bool) {
+	if ep.SLOConfig == nil {
+		return
+	}
+	now := getResultTimestamp(result)
+	for _, endpointAlert := range ep.Alerts {
+		if !endpointAlert.IsEnabled() || !endpointAlert.IsTriggeredBySLOBurnRate() {
+			continue
 		}
-		if !endpointAlert.IsSendingOnResolved() {
+		statistics, err := store.Get().GetServiceLevelStatisticsByKey(ep.Key(), now.Add(-endpointAlert.SLOBurnRateLookback()), now)
+		if err != nil {
+			log.Printf("[watchdog.handleSLOBurnRateAlerts] Failed to retrieve service level statistics for endpoint with key=%s: %s", ep.Key(), err.Error())
 			continue
 		}
-		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
-		if alertProvider != nil {
-			log.Printf("[watchdog.handleAlertsToResolve] Sending %s alert because alert for endpoint with key=%s with description='%s' has been RESOLVED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
-			err := alertProvider.Send(ep, endpointAlert, result, true)
-			if err != nil {
-				log.Printf("[watchdog.handleAlertsToResolve] Failed to send an alert for endpoint with key=%s: %s", ep.Key(), err.Error())
-			}
-		} else {
-			log.Printf("[watchdog.handleAlertsToResolve] Not sending alert of type=%s despite being RESOLVED, because the provider wasn't configured properly", endpointAlert.Type)
+		burnRate := ep.SLOConfig.BurnRate(statistics.TotalEvents, statistics.GoodEvents)
+		if debug {
+			log.Printf("[watchdog.handleSLOBurnRateAlerts] Burn rate of endpoint with key=%s over the last %s is %.2f", ep.Key(), endpointAlert.SLOBurnRateLookback(), burnRate)
+		}
+		if endpointAlert.ShouldBeTriggeredBySLOBurnRate(burnRate) {
+			triggerAlert(ep, endpointAlert, result, alertingConfig, debug)
+		} else if endpointAlert.Triggered {
+			resolveAlert(ep, endpointAlert, result, alertingConfig)
 		}
 	}
-	ep.NumberOfFailuresInARow = 0
+}
+
+// sendResolvedAlert sends the resolution of an alert, or queues it if alert delivery is configured, and returns the
+// summary of its delivery for the alert history
+func sendResolvedAlert(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, alertingConfig *alerting.Config, historyEntryID int64) *history.DeliverySummary {
+	alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
+	if alertProvider == nil {
+		log.Printf("[watchdog.handleAlertsToResolve] Not sending alert of type=%s despite being RESOLVED, because the provider wasn't configured properly", endpointAlert.Type)
+		return &history.DeliverySummary{Status: history.DeliveryStatusFailed, LastError: ErrAlertDeliveryProviderNotFound.Error()}
+	}
+	log.Printf("[watchdog.handleAlertsToResolve] Sending %s alert because alert for endpoint with key=%s with description='%s' has been RESOLVED", endpointAlert.Type, ep.Key(), endpointAlert.GetDescription())
+	var err error
+	deliverySummary := &history.DeliverySummary{Status: history.DeliveryStatusSent, Attempts: 1}
+	if alertingConfig.Delivery != nil {
+		err = queueAlertDelivery(ep, endpointAlert, result, true, historyEntryID)
+		deliverySummary = &history.DeliverySummary{Status: history.DeliveryStatusQueued}
+	} else {
+		err = alertProvider.Send(ep, endpointAlert, result, true)
+	}
+	if err != nil {
+		log.Printf("[watchdog.handleAlertsToResolve] Failed to send an alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		deliverySummary.Status, deliverySummary.LastError = history.DeliveryStatusFailed, err.Error()
+	}
+	return deliverySummary
+}
+
+// getResultTimestamp returns the timestamp of the result, or the current time if the result has no timestamp
+func getResultTimestamp(result *endpoint.Result) time.Time {
+	if result.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return result.Timestamp
 }
@@ -3,24 +3,30 @@ package watchdog
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
+	"github.com/TwiN/gatus/v5/alerting/provider/fcm"
 	"github.com/TwiN/gatus/v5/alerting/provider/jetbrainsspace"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
+	"github.com/TwiN/gatus/v5/alerting/provider/mqtt"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/silence"
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/endpoint/slo"
+	"github.com/TwiN/gatus/v5/storage/store"
 )
 
 func TestHandleAlerting(t *testing.T) {
@@ -282,6 +288,16 @@ func TestHandleAlertingWithProviderThatReturnsAnError(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:      "fcm",
+			AlertType: alert.TypeFCM,
+			AlertingConfig: &alerting.Config{
+				FCM: &fcm.AlertProvider{
+					ServerKey: "server-key",
+					Topic:     "gatus",
+				},
+			},
+		},
 		{
 			Name:      "jetbrainsspace",
 			AlertType: alert.TypeJetBrainsSpace,
@@ -313,6 +329,15 @@ func TestHandleAlertingWithProviderThatReturnsAnError(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:      "mqtt",
+			AlertType: alert.TypeMQTT,
+			AlertingConfig: &alerting.Config{
+				MQTT: &mqtt.AlertProvider{
+					BrokerURL: "tcp://127.0.0.1:1883",
+				},
+			},
+		},
 		{
 			Name:      "pagerduty",
 			AlertType: alert.TypePagerDuty,
@@ -487,6 +512,217 @@ func TestHandleAlertingWithProviderThatOnlyReturnsErrorOnResolve(t *testing.T) {
 	verify(t, ep, 0, 2, false, "")
 }
 
+func TestHandleAlertingWithTriggerIfLastSuccessOlderThan(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				TriggerIf:        "last-success-older-than 15m",
+			},
+		},
+	}
+	if err := ep.Alerts[0].ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	start := time.Now()
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: start}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert shouldn't start triggered")
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start.Add(5 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have triggered, because the last success is only 5m old, despite the failure threshold being 1")
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start.Add(10 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 2, 0, false, "The alert shouldn't have triggered, because the last success is only 10m old")
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start.Add(15 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 3, 0, true, "The alert should've triggered, because the last success is 15m old")
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: start.Add(20 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved")
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start.Add(30 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have triggered, because the last success is only 10m old")
+}
+
+func TestHandleAlertingWithTriggerIfLastSuccessOlderThanAndNoPreviousSuccess(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	ep := &endpoint.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:      alert.TypeCustom,
+				TriggerIf: "last-success-older-than 1h",
+			},
+		},
+	}
+	if err := ep.Alerts[0].ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	start := time.Now()
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have triggered, because the endpoint has only been failing for 0s")
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start.Add(59 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 2, 0, false, "The alert shouldn't have triggered, because the endpoint has only been failing for 59m")
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: start.Add(time.Hour)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 3, 0, true, "The alert should've triggered, because the endpoint has been failing for 1h")
+}
+
+func TestHandleAlertingWithTriggerIfSLOBurnRateAbove(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	ep := &endpoint.Endpoint{
+		Name:      "website",
+		URL:       "https://example.com",
+		SLOConfig: &slo.Config{Objective: 90},
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				TriggerIf:        "slo-burn-rate-above 5",
+			},
+		},
+	}
+	if err := ep.Alerts[0].ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err := ep.SLOConfig.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	evaluate := func(result *endpoint.Result) {
+		result.Timestamp = time.Now()
+		UpdateEndpointStatuses(ep, result)
+		HandleAlerting(ep, result, cfg.Alerting, cfg.Debug)
+	}
+	evaluate(&endpoint.Result{Success: true})
+	evaluate(&endpoint.Result{Success: true})
+	verify(t, ep, 0, 2, false, "The alert shouldn't start triggered")
+	evaluate(&endpoint.Result{Success: false})
+	verify(t, ep, 1, 0, false, "The alert shouldn't have triggered, because the burn rate is 3.33 despite the failure threshold being 1")
+	evaluate(&endpoint.Result{Success: false})
+	verify(t, ep, 2, 0, false, "The alert shouldn't have triggered, because the burn rate is 5")
+	evaluate(&endpoint.Result{Success: false})
+	verify(t, ep, 3, 0, true, "The alert should've triggered, because the burn rate is 6")
+	evaluate(&endpoint.Result{Success: true, ExceededResponseTimeObjective: true})
+	verify(t, ep, 0, 1, true, "The alert should still be triggered despite the success threshold being 1, because the burn rate is 6.67")
+	evaluate(&endpoint.Result{Success: true})
+	verify(t, ep, 0, 2, true, "The alert should still be triggered, because the burn rate is 5.71")
+	evaluate(&endpoint.Result{Success: true})
+	verify(t, ep, 0, 3, false, "The alert should've been resolved, because the burn rate is 5")
+}
+
+func TestHandleAlertingWithOverriddenThresholds(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 3,
+				SuccessThreshold: 3,
+				SendOnResolved:   &enabled,
+			},
+		},
+	}
+	ep.SetOverride(&endpoint.Override{FailureThreshold: 1, SuccessThreshold: 1, ExpiresAt: time.Now().Add(time.Hour)})
+
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, true, "The alert should've been triggered, because the failure threshold was overridden to 1")
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved, because the success threshold was overridden to 1")
+	ep.ClearOverride()
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered, because the override was cleared")
+}
+
+func TestHandleAlertingTracksDownSince(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	ep := &endpoint.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 2,
+				SuccessThreshold: 2,
+				SendOnResolved:   &enabled,
+			},
+		},
+	}
+	firstFailure := time.Now().Add(-time.Hour)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: firstFailure}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: firstFailure.Add(time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 2, 0, true, "The alert should've triggered")
+	if !ep.DownSince.Equal(firstFailure) {
+		t.Errorf("expected DownSince to be the timestamp of the first failure, got %s", ep.DownSince)
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: firstFailure.Add(2 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: firstFailure.Add(3 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	if !ep.DownSince.Equal(firstFailure) {
+		t.Errorf("expected DownSince to be unchanged, because the alert was never resolved, got %s", ep.DownSince)
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: firstFailure.Add(4 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: firstFailure.Add(5 * time.Minute)}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 2, false, "The alert should've been resolved")
+	if !ep.DownSince.IsZero() {
+		t.Errorf("expected DownSince to have been reset once the alert was resolved, got %s", ep.DownSince)
+	}
+}
+
 func verify(t *testing.T, ep *endpoint.Endpoint, expectedNumberOfFailuresInARow, expectedNumberOfSuccessInARow int, expectedTriggered bool, expectedTriggeredReason string) {
 	if ep.NumberOfFailuresInARow != expectedNumberOfFailuresInARow {
 		t.Errorf("endpoint.NumberOfFailuresInARow should've been %d, got %d", expectedNumberOfFailuresInARow, ep.NumberOfFailuresInARow)
@@ -506,3 +742,54 @@ func verify(t *testing.T, ep *endpoint.Endpoint, expectedNumberOfFailuresInARow,
 		}
 	}
 }
+
+func TestHandleAlertingWhileSilenced(t *testing.T) {
+	defer silence.Clear()
+	server, receivedPaths := newAlertReceiver(t, 0)
+	enabled := true
+	alertingConfig := &alerting.Config{Custom: &custom.AlertProvider{URL: server.URL + "/[ALERT_TRIGGERED_OR_RESOLVED]", Method: "POST"}}
+	ep := &endpoint.Endpoint{
+		Name: "frontend",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+		},
+	}
+	s, _ := silence.NewSilence(ep.Key(), time.Hour, "investigating")
+	silence.Set(s)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig, true)
+	verify(t, ep, 1, 0, false, "The alert shouldn't have been triggered, because the endpoint is silenced")
+	silence.Delete(ep.Key())
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig, true)
+	verify(t, ep, 2, 0, true, "The alert should've been triggered once the silence was removed")
+	silence.Set(s)
+	HandleAlerting(ep, &endpoint.Result{Success: true}, alertingConfig, true)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved despite the silence")
+	if paths := receivedPaths(); len(paths) != 1 || paths[0] != "/TRIGGERED" {
+		t.Errorf("expected only the alert triggered while the endpoint wasn't silenced to be sent, got %v", paths)
+	}
+}
+
+func TestHandleAlertingDuringGracePeriod(t *testing.T) {
+	defer startGracePeriod(0)
+	server, receivedPaths := newAlertReceiver(t, 0)
+	enabled := true
+	alertingConfig := &alerting.Config{Custom: &custom.AlertProvider{URL: server.URL + "/[ALERT_TRIGGERED_OR_RESOLVED]", Method: "POST"}}
+	ep := &endpoint.Endpoint{
+		Name: "frontend",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 2, SuccessThreshold: 1, SendOnResolved: &enabled},
+		},
+	}
+	startGracePeriod(time.Hour)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig, true)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig, true)
+	verify(t, ep, 2, 0, false, "The alert shouldn't have been triggered, because of the grace period")
+	startGracePeriod(0)
+	HandleAlerting(ep, &endpoint.Result{Success: false}, alertingConfig, true)
+	verify(t, ep, 3, 0, true, "The alert should've been triggered once the grace period ended, since the failures during the grace period are accounted for")
+	if paths := receivedPaths(); len(paths) != 1 || paths[0] != "/TRIGGERED" {
+		t.Errorf("expected only the alert triggered after the grace period to be sent, got %v", paths)
+	}
+}
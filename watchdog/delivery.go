@@ -0,0 +1,249 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+// maximumAlertDeliveryPollingInterval is the maximum duration between two checks of the alert delivery queue
+const maximumAlertDeliveryPollingInterval = time.Minute
+
+var (
+	// ErrAlertDeliveryEndpointNotFound is the error with which an alert delivery fails if its endpoint no longer exists
+	ErrAlertDeliveryEndpointNotFound = errors.New("endpoint no longer exists")
+
+	// ErrAlertDeliveryAlertNotFound is the error with which an alert delivery fails if its alert is no longer configured
+	ErrAlertDeliveryAlertNotFound = errors.New("alert is no longer configured")
+
+	// ErrAlertDeliveryProviderNotFound is the error with which an alert delivery fails if the alerting provider of the
+	// alert is not configured properly
+	ErrAlertDeliveryProviderNotFound = errors.New("alerting provider is not configured properly")
+)
+
+// alertDeliveryQueued is used to wake up the goroutine delivering alerts as soon as an alert is queued
+var alertDeliveryQueued = make(chan struct{}, 1)
+
+// queueAlertDelivery persists an alert to be delivered in the background by deliverQueuedAlerts
+func queueAlertDelivery(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, resolved bool, historyEntryID int64) error {
+	d := delivery.NewDelivery(ep, endpointAlert, result, resolved)
+	d.HistoryEntryID = historyEntryID
+	if err := store.Get().InsertAlertDelivery(d); err != nil {
+		return err
+	}
+	notifyAlertDeliveryQueued()
+	return nil
+}
+
+// queueTriggeredAlertDelivery adds a triggered alert to the alert history and queues its delivery.
+//
+// The entry is added first so that the delivery can refer to it, and removed if the delivery can't be queued, since
+// the alert isn't marked as triggered in that case.
+func queueTriggeredAlertDelivery(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result) error {
+	historyEntryID := recordTriggeredAlert(ep, endpointAlert, result, history.DeliverySummary{Status: history.DeliveryStatusQueued})
+	if err := queueAlertDelivery(ep, endpointAlert, result, false, historyEntryID); err != nil {
+		if historyEntryID != 0 {
+			if err := store.Get().DeleteAlertHistoryEntry(historyEntryID); err != nil {
+				log.Printf("[watchdog.queueTriggeredAlertDelivery] Failed to delete the alert history entry with id=%d: %s", historyEntryID, err.Error())
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func notifyAlertDeliveryQueued() {
+	select {
+	case alertDeliveryQueued <- struct{}{}:
+	default:
+		// The goroutine delivering alerts has already been notified
+	}
+}
+
+// RequeueAlertDelivery makes an alert delivery, such as one that has been dead-lettered, ready to be attempted again
+func RequeueAlertDelivery(id int64) (*delivery.Delivery, error) {
+	d, err := store.Get().GetAlertDeliveryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	d.Requeue()
+	if err = store.Get().UpdateAlertDelivery(d); err != nil {
+		return nil, err
+	}
+	notifyAlertDeliveryQueued()
+	return d, nil
+}
+
+// deliverQueuedAlerts delivers the alerts queued until the context passed is cancelled
+func deliverQueuedAlerts(cfg *config.Config, ctx context.Context) {
+	for {
+		wait := maximumAlertDeliveryPollingInterval
+		if nextAttemptAt := deliverDueAlerts(cfg); !nextAttemptAt.IsZero() {
+			wait = min(time.Until(nextAttemptAt), wait)
+		}
+		select {
+		case <-ctx.Done():
+			log.Println("[watchdog.deliverQueuedAlerts] Stopping the delivery of queued alerts")
+			return
+		case <-alertDeliveryQueued:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// deliverDueAlerts attempts to deliver every queued alert whose next attempt is due and returns the time of the
+// earliest attempt that isn't due yet, if any.
+//
+// Alerts of the same endpoint and alert configuration are delivered in the order in which they were queued, so that
+// a resolved alert is never delivered before the triggered alert that preceded it.
+func deliverDueAlerts(cfg *config.Config) (nextAttemptAt time.Time) {
+	deliveries, err := store.Get().GetAlertDeliveries()
+	if err != nil {
+		log.Printf("[watchdog.deliverDueAlerts] Failed to retrieve queued alerts: %s", err.Error())
+		return time.Time{}
+	}
+	blocked := make(map[string]bool)
+	for _, d := range deliveries {
+		if d.DeadLettered {
+			continue
+		}
+		orderingKey := d.EndpointKey + "_" + d.AlertChecksum
+		if blocked[orderingKey] {
+			continue
+		}
+		if time.Now().Before(d.NextAttemptAt) {
+			blocked[orderingKey] = true
+			if nextAttemptAt.IsZero() || d.NextAttemptAt.Before(nextAttemptAt) {
+				nextAttemptAt = d.NextAttemptAt
+			}
+			continue
+		}
+		if err := deliverAlert(cfg, d); err != nil {
+			d.RecordFailure(err, cfg.Alerting.Delivery)
+			deliverySummary := history.DeliverySummary{Status: history.DeliveryStatusQueued, Attempts: d.Attempts, LastError: d.LastError}
+			if d.DeadLettered {
+				deliverySummary.Status = history.DeliveryStatusDeadLettered
+			}
+			recordAlertDelivery(d, deliverySummary)
+			if d.DeadLettered {
+				log.Printf("[watchdog.deliverDueAlerts] Dead-lettering %s alert for endpoint with key=%s after %d failed attempt(s): %s", d.AlertType, d.EndpointKey, d.Attempts, err.Error())
+			} else {
+				log.Printf("[watchdog.deliverDueAlerts] Failed to deliver %s alert for endpoint with key=%s, retrying at %s: %s", d.AlertType, d.EndpointKey, d.NextAttemptAt.Format(time.RFC3339), err.Error())
+				blocked[orderingKey] = true
+				if nextAttemptAt.IsZero() || d.NextAttemptAt.Before(nextAttemptAt) {
+					nextAttemptAt = d.NextAttemptAt
+				}
+			}
+			if err := store.Get().UpdateAlertDelivery(d); err != nil {
+				log.Printf("[watchdog.deliverDueAlerts] Failed to update queued alert with id=%d: %s", d.ID, err.Error())
+			}
+			continue
+		}
+		recordAlertDelivery(d, history.DeliverySummary{Status: history.DeliveryStatusSent, Attempts: d.Attempts + 1})
+		if err := store.Get().DeleteAlertDelivery(d.ID); err != nil {
+			log.Printf("[watchdog.deliverDueAlerts] Failed to delete delivered alert with id=%d: %s", d.ID, err.Error())
+		}
+	}
+	return nextAttemptAt
+}
+
+// deliverAlert sends a queued alert through its alerting provider.
+//
+// The alert is sent using copies of the endpoint and of the alert on which the state of the delivery is set, since
+// the goroutine monitoring the endpoint keeps modifying the alerting state of the originals.
+func deliverAlert(cfg *config.Config, d *delivery.Delivery) error {
+	alertingStateMutex.RLock()
+	ep, endpointAlert := getEndpointAndAlert(cfg, d)
+	if ep != nil {
+		ep = ep.Copy()
+	}
+	var alertCopy alert.Alert
+	if endpointAlert != nil {
+		alertCopy = *endpointAlert
+	}
+	alertingStateMutex.RUnlock()
+	if ep == nil {
+		return ErrAlertDeliveryEndpointNotFound
+	}
+	if endpointAlert == nil {
+		return ErrAlertDeliveryAlertNotFound
+	}
+	alertProvider := cfg.Alerting.GetAlertingProviderByAlertType(d.AlertType)
+	if alertProvider == nil {
+		return ErrAlertDeliveryProviderNotFound
+	}
+	d.Apply(ep, &alertCopy)
+	log.Printf("[watchdog.deliverAlert] Delivering %s alert for endpoint with key=%s with description='%s' (resolved=%v, attempt=%d)", d.AlertType, d.EndpointKey, alertCopy.GetDescription(), d.Resolved, d.Attempts+1)
+	if err := alertProvider.Send(ep, &alertCopy, d.Result, d.Resolved); err != nil {
+		return err
+	}
+	if !d.Resolved && alertCopy.ResolveKey != d.State.ResolveKey {
+		// Some providers set the key required to resolve the alert when it is sent
+		handOverResolveKey(cfg, d, alertCopy.ResolveKey)
+	}
+	return nil
+}
+
+// getEndpointAndAlert returns the endpoint and the alert a delivery is for, if they're still configured.
+// The alerting state must be locked by the caller.
+func getEndpointAndAlert(cfg *config.Config, d *delivery.Delivery) (*endpoint.Endpoint, *alert.Alert) {
+	ep := cfg.GetEndpointByKey(d.EndpointKey)
+	if ep == nil {
+		ee := cfg.GetExternalEndpointByKey(d.EndpointKey)
+		if ee == nil {
+			return nil, nil
+		}
+		ep = ee.ToEndpoint()
+	}
+	for _, a := range ep.Alerts {
+		if a.Checksum() == d.AlertChecksum {
+			return ep, a
+		}
+	}
+	return ep, nil
+}
+
+// handOverResolveKey makes the key required to resolve an alert, which was set by the alerting provider when the
+// triggered alert was delivered, available to the delivery of the resolution of the alert.
+//
+// If the alert is still triggered, the key is set on the alert and persisted, so that it's part of the state of the
+// resolution once it is queued. Otherwise, the resolution has already been queued, so the key is set on its state.
+func handOverResolveKey(cfg *config.Config, d *delivery.Delivery, resolveKey string) {
+	alertingStateMutex.Lock()
+	defer alertingStateMutex.Unlock()
+	ep, endpointAlert := getEndpointAndAlert(cfg, d)
+	if ep == nil || endpointAlert == nil {
+		return
+	}
+	if endpointAlert.Triggered {
+		endpointAlert.ResolveKey = resolveKey
+		if err := store.Get().UpsertTriggeredEndpointAlert(ep, endpointAlert); err != nil {
+			log.Printf("[watchdog.handOverResolveKey] Failed to persist triggered endpoint alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+		return
+	}
+	deliveries, err := store.Get().GetAlertDeliveries()
+	if err != nil {
+		log.Printf("[watchdog.handOverResolveKey] Failed to retrieve queued alerts: %s", err.Error())
+		return
+	}
+	for _, resolution := range deliveries {
+		if resolution.ID <= d.ID || !resolution.Resolved || resolution.EndpointKey != d.EndpointKey || resolution.AlertChecksum != d.AlertChecksum {
+			continue
+		}
+		resolution.State.ResolveKey = resolveKey
+		if err = store.Get().UpdateAlertDelivery(resolution); err != nil {
+			log.Printf("[watchdog.handOverResolveKey] Failed to update queued alert with id=%d: %s", resolution.ID, err.Error())
+		}
+		// Only the first resolution following the triggered alert is for the same outage
+		break
+	}
+}
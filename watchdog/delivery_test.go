@@ -0,0 +1,173 @@
+package watchdog
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// newAlertReceiver creates a server recording the path of every request it receives, which fails the first
+// numberOfFailures requests
+func newAlertReceiver(t *testing.T, numberOfFailures int) (*httptest.Server, func() []string) {
+	var mutex sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		paths = append(paths, r.URL.Path)
+		if len(paths) <= numberOfFailures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func newConfigWithAlertDelivery(t *testing.T, receiverURL string, deliveryConfig *delivery.Config) *config.Config {
+	if err := deliveryConfig.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	enabled := true
+	return &config.Config{
+		Alerting: &alerting.Config{
+			Custom:   &custom.AlertProvider{URL: receiverURL + "/[ALERT_TRIGGERED_OR_RESOLVED]", Method: "POST"},
+			Delivery: deliveryConfig,
+		},
+		Endpoints: []*endpoint.Endpoint{
+			{
+				Name: "frontend",
+				URL:  "https://example.com",
+				Alerts: []*alert.Alert{
+					{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+				},
+			},
+		},
+	}
+}
+
+func TestHandleAlertingWithAlertDelivery(t *testing.T) {
+	store.Get().Clear()
+	defer store.Get().Clear()
+	server, receivedPaths := newAlertReceiver(t, 1)
+	cfg := newConfigWithAlertDelivery(t, server.URL, &delivery.Config{MaximumAttempts: 3, InitialBackoff: 50 * time.Millisecond})
+	ep := cfg.Endpoints[0]
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 1, 0, true, "The alert should've been triggered as soon as it was queued")
+	if paths := receivedPaths(); len(paths) != 0 {
+		t.Fatalf("expected no alert to have been sent synchronously, got %v", paths)
+	}
+	if nextAttemptAt := deliverDueAlerts(cfg); nextAttemptAt.IsZero() {
+		t.Error("expected the failed delivery to be retried later")
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	verify(t, ep, 0, 1, false, "The alert should've been resolved")
+	deliverDueAlerts(cfg)
+	if paths := receivedPaths(); len(paths) != 1 {
+		t.Fatalf("expected the resolved alert to wait for the triggered alert to be delivered, got %v", paths)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if nextAttemptAt := deliverDueAlerts(cfg); !nextAttemptAt.IsZero() {
+		t.Error("expected no delivery to be left")
+	}
+	if paths := receivedPaths(); len(paths) != 3 || paths[1] != "/TRIGGERED" || paths[2] != "/RESOLVED" {
+		t.Errorf("expected the triggered alert to be retried and delivered before the resolved alert, got %v", paths)
+	}
+	if deliveries, _ := store.Get().GetAlertDeliveries(); len(deliveries) != 0 {
+		t.Errorf("expected delivered alerts to have been removed from the queue, got %d", len(deliveries))
+	}
+}
+
+func TestHandleAlertingWithAlertDeliveryDeadLettered(t *testing.T) {
+	store.Get().Clear()
+	defer store.Get().Clear()
+	server, receivedPaths := newAlertReceiver(t, 2)
+	cfg := newConfigWithAlertDelivery(t, server.URL, &delivery.Config{MaximumAttempts: 2, InitialBackoff: time.Millisecond})
+	ep := cfg.Endpoints[0]
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	deliverDueAlerts(cfg)
+	time.Sleep(5 * time.Millisecond)
+	deliverDueAlerts(cfg)
+	deliveries, err := store.Get().GetAlertDeliveries()
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(deliveries) != 1 || !deliveries[0].DeadLettered || deliveries[0].Attempts != 2 {
+		t.Fatalf("expected the delivery to be dead-lettered after 2 attempts, got %+v", deliveries)
+	}
+	deliverDueAlerts(cfg)
+	if paths := receivedPaths(); len(paths) != 2 {
+		t.Fatalf("expected dead-lettered deliveries not to be attempted, got %v", paths)
+	}
+	if _, err := RequeueAlertDelivery(deliveries[0].ID); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	deliverDueAlerts(cfg)
+	if paths := receivedPaths(); len(paths) != 3 {
+		t.Errorf("expected requeued delivery to be attempted, got %v", paths)
+	}
+	if _, err := RequeueAlertDelivery(deliveries[0].ID); !errors.Is(err, common.ErrAlertDeliveryNotFound) {
+		t.Errorf("expected error %v, got %v", common.ErrAlertDeliveryNotFound, err)
+	}
+}
+
+func TestHandleAlertingWithAlertDeliveryForAlertNoLongerConfigured(t *testing.T) {
+	store.Get().Clear()
+	defer store.Get().Clear()
+	server, receivedPaths := newAlertReceiver(t, 0)
+	cfg := newConfigWithAlertDelivery(t, server.URL, &delivery.Config{MaximumAttempts: 1})
+	ep := cfg.Endpoints[0]
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	// The configuration was reloaded, and the endpoint no longer has alerts
+	ep.Alerts = nil
+	deliverDueAlerts(cfg)
+	deliveries, _ := store.Get().GetAlertDeliveries()
+	if len(deliveries) != 1 || !deliveries[0].DeadLettered || deliveries[0].LastError != ErrAlertDeliveryAlertNotFound.Error() {
+		t.Errorf("expected the delivery to be dead-lettered because the alert is no longer configured, got %+v", deliveries)
+	}
+	if paths := receivedPaths(); len(paths) != 0 {
+		t.Errorf("expected no alert to have been sent, got %v", paths)
+	}
+}
+
+func TestHandleAlertingWithAlertDeliveryWhileDelivering(t *testing.T) {
+	store.Get().Clear()
+	defer store.Get().Clear()
+	server, receivedPaths := newAlertReceiver(t, 0)
+	cfg := newConfigWithAlertDelivery(t, server.URL, &delivery.Config{})
+	ep := cfg.Endpoints[0]
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			deliverDueAlerts(cfg)
+		}
+	}()
+	// The alerting state of the endpoint is modified while alerts are being delivered
+	for i := 0; i < 10; i++ {
+		HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+		HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	}
+	<-done
+	deliverDueAlerts(cfg)
+	if paths := receivedPaths(); len(paths) != 20 {
+		t.Errorf("expected every alert to have been delivered, got %d", len(paths))
+	}
+}
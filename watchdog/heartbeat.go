@@ -0,0 +1,52 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// monitorExternalEndpointHeartbeat inserts a failed result for the external endpoint passed every time its heartbeat
+// interval elapses without a result being pushed, so that a client that stopped pushing results (e.g. a dead agent)
+// triggers the alerts of the external endpoint. The alerts are resolved once the client pushes successful results again.
+//
+// Gatus may have been down while the client was pushing, so the first interval starts when the monitoring starts.
+func monitorExternalEndpointHeartbeat(cfg *config.Config, ee *endpoint.ExternalEndpoint, ctx context.Context) {
+	lastCheckedAt := time.Now()
+	for {
+		expiresAt := ee.LastResultReceivedAt()
+		if lastCheckedAt.After(expiresAt) {
+			expiresAt = lastCheckedAt
+		}
+		expiresAt = expiresAt.Add(ee.HeartbeatInterval)
+		select {
+		case <-ctx.Done():
+			log.Printf("[watchdog.monitorExternalEndpointHeartbeat] Canceling heartbeat monitoring of external endpoint with key=%s", ee.Key())
+			return
+		case <-time.After(time.Until(expiresAt)):
+		}
+		if time.Since(ee.LastResultReceivedAt()) < ee.HeartbeatInterval {
+			// A result was pushed while waiting, so the heartbeat hasn't expired
+			continue
+		}
+		lastCheckedAt = time.Now()
+		handleExpiredHeartbeat(cfg, ee, lastCheckedAt)
+	}
+}
+
+// handleExpiredHeartbeat inserts a failed result on behalf of an external endpoint whose heartbeat expired
+func handleExpiredHeartbeat(cfg *config.Config, ee *endpoint.ExternalEndpoint, expiredAt time.Time) {
+	result := &endpoint.Result{
+		Timestamp: expiredAt,
+		Success:   false,
+		Errors:    []string{fmt.Sprintf("heartbeat expired: no result received within %s", ee.HeartbeatInterval)},
+	}
+	log.Printf("[watchdog.handleExpiredHeartbeat] Heartbeat of external endpoint with key=%s expired after %s", ee.Key(), ee.HeartbeatInterval)
+	if err := InsertExternalEndpointResult(cfg, ee, result); err != nil {
+		log.Printf("[watchdog.handleExpiredHeartbeat] Failed to insert result in storage: %s", err.Error())
+	}
+}
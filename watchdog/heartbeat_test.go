@@ -0,0 +1,93 @@
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestMonitorExternalEndpointHeartbeat(t *testing.T) {
+	defer store.Get().Clear()
+	scenarios := []struct {
+		name                   string
+		pushInterval           time.Duration
+		expectedFailedResults  bool
+		expectedSuccessResults bool
+	}{
+		{
+			name:                  "no-result-pushed",
+			expectedFailedResults: true,
+		},
+		{
+			name:                   "results-pushed-within-interval",
+			pushInterval:           20 * time.Millisecond,
+			expectedSuccessResults: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ee := &endpoint.ExternalEndpoint{Name: scenario.name, Group: "heartbeat", HeartbeatInterval: 100 * time.Millisecond}
+			cfg := &config.Config{ExternalEndpoints: []*endpoint.ExternalEndpoint{ee}, Maintenance: &maintenance.Config{}}
+			ctx, cancel := context.WithCancel(context.Background())
+			go monitorExternalEndpointHeartbeat(cfg, ee, ctx)
+			for deadline := time.Now().Add(350 * time.Millisecond); time.Now().Before(deadline); {
+				if scenario.pushInterval > 0 {
+					time.Sleep(scenario.pushInterval)
+					ee.MarkResultReceived(time.Now())
+					if err := InsertExternalEndpointResult(cfg, ee, &endpoint.Result{Success: true, Timestamp: time.Now()}); err != nil {
+						t.Fatal("expected no error, got", err)
+					}
+				} else {
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+			cancel()
+			time.Sleep(10 * time.Millisecond)
+			status, err := store.Get().GetEndpointStatusByKey(ee.Key(), paging.NewEndpointStatusParams().WithResults(1, 100))
+			if err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			var numberOfFailedResults, numberOfSuccessfulResults int
+			for _, result := range status.Results {
+				if result.Success {
+					numberOfSuccessfulResults++
+				} else {
+					numberOfFailedResults++
+				}
+			}
+			// The heartbeat expires every 100ms without results, so roughly 3 failed results are expected
+			if scenario.expectedFailedResults && (numberOfFailedResults < 2 || numberOfFailedResults > 4) {
+				t.Errorf("expected 2 to 4 failed results, got %d", numberOfFailedResults)
+			}
+			if !scenario.expectedFailedResults && numberOfFailedResults != 0 {
+				t.Errorf("expected no failed results, got %d", numberOfFailedResults)
+			}
+			if scenario.expectedSuccessResults != (numberOfSuccessfulResults > 0) {
+				t.Errorf("expected successful results=%v, got %d", scenario.expectedSuccessResults, numberOfSuccessfulResults)
+			}
+		})
+	}
+}
+
+func TestHandleExpiredHeartbeat(t *testing.T) {
+	defer store.Get().Clear()
+	ee := &endpoint.ExternalEndpoint{Name: "agent", Group: "heartbeat", HeartbeatInterval: 50 * time.Millisecond}
+	cfg := &config.Config{ExternalEndpoints: []*endpoint.ExternalEndpoint{ee}, Maintenance: &maintenance.Config{}}
+	handleExpiredHeartbeat(cfg, ee, time.Now())
+	status, err := store.Get().GetEndpointStatusByKey(ee.Key(), paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(status.Results) != 1 || status.Results[0].Success || len(status.Results[0].Errors) != 1 {
+		t.Fatalf("expected a failed result with an error, got %#v", status.Results)
+	}
+	if status.Results[0].Errors[0] != "heartbeat expired: no result received within 50ms" {
+		t.Errorf("unexpected error: %s", status.Results[0].Errors[0])
+	}
+}
@@ -0,0 +1,72 @@
+package watchdog
+
+import (
+	"errors"
+	"log"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+// recordTriggeredAlert adds an alert that has just been triggered to the alert history and returns the ID of its
+// entry, or 0 if it couldn't be added
+func recordTriggeredAlert(ep *endpoint.Endpoint, endpointAlert *alert.Alert, result *endpoint.Result, triggeredDelivery history.DeliverySummary) int64 {
+	entry := history.NewEntry(ep, endpointAlert, getResultTimestamp(result), triggeredDelivery)
+	if err := store.Get().InsertAlertHistoryEntry(entry); err != nil {
+		log.Printf("[watchdog.recordTriggeredAlert] Failed to add triggered alert for endpoint with key=%s to the alert history: %s", ep.Key(), err.Error())
+		return 0
+	}
+	return entry.ID
+}
+
+// getUnresolvedAlertHistoryEntry returns the entry of the alert history of an alert that is about to be resolved, or
+// nil if it has none, which is the case for alerts that were triggered before the alert history existed
+func getUnresolvedAlertHistoryEntry(ep *endpoint.Endpoint, endpointAlert *alert.Alert) *history.Entry {
+	entry, err := store.Get().GetUnresolvedAlertHistoryEntry(ep.Key(), endpointAlert.Checksum())
+	if err != nil {
+		if !errors.Is(err, common.ErrAlertHistoryEntryNotFound) {
+			log.Printf("[watchdog.getUnresolvedAlertHistoryEntry] Failed to retrieve the alert history entry of the alert for endpoint with key=%s: %s", ep.Key(), err.Error())
+		}
+		return nil
+	}
+	return entry
+}
+
+// recordResolvedAlert marks the entry of the alert history of an alert that has just been resolved as resolved
+func recordResolvedAlert(entry *history.Entry, result *endpoint.Result, resolvedDelivery *history.DeliverySummary) {
+	if entry == nil {
+		return
+	}
+	entry.Resolve(getResultTimestamp(result), resolvedDelivery)
+	if err := store.Get().UpdateAlertHistoryEntry(entry); err != nil {
+		log.Printf("[watchdog.recordResolvedAlert] Failed to resolve the alert history entry with id=%d: %s", entry.ID, err.Error())
+	}
+}
+
+// recordAlertDelivery sets the summary of an attempt to deliver a queued alert on the entry of the alert history the
+// delivery is for, if any
+func recordAlertDelivery(d *delivery.Delivery, deliverySummary history.DeliverySummary) {
+	if d.HistoryEntryID == 0 {
+		return
+	}
+	// The entry is also updated by the goroutine monitoring the endpoint when the alert is resolved
+	alertingStateMutex.Lock()
+	defer alertingStateMutex.Unlock()
+	entry, err := store.Get().GetAlertHistoryEntryByID(d.HistoryEntryID)
+	if err != nil {
+		log.Printf("[watchdog.recordAlertDelivery] Failed to retrieve the alert history entry with id=%d: %s", d.HistoryEntryID, err.Error())
+		return
+	}
+	if d.Resolved {
+		entry.ResolvedDelivery = &deliverySummary
+	} else {
+		entry.TriggeredDelivery = deliverySummary
+	}
+	if err = store.Get().UpdateAlertHistoryEntry(entry); err != nil {
+		log.Printf("[watchdog.recordAlertDelivery] Failed to update the alert history entry with id=%d: %s", entry.ID, err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/delivery"
+	"github.com/TwiN/gatus/v5/alerting/history"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestHandleAlertingWithAlertHistory(t *testing.T) {
+	store.Get().Clear()
+	defer store.Get().Clear()
+	server, _ := newAlertReceiver(t, 0)
+	enabled, disabled := true, false
+	alertingConfig := &alerting.Config{Custom: &custom.AlertProvider{URL: server.URL + "/[ALERT_TRIGGERED_OR_RESOLVED]", Method: "POST"}}
+	ep := &endpoint.Endpoint{
+		Name:  "frontend",
+		Group: "core",
+		URL:   "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+			{Type: alert.TypeCustom, Enabled: &enabled, FailureThreshold: 2, SuccessThreshold: 1, SendOnResolved: &disabled},
+		},
+	}
+	triggeredAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: triggeredAt}, alertingConfig, false)
+	HandleAlerting(ep, &endpoint.Result{Success: false, Timestamp: triggeredAt.Add(10 * time.Second)}, alertingConfig, false)
+	entries, _ := store.Get().GetAlertHistory(time.Time{}, time.Time{})
+	if len(entries) != 2 || entries[0].ResolvedAt != nil || entries[1].ResolvedAt != nil {
+		t.Fatalf("expected both alerts to have been added to the alert history as unresolved, got %v", entries)
+	}
+	if !entries[1].TriggeredAt.Equal(triggeredAt) || entries[1].EndpointKey != ep.Key() || entries[1].TriggeredDelivery.Status != history.DeliveryStatusSent {
+		t.Errorf("expected the first alert to have been sent when the endpoint started failing, got %+v", entries[1])
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: true, Timestamp: triggeredAt.Add(30 * time.Second)}, alertingConfig, false)
+	entries, _ = store.Get().GetAlertHistory(time.Time{}, time.Time{})
+	if len(entries) != 2 || entries[0].ResolvedAt == nil || entries[1].ResolvedAt == nil {
+		t.Fatalf("expected both alerts to have been resolved, got %v", entries)
+	}
+	if entries[1].Duration() != 30*time.Second || entries[1].ResolvedDelivery == nil || entries[1].ResolvedDelivery.Status != history.DeliveryStatusSent {
+		t.Errorf("expected the resolution of the first alert to have been sent after 30s, got %+v", entries[1])
+	}
+	if entries[0].Duration() != 20*time.Second || entries[0].ResolvedDelivery != nil {
+		t.Errorf("expected the resolution of the second alert not to have been sent, got %+v", entries[0])
+	}
+}
+
+func TestHandleAlertingWithAlertHistoryAndAlertDelivery(t *testing.T) {
+	store.Get().Clear()
+	defer store.Get().Clear()
+	server, _ := newAlertReceiver(t, 1)
+	cfg := newConfigWithAlertDelivery(t, server.URL, &delivery.Config{MaximumAttempts: 3, InitialBackoff: time.Millisecond})
+	ep := cfg.Endpoints[0]
+	HandleAlerting(ep, &endpoint.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	entries, _ := store.Get().GetAlertHistory(time.Time{}, time.Time{})
+	if len(entries) != 1 || entries[0].TriggeredDelivery.Status != history.DeliveryStatusQueued {
+		t.Fatalf("expected the triggered alert to have been added to the alert history as queued, got %v", entries)
+	}
+	deliverDueAlerts(cfg)
+	if entry, _ := store.Get().GetAlertHistoryEntryByID(entries[0].ID); entry.TriggeredDelivery.Status != history.DeliveryStatusQueued || entry.TriggeredDelivery.Attempts != 1 || len(entry.TriggeredDelivery.LastError) == 0 {
+		t.Errorf("expected the failed attempt to have been recorded, got %+v", entry.TriggeredDelivery)
+	}
+	HandleAlerting(ep, &endpoint.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	time.Sleep(5 * time.Millisecond)
+	deliverDueAlerts(cfg)
+	entry, _ := store.Get().GetAlertHistoryEntryByID(entries[0].ID)
+	if entry.ResolvedAt == nil || entry.TriggeredDelivery.Status != history.DeliveryStatusSent || entry.TriggeredDelivery.Attempts != 2 {
+		t.Errorf("expected the triggered alert to have been delivered on the second attempt, got %+v", entry)
+	}
+	if entry.ResolvedDelivery == nil || entry.ResolvedDelivery.Status != history.DeliveryStatusSent || entry.ResolvedDelivery.Attempts != 1 {
+		t.Errorf("expected the resolved alert to have been delivered, got %+v", entry.ResolvedDelivery)
+	}
+}
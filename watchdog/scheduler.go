@@ -0,0 +1,137 @@
+package watchdog
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+)
+
+// ScheduleStatus is the status of the monitoring of an endpoint
+type ScheduleStatus string
+
+const (
+	// ScheduleStatusScheduled means that the endpoint is waiting for its next run
+	ScheduleStatusScheduled ScheduleStatus = "scheduled"
+
+	// ScheduleStatusWaiting means that the endpoint is due, but is waiting for the monitoring lock, which is held while
+	// another endpoint is being evaluated
+	ScheduleStatusWaiting ScheduleStatus = "waiting"
+
+	// ScheduleStatusRunning means that the endpoint is being evaluated
+	ScheduleStatusRunning ScheduleStatus = "running"
+)
+
+// EndpointSchedule describes when an endpoint is monitored, which helps diagnosing why a check seems stuck or delayed
+type EndpointSchedule struct {
+	// Key of the endpoint
+	Key string `json:"key"`
+
+	// Group of the endpoint
+	Group string `json:"group,omitempty"`
+
+	// Name of the endpoint
+	Name string `json:"name"`
+
+	// Interval between the end of a run and the start of the next one
+	Interval time.Duration `json:"interval"`
+
+	// Status of the monitoring of the endpoint
+	Status ScheduleStatus `json:"status"`
+
+	// StatusSince is the time at which the endpoint transitioned to its current status
+	StatusSince time.Time `json:"statusSince"`
+
+	// NextRunAt is the time at which the next run is scheduled, if the status is ScheduleStatusScheduled
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+
+	// LastRunStartedAt is the time at which the last run started, excluding the time spent waiting for the monitoring
+	// lock, or nil if the endpoint hasn't been evaluated yet
+	LastRunStartedAt *time.Time `json:"lastRunStartedAt,omitempty"`
+
+	// LastRunDuration is how long the last run took, including the time it took to handle alerting
+	LastRunDuration time.Duration `json:"lastRunDuration"`
+}
+
+var (
+	endpointSchedules      = make(map[string]*EndpointSchedule)
+	endpointSchedulesMutex sync.RWMutex
+)
+
+// GetEndpointSchedules returns a copy of the schedule of every endpoint being monitored, sorted by key
+func GetEndpointSchedules() []*EndpointSchedule {
+	endpointSchedulesMutex.RLock()
+	defer endpointSchedulesMutex.RUnlock()
+	schedules := make([]*EndpointSchedule, 0, len(endpointSchedules))
+	for _, schedule := range endpointSchedules {
+		scheduleCopy := *schedule
+		schedules = append(schedules, &scheduleCopy)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Key < schedules[j].Key
+	})
+	return schedules
+}
+
+// scheduleEndpoint marks the endpoint as scheduled to run at the time passed
+func scheduleEndpoint(ep *endpoint.Endpoint, nextRunAt time.Time) {
+	updateEndpointSchedule(ep, ScheduleStatusScheduled, func(schedule *EndpointSchedule) {
+		schedule.NextRunAt = &nextRunAt
+	})
+}
+
+// startEndpointRun marks the endpoint as running, or as waiting for the monitoring lock if waitingForLock is true
+func startEndpointRun(ep *endpoint.Endpoint, waitingForLock bool) {
+	if waitingForLock {
+		updateEndpointSchedule(ep, ScheduleStatusWaiting, nil)
+		return
+	}
+	updateEndpointSchedule(ep, ScheduleStatusRunning, func(schedule *EndpointSchedule) {
+		now := time.Now()
+		schedule.LastRunStartedAt = &now
+	})
+}
+
+// endEndpointRun records the duration of the run that just ended and marks the endpoint as scheduled to run again once
+// its interval has elapsed
+func endEndpointRun(ep *endpoint.Endpoint) {
+	now := time.Now()
+	nextRunAt := now.Add(ep.EffectiveInterval())
+	updateEndpointSchedule(ep, ScheduleStatusScheduled, func(schedule *EndpointSchedule) {
+		if schedule.LastRunStartedAt != nil {
+			schedule.LastRunDuration = now.Sub(*schedule.LastRunStartedAt)
+		}
+		schedule.NextRunAt = &nextRunAt
+	})
+}
+
+// updateEndpointSchedule sets the status of the schedule of an endpoint and applies the update passed, if any
+func updateEndpointSchedule(ep *endpoint.Endpoint, status ScheduleStatus, update func(schedule *EndpointSchedule)) {
+	endpointSchedulesMutex.Lock()
+	defer endpointSchedulesMutex.Unlock()
+	key := ep.Key()
+	schedule, exists := endpointSchedules[key]
+	if !exists {
+		schedule = &EndpointSchedule{Key: key, Group: ep.Group, Name: ep.Name}
+		endpointSchedules[key] = schedule
+	}
+	schedule.Interval = ep.EffectiveInterval()
+	if schedule.Status != status {
+		schedule.Status = status
+		schedule.StatusSince = time.Now()
+	}
+	if status != ScheduleStatusScheduled {
+		schedule.NextRunAt = nil
+	}
+	if update != nil {
+		update(schedule)
+	}
+}
+
+// clearEndpointSchedules removes the schedule of every endpoint, which is necessary when monitoring is stopped
+func clearEndpointSchedules() {
+	endpointSchedulesMutex.Lock()
+	defer endpointSchedulesMutex.Unlock()
+	endpointSchedules = make(map[string]*EndpointSchedule)
+}
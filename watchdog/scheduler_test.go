@@ -0,0 +1,76 @@
+package watchdog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestEndpointSchedule(t *testing.T) {
+	defer clearEndpointSchedules()
+	ep := &endpoint.Endpoint{Name: "frontend", Group: "core", Interval: time.Minute}
+	nextRunAt := time.Now().Add(time.Second)
+	scheduleEndpoint(ep, nextRunAt)
+	schedules := GetEndpointSchedules()
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	if schedules[0].Key != "core_frontend" || schedules[0].Status != ScheduleStatusScheduled || schedules[0].Interval != time.Minute {
+		t.Errorf("expected core_frontend to be scheduled with an interval of 1m, got %#v", schedules[0])
+	}
+	if schedules[0].NextRunAt == nil || !schedules[0].NextRunAt.Equal(nextRunAt) {
+		t.Errorf("expected next run at %s, got %v", nextRunAt, schedules[0].NextRunAt)
+	}
+	startEndpointRun(ep, true)
+	if schedule := GetEndpointSchedules()[0]; schedule.Status != ScheduleStatusWaiting || schedule.NextRunAt != nil || schedule.LastRunStartedAt != nil {
+		t.Errorf("expected core_frontend to be waiting for the monitoring lock, got %#v", schedule)
+	}
+	startEndpointRun(ep, false)
+	if schedule := GetEndpointSchedules()[0]; schedule.Status != ScheduleStatusRunning || schedule.LastRunStartedAt == nil {
+		t.Errorf("expected core_frontend to be running, got %#v", schedule)
+	}
+	time.Sleep(5 * time.Millisecond)
+	endEndpointRun(ep)
+	schedule := GetEndpointSchedules()[0]
+	if schedule.Status != ScheduleStatusScheduled || schedule.NextRunAt == nil || schedule.LastRunDuration < 5*time.Millisecond {
+		t.Errorf("expected core_frontend to be scheduled again with a last run duration of at least 5ms, got %#v", schedule)
+	}
+	if schedule.NextRunAt.Sub(*schedule.LastRunStartedAt) < time.Minute {
+		t.Errorf("expected the next run to be scheduled at least 1m after the last run started, got %s", schedule.NextRunAt.Sub(*schedule.LastRunStartedAt))
+	}
+	// Modifying the schedules returned must not affect the ones being tracked
+	schedule.Status = ScheduleStatusRunning
+	if GetEndpointSchedules()[0].Status != ScheduleStatusScheduled {
+		t.Error("expected the schedules returned to be copies")
+	}
+	clearEndpointSchedules()
+	if len(GetEndpointSchedules()) != 0 {
+		t.Error("expected no schedules after clearing them")
+	}
+}
+
+func TestExecuteUpdatesEndpointSchedule(t *testing.T) {
+	defer store.Get().Clear()
+	defer clearEndpointSchedules()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ep := &endpoint.Endpoint{Name: "frontend", URL: server.URL, Conditions: []endpoint.Condition{"[STATUS] == 200"}}
+	if err := ep.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	execute(ep, nil, &maintenance.Config{}, nil, nil, false, false, false)
+	schedules := GetEndpointSchedules()
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	if schedules[0].Status != ScheduleStatusScheduled || schedules[0].LastRunStartedAt == nil || schedules[0].NextRunAt == nil {
+		t.Errorf("expected the endpoint to be scheduled again after having run, got %#v", schedules[0])
+	}
+}
@@ -3,16 +3,23 @@ package watchdog
 import (
 	"context"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/chaos"
 	"github.com/TwiN/gatus/v5/config/connectivity"
 	"github.com/TwiN/gatus/v5/config/endpoint"
 	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/events"
+	"github.com/TwiN/gatus/v5/loganalytics"
 	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/metricspush"
+	"github.com/TwiN/gatus/v5/resultlog"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
 var (
@@ -20,48 +27,148 @@ var (
 	// Without this, conditions using response time may become inaccurate.
 	monitoringMutex sync.Mutex
 
+	// latestResults is the latest result of each endpoint monitored, including the ones discarded by sampling, by key
+	latestResults      = make(map[string]*endpoint.Result)
+	latestResultsMutex sync.RWMutex
+
 	ctx        context.Context
 	cancelFunc context.CancelFunc
+
+	// restoredSchedule is the schedule to carry on with, which is set by RestoreSchedule
+	restoredSchedule      map[string]time.Time
+	restoredScheduleMutex sync.Mutex
 )
 
 // Monitor loops over each endpoint and starts a goroutine to monitor each endpoint separately
 func Monitor(cfg *config.Config) {
 	ctx, cancelFunc = context.WithCancel(context.Background())
+	endpoint.SetLatestResultProvider(getLatestResult)
+	startGracePeriod(cfg.GracePeriod)
+	if cfg.GracePeriod > 0 {
+		log.Printf("[watchdog.Monitor] Withholding alerts for the grace period of %s", cfg.GracePeriod)
+	}
+	if cfg.Alerting != nil && cfg.Alerting.Delivery != nil {
+		go deliverQueuedAlerts(cfg, ctx)
+	}
+	if cfg.LogAnalytics != nil {
+		go cfg.LogAnalytics.Run(ctx)
+	}
+	if cfg.MetricsPush != nil {
+		go cfg.MetricsPush.Run(ctx)
+	}
+	if cfg.Events != nil {
+		go cfg.Events.Run(ctx, cfg.Maintenance)
+	}
+	resultlog.SetConfig(cfg.ResultLog)
+	if len(cfg.Reports) > 0 {
+		// The endpoints of tenants are excluded, since reports are published outside of the API of their tenant
+		var reportedEndpoints []*endpoint.Endpoint
+		for _, ep := range cfg.Endpoints {
+			if ep.IsEnabled() && len(ep.Tenant) == 0 {
+				reportedEndpoints = append(reportedEndpoints, ep)
+			}
+		}
+		for _, ee := range cfg.ExternalEndpoints {
+			if ee.IsEnabled() && len(ee.Tenant) == 0 {
+				reportedEndpoints = append(reportedEndpoints, ee.ToEndpoint())
+			}
+		}
+		for _, r := range cfg.Reports {
+			go r.Run(ctx, reportedEndpoints)
+		}
+	}
+	for _, ee := range cfg.ExternalEndpoints {
+		if ee.IsEnabled() && ee.HeartbeatInterval > 0 {
+			go monitorExternalEndpointHeartbeat(cfg, ee, ctx)
+		}
+	}
+	restoredNextRunAt := takeRestoredSchedule()
+	// Each endpoint is scheduled first so that they're all visible right away, despite being started one after the other
+	nextRunAt := time.Now()
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint.IsEnabled() {
+			if restoredRunAt, exists := restoredNextRunAt[endpoint.Key()]; exists {
+				scheduleEndpoint(endpoint, restoredRunAt)
+				continue
+			}
+			nextRunAt = nextRunAt.Add(777 * time.Millisecond)
+			scheduleEndpoint(endpoint, nextRunAt.Add(endpoint.InitialDelay))
+		}
+	}
 	for _, endpoint := range cfg.Endpoints {
 		if endpoint.IsEnabled() {
+			if restoredRunAt, exists := restoredNextRunAt[endpoint.Key()]; exists {
+				// The endpoints were already spread out by the previous process, so they don't need to be staggered
+				go monitor(endpoint, time.Until(restoredRunAt), cfg.Alerting, cfg.Maintenance, cfg.Chaos, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
+				continue
+			}
 			// To prevent multiple requests from running at the same time, we'll wait for a little before each iteration
 			time.Sleep(777 * time.Millisecond)
-			go monitor(endpoint, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
+			go monitor(endpoint, endpoint.InitialDelay, cfg.Alerting, cfg.Maintenance, cfg.Chaos, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
 		}
 	}
 }
 
-// monitor a single endpoint in a loop
-func monitor(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
-	// Run it immediately on start
-	execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+// RestoreSchedule makes the next call to Monitor run each endpoint whose key is in the map passed at the time it maps
+// to rather than right away, which allows a process taking over from another to carry on with its schedule
+func RestoreSchedule(nextRunAt map[string]time.Time) {
+	restoredScheduleMutex.Lock()
+	defer restoredScheduleMutex.Unlock()
+	restoredSchedule = nextRunAt
+}
+
+// takeRestoredSchedule returns the schedule passed to RestoreSchedule, if any, which is only used once
+func takeRestoredSchedule() map[string]time.Time {
+	restoredScheduleMutex.Lock()
+	defer restoredScheduleMutex.Unlock()
+	schedule := restoredSchedule
+	restoredSchedule = nil
+	return schedule
+}
+
+// monitor a single endpoint in a loop, starting once the initial delay passed has elapsed
+func monitor(ep *endpoint.Endpoint, initialDelay time.Duration, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, chaosConfig *chaos.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
+	if initialDelay > 0 {
+		select {
+		case <-ctx.Done():
+			log.Printf("[watchdog.monitor] Canceling initial delay of group=%s; endpoint=%s", ep.Group, ep.Name)
+			return
+		case <-time.After(initialDelay):
+		}
+	}
+	// Run it immediately on start, or once the initial delay has elapsed
+	execute(ep, alertingConfig, maintenanceConfig, chaosConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
 	// Loop for the next executions
 	for {
 		select {
 		case <-ctx.Done():
 			log.Printf("[watchdog.monitor] Canceling current execution of group=%s; endpoint=%s", ep.Group, ep.Name)
 			return
-		case <-time.After(ep.Interval):
-			execute(ep, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+		case <-time.After(ep.EffectiveInterval()):
+			execute(ep, alertingConfig, maintenanceConfig, chaosConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+		case <-ep.OverrideChanged():
+			// The interval or the conditions may have changed, so we'll execute it right away rather than waiting for
+			// the previous interval to elapse
+			log.Printf("[watchdog.monitor] Override of group=%s; endpoint=%s changed, executing it immediately", ep.Group, ep.Name)
+			execute(ep, alertingConfig, maintenanceConfig, chaosConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
 		}
 	}
 	// Just in case somebody wandered all the way to here and wonders, "what about ExternalEndpoints?"
 	// Alerting is checked every time an external endpoint is pushed to Gatus, so they're not monitored
-	// periodically like they are for normal endpoints.
+	// periodically like they are for normal endpoints, unless they have a heartbeat interval.
+	// See monitorExternalEndpointHeartbeat.
 }
 
-func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool) {
+func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, chaosConfig *chaos.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool) {
 	if !disableMonitoringLock {
 		// By placing the lock here, we prevent multiple endpoints from being monitored at the exact same time, which
 		// could cause performance issues and return inaccurate results
+		startEndpointRun(ep, true)
 		monitoringMutex.Lock()
 		defer monitoringMutex.Unlock()
 	}
+	startEndpointRun(ep, false)
+	defer endEndpointRun(ep)
 	// If there's a connectivity checker configured, check if Gatus has internet connectivity
 	if connectivityConfig != nil && connectivityConfig.Checker != nil && !connectivityConfig.Checker.IsConnected() {
 		log.Println("[watchdog.execute] No connectivity; skipping execution")
@@ -71,10 +178,28 @@ func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenance
 		log.Printf("[watchdog.execute] Monitoring group=%s; endpoint=%s", ep.Group, ep.Name)
 	}
 	result := ep.EvaluateHealth()
+	if len(result.UnconfirmedErrors) > 0 {
+		log.Printf("[watchdog.execute] Discarded failure of group=%s; endpoint=%s because it was not confirmed by the double check; errors=%s", ep.Group, ep.Name, strings.Join(result.UnconfirmedErrors, ", "))
+	}
+	// Chaos experiments are applied before anything else, so that the synthetic failures go through the same pipeline
+	// as actual failures
+	chaosConfig.Apply(ep, result)
 	if enabledMetrics {
 		metrics.PublishMetricsForEndpoint(ep, result)
 	}
-	UpdateEndpointStatuses(ep, result)
+	loganalytics.PublishResult(ep, result)
+	metricspush.PublishResult(ep, result)
+	events.PublishResult(ep, result)
+	resultlog.PublishResult(ep, result)
+	if ep.ShouldStoreResult(result) {
+		UpdateEndpointStatuses(ep, result)
+	} else {
+		// The result is still accounted for in the uptime, otherwise sampling would skew the uptime toward failures
+		updateEndpointUptime(ep, result)
+		if debug {
+			log.Printf("[watchdog.execute] Not storing result for group=%s; endpoint=%s due to sampling", ep.Group, ep.Name)
+		}
+	}
 	if debug && !result.Success {
 		log.Printf("[watchdog.execute] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s; body=%s", ep.Group, ep.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond), result.Body)
 	} else {
@@ -87,17 +212,71 @@ func execute(ep *endpoint.Endpoint, alertingConfig *alerting.Config, maintenance
 		log.Println("[watchdog.execute] Not handling alerting because currently in the maintenance window")
 	}
 	if debug {
-		log.Printf("[watchdog.execute] Waiting for interval=%s before monitoring group=%s endpoint=%s again", ep.Interval, ep.Group, ep.Name)
+		log.Printf("[watchdog.execute] Waiting for interval=%s before monitoring group=%s endpoint=%s again", ep.EffectiveInterval(), ep.Group, ep.Name)
 	}
 }
 
 // UpdateEndpointStatuses updates the slice of endpoint statuses
 func UpdateEndpointStatuses(ep *endpoint.Endpoint, result *endpoint.Result) {
+	setLatestResult(ep.Key(), result)
 	if err := store.Get().Insert(ep, result); err != nil {
 		log.Println("[watchdog.UpdateEndpointStatuses] Failed to insert result in storage:", err.Error())
 	}
 }
 
+// InsertExternalEndpointResult persists the result of an external endpoint and, unless under maintenance,
+// triggers or resolves the alerts of the external endpoint accordingly
+func InsertExternalEndpointResult(cfg *config.Config, externalEndpoint *endpoint.ExternalEndpoint, result *endpoint.Result) error {
+	convertedEndpoint := externalEndpoint.ToEndpoint()
+	result.ClassifyFailure()
+	if err := store.Get().Insert(convertedEndpoint, result); err != nil {
+		return err
+	}
+	loganalytics.PublishResult(convertedEndpoint, result)
+	metricspush.PublishResult(convertedEndpoint, result)
+	events.PublishResult(convertedEndpoint, result)
+	resultlog.PublishResult(convertedEndpoint, result)
+	// Check if an alert should be triggered or resolved
+	if !cfg.Maintenance.IsUnderMaintenance() {
+		HandleExternalEndpointAlerting(externalEndpoint, result, cfg.Alerting, cfg.Debug)
+	}
+	return nil
+}
+
+// updateEndpointUptime accounts for a result discarded by sampling in the uptime of the endpoint without storing it
+func updateEndpointUptime(ep *endpoint.Endpoint, result *endpoint.Result) {
+	setLatestResult(ep.Key(), result)
+	if err := store.Get().InsertUptime(ep, result); err != nil {
+		log.Println("[watchdog.updateEndpointUptime] Failed to insert uptime in storage:", err.Error())
+	}
+}
+
+// setLatestResult keeps track of the latest result of the endpoint with the key passed
+func setLatestResult(key string, result *endpoint.Result) {
+	latestResultsMutex.Lock()
+	latestResults[key] = result
+	latestResultsMutex.Unlock()
+}
+
+// getLatestResult returns the latest result of the endpoint with the key passed, which is used to resolve the
+// conditions referencing the state of other endpoints.
+//
+// Results discarded by sampling aren't in the store, so the latest result observed takes precedence over the ones
+// stored, which are only used for endpoints that haven't been monitored since Gatus started (e.g. external endpoints)
+func getLatestResult(key string) *endpoint.Result {
+	latestResultsMutex.RLock()
+	result, exists := latestResults[key]
+	latestResultsMutex.RUnlock()
+	if exists {
+		return result
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil || len(endpointStatus.Results) == 0 {
+		return nil
+	}
+	return endpointStatus.Results[len(endpointStatus.Results)-1]
+}
+
 // Shutdown stops monitoring all endpoints
 func Shutdown(cfg *config.Config) {
 	// Disable all the old HTTP connections
@@ -105,4 +284,5 @@ func Shutdown(cfg *config.Config) {
 		ep.Close()
 	}
 	cancelFunc()
+	clearEndpointSchedules()
 }
@@ -0,0 +1,28 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/endpoint"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestGetLatestResult(t *testing.T) {
+	defer store.Get().Clear()
+	ep := &endpoint.Endpoint{Name: "database", Group: "core"}
+	if getLatestResult(ep.Key()) != nil {
+		t.Error("expected no result for an endpoint without results")
+	}
+	UpdateEndpointStatuses(ep, &endpoint.Result{Success: false, Timestamp: time.Now().Add(-time.Minute)})
+	UpdateEndpointStatuses(ep, &endpoint.Result{Success: true, Timestamp: time.Now()})
+	if result := getLatestResult(ep.Key()); result == nil || !result.Success {
+		t.Errorf("expected the latest result to be the successful one, got %#v", result)
+	}
+	// Results discarded by sampling aren't stored, but they're still the latest result
+	sampledOutResult := &endpoint.Result{Success: true, Timestamp: time.Now().Add(time.Second)}
+	updateEndpointUptime(ep, sampledOutResult)
+	if result := getLatestResult(ep.Key()); result != sampledOutResult {
+		t.Errorf("expected the latest result to be the one discarded by sampling, got %#v", result)
+	}
+}
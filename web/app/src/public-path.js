@@ -0,0 +1,5 @@
+// BASE_PATH is the path under which Gatus is served (e.g. /status), as rendered by the server in window.config
+export const BASE_PATH = window.config && window.config.basePath && window.config.basePath !== '{{ .BasePath }}' ? window.config.basePath : ''
+
+// eslint-disable-next-line no-undef
+__webpack_public_path__ = BASE_PATH + '/'
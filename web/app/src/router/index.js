@@ -1,6 +1,7 @@
 import {createRouter, createWebHistory} from 'vue-router'
 import Home from '@/views/Home'
 import Details from "@/views/Details";
+import {BASE_PATH} from "@/public-path";
 
 const routes = [
     {
@@ -13,10 +14,20 @@ const routes = [
         name: 'Details',
         component: Details,
     },
+    {
+        path: '/share/:token',
+        name: 'Share',
+        component: Home
+    },
+    {
+        path: '/tenants/:tenant',
+        name: 'Tenant',
+        component: Home
+    },
 ];
 
 const router = createRouter({
-    history: createWebHistory(process.env.BASE_URL),
+    history: createWebHistory(BASE_PATH + '/'),
     routes
 });
 
@@ -2,5 +2,6 @@ module.exports = {
 	filenameHashing: false,
 	productionSourceMap: false,
 	outputDir: '../static',
-	publicPath: '/'
-}
\ No newline at end of file
+	// The base path is injected by the server when rendering index.html, see src/public-path.js for the runtime equivalent
+	publicPath: process.env.NODE_ENV === 'production' ? '{{ .BasePath }}/' : '/'
+}
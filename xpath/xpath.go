@@ -0,0 +1,215 @@
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is the error returned when a path isn't part of the subset of XPath supported
+	ErrInvalidPath = errors.New("invalid xpath")
+
+	// ErrNoMatch is the error returned when a path doesn't match anything in the document
+	ErrNoMatch = errors.New("xpath did not match anything")
+
+	// ErrEmptyDocument is the error returned when the document passed has no root element
+	ErrEmptyDocument = errors.New("xml document has no root element")
+)
+
+// node is an element of an XML document
+type node struct {
+	name       string
+	attributes map[string]string
+	children   []*node
+	text       strings.Builder
+}
+
+// step is a single step of a path, e.g. "//Status[2]"
+type step struct {
+	// descendant is whether the step matches any descendant (//) rather than only children (/)
+	descendant bool
+
+	// name is the local name of the elements matched, or "*" for any element
+	name string
+
+	// index is the 1-based position of the element to select among the matched siblings, or 0 to select all of them
+	index int
+}
+
+// Eval evaluates a path against an XML document and returns the text of the first node matched as well as the number
+// of nodes matched.
+//
+// Only a subset of XPath is supported: absolute paths (/Envelope/Body), descendant paths (//Status), wildcards (*),
+// positional predicates ([1]), and a trailing attribute (/@code) or text() step.
+// Namespace prefixes are ignored, meaning that /soap:Envelope and /Envelope are equivalent.
+func Eval(path string, b []byte) (string, int, error) {
+	steps, attribute, err := parse(path)
+	if err != nil {
+		return "", 0, err
+	}
+	root, err := parseDocument(b)
+	if err != nil {
+		return "", 0, err
+	}
+	nodes := []*node{root}
+	for _, s := range steps {
+		nodes = s.apply(nodes)
+		if len(nodes) == 0 {
+			return "", 0, ErrNoMatch
+		}
+	}
+	if len(attribute) > 0 {
+		var values []string
+		for _, n := range nodes {
+			if value, exists := n.attributes[attribute]; exists {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			return "", 0, ErrNoMatch
+		}
+		return values[0], len(values), nil
+	}
+	return strings.TrimSpace(nodes[0].text.String()), len(nodes), nil
+}
+
+// parse splits a path into its steps and, if the last step selects an attribute, the name of that attribute
+func parse(path string) ([]*step, string, error) {
+	if !strings.HasPrefix(path, "/") || path == "/" {
+		return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+	}
+	var steps []*step
+	var attribute string
+	for remaining := path; len(remaining) > 0; {
+		s := &step{}
+		if strings.HasPrefix(remaining, "//") {
+			s.descendant = true
+			remaining = remaining[2:]
+		} else if strings.HasPrefix(remaining, "/") {
+			remaining = remaining[1:]
+		} else {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+		}
+		end := strings.Index(remaining, "/")
+		if end == -1 {
+			end = len(remaining)
+		}
+		token := remaining[:end]
+		remaining = remaining[end:]
+		isLastStep := len(remaining) == 0
+		if strings.HasPrefix(token, "@") {
+			if !isLastStep || s.descendant || len(token) == 1 {
+				return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+			}
+			attribute = localName(token[1:])
+			break
+		}
+		if token == "text()" {
+			if !isLastStep || s.descendant {
+				return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+			}
+			break
+		}
+		if openingBracket := strings.Index(token, "["); openingBracket != -1 {
+			if !strings.HasSuffix(token, "]") {
+				return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+			}
+			index, err := strconv.Atoi(token[openingBracket+1 : len(token)-1])
+			if err != nil || index < 1 {
+				return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+			}
+			s.index = index
+			token = token[:openingBracket]
+		}
+		if len(token) == 0 {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+		}
+		s.name = localName(token)
+		steps = append(steps, s)
+	}
+	return steps, attribute, nil
+}
+
+// apply returns the nodes matched by the step from the nodes passed
+func (s *step) apply(nodes []*node) []*node {
+	var matches []*node
+	for _, n := range nodes {
+		var candidates []*node
+		if s.descendant {
+			candidates = n.descendants()
+		} else {
+			candidates = n.children
+		}
+		var siblingMatches []*node
+		for _, candidate := range candidates {
+			if s.name == "*" || candidate.name == s.name {
+				siblingMatches = append(siblingMatches, candidate)
+			}
+		}
+		if s.index > 0 {
+			if s.index <= len(siblingMatches) {
+				matches = append(matches, siblingMatches[s.index-1])
+			}
+		} else {
+			matches = append(matches, siblingMatches...)
+		}
+	}
+	return matches
+}
+
+// descendants returns every node below the node, in document order
+func (n *node) descendants() []*node {
+	var descendants []*node
+	for _, child := range n.children {
+		descendants = append(descendants, child)
+		descendants = append(descendants, child.descendants()...)
+	}
+	return descendants
+}
+
+// parseDocument parses an XML document into a tree whose root is a nameless node containing the document element
+func parseDocument(b []byte) (*node, error) {
+	root := &node{}
+	stack := []*node{root}
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		current := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			child := &node{name: t.Name.Local, attributes: make(map[string]string, len(t.Attr))}
+			for _, attribute := range t.Attr {
+				child.attributes[attribute.Name.Local] = attribute.Value
+			}
+			current.children = append(current.children, child)
+			stack = append(stack, child)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			current.text.Write(t)
+		}
+	}
+	if len(root.children) == 0 {
+		return nil, ErrEmptyDocument
+	}
+	return root, nil
+}
+
+// localName returns the name without its namespace prefix, if any
+func localName(name string) string {
+	if index := strings.Index(name, ":"); index != -1 {
+		return name[index+1:]
+	}
+	return name
+}
@@ -0,0 +1,160 @@
+package xpath
+
+import (
+	"testing"
+)
+
+const envelope = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <GetStatusResponse xmlns="http://example.com/health">
+      <Status code="200">  UP  </Status>
+      <Dependencies>
+        <Dependency name="database">UP</Dependency>
+        <Dependency name="cache">DOWN</Dependency>
+      </Dependencies>
+    </GetStatusResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestEval(t *testing.T) {
+	type Scenario struct {
+		Name                 string
+		Path                 string
+		Data                 string
+		ExpectedOutput       string
+		ExpectedOutputLength int
+		ExpectedError        bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:                 "absolute",
+			Path:                 "/Envelope/Body/GetStatusResponse/Status",
+			Data:                 envelope,
+			ExpectedOutput:       "UP",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "absolute-with-namespace-prefixes",
+			Path:                 "/soap:Envelope/soap:Body/GetStatusResponse/Status",
+			Data:                 envelope,
+			ExpectedOutput:       "UP",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "descendant",
+			Path:                 "//Status",
+			Data:                 envelope,
+			ExpectedOutput:       "UP",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "descendant-with-multiple-matches",
+			Path:                 "//Dependency",
+			Data:                 envelope,
+			ExpectedOutput:       "UP",
+			ExpectedOutputLength: 2,
+		},
+		{
+			Name:                 "positional-predicate",
+			Path:                 "//Dependencies/Dependency[2]",
+			Data:                 envelope,
+			ExpectedOutput:       "DOWN",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "wildcard",
+			Path:                 "/Envelope/Body/*/Status",
+			Data:                 envelope,
+			ExpectedOutput:       "UP",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "attribute",
+			Path:                 "//Status/@code",
+			Data:                 envelope,
+			ExpectedOutput:       "200",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "attribute-of-positional-predicate",
+			Path:                 "//Dependency[2]/@name",
+			Data:                 envelope,
+			ExpectedOutput:       "cache",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:                 "text",
+			Path:                 "//Dependency[1]/text()",
+			Data:                 envelope,
+			ExpectedOutput:       "UP",
+			ExpectedOutputLength: 1,
+		},
+		{
+			Name:          "no-match",
+			Path:          "//Fault",
+			Data:          envelope,
+			ExpectedError: true,
+		},
+		{
+			Name:          "missing-attribute",
+			Path:          "//Status/@missing",
+			Data:          envelope,
+			ExpectedError: true,
+		},
+		{
+			Name:          "out-of-range-predicate",
+			Path:          "//Dependency[3]",
+			Data:          envelope,
+			ExpectedError: true,
+		},
+		{
+			Name:          "relative-path",
+			Path:          "Envelope/Body",
+			Data:          envelope,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-predicate",
+			Path:          "//Dependency[last()]",
+			Data:          envelope,
+			ExpectedError: true,
+		},
+		{
+			Name:          "attribute-not-last",
+			Path:          "//@code/Status",
+			Data:          envelope,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-data",
+			Path:          "//Status",
+			Data:          "<Envelope><Body></Envelope>",
+			ExpectedError: true,
+		},
+		{
+			Name:          "empty-data",
+			Path:          "//Status",
+			Data:          "",
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			output, outputLength, err := Eval(scenario.Path, []byte(scenario.Data))
+			if (err != nil) != scenario.ExpectedError {
+				if scenario.ExpectedError {
+					t.Errorf("Expected error, got '%v'", err)
+				} else {
+					t.Errorf("Expected no error, got '%v'", err)
+				}
+			}
+			if outputLength != scenario.ExpectedOutputLength {
+				t.Errorf("Expected output length to be %v, but was %v", scenario.ExpectedOutputLength, outputLength)
+			}
+			if output != scenario.ExpectedOutput {
+				t.Errorf("Expected output to be %v, but was %v", scenario.ExpectedOutput, output)
+			}
+		})
+	}
+}